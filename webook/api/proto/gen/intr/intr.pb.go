@@ -20,29 +20,76 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type GetByIDsReq struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// ctx context.Context, biz string, bizIDs []int64
-	Biz           string  `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizIds        []int64 `protobuf:"varint,2,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+type CommentSort int32
+
+const (
+	// COMMENT_SORT_TIME 按照时间倒序
+	CommentSort_COMMENT_SORT_TIME CommentSort = 0
+	// COMMENT_SORT_POPULAR 按照点赞数、回复数倒序
+	CommentSort_COMMENT_SORT_POPULAR CommentSort = 1
+)
+
+// Enum value maps for CommentSort.
+var (
+	CommentSort_name = map[int32]string{
+		0: "COMMENT_SORT_TIME",
+		1: "COMMENT_SORT_POPULAR",
+	}
+	CommentSort_value = map[string]int32{
+		"COMMENT_SORT_TIME":    0,
+		"COMMENT_SORT_POPULAR": 1,
+	}
+)
+
+func (x CommentSort) Enum() *CommentSort {
+	p := new(CommentSort)
+	*p = x
+	return p
+}
+
+func (x CommentSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CommentSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_intr_proto_enumTypes[0].Descriptor()
+}
+
+func (CommentSort) Type() protoreflect.EnumType {
+	return &file_intr_proto_enumTypes[0]
+}
+
+func (x CommentSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CommentSort.Descriptor instead.
+func (CommentSort) EnumDescriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{0}
+}
+
+type DeleteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetByIDsReq) Reset() {
-	*x = GetByIDsReq{}
+func (x *DeleteReq) Reset() {
+	*x = DeleteReq{}
 	mi := &file_intr_proto_msgTypes[0]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetByIDsReq) String() string {
+func (x *DeleteReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIDsReq) ProtoMessage() {}
+func (*DeleteReq) ProtoMessage() {}
 
-func (x *GetByIDsReq) ProtoReflect() protoreflect.Message {
+func (x *DeleteReq) ProtoReflect() protoreflect.Message {
 	mi := &file_intr_proto_msgTypes[0]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -54,46 +101,45 @@ func (x *GetByIDsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIDsReq.ProtoReflect.Descriptor instead.
-func (*GetByIDsReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteReq.ProtoReflect.Descriptor instead.
+func (*DeleteReq) Descriptor() ([]byte, []int) {
 	return file_intr_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *GetByIDsReq) GetBiz() string {
+func (x *DeleteReq) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *GetByIDsReq) GetBizIds() []int64 {
+func (x *DeleteReq) GetBizId() int64 {
 	if x != nil {
-		return x.BizIds
+		return x.BizId
 	}
-	return nil
+	return 0
 }
 
-type GetByIDsResp struct {
+type DeleteResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inters        map[int64]*Interaction `protobuf:"bytes,1,rep,name=inters,proto3" json:"inters,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetByIDsResp) Reset() {
-	*x = GetByIDsResp{}
+func (x *DeleteResp) Reset() {
+	*x = DeleteResp{}
 	mi := &file_intr_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetByIDsResp) String() string {
+func (x *DeleteResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetByIDsResp) ProtoMessage() {}
+func (*DeleteResp) ProtoMessage() {}
 
-func (x *GetByIDsResp) ProtoReflect() protoreflect.Message {
+func (x *DeleteResp) ProtoReflect() protoreflect.Message {
 	mi := &file_intr_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -105,42 +151,37 @@ func (x *GetByIDsResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetByIDsResp.ProtoReflect.Descriptor instead.
-func (*GetByIDsResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteResp.ProtoReflect.Descriptor instead.
+func (*DeleteResp) Descriptor() ([]byte, []int) {
 	return file_intr_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *GetByIDsResp) GetInters() map[int64]*Interaction {
-	if x != nil {
-		return x.Inters
-	}
-	return nil
-}
-
-type CollectedReq struct {
+type ListLikesReq struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// ctx context.Context, uid int64, biz string, bizID int64
-	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid   int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	// before_time, before_id 上一页最后一条点赞的时间（毫秒时间戳）和id，第一页都为0
+	BeforeTime    int64 `protobuf:"varint,3,opt,name=before_time,json=beforeTime,proto3" json:"before_time,omitempty"`
+	BeforeId      int64 `protobuf:"varint,4,opt,name=before_id,json=beforeId,proto3" json:"before_id,omitempty"`
+	Limit         int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CollectedReq) Reset() {
-	*x = CollectedReq{}
+func (x *ListLikesReq) Reset() {
+	*x = ListLikesReq{}
 	mi := &file_intr_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CollectedReq) String() string {
+func (x *ListLikesReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CollectedReq) ProtoMessage() {}
+func (*ListLikesReq) ProtoMessage() {}
 
-func (x *CollectedReq) ProtoReflect() protoreflect.Message {
+func (x *ListLikesReq) ProtoReflect() protoreflect.Message {
 	mi := &file_intr_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -152,53 +193,73 @@ func (x *CollectedReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CollectedReq.ProtoReflect.Descriptor instead.
-func (*CollectedReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListLikesReq.ProtoReflect.Descriptor instead.
+func (*ListLikesReq) Descriptor() ([]byte, []int) {
 	return file_intr_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CollectedReq) GetUid() int64 {
+func (x *ListLikesReq) GetUid() int64 {
 	if x != nil {
 		return x.Uid
 	}
 	return 0
 }
 
-func (x *CollectedReq) GetBiz() string {
+func (x *ListLikesReq) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *CollectedReq) GetBizId() int64 {
+func (x *ListLikesReq) GetBeforeTime() int64 {
 	if x != nil {
-		return x.BizId
+		return x.BeforeTime
 	}
 	return 0
 }
 
-type CollectedResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Collected     bool                   `protobuf:"varint,1,opt,name=collected,proto3" json:"collected,omitempty"`
+func (x *ListLikesReq) GetBeforeId() int64 {
+	if x != nil {
+		return x.BeforeId
+	}
+	return 0
+}
+
+func (x *ListLikesReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UserLike struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Biz   string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// time 点赞时间，毫秒时间戳
+	Time int64 `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	// reaction 用户的表态：like、love、insightful、funny
+	Reaction      string `protobuf:"bytes,5,opt,name=reaction,proto3" json:"reaction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CollectedResp) Reset() {
-	*x = CollectedResp{}
+func (x *UserLike) Reset() {
+	*x = UserLike{}
 	mi := &file_intr_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CollectedResp) String() string {
+func (x *UserLike) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CollectedResp) ProtoMessage() {}
+func (*UserLike) ProtoMessage() {}
 
-func (x *CollectedResp) ProtoReflect() protoreflect.Message {
+func (x *UserLike) ProtoReflect() protoreflect.Message {
 	mi := &file_intr_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -210,42 +271,67 @@ func (x *CollectedResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CollectedResp.ProtoReflect.Descriptor instead.
-func (*CollectedResp) Descriptor() ([]byte, []int) {
+// Deprecated: Use UserLike.ProtoReflect.Descriptor instead.
+func (*UserLike) Descriptor() ([]byte, []int) {
 	return file_intr_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CollectedResp) GetCollected() bool {
+func (x *UserLike) GetId() int64 {
 	if x != nil {
-		return x.Collected
+		return x.Id
 	}
-	return false
+	return 0
 }
 
-type LikedReq struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// ctx context.Context, uid int64, biz string, bizID int64
-	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+func (x *UserLike) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *UserLike) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *UserLike) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *UserLike) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type ListLikesResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Likes         []*UserLike            `protobuf:"bytes,1,rep,name=likes,proto3" json:"likes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikedReq) Reset() {
-	*x = LikedReq{}
+func (x *ListLikesResp) Reset() {
+	*x = ListLikesResp{}
 	mi := &file_intr_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikedReq) String() string {
+func (x *ListLikesResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikedReq) ProtoMessage() {}
+func (*ListLikesResp) ProtoMessage() {}
 
-func (x *LikedReq) ProtoReflect() protoreflect.Message {
+func (x *ListLikesResp) ProtoReflect() protoreflect.Message {
 	mi := &file_intr_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -257,54 +343,101 @@ func (x *LikedReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikedReq.ProtoReflect.Descriptor instead.
-func (*LikedReq) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListLikesResp.ProtoReflect.Descriptor instead.
+func (*ListLikesResp) Descriptor() ([]byte, []int) {
 	return file_intr_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *LikedReq) GetUid() int64 {
+func (x *ListLikesResp) GetLikes() []*UserLike {
+	if x != nil {
+		return x.Likes
+	}
+	return nil
+}
+
+type BatchCheckReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizIds        []int64                `protobuf:"varint,3,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchCheckReq) Reset() {
+	*x = BatchCheckReq{}
+	mi := &file_intr_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchCheckReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchCheckReq) ProtoMessage() {}
+
+func (x *BatchCheckReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchCheckReq.ProtoReflect.Descriptor instead.
+func (*BatchCheckReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchCheckReq) GetUid() int64 {
 	if x != nil {
 		return x.Uid
 	}
 	return 0
 }
 
-func (x *LikedReq) GetBiz() string {
+func (x *BatchCheckReq) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *LikedReq) GetBizId() int64 {
+func (x *BatchCheckReq) GetBizIds() []int64 {
 	if x != nil {
-		return x.BizId
+		return x.BizIds
 	}
-	return 0
+	return nil
 }
 
-type LikedResp struct {
+type UserState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Liked         bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	Collected     bool                   `protobuf:"varint,2,opt,name=collected,proto3" json:"collected,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikedResp) Reset() {
-	*x = LikedResp{}
-	mi := &file_intr_proto_msgTypes[5]
+func (x *UserState) Reset() {
+	*x = UserState{}
+	mi := &file_intr_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikedResp) String() string {
+func (x *UserState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikedResp) ProtoMessage() {}
+func (*UserState) ProtoMessage() {}
 
-func (x *LikedResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[5]
+func (x *UserState) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,43 +448,48 @@ func (x *LikedResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikedResp.ProtoReflect.Descriptor instead.
-func (*LikedResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use UserState.ProtoReflect.Descriptor instead.
+func (*UserState) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *LikedResp) GetLiked() bool {
+func (x *UserState) GetLiked() bool {
 	if x != nil {
 		return x.Liked
 	}
 	return false
 }
 
-type GetReq struct {
+func (x *UserState) GetCollected() bool {
+	if x != nil {
+		return x.Collected
+	}
+	return false
+}
+
+type BatchCheckResp struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// ctx context.Context, uid int64, biz string, bizID int64
-	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// states 每个biz_id都有结果
+	States        map[int64]*UserState `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetReq) Reset() {
-	*x = GetReq{}
-	mi := &file_intr_proto_msgTypes[6]
+func (x *BatchCheckResp) Reset() {
+	*x = BatchCheckResp{}
+	mi := &file_intr_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetReq) String() string {
+func (x *BatchCheckResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetReq) ProtoMessage() {}
+func (*BatchCheckResp) ProtoMessage() {}
 
-func (x *GetReq) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[6]
+func (x *BatchCheckResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,54 +500,46 @@ func (x *GetReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetReq.ProtoReflect.Descriptor instead.
-func (*GetReq) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *GetReq) GetUid() int64 {
-	if x != nil {
-		return x.Uid
-	}
-	return 0
-}
-
-func (x *GetReq) GetBiz() string {
-	if x != nil {
-		return x.Biz
-	}
-	return ""
+// Deprecated: Use BatchCheckResp.ProtoReflect.Descriptor instead.
+func (*BatchCheckResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetReq) GetBizId() int64 {
+func (x *BatchCheckResp) GetStates() map[int64]*UserState {
 	if x != nil {
-		return x.BizId
+		return x.States
 	}
-	return 0
+	return nil
 }
 
-type GetResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Inter         *Interaction           `protobuf:"bytes,1,opt,name=inter,proto3" json:"inter,omitempty"`
+type GetTrendReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Biz   string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// granularity hour 或者 day，小时只保留最近7天
+	Granularity string `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	// from, to 毫秒时间戳
+	From          int64 `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64 `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetResp) Reset() {
-	*x = GetResp{}
-	mi := &file_intr_proto_msgTypes[7]
+func (x *GetTrendReq) Reset() {
+	*x = GetTrendReq{}
+	mi := &file_intr_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetResp) String() string {
+func (x *GetTrendReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResp) ProtoMessage() {}
+func (*GetTrendReq) ProtoMessage() {}
 
-func (x *GetResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[7]
+func (x *GetTrendReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,49 +550,2493 @@ func (x *GetResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResp.ProtoReflect.Descriptor instead.
-func (*GetResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use GetTrendReq.ProtoReflect.Descriptor instead.
+func (*GetTrendReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTrendReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetTrendReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *GetTrendReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetTrendReq) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetTrendReq) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type GetTrendResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*TrendPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendResp) Reset() {
+	*x = GetTrendResp{}
+	mi := &file_intr_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendResp) ProtoMessage() {}
+
+func (x *GetTrendResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendResp.ProtoReflect.Descriptor instead.
+func (*GetTrendResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetTrendResp) GetPoints() []*TrendPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+type GetAggregatedTrendReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizIds        []int64                `protobuf:"varint,2,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	Granularity   string                 `protobuf:"bytes,3,opt,name=granularity,proto3" json:"granularity,omitempty"`
+	From          int64                  `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            int64                  `protobuf:"varint,5,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregatedTrendReq) Reset() {
+	*x = GetAggregatedTrendReq{}
+	mi := &file_intr_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregatedTrendReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregatedTrendReq) ProtoMessage() {}
+
+func (x *GetAggregatedTrendReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregatedTrendReq.ProtoReflect.Descriptor instead.
+func (*GetAggregatedTrendReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAggregatedTrendReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetAggregatedTrendReq) GetBizIds() []int64 {
+	if x != nil {
+		return x.BizIds
+	}
+	return nil
+}
+
+func (x *GetAggregatedTrendReq) GetGranularity() string {
+	if x != nil {
+		return x.Granularity
+	}
+	return ""
+}
+
+func (x *GetAggregatedTrendReq) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetAggregatedTrendReq) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type GetAggregatedTrendResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*TrendPoint          `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAggregatedTrendResp) Reset() {
+	*x = GetAggregatedTrendResp{}
+	mi := &file_intr_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAggregatedTrendResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAggregatedTrendResp) ProtoMessage() {}
+
+func (x *GetAggregatedTrendResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAggregatedTrendResp.ProtoReflect.Descriptor instead.
+func (*GetAggregatedTrendResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAggregatedTrendResp) GetPoints() []*TrendPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+// TrendPoint 一个时间桶内新增的计数，取消点赞、收藏时可能是负数
+type TrendPoint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// time 时间桶的开始时间，毫秒时间戳
+	Time          int64 `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	Views         int64 `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	Likes         int64 `protobuf:"varint,3,opt,name=likes,proto3" json:"likes,omitempty"`
+	Favorites     int64 `protobuf:"varint,4,opt,name=favorites,proto3" json:"favorites,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendPoint) Reset() {
+	*x = TrendPoint{}
+	mi := &file_intr_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendPoint) ProtoMessage() {}
+
+func (x *TrendPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendPoint.ProtoReflect.Descriptor instead.
+func (*TrendPoint) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TrendPoint) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *TrendPoint) GetFavorites() int64 {
+	if x != nil {
+		return x.Favorites
+	}
+	return 0
+}
+
+type GetByIDsReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//  ctx context.Context, biz string, bizIDs []int64
+	Biz           string  `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizIds        []int64 `protobuf:"varint,2,rep,packed,name=biz_ids,json=bizIds,proto3" json:"biz_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIDsReq) Reset() {
+	*x = GetByIDsReq{}
+	mi := &file_intr_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIDsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIDsReq) ProtoMessage() {}
+
+func (x *GetByIDsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIDsReq.ProtoReflect.Descriptor instead.
+func (*GetByIDsReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetByIDsReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetByIDsReq) GetBizIds() []int64 {
+	if x != nil {
+		return x.BizIds
+	}
+	return nil
+}
+
+type GetByIDsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inters        map[int64]*Interaction `protobuf:"bytes,1,rep,name=inters,proto3" json:"inters,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetByIDsResp) Reset() {
+	*x = GetByIDsResp{}
+	mi := &file_intr_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetByIDsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetByIDsResp) ProtoMessage() {}
+
+func (x *GetByIDsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetByIDsResp.ProtoReflect.Descriptor instead.
+func (*GetByIDsResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetByIDsResp) GetInters() map[int64]*Interaction {
+	if x != nil {
+		return x.Inters
+	}
+	return nil
+}
+
+type CollectedReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//  ctx context.Context, uid int64, biz string, bizID int64
+	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectedReq) Reset() {
+	*x = CollectedReq{}
+	mi := &file_intr_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectedReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectedReq) ProtoMessage() {}
+
+func (x *CollectedReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectedReq.ProtoReflect.Descriptor instead.
+func (*CollectedReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CollectedReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CollectedReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CollectedReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type CollectedResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collected     bool                   `protobuf:"varint,1,opt,name=collected,proto3" json:"collected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectedResp) Reset() {
+	*x = CollectedResp{}
+	mi := &file_intr_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectedResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectedResp) ProtoMessage() {}
+
+func (x *CollectedResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectedResp.ProtoReflect.Descriptor instead.
+func (*CollectedResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CollectedResp) GetCollected() bool {
+	if x != nil {
+		return x.Collected
+	}
+	return false
+}
+
+type LikedReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//  ctx context.Context, uid int64, biz string, bizID int64
+	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikedReq) Reset() {
+	*x = LikedReq{}
+	mi := &file_intr_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikedReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikedReq) ProtoMessage() {}
+
+func (x *LikedReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikedReq.ProtoReflect.Descriptor instead.
+func (*LikedReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LikedReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LikedReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *LikedReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type LikedResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Liked         bool                   `protobuf:"varint,1,opt,name=liked,proto3" json:"liked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikedResp) Reset() {
+	*x = LikedResp{}
+	mi := &file_intr_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikedResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikedResp) ProtoMessage() {}
+
+func (x *LikedResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikedResp.ProtoReflect.Descriptor instead.
+func (*LikedResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LikedResp) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+type GetReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//  ctx context.Context, uid int64, biz string, bizID int64
+	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReq) Reset() {
+	*x = GetReq{}
+	mi := &file_intr_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReq) ProtoMessage() {}
+
+func (x *GetReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReq.ProtoReflect.Descriptor instead.
+func (*GetReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *GetReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *GetReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type GetResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inter         *Interaction           `protobuf:"bytes,1,opt,name=inter,proto3" json:"inter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetResp) Reset() {
+	*x = GetResp{}
+	mi := &file_intr_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResp) ProtoMessage() {}
+
+func (x *GetResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResp.ProtoReflect.Descriptor instead.
+func (*GetResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetResp) GetInter() *Interaction {
+	if x != nil {
+		return x.Inter
+	}
+	return nil
+}
+
+type Interaction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Biz       string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId     int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	CTime     int64                  `protobuf:"varint,4,opt,name=c_time,json=cTime,proto3" json:"c_time,omitempty"`
+	UTime     int64                  `protobuf:"varint,5,opt,name=u_time,json=uTime,proto3" json:"u_time,omitempty"`
+	Views     int64                  `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
+	Likes     int64                  `protobuf:"varint,7,opt,name=likes,proto3" json:"likes,omitempty"`
+	Favorites int64                  `protobuf:"varint,8,opt,name=favorites,proto3" json:"favorites,omitempty"`
+	Liked     bool                   `protobuf:"varint,9,opt,name=liked,proto3" json:"liked,omitempty"`
+	Collected bool                   `protobuf:"varint,10,opt,name=collected,proto3" json:"collected,omitempty"`
+	// comments 评论数，包括回复
+	Comments int64 `protobuf:"varint,11,opt,name=comments,proto3" json:"comments,omitempty"`
+	// unique_views 去重后的阅读数，同一个用户每天最多计一次
+	UniqueViews int64 `protobuf:"varint,12,opt,name=unique_views,json=uniqueViews,proto3" json:"unique_views,omitempty"`
+	// reactions 每种表态的数量，likes是所有表态的总数
+	Reactions map[string]int64 `protobuf:"bytes,13,rep,name=reactions,proto3" json:"reactions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// reaction 用户当前的表态，没有表态时为空
+	Reaction      string `protobuf:"bytes,14,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Interaction) Reset() {
+	*x = Interaction{}
+	mi := &file_intr_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Interaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Interaction) ProtoMessage() {}
+
+func (x *Interaction) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Interaction.ProtoReflect.Descriptor instead.
+func (*Interaction) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Interaction) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Interaction) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *Interaction) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *Interaction) GetCTime() int64 {
+	if x != nil {
+		return x.CTime
+	}
+	return 0
+}
+
+func (x *Interaction) GetUTime() int64 {
+	if x != nil {
+		return x.UTime
+	}
+	return 0
+}
+
+func (x *Interaction) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *Interaction) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *Interaction) GetFavorites() int64 {
+	if x != nil {
+		return x.Favorites
+	}
+	return 0
+}
+
+func (x *Interaction) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *Interaction) GetCollected() bool {
+	if x != nil {
+		return x.Collected
+	}
+	return false
+}
+
+func (x *Interaction) GetComments() int64 {
+	if x != nil {
+		return x.Comments
+	}
+	return 0
+}
+
+func (x *Interaction) GetUniqueViews() int64 {
+	if x != nil {
+		return x.UniqueViews
+	}
+	return 0
+}
+
+func (x *Interaction) GetReactions() map[string]int64 {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+func (x *Interaction) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type FavoriteReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	//  ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64
+	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FavoriteId    int64  `protobuf:"varint,2,opt,name=favorite_id,json=favoriteId,proto3" json:"favorite_id,omitempty"`
+	Biz           string `protobuf:"bytes,3,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64  `protobuf:"varint,4,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FavoriteReq) Reset() {
+	*x = FavoriteReq{}
+	mi := &file_intr_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FavoriteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FavoriteReq) ProtoMessage() {}
+
+func (x *FavoriteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FavoriteReq.ProtoReflect.Descriptor instead.
+func (*FavoriteReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FavoriteReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *FavoriteReq) GetFavoriteId() int64 {
+	if x != nil {
+		return x.FavoriteId
+	}
+	return 0
+}
+
+func (x *FavoriteReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *FavoriteReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type FavoriteResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FavoriteResp) Reset() {
+	*x = FavoriteResp{}
+	mi := &file_intr_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FavoriteResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FavoriteResp) ProtoMessage() {}
+
+func (x *FavoriteResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FavoriteResp.ProtoReflect.Descriptor instead.
+func (*FavoriteResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{23}
+}
+
+type CancelFavoriteReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelFavoriteReq) Reset() {
+	*x = CancelFavoriteReq{}
+	mi := &file_intr_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelFavoriteReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelFavoriteReq) ProtoMessage() {}
+
+func (x *CancelFavoriteReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelFavoriteReq.ProtoReflect.Descriptor instead.
+func (*CancelFavoriteReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CancelFavoriteReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CancelFavoriteReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CancelFavoriteReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type CancelFavoriteResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelFavoriteResp) Reset() {
+	*x = CancelFavoriteResp{}
+	mi := &file_intr_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelFavoriteResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelFavoriteResp) ProtoMessage() {}
+
+func (x *CancelFavoriteResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelFavoriteResp.ProtoReflect.Descriptor instead.
+func (*CancelFavoriteResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{25}
+}
+
+type FavoriteFolder struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uid     int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name    string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Private bool                   `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`
+	// items 收藏夹中的收藏数
+	Items         int64 `protobuf:"varint,5,opt,name=items,proto3" json:"items,omitempty"`
+	CTime         int64 `protobuf:"varint,6,opt,name=c_time,json=cTime,proto3" json:"c_time,omitempty"`
+	UTime         int64 `protobuf:"varint,7,opt,name=u_time,json=uTime,proto3" json:"u_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FavoriteFolder) Reset() {
+	*x = FavoriteFolder{}
+	mi := &file_intr_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FavoriteFolder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FavoriteFolder) ProtoMessage() {}
+
+func (x *FavoriteFolder) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FavoriteFolder.ProtoReflect.Descriptor instead.
+func (*FavoriteFolder) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *FavoriteFolder) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FavoriteFolder) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *FavoriteFolder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FavoriteFolder) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+func (x *FavoriteFolder) GetItems() int64 {
+	if x != nil {
+		return x.Items
+	}
+	return 0
+}
+
+func (x *FavoriteFolder) GetCTime() int64 {
+	if x != nil {
+		return x.CTime
+	}
+	return 0
+}
+
+func (x *FavoriteFolder) GetUTime() int64 {
+	if x != nil {
+		return x.UTime
+	}
+	return 0
+}
+
+type FavoriteItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FolderId      int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Biz           string                 `protobuf:"bytes,3,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,4,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	CTime         int64                  `protobuf:"varint,5,opt,name=c_time,json=cTime,proto3" json:"c_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FavoriteItem) Reset() {
+	*x = FavoriteItem{}
+	mi := &file_intr_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FavoriteItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FavoriteItem) ProtoMessage() {}
+
+func (x *FavoriteItem) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FavoriteItem.ProtoReflect.Descriptor instead.
+func (*FavoriteItem) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *FavoriteItem) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *FavoriteItem) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *FavoriteItem) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *FavoriteItem) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *FavoriteItem) GetCTime() int64 {
+	if x != nil {
+		return x.CTime
+	}
+	return 0
+}
+
+type CreateFavoriteFolderReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Private       bool                   `protobuf:"varint,3,opt,name=private,proto3" json:"private,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFavoriteFolderReq) Reset() {
+	*x = CreateFavoriteFolderReq{}
+	mi := &file_intr_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFavoriteFolderReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFavoriteFolderReq) ProtoMessage() {}
+
+func (x *CreateFavoriteFolderReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFavoriteFolderReq.ProtoReflect.Descriptor instead.
+func (*CreateFavoriteFolderReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateFavoriteFolderReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CreateFavoriteFolderReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFavoriteFolderReq) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+type CreateFavoriteFolderResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *FavoriteFolder        `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFavoriteFolderResp) Reset() {
+	*x = CreateFavoriteFolderResp{}
+	mi := &file_intr_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFavoriteFolderResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFavoriteFolderResp) ProtoMessage() {}
+
+func (x *CreateFavoriteFolderResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFavoriteFolderResp.ProtoReflect.Descriptor instead.
+func (*CreateFavoriteFolderResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CreateFavoriteFolderResp) GetFolder() *FavoriteFolder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type UpdateFavoriteFolderReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Private       bool                   `protobuf:"varint,4,opt,name=private,proto3" json:"private,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFavoriteFolderReq) Reset() {
+	*x = UpdateFavoriteFolderReq{}
+	mi := &file_intr_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFavoriteFolderReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFavoriteFolderReq) ProtoMessage() {}
+
+func (x *UpdateFavoriteFolderReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFavoriteFolderReq.ProtoReflect.Descriptor instead.
+func (*UpdateFavoriteFolderReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *UpdateFavoriteFolderReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UpdateFavoriteFolderReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateFavoriteFolderReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateFavoriteFolderReq) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
+type UpdateFavoriteFolderResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFavoriteFolderResp) Reset() {
+	*x = UpdateFavoriteFolderResp{}
+	mi := &file_intr_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFavoriteFolderResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFavoriteFolderResp) ProtoMessage() {}
+
+func (x *UpdateFavoriteFolderResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFavoriteFolderResp.ProtoReflect.Descriptor instead.
+func (*UpdateFavoriteFolderResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{31}
+}
+
+type DeleteFavoriteFolderReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFavoriteFolderReq) Reset() {
+	*x = DeleteFavoriteFolderReq{}
+	mi := &file_intr_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFavoriteFolderReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFavoriteFolderReq) ProtoMessage() {}
+
+func (x *DeleteFavoriteFolderReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFavoriteFolderReq.ProtoReflect.Descriptor instead.
+func (*DeleteFavoriteFolderReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteFavoriteFolderReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *DeleteFavoriteFolderReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteFavoriteFolderResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFavoriteFolderResp) Reset() {
+	*x = DeleteFavoriteFolderResp{}
+	mi := &file_intr_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFavoriteFolderResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFavoriteFolderResp) ProtoMessage() {}
+
+func (x *DeleteFavoriteFolderResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFavoriteFolderResp.ProtoReflect.Descriptor instead.
+func (*DeleteFavoriteFolderResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{33}
+}
+
+type ListFavoriteFoldersReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// uid 查看的用户，owner 收藏夹的所有者，两者不同时只返回公开的收藏夹
+	Uid           int64 `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Owner         int64 `protobuf:"varint,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteFoldersReq) Reset() {
+	*x = ListFavoriteFoldersReq{}
+	mi := &file_intr_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteFoldersReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteFoldersReq) ProtoMessage() {}
+
+func (x *ListFavoriteFoldersReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteFoldersReq.ProtoReflect.Descriptor instead.
+func (*ListFavoriteFoldersReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListFavoriteFoldersReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ListFavoriteFoldersReq) GetOwner() int64 {
+	if x != nil {
+		return x.Owner
+	}
+	return 0
+}
+
+type ListFavoriteFoldersResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*FavoriteFolder      `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteFoldersResp) Reset() {
+	*x = ListFavoriteFoldersResp{}
+	mi := &file_intr_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteFoldersResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteFoldersResp) ProtoMessage() {}
+
+func (x *ListFavoriteFoldersResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteFoldersResp.ProtoReflect.Descriptor instead.
+func (*ListFavoriteFoldersResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListFavoriteFoldersResp) GetFolders() []*FavoriteFolder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+type ListFavoriteItemsReq struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Uid      int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	FolderId int64                  `protobuf:"varint,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// max_id 上一页最后一条收藏的id，第一页为0
+	MaxId         int64 `protobuf:"varint,3,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteItemsReq) Reset() {
+	*x = ListFavoriteItemsReq{}
+	mi := &file_intr_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteItemsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteItemsReq) ProtoMessage() {}
+
+func (x *ListFavoriteItemsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteItemsReq.ProtoReflect.Descriptor instead.
+func (*ListFavoriteItemsReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListFavoriteItemsReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *ListFavoriteItemsReq) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *ListFavoriteItemsReq) GetMaxId() int64 {
+	if x != nil {
+		return x.MaxId
+	}
+	return 0
+}
+
+func (x *ListFavoriteItemsReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListFavoriteItemsResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*FavoriteItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFavoriteItemsResp) Reset() {
+	*x = ListFavoriteItemsResp{}
+	mi := &file_intr_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFavoriteItemsResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFavoriteItemsResp) ProtoMessage() {}
+
+func (x *ListFavoriteItemsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFavoriteItemsResp.ProtoReflect.Descriptor instead.
+func (*ListFavoriteItemsResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListFavoriteItemsResp) GetItems() []*FavoriteItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CancelLikeReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelLikeReq) Reset() {
+	*x = CancelLikeReq{}
+	mi := &file_intr_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelLikeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLikeReq) ProtoMessage() {}
+
+func (x *CancelLikeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLikeReq.ProtoReflect.Descriptor instead.
+func (*CancelLikeReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CancelLikeReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CancelLikeReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CancelLikeReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type CancelLikeResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelLikeResp) Reset() {
+	*x = CancelLikeResp{}
+	mi := &file_intr_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelLikeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelLikeResp) ProtoMessage() {}
+
+func (x *CancelLikeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelLikeResp.ProtoReflect.Descriptor instead.
+func (*CancelLikeResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{39}
+}
+
+type LikeReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uid   int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// reaction 表态：like、love、insightful、funny，为空时是点赞，兼容只有点赞的客户端
+	Reaction      string `protobuf:"bytes,4,opt,name=reaction,proto3" json:"reaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeReq) Reset() {
+	*x = LikeReq{}
+	mi := &file_intr_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeReq) ProtoMessage() {}
+
+func (x *LikeReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeReq.ProtoReflect.Descriptor instead.
+func (*LikeReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *LikeReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *LikeReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *LikeReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *LikeReq) GetReaction() string {
+	if x != nil {
+		return x.Reaction
+	}
+	return ""
+}
+
+type LikeResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LikeResp) Reset() {
+	*x = LikeResp{}
+	mi := &file_intr_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LikeResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LikeResp) ProtoMessage() {}
+
+func (x *LikeResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LikeResp.ProtoReflect.Descriptor instead.
+func (*LikeResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{41}
+}
+
+type ViewReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewReq) Reset() {
+	*x = ViewReq{}
+	mi := &file_intr_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewReq) ProtoMessage() {}
+
+func (x *ViewReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewReq.ProtoReflect.Descriptor instead.
+func (*ViewReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ViewReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *ViewReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+type ViewResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ViewResp) Reset() {
+	*x = ViewResp{}
+	mi := &file_intr_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ViewResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewResp) ProtoMessage() {}
+
+func (x *ViewResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewResp.ProtoReflect.Descriptor instead.
+func (*ViewResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{43}
+}
+
+type Comment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Uid   int64                  `protobuf:"varint,2,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string                 `protobuf:"bytes,3,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,4,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// root_id 根评论为0
+	RootId int64 `protobuf:"varint,5,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	// parent_id 被回复的评论，根评论为0
+	ParentId int64 `protobuf:"varint,6,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// reply_to_uid 被回复的评论的作者
+	ReplyToUid int64  `protobuf:"varint,7,opt,name=reply_to_uid,json=replyToUid,proto3" json:"reply_to_uid,omitempty"`
+	Content    string `protobuf:"bytes,8,opt,name=content,proto3" json:"content,omitempty"`
+	Likes      int64  `protobuf:"varint,9,opt,name=likes,proto3" json:"likes,omitempty"`
+	// replies 根评论的回复数
+	Replies int64 `protobuf:"varint,10,opt,name=replies,proto3" json:"replies,omitempty"`
+	Pinned  bool  `protobuf:"varint,11,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	// liked 查询的用户是否点赞
+	Liked         bool  `protobuf:"varint,12,opt,name=liked,proto3" json:"liked,omitempty"`
+	CTime         int64 `protobuf:"varint,13,opt,name=c_time,json=cTime,proto3" json:"c_time,omitempty"`
+	UTime         int64 `protobuf:"varint,14,opt,name=u_time,json=uTime,proto3" json:"u_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_intr_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *Comment) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *Comment) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *Comment) GetRootId() int64 {
+	if x != nil {
+		return x.RootId
+	}
+	return 0
+}
+
+func (x *Comment) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Comment) GetReplyToUid() int64 {
+	if x != nil {
+		return x.ReplyToUid
+	}
+	return 0
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetLikes() int64 {
+	if x != nil {
+		return x.Likes
+	}
+	return 0
+}
+
+func (x *Comment) GetReplies() int64 {
+	if x != nil {
+		return x.Replies
+	}
+	return 0
+}
+
+func (x *Comment) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Comment) GetLiked() bool {
+	if x != nil {
+		return x.Liked
+	}
+	return false
+}
+
+func (x *Comment) GetCTime() int64 {
+	if x != nil {
+		return x.CTime
+	}
+	return 0
+}
+
+func (x *Comment) GetUTime() int64 {
+	if x != nil {
+		return x.UTime
+	}
+	return 0
+}
+
+type CreateCommentReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Uid   int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// parent_id 回复的评论，0表示直接评论资源
+	ParentId      int64  `protobuf:"varint,4,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Content       string `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentReq) Reset() {
+	*x = CreateCommentReq{}
+	mi := &file_intr_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentReq) ProtoMessage() {}
+
+func (x *CreateCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentReq.ProtoReflect.Descriptor instead.
+func (*CreateCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CreateCommentReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *CreateCommentReq) GetBiz() string {
+	if x != nil {
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *CreateCommentReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
+	}
+	return 0
+}
+
+func (x *CreateCommentReq) GetParentId() int64 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *CreateCommentReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type CreateCommentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentResp) Reset() {
+	*x = CreateCommentResp{}
+	mi := &file_intr_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentResp) ProtoMessage() {}
+
+func (x *CreateCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentResp.ProtoReflect.Descriptor instead.
+func (*CreateCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CreateCommentResp) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type UpdateCommentReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentReq) Reset() {
+	*x = UpdateCommentReq{}
+	mi := &file_intr_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentReq) ProtoMessage() {}
+
+func (x *UpdateCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentReq.ProtoReflect.Descriptor instead.
+func (*UpdateCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *UpdateCommentReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *UpdateCommentReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateCommentReq) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type UpdateCommentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCommentResp) Reset() {
+	*x = UpdateCommentResp{}
+	mi := &file_intr_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentResp) ProtoMessage() {}
+
+func (x *UpdateCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentResp.ProtoReflect.Descriptor instead.
+func (*UpdateCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{48}
+}
+
+type DeleteCommentReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentReq) Reset() {
+	*x = DeleteCommentReq{}
+	mi := &file_intr_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentReq) ProtoMessage() {}
+
+func (x *DeleteCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentReq.ProtoReflect.Descriptor instead.
+func (*DeleteCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DeleteCommentReq) GetUid() int64 {
+	if x != nil {
+		return x.Uid
+	}
+	return 0
+}
+
+func (x *DeleteCommentReq) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteCommentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentResp) Reset() {
+	*x = DeleteCommentResp{}
+	mi := &file_intr_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentResp) ProtoMessage() {}
+
+func (x *DeleteCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *GetResp) GetInter() *Interaction {
-	if x != nil {
-		return x.Inter
-	}
-	return nil
+// Deprecated: Use DeleteCommentResp.ProtoReflect.Descriptor instead.
+func (*DeleteCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{50}
 }
 
-type Interaction struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
-	CTime         int64                  `protobuf:"varint,4,opt,name=c_time,json=cTime,proto3" json:"c_time,omitempty"`
-	UTime         int64                  `protobuf:"varint,5,opt,name=u_time,json=uTime,proto3" json:"u_time,omitempty"`
-	Views         int64                  `protobuf:"varint,6,opt,name=views,proto3" json:"views,omitempty"`
-	Likes         int64                  `protobuf:"varint,7,opt,name=likes,proto3" json:"likes,omitempty"`
-	Favorites     int64                  `protobuf:"varint,8,opt,name=favorites,proto3" json:"favorites,omitempty"`
-	Liked         bool                   `protobuf:"varint,9,opt,name=liked,proto3" json:"liked,omitempty"`
-	Collected     bool                   `protobuf:"varint,10,opt,name=collected,proto3" json:"collected,omitempty"`
+type PinCommentReq struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Biz   string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Id    int64                  `protobuf:"varint,3,opt,name=id,proto3" json:"id,omitempty"`
+	// pinned false表示取消置顶
+	Pinned        bool `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Interaction) Reset() {
-	*x = Interaction{}
-	mi := &file_intr_proto_msgTypes[8]
+func (x *PinCommentReq) Reset() {
+	*x = PinCommentReq{}
+	mi := &file_intr_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Interaction) String() string {
+func (x *PinCommentReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Interaction) ProtoMessage() {}
+func (*PinCommentReq) ProtoMessage() {}
 
-func (x *Interaction) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[8]
+func (x *PinCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -473,107 +3047,104 @@ func (x *Interaction) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Interaction.ProtoReflect.Descriptor instead.
-func (*Interaction) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{8}
-}
-
-func (x *Interaction) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use PinCommentReq.ProtoReflect.Descriptor instead.
+func (*PinCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *Interaction) GetBiz() string {
+func (x *PinCommentReq) GetBiz() string {
 	if x != nil {
 		return x.Biz
 	}
 	return ""
 }
 
-func (x *Interaction) GetBizId() int64 {
+func (x *PinCommentReq) GetBizId() int64 {
 	if x != nil {
 		return x.BizId
 	}
 	return 0
 }
 
-func (x *Interaction) GetCTime() int64 {
+func (x *PinCommentReq) GetId() int64 {
 	if x != nil {
-		return x.CTime
+		return x.Id
 	}
 	return 0
 }
 
-func (x *Interaction) GetUTime() int64 {
+func (x *PinCommentReq) GetPinned() bool {
 	if x != nil {
-		return x.UTime
+		return x.Pinned
 	}
-	return 0
+	return false
 }
 
-func (x *Interaction) GetViews() int64 {
-	if x != nil {
-		return x.Views
-	}
-	return 0
+type PinCommentResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Interaction) GetLikes() int64 {
-	if x != nil {
-		return x.Likes
-	}
-	return 0
+func (x *PinCommentResp) Reset() {
+	*x = PinCommentResp{}
+	mi := &file_intr_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Interaction) GetFavorites() int64 {
-	if x != nil {
-		return x.Favorites
-	}
-	return 0
+func (x *PinCommentResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Interaction) GetLiked() bool {
+func (*PinCommentResp) ProtoMessage() {}
+
+func (x *PinCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[52]
 	if x != nil {
-		return x.Liked
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Interaction) GetCollected() bool {
-	if x != nil {
-		return x.Collected
-	}
-	return false
+// Deprecated: Use PinCommentResp.ProtoReflect.Descriptor instead.
+func (*PinCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{52}
 }
 
-type FavoriteReq struct {
+type ListCommentsReq struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64
-	Uid           int64  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	FavoriteId    int64  `protobuf:"varint,2,opt,name=favorite_id,json=favoriteId,proto3" json:"favorite_id,omitempty"`
-	Biz           string `protobuf:"bytes,3,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64  `protobuf:"varint,4,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	// uid 查询的用户，用于返回是否点赞
+	Uid   int64       `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Biz   string      `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
+	BizId int64       `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Sort  CommentSort `protobuf:"varint,4,opt,name=sort,proto3,enum=intr.v1.CommentSort" json:"sort,omitempty"`
+	// cursor 按时间排序时是上一页最后一条评论的id，按热度排序时是偏移量，0表示第一页
+	Cursor        int64 `protobuf:"varint,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32 `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FavoriteReq) Reset() {
-	*x = FavoriteReq{}
-	mi := &file_intr_proto_msgTypes[9]
+func (x *ListCommentsReq) Reset() {
+	*x = ListCommentsReq{}
+	mi := &file_intr_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FavoriteReq) String() string {
+func (x *ListCommentsReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FavoriteReq) ProtoMessage() {}
+func (*ListCommentsReq) ProtoMessage() {}
 
-func (x *FavoriteReq) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[9]
+func (x *ListCommentsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,60 +3155,75 @@ func (x *FavoriteReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FavoriteReq.ProtoReflect.Descriptor instead.
-func (*FavoriteReq) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListCommentsReq.ProtoReflect.Descriptor instead.
+func (*ListCommentsReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *FavoriteReq) GetUid() int64 {
+func (x *ListCommentsReq) GetUid() int64 {
 	if x != nil {
 		return x.Uid
 	}
 	return 0
 }
 
-func (x *FavoriteReq) GetFavoriteId() int64 {
+func (x *ListCommentsReq) GetBiz() string {
 	if x != nil {
-		return x.FavoriteId
+		return x.Biz
+	}
+	return ""
+}
+
+func (x *ListCommentsReq) GetBizId() int64 {
+	if x != nil {
+		return x.BizId
 	}
 	return 0
 }
 
-func (x *FavoriteReq) GetBiz() string {
+func (x *ListCommentsReq) GetSort() CommentSort {
 	if x != nil {
-		return x.Biz
+		return x.Sort
 	}
-	return ""
+	return CommentSort_COMMENT_SORT_TIME
 }
 
-func (x *FavoriteReq) GetBizId() int64 {
+func (x *ListCommentsReq) GetCursor() int64 {
 	if x != nil {
-		return x.BizId
+		return x.Cursor
 	}
 	return 0
 }
 
-type FavoriteResp struct {
+func (x *ListCommentsReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListCommentsResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FavoriteResp) Reset() {
-	*x = FavoriteResp{}
-	mi := &file_intr_proto_msgTypes[10]
+func (x *ListCommentsResp) Reset() {
+	*x = ListCommentsResp{}
+	mi := &file_intr_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FavoriteResp) String() string {
+func (x *ListCommentsResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FavoriteResp) ProtoMessage() {}
+func (*ListCommentsResp) ProtoMessage() {}
 
-func (x *FavoriteResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[10]
+func (x *ListCommentsResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,35 +3234,44 @@ func (x *FavoriteResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FavoriteResp.ProtoReflect.Descriptor instead.
-func (*FavoriteResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListCommentsResp.ProtoReflect.Descriptor instead.
+func (*ListCommentsResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{54}
 }
 
-type CancelLikeReq struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+func (x *ListCommentsResp) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+type ListRepliesReq struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Uid    int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	RootId int64                  `protobuf:"varint,2,opt,name=root_id,json=rootId,proto3" json:"root_id,omitempty"`
+	// min_id 上一页最后一条回复的id，0表示第一页
+	MinId         int64 `protobuf:"varint,3,opt,name=min_id,json=minId,proto3" json:"min_id,omitempty"`
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelLikeReq) Reset() {
-	*x = CancelLikeReq{}
-	mi := &file_intr_proto_msgTypes[11]
+func (x *ListRepliesReq) Reset() {
+	*x = ListRepliesReq{}
+	mi := &file_intr_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelLikeReq) String() string {
+func (x *ListRepliesReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelLikeReq) ProtoMessage() {}
+func (*ListRepliesReq) ProtoMessage() {}
 
-func (x *CancelLikeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[11]
+func (x *ListRepliesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -687,53 +3282,61 @@ func (x *CancelLikeReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelLikeReq.ProtoReflect.Descriptor instead.
-func (*CancelLikeReq) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListRepliesReq.ProtoReflect.Descriptor instead.
+func (*ListRepliesReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *CancelLikeReq) GetUid() int64 {
+func (x *ListRepliesReq) GetUid() int64 {
 	if x != nil {
 		return x.Uid
 	}
 	return 0
 }
 
-func (x *CancelLikeReq) GetBiz() string {
+func (x *ListRepliesReq) GetRootId() int64 {
 	if x != nil {
-		return x.Biz
+		return x.RootId
 	}
-	return ""
+	return 0
 }
 
-func (x *CancelLikeReq) GetBizId() int64 {
+func (x *ListRepliesReq) GetMinId() int64 {
 	if x != nil {
-		return x.BizId
+		return x.MinId
 	}
 	return 0
 }
 
-type CancelLikeResp struct {
+func (x *ListRepliesReq) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRepliesResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Replies       []*Comment             `protobuf:"bytes,1,rep,name=replies,proto3" json:"replies,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelLikeResp) Reset() {
-	*x = CancelLikeResp{}
-	mi := &file_intr_proto_msgTypes[12]
+func (x *ListRepliesResp) Reset() {
+	*x = ListRepliesResp{}
+	mi := &file_intr_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelLikeResp) String() string {
+func (x *ListRepliesResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelLikeResp) ProtoMessage() {}
+func (*ListRepliesResp) ProtoMessage() {}
 
-func (x *CancelLikeResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[12]
+func (x *ListRepliesResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,35 +3347,41 @@ func (x *CancelLikeResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelLikeResp.ProtoReflect.Descriptor instead.
-func (*CancelLikeResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ListRepliesResp.ProtoReflect.Descriptor instead.
+func (*ListRepliesResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{56}
 }
 
-type LikeReq struct {
+func (x *ListRepliesResp) GetReplies() []*Comment {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type LikeCommentReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
-	Biz           string                 `protobuf:"bytes,2,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64                  `protobuf:"varint,3,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikeReq) Reset() {
-	*x = LikeReq{}
-	mi := &file_intr_proto_msgTypes[13]
+func (x *LikeCommentReq) Reset() {
+	*x = LikeCommentReq{}
+	mi := &file_intr_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikeReq) String() string {
+func (x *LikeCommentReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeReq) ProtoMessage() {}
+func (*LikeCommentReq) ProtoMessage() {}
 
-func (x *LikeReq) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[13]
+func (x *LikeCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -783,53 +3392,46 @@ func (x *LikeReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeReq.ProtoReflect.Descriptor instead.
-func (*LikeReq) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use LikeCommentReq.ProtoReflect.Descriptor instead.
+func (*LikeCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *LikeReq) GetUid() int64 {
+func (x *LikeCommentReq) GetUid() int64 {
 	if x != nil {
 		return x.Uid
 	}
 	return 0
 }
 
-func (x *LikeReq) GetBiz() string {
-	if x != nil {
-		return x.Biz
-	}
-	return ""
-}
-
-func (x *LikeReq) GetBizId() int64 {
+func (x *LikeCommentReq) GetId() int64 {
 	if x != nil {
-		return x.BizId
+		return x.Id
 	}
 	return 0
 }
 
-type LikeResp struct {
+type LikeCommentResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LikeResp) Reset() {
-	*x = LikeResp{}
-	mi := &file_intr_proto_msgTypes[14]
+func (x *LikeCommentResp) Reset() {
+	*x = LikeCommentResp{}
+	mi := &file_intr_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LikeResp) String() string {
+func (x *LikeCommentResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LikeResp) ProtoMessage() {}
+func (*LikeCommentResp) ProtoMessage() {}
 
-func (x *LikeResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[14]
+func (x *LikeCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -840,34 +3442,34 @@ func (x *LikeResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LikeResp.ProtoReflect.Descriptor instead.
-func (*LikeResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use LikeCommentResp.ProtoReflect.Descriptor instead.
+func (*LikeCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{58}
 }
 
-type ViewReq struct {
+type CancelLikeCommentReq struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Biz           string                 `protobuf:"bytes,1,opt,name=biz,proto3" json:"biz,omitempty"`
-	BizId         int64                  `protobuf:"varint,2,opt,name=biz_id,json=bizId,proto3" json:"biz_id,omitempty"`
+	Uid           int64                  `protobuf:"varint,1,opt,name=uid,proto3" json:"uid,omitempty"`
+	Id            int64                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ViewReq) Reset() {
-	*x = ViewReq{}
-	mi := &file_intr_proto_msgTypes[15]
+func (x *CancelLikeCommentReq) Reset() {
+	*x = CancelLikeCommentReq{}
+	mi := &file_intr_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ViewReq) String() string {
+func (x *CancelLikeCommentReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ViewReq) ProtoMessage() {}
+func (*CancelLikeCommentReq) ProtoMessage() {}
 
-func (x *ViewReq) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[15]
+func (x *CancelLikeCommentReq) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -878,46 +3480,46 @@ func (x *ViewReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ViewReq.ProtoReflect.Descriptor instead.
-func (*ViewReq) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use CancelLikeCommentReq.ProtoReflect.Descriptor instead.
+func (*CancelLikeCommentReq) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *ViewReq) GetBiz() string {
+func (x *CancelLikeCommentReq) GetUid() int64 {
 	if x != nil {
-		return x.Biz
+		return x.Uid
 	}
-	return ""
+	return 0
 }
 
-func (x *ViewReq) GetBizId() int64 {
+func (x *CancelLikeCommentReq) GetId() int64 {
 	if x != nil {
-		return x.BizId
+		return x.Id
 	}
 	return 0
 }
 
-type ViewResp struct {
+type CancelLikeCommentResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ViewResp) Reset() {
-	*x = ViewResp{}
-	mi := &file_intr_proto_msgTypes[16]
+func (x *CancelLikeCommentResp) Reset() {
+	*x = CancelLikeCommentResp{}
+	mi := &file_intr_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ViewResp) String() string {
+func (x *CancelLikeCommentResp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ViewResp) ProtoMessage() {}
+func (*CancelLikeCommentResp) ProtoMessage() {}
 
-func (x *ViewResp) ProtoReflect() protoreflect.Message {
-	mi := &file_intr_proto_msgTypes[16]
+func (x *CancelLikeCommentResp) ProtoReflect() protoreflect.Message {
+	mi := &file_intr_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -928,120 +3530,460 @@ func (x *ViewResp) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ViewResp.ProtoReflect.Descriptor instead.
-func (*ViewResp) Descriptor() ([]byte, []int) {
-	return file_intr_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use CancelLikeCommentResp.ProtoReflect.Descriptor instead.
+func (*CancelLikeCommentResp) Descriptor() ([]byte, []int) {
+	return file_intr_proto_rawDescGZIP(), []int{60}
 }
 
 var File_intr_proto protoreflect.FileDescriptor
 
 var file_intr_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x38, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44,
-	0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x73, 0x22,
-	0x9a, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x39, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x21, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79,
-	0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x4f, 0x0a, 0x0b, 0x49,
-	0x6e, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x49, 0x0a, 0x0c,
-	0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03,
-	0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x34, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x0c, 0x0a, 0x0a, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x86, 0x01, 0x0a, 0x0c, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
+	0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x1f,
+	0x0a, 0x0b, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0a, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x73, 0x0a, 0x08, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10,
 	0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a,
 	0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x0d, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
-	0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c,
-	0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52,
+	0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x38, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4c,
+	0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x27, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65,
+	0x73, 0x22, 0x4c, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
 	0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
 	0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x21, 0x0a,
-	0x09, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
-	0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64,
-	0x22, 0x43, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15,
-	0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x2a, 0x0a, 0x05, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x22, 0xf2, 0x01, 0x0a,
-	0x0b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15,
-	0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06,
-	0x75, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b,
-	0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69,
-	0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65,
-	0x64, 0x22, 0x69, 0x0a, 0x0b, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x73, 0x22,
+	0x3f, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b,
+	0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x22, 0x9c, 0x01, 0x0a, 0x0e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x3b, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73,
+	0x1a, 0x4d, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x7c, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x12, 0x10,
+	0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a,
+	0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75,
+	0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72,
+	0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a,
+	0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x3b, 0x0a,
+	0x0c, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a,
+	0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x65, 0x6e,
+	0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x73, 0x12,
+	0x20, 0x0a, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x61, 0x6e, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74,
+	0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x2b, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x6a, 0x0a, 0x0a,
+	0x54, 0x72, 0x65, 0x6e, 0x64, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76,
+	0x69, 0x65, 0x77, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66,
+	0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x22, 0x38, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x42,
+	0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x7a,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x62, 0x69, 0x7a, 0x49,
+	0x64, 0x73, 0x22, 0x9a, 0x01, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x39, 0x0a, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x73, 0x70, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x1a, 0x4f,
+	0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x49, 0x0a, 0x0c, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x0d, 0x43, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0x45, 0x0a, 0x08, 0x4c, 0x69, 0x6b,
+	0x65, 0x64, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64,
+	0x22, 0x21, 0x0a, 0x09, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69,
+	0x6b, 0x65, 0x64, 0x22, 0x43, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
+	0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x35, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x05, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x05, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x22,
+	0xce, 0x03, 0x0a, 0x0b, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
+	0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x15, 0x0a, 0x06, 0x75, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6b,
+	0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x73,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x73, 0x12, 0x41, 0x0a, 0x09, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x72, 0x65, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x3c, 0x0a, 0x0e, 0x52, 0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x69, 0x0a, 0x0b, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x0e, 0x0a, 0x0c, 0x46,
+	0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x4e, 0x0a, 0x11, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75,
+	0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x43,
+	0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x22, 0xa4, 0x01, 0x0a, 0x0e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x15, 0x0a, 0x06, 0x75, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x7b, 0x0a, 0x0c, 0x46, 0x61, 0x76, 0x6f,
+	0x72, 0x69, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x15,
+	0x0a, 0x06, 0x63, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x59, 0x0a, 0x17, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46,
+	0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x22, 0x4b, 0x0a, 0x18, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69,
+	0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2f, 0x0a, 0x06,
+	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x22, 0x69, 0x0a,
+	0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x07, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x22, 0x1a, 0x0a, 0x18, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0x3b, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x1a, 0x0a, 0x18, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x22, 0x40, 0x0a,
+	0x16, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x22,
+	0x4c, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x31, 0x0a, 0x07, 0x66, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f,
+	0x6c, 0x64, 0x65, 0x72, 0x52, 0x07, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73, 0x22, 0x72, 0x0a,
+	0x14, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x49, 0x74, 0x65,
+	0x6d, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x22, 0x44, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a, 0x05, 0x69, 0x74,
+	0x65, 0x6d, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d,
+	0x52, 0x05, 0x69, 0x74, 0x65, 0x6d, 0x73, 0x22, 0x4a, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69,
+	0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06,
+	0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69,
+	0x7a, 0x49, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x60, 0x0a, 0x07, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71,
 	0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75,
-	0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x66, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
-	0x65, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x0e, 0x0a, 0x0c,
-	0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x4a, 0x0a, 0x0d,
-	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a,
+	0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x0a, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0x32, 0x0a, 0x07, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x10,
+	0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a,
+	0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x0a, 0x0a, 0x08, 0x56, 0x69, 0x65, 0x77, 0x52,
+	0x65, 0x73, 0x70, 0x22, 0xd2, 0x02, 0x0a, 0x07, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f,
+	0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6f,
+	0x74, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64,
+	0x12, 0x20, 0x0a, 0x0c, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x75, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x79, 0x54, 0x6f, 0x55,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6b, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x69, 0x6b,
+	0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x70, 0x69, 0x6e, 0x6e, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x05, 0x6c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x63, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x15, 0x0a, 0x06, 0x75, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x75, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x84, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a,
 	0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12,
 	0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69,
 	0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x43, 0x61, 0x6e, 0x63,
-	0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x44, 0x0a, 0x07, 0x4c, 0x69,
-	0x6b, 0x65, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64,
-	0x22, 0x0a, 0x0a, 0x08, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x22, 0x32, 0x0a, 0x07,
-	0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64,
-	0x22, 0x0a, 0x0a, 0x08, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x32, 0xb5, 0x03, 0x0a,
-	0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12, 0x10, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e,
-	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70,
-	0x12, 0x2b, 0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x10, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x69, 0x6e, 0x74,
-	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3d, 0x0a,
-	0x0a, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x16, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65,
-	0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61,
-	0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x08,
-	0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x15,
-	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x28, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x0f, 0x2e, 0x69,
-	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x10, 0x2e,
-	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x2e, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x11, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x69, 0x6e,
-	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12,
-	0x3a, 0x0a, 0x09, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x15, 0x2e, 0x69,
-	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
-	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f,
-	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x08, 0x47,
-	0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e,
-	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x42, 0x2b, 0x5a, 0x29, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x5f, 0x67, 0x6f,
-	0x2f, 0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x69, 0x6e, 0x74, 0x72, 0x3b, 0x69, 0x6e, 0x74, 0x72, 0x76,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22,
+	0x3f, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x22, 0x4e, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x22, 0x13, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x34, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x22, 0x60, 0x0a, 0x0d, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x7a, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x69,
+	0x6e, 0x6e, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x69, 0x6e, 0x6e,
+	0x65, 0x64, 0x22, 0x10, 0x0a, 0x0e, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x22, 0xa4, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69,
+	0x7a, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x69, 0x7a, 0x12, 0x15, 0x0a, 0x06,
+	0x62, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x69,
+	0x7a, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x63,
+	0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x40, 0x0a, 0x10, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x2c, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x68, 0x0a,
+	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x72, 0x6f, 0x6f, 0x74, 0x49, 0x64, 0x12, 0x15, 0x0a, 0x06, 0x6d, 0x69,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x3d, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x2a, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x07, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x22, 0x32, 0x0a, 0x0e, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x4c, 0x69,
+	0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x22, 0x38, 0x0a,
+	0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x03, 0x75, 0x69, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x17, 0x0a, 0x15, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x2a, 0x3e, 0x0a, 0x0b, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x6f, 0x72, 0x74, 0x12,
+	0x15, 0x0a, 0x11, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f,
+	0x54, 0x49, 0x4d, 0x45, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x4f, 0x4d, 0x4d, 0x45, 0x4e,
+	0x54, 0x5f, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x50, 0x4f, 0x50, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x01,
+	0x32, 0x83, 0x0a, 0x0a, 0x12, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x56, 0x69, 0x65, 0x77, 0x12,
+	0x10, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x1a, 0x11, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x69, 0x65, 0x77,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x2b, 0x0a, 0x04, 0x4c, 0x69, 0x6b, 0x65, 0x12, 0x10, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x11,
+	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x3d, 0x0a, 0x0a, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x12,
+	0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x37, 0x0a, 0x08, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x12, 0x49, 0x0a, 0x0e, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x76, 0x6f,
+	0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x12, 0x5b, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x61,
+	0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x21,
+	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46,
+	0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x5b, 0x0a, 0x14, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72,
+	0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69,
+	0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x21, 0x2e, 0x69, 0x6e,
+	0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f,
+	0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x12, 0x5b,
+	0x0a, 0x14, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x20, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x71, 0x1a, 0x21, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x12, 0x58, 0x0a, 0x13, 0x4c,
+	0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x73, 0x12, 0x1f, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x52, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76,
+	0x6f, 0x72, 0x69, 0x74, 0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x1d, 0x2e, 0x69, 0x6e, 0x74,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74,
+	0x65, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x61, 0x76, 0x6f, 0x72, 0x69, 0x74, 0x65,
+	0x49, 0x74, 0x65, 0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x28, 0x0a, 0x03, 0x47, 0x65, 0x74,
+	0x12, 0x0f, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x1a, 0x10, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x2e, 0x0a, 0x05, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x12, 0x11, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52, 0x65, 0x71, 0x1a,
+	0x12, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x3a, 0x0a, 0x09, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x12, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12,
+	0x3a, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x3d, 0x0a, 0x0a, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x08, 0x47, 0x65,
+	0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x12, 0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x69,
+	0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x79, 0x49, 0x44, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x37, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x12,
+	0x14, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x65,
+	0x6e, 0x64, 0x52, 0x65, 0x71, 0x1a, 0x15, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x12, 0x55, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x65,
+	0x6e, 0x64, 0x12, 0x1e, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x71, 0x1a, 0x1f, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x64, 0x54, 0x72, 0x65, 0x6e, 0x64, 0x52,
+	0x65, 0x73, 0x70, 0x12, 0x31, 0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x12, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x1a, 0x13, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x32, 0xc4, 0x04, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x46, 0x0a, 0x0d, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6e, 0x74,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x46, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x46, 0x0a, 0x0d, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x69, 0x6e, 0x74,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x12, 0x3d, 0x0a, 0x0a, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x16, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x50, 0x69, 0x6e, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x12, 0x43, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x18, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x69, 0x6e, 0x74,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x40, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x69, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x12, 0x40, 0x0a, 0x0b, 0x4c, 0x69, 0x6b, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x17, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a,
+	0x18, 0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x12, 0x52, 0x0a, 0x11, 0x43, 0x61, 0x6e,
+	0x63, 0x65, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1d,
+	0x2e, 0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c,
+	0x69, 0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1e, 0x2e,
+	0x69, 0x6e, 0x74, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4c, 0x69,
+	0x6b, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x42, 0x2b, 0x5a,
+	0x29, 0x6c, 0x65, 0x61, 0x72, 0x6e, 0x5f, 0x67, 0x6f, 0x2f, 0x77, 0x65, 0x62, 0x6f, 0x6f, 0x6b,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x69,
+	0x6e, 0x74, 0x72, 0x3b, 0x69, 0x6e, 0x74, 0x72, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1056,52 +3998,151 @@ func file_intr_proto_rawDescGZIP() []byte {
 	return file_intr_proto_rawDescData
 }
 
-var file_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_intr_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_intr_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_intr_proto_goTypes = []any{
-	(*GetByIDsReq)(nil),    // 0: intr.v1.GetByIDsReq
-	(*GetByIDsResp)(nil),   // 1: intr.v1.GetByIDsResp
-	(*CollectedReq)(nil),   // 2: intr.v1.CollectedReq
-	(*CollectedResp)(nil),  // 3: intr.v1.CollectedResp
-	(*LikedReq)(nil),       // 4: intr.v1.LikedReq
-	(*LikedResp)(nil),      // 5: intr.v1.LikedResp
-	(*GetReq)(nil),         // 6: intr.v1.GetReq
-	(*GetResp)(nil),        // 7: intr.v1.GetResp
-	(*Interaction)(nil),    // 8: intr.v1.Interaction
-	(*FavoriteReq)(nil),    // 9: intr.v1.FavoriteReq
-	(*FavoriteResp)(nil),   // 10: intr.v1.FavoriteResp
-	(*CancelLikeReq)(nil),  // 11: intr.v1.CancelLikeReq
-	(*CancelLikeResp)(nil), // 12: intr.v1.CancelLikeResp
-	(*LikeReq)(nil),        // 13: intr.v1.LikeReq
-	(*LikeResp)(nil),       // 14: intr.v1.LikeResp
-	(*ViewReq)(nil),        // 15: intr.v1.ViewReq
-	(*ViewResp)(nil),       // 16: intr.v1.ViewResp
-	nil,                    // 17: intr.v1.GetByIDsResp.IntersEntry
+	(CommentSort)(0),                 // 0: intr.v1.CommentSort
+	(*DeleteReq)(nil),                // 1: intr.v1.DeleteReq
+	(*DeleteResp)(nil),               // 2: intr.v1.DeleteResp
+	(*ListLikesReq)(nil),             // 3: intr.v1.ListLikesReq
+	(*UserLike)(nil),                 // 4: intr.v1.UserLike
+	(*ListLikesResp)(nil),            // 5: intr.v1.ListLikesResp
+	(*BatchCheckReq)(nil),            // 6: intr.v1.BatchCheckReq
+	(*UserState)(nil),                // 7: intr.v1.UserState
+	(*BatchCheckResp)(nil),           // 8: intr.v1.BatchCheckResp
+	(*GetTrendReq)(nil),              // 9: intr.v1.GetTrendReq
+	(*GetTrendResp)(nil),             // 10: intr.v1.GetTrendResp
+	(*GetAggregatedTrendReq)(nil),    // 11: intr.v1.GetAggregatedTrendReq
+	(*GetAggregatedTrendResp)(nil),   // 12: intr.v1.GetAggregatedTrendResp
+	(*TrendPoint)(nil),               // 13: intr.v1.TrendPoint
+	(*GetByIDsReq)(nil),              // 14: intr.v1.GetByIDsReq
+	(*GetByIDsResp)(nil),             // 15: intr.v1.GetByIDsResp
+	(*CollectedReq)(nil),             // 16: intr.v1.CollectedReq
+	(*CollectedResp)(nil),            // 17: intr.v1.CollectedResp
+	(*LikedReq)(nil),                 // 18: intr.v1.LikedReq
+	(*LikedResp)(nil),                // 19: intr.v1.LikedResp
+	(*GetReq)(nil),                   // 20: intr.v1.GetReq
+	(*GetResp)(nil),                  // 21: intr.v1.GetResp
+	(*Interaction)(nil),              // 22: intr.v1.Interaction
+	(*FavoriteReq)(nil),              // 23: intr.v1.FavoriteReq
+	(*FavoriteResp)(nil),             // 24: intr.v1.FavoriteResp
+	(*CancelFavoriteReq)(nil),        // 25: intr.v1.CancelFavoriteReq
+	(*CancelFavoriteResp)(nil),       // 26: intr.v1.CancelFavoriteResp
+	(*FavoriteFolder)(nil),           // 27: intr.v1.FavoriteFolder
+	(*FavoriteItem)(nil),             // 28: intr.v1.FavoriteItem
+	(*CreateFavoriteFolderReq)(nil),  // 29: intr.v1.CreateFavoriteFolderReq
+	(*CreateFavoriteFolderResp)(nil), // 30: intr.v1.CreateFavoriteFolderResp
+	(*UpdateFavoriteFolderReq)(nil),  // 31: intr.v1.UpdateFavoriteFolderReq
+	(*UpdateFavoriteFolderResp)(nil), // 32: intr.v1.UpdateFavoriteFolderResp
+	(*DeleteFavoriteFolderReq)(nil),  // 33: intr.v1.DeleteFavoriteFolderReq
+	(*DeleteFavoriteFolderResp)(nil), // 34: intr.v1.DeleteFavoriteFolderResp
+	(*ListFavoriteFoldersReq)(nil),   // 35: intr.v1.ListFavoriteFoldersReq
+	(*ListFavoriteFoldersResp)(nil),  // 36: intr.v1.ListFavoriteFoldersResp
+	(*ListFavoriteItemsReq)(nil),     // 37: intr.v1.ListFavoriteItemsReq
+	(*ListFavoriteItemsResp)(nil),    // 38: intr.v1.ListFavoriteItemsResp
+	(*CancelLikeReq)(nil),            // 39: intr.v1.CancelLikeReq
+	(*CancelLikeResp)(nil),           // 40: intr.v1.CancelLikeResp
+	(*LikeReq)(nil),                  // 41: intr.v1.LikeReq
+	(*LikeResp)(nil),                 // 42: intr.v1.LikeResp
+	(*ViewReq)(nil),                  // 43: intr.v1.ViewReq
+	(*ViewResp)(nil),                 // 44: intr.v1.ViewResp
+	(*Comment)(nil),                  // 45: intr.v1.Comment
+	(*CreateCommentReq)(nil),         // 46: intr.v1.CreateCommentReq
+	(*CreateCommentResp)(nil),        // 47: intr.v1.CreateCommentResp
+	(*UpdateCommentReq)(nil),         // 48: intr.v1.UpdateCommentReq
+	(*UpdateCommentResp)(nil),        // 49: intr.v1.UpdateCommentResp
+	(*DeleteCommentReq)(nil),         // 50: intr.v1.DeleteCommentReq
+	(*DeleteCommentResp)(nil),        // 51: intr.v1.DeleteCommentResp
+	(*PinCommentReq)(nil),            // 52: intr.v1.PinCommentReq
+	(*PinCommentResp)(nil),           // 53: intr.v1.PinCommentResp
+	(*ListCommentsReq)(nil),          // 54: intr.v1.ListCommentsReq
+	(*ListCommentsResp)(nil),         // 55: intr.v1.ListCommentsResp
+	(*ListRepliesReq)(nil),           // 56: intr.v1.ListRepliesReq
+	(*ListRepliesResp)(nil),          // 57: intr.v1.ListRepliesResp
+	(*LikeCommentReq)(nil),           // 58: intr.v1.LikeCommentReq
+	(*LikeCommentResp)(nil),          // 59: intr.v1.LikeCommentResp
+	(*CancelLikeCommentReq)(nil),     // 60: intr.v1.CancelLikeCommentReq
+	(*CancelLikeCommentResp)(nil),    // 61: intr.v1.CancelLikeCommentResp
+	nil,                              // 62: intr.v1.BatchCheckResp.StatesEntry
+	nil,                              // 63: intr.v1.GetByIDsResp.IntersEntry
+	nil,                              // 64: intr.v1.Interaction.ReactionsEntry
 }
 var file_intr_proto_depIdxs = []int32{
-	17, // 0: intr.v1.GetByIDsResp.inters:type_name -> intr.v1.GetByIDsResp.IntersEntry
-	8,  // 1: intr.v1.GetResp.inter:type_name -> intr.v1.Interaction
-	8,  // 2: intr.v1.GetByIDsResp.IntersEntry.value:type_name -> intr.v1.Interaction
-	15, // 3: intr.v1.InteractionService.View:input_type -> intr.v1.ViewReq
-	13, // 4: intr.v1.InteractionService.Like:input_type -> intr.v1.LikeReq
-	11, // 5: intr.v1.InteractionService.CancelLike:input_type -> intr.v1.CancelLikeReq
-	9,  // 6: intr.v1.InteractionService.Favorite:input_type -> intr.v1.FavoriteReq
-	6,  // 7: intr.v1.InteractionService.Get:input_type -> intr.v1.GetReq
-	4,  // 8: intr.v1.InteractionService.Liked:input_type -> intr.v1.LikedReq
-	2,  // 9: intr.v1.InteractionService.Collected:input_type -> intr.v1.CollectedReq
-	0,  // 10: intr.v1.InteractionService.GetByIDs:input_type -> intr.v1.GetByIDsReq
-	16, // 11: intr.v1.InteractionService.View:output_type -> intr.v1.ViewResp
-	14, // 12: intr.v1.InteractionService.Like:output_type -> intr.v1.LikeResp
-	12, // 13: intr.v1.InteractionService.CancelLike:output_type -> intr.v1.CancelLikeResp
-	10, // 14: intr.v1.InteractionService.Favorite:output_type -> intr.v1.FavoriteResp
-	7,  // 15: intr.v1.InteractionService.Get:output_type -> intr.v1.GetResp
-	5,  // 16: intr.v1.InteractionService.Liked:output_type -> intr.v1.LikedResp
-	3,  // 17: intr.v1.InteractionService.Collected:output_type -> intr.v1.CollectedResp
-	1,  // 18: intr.v1.InteractionService.GetByIDs:output_type -> intr.v1.GetByIDsResp
-	11, // [11:19] is the sub-list for method output_type
-	3,  // [3:11] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	4,  // 0: intr.v1.ListLikesResp.likes:type_name -> intr.v1.UserLike
+	62, // 1: intr.v1.BatchCheckResp.states:type_name -> intr.v1.BatchCheckResp.StatesEntry
+	13, // 2: intr.v1.GetTrendResp.points:type_name -> intr.v1.TrendPoint
+	13, // 3: intr.v1.GetAggregatedTrendResp.points:type_name -> intr.v1.TrendPoint
+	63, // 4: intr.v1.GetByIDsResp.inters:type_name -> intr.v1.GetByIDsResp.IntersEntry
+	22, // 5: intr.v1.GetResp.inter:type_name -> intr.v1.Interaction
+	64, // 6: intr.v1.Interaction.reactions:type_name -> intr.v1.Interaction.ReactionsEntry
+	27, // 7: intr.v1.CreateFavoriteFolderResp.folder:type_name -> intr.v1.FavoriteFolder
+	27, // 8: intr.v1.ListFavoriteFoldersResp.folders:type_name -> intr.v1.FavoriteFolder
+	28, // 9: intr.v1.ListFavoriteItemsResp.items:type_name -> intr.v1.FavoriteItem
+	45, // 10: intr.v1.CreateCommentResp.comment:type_name -> intr.v1.Comment
+	0,  // 11: intr.v1.ListCommentsReq.sort:type_name -> intr.v1.CommentSort
+	45, // 12: intr.v1.ListCommentsResp.comments:type_name -> intr.v1.Comment
+	45, // 13: intr.v1.ListRepliesResp.replies:type_name -> intr.v1.Comment
+	7,  // 14: intr.v1.BatchCheckResp.StatesEntry.value:type_name -> intr.v1.UserState
+	22, // 15: intr.v1.GetByIDsResp.IntersEntry.value:type_name -> intr.v1.Interaction
+	43, // 16: intr.v1.InteractionService.View:input_type -> intr.v1.ViewReq
+	41, // 17: intr.v1.InteractionService.Like:input_type -> intr.v1.LikeReq
+	39, // 18: intr.v1.InteractionService.CancelLike:input_type -> intr.v1.CancelLikeReq
+	23, // 19: intr.v1.InteractionService.Favorite:input_type -> intr.v1.FavoriteReq
+	25, // 20: intr.v1.InteractionService.CancelFavorite:input_type -> intr.v1.CancelFavoriteReq
+	29, // 21: intr.v1.InteractionService.CreateFavoriteFolder:input_type -> intr.v1.CreateFavoriteFolderReq
+	31, // 22: intr.v1.InteractionService.UpdateFavoriteFolder:input_type -> intr.v1.UpdateFavoriteFolderReq
+	33, // 23: intr.v1.InteractionService.DeleteFavoriteFolder:input_type -> intr.v1.DeleteFavoriteFolderReq
+	35, // 24: intr.v1.InteractionService.ListFavoriteFolders:input_type -> intr.v1.ListFavoriteFoldersReq
+	37, // 25: intr.v1.InteractionService.ListFavoriteItems:input_type -> intr.v1.ListFavoriteItemsReq
+	20, // 26: intr.v1.InteractionService.Get:input_type -> intr.v1.GetReq
+	18, // 27: intr.v1.InteractionService.Liked:input_type -> intr.v1.LikedReq
+	16, // 28: intr.v1.InteractionService.Collected:input_type -> intr.v1.CollectedReq
+	3,  // 29: intr.v1.InteractionService.ListLikes:input_type -> intr.v1.ListLikesReq
+	6,  // 30: intr.v1.InteractionService.BatchCheck:input_type -> intr.v1.BatchCheckReq
+	14, // 31: intr.v1.InteractionService.GetByIDs:input_type -> intr.v1.GetByIDsReq
+	9,  // 32: intr.v1.InteractionService.GetTrend:input_type -> intr.v1.GetTrendReq
+	11, // 33: intr.v1.InteractionService.GetAggregatedTrend:input_type -> intr.v1.GetAggregatedTrendReq
+	1,  // 34: intr.v1.InteractionService.Delete:input_type -> intr.v1.DeleteReq
+	46, // 35: intr.v1.CommentService.CreateComment:input_type -> intr.v1.CreateCommentReq
+	48, // 36: intr.v1.CommentService.UpdateComment:input_type -> intr.v1.UpdateCommentReq
+	50, // 37: intr.v1.CommentService.DeleteComment:input_type -> intr.v1.DeleteCommentReq
+	52, // 38: intr.v1.CommentService.PinComment:input_type -> intr.v1.PinCommentReq
+	54, // 39: intr.v1.CommentService.ListComments:input_type -> intr.v1.ListCommentsReq
+	56, // 40: intr.v1.CommentService.ListReplies:input_type -> intr.v1.ListRepliesReq
+	58, // 41: intr.v1.CommentService.LikeComment:input_type -> intr.v1.LikeCommentReq
+	60, // 42: intr.v1.CommentService.CancelLikeComment:input_type -> intr.v1.CancelLikeCommentReq
+	44, // 43: intr.v1.InteractionService.View:output_type -> intr.v1.ViewResp
+	42, // 44: intr.v1.InteractionService.Like:output_type -> intr.v1.LikeResp
+	40, // 45: intr.v1.InteractionService.CancelLike:output_type -> intr.v1.CancelLikeResp
+	24, // 46: intr.v1.InteractionService.Favorite:output_type -> intr.v1.FavoriteResp
+	26, // 47: intr.v1.InteractionService.CancelFavorite:output_type -> intr.v1.CancelFavoriteResp
+	30, // 48: intr.v1.InteractionService.CreateFavoriteFolder:output_type -> intr.v1.CreateFavoriteFolderResp
+	32, // 49: intr.v1.InteractionService.UpdateFavoriteFolder:output_type -> intr.v1.UpdateFavoriteFolderResp
+	34, // 50: intr.v1.InteractionService.DeleteFavoriteFolder:output_type -> intr.v1.DeleteFavoriteFolderResp
+	36, // 51: intr.v1.InteractionService.ListFavoriteFolders:output_type -> intr.v1.ListFavoriteFoldersResp
+	38, // 52: intr.v1.InteractionService.ListFavoriteItems:output_type -> intr.v1.ListFavoriteItemsResp
+	21, // 53: intr.v1.InteractionService.Get:output_type -> intr.v1.GetResp
+	19, // 54: intr.v1.InteractionService.Liked:output_type -> intr.v1.LikedResp
+	17, // 55: intr.v1.InteractionService.Collected:output_type -> intr.v1.CollectedResp
+	5,  // 56: intr.v1.InteractionService.ListLikes:output_type -> intr.v1.ListLikesResp
+	8,  // 57: intr.v1.InteractionService.BatchCheck:output_type -> intr.v1.BatchCheckResp
+	15, // 58: intr.v1.InteractionService.GetByIDs:output_type -> intr.v1.GetByIDsResp
+	10, // 59: intr.v1.InteractionService.GetTrend:output_type -> intr.v1.GetTrendResp
+	12, // 60: intr.v1.InteractionService.GetAggregatedTrend:output_type -> intr.v1.GetAggregatedTrendResp
+	2,  // 61: intr.v1.InteractionService.Delete:output_type -> intr.v1.DeleteResp
+	47, // 62: intr.v1.CommentService.CreateComment:output_type -> intr.v1.CreateCommentResp
+	49, // 63: intr.v1.CommentService.UpdateComment:output_type -> intr.v1.UpdateCommentResp
+	51, // 64: intr.v1.CommentService.DeleteComment:output_type -> intr.v1.DeleteCommentResp
+	53, // 65: intr.v1.CommentService.PinComment:output_type -> intr.v1.PinCommentResp
+	55, // 66: intr.v1.CommentService.ListComments:output_type -> intr.v1.ListCommentsResp
+	57, // 67: intr.v1.CommentService.ListReplies:output_type -> intr.v1.ListRepliesResp
+	59, // 68: intr.v1.CommentService.LikeComment:output_type -> intr.v1.LikeCommentResp
+	61, // 69: intr.v1.CommentService.CancelLikeComment:output_type -> intr.v1.CancelLikeCommentResp
+	43, // [43:70] is the sub-list for method output_type
+	16, // [16:43] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_intr_proto_init() }
@@ -1114,13 +4155,14 @@ func file_intr_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_intr_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   18,
+			NumEnums:      1,
+			NumMessages:   64,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_intr_proto_goTypes,
 		DependencyIndexes: file_intr_proto_depIdxs,
+		EnumInfos:         file_intr_proto_enumTypes,
 		MessageInfos:      file_intr_proto_msgTypes,
 	}.Build()
 	File_intr_proto = out.File
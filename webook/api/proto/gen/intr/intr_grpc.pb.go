@@ -19,14 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	InteractionService_View_FullMethodName       = "/intr.v1.InteractionService/View"
-	InteractionService_Like_FullMethodName       = "/intr.v1.InteractionService/Like"
-	InteractionService_CancelLike_FullMethodName = "/intr.v1.InteractionService/CancelLike"
-	InteractionService_Favorite_FullMethodName   = "/intr.v1.InteractionService/Favorite"
-	InteractionService_Get_FullMethodName        = "/intr.v1.InteractionService/Get"
-	InteractionService_Liked_FullMethodName      = "/intr.v1.InteractionService/Liked"
-	InteractionService_Collected_FullMethodName  = "/intr.v1.InteractionService/Collected"
-	InteractionService_GetByIDs_FullMethodName   = "/intr.v1.InteractionService/GetByIDs"
+	InteractionService_View_FullMethodName                 = "/intr.v1.InteractionService/View"
+	InteractionService_Like_FullMethodName                 = "/intr.v1.InteractionService/Like"
+	InteractionService_CancelLike_FullMethodName           = "/intr.v1.InteractionService/CancelLike"
+	InteractionService_Favorite_FullMethodName             = "/intr.v1.InteractionService/Favorite"
+	InteractionService_CancelFavorite_FullMethodName       = "/intr.v1.InteractionService/CancelFavorite"
+	InteractionService_CreateFavoriteFolder_FullMethodName = "/intr.v1.InteractionService/CreateFavoriteFolder"
+	InteractionService_UpdateFavoriteFolder_FullMethodName = "/intr.v1.InteractionService/UpdateFavoriteFolder"
+	InteractionService_DeleteFavoriteFolder_FullMethodName = "/intr.v1.InteractionService/DeleteFavoriteFolder"
+	InteractionService_ListFavoriteFolders_FullMethodName  = "/intr.v1.InteractionService/ListFavoriteFolders"
+	InteractionService_ListFavoriteItems_FullMethodName    = "/intr.v1.InteractionService/ListFavoriteItems"
+	InteractionService_Get_FullMethodName                  = "/intr.v1.InteractionService/Get"
+	InteractionService_Liked_FullMethodName                = "/intr.v1.InteractionService/Liked"
+	InteractionService_Collected_FullMethodName            = "/intr.v1.InteractionService/Collected"
+	InteractionService_ListLikes_FullMethodName            = "/intr.v1.InteractionService/ListLikes"
+	InteractionService_BatchCheck_FullMethodName           = "/intr.v1.InteractionService/BatchCheck"
+	InteractionService_GetByIDs_FullMethodName             = "/intr.v1.InteractionService/GetByIDs"
+	InteractionService_GetTrend_FullMethodName             = "/intr.v1.InteractionService/GetTrend"
+	InteractionService_GetAggregatedTrend_FullMethodName   = "/intr.v1.InteractionService/GetAggregatedTrend"
+	InteractionService_Delete_FullMethodName               = "/intr.v1.InteractionService/Delete"
 )
 
 // InteractionServiceClient is the client API for InteractionService service.
@@ -36,14 +47,36 @@ type InteractionServiceClient interface {
 	View(ctx context.Context, in *ViewReq, opts ...grpc.CallOption) (*ViewResp, error)
 	Like(ctx context.Context, in *LikeReq, opts ...grpc.CallOption) (*LikeResp, error)
 	CancelLike(ctx context.Context, in *CancelLikeReq, opts ...grpc.CallOption) (*CancelLikeResp, error)
+	// Favorite 收藏到自己的收藏夹，已经收藏在其他收藏夹时移动到该收藏夹
 	Favorite(ctx context.Context, in *FavoriteReq, opts ...grpc.CallOption) (*FavoriteResp, error)
+	// CancelFavorite 取消收藏，没有收藏时不会返回错误
+	CancelFavorite(ctx context.Context, in *CancelFavoriteReq, opts ...grpc.CallOption) (*CancelFavoriteResp, error)
+	// 收藏夹，只有所有者可以修改；私密收藏夹只有所有者可以查看
+	CreateFavoriteFolder(ctx context.Context, in *CreateFavoriteFolderReq, opts ...grpc.CallOption) (*CreateFavoriteFolderResp, error)
+	// UpdateFavoriteFolder 修改收藏夹的名称和是否私密
+	UpdateFavoriteFolder(ctx context.Context, in *UpdateFavoriteFolderReq, opts ...grpc.CallOption) (*UpdateFavoriteFolderResp, error)
+	// DeleteFavoriteFolder 删除收藏夹和其中的收藏，资源的收藏数同时减少
+	DeleteFavoriteFolder(ctx context.Context, in *DeleteFavoriteFolderReq, opts ...grpc.CallOption) (*DeleteFavoriteFolderResp, error)
+	ListFavoriteFolders(ctx context.Context, in *ListFavoriteFoldersReq, opts ...grpc.CallOption) (*ListFavoriteFoldersResp, error)
+	// ListFavoriteItems 按照收藏时间倒序分页查询收藏夹中的收藏
+	ListFavoriteItems(ctx context.Context, in *ListFavoriteItemsReq, opts ...grpc.CallOption) (*ListFavoriteItemsResp, error)
 	// Get 查询bizID的交互数据，以及用户id（uid）对应的交互数据
 	Get(ctx context.Context, in *GetReq, opts ...grpc.CallOption) (*GetResp, error)
 	// Liked 用户是否点赞
 	Liked(ctx context.Context, in *LikedReq, opts ...grpc.CallOption) (*LikedResp, error)
 	// Collected 用户是否收藏
 	Collected(ctx context.Context, in *CollectedReq, opts ...grpc.CallOption) (*CollectedResp, error)
+	// ListLikes 按照点赞时间倒序分页查询用户点赞的资源
+	ListLikes(ctx context.Context, in *ListLikesReq, opts ...grpc.CallOption) (*ListLikesResp, error)
+	// BatchCheck 批量查询用户是否点赞、收藏了这些资源，用于渲染列表页，一次最多100个
+	BatchCheck(ctx context.Context, in *BatchCheckReq, opts ...grpc.CallOption) (*BatchCheckResp, error)
 	GetByIDs(ctx context.Context, in *GetByIDsReq, opts ...grpc.CallOption) (*GetByIDsResp, error)
+	// GetTrend 按照小时或者天查询资源在[from, to)之间每个时间桶新增的阅读数、点赞数、收藏数
+	GetTrend(ctx context.Context, in *GetTrendReq, opts ...grpc.CallOption) (*GetTrendResp, error)
+	// GetAggregatedTrend 多个资源的趋势，同一个时间桶的计数求和，用于创作者看板
+	GetAggregatedTrend(ctx context.Context, in *GetAggregatedTrendReq, opts ...grpc.CallOption) (*GetAggregatedTrendResp, error)
+	// Delete 删除资源的全部交互数据（计数、点赞、收藏记录），资源被彻底删除时调用，重复调用是安全的
+	Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*DeleteResp, error)
 }
 
 type interactionServiceClient struct {
@@ -94,6 +127,66 @@ func (c *interactionServiceClient) Favorite(ctx context.Context, in *FavoriteReq
 	return out, nil
 }
 
+func (c *interactionServiceClient) CancelFavorite(ctx context.Context, in *CancelFavoriteReq, opts ...grpc.CallOption) (*CancelFavoriteResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelFavoriteResp)
+	err := c.cc.Invoke(ctx, InteractionService_CancelFavorite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) CreateFavoriteFolder(ctx context.Context, in *CreateFavoriteFolderReq, opts ...grpc.CallOption) (*CreateFavoriteFolderResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFavoriteFolderResp)
+	err := c.cc.Invoke(ctx, InteractionService_CreateFavoriteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) UpdateFavoriteFolder(ctx context.Context, in *UpdateFavoriteFolderReq, opts ...grpc.CallOption) (*UpdateFavoriteFolderResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateFavoriteFolderResp)
+	err := c.cc.Invoke(ctx, InteractionService_UpdateFavoriteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) DeleteFavoriteFolder(ctx context.Context, in *DeleteFavoriteFolderReq, opts ...grpc.CallOption) (*DeleteFavoriteFolderResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFavoriteFolderResp)
+	err := c.cc.Invoke(ctx, InteractionService_DeleteFavoriteFolder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) ListFavoriteFolders(ctx context.Context, in *ListFavoriteFoldersReq, opts ...grpc.CallOption) (*ListFavoriteFoldersResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFavoriteFoldersResp)
+	err := c.cc.Invoke(ctx, InteractionService_ListFavoriteFolders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) ListFavoriteItems(ctx context.Context, in *ListFavoriteItemsReq, opts ...grpc.CallOption) (*ListFavoriteItemsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFavoriteItemsResp)
+	err := c.cc.Invoke(ctx, InteractionService_ListFavoriteItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *interactionServiceClient) Get(ctx context.Context, in *GetReq, opts ...grpc.CallOption) (*GetResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetResp)
@@ -124,6 +217,26 @@ func (c *interactionServiceClient) Collected(ctx context.Context, in *CollectedR
 	return out, nil
 }
 
+func (c *interactionServiceClient) ListLikes(ctx context.Context, in *ListLikesReq, opts ...grpc.CallOption) (*ListLikesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLikesResp)
+	err := c.cc.Invoke(ctx, InteractionService_ListLikes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) BatchCheck(ctx context.Context, in *BatchCheckReq, opts ...grpc.CallOption) (*BatchCheckResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCheckResp)
+	err := c.cc.Invoke(ctx, InteractionService_BatchCheck_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *interactionServiceClient) GetByIDs(ctx context.Context, in *GetByIDsReq, opts ...grpc.CallOption) (*GetByIDsResp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetByIDsResp)
@@ -134,6 +247,36 @@ func (c *interactionServiceClient) GetByIDs(ctx context.Context, in *GetByIDsReq
 	return out, nil
 }
 
+func (c *interactionServiceClient) GetTrend(ctx context.Context, in *GetTrendReq, opts ...grpc.CallOption) (*GetTrendResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTrendResp)
+	err := c.cc.Invoke(ctx, InteractionService_GetTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) GetAggregatedTrend(ctx context.Context, in *GetAggregatedTrendReq, opts ...grpc.CallOption) (*GetAggregatedTrendResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAggregatedTrendResp)
+	err := c.cc.Invoke(ctx, InteractionService_GetAggregatedTrend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactionServiceClient) Delete(ctx context.Context, in *DeleteReq, opts ...grpc.CallOption) (*DeleteResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteResp)
+	err := c.cc.Invoke(ctx, InteractionService_Delete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InteractionServiceServer is the server API for InteractionService service.
 // All implementations must embed UnimplementedInteractionServiceServer
 // for forward compatibility.
@@ -141,14 +284,36 @@ type InteractionServiceServer interface {
 	View(context.Context, *ViewReq) (*ViewResp, error)
 	Like(context.Context, *LikeReq) (*LikeResp, error)
 	CancelLike(context.Context, *CancelLikeReq) (*CancelLikeResp, error)
+	// Favorite 收藏到自己的收藏夹，已经收藏在其他收藏夹时移动到该收藏夹
 	Favorite(context.Context, *FavoriteReq) (*FavoriteResp, error)
+	// CancelFavorite 取消收藏，没有收藏时不会返回错误
+	CancelFavorite(context.Context, *CancelFavoriteReq) (*CancelFavoriteResp, error)
+	// 收藏夹，只有所有者可以修改；私密收藏夹只有所有者可以查看
+	CreateFavoriteFolder(context.Context, *CreateFavoriteFolderReq) (*CreateFavoriteFolderResp, error)
+	// UpdateFavoriteFolder 修改收藏夹的名称和是否私密
+	UpdateFavoriteFolder(context.Context, *UpdateFavoriteFolderReq) (*UpdateFavoriteFolderResp, error)
+	// DeleteFavoriteFolder 删除收藏夹和其中的收藏，资源的收藏数同时减少
+	DeleteFavoriteFolder(context.Context, *DeleteFavoriteFolderReq) (*DeleteFavoriteFolderResp, error)
+	ListFavoriteFolders(context.Context, *ListFavoriteFoldersReq) (*ListFavoriteFoldersResp, error)
+	// ListFavoriteItems 按照收藏时间倒序分页查询收藏夹中的收藏
+	ListFavoriteItems(context.Context, *ListFavoriteItemsReq) (*ListFavoriteItemsResp, error)
 	// Get 查询bizID的交互数据，以及用户id（uid）对应的交互数据
 	Get(context.Context, *GetReq) (*GetResp, error)
 	// Liked 用户是否点赞
 	Liked(context.Context, *LikedReq) (*LikedResp, error)
 	// Collected 用户是否收藏
 	Collected(context.Context, *CollectedReq) (*CollectedResp, error)
+	// ListLikes 按照点赞时间倒序分页查询用户点赞的资源
+	ListLikes(context.Context, *ListLikesReq) (*ListLikesResp, error)
+	// BatchCheck 批量查询用户是否点赞、收藏了这些资源，用于渲染列表页，一次最多100个
+	BatchCheck(context.Context, *BatchCheckReq) (*BatchCheckResp, error)
 	GetByIDs(context.Context, *GetByIDsReq) (*GetByIDsResp, error)
+	// GetTrend 按照小时或者天查询资源在[from, to)之间每个时间桶新增的阅读数、点赞数、收藏数
+	GetTrend(context.Context, *GetTrendReq) (*GetTrendResp, error)
+	// GetAggregatedTrend 多个资源的趋势，同一个时间桶的计数求和，用于创作者看板
+	GetAggregatedTrend(context.Context, *GetAggregatedTrendReq) (*GetAggregatedTrendResp, error)
+	// Delete 删除资源的全部交互数据（计数、点赞、收藏记录），资源被彻底删除时调用，重复调用是安全的
+	Delete(context.Context, *DeleteReq) (*DeleteResp, error)
 	mustEmbedUnimplementedInteractionServiceServer()
 }
 
@@ -171,6 +336,24 @@ func (UnimplementedInteractionServiceServer) CancelLike(context.Context, *Cancel
 func (UnimplementedInteractionServiceServer) Favorite(context.Context, *FavoriteReq) (*FavoriteResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Favorite not implemented")
 }
+func (UnimplementedInteractionServiceServer) CancelFavorite(context.Context, *CancelFavoriteReq) (*CancelFavoriteResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelFavorite not implemented")
+}
+func (UnimplementedInteractionServiceServer) CreateFavoriteFolder(context.Context, *CreateFavoriteFolderReq) (*CreateFavoriteFolderResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFavoriteFolder not implemented")
+}
+func (UnimplementedInteractionServiceServer) UpdateFavoriteFolder(context.Context, *UpdateFavoriteFolderReq) (*UpdateFavoriteFolderResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateFavoriteFolder not implemented")
+}
+func (UnimplementedInteractionServiceServer) DeleteFavoriteFolder(context.Context, *DeleteFavoriteFolderReq) (*DeleteFavoriteFolderResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFavoriteFolder not implemented")
+}
+func (UnimplementedInteractionServiceServer) ListFavoriteFolders(context.Context, *ListFavoriteFoldersReq) (*ListFavoriteFoldersResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavoriteFolders not implemented")
+}
+func (UnimplementedInteractionServiceServer) ListFavoriteItems(context.Context, *ListFavoriteItemsReq) (*ListFavoriteItemsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFavoriteItems not implemented")
+}
 func (UnimplementedInteractionServiceServer) Get(context.Context, *GetReq) (*GetResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
@@ -180,9 +363,24 @@ func (UnimplementedInteractionServiceServer) Liked(context.Context, *LikedReq) (
 func (UnimplementedInteractionServiceServer) Collected(context.Context, *CollectedReq) (*CollectedResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Collected not implemented")
 }
+func (UnimplementedInteractionServiceServer) ListLikes(context.Context, *ListLikesReq) (*ListLikesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLikes not implemented")
+}
+func (UnimplementedInteractionServiceServer) BatchCheck(context.Context, *BatchCheckReq) (*BatchCheckResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCheck not implemented")
+}
 func (UnimplementedInteractionServiceServer) GetByIDs(context.Context, *GetByIDsReq) (*GetByIDsResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetByIDs not implemented")
 }
+func (UnimplementedInteractionServiceServer) GetTrend(context.Context, *GetTrendReq) (*GetTrendResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrend not implemented")
+}
+func (UnimplementedInteractionServiceServer) GetAggregatedTrend(context.Context, *GetAggregatedTrendReq) (*GetAggregatedTrendResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAggregatedTrend not implemented")
+}
+func (UnimplementedInteractionServiceServer) Delete(context.Context, *DeleteReq) (*DeleteResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
 func (UnimplementedInteractionServiceServer) mustEmbedUnimplementedInteractionServiceServer() {}
 func (UnimplementedInteractionServiceServer) testEmbeddedByValue()                            {}
 
@@ -276,6 +474,114 @@ func _InteractionService_Favorite_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractionService_CancelFavorite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelFavoriteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).CancelFavorite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_CancelFavorite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).CancelFavorite(ctx, req.(*CancelFavoriteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_CreateFavoriteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFavoriteFolderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).CreateFavoriteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_CreateFavoriteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).CreateFavoriteFolder(ctx, req.(*CreateFavoriteFolderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_UpdateFavoriteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateFavoriteFolderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).UpdateFavoriteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_UpdateFavoriteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).UpdateFavoriteFolder(ctx, req.(*UpdateFavoriteFolderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_DeleteFavoriteFolder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFavoriteFolderReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).DeleteFavoriteFolder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_DeleteFavoriteFolder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).DeleteFavoriteFolder(ctx, req.(*DeleteFavoriteFolderReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_ListFavoriteFolders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoriteFoldersReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).ListFavoriteFolders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_ListFavoriteFolders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).ListFavoriteFolders(ctx, req.(*ListFavoriteFoldersReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_ListFavoriteItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFavoriteItemsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).ListFavoriteItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_ListFavoriteItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).ListFavoriteItems(ctx, req.(*ListFavoriteItemsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InteractionService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetReq)
 	if err := dec(in); err != nil {
@@ -330,6 +636,42 @@ func _InteractionService_Collected_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractionService_ListLikes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLikesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).ListLikes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_ListLikes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).ListLikes(ctx, req.(*ListLikesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_BatchCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCheckReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).BatchCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_BatchCheck_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).BatchCheck(ctx, req.(*BatchCheckReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InteractionService_GetByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetByIDsReq)
 	if err := dec(in); err != nil {
@@ -348,6 +690,60 @@ func _InteractionService_GetByIDs_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InteractionService_GetTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).GetTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_GetTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).GetTrend(ctx, req.(*GetTrendReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_GetAggregatedTrend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAggregatedTrendReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).GetAggregatedTrend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_GetAggregatedTrend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).GetAggregatedTrend(ctx, req.(*GetAggregatedTrendReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractionService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractionServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractionService_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractionServiceServer).Delete(ctx, req.(*DeleteReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InteractionService_ServiceDesc is the grpc.ServiceDesc for InteractionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -371,6 +767,30 @@ var InteractionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Favorite",
 			Handler:    _InteractionService_Favorite_Handler,
 		},
+		{
+			MethodName: "CancelFavorite",
+			Handler:    _InteractionService_CancelFavorite_Handler,
+		},
+		{
+			MethodName: "CreateFavoriteFolder",
+			Handler:    _InteractionService_CreateFavoriteFolder_Handler,
+		},
+		{
+			MethodName: "UpdateFavoriteFolder",
+			Handler:    _InteractionService_UpdateFavoriteFolder_Handler,
+		},
+		{
+			MethodName: "DeleteFavoriteFolder",
+			Handler:    _InteractionService_DeleteFavoriteFolder_Handler,
+		},
+		{
+			MethodName: "ListFavoriteFolders",
+			Handler:    _InteractionService_ListFavoriteFolders_Handler,
+		},
+		{
+			MethodName: "ListFavoriteItems",
+			Handler:    _InteractionService_ListFavoriteItems_Handler,
+		},
 		{
 			MethodName: "Get",
 			Handler:    _InteractionService_Get_Handler,
@@ -383,10 +803,414 @@ var InteractionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Collected",
 			Handler:    _InteractionService_Collected_Handler,
 		},
+		{
+			MethodName: "ListLikes",
+			Handler:    _InteractionService_ListLikes_Handler,
+		},
+		{
+			MethodName: "BatchCheck",
+			Handler:    _InteractionService_BatchCheck_Handler,
+		},
 		{
 			MethodName: "GetByIDs",
 			Handler:    _InteractionService_GetByIDs_Handler,
 		},
+		{
+			MethodName: "GetTrend",
+			Handler:    _InteractionService_GetTrend_Handler,
+		},
+		{
+			MethodName: "GetAggregatedTrend",
+			Handler:    _InteractionService_GetAggregatedTrend_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _InteractionService_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "intr.proto",
+}
+
+const (
+	CommentService_CreateComment_FullMethodName     = "/intr.v1.CommentService/CreateComment"
+	CommentService_UpdateComment_FullMethodName     = "/intr.v1.CommentService/UpdateComment"
+	CommentService_DeleteComment_FullMethodName     = "/intr.v1.CommentService/DeleteComment"
+	CommentService_PinComment_FullMethodName        = "/intr.v1.CommentService/PinComment"
+	CommentService_ListComments_FullMethodName      = "/intr.v1.CommentService/ListComments"
+	CommentService_ListReplies_FullMethodName       = "/intr.v1.CommentService/ListReplies"
+	CommentService_LikeComment_FullMethodName       = "/intr.v1.CommentService/LikeComment"
+	CommentService_CancelLikeComment_FullMethodName = "/intr.v1.CommentService/CancelLikeComment"
+)
+
+// CommentServiceClient is the client API for CommentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// CommentService 资源的评论。评论只有两层：直接评论资源的是根评论，回复都挂在根评论下面，
+// parent_id记录被回复的评论。资源的所有者置顶评论时，由调用方校验所有者的身份。
+type CommentServiceClient interface {
+	CreateComment(ctx context.Context, in *CreateCommentReq, opts ...grpc.CallOption) (*CreateCommentResp, error)
+	// UpdateComment 只有评论的作者可以修改
+	UpdateComment(ctx context.Context, in *UpdateCommentReq, opts ...grpc.CallOption) (*UpdateCommentResp, error)
+	// DeleteComment 只有评论的作者可以删除，删除根评论时同时删除它的回复
+	DeleteComment(ctx context.Context, in *DeleteCommentReq, opts ...grpc.CallOption) (*DeleteCommentResp, error)
+	// PinComment 置顶或者取消置顶根评论，一个资源只有一条置顶评论
+	PinComment(ctx context.Context, in *PinCommentReq, opts ...grpc.CallOption) (*PinCommentResp, error)
+	// ListComments 查询资源的根评论，第一页的第一条是置顶评论
+	ListComments(ctx context.Context, in *ListCommentsReq, opts ...grpc.CallOption) (*ListCommentsResp, error)
+	// ListReplies 按照时间顺序查询根评论的回复
+	ListReplies(ctx context.Context, in *ListRepliesReq, opts ...grpc.CallOption) (*ListRepliesResp, error)
+	LikeComment(ctx context.Context, in *LikeCommentReq, opts ...grpc.CallOption) (*LikeCommentResp, error)
+	CancelLikeComment(ctx context.Context, in *CancelLikeCommentReq, opts ...grpc.CallOption) (*CancelLikeCommentResp, error)
+}
+
+type commentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCommentServiceClient(cc grpc.ClientConnInterface) CommentServiceClient {
+	return &commentServiceClient{cc}
+}
+
+func (c *commentServiceClient) CreateComment(ctx context.Context, in *CreateCommentReq, opts ...grpc.CallOption) (*CreateCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_CreateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) UpdateComment(ctx context.Context, in *UpdateCommentReq, opts ...grpc.CallOption) (*UpdateCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_UpdateComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentReq, opts ...grpc.CallOption) (*DeleteCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_DeleteComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) PinComment(ctx context.Context, in *PinCommentReq, opts ...grpc.CallOption) (*PinCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_PinComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ListComments(ctx context.Context, in *ListCommentsReq, opts ...grpc.CallOption) (*ListCommentsResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCommentsResp)
+	err := c.cc.Invoke(ctx, CommentService_ListComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) ListReplies(ctx context.Context, in *ListRepliesReq, opts ...grpc.CallOption) (*ListRepliesResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRepliesResp)
+	err := c.cc.Invoke(ctx, CommentService_ListReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) LikeComment(ctx context.Context, in *LikeCommentReq, opts ...grpc.CallOption) (*LikeCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LikeCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_LikeComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commentServiceClient) CancelLikeComment(ctx context.Context, in *CancelLikeCommentReq, opts ...grpc.CallOption) (*CancelLikeCommentResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelLikeCommentResp)
+	err := c.cc.Invoke(ctx, CommentService_CancelLikeComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CommentServiceServer is the server API for CommentService service.
+// All implementations must embed UnimplementedCommentServiceServer
+// for forward compatibility.
+//
+// CommentService 资源的评论。评论只有两层：直接评论资源的是根评论，回复都挂在根评论下面，
+// parent_id记录被回复的评论。资源的所有者置顶评论时，由调用方校验所有者的身份。
+type CommentServiceServer interface {
+	CreateComment(context.Context, *CreateCommentReq) (*CreateCommentResp, error)
+	// UpdateComment 只有评论的作者可以修改
+	UpdateComment(context.Context, *UpdateCommentReq) (*UpdateCommentResp, error)
+	// DeleteComment 只有评论的作者可以删除，删除根评论时同时删除它的回复
+	DeleteComment(context.Context, *DeleteCommentReq) (*DeleteCommentResp, error)
+	// PinComment 置顶或者取消置顶根评论，一个资源只有一条置顶评论
+	PinComment(context.Context, *PinCommentReq) (*PinCommentResp, error)
+	// ListComments 查询资源的根评论，第一页的第一条是置顶评论
+	ListComments(context.Context, *ListCommentsReq) (*ListCommentsResp, error)
+	// ListReplies 按照时间顺序查询根评论的回复
+	ListReplies(context.Context, *ListRepliesReq) (*ListRepliesResp, error)
+	LikeComment(context.Context, *LikeCommentReq) (*LikeCommentResp, error)
+	CancelLikeComment(context.Context, *CancelLikeCommentReq) (*CancelLikeCommentResp, error)
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+// UnimplementedCommentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCommentServiceServer struct{}
+
+func (UnimplementedCommentServiceServer) CreateComment(context.Context, *CreateCommentReq) (*CreateCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedCommentServiceServer) UpdateComment(context.Context, *UpdateCommentReq) (*UpdateCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedCommentServiceServer) DeleteComment(context.Context, *DeleteCommentReq) (*DeleteCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedCommentServiceServer) PinComment(context.Context, *PinCommentReq) (*PinCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinComment not implemented")
+}
+func (UnimplementedCommentServiceServer) ListComments(context.Context, *ListCommentsReq) (*ListCommentsResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedCommentServiceServer) ListReplies(context.Context, *ListRepliesReq) (*ListRepliesResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReplies not implemented")
+}
+func (UnimplementedCommentServiceServer) LikeComment(context.Context, *LikeCommentReq) (*LikeCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeComment not implemented")
+}
+func (UnimplementedCommentServiceServer) CancelLikeComment(context.Context, *CancelLikeCommentReq) (*CancelLikeCommentResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelLikeComment not implemented")
+}
+func (UnimplementedCommentServiceServer) mustEmbedUnimplementedCommentServiceServer() {}
+func (UnimplementedCommentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeCommentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CommentServiceServer will
+// result in compilation errors.
+type UnsafeCommentServiceServer interface {
+	mustEmbedUnimplementedCommentServiceServer()
+}
+
+func RegisterCommentServiceServer(s grpc.ServiceRegistrar, srv CommentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedCommentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&CommentService_ServiceDesc, srv)
+}
+
+func _CommentService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_CreateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).CreateComment(ctx, req.(*CreateCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_UpdateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).UpdateComment(ctx, req.(*UpdateCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).DeleteComment(ctx, req.(*DeleteCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_PinComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).PinComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_PinComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).PinComment(ctx, req.(*PinCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ListComments(ctx, req.(*ListCommentsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_ListReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRepliesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).ListReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_ListReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).ListReplies(ctx, req.(*ListRepliesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_LikeComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).LikeComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_LikeComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).LikeComment(ctx, req.(*LikeCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommentService_CancelLikeComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelLikeCommentReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommentServiceServer).CancelLikeComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommentService_CancelLikeComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommentServiceServer).CancelLikeComment(ctx, req.(*CancelLikeCommentReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CommentService_ServiceDesc is the grpc.ServiceDesc for CommentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CommentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "intr.v1.CommentService",
+	HandlerType: (*CommentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateComment",
+			Handler:    _CommentService_CreateComment_Handler,
+		},
+		{
+			MethodName: "UpdateComment",
+			Handler:    _CommentService_UpdateComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _CommentService_DeleteComment_Handler,
+		},
+		{
+			MethodName: "PinComment",
+			Handler:    _CommentService_PinComment_Handler,
+		},
+		{
+			MethodName: "ListComments",
+			Handler:    _CommentService_ListComments_Handler,
+		},
+		{
+			MethodName: "ListReplies",
+			Handler:    _CommentService_ListReplies_Handler,
+		},
+		{
+			MethodName: "LikeComment",
+			Handler:    _CommentService_LikeComment_Handler,
+		},
+		{
+			MethodName: "CancelLikeComment",
+			Handler:    _CommentService_CancelLikeComment_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "intr.proto",
@@ -3,12 +3,16 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/robfig/cron/v3"
+	"learn_go/webook/internal/job"
+	"learn_go/webook/pkg/saramax"
 )
 
 type App struct {
 	server *gin.Engine
-	//// 消费者服务
-	//consumers []saramax.Consumer
+	// 消费者服务
+	consumers []saramax.Consumer
 	// cron
 	cron *cron.Cron
+	// 基于mysql抢占的分布式任务调度
+	scheduler *job.Scheduler
 }
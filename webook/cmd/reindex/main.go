@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"log"
+	"time"
+)
+
+/*
+reindex 从线上库（PublishArticle）重建文章的搜索索引。
+
+	go run ./cmd/reindex --config=./config/dev.yaml
+
+已发布的文章会写入索引，已撤回的文章会从索引中删除。重建期间可以正常提供搜索服务，
+消费者同步的数据和重建写入的数据都以线上库为准，重复写入不影响结果。
+*/
+
+func main() {
+	configFile := pflag.String("config", "./config/dev.yaml", "配置文件路径")
+	timeout := pflag.Duration("timeout", time.Hour, "重建的超时时间")
+	pflag.Parse()
+	viper.SetConfigFile(*configFile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(fmt.Errorf("fatal error config file: %w", err))
+	}
+
+	svc := InitSearchService()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	start := time.Now()
+	err = svc.Reindex(ctx)
+	if err != nil {
+		log.Fatalf("重建搜索索引失败: %v", err)
+	}
+	log.Printf("重建搜索索引完成，耗时: %v", time.Since(start))
+}
@@ -0,0 +1,44 @@
+//go:build wireinject
+// +build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/internal/repository/cache"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/internal/repository/search"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/ioc"
+)
+
+var thirdPartySet = wire.NewSet(
+	ioc.NewLogger,
+	ioc.NewDB,
+	ioc.NewRedis,
+)
+
+var articleSet = wire.NewSet(
+	article.NewArticleRepository,
+	dao.NewArticleDao,
+	cache.NewArticleCache,
+
+	repository.NewUserRepository,
+	dao.NewUserDao,
+	cache.NewUserCache,
+)
+
+// 离线重建只针对mysql索引，进程内的索引由各个实例启动时自行重建
+var searchSet = wire.NewSet(
+	service.NewSearchService,
+	search.NewMySQLArticleIndex,
+	wire.Bind(new(search.ArticleIndex), new(*search.MySQLArticleIndex)),
+	dao.NewArticleSearchDao,
+)
+
+func InitSearchService() service.SearchService {
+	wire.Build(thirdPartySet, articleSet, searchSet)
+	return nil
+}
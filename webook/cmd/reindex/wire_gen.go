@@ -0,0 +1,45 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/internal/repository/cache"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/internal/repository/search"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/ioc"
+)
+
+// Injectors from wire.go:
+
+func InitSearchService() service.SearchService {
+	loggerV2 := ioc.NewLogger()
+	db := ioc.NewDB(loggerV2)
+	articleSearchDao := dao.NewArticleSearchDao(db)
+	mySQLArticleIndex := search.NewMySQLArticleIndex(articleSearchDao)
+	articleDao := dao.NewArticleDao(db)
+	cmdable := ioc.NewRedis(loggerV2)
+	articleCache := cache.NewArticleCache(cmdable)
+	userDao := dao.NewUserDao(db)
+	userCache := cache.NewUserCache(cmdable)
+	userRepository := repository.NewUserRepository(userDao, userCache)
+	articleRepository := article.NewArticleRepository(articleDao, articleCache, userRepository, loggerV2)
+	searchService := service.NewSearchService(mySQLArticleIndex, articleRepository, loggerV2)
+	return searchService
+}
+
+// wire.go:
+
+var thirdPartySet = wire.NewSet(ioc.NewLogger, ioc.NewDB, ioc.NewRedis)
+
+var articleSet = wire.NewSet(article.NewArticleRepository, dao.NewArticleDao, cache.NewArticleCache, repository.NewUserRepository, dao.NewUserDao, cache.NewUserCache)
+
+// 离线重建只针对mysql索引，进程内的索引由各个实例启动时自行重建
+var searchSet = wire.NewSet(service.NewSearchService, search.NewMySQLArticleIndex, wire.Bind(new(search.ArticleIndex), new(*search.MySQLArticleIndex)), dao.NewArticleSearchDao)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/robfig/cron/v3"
 	"learn_go/webook/pkg/grpcx"
 	"learn_go/webook/pkg/saramax"
 )
@@ -10,4 +11,7 @@ type App struct {
 	consumers []saramax.Consumer
 
 	server *grpcx.Server
+
+	// 计数写回、对账任务
+	cron *cron.Cron
 }
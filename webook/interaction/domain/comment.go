@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// MaxCommentLen 评论内容的最大长度（rune）
+const MaxCommentLen = 1000
+
+// CommentSort 根评论的排序方式
+type CommentSort int8
+
+const (
+	// CommentSortTime 按照时间倒序
+	CommentSortTime CommentSort = iota
+	// CommentSortPopular 按照点赞数、回复数倒序
+	CommentSortPopular
+)
+
+// Comment 资源的评论。评论只有两层：直接评论资源的是根评论，回复都挂在根评论下面。
+type Comment struct {
+	ID    int64
+	Uid   int64
+	Biz   string
+	BizID int64
+
+	// RootID 根评论为0
+	RootID int64
+	// ParentID 被回复的评论，根评论为0；ReplyToUid是被回复的评论的作者
+	ParentID   int64
+	ReplyToUid int64
+
+	Content string
+	Likes   int64
+	// Replies 根评论的回复数
+	Replies int64
+	// Pinned 资源的所有者置顶的根评论
+	Pinned bool
+	// Liked 查询的用户是否点赞
+	Liked bool
+
+	CTime time.Time
+	UTime time.Time
+}
+
+func (c Comment) IsRoot() bool {
+	return c.RootID == 0
+}
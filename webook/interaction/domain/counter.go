@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// CounterDelta 写回缓冲区中一个资源还没有写入数据库的计数增量
+type CounterDelta struct {
+	Biz   string
+	BizID int64
+	// Bucket 增量所在小时桶的开始时间，零值说明没有记录时间，按照写入数据库的时间统计
+	Bucket time.Time
+
+	Views       int64
+	UniqueViews int64
+	Likes       int64
+	// Reactions 每种表态数量的增量，切换表态时一个减一个加
+	Reactions map[Reaction]int64
+}
+
+// NewReactionDelta 用户的表态从from变成to时计数的增量，ReactionNone表示没有表态。
+// 旧表态-1、新表态+1，从没有表态变成有表态（或者反过来）时点赞总数也会变化
+func NewReactionDelta(biz string, bizID int64, from, to Reaction) CounterDelta {
+	d := CounterDelta{Biz: biz, BizID: bizID}
+	if from == to {
+		return d
+	}
+	d.Reactions = make(map[Reaction]int64, 2)
+	if from == ReactionNone {
+		d.Likes++
+	} else {
+		d.Reactions[from]--
+	}
+	if to == ReactionNone {
+		d.Likes--
+	} else {
+		d.Reactions[to]++
+	}
+	return d
+}
+
+func (d CounterDelta) IsZero() bool {
+	for _, delta := range d.Reactions {
+		if delta != 0 {
+			return false
+		}
+	}
+	return d.Views == 0 && d.UniqueViews == 0 && d.Likes == 0
+}
+
+// CounterBatch 从写回缓冲区中取出的一批增量。
+// 数据库中记录了已经写入的批次ID，进程崩溃后重放同一个批次不会重复计数
+type CounterBatch struct {
+	ID     string
+	Deltas []CounterDelta
+}
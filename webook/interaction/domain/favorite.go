@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+const (
+	// MaxFavoriteFolders 一个用户最多创建的收藏夹数量
+	MaxFavoriteFolders = 100
+	// MaxFavoriteFolderNameLen 收藏夹名称的最大长度（rune）
+	MaxFavoriteFolderNameLen = 64
+)
+
+// FavoriteFolder 收藏夹，收藏的资源（UserFavorite）必须放在收藏夹中
+type FavoriteFolder struct {
+	ID   int64
+	Uid  int64
+	Name string
+	// Private 私密收藏夹只有所有者可以查看
+	Private bool
+	// Items 收藏夹中的收藏数
+	Items int64
+
+	CTime time.Time
+	UTime time.Time
+}
+
+// VisibleTo uid是否可以查看该收藏夹
+func (f FavoriteFolder) VisibleTo(uid int64) bool {
+	return !f.Private || f.Uid == uid
+}
@@ -11,21 +11,32 @@ type Interaction struct {
 	UTime time.Time `json:"u_time"`
 	CTime time.Time `json:"c_time"`
 
-	Views     int64
-	Likes     int64
+	Views int64
+	// UniqueViews 去重后的阅读数，同一个用户每天最多计一次
+	UniqueViews int64
+	// Likes 所有表态的总数
+	Likes int64
+	// Reactions 每种表态的数量
+	Reactions map[Reaction]int64
 	Favorites int64
+	// Comments 评论数，包括回复
+	Comments int64
 
+	// Liked 用户是否有表态，Reaction是用户当前的表态
 	Liked     bool
+	Reaction  Reaction
 	Collected bool
 }
 
-// UserLike 用户点赞对象
+// UserLike 用户点赞对象，点赞泛化成了表态
 type UserLike struct {
 	ID int64
 
 	Uid   int64
 	Biz   string
 	BizID int64
+	// Reaction 用户当前的表态
+	Reaction Reaction
 
 	UTime time.Time `json:"u_time"`
 	CTime time.Time `json:"c_time"`
@@ -53,3 +64,20 @@ type UserFavorite struct {
 func (f UserFavorite) Collected() bool {
 	return f.Uid > 0 && f.BizID > 0
 }
+
+// View 用户的一次阅读
+type View struct {
+	Uid   int64
+	Biz   string
+	BizID int64
+	Time  time.Time
+}
+
+// ViewResult 阅读去重的结果
+type ViewResult struct {
+	View
+	// Counted 是否计入阅读数，去重窗口内的重复阅读不计入
+	Counted bool
+	// Unique 是否是资源当天的新读者，计入去重后的阅读数
+	Unique bool
+}
@@ -0,0 +1,52 @@
+package domain
+
+// Reaction 用户对资源的表态，一个用户对一个资源同时只有一个表态。
+// 原来的点赞就是ReactionLike，Interaction.Likes是所有表态的总数
+type Reaction uint8
+
+const (
+	ReactionNone Reaction = iota
+	ReactionLike
+	ReactionLove
+	ReactionInsightful
+	ReactionFunny
+)
+
+// DefaultReaction 只有点赞的旧接口映射到的表态
+const DefaultReaction = ReactionLike
+
+// Reactions 所有的表态，按照展示的顺序
+var Reactions = []Reaction{ReactionLike, ReactionLove, ReactionInsightful, ReactionFunny}
+
+var reactionNames = map[Reaction]string{
+	ReactionLike:       "like",
+	ReactionLove:       "love",
+	ReactionInsightful: "insightful",
+	ReactionFunny:      "funny",
+}
+
+// ParseReaction 空字符串映射到DefaultReaction，兼容只有点赞的接口
+func ParseReaction(name string) (Reaction, bool) {
+	if name == "" {
+		return DefaultReaction, true
+	}
+	for r, n := range reactionNames {
+		if n == name {
+			return r, true
+		}
+	}
+	return ReactionNone, false
+}
+
+func (r Reaction) Valid() bool {
+	return r >= ReactionLike && r <= ReactionFunny
+}
+
+func (r Reaction) ToUint8() uint8 {
+	return uint8(r)
+}
+
+// String ReactionNone返回空字符串
+func (r Reaction) String() string {
+	return reactionNames[r]
+}
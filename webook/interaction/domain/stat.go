@@ -0,0 +1,60 @@
+package domain
+
+import "time"
+
+// StatGranularity 时间桶的粒度
+type StatGranularity string
+
+const (
+	StatHour StatGranularity = "hour"
+	StatDay  StatGranularity = "day"
+)
+
+const (
+	// HourStatRetention 小时桶的保留时间，更早的数据只能按天查询
+	HourStatRetention = time.Hour * 24 * 7
+	// MaxTrendPoints 一次查询最多返回的时间桶数
+	MaxTrendPoints = 400
+)
+
+func (g StatGranularity) Valid() bool {
+	return g == StatHour || g == StatDay
+}
+
+// Truncate 返回t所在时间桶的开始时间，天桶按照本地时区划分
+func (g StatGranularity) Truncate(t time.Time) time.Time {
+	if g == StatHour {
+		return t.Truncate(time.Hour)
+	}
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Next 返回下一个时间桶的开始时间，bucket必须是桶的开始时间
+func (g StatGranularity) Next(bucket time.Time) time.Time {
+	if g == StatHour {
+		return bucket.Add(time.Hour)
+	}
+	return bucket.AddDate(0, 0, 1)
+}
+
+// InteractionStat 资源在一个时间桶内新增的阅读数、点赞数、收藏数，取消点赞、收藏时可能是负数
+type InteractionStat struct {
+	Biz         string
+	BizID       int64
+	Granularity StatGranularity
+	Bucket      time.Time
+
+	Views     int64
+	Likes     int64
+	Favorites int64
+}
+
+// TrendPoint 趋势中的一个时间桶，多个资源的趋势是同一个时间桶的和
+type TrendPoint struct {
+	Time time.Time
+
+	Views     int64
+	Likes     int64
+	Favorites int64
+}
@@ -3,10 +3,13 @@ package article
 import (
 	"context"
 	"github.com/IBM/sarama"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
 	"learn_go/webook/interaction/event"
 	"learn_go/webook/interaction/repository"
 	"learn_go/webook/pkg/logger"
 	"learn_go/webook/pkg/saramax"
+	"time"
 )
 
 // BatchReadEventConsumer 消费者：消费生产者投递的各种事件（消息）
@@ -42,17 +45,51 @@ func (c *BatchReadEventConsumer) Start() error {
 	return nil
 }
 
-// Consume 消费文章读取时间
+// Consume 消费文章读取事件，先去重再累加阅读数和去重后的阅读数
 func (c *BatchReadEventConsumer) Consume(messages []*sarama.ConsumerMessage, events []event.ReadEvent) error {
 	//ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	//defer cancel()
 	ctx := context.Background()
 
-	bizs := make([]string, 0, len(events))
-	bizIDs := make([]int64, 0, len(events))
+	now := time.Now()
+	views := make([]domain.View, 0, len(events))
 	for _, evt := range events {
-		bizs = append(bizs, "article")
-		bizIDs = append(bizIDs, evt.ArticleID)
+		views = append(views, toView(evt, now))
 	}
-	return c.interactionRepo.BatchIncrReadCnt(ctx, bizs, bizIDs)
+	return incrViews(ctx, c.interactionRepo, c.l, views)
+}
+
+// toView 按照阅读发生的时间统计，消息积压时不会算到消费的那一天。旧的消息中没有时间，使用now
+func toView(evt event.ReadEvent, now time.Time) domain.View {
+	t := now
+	if evt.Time > 0 {
+		t = time.UnixMilli(evt.Time)
+	}
+	return domain.View{Uid: evt.Uid, Biz: "article", BizID: evt.ArticleID, Time: t}
+}
+
+// incrViews 去重之后累加阅读数和去重后的阅读数。去重失败时不去重，宁可多计也不丢失阅读数
+func incrViews(ctx context.Context, repo repository.InteractionRepository, l logger.LoggerV2, views []domain.View) error {
+	res, err := repo.DedupViews(ctx, views)
+	if err != nil {
+		l.Error("阅读去重失败", logger.Error(err))
+		res = slice.Map(views, func(idx int, src domain.View) domain.ViewResult {
+			return domain.ViewResult{View: src, Counted: true}
+		})
+	}
+
+	var counted, unique []domain.View
+	for _, r := range res {
+		if r.Counted {
+			counted = append(counted, r.View)
+		}
+		if r.Unique {
+			unique = append(unique, r.View)
+		}
+	}
+	err = repo.BatchIncrReadCnt(ctx, counted)
+	if err != nil {
+		return err
+	}
+	return repo.BatchIncrUniqueViewCnt(ctx, unique)
 }
@@ -0,0 +1,87 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/event"
+	"learn_go/webook/interaction/repository"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestIncrViews(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	views := []domain.View{
+		{Uid: 1001, Biz: "article", BizID: 1, Time: now},
+		{Uid: 1002, Biz: "article", BizID: 1, Time: now},
+		{Uid: 1001, Biz: "article", BizID: 2, Time: now},
+	}
+
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractionRepository
+
+		wantErr error
+	}{
+		{
+			name: "去重之后累加阅读数和独立读者",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().DedupViews(gomock.Any(), views).Return([]domain.ViewResult{
+					{View: views[0], Counted: true, Unique: false},
+					{View: views[1], Counted: true, Unique: true},
+					{View: views[2], Counted: false, Unique: false},
+				}, nil)
+				repo.EXPECT().BatchIncrReadCnt(gomock.Any(), views[:2]).Return(nil)
+				repo.EXPECT().BatchIncrUniqueViewCnt(gomock.Any(), views[1:2]).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "去重失败时全部计入阅读数，不计独立读者",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().DedupViews(gomock.Any(), views).Return(nil, errors.New("mock redis error"))
+				repo.EXPECT().BatchIncrReadCnt(gomock.Any(), views).Return(nil)
+				repo.EXPECT().BatchIncrUniqueViewCnt(gomock.Any(), gomock.Nil()).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "累加阅读数失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().DedupViews(gomock.Any(), views).Return([]domain.ViewResult{
+					{View: views[0], Counted: true},
+				}, nil)
+				repo.EXPECT().BatchIncrReadCnt(gomock.Any(), views[:1]).Return(errors.New("mock redis error"))
+				return repo
+			},
+			wantErr: errors.New("mock redis error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			err := incrViews(context.Background(), tc.mock(ctrl), logger.NewNopLogger(), views)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestToView(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	// 按照阅读发生的时间统计
+	v := toView(event.ReadEvent{Uid: 1001, ArticleID: 1, Time: 1600000000000}, now)
+	assert.Equal(t, domain.View{Uid: 1001, Biz: "article", BizID: 1, Time: time.UnixMilli(1600000000000)}, v)
+	// 旧的消息中没有时间
+	v = toView(event.ReadEvent{Uid: 1001, ArticleID: 1}, now)
+	assert.Equal(t, now, v.Time)
+}
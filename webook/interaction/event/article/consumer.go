@@ -3,6 +3,7 @@ package article
 import (
 	"context"
 	"github.com/IBM/sarama"
+	"learn_go/webook/interaction/domain"
 	"learn_go/webook/interaction/event"
 	"learn_go/webook/interaction/repository"
 	"learn_go/webook/pkg/logger"
@@ -54,5 +55,5 @@ func (c *Consumer) Start() error {
 func (c *Consumer) Consume(message *sarama.ConsumerMessage, event event.ReadEvent) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	return c.interactionRepo.IncrReadCnt(ctx, "article", event.ArticleID)
+	return incrViews(ctx, c.interactionRepo, c.l, []domain.View{toView(event, time.Now())})
 }
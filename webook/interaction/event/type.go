@@ -5,4 +5,6 @@ const TopicArticleReadEvent = "article_read"
 type ReadEvent struct {
 	Uid       int64 `json:"uid"`
 	ArticleID int64 `json:"article_id"`
+	// Time 阅读的时间（毫秒），消息可能积压，消费者按照这个时间去重、统计独立读者
+	Time int64 `json:"time"`
 }
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/service"
+)
+
+type CommentServiceServer struct {
+	svc service.CommentService
+
+	intrv1.UnimplementedCommentServiceServer
+}
+
+func NewCommentServiceServer(svc service.CommentService) *CommentServiceServer {
+	return &CommentServiceServer{
+		svc: svc,
+	}
+}
+
+func (server *CommentServiceServer) CreateComment(ctx context.Context, req *intrv1.CreateCommentReq) (*intrv1.CreateCommentResp, error) {
+	c, err := server.svc.Create(ctx, domain.Comment{
+		Uid:      req.GetUid(),
+		Biz:      req.GetBiz(),
+		BizID:    req.GetBizId(),
+		ParentID: req.GetParentId(),
+		Content:  req.GetContent(),
+	})
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.CreateCommentResp{Comment: server.toDTO(0, c)}, nil
+}
+
+func (server *CommentServiceServer) UpdateComment(ctx context.Context, req *intrv1.UpdateCommentReq) (*intrv1.UpdateCommentResp, error) {
+	err := server.svc.Update(ctx, req.GetUid(), req.GetId(), req.GetContent())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.UpdateCommentResp{}, nil
+}
+
+func (server *CommentServiceServer) DeleteComment(ctx context.Context, req *intrv1.DeleteCommentReq) (*intrv1.DeleteCommentResp, error) {
+	err := server.svc.Delete(ctx, req.GetUid(), req.GetId())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.DeleteCommentResp{}, nil
+}
+
+func (server *CommentServiceServer) PinComment(ctx context.Context, req *intrv1.PinCommentReq) (*intrv1.PinCommentResp, error) {
+	err := server.svc.Pin(ctx, req.GetBiz(), req.GetBizId(), req.GetId(), req.GetPinned())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.PinCommentResp{}, nil
+}
+
+func (server *CommentServiceServer) ListComments(ctx context.Context, req *intrv1.ListCommentsReq) (*intrv1.ListCommentsResp, error) {
+	sort := domain.CommentSortTime
+	if req.GetSort() == intrv1.CommentSort_COMMENT_SORT_POPULAR {
+		sort = domain.CommentSortPopular
+	}
+	res, err := server.svc.List(ctx, req.GetUid(), req.GetBiz(), req.GetBizId(), sort, req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.ListCommentsResp{Comments: slice.Map(res, server.toDTO)}, nil
+}
+
+func (server *CommentServiceServer) ListReplies(ctx context.Context, req *intrv1.ListRepliesReq) (*intrv1.ListRepliesResp, error) {
+	res, err := server.svc.ListReplies(ctx, req.GetUid(), req.GetRootId(), req.GetMinId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.ListRepliesResp{Replies: slice.Map(res, server.toDTO)}, nil
+}
+
+func (server *CommentServiceServer) LikeComment(ctx context.Context, req *intrv1.LikeCommentReq) (*intrv1.LikeCommentResp, error) {
+	err := server.svc.Like(ctx, req.GetUid(), req.GetId())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.LikeCommentResp{}, nil
+}
+
+func (server *CommentServiceServer) CancelLikeComment(ctx context.Context, req *intrv1.CancelLikeCommentReq) (*intrv1.CancelLikeCommentResp, error) {
+	err := server.svc.CancelLike(ctx, req.GetUid(), req.GetId())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.CancelLikeCommentResp{}, nil
+}
+
+// toStatus 业务错误转换成grpc的状态码，调用方根据状态码区分
+func (server *CommentServiceServer) toStatus(err error) error {
+	switch err {
+	case service.ErrCommentNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.ErrInvalidComment:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.ErrNoPermission:
+		return status.Error(codes.PermissionDenied, err.Error())
+	default:
+		return err
+	}
+}
+
+func (server *CommentServiceServer) toDTO(idx int, c domain.Comment) *intrv1.Comment {
+	return &intrv1.Comment{
+		Id:         c.ID,
+		Uid:        c.Uid,
+		Biz:        c.Biz,
+		BizId:      c.BizID,
+		RootId:     c.RootID,
+		ParentId:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Content:    c.Content,
+		Likes:      c.Likes,
+		Replies:    c.Replies,
+		Pinned:     c.Pinned,
+		Liked:      c.Liked,
+		CTime:      c.CTime.UnixMilli(),
+		UTime:      c.UTime.UnixMilli(),
+	}
+}
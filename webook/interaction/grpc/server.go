@@ -2,20 +2,29 @@ package grpc
 
 import (
 	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"learn_go/webook/api/proto/gen/intr"
 	"learn_go/webook/interaction/domain"
 	"learn_go/webook/interaction/service"
+	"time"
 )
 
 type InteractionServiceServer struct {
-	svc service.InteractionService
+	svc     service.InteractionService
+	favSvc  service.FavoriteService
+	statSvc service.StatService
 
 	intrv1.UnimplementedInteractionServiceServer
 }
 
-func NewInteractionServiceServer(svc service.InteractionService) *InteractionServiceServer {
+func NewInteractionServiceServer(svc service.InteractionService, favSvc service.FavoriteService,
+	statSvc service.StatService) *InteractionServiceServer {
 	return &InteractionServiceServer{
-		svc: svc,
+		svc:     svc,
+		favSvc:  favSvc,
+		statSvc: statSvc,
 	}
 }
 
@@ -25,8 +34,16 @@ func (server *InteractionServiceServer) View(ctx context.Context, req *intrv1.Vi
 }
 
 func (server *InteractionServiceServer) Like(ctx context.Context, req *intrv1.LikeReq) (*intrv1.LikeResp, error) {
-	err := server.svc.Like(ctx, req.GetUid(), req.GetBiz(), req.GetBizId())
-	return &intrv1.LikeResp{}, err
+	// 没有reaction的旧客户端映射到点赞
+	reaction, ok := domain.ParseReaction(req.GetReaction())
+	if !ok {
+		return nil, server.toStatus(service.ErrInvalidReaction)
+	}
+	err := server.svc.Like(ctx, req.GetUid(), req.GetBiz(), req.GetBizId(), reaction)
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.LikeResp{}, nil
 }
 
 func (server *InteractionServiceServer) CancelLike(ctx context.Context, req *intrv1.CancelLikeReq) (*intrv1.CancelLikeResp, error) {
@@ -36,7 +53,77 @@ func (server *InteractionServiceServer) CancelLike(ctx context.Context, req *int
 
 func (server *InteractionServiceServer) Favorite(ctx context.Context, req *intrv1.FavoriteReq) (*intrv1.FavoriteResp, error) {
 	err := server.svc.Favorite(ctx, req.GetUid(), req.GetFavoriteId(), req.GetBiz(), req.GetBizId())
-	return &intrv1.FavoriteResp{}, err
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.FavoriteResp{}, nil
+}
+
+func (server *InteractionServiceServer) CancelFavorite(ctx context.Context, req *intrv1.CancelFavoriteReq) (*intrv1.CancelFavoriteResp, error) {
+	err := server.svc.CancelFavorite(ctx, req.GetUid(), req.GetBiz(), req.GetBizId())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.CancelFavoriteResp{}, nil
+}
+
+func (server *InteractionServiceServer) CreateFavoriteFolder(ctx context.Context, req *intrv1.CreateFavoriteFolderReq) (*intrv1.CreateFavoriteFolderResp, error) {
+	f, err := server.favSvc.CreateFolder(ctx, domain.FavoriteFolder{
+		Uid:     req.GetUid(),
+		Name:    req.GetName(),
+		Private: req.GetPrivate(),
+	})
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.CreateFavoriteFolderResp{Folder: server.toFolderDTO(0, f)}, nil
+}
+
+func (server *InteractionServiceServer) UpdateFavoriteFolder(ctx context.Context, req *intrv1.UpdateFavoriteFolderReq) (*intrv1.UpdateFavoriteFolderResp, error) {
+	err := server.favSvc.UpdateFolder(ctx, domain.FavoriteFolder{
+		ID:      req.GetId(),
+		Uid:     req.GetUid(),
+		Name:    req.GetName(),
+		Private: req.GetPrivate(),
+	})
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.UpdateFavoriteFolderResp{}, nil
+}
+
+func (server *InteractionServiceServer) DeleteFavoriteFolder(ctx context.Context, req *intrv1.DeleteFavoriteFolderReq) (*intrv1.DeleteFavoriteFolderResp, error) {
+	err := server.favSvc.DeleteFolder(ctx, req.GetUid(), req.GetId())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.DeleteFavoriteFolderResp{}, nil
+}
+
+func (server *InteractionServiceServer) ListFavoriteFolders(ctx context.Context, req *intrv1.ListFavoriteFoldersReq) (*intrv1.ListFavoriteFoldersResp, error) {
+	res, err := server.favSvc.ListFolders(ctx, req.GetUid(), req.GetOwner())
+	if err != nil {
+		return nil, err
+	}
+	return &intrv1.ListFavoriteFoldersResp{Folders: slice.Map(res, server.toFolderDTO)}, nil
+}
+
+func (server *InteractionServiceServer) ListFavoriteItems(ctx context.Context, req *intrv1.ListFavoriteItemsReq) (*intrv1.ListFavoriteItemsResp, error) {
+	res, err := server.favSvc.ListItems(ctx, req.GetUid(), req.GetFolderId(), req.GetMaxId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.ListFavoriteItemsResp{
+		Items: slice.Map(res, func(idx int, src domain.UserFavorite) *intrv1.FavoriteItem {
+			return &intrv1.FavoriteItem{
+				Id:       src.ID,
+				FolderId: src.FavoriteID,
+				Biz:      src.Biz,
+				BizId:    src.BizID,
+				CTime:    src.CTime.UnixMilli(),
+			}
+		}),
+	}, nil
 }
 
 func (server *InteractionServiceServer) Get(ctx context.Context, req *intrv1.GetReq) (*intrv1.GetResp, error) {
@@ -79,19 +166,117 @@ func (server *InteractionServiceServer) GetByIDs(ctx context.Context, req *intrv
 	}, nil
 }
 
+func (server *InteractionServiceServer) ListLikes(ctx context.Context, req *intrv1.ListLikesReq) (*intrv1.ListLikesResp, error) {
+	likes, err := server.svc.ListLiked(ctx, req.GetUid(), req.GetBiz(),
+		time.UnixMilli(req.GetBeforeTime()), req.GetBeforeId(), int(req.GetLimit()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.ListLikesResp{
+		Likes: slice.Map(likes, func(idx int, src domain.UserLike) *intrv1.UserLike {
+			return &intrv1.UserLike{
+				Id:    src.ID,
+				Biz:   src.Biz,
+				BizId: src.BizID,
+				Time:  src.UTime.UnixMilli(),
+
+				Reaction: src.Reaction.String(),
+			}
+		}),
+	}, nil
+}
+
+func (server *InteractionServiceServer) BatchCheck(ctx context.Context, req *intrv1.BatchCheckReq) (*intrv1.BatchCheckResp, error) {
+	m, err := server.svc.BatchCheck(ctx, req.GetUid(), req.GetBiz(), req.GetBizIds())
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	states := make(map[int64]*intrv1.UserState, len(m))
+	for id, inter := range m {
+		states[id] = &intrv1.UserState{Liked: inter.Liked, Collected: inter.Collected}
+	}
+	return &intrv1.BatchCheckResp{States: states}, nil
+}
+
+func (server *InteractionServiceServer) GetTrend(ctx context.Context, req *intrv1.GetTrendReq) (*intrv1.GetTrendResp, error) {
+	points, err := server.statSvc.GetTrend(ctx, req.GetBiz(), []int64{req.GetBizId()},
+		domain.StatGranularity(req.GetGranularity()), time.UnixMilli(req.GetFrom()), time.UnixMilli(req.GetTo()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.GetTrendResp{Points: slice.Map(points, server.toPointDTO)}, nil
+}
+
+func (server *InteractionServiceServer) GetAggregatedTrend(ctx context.Context, req *intrv1.GetAggregatedTrendReq) (*intrv1.GetAggregatedTrendResp, error) {
+	points, err := server.statSvc.GetTrend(ctx, req.GetBiz(), req.GetBizIds(),
+		domain.StatGranularity(req.GetGranularity()), time.UnixMilli(req.GetFrom()), time.UnixMilli(req.GetTo()))
+	if err != nil {
+		return nil, server.toStatus(err)
+	}
+	return &intrv1.GetAggregatedTrendResp{Points: slice.Map(points, server.toPointDTO)}, nil
+}
+
+func (server *InteractionServiceServer) Delete(ctx context.Context, req *intrv1.DeleteReq) (*intrv1.DeleteResp, error) {
+	err := server.svc.Delete(ctx, req.GetBiz(), req.GetBizId())
+	return &intrv1.DeleteResp{}, err
+}
+
+// toStatus 收藏夹、趋势查询、批量查询的业务错误转换成grpc的状态码
+func (server *InteractionServiceServer) toStatus(err error) error {
+	switch err {
+	case service.ErrFolderNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case service.ErrInvalidFolder, service.ErrInvalidTrend, service.ErrTooManyBizIDs, service.ErrInvalidReaction:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case service.ErrTooManyFolders:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return err
+	}
+}
+
+func (server *InteractionServiceServer) toFolderDTO(idx int, f domain.FavoriteFolder) *intrv1.FavoriteFolder {
+	return &intrv1.FavoriteFolder{
+		Id:      f.ID,
+		Uid:     f.Uid,
+		Name:    f.Name,
+		Private: f.Private,
+		Items:   f.Items,
+		CTime:   f.CTime.UnixMilli(),
+		UTime:   f.UTime.UnixMilli(),
+	}
+}
+
+func (server *InteractionServiceServer) toPointDTO(idx int, p domain.TrendPoint) *intrv1.TrendPoint {
+	return &intrv1.TrendPoint{
+		Time:      p.Time.UnixMilli(),
+		Views:     p.Views,
+		Likes:     p.Likes,
+		Favorites: p.Favorites,
+	}
+}
+
 // data transfer object
 func (server *InteractionServiceServer) toDTO(inter domain.Interaction) *intrv1.Interaction {
+	reactions := make(map[string]int64, len(inter.Reactions))
+	for r, cnt := range inter.Reactions {
+		reactions[r.String()] = cnt
+	}
 	return &intrv1.Interaction{
-		Id:        inter.ID,
-		Biz:       inter.Biz,
-		BizId:     inter.BizID,
-		CTime:     inter.CTime.UnixMilli(),
-		UTime:     inter.UTime.UnixMilli(),
-		Views:     inter.Views,
-		Likes:     inter.Likes,
-		Favorites: inter.Favorites,
+		Id:          inter.ID,
+		Biz:         inter.Biz,
+		BizId:       inter.BizID,
+		CTime:       inter.CTime.UnixMilli(),
+		UTime:       inter.UTime.UnixMilli(),
+		Views:       inter.Views,
+		UniqueViews: inter.UniqueViews,
+		Likes:       inter.Likes,
+		Favorites:   inter.Favorites,
+		Comments:    inter.Comments,
+		Reactions:   reactions,
 
 		Liked:     inter.Liked,
+		Reaction:  inter.Reaction.String(),
 		Collected: inter.Collected,
 	}
 }
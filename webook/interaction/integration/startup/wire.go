@@ -24,6 +24,17 @@ var interactionSet = wire.NewSet(
 	repository.NewInteractionRepository,
 	dao.NewInteractionDao,
 	cache.NewInteractionCache,
+	cache.NewCounterCache,
+	cache.NewViewCache,
+	cache.NewLikeCache,
+
+	service.NewFavoriteService,
+	repository.NewFavoriteRepository,
+	dao.NewFavoriteDao,
+
+	service.NewStatService,
+	repository.NewStatRepository,
+	dao.NewStatDao,
 )
 
 func InitInteractionService() service.InteractionService {
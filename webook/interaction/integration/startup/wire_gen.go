@@ -23,7 +23,10 @@ func InitInteractionService() service.InteractionService {
 	interactionDao := dao.NewInteractionDao(db)
 	cmdable := NewRedis()
 	interactionCache := cache.NewInteractionCache(cmdable)
-	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache)
+	counterCache := cache.NewCounterCache(cmdable)
+	viewCache := cache.NewViewCache(cmdable)
+	likeCache := cache.NewLikeCache(cmdable)
+	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache, counterCache, viewCache, likeCache)
 	interactionService := service.NewInteractionService(interactionRepository)
 	return interactionService
 }
@@ -33,9 +36,18 @@ func InitInteractionServiceServer() *grpc.InteractionServiceServer {
 	interactionDao := dao.NewInteractionDao(db)
 	cmdable := NewRedis()
 	interactionCache := cache.NewInteractionCache(cmdable)
-	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache)
+	counterCache := cache.NewCounterCache(cmdable)
+	viewCache := cache.NewViewCache(cmdable)
+	likeCache := cache.NewLikeCache(cmdable)
+	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache, counterCache, viewCache, likeCache)
 	interactionService := service.NewInteractionService(interactionRepository)
-	interactionServiceServer := grpc.NewInteractionServiceServer(interactionService)
+	favoriteDao := dao.NewFavoriteDao(db)
+	favoriteRepository := repository.NewFavoriteRepository(favoriteDao, interactionCache)
+	favoriteService := service.NewFavoriteService(favoriteRepository)
+	statDao := dao.NewStatDao(db)
+	statRepository := repository.NewStatRepository(statDao)
+	statService := service.NewStatService(statRepository)
+	interactionServiceServer := grpc.NewInteractionServiceServer(interactionService, favoriteService, statService)
 	return interactionServiceServer
 }
 
@@ -46,4 +58,4 @@ var thirdPartySet = wire.NewSet(
 	NewRedis, ioc.NewLogger,
 )
 
-var interactionSet = wire.NewSet(service.NewInteractionService, repository.NewInteractionRepository, dao.NewInteractionDao, cache.NewInteractionCache)
+var interactionSet = wire.NewSet(service.NewInteractionService, repository.NewInteractionRepository, dao.NewInteractionDao, cache.NewInteractionCache, cache.NewCounterCache, cache.NewViewCache, cache.NewLikeCache, service.NewFavoriteService, repository.NewFavoriteRepository, dao.NewFavoriteDao, service.NewStatService, repository.NewStatRepository, dao.NewStatDao)
@@ -8,7 +8,7 @@ import (
 	"learn_go/webook/pkg/grpcx"
 )
 
-func InitGRPCServer(intrSvcServer *grpc2.InteractionServiceServer) *grpcx.Server {
+func InitGRPCServer(intrSvcServer *grpc2.InteractionServiceServer, commentSvcServer *grpc2.CommentServiceServer) *grpcx.Server {
 	type config struct {
 		Addr string
 	}
@@ -20,6 +20,7 @@ func InitGRPCServer(intrSvcServer *grpc2.InteractionServiceServer) *grpcx.Server
 
 	server := grpc.NewServer()
 	intrv1.RegisterInteractionServiceServer(server, intrSvcServer)
+	intrv1.RegisterCommentServiceServer(server, commentSvcServer)
 
 	return &grpcx.Server{
 		Server: server,
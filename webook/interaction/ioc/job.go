@@ -0,0 +1,53 @@
+package ioc
+
+import (
+	"github.com/robfig/cron/v3"
+	"learn_go/webook/interaction/job"
+	"learn_go/webook/interaction/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+func InitCounterFlushJob(svc service.CounterService) *job.CounterFlushJob {
+	return job.NewCounterFlushJob(svc, time.Second*4)
+}
+
+func InitCounterReconcileJob(svc service.CounterService) *job.CounterReconcileJob {
+	// 每10分钟对账最近半小时修改过的计数
+	return job.NewCounterReconcileJob(svc, time.Minute*5, time.Minute*30)
+}
+
+func InitStatRollupJob(svc service.StatService) *job.StatRollupJob {
+	return job.NewStatRollupJob(svc, time.Minute*10)
+}
+
+// InitCron 计数写回和分时统计的汇总任务。多个实例同时执行也是安全的：
+// 缓冲区分片是原子地取出的，同一个批次只会写入数据库一次；汇总是覆盖天桶，重复执行结果相同
+func InitCron(l logger.LoggerV2, flushJob *job.CounterFlushJob, reconcileJob *job.CounterReconcileJob,
+	rollupJob *job.StatRollupJob) *cron.Cron {
+	c := cron.New(cron.WithSeconds())
+	// 任务超时时间 < 定时任务的间隔时间
+	_, err := c.AddJob("*/5 * * * * ?", cronJob(l, flushJob.Name(), flushJob.Run))
+	if err != nil {
+		panic(err)
+	}
+	_, err = c.AddJob("0 */10 * * * ?", cronJob(l, reconcileJob.Name(), reconcileJob.Run))
+	if err != nil {
+		panic(err)
+	}
+	// 每小时汇总一次，错开整点的写入高峰
+	_, err = c.AddJob("0 5 * * * ?", cronJob(l, rollupJob.Name(), rollupJob.Run))
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func cronJob(l logger.LoggerV2, name string, run func() error) cron.Job {
+	return cron.FuncJob(func() {
+		err := run()
+		if err != nil {
+			l.Error("定时任务执行失败", logger.String("job", name), logger.Error(err))
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/interaction/service"
+	"time"
+)
+
+// CounterFlushJob 把写回缓冲区中的计数批量写入数据库
+type CounterFlushJob struct {
+	svc     service.CounterService
+	timeout time.Duration
+}
+
+func NewCounterFlushJob(svc service.CounterService, timeout time.Duration) *CounterFlushJob {
+	return &CounterFlushJob{svc: svc, timeout: timeout}
+}
+
+func (j *CounterFlushJob) Name() string {
+	return "interaction:counter_flush"
+}
+
+func (j *CounterFlushJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.Flush(ctx)
+}
+
+// CounterReconcileJob 对账最近修改过的计数，修复缓存和数据库之间的偏差
+type CounterReconcileJob struct {
+	svc     service.CounterService
+	timeout time.Duration
+	// window 对账的时间范围，要大于任务的执行间隔
+	window time.Duration
+}
+
+func NewCounterReconcileJob(svc service.CounterService, timeout time.Duration, window time.Duration) *CounterReconcileJob {
+	return &CounterReconcileJob{svc: svc, timeout: timeout, window: window}
+}
+
+func (j *CounterReconcileJob) Name() string {
+	return "interaction:counter_reconcile"
+}
+
+func (j *CounterReconcileJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.Reconcile(ctx, time.Now().Add(-j.window))
+}
@@ -0,0 +1,27 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/interaction/service"
+	"time"
+)
+
+// StatRollupJob 把小时桶汇总成天桶，并清理过期的小时桶
+type StatRollupJob struct {
+	svc     service.StatService
+	timeout time.Duration
+}
+
+func NewStatRollupJob(svc service.StatService, timeout time.Duration) *StatRollupJob {
+	return &StatRollupJob{svc: svc, timeout: timeout}
+}
+
+func (j *StatRollupJob) Name() string {
+	return "interaction:stat_rollup"
+}
+
+func (j *StatRollupJob) Run() error {
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	defer cancel()
+	return j.svc.Rollup(ctx, time.Now())
+}
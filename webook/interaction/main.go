@@ -41,6 +41,12 @@ func main() {
 		consumer.Start()
 	}
 
+	app.cron.Start()
+	defer func() {
+		// 等待正在执行的写回任务结束
+		<-app.cron.Stop().Done()
+	}()
+
 	err := app.server.Start()
 	log.Printf("err: %v\n", err)
 }
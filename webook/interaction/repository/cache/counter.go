@@ -0,0 +1,394 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"hash/fnv"
+	"learn_go/webook/interaction/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	//go:embed lua/take_batch.lua
+	takeBatchScript string
+	//go:embed lua/reaction.lua
+	reactionScript string
+)
+
+const (
+	// counterShards 增量缓冲区的分片数，热点资源的增量分散到不同的key上
+	counterShards = 16
+
+	counterBatchesKey = "interaction:counter:batches"
+)
+
+/*
+写回缓冲区
+
+	点赞数、每种表态的数量、阅读数、去重后的阅读数先累加到缓冲区（按资源哈希分片的hash）中，定时任务再批量写入数据库，避免热点资源的行锁竞争。
+	缓冲区的field是 biz:biz_id:计数字段:小时桶，值是还没有写入数据库的增量。小时桶是计数发生时所在小时的开始时间（毫秒），
+	写入数据库时累加到这个时间桶，而不是写入时的时间桶，消息积压、批次重放都不会把计数算到别的小时。
+	写入时先把分片原子地改名为一个批次，批次ID是 分片:时间戳，批次写入数据库并记录批次ID之后才删除，中途崩溃时重放该批次。
+*/
+
+// CounterCache 计数的写回缓冲区，同时维护InteractionCache中的计数
+type CounterCache interface {
+	// IncrReadCnt 阅读数增加delta，at是阅读发生的时间，缓存存在时同时修改缓存
+	IncrReadCnt(ctx context.Context, biz string, bizID int64, delta int64, at time.Time) error
+	// IncrUniqueViewCnt 去重后的阅读数增加delta
+	IncrUniqueViewCnt(ctx context.Context, biz string, bizID int64, delta int64, at time.Time) error
+	// IncrReaction 用户的表态从from变成to，ReactionNone表示没有表态。
+	// 旧表态-1、新表态+1，从没有表态变成有表态（或者反过来）时点赞总数也会变化，这些修改是一次原子操作
+	IncrReaction(ctx context.Context, biz string, bizID int64, from, to domain.Reaction) error
+	// Pending 查询资源在缓冲区中还没有写入数据库的增量，包括已经取出、还没有删除的批次。
+	// 批次写入数据库之后、删除之前的这段时间里，这个批次的增量会被重复计算
+	Pending(ctx context.Context, biz string, bizID int64) (domain.CounterDelta, error)
+	// PendingByIDs 批量查询多个资源的增量，只返回有增量的资源。每个分片和批次只扫描一次
+	PendingByIDs(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.CounterDelta, error)
+	// Del 删除资源在缓冲区和未完成的批次中的增量，资源被彻底删除时使用
+	Del(ctx context.Context, biz string, bizID int64) error
+
+	// TakeBatches 把所有分片的增量取出成批次，返回所有未完成的批次ID，包括之前崩溃遗留的批次
+	TakeBatches(ctx context.Context) ([]string, error)
+	GetBatch(ctx context.Context, id string) (domain.CounterBatch, error)
+	// DelBatch 批次写入数据库之后删除
+	DelBatch(ctx context.Context, id string) error
+}
+
+type counterCache struct {
+	cmd redis.Cmdable
+}
+
+func NewCounterCache(cmd redis.Cmdable) CounterCache {
+	return &counterCache{
+		cmd: cmd,
+	}
+}
+
+func (cache *counterCache) IncrReadCnt(ctx context.Context, biz string, bizID int64, delta int64, at time.Time) error {
+	return cache.incr(ctx, biz, bizID, readCntField, delta, at)
+}
+
+func (cache *counterCache) IncrUniqueViewCnt(ctx context.Context, biz string, bizID int64, delta int64, at time.Time) error {
+	return cache.incr(ctx, biz, bizID, uniqueCntField, delta, at)
+}
+
+func (cache *counterCache) IncrReaction(ctx context.Context, biz string, bizID int64, from, to domain.Reaction) error {
+	d := domain.NewReactionDelta(biz, bizID, from, to)
+	if d.IsZero() {
+		return nil
+	}
+	now := time.Now()
+	args := make([]any, 0, 9)
+	add := func(field string, delta int64) {
+		args = append(args, field, delta, cache.field(biz, bizID, field, now))
+	}
+	if d.Likes != 0 {
+		add(likeCntField, d.Likes)
+	}
+	for _, r := range domain.Reactions {
+		if delta := d.Reactions[r]; delta != 0 {
+			add(reactionField(r), delta)
+		}
+	}
+	keys := []string{fmt.Sprintf("interaction:%s:%d", biz, bizID), cache.shardKey(biz, bizID)}
+	return cache.cmd.Eval(ctx, reactionScript, keys, args...).Err()
+}
+
+func (cache *counterCache) incr(ctx context.Context, biz string, bizID int64, field string, delta int64, at time.Time) error {
+	// 第一个key和interactionCache中的缓存key相同
+	keys := []string{fmt.Sprintf("interaction:%s:%d", biz, bizID), cache.shardKey(biz, bizID)}
+	return cache.cmd.Eval(ctx, script, keys, []any{field, delta, cache.field(biz, bizID, field, at)}).Err()
+}
+
+func (cache *counterCache) Pending(ctx context.Context, biz string, bizID int64) (domain.CounterDelta, error) {
+	res := domain.CounterDelta{Biz: biz, BizID: bizID}
+	keys, err := cache.pendingKeys(ctx, biz, bizID)
+	if err != nil {
+		return res, err
+	}
+	for _, key := range keys {
+		fields, err := cache.scanFields(ctx, key, biz, bizID)
+		if err != nil {
+			return res, err
+		}
+		for _, f := range fields {
+			cache.addDelta(&res, f.field, f.delta)
+		}
+	}
+	cache.dropZeroReactions(&res)
+	return res, nil
+}
+
+func (cache *counterCache) PendingByIDs(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.CounterDelta, error) {
+	wanted := make(map[int64]struct{}, len(bizIDs))
+	shards := make(map[int]struct{}, len(bizIDs))
+	for _, id := range bizIDs {
+		wanted[id] = struct{}{}
+		shards[cache.shard(biz, id)] = struct{}{}
+	}
+	keys, err := cache.keysOfShards(ctx, shards)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.CounterDelta, len(bizIDs))
+	for _, key := range keys {
+		iter := cache.cmd.HScan(ctx, key, 0, biz+":*", 1000).Iterator()
+		for iter.Next(ctx) {
+			name := iter.Val()
+			if !iter.Next(ctx) {
+				break
+			}
+			fBiz, fBizID, field, _, ok := cache.parseField(name)
+			if _, has := wanted[fBizID]; !ok || fBiz != biz || !has {
+				continue
+			}
+			d, has := res[fBizID]
+			if !has {
+				d = domain.CounterDelta{Biz: biz, BizID: fBizID}
+			}
+			cache.addDelta(&d, field, cache.toInt64(iter.Val()))
+			res[fBizID] = d
+		}
+		if err = iter.Err(); err != nil {
+			return nil, err
+		}
+	}
+	for id, d := range res {
+		cache.dropZeroReactions(&d)
+		if d.IsZero() {
+			delete(res, id)
+			continue
+		}
+		res[id] = d
+	}
+	return res, nil
+}
+
+// dropZeroReactions 切换表态时不同批次、不同小时桶中的增量可能抵消
+func (cache *counterCache) dropZeroReactions(d *domain.CounterDelta) {
+	for r, delta := range d.Reactions {
+		if delta == 0 {
+			delete(d.Reactions, r)
+		}
+	}
+}
+
+func (cache *counterCache) Del(ctx context.Context, biz string, bizID int64) error {
+	keys, err := cache.pendingKeys(ctx, biz, bizID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		fields, err := cache.scanFields(ctx, key, biz, bizID)
+		if err != nil {
+			return err
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			names = append(names, f.name)
+		}
+		if err = cache.cmd.HDel(ctx, key, names...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pendingKeys 资源所在的分片，以及从这个分片取出的、还没有删除的批次
+func (cache *counterCache) pendingKeys(ctx context.Context, biz string, bizID int64) ([]string, error) {
+	return cache.keysOfShards(ctx, map[int]struct{}{cache.shard(biz, bizID): {}})
+}
+
+// keysOfShards 这些分片，以及从这些分片取出的、还没有删除的批次
+func (cache *counterCache) keysOfShards(ctx context.Context, shards map[int]struct{}) ([]string, error) {
+	ids, err := cache.cmd.SMembers(ctx, counterBatchesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(shards)+len(ids))
+	for shard := range shards {
+		keys = append(keys, cache.shardKeyOf(shard))
+	}
+	for _, id := range ids {
+		shard, _, ok := strings.Cut(id, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(shard)
+		if err != nil {
+			continue
+		}
+		if _, has := shards[n]; has {
+			keys = append(keys, cache.batchKey(id))
+		}
+	}
+	return keys, nil
+}
+
+// bufferField 缓冲区中的一个field
+type bufferField struct {
+	name  string
+	field string
+	delta int64
+}
+
+// scanFields 扫描key中资源的所有field，不同小时桶的增量在不同的field里。
+// biz中可能包含冒号，匹配出来的field需要再解析一次确认是这个资源的
+func (cache *counterCache) scanFields(ctx context.Context, key string, biz string, bizID int64) ([]bufferField, error) {
+	var res []bufferField
+	iter := cache.cmd.HScan(ctx, key, 0, fmt.Sprintf("%s:%d:*", biz, bizID), 1000).Iterator()
+	for iter.Next(ctx) {
+		name := iter.Val()
+		if !iter.Next(ctx) {
+			break
+		}
+		fBiz, fBizID, field, _, ok := cache.parseField(name)
+		if !ok || fBiz != biz || fBizID != bizID {
+			continue
+		}
+		res = append(res, bufferField{name: name, field: field, delta: cache.toInt64(iter.Val())})
+	}
+	return res, iter.Err()
+}
+
+// addDelta 把缓冲区中一个计数字段的增量累加到d
+func (cache *counterCache) addDelta(d *domain.CounterDelta, field string, delta int64) {
+	switch field {
+	case readCntField:
+		d.Views += delta
+	case uniqueCntField:
+		d.UniqueViews += delta
+	case likeCntField:
+		d.Likes += delta
+	default:
+		if r, ok := parseReactionField(field); ok && delta != 0 {
+			if d.Reactions == nil {
+				d.Reactions = make(map[domain.Reaction]int64, len(domain.Reactions))
+			}
+			d.Reactions[r] += delta
+		}
+	}
+}
+
+func (cache *counterCache) TakeBatches(ctx context.Context) ([]string, error) {
+	now := time.Now().UnixNano()
+	for shard := 0; shard < counterShards; shard++ {
+		id := fmt.Sprintf("%d:%d", shard, now)
+		keys := []string{cache.shardKeyOf(shard), cache.batchKey(id), counterBatchesKey}
+		err := cache.cmd.Eval(ctx, takeBatchScript, keys, id).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cache.cmd.SMembers(ctx, counterBatchesKey).Result()
+}
+
+func (cache *counterCache) GetBatch(ctx context.Context, id string) (domain.CounterBatch, error) {
+	res := domain.CounterBatch{ID: id}
+	vals, err := cache.cmd.HGetAll(ctx, cache.batchKey(id)).Result()
+	if err != nil {
+		return res, err
+	}
+	deltas := make(map[string]*domain.CounterDelta, len(vals))
+	for f, v := range vals {
+		biz, bizID, field, bucket, ok := cache.parseField(f)
+		if !ok {
+			continue
+		}
+		delta, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || delta == 0 {
+			continue
+		}
+		// 同一个资源不同小时桶的增量分开写入
+		key := fmt.Sprintf("%s:%d:%d", biz, bizID, bucket)
+		d, ok := deltas[key]
+		if !ok {
+			d = &domain.CounterDelta{Biz: biz, BizID: bizID}
+			if bucket > 0 {
+				d.Bucket = time.UnixMilli(bucket)
+			}
+			deltas[key] = d
+		}
+		cache.addDelta(d, field, delta)
+	}
+	res.Deltas = make([]domain.CounterDelta, 0, len(deltas))
+	for _, d := range deltas {
+		res.Deltas = append(res.Deltas, *d)
+	}
+	return res, nil
+}
+
+func (cache *counterCache) DelBatch(ctx context.Context, id string) error {
+	_, err := cache.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, cache.batchKey(id))
+		pipe.SRem(ctx, counterBatchesKey, id)
+		return nil
+	})
+	return err
+}
+
+func (cache *counterCache) shardKey(biz string, bizID int64) string {
+	return cache.shardKeyOf(cache.shard(biz, bizID))
+}
+
+func (cache *counterCache) shard(biz string, bizID int64) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s:%d", biz, bizID)))
+	return int(h.Sum32() % counterShards)
+}
+
+func (cache *counterCache) shardKeyOf(shard int) string {
+	return fmt.Sprintf("interaction:counter:delta:%d", shard)
+}
+
+func (cache *counterCache) batchKey(id string) string {
+	return fmt.Sprintf("interaction:counter:batch:%s", id)
+}
+
+// field 缓冲区中的field，at所在的小时桶作为最后一段
+func (cache *counterCache) field(biz string, bizID int64, field string, at time.Time) string {
+	at = at.Truncate(time.Hour)
+	return fmt.Sprintf("%s:%d:%s:%d", biz, bizID, field, at.UnixMilli())
+}
+
+// parseField 解析 biz:biz_id:计数字段:小时桶，biz中可能包含冒号，所以从后往前解析。
+// 升级之前写入的field没有小时桶，返回的bucket是0
+func (cache *counterCache) parseField(f string) (string, int64, string, int64, bool) {
+	var bucket int64
+	if i := strings.LastIndexByte(f, ':'); i > 0 {
+		if b, err := strconv.ParseInt(f[i+1:], 10, 64); err == nil {
+			bucket = b
+			f = f[:i]
+		}
+	}
+	i := strings.LastIndexByte(f, ':')
+	if i <= 0 {
+		return "", 0, "", 0, false
+	}
+	j := strings.LastIndexByte(f[:i], ':')
+	if j <= 0 {
+		return "", 0, "", 0, false
+	}
+	bizID, err := strconv.ParseInt(f[j+1:i], 10, 64)
+	if err != nil {
+		return "", 0, "", 0, false
+	}
+	return f[:j], bizID, f[i+1:], bucket, true
+}
+
+func (cache *counterCache) toInt64(val any) int64 {
+	s, ok := val.(string)
+	if !ok {
+		return 0
+	}
+	res, _ := strconv.ParseInt(s, 10, 64)
+	return res
+}
@@ -0,0 +1,277 @@
+package cache
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newMiniredis lua脚本需要真正执行，使用miniredis而不是mock
+func newMiniredis(t *testing.T) (*miniredis.Miniredis, redis.Cmdable) {
+	mr := miniredis.RunT(t)
+	return mr, redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestCounterCache_TakeBatches(t *testing.T) {
+	mr, cmd := newMiniredis(t)
+	cache := NewCounterCache(cmd).(*counterCache)
+	ctx := context.Background()
+	// 阅读发生在一个小时之前，表态发生在现在
+	readAt := time.Now().Add(-time.Hour)
+	readField := cache.field("article", 1, readCntField, readAt)
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 2, readAt))
+	require.NoError(t, cache.IncrReaction(ctx, "article", 1, domain.ReactionNone, domain.ReactionLove))
+	// 之前崩溃遗留的批次
+	_, err := cmd.SAdd(ctx, counterBatchesKey, "3:100").Result()
+	require.NoError(t, err)
+
+	ids, err := cache.TakeBatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	assert.Contains(t, ids, "3:100")
+	// 只有一个分片有增量，这个分片改名成了批次，空的分片不会产生批次
+	shard := cache.shardKey("article", 1)
+	assert.False(t, mr.Exists(shard))
+	var taken string
+	for _, id := range ids {
+		if id != "3:100" {
+			taken = id
+		}
+	}
+	assert.True(t, strings.HasPrefix(taken, strings.TrimPrefix(shard, "interaction:counter:delta:")+":"))
+	assert.Equal(t, "2", mr.HGet(cache.batchKey(taken), readField))
+
+	// 取出之后缓冲区中的新增量进入下一个批次
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 1, readAt))
+	assert.Equal(t, "1", mr.HGet(shard, readField))
+
+	// 不同小时桶的增量分开写入
+	batch, err := cache.GetBatch(ctx, taken)
+	require.NoError(t, err)
+	require.Len(t, batch.Deltas, 2)
+	deltas := make(map[int64]domain.CounterDelta, 2)
+	for _, d := range batch.Deltas {
+		deltas[d.Bucket.UnixMilli()] = d
+	}
+	readHour := readAt.Truncate(time.Hour)
+	assert.Equal(t, domain.CounterDelta{Biz: "article", BizID: 1, Bucket: readHour, Views: 2},
+		deltas[readHour.UnixMilli()])
+	likeHour := readHour.Add(time.Hour)
+	assert.Equal(t, domain.CounterDelta{
+		Biz:       "article",
+		BizID:     1,
+		Bucket:    likeHour,
+		Likes:     1,
+		Reactions: map[domain.Reaction]int64{domain.ReactionLove: 1},
+	}, deltas[likeHour.UnixMilli()])
+
+	require.NoError(t, cache.DelBatch(ctx, taken))
+	assert.False(t, mr.Exists(cache.batchKey(taken)))
+	ids, err = cmd.SMembers(ctx, counterBatchesKey).Result()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"3:100"}, ids)
+}
+
+func TestCounterCache_Pending(t *testing.T) {
+	mr, cmd := newMiniredis(t)
+	cache := NewCounterCache(cmd).(*counterCache)
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 2, now.Add(-time.Hour)))
+	require.NoError(t, cache.IncrReaction(ctx, "article", 1, domain.ReactionNone, domain.ReactionLike))
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 2, 5, now))
+	// biz中有冒号时匹配到的其他资源
+	require.NoError(t, cache.IncrReadCnt(ctx, "article:1", 2, 7, now))
+	// 升级之前写入的field没有小时桶
+	require.NoError(t, cmd.HIncrBy(ctx, cache.shardKey("article", 1), "article:1:read_cnt", 4).Err())
+	_, err := cache.TakeBatches(ctx)
+	require.NoError(t, err)
+	// 取出批次之后、写入数据库之前的增量
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 1, now))
+	require.NoError(t, cache.IncrReaction(ctx, "article", 1, domain.ReactionLike, domain.ReactionFunny))
+
+	pending, err := cache.Pending(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CounterDelta{
+		Biz:   "article",
+		BizID: 1,
+		Views: 7,
+		Likes: 1,
+		Reactions: map[domain.Reaction]int64{
+			domain.ReactionFunny: 1,
+		},
+	}, pending)
+
+	// 删除资源时同时删除缓冲区和批次中的增量，其他资源不受影响
+	require.NoError(t, cache.Del(ctx, "article", 1))
+	pending, err = cache.Pending(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.True(t, pending.IsZero())
+	pending, err = cache.Pending(ctx, "article", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), pending.Views)
+	pending, err = cache.Pending(ctx, "article:1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), pending.Views)
+	assert.True(t, mr.Exists(counterBatchesKey))
+}
+
+func TestCounterCache_PendingByIDs(t *testing.T) {
+	_, cmd := newMiniredis(t)
+	cache := NewCounterCache(cmd).(*counterCache)
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 2, now.Add(-time.Hour)))
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 2, 5, now))
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 3, 1, now))
+	require.NoError(t, cache.IncrReadCnt(ctx, "article:1", 2, 7, now))
+	_, err := cache.TakeBatches(ctx)
+	require.NoError(t, err)
+	// 取出批次之后的增量和批次中的增量加在一起
+	require.NoError(t, cache.IncrReadCnt(ctx, "article", 1, 1, now))
+	require.NoError(t, cache.IncrReaction(ctx, "article", 1, domain.ReactionNone, domain.ReactionLike))
+	// 增量互相抵消的资源不返回
+	require.NoError(t, cache.IncrReaction(ctx, "article", 4, domain.ReactionNone, domain.ReactionLike))
+	require.NoError(t, cache.IncrReaction(ctx, "article", 4, domain.ReactionLike, domain.ReactionNone))
+
+	pendings, err := cache.PendingByIDs(ctx, "article", []int64{1, 2, 4, 5})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]domain.CounterDelta{
+		1: {
+			Biz:       "article",
+			BizID:     1,
+			Views:     3,
+			Likes:     1,
+			Reactions: map[domain.Reaction]int64{domain.ReactionLike: 1},
+		},
+		2: {Biz: "article", BizID: 2, Views: 5},
+	}, pendings)
+	// 和单个查询的结果一致
+	pending, err := cache.Pending(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, pending, pendings[1])
+}
+
+func TestCounterCache_parseField(t *testing.T) {
+	testCases := []struct {
+		name  string
+		field string
+
+		wantBiz    string
+		wantBizID  int64
+		wantField  string
+		wantBucket int64
+		wantOK     bool
+	}{
+		{
+			name:       "正常的field",
+			field:      "article:1:read_cnt:1699999200000",
+			wantBiz:    "article",
+			wantBizID:  1,
+			wantField:  "read_cnt",
+			wantBucket: 1699999200000,
+			wantOK:     true,
+		},
+		{
+			name:       "biz中有冒号",
+			field:      "course:chapter:12:react_2:1699999200000",
+			wantBiz:    "course:chapter",
+			wantBizID:  12,
+			wantField:  "react_2",
+			wantBucket: 1699999200000,
+			wantOK:     true,
+		},
+		{
+			name:      "升级之前没有小时桶的field",
+			field:     "article:1:read_cnt",
+			wantBiz:   "article",
+			wantBizID: 1,
+			wantField: "read_cnt",
+			wantOK:    true,
+		},
+		{
+			name:  "缺少biz",
+			field: ":1:read_cnt",
+		},
+		{
+			name:  "biz_id不是数字",
+			field: "article:abc:read_cnt",
+		},
+		{
+			name:  "只有一段",
+			field: "read_cnt",
+		},
+	}
+
+	cache := &counterCache{}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			biz, bizID, field, bucket, ok := cache.parseField(tc.field)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantBucket, bucket)
+			assert.Equal(t, tc.wantBiz, biz)
+			assert.Equal(t, tc.wantBizID, bizID)
+			assert.Equal(t, tc.wantField, field)
+		})
+	}
+}
+
+func TestCounterCache_IncrReaction(t *testing.T) {
+	testCases := []struct {
+		name string
+		// before 交互数据的缓存，nil表示缓存不存在
+		before map[string]string
+
+		wantCache map[string]string
+	}{
+		{
+			name: "缓存存在，同时修改缓存",
+			before: map[string]string{
+				likeCntField: "3", reactionField(domain.ReactionLike): "2", reactionField(domain.ReactionLove): "1",
+			},
+			wantCache: map[string]string{
+				likeCntField: "3", reactionField(domain.ReactionLike): "1", reactionField(domain.ReactionLove): "2",
+			},
+		},
+		{
+			name: "缓存不存在",
+		},
+		{
+			// 表态上线之前写入的缓存，在0上累加会得到负数
+			name:   "缓存中没有表态的数量，删除缓存",
+			before: map[string]string{likeCntField: "3", readCntField: "10"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr, cmd := newMiniredis(t)
+			cache := NewCounterCache(cmd).(*counterCache)
+			ctx := context.Background()
+			key := "interaction:article:1"
+			for f, v := range tc.before {
+				mr.HSet(key, f, v)
+			}
+
+			require.NoError(t, cache.IncrReaction(ctx, "article", 1, domain.ReactionLike, domain.ReactionLove))
+			if tc.wantCache == nil {
+				assert.False(t, mr.Exists(key))
+			} else {
+				got, err := cmd.HGetAll(ctx, key).Result()
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantCache, got)
+			}
+			// 不管缓存是否存在，增量都写入缓冲区
+			pending, err := cache.Pending(ctx, "article", 1)
+			require.NoError(t, err)
+			assert.Equal(t, map[domain.Reaction]int64{domain.ReactionLike: -1, domain.ReactionLove: 1}, pending.Reactions)
+			assert.Equal(t, int64(0), pending.Likes)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"learn_go/webook/interaction/domain"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,9 +17,29 @@ var (
 
 	likeCntField     = "like_cnt"
 	readCntField     = "read_cnt"
+	uniqueCntField   = "unique_cnt"
 	favoriteCntField = "favorite_cnt"
+	commentCntField  = "comment_cnt"
+	// reactionFieldPrefix 每种表态的数量，field是 react_表态，不能包含冒号，写回缓冲区按冒号解析field
+	reactionFieldPrefix = "react_"
 )
 
+func reactionField(r domain.Reaction) string {
+	return reactionFieldPrefix + strconv.Itoa(int(r))
+}
+
+// parseReactionField 解析 react_表态，不是表态的field返回false
+func parseReactionField(field string) (domain.Reaction, bool) {
+	if !strings.HasPrefix(field, reactionFieldPrefix) {
+		return domain.ReactionNone, false
+	}
+	r, err := strconv.ParseUint(field[len(reactionFieldPrefix):], 10, 8)
+	if err != nil || !domain.Reaction(r).Valid() {
+		return domain.ReactionNone, false
+	}
+	return domain.Reaction(r), true
+}
+
 // InteractionCache 使用hash来存储文章的交互信息
 type InteractionCache interface {
 	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
@@ -30,8 +51,12 @@ type InteractionCache interface {
 	IncrFavoriteCnt(ctx context.Context, biz string, bizID int64) error
 	DecrFavoriteCnt(ctx context.Context, biz string, bizID int64) error
 
+	// IncrCommentCnt 修改评论数，删除评论时delta为负数
+	IncrCommentCnt(ctx context.Context, biz string, bizID int64, delta int64) error
+
 	Get(ctx context.Context, biz string, bizID int64) (domain.Interaction, error)
 	Set(ctx context.Context, biz string, bizID int64, interaction domain.Interaction) error
+	Del(ctx context.Context, biz string, bizID int64) error
 }
 
 func (cache *interactionCache) Get(ctx context.Context, biz string, bizID int64) (domain.Interaction, error) {
@@ -47,19 +72,39 @@ func (cache *interactionCache) Get(ctx context.Context, biz string, bizID int64)
 	if len(res) == 0 {
 		return inter, ErrKeyNotExist
 	}
+	// 表态上线之前写入的缓存中没有每种表态的数量，当作缓存不存在，从数据库重新加载
+	if _, ok := res[reactionField(domain.ReactionLike)]; !ok {
+		return inter, ErrKeyNotExist
+	}
 	inter.Likes, _ = strconv.ParseInt(res[likeCntField], 10, 64)
 	inter.Views, _ = strconv.ParseInt(res[readCntField], 10, 64)
+	inter.UniqueViews, _ = strconv.ParseInt(res[uniqueCntField], 10, 64)
 	inter.Favorites, _ = strconv.ParseInt(res[favoriteCntField], 10, 64)
+	inter.Comments, _ = strconv.ParseInt(res[commentCntField], 10, 64)
+	inter.Reactions = make(map[domain.Reaction]int64, len(domain.Reactions))
+	for field, val := range res {
+		if r, ok := parseReactionField(field); ok {
+			inter.Reactions[r], _ = strconv.ParseInt(val, 10, 64)
+		}
+	}
 	return inter, nil
 }
 
 func (cache *interactionCache) Set(ctx context.Context, biz string, bizID int64, interaction domain.Interaction) error {
 	key := cache.key(biz, bizID)
 
-	err := cache.cmd.HSet(ctx, key,
+	values := []any{
 		likeCntField, interaction.Likes,
 		readCntField, interaction.Views,
-		favoriteCntField, interaction.Favorites).Err()
+		uniqueCntField, interaction.UniqueViews,
+		favoriteCntField, interaction.Favorites,
+		commentCntField, interaction.Comments,
+	}
+	// 所有表态都写入缓存，没有的表态是0，写回时直接hincrby
+	for _, r := range domain.Reactions {
+		values = append(values, reactionField(r), interaction.Reactions[r])
+	}
+	err := cache.cmd.HSet(ctx, key, values...).Err()
 	if err != nil {
 		return err
 	}
@@ -103,3 +148,11 @@ func (cache *interactionCache) IncrFavoriteCnt(ctx context.Context, biz string,
 func (cache *interactionCache) DecrFavoriteCnt(ctx context.Context, biz string, bizID int64) error {
 	return cache.cmd.Eval(ctx, script, []string{cache.key(biz, bizID)}, []any{favoriteCntField, -1}).Err()
 }
+
+func (cache *interactionCache) IncrCommentCnt(ctx context.Context, biz string, bizID int64, delta int64) error {
+	return cache.cmd.Eval(ctx, script, []string{cache.key(biz, bizID)}, []any{commentCntField, delta}).Err()
+}
+
+func (cache *interactionCache) Del(ctx context.Context, biz string, bizID int64) error {
+	return cache.cmd.Del(ctx, cache.key(biz, bizID)).Err()
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"testing"
+)
+
+func TestInteractionCache_Get(t *testing.T) {
+	mr, cmd := newMiniredis(t)
+	cache := NewInteractionCache(cmd).(*interactionCache)
+	ctx := context.Background()
+
+	_, err := cache.Get(ctx, "article", 1)
+	assert.Equal(t, ErrKeyNotExist, err)
+
+	// 表态上线之前写入的缓存，没有每种表态的数量，需要从数据库重新加载
+	mr.HSet(cache.key("article", 1), likeCntField, "3", readCntField, "10")
+	_, err = cache.Get(ctx, "article", 1)
+	assert.Equal(t, ErrKeyNotExist, err)
+
+	inter := domain.Interaction{
+		Likes:     3,
+		Views:     10,
+		Reactions: map[domain.Reaction]int64{domain.ReactionLike: 2, domain.ReactionLove: 1},
+	}
+	require.NoError(t, cache.Set(ctx, "article", 1, inter))
+	got, err := cache.Get(ctx, "article", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), got.Likes)
+	assert.Equal(t, int64(10), got.Views)
+	// 没有的表态也写入了缓存，数量是0
+	for _, r := range domain.Reactions {
+		assert.Equal(t, inter.Reactions[r], got.Reactions[r])
+	}
+	assert.Len(t, got.Reactions, len(domain.Reactions))
+}
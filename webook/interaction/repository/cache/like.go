@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"learn_go/webook/interaction/domain"
+	"strconv"
+	"time"
+)
+
+var (
+	//go:embed lua/like_add.lua
+	likeAddScript string
+	//go:embed lua/like_check.lua
+	likeCheckScript string
+	//go:embed lua/like_set.lua
+	likeSetScript string
+)
+
+const (
+	// MaxRecentLikes 每个用户最多缓存的点赞数量，点赞更多的用户只缓存最近的点赞
+	MaxRecentLikes = 1000
+	// likeCompleteMember 完整标记，缓存中包含用户全部的点赞时才有。biz_id不会是0，不会和点赞冲突
+	likeCompleteMember = "0"
+
+	likeExpiration = time.Minute * 30
+)
+
+// LikeCache 用户最近点赞的资源，用zset保存，score是点赞时间。
+// 大多数用户的点赞不超过MaxRecentLikes，缓存是完整的，批量判断是否点赞时不需要查询数据库。
+//
+// 每个用户还有一个版本号，每次点赞、取消点赞都会增加，缓存不存在时也一样。
+// 重建缓存时先读版本号再查询数据库，写入缓存时版本号变了说明查询期间有新的点赞或者取消，查询结果已经旧了，不写入缓存
+type LikeCache interface {
+	// Add 增加版本号，缓存存在时加入一条点赞，超过上限时淘汰最早的点赞
+	Add(ctx context.Context, like domain.UserLike) error
+	// Remove 增加版本号，从缓存中删除一条点赞
+	Remove(ctx context.Context, uid int64, biz string, bizID int64) error
+	// RemoveUsers 从uids的缓存中删除bizID，资源被彻底删除时使用
+	RemoveUsers(ctx context.Context, uids []int64, biz string, bizID int64) error
+	// Check 返回bizIDs中在缓存里的资源。complete为true时缓存中是用户全部的点赞，不在缓存里的就是没有点赞。
+	// 缓存不存在时返回ErrKeyNotExist
+	Check(ctx context.Context, uid int64, biz string, bizIDs []int64) (liked map[int64]bool, complete bool, err error)
+	// Version 查询用户点赞的版本号，重建缓存之前调用
+	Version(ctx context.Context, uid int64, biz string) (int64, error)
+	// Set 用最近的点赞重建缓存，complete表示likes是用户全部的点赞。
+	// version是查询likes之前的版本号，版本号已经变了时不重建，返回false
+	Set(ctx context.Context, uid int64, biz string, likes []domain.UserLike, complete bool, version int64) (bool, error)
+	// Del 删除缓存并增加版本号
+	Del(ctx context.Context, uid int64, biz string) error
+}
+
+type likeCache struct {
+	cmd redis.Cmdable
+}
+
+func NewLikeCache(cmd redis.Cmdable) LikeCache {
+	return &likeCache{
+		cmd: cmd,
+	}
+}
+
+func (cache *likeCache) Add(ctx context.Context, like domain.UserLike) error {
+	keys := []string{cache.key(like.Uid, like.Biz), cache.versionKey(like.Uid, like.Biz)}
+	return cache.cmd.Eval(ctx, likeAddScript, keys,
+		like.UTime.UnixMilli(), like.BizID, MaxRecentLikes+1, int64(likeExpiration/time.Second)).Err()
+}
+
+func (cache *likeCache) Remove(ctx context.Context, uid int64, biz string, bizID int64) error {
+	_, err := cache.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		cache.incrVersion(ctx, pipe, uid, biz)
+		pipe.ZRem(ctx, cache.key(uid, biz), bizID)
+		return nil
+	})
+	return err
+}
+
+func (cache *likeCache) RemoveUsers(ctx context.Context, uids []int64, biz string, bizID int64) error {
+	_, err := cache.cmd.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, uid := range uids {
+			cache.incrVersion(ctx, pipe, uid, biz)
+			pipe.ZRem(ctx, cache.key(uid, biz), bizID)
+		}
+		return nil
+	})
+	return err
+}
+
+func (cache *likeCache) Check(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, bool, error) {
+	args := make([]any, 0, len(bizIDs)+1)
+	args = append(args, likeCompleteMember)
+	for _, id := range bizIDs {
+		args = append(args, id)
+	}
+	vals, err := cache.cmd.Eval(ctx, likeCheckScript, []string{cache.key(uid, biz)}, args...).Int64Slice()
+	if err != nil {
+		return nil, false, err
+	}
+	if len(vals) != len(args) {
+		return nil, false, fmt.Errorf("点赞缓存结果错误 %v", vals)
+	}
+	liked := make(map[int64]bool, len(bizIDs))
+	for i, id := range bizIDs {
+		if vals[i+1] == 1 {
+			liked[id] = true
+		}
+	}
+	return liked, vals[0] == 1, nil
+}
+
+func (cache *likeCache) Version(ctx context.Context, uid int64, biz string) (int64, error) {
+	version, err := cache.cmd.Get(ctx, cache.versionKey(uid, biz)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (cache *likeCache) Set(ctx context.Context, uid int64, biz string, likes []domain.UserLike,
+	complete bool, version int64) (bool, error) {
+	args := make([]any, 0, len(likes)*2+4)
+	args = append(args, version, int64(likeExpiration/time.Second))
+	for _, l := range likes {
+		args = append(args, l.UTime.UnixMilli(), strconv.FormatInt(l.BizID, 10))
+	}
+	if complete {
+		args = append(args, 0, likeCompleteMember)
+	}
+	keys := []string{cache.key(uid, biz), cache.versionKey(uid, biz)}
+	res, err := cache.cmd.Eval(ctx, likeSetScript, keys, args...).Int()
+	return res == 1, err
+}
+
+func (cache *likeCache) Del(ctx context.Context, uid int64, biz string) error {
+	_, err := cache.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		cache.incrVersion(ctx, pipe, uid, biz)
+		pipe.Del(ctx, cache.key(uid, biz))
+		return nil
+	})
+	return err
+}
+
+func (cache *likeCache) incrVersion(ctx context.Context, pipe redis.Pipeliner, uid int64, biz string) {
+	key := cache.versionKey(uid, biz)
+	pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, likeExpiration)
+}
+
+func (cache *likeCache) key(uid int64, biz string) string {
+	return fmt.Sprintf("interaction:user_likes:%s:%d", biz, uid)
+}
+
+func (cache *likeCache) versionKey(uid int64, biz string) string {
+	return fmt.Sprintf("interaction:user_likes_version:%s:%d", biz, uid)
+}
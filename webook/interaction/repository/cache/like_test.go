@@ -0,0 +1,146 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"testing"
+	"time"
+)
+
+func TestLikeCache_Add(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+
+	t.Run("缓存不存在时不加入，只增加版本号", func(t *testing.T) {
+		mr, cmd := newMiniredis(t)
+		cache := NewLikeCache(cmd).(*likeCache)
+		ctx := context.Background()
+		require.NoError(t, cache.Add(ctx, domain.UserLike{Uid: 1001, Biz: "article", BizID: 1, UTime: now}))
+		assert.False(t, mr.Exists(cache.key(1001, "article")))
+		version, err := cache.Version(ctx, 1001, "article")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), version)
+	})
+
+	t.Run("超过上限时淘汰最早的点赞和完整标记", func(t *testing.T) {
+		mr, cmd := newMiniredis(t)
+		cache := NewLikeCache(cmd).(*likeCache)
+		ctx := context.Background()
+		likes := make([]domain.UserLike, 0, MaxRecentLikes)
+		for i := 1; i <= MaxRecentLikes; i++ {
+			likes = append(likes, domain.UserLike{BizID: int64(i), UTime: now.Add(time.Duration(i) * time.Second)})
+		}
+		ok, err := cache.Set(ctx, 1001, "article", likes, true, 0)
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, complete, err := cache.Check(ctx, 1001, "article", nil)
+		require.NoError(t, err)
+		assert.True(t, complete)
+
+		// 缓存已经满了，完整标记的score最小，最先被淘汰
+		later := now.Add(time.Hour)
+		require.NoError(t, cache.Add(ctx, domain.UserLike{Uid: 1001, Biz: "article", BizID: 5000, UTime: later}))
+		members, err := mr.ZMembers(cache.key(1001, "article"))
+		require.NoError(t, err)
+		assert.Len(t, members, MaxRecentLikes+1)
+		liked, complete, err := cache.Check(ctx, 1001, "article", []int64{1, 5000})
+		require.NoError(t, err)
+		assert.False(t, complete)
+		assert.Equal(t, map[int64]bool{1: true, 5000: true}, liked)
+
+		// 再加入一条，淘汰最早的点赞
+		require.NoError(t, cache.Add(ctx, domain.UserLike{Uid: 1001, Biz: "article", BizID: 5001, UTime: later}))
+		liked, _, err = cache.Check(ctx, 1001, "article", []int64{1, 2, 5001})
+		require.NoError(t, err)
+		assert.Equal(t, map[int64]bool{2: true, 5001: true}, liked)
+		assert.True(t, mr.TTL(cache.key(1001, "article")) > 0)
+	})
+}
+
+func TestLikeCache_Check(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	_, cmd := newMiniredis(t)
+	cache := NewLikeCache(cmd).(*likeCache)
+	ctx := context.Background()
+
+	_, _, err := cache.Check(ctx, 1001, "article", []int64{1})
+	assert.Equal(t, ErrKeyNotExist, err)
+
+	testCases := []struct {
+		name     string
+		likes    []domain.UserLike
+		complete bool
+
+		wantLiked map[int64]bool
+	}{
+		{
+			name:      "完整的缓存",
+			likes:     []domain.UserLike{{BizID: 1, UTime: now}, {BizID: 3, UTime: now}},
+			complete:  true,
+			wantLiked: map[int64]bool{1: true, 3: true},
+		},
+		{
+			name:      "只有最近的点赞",
+			likes:     []domain.UserLike{{BizID: 1, UTime: now}},
+			wantLiked: map[int64]bool{1: true},
+		},
+		{
+			// 没有点赞的用户也有缓存，只有完整标记
+			name:      "没有点赞",
+			complete:  true,
+			wantLiked: map[int64]bool{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := cache.Set(ctx, 1001, "article", tc.likes, tc.complete, 0)
+			require.NoError(t, err)
+			require.True(t, ok)
+			liked, complete, err := cache.Check(ctx, 1001, "article", []int64{1, 2, 3})
+			require.NoError(t, err)
+			assert.Equal(t, tc.complete, complete)
+			assert.Equal(t, tc.wantLiked, liked)
+		})
+	}
+}
+
+func TestLikeCache_Set(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	mr, cmd := newMiniredis(t)
+	cache := NewLikeCache(cmd).(*likeCache)
+	ctx := context.Background()
+
+	version, err := cache.Version(ctx, 1001, "article")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), version)
+	// 查询数据库期间用户取消了点赞，查询结果已经旧了，不重建
+	require.NoError(t, cache.Remove(ctx, 1001, "article", 1))
+	ok, err := cache.Set(ctx, 1001, "article", []domain.UserLike{{BizID: 1, UTime: now}}, true, version)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.False(t, mr.Exists(cache.key(1001, "article")))
+
+	version, err = cache.Version(ctx, 1001, "article")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+	ok, err = cache.Set(ctx, 1001, "article", []domain.UserLike{{BizID: 2, UTime: now}}, true, version)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	members, err := mr.ZMembers(cache.key(1001, "article"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{likeCompleteMember, "2"}, members)
+
+	// 删除缓存也会增加版本号
+	require.NoError(t, cache.Del(ctx, 1001, "article"))
+	ok, err = cache.Set(ctx, 1001, "article", nil, true, version)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// 删除资源时增加每个点赞用户的版本号
+	require.NoError(t, cache.RemoveUsers(ctx, []int64{1001, 1002}, "article", 2))
+	version, err = cache.Version(ctx, 1002, "article")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), version)
+}
@@ -6,9 +6,16 @@ local key = KEYS[1]
 local field = ARGV[1]
 local value = tonumber(ARGV[2])
 
+-- 写回模式：KEYS[2]是增量缓冲区，ARGV[3]是缓冲区中的field。
+-- 不管缓存是否存在都要累加增量，定时任务再把增量写入数据库
+if #KEYS > 1 then
+    redis.call("hincrby", KEYS[2], ARGV[3], value)
+end
+
 if redis.call("exists", key) == 1 then
     -- 返回自增后的值
     return redis.call("hincrby", key, field, value)
 else
-    return nil
+    -- 缓存不存在时返回0，返回nil会被客户端当作redis.Nil错误
+    return 0
 end
\ No newline at end of file
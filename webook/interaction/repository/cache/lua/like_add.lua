@@ -0,0 +1,24 @@
+-- 缓存存在时加入一条点赞，缓存不存在时不加入，避免只有部分点赞的缓存被当成完整的
+
+-- 用户最近点赞的zset，score是点赞时间
+local key = KEYS[1]
+-- 用户点赞的版本号，缓存不存在时也要增加，正在从数据库重建的缓存不会覆盖这次点赞
+local version = KEYS[2]
+
+local score = ARGV[1]
+local member = ARGV[2]
+-- 最多保留的成员数量，包括完整标记
+local capacity = tonumber(ARGV[3])
+-- 过期时间（秒）
+local ttl = ARGV[4]
+
+redis.call("incr", version)
+redis.call("expire", version, ttl)
+if redis.call("exists", key) == 0 then
+    return 0
+end
+redis.call("zadd", key, score, member)
+-- 淘汰点赞时间最早的成员。完整标记的score是0，超过上限时最先被淘汰，之后缓存就不再是完整的了
+redis.call("zremrangebyrank", key, 0, -capacity - 1)
+redis.call("expire", key, ttl)
+return 1
@@ -0,0 +1,16 @@
+-- 批量判断成员是否在用户最近点赞的zset中，缓存不存在时返回nil
+
+local key = KEYS[1]
+
+if redis.call("exists", key) == 0 then
+    return nil
+end
+local res = {}
+for i = 1, #ARGV do
+    if redis.call("zscore", key, ARGV[i]) then
+        res[i] = 1
+    else
+        res[i] = 0
+    end
+end
+return res
@@ -0,0 +1,22 @@
+-- 用从数据库查询的点赞重建缓存。查询之后用户又点赞或者取消了点赞时版本号已经变了，查询结果是旧的，不重建
+
+-- 用户最近点赞的zset，score是点赞时间
+local key = KEYS[1]
+-- 用户点赞的版本号，不存在时是0
+local version = KEYS[2]
+
+-- 查询数据库之前读到的版本号
+local expected = ARGV[1]
+-- 过期时间（秒）
+local ttl = ARGV[2]
+
+if (redis.call("get", version) or "0") ~= expected then
+    return 0
+end
+redis.call("del", key)
+-- ARGV[3]开始是若干组 score, member
+if #ARGV > 2 then
+    redis.call("zadd", key, unpack(ARGV, 3))
+    redis.call("expire", key, ttl)
+end
+return 1
@@ -0,0 +1,25 @@
+-- KEYS[1]是交互数据的缓存，KEYS[2]是增量缓冲区
+local key = KEYS[1]
+local buffer = KEYS[2]
+
+-- ARGV是若干组 缓存的field, 增量, 缓冲区的field。
+-- 切换表态时旧表态-1、新表态+1在一个脚本里完成，不会只改了一半
+local exists = redis.call("exists", key) == 1
+if exists then
+    -- 表态上线之前写入的缓存中没有每种表态的数量，在0上累加会得到负数，删除缓存，下次从数据库加载
+    for i = 1, #ARGV, 3 do
+        if redis.call("hexists", key, ARGV[i]) == 0 then
+            redis.call("del", key)
+            exists = false
+            break
+        end
+    end
+end
+for i = 1, #ARGV, 3 do
+    local value = tonumber(ARGV[i + 1])
+    redis.call("hincrby", buffer, ARGV[i + 2], value)
+    if exists then
+        redis.call("hincrby", key, ARGV[i], value)
+    end
+end
+return 0
@@ -0,0 +1,16 @@
+-- 把增量缓冲区的一个分片原子地改名为一个批次，并记录到未完成的批次集合中
+
+-- 缓冲区分片
+local shard = KEYS[1]
+-- 批次
+local batch = KEYS[2]
+-- 未完成的批次集合
+local batches = KEYS[3]
+local batchID = ARGV[1]
+
+if redis.call("exists", shard) == 0 then
+    return 0
+end
+redis.call("rename", shard, batch)
+redis.call("sadd", batches, batchID)
+return 1
\ No newline at end of file
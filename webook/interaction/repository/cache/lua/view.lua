@@ -0,0 +1,24 @@
+-- 记录一次阅读：去重窗口内同一个用户的重复阅读不计入阅读数，当天的HyperLogLog统计独立读者
+
+-- 用户对资源的去重key
+local dedupKey = KEYS[1]
+-- 资源当天读者的HyperLogLog
+local hllKey = KEYS[2]
+
+-- 去重窗口（秒）
+local window = ARGV[1]
+local uid = ARGV[2]
+-- HyperLogLog的过期时间（秒）
+local ttl = ARGV[3]
+
+local counted = 0
+if redis.call("set", dedupKey, 1, "NX", "EX", window) then
+    counted = 1
+end
+
+-- 基数估计值变化时返回1，认为是当天的新读者
+local unique = redis.call("pfadd", hllKey, uid)
+if unique == 1 then
+    redis.call("expire", hllKey, ttl)
+end
+return {counted, unique}
\ No newline at end of file
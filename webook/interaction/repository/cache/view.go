@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"learn_go/webook/interaction/domain"
+	"strconv"
+	"time"
+)
+
+var (
+	//go:embed lua/view.lua
+	viewScript string
+)
+
+const (
+	// viewDedupWindow 同一个用户在这个时间内重复阅读只计一次阅读数
+	viewDedupWindow = time.Minute * 30
+	// viewHLLTTL 每天的HyperLogLog多保留一天，跨天的阅读事件也能正确去重
+	viewHLLTTL = time.Hour * 48
+)
+
+// ViewCache 阅读去重：去重窗口过滤刷新页面，HyperLogLog按天统计独立读者
+type ViewCache interface {
+	// Dedup 记录一批阅读，返回每次阅读是否计入阅读数、是否是资源当天的新读者
+	Dedup(ctx context.Context, views []domain.View) ([]domain.ViewResult, error)
+	// Del 删除资源还没有过期的HyperLogLog，资源被彻底删除时使用
+	Del(ctx context.Context, biz string, bizID int64) error
+}
+
+type viewCache struct {
+	cmd redis.Cmdable
+}
+
+func NewViewCache(cmd redis.Cmdable) ViewCache {
+	return &viewCache{
+		cmd: cmd,
+	}
+}
+
+func (cache *viewCache) Dedup(ctx context.Context, views []domain.View) ([]domain.ViewResult, error) {
+	cmds := make([]*redis.Cmd, 0, len(views))
+	_, err := cache.cmd.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, v := range views {
+			keys := []string{cache.dedupKey(v), cache.hllKey(v.Biz, v.BizID, v.Time)}
+			cmds = append(cmds, pipe.Eval(ctx, viewScript, keys,
+				int64(viewDedupWindow/time.Second), v.Uid, int64(viewHLLTTL/time.Second)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := make([]domain.ViewResult, 0, len(views))
+	for i, cmd := range cmds {
+		vals, err := cmd.Int64Slice()
+		if err != nil || len(vals) != 2 {
+			return nil, fmt.Errorf("阅读去重结果错误 %v: %w", vals, err)
+		}
+		res = append(res, domain.ViewResult{
+			View:    views[i],
+			Counted: vals[0] == 1,
+			Unique:  vals[1] == 1,
+		})
+	}
+	return res, nil
+}
+
+func (cache *viewCache) Del(ctx context.Context, biz string, bizID int64) error {
+	// 去重key按照用户分开，扫描需要遍历整个keyspace。它们在viewDedupWindow之后就会过期，不需要删除
+	// HyperLogLog保留viewHLLTTL，这段时间内每一天的都可能存在
+	keys := make([]string, 0, 3)
+	now := time.Now()
+	for day := now.Add(-viewHLLTTL); !day.After(now); day = day.Add(time.Hour * 24) {
+		keys = append(keys, cache.hllKey(biz, bizID, day))
+	}
+	return cache.cmd.Del(ctx, keys...).Err()
+}
+
+func (cache *viewCache) dedupKey(v domain.View) string {
+	return cache.dedupKeyOf(v.Biz, v.BizID, strconv.FormatInt(v.Uid, 10))
+}
+
+func (cache *viewCache) dedupKeyOf(biz string, bizID int64, uid string) string {
+	return fmt.Sprintf("interaction:view:%s:%d:%s", biz, bizID, uid)
+}
+
+func (cache *viewCache) hllKey(biz string, bizID int64, day time.Time) string {
+	return fmt.Sprintf("interaction:uv:%s:%d:%s", biz, bizID, day.Format("20060102"))
+}
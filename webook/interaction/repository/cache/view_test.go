@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"testing"
+	"time"
+)
+
+func TestViewCache_Dedup(t *testing.T) {
+	mr, cmd := newMiniredis(t)
+	cache := NewViewCache(cmd).(*viewCache)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 10, 0, 0, 0, time.Local)
+	view := domain.View{Uid: 1001, Biz: "article", BizID: 1, Time: now}
+
+	testCases := []struct {
+		name    string
+		before  func()
+		views   []domain.View
+		counted []bool
+		unique  []bool
+	}{
+		{
+			name:    "第一次阅读",
+			views:   []domain.View{view},
+			counted: []bool{true},
+			unique:  []bool{true},
+		},
+		{
+			name: "去重窗口内重复阅读，同一批中的重复阅读也只计一次",
+			views: []domain.View{view, view, func() domain.View {
+				v := view
+				v.Uid = 1002
+				return v
+			}()},
+			counted: []bool{false, false, true},
+			unique:  []bool{false, false, true},
+		},
+		{
+			name: "去重窗口之后再次阅读，计入阅读数，但是当天已经读过了",
+			before: func() {
+				mr.FastForward(viewDedupWindow + time.Second)
+			},
+			views:   []domain.View{view},
+			counted: []bool{true},
+			unique:  []bool{false},
+		},
+		{
+			name: "第二天是新的读者",
+			before: func() {
+				mr.FastForward(viewDedupWindow + time.Second)
+			},
+			views: []domain.View{func() domain.View {
+				v := view
+				v.Time = now.Add(time.Hour * 24)
+				return v
+			}()},
+			counted: []bool{true},
+			unique:  []bool{true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.before != nil {
+				tc.before()
+			}
+			res, err := cache.Dedup(ctx, tc.views)
+			require.NoError(t, err)
+			require.Len(t, res, len(tc.views))
+			for i, r := range res {
+				assert.Equal(t, tc.views[i], r.View)
+				assert.Equal(t, tc.counted[i], r.Counted)
+				assert.Equal(t, tc.unique[i], r.Unique)
+			}
+		})
+	}
+	// HyperLogLog按天设置过期时间
+	ttl := mr.TTL(cache.hllKey("article", 1, now))
+	assert.True(t, ttl > 0 && ttl <= viewHLLTTL)
+}
+
+func TestViewCache_Del(t *testing.T) {
+	mr, cmd := newMiniredis(t)
+	cache := NewViewCache(cmd).(*viewCache)
+	ctx := context.Background()
+	now := time.Now()
+	_, err := cache.Dedup(ctx, []domain.View{
+		{Uid: 1001, Biz: "article", BizID: 1, Time: now},
+		{Uid: 1002, Biz: "article", BizID: 1, Time: now.Add(-time.Hour * 24)},
+		{Uid: 1001, Biz: "article", BizID: 12, Time: now},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Del(ctx, "article", 1))
+	// 只删除这个资源的HyperLogLog，biz_id是1的前缀的资源不受影响。去重key留着自然过期
+	assert.ElementsMatch(t, []string{
+		cache.dedupKey(domain.View{Uid: 1001, Biz: "article", BizID: 1}),
+		cache.dedupKey(domain.View{Uid: 1002, Biz: "article", BizID: 1}),
+		cache.dedupKey(domain.View{Uid: 1001, Biz: "article", BizID: 12}),
+		cache.hllKey("article", 12, now),
+	}, mr.Keys())
+	mr.FastForward(viewDedupWindow)
+	assert.Equal(t, []string{cache.hllKey("article", 12, now)}, mr.Keys())
+}
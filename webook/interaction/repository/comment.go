@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"time"
+)
+
+//go:generate mockgen -source=./comment.go -package=repomocks -destination=./mocks/comment.mock.go CommentRepository
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Comment, error)
+	UpdateContent(ctx context.Context, id int64, uid int64, content string) error
+	// Delete 删除评论，根评论的回复也会被删除
+	Delete(ctx context.Context, c domain.Comment) error
+
+	// ListRoots 查询没有置顶的根评论，uid用于填充是否点赞
+	ListRoots(ctx context.Context, uid int64, biz string, bizID int64, maxID int64, limit int) ([]domain.Comment, error)
+	ListPopularRoots(ctx context.Context, uid int64, biz string, bizID int64, offset int, limit int) ([]domain.Comment, error)
+	GetPinned(ctx context.Context, uid int64, biz string, bizID int64) (domain.Comment, error)
+	ListReplies(ctx context.Context, uid int64, rootID int64, minID int64, limit int) ([]domain.Comment, error)
+	Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error
+
+	Like(ctx context.Context, uid int64, id int64) error
+	CancelLike(ctx context.Context, uid int64, id int64) error
+}
+
+type commentRepository struct {
+	dao   dao.CommentDao
+	cache cache.InteractionCache
+}
+
+func NewCommentRepository(dao dao.CommentDao, cache cache.InteractionCache) CommentRepository {
+	return &commentRepository{
+		dao:   dao,
+		cache: cache,
+	}
+}
+
+func (repo *commentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	id, err := repo.dao.Insert(ctx, repo.toEntity(c))
+	if err != nil {
+		return 0, err
+	}
+	// 缓存中的评论数+1，缓存不存在时不处理
+	err = repo.cache.IncrCommentCnt(ctx, c.Biz, c.BizID, 1)
+	if err != nil {
+		// 记录日志
+	}
+	return id, nil
+}
+
+func (repo *commentRepository) GetByID(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := repo.dao.GetByID(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return repo.toDomain(c), nil
+}
+
+func (repo *commentRepository) UpdateContent(ctx context.Context, id int64, uid int64, content string) error {
+	return repo.dao.UpdateContent(ctx, id, uid, content)
+}
+
+func (repo *commentRepository) Delete(ctx context.Context, c domain.Comment) error {
+	deleted, err := repo.dao.Delete(ctx, repo.toEntity(c))
+	if err != nil || deleted == 0 {
+		return err
+	}
+	err = repo.cache.IncrCommentCnt(ctx, c.Biz, c.BizID, -deleted)
+	if err != nil {
+		// 记录日志
+	}
+	return nil
+}
+
+func (repo *commentRepository) ListRoots(ctx context.Context, uid int64, biz string, bizID int64, maxID int64, limit int) ([]domain.Comment, error) {
+	res, err := repo.dao.ListRoots(ctx, biz, bizID, maxID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.withLiked(ctx, uid, res)
+}
+
+func (repo *commentRepository) ListPopularRoots(ctx context.Context, uid int64, biz string, bizID int64, offset int, limit int) ([]domain.Comment, error) {
+	res, err := repo.dao.ListPopularRoots(ctx, biz, bizID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.withLiked(ctx, uid, res)
+}
+
+func (repo *commentRepository) GetPinned(ctx context.Context, uid int64, biz string, bizID int64) (domain.Comment, error) {
+	c, err := repo.dao.GetPinned(ctx, biz, bizID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	res, err := repo.withLiked(ctx, uid, []dao.Comment{c})
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return res[0], nil
+}
+
+func (repo *commentRepository) ListReplies(ctx context.Context, uid int64, rootID int64, minID int64, limit int) ([]domain.Comment, error) {
+	res, err := repo.dao.ListReplies(ctx, rootID, minID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return repo.withLiked(ctx, uid, res)
+}
+
+func (repo *commentRepository) Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error {
+	return repo.dao.Pin(ctx, biz, bizID, id, pinned)
+}
+
+func (repo *commentRepository) Like(ctx context.Context, uid int64, id int64) error {
+	return repo.dao.InsertLike(ctx, uid, id)
+}
+
+func (repo *commentRepository) CancelLike(ctx context.Context, uid int64, id int64) error {
+	return repo.dao.DeleteLike(ctx, uid, id)
+}
+
+// withLiked 转换成领域对象，并填充uid是否点赞
+func (repo *commentRepository) withLiked(ctx context.Context, uid int64, comments []dao.Comment) ([]domain.Comment, error) {
+	ids := slice.Map(comments, func(idx int, src dao.Comment) int64 {
+		return src.ID
+	})
+	liked, err := repo.dao.LikedIDs(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	likedSet := make(map[int64]struct{}, len(liked))
+	for _, id := range liked {
+		likedSet[id] = struct{}{}
+	}
+	return slice.Map(comments, func(idx int, src dao.Comment) domain.Comment {
+		c := repo.toDomain(src)
+		_, c.Liked = likedSet[c.ID]
+		return c
+	}), nil
+}
+
+func (repo *commentRepository) toEntity(c domain.Comment) dao.Comment {
+	return dao.Comment{
+		ID:         c.ID,
+		Uid:        c.Uid,
+		Biz:        c.Biz,
+		BizID:      c.BizID,
+		RootID:     c.RootID,
+		ParentID:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Content:    c.Content,
+		LikeCnt:    c.Likes,
+		ReplyCnt:   c.Replies,
+		Pinned:     c.Pinned,
+	}
+}
+
+func (repo *commentRepository) toDomain(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		ID:         c.ID,
+		Uid:        c.Uid,
+		Biz:        c.Biz,
+		BizID:      c.BizID,
+		RootID:     c.RootID,
+		ParentID:   c.ParentID,
+		ReplyToUid: c.ReplyToUid,
+		Content:    c.Content,
+		Likes:      c.LikeCnt,
+		Replies:    c.ReplyCnt,
+		Pinned:     c.Pinned,
+		CTime:      time.UnixMilli(c.Ctime),
+		UTime:      time.UnixMilli(c.Utime),
+	}
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"time"
+)
+
+//go:generate mockgen -source=./counter.go -package=repomocks -destination=./mocks/counter.mock.go CounterRepository
+type CounterRepository interface {
+	// TakeBatches 把缓冲区中的增量取出成批次，返回所有未完成的批次ID
+	TakeBatches(ctx context.Context) ([]string, error)
+	// ApplyBatch 把批次写入数据库之后删除，同一个批次重复写入不会重复计数
+	ApplyBatch(ctx context.Context, id string) error
+	// DeleteFlushLogs 删除早于before的批次记录，晚于这个时间的批次才能安全地重放
+	DeleteFlushLogs(ctx context.Context, before time.Time) error
+
+	// ListUpdated 按照id顺序查询since之后修改过的交互数据
+	ListUpdated(ctx context.Context, since time.Time, minID int64, limit int) ([]domain.Interaction, error)
+	// CountActual 根据点赞、收藏记录统计资源真实的点赞数、每种表态的数量、收藏数
+	CountActual(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.Interaction, error)
+	Pending(ctx context.Context, biz string, bizID int64) (domain.CounterDelta, error)
+	// Repair 修复数据库中的点赞数、每种表态的数量、收藏数，并且删除缓存
+	Repair(ctx context.Context, inter domain.Interaction) error
+
+	GetCached(ctx context.Context, biz string, bizID int64) (domain.Interaction, error)
+	DelCached(ctx context.Context, biz string, bizID int64) error
+}
+
+type counterRepository struct {
+	dao          dao.CounterDao
+	cache        cache.InteractionCache
+	counterCache cache.CounterCache
+}
+
+func NewCounterRepository(dao dao.CounterDao, cache cache.InteractionCache, counterCache cache.CounterCache) CounterRepository {
+	return &counterRepository{
+		dao:          dao,
+		cache:        cache,
+		counterCache: counterCache,
+	}
+}
+
+func (repo *counterRepository) TakeBatches(ctx context.Context) ([]string, error) {
+	return repo.counterCache.TakeBatches(ctx)
+}
+
+func (repo *counterRepository) ApplyBatch(ctx context.Context, id string) error {
+	batch, err := repo.counterCache.GetBatch(ctx, id)
+	if err != nil {
+		return err
+	}
+	deltas := slice.Map(batch.Deltas, func(idx int, src domain.CounterDelta) dao.CounterDelta {
+		var bucket int64
+		if !src.Bucket.IsZero() {
+			bucket = src.Bucket.UnixMilli()
+		}
+		return dao.CounterDelta{
+			Biz:         src.Biz,
+			BizID:       src.BizID,
+			Bucket:      bucket,
+			ReadCnt:     src.Views,
+			UniqueViews: src.UniqueViews,
+			Likes:       src.Likes,
+			Reactions:   repo.toReactionEntities(src.Reactions),
+		}
+	})
+	if len(deltas) > 0 {
+		err = repo.dao.ApplyBatch(ctx, id, deltas)
+		if err != nil {
+			return err
+		}
+	}
+	// 删除失败时下一次会重放该批次，数据库中记录了批次ID，不会重复计数
+	return repo.counterCache.DelBatch(ctx, id)
+}
+
+func (repo *counterRepository) DeleteFlushLogs(ctx context.Context, before time.Time) error {
+	return repo.dao.DeleteFlushLogs(ctx, before.UnixMilli())
+}
+
+func (repo *counterRepository) ListUpdated(ctx context.Context, since time.Time, minID int64, limit int) ([]domain.Interaction, error) {
+	inters, err := repo.dao.ListUpdated(ctx, since.UnixMilli(), minID, limit)
+	if err != nil {
+		return nil, err
+	}
+	// 按照biz分组查询每种表态的数量
+	bizIDs := make(map[string][]int64)
+	for _, inter := range inters {
+		bizIDs[inter.Biz] = append(bizIDs[inter.Biz], inter.BizID)
+	}
+	reactions := make(map[string]map[int64]map[uint8]int64, len(bizIDs))
+	for biz, ids := range bizIDs {
+		reactions[biz], err = repo.dao.GetReactions(ctx, biz, ids)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return slice.Map(inters, func(idx int, src dao.Interaction) domain.Interaction {
+		return domain.Interaction{
+			ID:          src.ID,
+			Biz:         src.Biz,
+			BizID:       src.BizID,
+			Views:       src.ReadCnt,
+			UniqueViews: src.UniqueViews,
+			Likes:       src.Likes,
+			Reactions:   toReactions(reactions[src.Biz][src.BizID]),
+			Favorites:   src.Favorites,
+			Comments:    src.Comments,
+			CTime:       time.UnixMilli(src.CTime),
+			UTime:       time.UnixMilli(src.UTime),
+		}
+	}), nil
+}
+
+func (repo *counterRepository) CountActual(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.Interaction, error) {
+	reactions, err := repo.dao.CountReactions(ctx, biz, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+	favorites, err := repo.dao.CountFavorites(ctx, biz, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.Interaction, len(bizIDs))
+	for _, bizID := range bizIDs {
+		inter := domain.Interaction{
+			Biz:       biz,
+			BizID:     bizID,
+			Reactions: toReactions(reactions[bizID]),
+			Favorites: favorites[bizID],
+		}
+		// 点赞数是所有表态的总数
+		for _, cnt := range inter.Reactions {
+			inter.Likes += cnt
+		}
+		res[bizID] = inter
+	}
+	return res, nil
+}
+
+func (repo *counterRepository) Pending(ctx context.Context, biz string, bizID int64) (domain.CounterDelta, error) {
+	return repo.counterCache.Pending(ctx, biz, bizID)
+}
+
+func (repo *counterRepository) Repair(ctx context.Context, inter domain.Interaction) error {
+	err := repo.dao.UpdateCounts(ctx, dao.Interaction{
+		ID:        inter.ID,
+		Biz:       inter.Biz,
+		BizID:     inter.BizID,
+		Likes:     inter.Likes,
+		Favorites: inter.Favorites,
+	}, repo.toReactionEntities(inter.Reactions))
+	if err != nil {
+		return err
+	}
+	return repo.cache.Del(ctx, inter.Biz, inter.BizID)
+}
+
+func (repo *counterRepository) GetCached(ctx context.Context, biz string, bizID int64) (domain.Interaction, error) {
+	return repo.cache.Get(ctx, biz, bizID)
+}
+
+func (repo *counterRepository) DelCached(ctx context.Context, biz string, bizID int64) error {
+	return repo.cache.Del(ctx, biz, bizID)
+}
+
+func (repo *counterRepository) toReactionEntities(reactions map[domain.Reaction]int64) map[uint8]int64 {
+	res := make(map[uint8]int64, len(reactions))
+	for r, cnt := range reactions {
+		res[r.ToUint8()] = cnt
+	}
+	return res
+}
@@ -0,0 +1,283 @@
+package dao
+
+import (
+	"context"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+评论
+
+	评论只有两层，回复的root_id是根评论的id，parent_id是被回复的评论。
+	根评论的reply_cnt、Interaction的comments和评论在同一个事务中修改，保证计数和评论一致。
+	删除根评论时同时删除它的回复，资源的评论数减去删除的数量。
+	删除时先锁住评论和它的回复，同时插入的回复要等待删除结束，此时根评论已经不存在，插入失败，不会留下没有根评论的回复。
+*/
+
+type Comment struct {
+	ID  int64 `gorm:"primaryKey,autoIncrement"`
+	Uid int64 `gorm:"index"`
+
+	// 按时间查询根评论：where biz = ? and biz_id = ? and root_id = 0 and id < ? order by id desc
+	Biz    string `gorm:"type:varchar(128);index:idx_biz_root,priority:1"`
+	BizID  int64  `gorm:"index:idx_biz_root,priority:2"`
+	RootID int64  `gorm:"index:idx_biz_root,priority:3;index:idx_root_id"`
+
+	ParentID   int64
+	ReplyToUid int64
+	Content    string `gorm:"type:varchar(4096)"`
+	LikeCnt    int64
+	ReplyCnt   int64
+	Pinned     bool
+
+	Ctime int64 `gorm:"column:c_time"`
+	Utime int64 `gorm:"column:u_time"`
+}
+
+// CommentLike 用户点赞的评论，存在即点赞
+type CommentLike struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex:idx_uid_comment_id"`
+	CommentID int64 `gorm:"uniqueIndex:idx_uid_comment_id;index"`
+	Ctime     int64 `gorm:"column:c_time"`
+}
+
+type CommentDao interface {
+	// Insert 插入评论，回复时增加根评论的回复数，并增加资源的评论数
+	Insert(ctx context.Context, c Comment) (int64, error)
+	GetByID(ctx context.Context, id int64) (Comment, error)
+	// UpdateContent 修改评论的内容，评论不存在或者不是uid的评论时返回ErrNotFound
+	UpdateContent(ctx context.Context, id int64, uid int64, content string) error
+	// Delete 删除评论，返回删除的数量，包括根评论的回复
+	Delete(ctx context.Context, c Comment) (int64, error)
+
+	// ListRoots 按照id倒序查询没有置顶的根评论，maxID为0时从最新的评论开始
+	ListRoots(ctx context.Context, biz string, bizID int64, maxID int64, limit int) ([]Comment, error)
+	// ListPopularRoots 按照点赞数、回复数倒序查询没有置顶的根评论
+	ListPopularRoots(ctx context.Context, biz string, bizID int64, offset int, limit int) ([]Comment, error)
+	// GetPinned 查询资源置顶的评论，没有时返回ErrNotFound
+	GetPinned(ctx context.Context, biz string, bizID int64) (Comment, error)
+	// ListReplies 按照id顺序查询根评论的回复
+	ListReplies(ctx context.Context, rootID int64, minID int64, limit int) ([]Comment, error)
+	// Pin 置顶根评论，同时取消资源其他评论的置顶；pinned为false时取消置顶
+	Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error
+
+	// InsertLike 点赞评论，重复点赞不会增加点赞数
+	InsertLike(ctx context.Context, uid int64, commentID int64) error
+	DeleteLike(ctx context.Context, uid int64, commentID int64) error
+	// LikedIDs 返回commentIDs中用户点赞过的评论
+	LikedIDs(ctx context.Context, uid int64, commentIDs []int64) ([]int64, error)
+}
+
+type commentDao struct {
+	db *gorm.DB
+}
+
+func NewCommentDao(db *gorm.DB) CommentDao {
+	return &commentDao{
+		db: db,
+	}
+}
+
+func (dao *commentDao) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if c.RootID > 0 {
+			res := tx.Model(&Comment{}).Where("id = ?", c.RootID).
+				Update("reply_cnt", gorm.Expr("reply_cnt + 1"))
+			if res.Error != nil {
+				return res.Error
+			}
+			// 根评论已经被删除了
+			if res.RowsAffected == 0 {
+				return ErrNotFound
+			}
+		}
+		if err := tx.Create(&c).Error; err != nil {
+			return err
+		}
+		return dao.incrComments(tx, c.Biz, c.BizID, 1, now)
+	})
+	return c.ID, err
+}
+
+// incrComments 修改资源的评论数，资源还没有交互数据时插入
+func (dao *commentDao) incrComments(tx *gorm.DB, biz string, bizID int64, delta int64, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"comments": gorm.Expr("comments + ?", delta),
+			"u_time":   now,
+		}),
+	}).Create(&Interaction{
+		Biz:      biz,
+		BizID:    bizID,
+		Comments: max(delta, 0),
+		CTime:    now,
+		UTime:    now,
+	}).Error
+}
+
+func (dao *commentDao) GetByID(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+func (dao *commentDao) UpdateContent(ctx context.Context, id int64, uid int64, content string) error {
+	res := dao.db.WithContext(ctx).Model(&Comment{}).
+		Where("id = ? and uid = ?", id, uid).
+		Updates(map[string]any{
+			"content": content,
+			"u_time":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *commentDao) Delete(ctx context.Context, c Comment) (int64, error) {
+	var deleted int64
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		q := tx.Where("id = ?", c.ID)
+		if c.RootID == 0 {
+			q = tx.Where("id = ? or root_id = ?", c.ID, c.ID)
+		}
+		var ids []int64
+		err := q.Model(&Comment{}).Clauses(clause.Locking{Strength: "UPDATE"}).Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		if err = tx.Where("comment_id in ?", ids).Delete(&CommentLike{}).Error; err != nil {
+			return err
+		}
+		res := tx.Where("id in ?", ids).Delete(&Comment{})
+		if res.Error != nil {
+			return res.Error
+		}
+		deleted = res.RowsAffected
+		if c.RootID > 0 {
+			err = tx.Model(&Comment{}).Where("id = ?", c.RootID).
+				Update("reply_cnt", gorm.Expr("reply_cnt - ?", deleted)).Error
+			if err != nil {
+				return err
+			}
+		}
+		return dao.incrComments(tx, c.Biz, c.BizID, -deleted, time.Now().UnixMilli())
+	})
+	return deleted, err
+}
+
+func (dao *commentDao) ListRoots(ctx context.Context, biz string, bizID int64, maxID int64, limit int) ([]Comment, error) {
+	q := dao.roots(dao.db.WithContext(ctx), biz, bizID)
+	if maxID > 0 {
+		q = q.Where("id < ?", maxID)
+	}
+	var res []Comment
+	err := q.Order("id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *commentDao) ListPopularRoots(ctx context.Context, biz string, bizID int64, offset int, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.roots(dao.db.WithContext(ctx), biz, bizID).
+		Order("like_cnt desc, reply_cnt desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *commentDao) roots(db *gorm.DB, biz string, bizID int64) *gorm.DB {
+	return db.Where("biz = ? and biz_id = ? and root_id = 0 and pinned = ?", biz, bizID, false)
+}
+
+func (dao *commentDao) GetPinned(ctx context.Context, biz string, bizID int64) (Comment, error) {
+	var c Comment
+	err := dao.db.WithContext(ctx).
+		Where("biz = ? and biz_id = ? and root_id = 0 and pinned = ?", biz, bizID, true).
+		First(&c).Error
+	return c, err
+}
+
+func (dao *commentDao) ListReplies(ctx context.Context, rootID int64, minID int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := dao.db.WithContext(ctx).
+		Where("root_id = ? and id > ?", rootID, minID).
+		Order("id").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *commentDao) Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		if pinned {
+			err := tx.Model(&Comment{}).
+				Where("biz = ? and biz_id = ? and root_id = 0 and pinned = ? and id <> ?", biz, bizID, true, id).
+				Updates(map[string]any{"pinned": false, "u_time": now}).Error
+			if err != nil {
+				return err
+			}
+		}
+		res := tx.Model(&Comment{}).
+			Where("id = ? and biz = ? and biz_id = ? and root_id = 0", id, biz, bizID).
+			Updates(map[string]any{"pinned": pinned, "u_time": now})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (dao *commentDao) InsertLike(ctx context.Context, uid int64, commentID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&CommentLike{Uid: uid, CommentID: commentID, Ctime: time.Now().UnixMilli()}).Error
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == 1062 {
+			// 已经点赞过了
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Comment{}).Where("id = ?", commentID).
+			Update("like_cnt", gorm.Expr("like_cnt + 1")).Error
+	})
+}
+
+func (dao *commentDao) DeleteLike(ctx context.Context, uid int64, commentID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid = ? and comment_id = ?", uid, commentID).Delete(&CommentLike{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		return tx.Model(&Comment{}).Where("id = ?", commentID).
+			Update("like_cnt", gorm.Expr("like_cnt - 1")).Error
+	})
+}
+
+func (dao *commentDao) LikedIDs(ctx context.Context, uid int64, commentIDs []int64) ([]int64, error) {
+	var res []int64
+	if uid <= 0 || len(commentIDs) == 0 {
+		return res, nil
+	}
+	err := dao.db.WithContext(ctx).Model(&CommentLike{}).
+		Where("uid = ? and comment_id in ?", uid, commentIDs).
+		Pluck("comment_id", &res).Error
+	return res, err
+}
@@ -0,0 +1,147 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestCommentDao_Delete(t *testing.T) {
+	testCases := []struct {
+		name    string
+		comment Comment
+		mock    func(mock sqlmock.Sqlmock)
+
+		wantDeleted int64
+		wantErr     error
+	}{
+		{
+			name:    "删除根评论，锁住根评论和回复后一起删除",
+			comment: Comment{ID: 1, Biz: "article", BizID: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE id = \\? or root_id = \\? FOR UPDATE").
+					WithArgs(int64(1), int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2).AddRow(3))
+				mock.ExpectExec("DELETE FROM `comment_likes` WHERE comment_id in \\(\\?,\\?,\\?\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM `comments` WHERE id in \\(\\?,\\?,\\?\\)").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("INSERT INTO `interactions` .* ON DUPLICATE KEY UPDATE `comments`=comments \\+ \\?").
+					WithArgs("article", int64(10), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						sqlmock.AnyArg(), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(-3), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantDeleted: 3,
+		},
+		{
+			name:    "删除回复，减少根评论的回复数",
+			comment: Comment{ID: 2, RootID: 1, Biz: "article", BizID: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE id = \\? FOR UPDATE").
+					WithArgs(int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mock.ExpectExec("DELETE FROM `comment_likes` WHERE comment_id in \\(\\?\\)").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM `comments` WHERE id in \\(\\?\\)").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=reply_cnt - \\? WHERE id = \\?").
+					WithArgs(int64(1), int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interactions`").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantDeleted: 1,
+		},
+		{
+			name:    "评论已经被删除",
+			comment: Comment{ID: 2, RootID: 1, Biz: "article", BizID: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `id` FROM `comments` WHERE id = \\? FOR UPDATE").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "删除失败时回滚",
+			comment: Comment{ID: 1, Biz: "article", BizID: 10},
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT `id` FROM `comments`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectExec("DELETE FROM `comment_likes`").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			deleted, err := NewCommentDao(newMockGORM(t, sqlDB)).Delete(context.Background(), tc.comment)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantDeleted, deleted)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCommentDao_InsertReply(t *testing.T) {
+	t.Run("根评论已经被删除", func(t *testing.T) {
+		sqlDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectBegin()
+		// 删除根评论时锁住了根评论，这里等删除结束后更新不到根评论
+		mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=reply_cnt \\+ 1 WHERE id = \\?").
+			WithArgs(int64(1)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		_, err = NewCommentDao(newMockGORM(t, sqlDB)).Insert(context.Background(),
+			Comment{Uid: 123, Biz: "article", BizID: 10, RootID: 1, ParentID: 1, Content: "回复"})
+		assert.Equal(t, ErrNotFound, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCommentDao_ListReplies(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE root_id = \\? and id > \\? ORDER BY id LIMIT \\?").
+		WithArgs(int64(1), int64(2), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "root_id", "parent_id"}).
+			AddRow(3, 1, 1).
+			AddRow(4, 1, 3))
+
+	res, err := NewCommentDao(newMockGORM(t, sqlDB)).ListReplies(context.Background(), 1, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{{ID: 3, RootID: 1, ParentID: 1}, {ID: 4, RootID: 1, ParentID: 3}}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func newMockGORM(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
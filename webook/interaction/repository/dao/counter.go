@@ -0,0 +1,234 @@
+package dao
+
+import (
+	"context"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
+)
+
+// CounterFlushLog 已经写入数据库的写回批次，重放批次时用来去重
+type CounterFlushLog struct {
+	ID      int64  `gorm:"primaryKey,autoIncrement"`
+	BatchID string `gorm:"type:varchar(128);uniqueIndex"`
+	CTime   int64  `gorm:"column:c_time;index"`
+}
+
+// CounterDelta 一个资源的计数增量
+type CounterDelta struct {
+	Biz   string
+	BizID int64
+	// Bucket 增量所在小时桶的开始时间（毫秒），0表示累加到写入时的小时桶
+	Bucket      int64
+	ReadCnt     int64
+	UniqueViews int64
+	Likes       int64
+	// Reactions 每种表态数量的增量
+	Reactions map[uint8]int64
+}
+
+type CounterDao interface {
+	// ApplyBatch 在一个事务中把一批增量写入数据库并记录批次ID，批次已经写入过时直接返回
+	ApplyBatch(ctx context.Context, batchID string, deltas []CounterDelta) error
+	// DeleteFlushLogs 删除c_time早于before的批次记录
+	DeleteFlushLogs(ctx context.Context, before int64) error
+
+	// ListUpdated 按照id顺序查询u_time不早于since的交互数据，minID是上一批最后一条的id
+	ListUpdated(ctx context.Context, since int64, minID int64, limit int) ([]Interaction, error)
+	// GetReactions 查询资源每种表态的数量，key是biz_id
+	GetReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error)
+	// CountReactions 根据点赞记录统计资源每种表态的数量，点赞数是所有表态的总数
+	CountReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error)
+	// CountFavorites 根据收藏记录统计资源的收藏数
+	CountFavorites(ctx context.Context, biz string, bizIDs []int64) (map[int64]int64, error)
+	// UpdateCounts 用统计出来的点赞数、收藏数和每种表态的数量修复计数，inter中需要ID、Biz、BizID
+	UpdateCounts(ctx context.Context, inter Interaction, reactions map[uint8]int64) error
+}
+
+type counterDao struct {
+	db *gorm.DB
+}
+
+func NewCounterDao(db *gorm.DB) CounterDao {
+	return &counterDao{
+		db: db,
+	}
+}
+
+func (dao *counterDao) ApplyBatch(ctx context.Context, batchID string, deltas []CounterDelta) error {
+	now := time.Now().UnixMilli()
+	// 按照唯一索引的顺序更新，避免多个批次并发写入时死锁
+	sort.Slice(deltas, func(i, j int) bool {
+		if deltas[i].Biz != deltas[j].Biz {
+			return deltas[i].Biz < deltas[j].Biz
+		}
+		return deltas[i].BizID < deltas[j].BizID
+	})
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&CounterFlushLog{BatchID: batchID, CTime: now}).Error
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == 1062 {
+			// 批次已经写入，崩溃发生在写入数据库之后、删除批次之前
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, d := range deltas {
+			if err = applyDelta(tx, d, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *counterDao) DeleteFlushLogs(ctx context.Context, before int64) error {
+	return dao.db.WithContext(ctx).Where("c_time < ?", before).Delete(&CounterFlushLog{}).Error
+}
+
+func (dao *counterDao) ListUpdated(ctx context.Context, since int64, minID int64, limit int) ([]Interaction, error) {
+	var res []Interaction
+	err := dao.db.WithContext(ctx).
+		Where("u_time >= ? and id > ?", since, minID).
+		Order("id").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+type bizCount struct {
+	BizID int64
+	Cnt   int64
+}
+
+func (dao *counterDao) GetReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error) {
+	return getReactions(dao.db.WithContext(ctx), biz, bizIDs)
+}
+
+func (dao *counterDao) CountReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error) {
+	var rows []struct {
+		BizID    int64
+		Reaction uint8
+		Cnt      int64
+	}
+	err := dao.db.WithContext(ctx).Model(&UserLike{}).
+		Select("biz_id, reaction, count(*) as cnt").
+		Where("biz = ? and biz_id in ? and status = ?", biz, bizIDs, Liked).
+		Group("biz_id, reaction").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]map[uint8]int64, len(bizIDs))
+	for _, row := range rows {
+		if res[row.BizID] == nil {
+			res[row.BizID] = make(map[uint8]int64)
+		}
+		res[row.BizID][row.Reaction] = row.Cnt
+	}
+	return res, nil
+}
+
+func (dao *counterDao) CountFavorites(ctx context.Context, biz string, bizIDs []int64) (map[int64]int64, error) {
+	var res []bizCount
+	err := dao.db.WithContext(ctx).Model(&UserFavorite{}).
+		Select("biz_id, count(*) as cnt").
+		Where("biz = ? and biz_id in ?", biz, bizIDs).
+		Group("biz_id").Scan(&res).Error
+	return dao.toMap(res), err
+}
+
+func (dao *counterDao) UpdateCounts(ctx context.Context, inter Interaction, reactions map[uint8]int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&Interaction{}).Where("id = ?", inter.ID).
+			Updates(map[string]any{
+				"likes":     inter.Likes,
+				"favorites": inter.Favorites,
+				"u_time":    now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		// 先清零已有的表态，统计结果中没有的表态数量就是0
+		err = tx.Model(&InteractionReaction{}).
+			Where("biz = ? and biz_id = ?", inter.Biz, inter.BizID).
+			Updates(map[string]any{
+				"cnt":    0,
+				"u_time": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		for r, cnt := range reactions {
+			err = tx.Clauses(clause.OnConflict{
+				DoUpdates: clause.Assignments(map[string]any{
+					"cnt":    cnt,
+					"u_time": now,
+				}),
+			}).Create(&InteractionReaction{
+				Biz:      inter.Biz,
+				BizID:    inter.BizID,
+				Reaction: r,
+				Cnt:      cnt,
+				CTime:    now,
+				UTime:    now,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *counterDao) toMap(counts []bizCount) map[int64]int64 {
+	res := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		res[c.BizID] = c.Cnt
+	}
+	return res
+}
+
+// applyDelta 把一个资源的增量写入数据库，资源还没有交互数据时插入，同时累加增量所在的小时桶。
+// 和 incrReaction 一样插入时保留负数的增量，保证计数和各个表态的和一致，由对账任务修正
+func applyDelta(tx *gorm.DB, d CounterDelta, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":     gorm.Expr("read_cnt + ?", d.ReadCnt),
+			"unique_views": gorm.Expr("unique_views + ?", d.UniqueViews),
+			"likes":        gorm.Expr("likes + ?", d.Likes),
+			"u_time":       now,
+		}),
+	}).Create(&Interaction{
+		Biz:         d.Biz,
+		BizID:       d.BizID,
+		ReadCnt:     d.ReadCnt,
+		UniqueViews: d.UniqueViews,
+		Likes:       d.Likes,
+		CTime:       now,
+		UTime:       now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	err = incrStat(tx, InteractionStat{Biz: d.Biz, BizID: d.BizID, Bucket: d.Bucket, ReadCnt: d.ReadCnt, Likes: d.Likes}, now)
+	if err != nil {
+		return err
+	}
+	// 同一个资源的表态也按照唯一索引的顺序更新
+	reactions := make([]uint8, 0, len(d.Reactions))
+	for r, delta := range d.Reactions {
+		if delta != 0 {
+			reactions = append(reactions, r)
+		}
+	}
+	sort.Slice(reactions, func(i, j int) bool {
+		return reactions[i] < reactions[j]
+	})
+	for _, r := range reactions {
+		if err = incrReaction(tx, d.Biz, d.BizID, r, d.Reactions[r], now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCounterDao_ApplyBatch(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		deltas []CounterDelta
+
+		wantErr error
+	}{
+		{
+			name: "写入批次，按照唯一索引的顺序更新",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `counter_flush_logs`").
+					WithArgs("1:100", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactions` .* ON DUPLICATE KEY UPDATE").
+					WithArgs("article", int64(1), int64(0), int64(0), int64(-1), int64(0), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						int64(-1), int64(0), sqlmock.AnyArg(), int64(0)).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `interaction_stats`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interaction_reactions` .* ON DUPLICATE KEY UPDATE").
+					WithArgs("article", int64(1), uint8(1), int64(-1), sqlmock.AnyArg(), sqlmock.AnyArg(), int64(-1), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("INSERT INTO `interactions` .* ON DUPLICATE KEY UPDATE").
+					WithArgs("article", int64(2), int64(3), int64(0), int64(0), int64(0), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						int64(0), int64(3), sqlmock.AnyArg(), int64(0)).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("INSERT INTO `interaction_stats`").
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			deltas: []CounterDelta{
+				{Biz: "article", BizID: 2, ReadCnt: 3},
+				{Biz: "article", BizID: 1, Likes: -1, Reactions: map[uint8]int64{1: -1, 2: 0}},
+			},
+		},
+		{
+			name: "重放已经写入的批次，不会重复计数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `counter_flush_logs`").
+					WithArgs("1:100", sqlmock.AnyArg()).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectCommit()
+			},
+			deltas: []CounterDelta{{Biz: "article", BizID: 1, ReadCnt: 3}},
+		},
+		{
+			name: "写入失败时回滚，批次记录也不保留",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `counter_flush_logs`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactions`").
+					WillReturnError(&mysql.MySQLError{Number: 1213})
+				mock.ExpectRollback()
+			},
+			deltas:  []CounterDelta{{Biz: "article", BizID: 1, ReadCnt: 3}},
+			wantErr: &mysql.MySQLError{Number: 1213},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			err = NewCounterDao(newMockGORM(t, sqlDB)).ApplyBatch(context.Background(), "1:100", tc.deltas)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+收藏夹
+
+	user_favorites中(uid, biz, biz_id)唯一，一个资源只能收藏在用户的一个收藏夹中，再次收藏到其他收藏夹时移动过去。
+	收藏夹的items、Interaction的favorites和收藏记录在同一个事务中修改。
+	删除收藏夹时同时删除其中的收藏，每条收藏对应资源的收藏数减1，同一个biz的资源用一条语句修改。
+*/
+
+type FavoriteFolder struct {
+	ID      int64  `gorm:"primaryKey,autoIncrement"`
+	Uid     int64  `gorm:"index"`
+	Name    string `gorm:"type:varchar(256)"`
+	Private bool
+	Items   int64
+
+	CTime int64 `gorm:"column:c_time"`
+	UTime int64 `gorm:"column:u_time"`
+}
+
+type FavoriteDao interface {
+	InsertFolder(ctx context.Context, f FavoriteFolder) (int64, error)
+	// UpdateFolder 修改名称和是否私密，收藏夹不存在或者不属于f.Uid时返回ErrNotFound
+	UpdateFolder(ctx context.Context, f FavoriteFolder) error
+	// DeleteFolder 删除收藏夹和其中的收藏，返回删除的收藏
+	DeleteFolder(ctx context.Context, uid int64, id int64) ([]UserFavorite, error)
+	GetFolder(ctx context.Context, id int64) (FavoriteFolder, error)
+	// ListFolders 按照创建时间查询用户的收藏夹，includePrivate为false时只查询公开的收藏夹
+	ListFolders(ctx context.Context, uid int64, includePrivate bool) ([]FavoriteFolder, error)
+	CountFolders(ctx context.Context, uid int64) (int64, error)
+
+	// ListItems 按照id倒序查询收藏夹中的收藏，maxID为0时查询第一页
+	ListItems(ctx context.Context, folderID int64, maxID int64, limit int) ([]UserFavorite, error)
+}
+
+type favoriteDao struct {
+	db *gorm.DB
+}
+
+func NewFavoriteDao(db *gorm.DB) FavoriteDao {
+	return &favoriteDao{
+		db: db,
+	}
+}
+
+func (dao *favoriteDao) InsertFolder(ctx context.Context, f FavoriteFolder) (int64, error) {
+	now := time.Now().UnixMilli()
+	f.Items = 0
+	f.CTime = now
+	f.UTime = now
+	err := dao.db.WithContext(ctx).Create(&f).Error
+	return f.ID, err
+}
+
+func (dao *favoriteDao) UpdateFolder(ctx context.Context, f FavoriteFolder) error {
+	res := dao.db.WithContext(ctx).Model(&FavoriteFolder{}).
+		Where("id = ? and uid = ?", f.ID, f.Uid).
+		Updates(map[string]any{
+			"name":    f.Name,
+			"private": f.Private,
+			"u_time":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *favoriteDao) DeleteFolder(ctx context.Context, uid int64, id int64) ([]UserFavorite, error) {
+	var items []UserFavorite
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? and uid = ?", id, uid).Delete(&FavoriteFolder{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		err := tx.Where("favorite_id = ?", id).Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+		err = tx.Where("favorite_id = ?", id).Delete(&UserFavorite{}).Error
+		if err != nil {
+			return err
+		}
+		// 按照biz分组，每组一条语句修改收藏数，而不是每条收藏单独修改
+		var bizs []string
+		bizIDs := make(map[string][]int64, 1)
+		for _, item := range items {
+			if _, ok := bizIDs[item.Biz]; !ok {
+				bizs = append(bizs, item.Biz)
+			}
+			bizIDs[item.Biz] = append(bizIDs[item.Biz], item.BizID)
+		}
+		now := time.Now().UnixMilli()
+		for _, biz := range bizs {
+			if err = decrFavorites(tx, biz, bizIDs[biz], now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return items, err
+}
+
+func (dao *favoriteDao) GetFolder(ctx context.Context, id int64) (FavoriteFolder, error) {
+	var res FavoriteFolder
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (dao *favoriteDao) ListFolders(ctx context.Context, uid int64, includePrivate bool) ([]FavoriteFolder, error) {
+	q := dao.db.WithContext(ctx).Where("uid = ?", uid)
+	if !includePrivate {
+		q = q.Where("private = ?", false)
+	}
+	var res []FavoriteFolder
+	err := q.Order("id").Limit(1000).Find(&res).Error
+	return res, err
+}
+
+func (dao *favoriteDao) CountFolders(ctx context.Context, uid int64) (int64, error) {
+	var res int64
+	err := dao.db.WithContext(ctx).Model(&FavoriteFolder{}).Where("uid = ?", uid).Count(&res).Error
+	return res, err
+}
+
+func (dao *favoriteDao) ListItems(ctx context.Context, folderID int64, maxID int64, limit int) ([]UserFavorite, error) {
+	q := dao.db.WithContext(ctx).Where("favorite_id = ?", folderID)
+	if maxID > 0 {
+		q = q.Where("id < ?", maxID)
+	}
+	var res []UserFavorite
+	err := q.Order("id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// incrFolderItems 修改收藏夹中的收藏数
+func incrFolderItems(tx *gorm.DB, folderID int64, delta int64, now int64) error {
+	return tx.Model(&FavoriteFolder{}).Where("id = ?", folderID).
+		Updates(map[string]any{
+			"items":  gorm.Expr("items + ?", delta),
+			"u_time": now,
+		}).Error
+}
+
+// incrFavorites 修改资源的收藏数，资源还没有交互数据时插入，同时累加当前小时的时间桶
+func incrFavorites(tx *gorm.DB, biz string, bizID int64, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"favorites": gorm.Expr("favorites + ?", delta),
+			"u_time":    now,
+		}),
+	}).Create(&Interaction{
+		Biz:       biz,
+		BizID:     bizID,
+		Favorites: max(delta, 0),
+		CTime:     now,
+		UTime:     now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return incrStat(tx, InteractionStat{Biz: biz, BizID: bizID, Favorites: delta}, now)
+}
+
+// decrFavorites 把一批资源的收藏数减1，收藏时已经插入了交互数据，只需要更新
+func decrFavorites(tx *gorm.DB, biz string, bizIDs []int64, now int64) error {
+	err := tx.Model(&Interaction{}).Where("biz = ? and biz_id in ?", biz, bizIDs).
+		Updates(map[string]any{
+			"favorites": gorm.Expr("favorites - ?", 1),
+			"u_time":    now,
+		}).Error
+	if err != nil {
+		return err
+	}
+	stats := make([]InteractionStat, 0, len(bizIDs))
+	for _, bizID := range bizIDs {
+		stats = append(stats, InteractionStat{Biz: biz, BizID: bizID, Favorites: -1})
+	}
+	return incrStats(tx, stats, now)
+}
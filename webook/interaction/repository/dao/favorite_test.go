@@ -0,0 +1,143 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFavoriteDao_DeleteFolder(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantItems []UserFavorite
+		wantErr   error
+	}{
+		{
+			name: "同一个biz的收藏数用一条语句修改",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `favorite_folders` WHERE id = \\? and uid = \\?").
+					WithArgs(int64(10), int64(2000)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_favorites` WHERE favorite_id = \\?").
+					WithArgs(int64(10)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz", "biz_id", "favorite_id"}).
+						AddRow(1, 2000, "article", 1, 10).
+						AddRow(2, 2000, "article", 2, 10).
+						AddRow(3, 2000, "video", 1, 10))
+				mock.ExpectExec("DELETE FROM `user_favorites` WHERE favorite_id = \\?").
+					WithArgs(int64(10)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("UPDATE `interactions` SET `favorites`=favorites - \\?,`u_time`=\\? WHERE biz = \\? and biz_id in \\(\\?,\\?\\)").
+					WithArgs(1, sqlmock.AnyArg(), "article", int64(1), int64(2)).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				// 两个资源的小时桶一起写入
+				mock.ExpectExec("INSERT INTO `interaction_stats` .* VALUES \\(.*\\),\\(.*\\) ON DUPLICATE KEY UPDATE " +
+					"`favorites`=favorites \\+ VALUES\\(favorites\\),`likes`=likes \\+ VALUES\\(likes\\),`read_cnt`=read_cnt \\+ VALUES\\(read_cnt\\),`u_time`=\\?").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				mock.ExpectExec("UPDATE `interactions` SET `favorites`=favorites - \\?,`u_time`=\\? WHERE biz = \\? and biz_id in \\(\\?\\)").
+					WithArgs(1, sqlmock.AnyArg(), "video", int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO `interaction_stats`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantItems: []UserFavorite{
+				{ID: 1, Uid: 2000, Biz: "article", BizID: 1, FavoriteID: 10},
+				{ID: 2, Uid: 2000, Biz: "article", BizID: 2, FavoriteID: 10},
+				{ID: 3, Uid: 2000, Biz: "video", BizID: 1, FavoriteID: 10},
+			},
+		},
+		{
+			name: "空的收藏夹",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `favorite_folders`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_favorites`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectCommit()
+			},
+			wantItems: []UserFavorite{},
+		},
+		{
+			name: "收藏夹不存在或者不属于用户",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `favorite_folders`").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrNotFound,
+		},
+		{
+			name: "修改收藏数失败时回滚",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `favorite_folders`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("SELECT \\* FROM `user_favorites`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "biz", "biz_id", "favorite_id"}).
+						AddRow(1, 2000, "article", 1, 10))
+				mock.ExpectExec("DELETE FROM `user_favorites`").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `interactions`").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+			},
+			wantItems: []UserFavorite{{ID: 1, Uid: 2000, Biz: "article", BizID: 1, FavoriteID: 10}},
+			wantErr:   errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			items, err := NewFavoriteDao(newMockGORM(t, sqlDB)).DeleteFolder(context.Background(), 2000, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, items)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+// 收藏夹上线之前的收藏移到新建的默认收藏夹中
+func TestMigrateLegacyFavorites(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT DISTINCT `uid` FROM `user_favorites` WHERE uid > \\? AND \\(NOT EXISTS \\(.*\\)\\) ORDER BY uid LIMIT \\?").
+		WithArgs(int64(0), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"uid"}).AddRow(1001).AddRow(1002))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `id` FROM `user_favorites` WHERE uid = \\? AND \\(NOT EXISTS \\(.*\\)\\) FOR UPDATE").
+		WithArgs(int64(1001)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec("INSERT INTO `favorite_folders`").
+		WithArgs(int64(1001), defaultFavoriteFolderName, true, int64(2), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec("UPDATE `user_favorites` SET `favorite_id`=\\? WHERE id in \\(\\?,\\?\\)").
+		WithArgs(int64(10), int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	// 并发的迁移已经处理过这个用户
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT `id` FROM `user_favorites`").
+		WithArgs(int64(1002)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+	// 下一批从上一批最后一个用户之后开始
+	mock.ExpectQuery("SELECT DISTINCT `uid` FROM `user_favorites`").
+		WithArgs(int64(1002), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"uid"}))
+
+	require.NoError(t, migrateLegacyFavorites(newMockGORM(t, sqlDB), 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,13 +1,199 @@
 package dao
 
 import (
+	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"sort"
+	"time"
 )
 
+// defaultFavoriteFolderName 为收藏夹上线之前的收藏创建的收藏夹名称
+const defaultFavoriteFolderName = "默认收藏夹"
+
 func InitTable(db *gorm.DB) error {
-	return db.AutoMigrate(
+	// 后来加入的计数列允许为NULL，NULL + 1还是NULL，改成NOT NULL之前先把已有的NULL改成0
+	if err := fillNullCounters(db, &Interaction{}, "comments", "unique_views"); err != nil {
+		return err
+	}
+	err := db.AutoMigrate(
 		&Interaction{},
 		&UserLike{},
 		&UserFavorite{},
+		&FavoriteFolder{},
+		&CounterFlushLog{},
+		&InteractionStat{},
+		&InteractionReaction{},
+		&Comment{},
+		&CommentLike{},
 	)
+	if err != nil {
+		return err
+	}
+	if err = migrateLegacyFavorites(db, 100); err != nil {
+		return err
+	}
+	return migrateLegacyReactions(db, 100)
+}
+
+// fillNullCounters 表或者列还不存在时不需要处理，AutoMigrate添加的NOT NULL列会使用默认值
+func fillNullCounters(db *gorm.DB, model any, columns ...string) error {
+	m := db.Migrator()
+	if !m.HasTable(model) {
+		return nil
+	}
+	for _, col := range columns {
+		if !m.HasColumn(model, col) {
+			continue
+		}
+		if err := db.Model(model).Where(col+" IS NULL").Update(col, 0).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateLegacyFavorites 收藏夹上线之前的收藏不属于任何收藏夹，查询不到也不能移动，
+// 为有这种收藏的用户各创建一个私密的默认收藏夹，把收藏移进去。已经在收藏夹中的收藏不会处理，可以重复执行
+func migrateLegacyFavorites(db *gorm.DB, batchSize int) error {
+	var after int64
+	for {
+		var uids []int64
+		err := db.Model(&UserFavorite{}).
+			Distinct("uid").
+			Where("uid > ?", after).
+			Where(legacyFavoriteCond).
+			Order("uid").
+			Limit(batchSize).
+			Pluck("uid", &uids).Error
+		if err != nil {
+			return err
+		}
+		for _, uid := range uids {
+			if err = migrateUserLegacyFavorites(db, uid); err != nil {
+				return err
+			}
+		}
+		if len(uids) < batchSize {
+			return nil
+		}
+		after = uids[len(uids)-1]
+	}
+}
+
+// legacyFavoriteCond 收藏的favorite_id不是用户自己的收藏夹
+const legacyFavoriteCond = "NOT EXISTS (SELECT 1 FROM favorite_folders WHERE favorite_folders.id = user_favorites.favorite_id AND favorite_folders.uid = user_favorites.uid)"
+
+func migrateUserLegacyFavorites(db *gorm.DB, uid int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// 先锁住要移动的收藏，新的收藏夹的id可能和旧数据中的favorite_id相同
+		var ids []int64
+		err := tx.Model(&UserFavorite{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ?", uid).
+			Where(legacyFavoriteCond).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		now := time.Now().UnixMilli()
+		folder := FavoriteFolder{
+			Uid:     uid,
+			Name:    defaultFavoriteFolderName,
+			Private: true,
+			Items:   int64(len(ids)),
+			CTime:   now,
+			UTime:   now,
+		}
+		if err = tx.Create(&folder).Error; err != nil {
+			return err
+		}
+		return tx.Model(&UserFavorite{}).Where("id in ?", ids).
+			Update("favorite_id", folder.ID).Error
+	})
+}
+
+// migrateLegacyReactions 表态上线之前的点赞在interaction_reactions中没有记录，切换表态时旧表态的数量会从0开始减，
+// 每种表态的和就和点赞数对不上了。为有点赞、但是没有任何表态记录的资源，用点赞记录统计出每种表态的数量。
+// 已经有表态记录的资源不会处理，可以重复执行
+func migrateLegacyReactions(db *gorm.DB, batchSize int) error {
+	var after int64
+	for {
+		var ids []int64
+		err := db.Model(&Interaction{}).
+			Where("id > ? and likes > 0", after).
+			Where(legacyReactionCond).
+			Order("id").
+			Limit(batchSize).
+			Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err = migrateLegacyReactionBatch(db, ids); err != nil {
+				return err
+			}
+		}
+		if len(ids) < batchSize {
+			return nil
+		}
+		after = ids[len(ids)-1]
+	}
+}
+
+// legacyReactionCond 资源没有任何表态记录
+const legacyReactionCond = "NOT EXISTS (SELECT 1 FROM interaction_reactions WHERE interaction_reactions.biz = interactions.biz AND interaction_reactions.biz_id = interactions.biz_id)"
+
+func migrateLegacyReactionBatch(db *gorm.DB, ids []int64) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		// 先锁住资源的计数，写回批次修改表态之前也会修改这一行，统计期间不会插入表态记录
+		var inters []Interaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id in ?", ids).
+			Where(legacyReactionCond).
+			Order("id").
+			Find(&inters).Error
+		if err != nil || len(inters) == 0 {
+			return err
+		}
+		var bizs []string
+		bizIDs := make(map[string][]int64)
+		for _, inter := range inters {
+			if _, ok := bizIDs[inter.Biz]; !ok {
+				bizs = append(bizs, inter.Biz)
+			}
+			bizIDs[inter.Biz] = append(bizIDs[inter.Biz], inter.BizID)
+		}
+		now := time.Now().UnixMilli()
+		var rows []InteractionReaction
+		for _, biz := range bizs {
+			counts, err := NewCounterDao(tx).CountReactions(context.Background(), biz, bizIDs[biz])
+			if err != nil {
+				return err
+			}
+			for _, bizID := range bizIDs[biz] {
+				reactions := make([]uint8, 0, len(counts[bizID]))
+				for r := range counts[bizID] {
+					reactions = append(reactions, r)
+				}
+				sort.Slice(reactions, func(i, j int) bool {
+					return reactions[i] < reactions[j]
+				})
+				for _, r := range reactions {
+					rows = append(rows, InteractionReaction{
+						Biz:      biz,
+						BizID:    bizID,
+						Reaction: r,
+						Cnt:      counts[bizID][r],
+						CTime:    now,
+						UTime:    now,
+					})
+				}
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
 }
@@ -2,6 +2,7 @@ package dao
 
 import (
 	"context"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -11,11 +12,15 @@ type Interaction struct {
 	ID int64 `json:"id" gorm:"primaryKey,autoIncrement"`
 
 	// biz, biz_id组成唯一索引
-	Biz       string `json:"biz" gorm:"index:idx_biz_biz_id,unique"`
-	BizID     int64  `json:"biz_id" gorm:"index:idx_biz_biz_id,unique"`
-	ReadCnt   int64  `json:"read_cnt"`
-	Likes     int64
-	Favorites int64
+	Biz     string `json:"biz" gorm:"index:idx_biz_biz_id,unique"`
+	BizID   int64  `json:"biz_id" gorm:"index:idx_biz_biz_id,unique"`
+	ReadCnt int64  `json:"read_cnt"`
+	// UniqueViews 去重后的阅读数，同一个用户每天最多计一次
+	UniqueViews int64 `gorm:"not null;default:0"`
+	Likes       int64
+	Favorites   int64
+	// Comments 评论数，包括回复，和评论在同一个事务中修改
+	Comments int64 `gorm:"not null;default:0"`
 
 	CTime int64 `json:"c_time"`
 	UTime int64 `json:"u_time"`
@@ -32,14 +37,18 @@ type UserLike struct {
 
 	// 查询用户点赞的视频。
 	// where uid = ? and biz = 'article'
-	Uid   int64  `json:"uid" gorm:"index:idx_uid_biz_biz_id,unique"`
-	Biz   string `json:"biz" gorm:"index:idx_uid_biz_biz_id,unique"`
+	// (uid, biz, u_time)索引用于按照点赞时间分页查询用户的点赞
+	Uid   int64  `json:"uid" gorm:"index:idx_uid_biz_biz_id,unique;index:idx_uid_biz_utime,priority:1"`
+	Biz   string `json:"biz" gorm:"index:idx_uid_biz_biz_id,unique;index:idx_uid_biz_utime,priority:2"`
 	BizID int64  `json:"biz_id" gorm:"index:idx_uid_biz_biz_id,unique"`
 
 	// 1表示点赞，2表示取消点赞
 	Status uint8 `json:"status" gorm:"tinyint"`
+	// Reaction 点赞状态下用户的表态，旧数据都是点赞
+	Reaction uint8 `json:"reaction" gorm:"default:1"`
 
-	UTime int64 `json:"u_time" gorm:"column:u_time"`
+	// UTime 点赞、取消点赞的时间
+	UTime int64 `json:"u_time" gorm:"column:u_time;index:idx_uid_biz_utime,priority:3"`
 	CTime int64 `json:"c_time" gorm:"column:c_time"`
 }
 
@@ -59,17 +68,37 @@ type UserFavorite struct {
 }
 
 type InteractionDao interface {
-	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
-
-	InsertLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) error
-	DeleteLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) error
-	InsertFavorite(ctx context.Context, favorite UserFavorite) error
+	// InsertLikeInfo 设置用户的表态，已经有其他表态时切换过去，返回之前的表态，没有表态时返回0。
+	// 返回值和reaction相同说明没有变化。表态的数量由写回缓冲区（CounterDao）写入
+	InsertLikeInfo(ctx context.Context, uid int64, biz string, bizID int64, reaction uint8) (uint8, error)
+	// DeleteLikeInfo 取消表态，返回取消的表态，没有表态时返回0
+	DeleteLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (uint8, error)
+	// InsertFavorite 收藏到favorite.FavoriteID收藏夹，收藏夹不存在或者不属于favorite.Uid时返回ErrNotFound。
+	// 已经收藏在其他收藏夹时移动过去，只有新增收藏时返回true
+	InsertFavorite(ctx context.Context, favorite UserFavorite) (bool, error)
+	// DeleteFavorite 取消收藏，返回是否删除了收藏记录
+	DeleteFavorite(ctx context.Context, uid int64, biz string, bizID int64) (bool, error)
 	Get(ctx context.Context, biz string, bizID int64) (Interaction, error)
 	GetUserLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (UserLike, error)
 	GetUserFavoriteInfo(ctx context.Context, uid int64, biz string, id int64) (UserFavorite, error)
-	BatchIncrReadCnt(ctx context.Context, bizs []string, ds []int64) error
+	// ListUserLikes 按照(u_time, id)倒序查询用户的点赞，beforeUtime、beforeID是上一页最后一条点赞的位置，都为0表示第一页
+	ListUserLikes(ctx context.Context, uid int64, biz string, beforeUtime int64, beforeID int64, limit int) ([]UserLike, error)
+	// BatchGetUserLikes 查询用户对bizIDs的点赞，只返回点赞了的记录
+	BatchGetUserLikes(ctx context.Context, uid int64, biz string, bizIDs []int64) ([]UserLike, error)
+	// BatchGetUserFavorites 查询用户对bizIDs的收藏，只返回收藏了的记录
+	BatchGetUserFavorites(ctx context.Context, uid int64, biz string, bizIDs []int64) ([]UserFavorite, error)
 
 	GetByIDs(ctx context.Context, biz string, ds []int64) ([]Interaction, error)
+	// GetReactions 查询资源每种表态的数量，key是biz_id
+	GetReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error)
+
+	// ApplyDelta 直接把一个资源的增量写入数据库，写回缓冲区不可用时使用
+	ApplyDelta(ctx context.Context, delta CounterDelta) error
+
+	// ListLikers 查询对资源有表态的用户
+	ListLikers(ctx context.Context, biz string, bizID int64) ([]int64, error)
+	// Delete 删除资源的计数、点赞记录、收藏记录和评论
+	Delete(ctx context.Context, biz string, bizID int64) error
 }
 
 func (dao *interactionDao) GetUserFavoriteInfo(ctx context.Context, uid int64, biz string, bizID int64) (UserFavorite, error) {
@@ -83,10 +112,36 @@ func (dao *interactionDao) GetUserFavoriteInfo(ctx context.Context, uid int64, b
 func (dao *interactionDao) GetUserLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (UserLike, error) {
 	var userLike UserLike
 	err := dao.db.WithContext(ctx).Model(&UserLike{}).
-		Where("uid = ? and biz = ? and biz_id = ?", uid, biz, bizID).First(&userLike).Error
+		Where("uid = ? and biz = ? and biz_id = ? and status = ?", uid, biz, bizID, Liked).First(&userLike).Error
 	return userLike, err
 }
 
+func (dao *interactionDao) ListUserLikes(ctx context.Context, uid int64, biz string, beforeUtime int64, beforeID int64, limit int) ([]UserLike, error) {
+	db := dao.db.WithContext(ctx).Where("uid = ? and biz = ? and status = ?", uid, biz, Liked)
+	if beforeUtime > 0 || beforeID > 0 {
+		db = db.Where("u_time < ? or (u_time = ? and id < ?)", beforeUtime, beforeUtime, beforeID)
+	}
+	var res []UserLike
+	err := db.Order("u_time desc, id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *interactionDao) BatchGetUserLikes(ctx context.Context, uid int64, biz string, bizIDs []int64) ([]UserLike, error) {
+	var res []UserLike
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? and biz = ? and biz_id in ? and status = ?", uid, biz, bizIDs, Liked).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *interactionDao) BatchGetUserFavorites(ctx context.Context, uid int64, biz string, bizIDs []int64) ([]UserFavorite, error) {
+	var res []UserFavorite
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? and biz = ? and biz_id in ?", uid, biz, bizIDs).
+		Find(&res).Error
+	return res, err
+}
+
 func (dao *interactionDao) Get(ctx context.Context, biz string, bizID int64) (Interaction, error) {
 	var inter Interaction
 	err := dao.db.WithContext(ctx).Model(&Interaction{}).
@@ -94,118 +149,163 @@ func (dao *interactionDao) Get(ctx context.Context, biz string, bizID int64) (In
 	return inter, err
 }
 
-func (dao *interactionDao) InsertFavorite(ctx context.Context, favorite UserFavorite) error {
-	// 暂时不考虑分享数的正确性
+func (dao *interactionDao) InsertFavorite(ctx context.Context, favorite UserFavorite) (bool, error) {
 	now := time.Now().UnixMilli()
-	return dao.db.Transaction(func(tx *gorm.DB) error {
+	favorite.CTime = now
+	favorite.UTime = now
+	inserted := false
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 锁住目标收藏夹，收藏夹必须存在并且属于该用户
+		var folder FavoriteFolder
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? and uid = ?", favorite.FavoriteID, favorite.Uid).
+			First(&folder).Error
+		if err != nil {
+			return err
+		}
+
+		var old UserFavorite
+		err = tx.Where("uid = ? and biz = ? and biz_id = ?", favorite.Uid, favorite.Biz, favorite.BizID).
+			First(&old).Error
+		switch err {
+		case nil:
+			// 已经收藏过了，移动到目标收藏夹，资源的收藏数不变
+			if old.FavoriteID == favorite.FavoriteID {
+				return nil
+			}
+			err = tx.Model(&UserFavorite{}).Where("id = ?", old.ID).
+				Updates(map[string]any{
+					"favorite_id": favorite.FavoriteID,
+					"u_time":      now,
+				}).Error
+			if err != nil {
+				return err
+			}
+			if err = incrFolderItems(tx, old.FavoriteID, -1, now); err != nil {
+				return err
+			}
+			return incrFolderItems(tx, favorite.FavoriteID, 1, now)
+		case ErrNotFound:
+		default:
+			return err
+		}
+
 		// 新建收藏记录
-		err := tx.WithContext(ctx).Create(&favorite).Error
+		err = tx.Create(&favorite).Error
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == 1062 {
+			// 并发收藏，另一个请求已经插入了
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		//增加分享次数
-		return tx.WithContext(ctx).Model(&Interaction{}).
-			Clauses(clause.OnConflict{
-				DoUpdates: clause.Assignments(map[string]interface{}{
-					"favorites": gorm.Expr("favorites + 1"),
-					"u_time":    now,
-				}),
-			}).Create(&Interaction{
-			Biz:       favorite.Biz,
-			BizID:     favorite.BizID,
-			Favorites: 1,
-			CTime:     now,
-			UTime:     now,
-		}).Error
+		inserted = true
+		if err = incrFolderItems(tx, favorite.FavoriteID, 1, now); err != nil {
+			return err
+		}
+		// 增加收藏数
+		return incrFavorites(tx, favorite.Biz, favorite.BizID, 1, now)
 	})
+	return inserted, err
 }
 
-func (dao *interactionDao) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
-	now := time.Now().UnixMilli()
-
-	inter := Interaction{
-		Biz:     biz,
-		BizID:   bizID,
-		ReadCnt: 1,
-		CTime:   now,
-		UTime:   now,
-	}
-
-	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"u_time":   now,
-			"read_cnt": gorm.Expr("read_cnt + ?", 1),
-		}),
-	}).Create(&inter).Error
-}
-
-// InsertLikeInfo 插入点赞记录
-func (dao *interactionDao) InsertLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) error {
-	/*
-		目前这种实现，没有判定玩家是否点赞成功，因此只要用户不停的取消并再次点赞，点赞数就会一直增加。
-		解决方案：
-			锁住一条记录
-			不存在则插入
-			更新则更新
-		并发高的时候会导致死锁，但是锁住的是个人数据，几率会低，可以接受。
-	*/
-
-	// 1. 插入点赞信息
-	now := time.Now().UnixMilli()
-
-	err := dao.db.WithContext(ctx).Clauses(clause.OnConflict{
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"u_time": now,
-			// 因为使用status来表示记录是否存在，所以由dao层来设置status的值
-			"status": Liked,
-		}),
-	}).Create(&UserLike{
-		Uid:    uid,
-		Biz:    biz,
-		BizID:  bizID,
-		Status: Liked,
-		CTime:  now,
-		UTime:  now,
-	}).Error
-	if err != nil {
-		return err
-	}
+func (dao *interactionDao) DeleteFavorite(ctx context.Context, uid int64, biz string, bizID int64) (bool, error) {
+	deleted := false
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var old UserFavorite
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? and biz = ? and biz_id = ?", uid, biz, bizID).
+			First(&old).Error
+		if err == ErrNotFound {
+			// 没有收藏
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		res := tx.Where("id = ?", old.ID).Delete(&UserFavorite{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		deleted = true
+		now := time.Now().UnixMilli()
+		if err = incrFolderItems(tx, old.FavoriteID, -1, now); err != nil {
+			return err
+		}
+		return incrFavorites(tx, biz, bizID, -1, now)
+	})
+	return deleted, err
+}
 
-	// 增加计数
-	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
-		DoUpdates: clause.Assignments(map[string]interface{}{
-			"u_time": now,
-			"likes":  gorm.Expr("likes + ?", 1),
-		}),
-	}).Create(&Interaction{
-		Biz:   biz,
-		BizID: bizID,
-		Likes: 1,
-		CTime: now,
-		UTime: now,
-	}).Error
-}
-
-func (dao *interactionDao) DeleteLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) error {
+func (dao *interactionDao) InsertLikeInfo(ctx context.Context, uid int64, biz string, bizID int64, reaction uint8) (uint8, error) {
+	// 锁住用户自己的点赞记录，返回之前的表态，重复表态不会重复计数。
+	// 并发高的时候可能死锁，但是锁住的是个人数据，几率很低，可以接受。
 	now := time.Now().UnixMilli()
+	var old uint8
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var like UserLike
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? and biz = ? and biz_id = ?", uid, biz, bizID).
+			First(&like).Error
+		switch err {
+		case nil:
+			if like.Status == Liked {
+				old = like.Reaction
+				if like.Reaction == reaction {
+					return nil
+				}
+			}
+			return tx.Model(&UserLike{}).Where("id = ?", like.ID).
+				Updates(map[string]interface{}{
+					"u_time": now,
+					// 因为使用status来表示记录是否存在，所以由dao层来设置status的值
+					"status":   Liked,
+					"reaction": reaction,
+				}).Error
+		case ErrNotFound:
+			err = tx.Create(&UserLike{
+				Uid:      uid,
+				Biz:      biz,
+				BizID:    bizID,
+				Status:   Liked,
+				Reaction: reaction,
+				CTime:    now,
+				UTime:    now,
+			}).Error
+			if me, ok := err.(*mysql.MySQLError); ok && me.Number == 1062 {
+				// 并发表态，另一个请求已经插入了，当作没有变化
+				old = reaction
+				return nil
+			}
+			return err
+		default:
+			return err
+		}
+	})
+	return old, err
+}
 
-	return dao.db.Transaction(func(tx *gorm.DB) error {
-		err := tx.WithContext(ctx).Model(&UserLike{}).
-			Where("uid = ? and biz = ? and biz_id = ?", uid, biz, bizID).Updates(map[string]interface{}{
-			"u_time": now,
-			"status": Unliked,
-		}).Error
+func (dao *interactionDao) DeleteLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (uint8, error) {
+	var old uint8
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var like UserLike
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? and biz = ? and biz_id = ? and status = ?", uid, biz, bizID, Liked).
+			First(&like).Error
+		if err == ErrNotFound {
+			return nil
+		}
 		if err != nil {
 			return err
 		}
-		// 严格一点的需要判定执行行数是否等于1，成功在能够取消点赞
-		return tx.WithContext(ctx).Model(&Interaction{}).
-			Where("biz = ? and biz_id = ?", biz, bizID).
+		old = like.Reaction
+		return tx.Model(&UserLike{}).Where("id = ?", like.ID).
 			Updates(map[string]interface{}{
-				"u_time": now,
-				"likes":  gorm.Expr("likes - ?", 1),
+				"u_time": time.Now().UnixMilli(),
+				"status": Unliked,
 			}).Error
 	})
+	return old, err
 }
 
 type interactionDao struct {
@@ -220,16 +320,8 @@ func (dao *interactionDao) GetByIDs(ctx context.Context, biz string, ds []int64)
 	return inters, err
 }
 
-func (dao *interactionDao) BatchIncrReadCnt(ctx context.Context, bizs []string, ids []int64) error {
-	return dao.db.Transaction(func(tx *gorm.DB) error {
-		for i := range ids {
-			err := dao.IncrReadCnt(ctx, bizs[i], ids[i])
-			if err != nil {
-				// 对于文章这种计数器，即使少了个别文章的计数影响也不大，因此不回滚，只记录该错误。
-			}
-		}
-		return nil
-	})
+func (dao *interactionDao) GetReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error) {
+	return getReactions(dao.db.WithContext(ctx), biz, bizIDs)
 }
 
 func NewInteractionDao(db *gorm.DB) InteractionDao {
@@ -237,3 +329,55 @@ func NewInteractionDao(db *gorm.DB) InteractionDao {
 		db: db,
 	}
 }
+
+func (dao *interactionDao) ApplyDelta(ctx context.Context, delta CounterDelta) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return applyDelta(tx, delta, time.Now().UnixMilli())
+	})
+}
+
+func (dao *interactionDao) ListLikers(ctx context.Context, biz string, bizID int64) ([]int64, error) {
+	var uids []int64
+	err := dao.db.WithContext(ctx).Model(&UserLike{}).
+		Where("biz = ? and biz_id = ? and status = ?", biz, bizID, Liked).
+		Pluck("uid", &uids).Error
+	return uids, err
+}
+
+func (dao *interactionDao) Delete(ctx context.Context, biz string, bizID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&UserLike{}).Error
+		if err != nil {
+			return err
+		}
+		// 一个收藏夹中同一个资源最多只有一条收藏
+		err = tx.Model(&FavoriteFolder{}).Where("id in (?)", tx.Model(&UserFavorite{}).Select("favorite_id").
+			Where("biz = ? and biz_id = ?", biz, bizID)).
+			Update("items", gorm.Expr("items - 1")).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&UserFavorite{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("comment_id in (?)", tx.Model(&Comment{}).Select("id").
+			Where("biz = ? and biz_id = ?", biz, bizID)).Delete(&CommentLike{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&Comment{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&InteractionReaction{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&InteractionStat{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("biz = ? and biz_id = ?", biz, bizID).Delete(&Interaction{}).Error
+	})
+}
@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInteractionDao_InsertLikeInfo(t *testing.T) {
+	likeColumns := []string{"id", "uid", "biz", "biz_id", "status", "reaction"}
+	const selectLike = "SELECT \\* FROM `user_likes` WHERE uid = \\? and biz = \\? and biz_id = \\? ORDER BY `user_likes`.`id` LIMIT \\? FOR UPDATE"
+	const updateLike = "UPDATE `user_likes` SET `reaction`=\\?,`status`=\\?,`u_time`=\\? WHERE id = \\?"
+
+	testCases := []struct {
+		name     string
+		mock     func(mock sqlmock.Sqlmock)
+		reaction uint8
+
+		wantOld uint8
+	}{
+		{
+			name: "切换表态，返回之前的表态",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectLike).
+					WithArgs(int64(1001), "article", int64(1), 1).
+					WillReturnRows(sqlmock.NewRows(likeColumns).AddRow(10, 1001, "article", 1, Liked, 1))
+				mock.ExpectExec(updateLike).
+					WithArgs(uint8(2), Liked, sqlmock.AnyArg(), int64(10)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			reaction: 2,
+			wantOld:  1,
+		},
+		{
+			name: "重复表态，不修改",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectLike).
+					WillReturnRows(sqlmock.NewRows(likeColumns).AddRow(10, 1001, "article", 1, Liked, 2))
+				mock.ExpectCommit()
+			},
+			reaction: 2,
+			wantOld:  2,
+		},
+		{
+			name: "取消之后重新表态，之前没有表态",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectLike).
+					WillReturnRows(sqlmock.NewRows(likeColumns).AddRow(10, 1001, "article", 1, Unliked, 1))
+				mock.ExpectExec(updateLike).
+					WithArgs(uint8(3), Liked, sqlmock.AnyArg(), int64(10)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			reaction: 3,
+			wantOld:  0,
+		},
+		{
+			name: "第一次表态",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectLike).
+					WillReturnRows(sqlmock.NewRows(likeColumns))
+				mock.ExpectExec("INSERT INTO `user_likes`").
+					WithArgs(int64(1001), "article", int64(1), uint8(Liked), uint8(2), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(10, 1))
+				mock.ExpectCommit()
+			},
+			reaction: 2,
+			wantOld:  0,
+		},
+		{
+			name: "并发的第一次表态，当作没有变化",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(selectLike).
+					WillReturnRows(sqlmock.NewRows(likeColumns))
+				mock.ExpectExec("INSERT INTO `user_likes`").
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+				mock.ExpectCommit()
+			},
+			reaction: 2,
+			wantOld:  2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			old, err := NewInteractionDao(newMockGORM(t, sqlDB)).InsertLikeInfo(context.Background(), 1001, "article", 1, tc.reaction)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantOld, old)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
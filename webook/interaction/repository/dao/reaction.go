@@ -0,0 +1,64 @@
+package dao
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+表态
+
+	点赞泛化成了表态，user_likes中的reaction是用户当前的表态，旧数据默认是点赞（1）。
+	Interaction的likes是所有表态的总数，interaction_reactions中是每种表态的数量，两者在写回批次的同一个事务中修改。
+	切换表态时，旧表态-1、新表态+1在缓冲区中是一次原子操作，写入数据库时也在同一个事务中，总数不变。
+	表态上线之前的资源没有interaction_reactions记录，InitTable中用点赞记录统计出每种表态的数量（migrateLegacyReactions）。
+*/
+
+// InteractionReaction 资源每种表态的数量
+type InteractionReaction struct {
+	ID int64 `gorm:"primaryKey,autoIncrement"`
+
+	// biz, biz_id, reaction组成唯一索引
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:idx_biz_biz_id_reaction,priority:1"`
+	BizID    int64  `gorm:"uniqueIndex:idx_biz_biz_id_reaction,priority:2"`
+	Reaction uint8  `gorm:"uniqueIndex:idx_biz_biz_id_reaction,priority:3"`
+	Cnt      int64
+
+	CTime int64 `gorm:"column:c_time"`
+	UTime int64 `gorm:"column:u_time"`
+}
+
+// incrReaction 修改资源一种表态的数量，还没有记录时插入delta。
+// delta是负数时也照样插入，每种表态的和才能和点赞数的增量一致，负数由对账任务修正
+func incrReaction(tx *gorm.DB, biz string, bizID int64, reaction uint8, delta int64, now int64) error {
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"cnt":    gorm.Expr("cnt + ?", delta),
+			"u_time": now,
+		}),
+	}).Create(&InteractionReaction{
+		Biz:      biz,
+		BizID:    bizID,
+		Reaction: reaction,
+		Cnt:      delta,
+		CTime:    now,
+		UTime:    now,
+	}).Error
+}
+
+// getReactions 查询资源每种表态的数量，key是biz_id
+func getReactions(db *gorm.DB, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error) {
+	var rows []InteractionReaction
+	err := db.Where("biz = ? and biz_id in ?", biz, bizIDs).Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]map[uint8]int64, len(bizIDs))
+	for _, row := range rows {
+		if res[row.BizID] == nil {
+			res[row.BizID] = make(map[uint8]int64)
+		}
+		res[row.BizID][row.Reaction] = row.Cnt
+	}
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestIncrReaction(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 迁移之前的点赞切换成其他表态，点赞还没有记录，插入负数
+	mock.ExpectExec("INSERT INTO `interaction_reactions` .* ON DUPLICATE KEY UPDATE `cnt`=cnt \\+ \\?,`u_time`=\\?").
+		WithArgs("article", int64(1), uint8(1), int64(-1), int64(100), int64(100), int64(-1), int64(100)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, incrReaction(newMockGORM(t, sqlDB), "article", 1, 1, -1, 100))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateLegacyReactions(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT `id` FROM `interactions` WHERE \\(id > \\? and likes > 0\\) AND \\(NOT EXISTS \\(.*\\)\\) ORDER BY id LIMIT \\?").
+		WithArgs(int64(0), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `interactions` WHERE id in \\(\\?,\\?\\) AND \\(NOT EXISTS \\(.*\\)\\) ORDER BY id FOR UPDATE").
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id", "likes"}).
+			AddRow(1, "article", 11, 3).
+			// 统计的时候点赞已经全部取消了
+			AddRow(2, "article", 12, 1))
+	mock.ExpectQuery("SELECT biz_id, reaction, count\\(\\*\\) as cnt FROM `user_likes` "+
+		"WHERE biz = \\? and biz_id in \\(\\?,\\?\\) and status = \\? GROUP BY biz_id, reaction").
+		WithArgs("article", int64(11), int64(12), Liked).
+		WillReturnRows(sqlmock.NewRows([]string{"biz_id", "reaction", "cnt"}).
+			AddRow(11, 2, 1).
+			AddRow(11, 1, 2))
+	// 按照表态的顺序插入统计出来的数量
+	mock.ExpectExec("INSERT INTO `interaction_reactions`").
+		WithArgs(
+			"article", int64(11), uint8(1), int64(2), sqlmock.AnyArg(), sqlmock.AnyArg(),
+			"article", int64(11), uint8(2), int64(1), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+	// 下一批从上一批最后一个资源之后开始
+	mock.ExpectQuery("SELECT `id` FROM `interactions`").
+		WithArgs(int64(2), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	require.NoError(t, migrateLegacyReactions(newMockGORM(t, sqlDB), 2))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrateLegacyReactions_Migrated(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT `id` FROM `interactions`").
+		WithArgs(int64(0), 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	// 并发的迁移已经处理过这个资源
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT \\* FROM `interactions` .* FOR UPDATE").
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "biz", "biz_id", "likes"}))
+	mock.ExpectCommit()
+
+	require.NoError(t, migrateLegacyReactions(newMockGORM(t, sqlDB), 100))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,124 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+分时统计
+
+	阅读数、点赞数、收藏数在修改计数的同一个事务中累加到计数发生时所在小时的桶里，桶中记录的是这段时间内的净增量。
+	写回缓冲区中的增量记录了发生时的小时桶，晚一些写入数据库也不会算到别的小时。
+	小时桶只保留最近几天，定时任务把已经结束的那一天的小时桶汇总成天桶，再删除过期的小时桶。
+	汇总是用小时桶的和覆盖天桶，重复执行不会重复计数。
+*/
+
+const (
+	StatGranularityHour = "hour"
+	StatGranularityDay  = "day"
+)
+
+// InteractionStat 资源在一个时间桶内的计数增量，bucket是桶的开始时间
+type InteractionStat struct {
+	ID int64 `gorm:"primaryKey,autoIncrement"`
+
+	// biz, biz_id, granularity, bucket组成唯一索引
+	Biz         string `gorm:"type:varchar(128);uniqueIndex:idx_biz_granularity_bucket,priority:1"`
+	BizID       int64  `gorm:"uniqueIndex:idx_biz_granularity_bucket,priority:2"`
+	Granularity string `gorm:"type:varchar(16);uniqueIndex:idx_biz_granularity_bucket,priority:3;index:idx_granularity_bucket,priority:1"`
+	Bucket      int64  `gorm:"uniqueIndex:idx_biz_granularity_bucket,priority:4;index:idx_granularity_bucket,priority:2"`
+
+	ReadCnt   int64
+	Likes     int64
+	Favorites int64
+
+	CTime int64 `gorm:"column:c_time"`
+	UTime int64 `gorm:"column:u_time"`
+}
+
+type StatDao interface {
+	// List 查询资源在[from, to)之间的时间桶
+	List(ctx context.Context, biz string, bizIDs []int64, granularity string, from int64, to int64) ([]InteractionStat, error)
+	// Rollup 把[from, to)之间的小时桶汇总成bucket为from的天桶
+	Rollup(ctx context.Context, from int64, to int64) error
+	// DeleteBefore 删除bucket早于before的时间桶
+	DeleteBefore(ctx context.Context, granularity string, before int64) error
+}
+
+type statDao struct {
+	db *gorm.DB
+}
+
+func NewStatDao(db *gorm.DB) StatDao {
+	return &statDao{
+		db: db,
+	}
+}
+
+func (dao *statDao) List(ctx context.Context, biz string, bizIDs []int64, granularity string, from int64, to int64) ([]InteractionStat, error) {
+	var res []InteractionStat
+	err := dao.db.WithContext(ctx).
+		Where("biz = ? and biz_id in ? and granularity = ? and bucket >= ? and bucket < ?",
+			biz, bizIDs, granularity, from, to).
+		Order("bucket").Find(&res).Error
+	return res, err
+}
+
+func (dao *statDao) Rollup(ctx context.Context, from int64, to int64) error {
+	var sums []InteractionStat
+	err := dao.db.WithContext(ctx).Model(&InteractionStat{}).
+		Select("biz, biz_id, sum(read_cnt) as read_cnt, sum(likes) as likes, sum(favorites) as favorites").
+		Where("granularity = ? and bucket >= ? and bucket < ?", StatGranularityHour, from, to).
+		Group("biz, biz_id").Scan(&sums).Error
+	if err != nil || len(sums) == 0 {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	for i := range sums {
+		sums[i].Granularity = StatGranularityDay
+		sums[i].Bucket = from
+		sums[i].CTime = now
+		sums[i].UTime = now
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"read_cnt", "likes", "favorites", "u_time"}),
+	}).CreateInBatches(sums, 500).Error
+}
+
+func (dao *statDao) DeleteBefore(ctx context.Context, granularity string, before int64) error {
+	return dao.db.WithContext(ctx).
+		Where("granularity = ? and bucket < ?", granularity, before).
+		Delete(&InteractionStat{}).Error
+}
+
+// incrStat 累加资源的小时桶，和计数在同一个事务中修改
+func incrStat(tx *gorm.DB, stat InteractionStat, now int64) error {
+	return incrStats(tx, []InteractionStat{stat}, now)
+}
+
+// incrStats 一条语句累加多个资源的小时桶，桶已经存在时加上这次的增量。
+// stat中的Bucket是计数发生的时间，为0时使用now
+func incrStats(tx *gorm.DB, stats []InteractionStat, now int64) error {
+	hour := time.Hour.Milliseconds()
+	for i := range stats {
+		at := stats[i].Bucket
+		if at <= 0 {
+			at = now
+		}
+		stats[i].Granularity = StatGranularityHour
+		stats[i].Bucket = at - at%hour
+		stats[i].CTime = now
+		stats[i].UTime = now
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":  gorm.Expr("read_cnt + VALUES(read_cnt)"),
+			"likes":     gorm.Expr("likes + VALUES(likes)"),
+			"favorites": gorm.Expr("favorites + VALUES(favorites)"),
+			"u_time":    now,
+		}),
+	}).Create(&stats).Error
+}
@@ -0,0 +1,110 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestStatDao_Rollup(t *testing.T) {
+	day := time.Date(2023, 11, 14, 0, 0, 0, 0, time.Local).UnixMilli()
+	next := day + (time.Hour * 24).Milliseconds()
+
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "小时桶的和覆盖天桶",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT biz, biz_id, sum\\(read_cnt\\) as read_cnt, sum\\(likes\\) as likes, sum\\(favorites\\) as favorites "+
+					"FROM `interaction_stats` WHERE \\(?granularity = \\? and bucket >= \\? and bucket < \\?\\)? GROUP BY biz, biz_id").
+					WithArgs(StatGranularityHour, day, next).
+					WillReturnRows(sqlmock.NewRows([]string{"biz", "biz_id", "read_cnt", "likes", "favorites"}).
+						AddRow("article", 1, 10, 2, 1).
+						AddRow("article", 2, 3, -1, 0))
+				// 重复汇总时用新的和覆盖，而不是累加
+				mock.ExpectExec("INSERT INTO `interaction_stats` .* ON DUPLICATE KEY UPDATE "+
+					"`read_cnt`=VALUES\\(`read_cnt`\\),`likes`=VALUES\\(`likes`\\),`favorites`=VALUES\\(`favorites`\\),`u_time`=VALUES\\(`u_time`\\)").
+					WithArgs(
+						"article", int64(1), StatGranularityDay, day, int64(10), int64(2), int64(1), sqlmock.AnyArg(), sqlmock.AnyArg(),
+						"article", int64(2), StatGranularityDay, day, int64(3), int64(-1), int64(0), sqlmock.AnyArg(), sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name: "这一天没有小时桶",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT biz, biz_id").
+					WillReturnRows(sqlmock.NewRows([]string{"biz", "biz_id", "read_cnt", "likes", "favorites"}))
+			},
+		},
+		{
+			name: "查询失败",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT biz, biz_id").
+					WillReturnError(errors.New("mock db error"))
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			err = NewStatDao(newMockGORM(t, sqlDB)).Rollup(context.Background(), day, next)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestIncrStat(t *testing.T) {
+	hour := time.Hour.Milliseconds()
+	now := int64(1700000000000)
+	readAt := now - hour*3 + 1000
+
+	testCases := []struct {
+		name string
+		stat InteractionStat
+
+		wantBucket int64
+	}{
+		{
+			name:       "累加到计数发生时所在的小时桶",
+			stat:       InteractionStat{Biz: "article", BizID: 1, Bucket: readAt, ReadCnt: 2},
+			wantBucket: readAt - readAt%hour,
+		},
+		{
+			name:       "没有记录时间，累加到当前小时的桶",
+			stat:       InteractionStat{Biz: "article", BizID: 1, ReadCnt: 2},
+			wantBucket: now - now%hour,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			// 桶已经存在时加上这次的增量
+			mock.ExpectExec("INSERT INTO `interaction_stats` .* ON DUPLICATE KEY UPDATE "+
+				"`favorites`=favorites \\+ VALUES\\(favorites\\),`likes`=likes \\+ VALUES\\(likes\\),"+
+				"`read_cnt`=read_cnt \\+ VALUES\\(read_cnt\\),`u_time`=\\?").
+				WithArgs("article", int64(1), StatGranularityHour, tc.wantBucket, int64(2), int64(0), int64(0), now, now, now).
+				WillReturnResult(sqlmock.NewResult(1, 1))
+
+			err = incrStat(newMockGORM(t, sqlDB), tc.stat, now)
+			require.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"time"
+)
+
+//go:generate mockgen -source=./favorite.go -package=repomocks -destination=./mocks/favorite.mock.go FavoriteRepository
+type FavoriteRepository interface {
+	CreateFolder(ctx context.Context, f domain.FavoriteFolder) (int64, error)
+	// UpdateFolder 修改名称和是否私密，收藏夹不存在或者不属于f.Uid时返回ErrNotFound
+	UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error
+	// DeleteFolder 删除收藏夹和其中的收藏
+	DeleteFolder(ctx context.Context, uid int64, id int64) error
+	GetFolder(ctx context.Context, id int64) (domain.FavoriteFolder, error)
+	ListFolders(ctx context.Context, uid int64, includePrivate bool) ([]domain.FavoriteFolder, error)
+	CountFolders(ctx context.Context, uid int64) (int64, error)
+
+	// ListItems 按照收藏时间倒序查询收藏夹中的收藏，maxID是上一页最后一条收藏的id
+	ListItems(ctx context.Context, folderID int64, maxID int64, limit int) ([]domain.UserFavorite, error)
+}
+
+type favoriteRepository struct {
+	dao   dao.FavoriteDao
+	cache cache.InteractionCache
+}
+
+func NewFavoriteRepository(dao dao.FavoriteDao, cache cache.InteractionCache) FavoriteRepository {
+	return &favoriteRepository{
+		dao:   dao,
+		cache: cache,
+	}
+}
+
+func (repo *favoriteRepository) CreateFolder(ctx context.Context, f domain.FavoriteFolder) (int64, error) {
+	return repo.dao.InsertFolder(ctx, repo.toEntity(f))
+}
+
+func (repo *favoriteRepository) UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error {
+	return repo.dao.UpdateFolder(ctx, repo.toEntity(f))
+}
+
+func (repo *favoriteRepository) DeleteFolder(ctx context.Context, uid int64, id int64) error {
+	items, err := repo.dao.DeleteFolder(ctx, uid, id)
+	if err != nil {
+		return err
+	}
+	// 缓存中被删除的收藏对应资源的收藏数-1
+	for _, item := range items {
+		err = repo.cache.DecrFavoriteCnt(ctx, item.Biz, item.BizID)
+		if err != nil {
+			// 记录日志
+		}
+	}
+	return nil
+}
+
+func (repo *favoriteRepository) GetFolder(ctx context.Context, id int64) (domain.FavoriteFolder, error) {
+	f, err := repo.dao.GetFolder(ctx, id)
+	if err != nil {
+		return domain.FavoriteFolder{}, err
+	}
+	return repo.toDomain(f), nil
+}
+
+func (repo *favoriteRepository) ListFolders(ctx context.Context, uid int64, includePrivate bool) ([]domain.FavoriteFolder, error) {
+	fs, err := repo.dao.ListFolders(ctx, uid, includePrivate)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(fs, func(idx int, src dao.FavoriteFolder) domain.FavoriteFolder {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *favoriteRepository) CountFolders(ctx context.Context, uid int64) (int64, error) {
+	return repo.dao.CountFolders(ctx, uid)
+}
+
+func (repo *favoriteRepository) ListItems(ctx context.Context, folderID int64, maxID int64, limit int) ([]domain.UserFavorite, error) {
+	items, err := repo.dao.ListItems(ctx, folderID, maxID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(items, func(idx int, src dao.UserFavorite) domain.UserFavorite {
+		return domain.UserFavorite{
+			ID:         src.ID,
+			Uid:        src.Uid,
+			Biz:        src.Biz,
+			BizID:      src.BizID,
+			FavoriteID: src.FavoriteID,
+			CTime:      time.UnixMilli(src.CTime),
+			UTime:      time.UnixMilli(src.UTime),
+		}
+	}), nil
+}
+
+func (repo *favoriteRepository) toEntity(f domain.FavoriteFolder) dao.FavoriteFolder {
+	return dao.FavoriteFolder{
+		ID:      f.ID,
+		Uid:     f.Uid,
+		Name:    f.Name,
+		Private: f.Private,
+		Items:   f.Items,
+	}
+}
+
+func (repo *favoriteRepository) toDomain(f dao.FavoriteFolder) domain.FavoriteFolder {
+	return domain.FavoriteFolder{
+		ID:      f.ID,
+		Uid:     f.Uid,
+		Name:    f.Name,
+		Private: f.Private,
+		Items:   f.Items,
+		CTime:   time.UnixMilli(f.CTime),
+		UTime:   time.UnixMilli(f.UTime),
+	}
+}
@@ -2,7 +2,6 @@ package repository
 
 import (
 	"context"
-	"errors"
 	"github.com/ecodeclub/ekit/slice"
 	"golang.org/x/sync/errgroup"
 	"learn_go/webook/interaction/domain"
@@ -11,23 +10,40 @@ import (
 	"time"
 )
 
+//go:generate mockgen -source=./interaction.go -package=repomocks -destination=./mocks/interaction.mock.go InteractionRepository
 type InteractionRepository interface {
 	IncrReadCnt(ctx context.Context, biz string, bizID int64) error
 
-	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIDs []int64) error
+	// BatchIncrReadCnt 每次阅读的阅读数+1，按照阅读发生的时间统计到对应的小时桶
+	BatchIncrReadCnt(ctx context.Context, views []domain.View) error
+	// BatchIncrUniqueViewCnt 去重后的阅读数批量+1
+	BatchIncrUniqueViewCnt(ctx context.Context, views []domain.View) error
+	// DedupViews 阅读去重，返回每次阅读是否计入阅读数、是否计入去重后的阅读数
+	DedupViews(ctx context.Context, views []domain.View) ([]domain.ViewResult, error)
 
-	// IncrLike 创建点赞信息
-	IncrLike(ctx context.Context, uid int64, biz string, bizID int64) error
-	// DecrLike 移除点赞信息
+	// IncrLike 设置用户的表态，已经有其他表态时切换过去
+	IncrLike(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error
+	// DecrLike 取消用户的表态
 	DecrLike(ctx context.Context, uid int64, biz string, bizID int64) error
+	// AddFavoriteItem 收藏到favoriteID收藏夹，已经收藏在其他收藏夹时移动过去
 	AddFavoriteItem(ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64) error
+	// DeleteFavoriteItem 取消收藏，没有收藏时不会返回错误
+	DeleteFavoriteItem(ctx context.Context, uid int64, biz string, bizID int64) error
 	Get(ctx context.Context, uid int64, biz string, bizID int64) (domain.Interaction, error)
 
 	// GetUserLikeInfo 获取用户的某个资源的点赞信息
 	GetUserLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserLike, error)
 	// GetUserFavoriteInfo 获取用户的某个资源的收藏信息
 	GetUserFavoriteInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserFavorite, error)
+	// ListUserLikes 按照点赞时间倒序查询用户的点赞，beforeTime、beforeID是上一页最后一条点赞的位置，beforeID为0表示第一页
+	ListUserLikes(ctx context.Context, uid int64, biz string, beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error)
+	// GetUserLiked 批量查询用户是否点赞，优先使用用户最近点赞的缓存
+	GetUserLiked(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error)
+	// GetUserCollected 批量查询用户是否收藏
+	GetUserCollected(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error)
 	GetByIDs(ctx context.Context, biz string, ds []int64) ([]domain.Interaction, error)
+	// Delete 删除资源的全部交互数据
+	Delete(ctx context.Context, biz string, bizID int64) error
 }
 
 func (repo *interactionRepository) GetUserLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserLike, error) {
@@ -35,14 +51,7 @@ func (repo *interactionRepository) GetUserLikeInfo(ctx context.Context, uid int6
 	if err != nil {
 		return domain.UserLike{}, err
 	}
-	return domain.UserLike{
-		ID:    userLike.ID,
-		Biz:   userLike.Biz,
-		Uid:   userLike.Uid,
-		BizID: userLike.BizID,
-		CTime: time.UnixMilli(userLike.CTime),
-		UTime: time.UnixMilli(userLike.UTime),
-	}, nil
+	return repo.toLikeDomain(userLike), nil
 }
 
 func (repo *interactionRepository) GetUserFavoriteInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserFavorite, error) {
@@ -69,10 +78,33 @@ func (repo *interactionRepository) Get(ctx context.Context, uid int64, biz strin
 	}
 
 	interEntity, err := repo.dao.Get(ctx, biz, bizID)
-	if err != nil {
+	switch err {
+	case nil:
+		inter = repo.toDomain(interEntity)
+		reactions, err := repo.dao.GetReactions(ctx, biz, []int64{bizID})
+		if err != nil {
+			return inter, err
+		}
+		inter.Reactions = toReactions(reactions[bizID])
+	case dao.ErrNotFound:
+		// 新资源的计数可能还在写回缓冲区中
+		inter.Reactions = make(map[domain.Reaction]int64, len(domain.Reactions))
+	default:
 		return inter, err
 	}
-	inter = repo.toDomain(interEntity)
+	// 加上还没有写入数据库的增量
+	pending, err := repo.counterCache.Pending(ctx, biz, bizID)
+	if err != nil {
+		// 记录日志。只有数据库中的计数，不写入缓存，避免缓存中的计数一直偏小
+		if interEntity.ID == 0 {
+			return inter, dao.ErrNotFound
+		}
+		return repo.appendLikedAndCollected(ctx, inter, uid, biz, bizID), nil
+	}
+	if pending.IsZero() && interEntity.ID == 0 {
+		return inter, dao.ErrNotFound
+	}
+	repo.addPending(&inter, pending)
 
 	go func() {
 		err := repo.cache.Set(ctx, biz, bizID, inter)
@@ -94,6 +126,9 @@ func (repo *interactionRepository) appendLikedAndCollected(ctx context.Context,
 			inter.Liked = false
 		} else {
 			inter.Liked = userLike.Liked()
+			if inter.Liked {
+				inter.Reaction = userLike.Reaction
+			}
 		}
 		return nil
 	})
@@ -112,13 +147,14 @@ func (repo *interactionRepository) appendLikedAndCollected(ctx context.Context,
 }
 
 func (repo *interactionRepository) AddFavoriteItem(ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64) error {
-	err := repo.dao.InsertFavorite(ctx, dao.UserFavorite{
+	inserted, err := repo.dao.InsertFavorite(ctx, dao.UserFavorite{
 		Uid:        uid,
 		FavoriteID: favoriteID,
 		Biz:        biz,
 		BizID:      bizID,
 	})
-	if err != nil {
+	// 移动到其他收藏夹时收藏数不变
+	if err != nil || !inserted {
 		return err
 	}
 	// 增加缓存计数
@@ -129,9 +165,27 @@ func (repo *interactionRepository) AddFavoriteItem(ctx context.Context, uid int6
 	return nil
 }
 
+func (repo *interactionRepository) DeleteFavoriteItem(ctx context.Context, uid int64, biz string, bizID int64) error {
+	deleted, err := repo.dao.DeleteFavorite(ctx, uid, biz, bizID)
+	if err != nil || !deleted {
+		return err
+	}
+	// 缓存计数-1
+	err = repo.cache.DecrFavoriteCnt(ctx, biz, bizID)
+	if err != nil {
+		// 记录日志
+	}
+	return nil
+}
+
 type interactionRepository struct {
 	dao   dao.InteractionDao
 	cache cache.InteractionCache
+	// counterCache 阅读数、点赞数的写回缓冲区
+	counterCache cache.CounterCache
+	viewCache    cache.ViewCache
+	// likeCache 用户最近的点赞，用于批量判断是否点赞
+	likeCache cache.LikeCache
 }
 
 func (repo *interactionRepository) GetByIDs(ctx context.Context, biz string, ds []int64) ([]domain.Interaction, error) {
@@ -139,79 +193,169 @@ func (repo *interactionRepository) GetByIDs(ctx context.Context, biz string, ds
 	if err != nil {
 		return nil, err
 	}
+	reactions, err := repo.dao.GetReactions(ctx, biz, ds)
+	if err != nil {
+		return nil, err
+	}
+	// 加上还没有写入数据库的增量，查询失败时返回数据库中的计数
+	pendings, err := repo.counterCache.PendingByIDs(ctx, biz, ds)
+	if err != nil {
+		// 记录日志
+	}
 	domainObjs := slice.Map(inters, func(idx int, src dao.Interaction) domain.Interaction {
-		return repo.toDomain(src)
+		inter := repo.toDomain(src)
+		inter.Reactions = toReactions(reactions[src.BizID])
+		repo.addPending(&inter, pendings[src.BizID])
+		delete(pendings, src.BizID)
+		return inter
 	})
+	// 新资源的计数可能还全部在写回缓冲区中
+	for _, id := range ds {
+		pending, ok := pendings[id]
+		if !ok {
+			continue
+		}
+		delete(pendings, id)
+		inter := domain.Interaction{
+			Biz:       biz,
+			BizID:     id,
+			Reactions: make(map[domain.Reaction]int64, len(domain.Reactions)),
+		}
+		repo.addPending(&inter, pending)
+		domainObjs = append(domainObjs, inter)
+	}
 	return domainObjs, nil
 }
 
-func (repo *interactionRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIDs []int64) error {
-	if len(bizIDs) != len(bizIDs) {
-		return errors.New("the length of a and b must be equal")
-	}
-	// 1. 调用dao的批量增加
-	err := repo.dao.BatchIncrReadCnt(ctx, bizs, bizIDs)
-	if err != nil {
-		return err
+// addPending 把写回缓冲区中的增量加到计数上
+func (repo *interactionRepository) addPending(inter *domain.Interaction, pending domain.CounterDelta) {
+	inter.Views += pending.Views
+	inter.UniqueViews += pending.UniqueViews
+	inter.Likes += pending.Likes
+	for r, delta := range pending.Reactions {
+		inter.Reactions[r] += delta
 	}
+}
 
-	// TODO redis批量的执行lua脚本，压力很大，也可以考虑合并数据，批量执行。
-	// 2. 批量增加缓存
-	go func() {
-		for i := range bizIDs {
-			err := repo.cache.IncrReadCnt(ctx, bizs[i], bizIDs[i])
-			if err != nil {
-				// 记录日志
-			}
-		}
-	}()
-	return nil
+func (repo *interactionRepository) BatchIncrReadCnt(ctx context.Context, views []domain.View) error {
+	return repo.batchIncr(ctx, views, repo.counterCache.IncrReadCnt)
 }
 
-func NewInteractionRepository(dao dao.InteractionDao, cache cache.InteractionCache) InteractionRepository {
-	return &interactionRepository{
-		dao:   dao,
-		cache: cache,
+func (repo *interactionRepository) BatchIncrUniqueViewCnt(ctx context.Context, views []domain.View) error {
+	return repo.batchIncr(ctx, views, repo.counterCache.IncrUniqueViewCnt)
+}
+
+// batchIncr 合并同一个资源同一个小时的计数，热点资源一批只需要写一次缓冲区
+func (repo *interactionRepository) batchIncr(ctx context.Context, views []domain.View,
+	incr func(ctx context.Context, biz string, bizID int64, delta int64, at time.Time) error) error {
+	type bizKey struct {
+		biz   string
+		bizID int64
+		hour  int64
 	}
+	deltas := make(map[bizKey]int64, len(views))
+	for _, v := range views {
+		deltas[bizKey{biz: v.Biz, bizID: v.BizID, hour: v.Time.Truncate(time.Hour).UnixMilli()}]++
+	}
+	for k, delta := range deltas {
+		err := incr(ctx, k.biz, k.bizID, delta, time.UnixMilli(k.hour))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (repo *interactionRepository) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
-	// 1. 数据库自增+1
-	err := repo.dao.IncrReadCnt(ctx, biz, bizID)
+func (repo *interactionRepository) DedupViews(ctx context.Context, views []domain.View) ([]domain.ViewResult, error) {
+	// 未登录用户无法去重，只计入阅读数
+	res := make([]domain.ViewResult, len(views))
+	users := make([]domain.View, 0, len(views))
+	idx := make([]int, 0, len(views))
+	for i, v := range views {
+		res[i] = domain.ViewResult{View: v, Counted: true}
+		if v.Uid > 0 {
+			users = append(users, v)
+			idx = append(idx, i)
+		}
+	}
+	if len(users) == 0 {
+		return res, nil
+	}
+	deduped, err := repo.viewCache.Dedup(ctx, users)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	for i, r := range deduped {
+		res[idx[i]] = r
+	}
+	return res, nil
+}
 
-	// 2. 缓存自增+1。
-	err = repo.cache.IncrReadCnt(ctx, biz, bizID)
-	if err != nil {
-		// 记录日志错误
+func NewInteractionRepository(dao dao.InteractionDao, cache cache.InteractionCache,
+	counterCache cache.CounterCache, viewCache cache.ViewCache, likeCache cache.LikeCache) InteractionRepository {
+	return &interactionRepository{
+		dao:          dao,
+		cache:        cache,
+		counterCache: counterCache,
+		viewCache:    viewCache,
+		likeCache:    likeCache,
 	}
-	return nil
 }
 
-func (repo *interactionRepository) IncrLike(ctx context.Context, uid int64, biz string, bizID int64) error {
-	// 1. 插入我点赞的文章
-	// 2. 文章点赞+1
+// IncrReadCnt 阅读数累加到写回缓冲区，定时任务批量写入数据库
+func (repo *interactionRepository) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
+	return repo.counterCache.IncrReadCnt(ctx, biz, bizID, 1, time.Now())
+}
 
-	err := repo.dao.InsertLikeInfo(ctx, uid, biz, bizID)
-	if err != nil {
+func (repo *interactionRepository) IncrLike(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error {
+	// 1. 设置我对文章的表态
+	// 2. 表态数量的变化累加到写回缓冲区，重复表态不计数，切换表态时点赞总数不变
+	old, err := repo.dao.InsertLikeInfo(ctx, uid, biz, bizID, reaction.ToUint8())
+	if err != nil || domain.Reaction(old) == reaction {
 		return err
 	}
-
-	// 缓存计数+1
-	return repo.cache.IncrLikeCnt(ctx, biz, bizID)
+	if domain.Reaction(old) == domain.ReactionNone {
+		// 最近点赞的缓存只记录是否点赞，切换表态时不需要修改
+		err = repo.likeCache.Add(ctx, domain.UserLike{Uid: uid, Biz: biz, BizID: bizID, UTime: time.Now()})
+		if err != nil {
+			// 加入失败时删除缓存，下次查询时从数据库重新加载
+			_ = repo.likeCache.Del(ctx, uid, biz)
+		}
+	}
+	return repo.incrReaction(ctx, biz, bizID, domain.Reaction(old), reaction)
 }
 
 func (repo *interactionRepository) DecrLike(ctx context.Context, uid int64, biz string, bizID int64) error {
+	old, err := repo.dao.DeleteLikeInfo(ctx, uid, biz, bizID)
+	if err != nil || domain.Reaction(old) == domain.ReactionNone {
+		return err
+	}
+	err = repo.likeCache.Remove(ctx, uid, biz, bizID)
+	if err != nil {
+		_ = repo.likeCache.Del(ctx, uid, biz)
+	}
+	// 点赞数、表态数量-1累加到写回缓冲区
+	return repo.incrReaction(ctx, biz, bizID, domain.Reaction(old), domain.ReactionNone)
+}
 
-	err := repo.dao.DeleteLikeInfo(ctx, uid, biz, bizID)
+// incrReaction 表态记录已经提交，累加到写回缓冲区失败时直接写入数据库，
+// 否则这次的计数和u_time都会丢失，对账任务也找不到这个资源
+func (repo *interactionRepository) incrReaction(ctx context.Context, biz string, bizID int64, from, to domain.Reaction) error {
+	err := repo.counterCache.IncrReaction(ctx, biz, bizID, from, to)
+	if err == nil {
+		return nil
+	}
+	d := domain.NewReactionDelta(biz, bizID, from, to)
+	reactions := make(map[uint8]int64, len(d.Reactions))
+	for r, delta := range d.Reactions {
+		reactions[r.ToUint8()] = delta
+	}
+	err = repo.dao.ApplyDelta(ctx, dao.CounterDelta{Biz: biz, BizID: bizID, Likes: d.Likes, Reactions: reactions})
 	if err != nil {
 		return err
 	}
-
-	// 缓存计数-1
-	return repo.cache.DecrLikeCnt(ctx, biz, bizID)
+	// 脚本失败时缓存也没有修改，删除之后从数据库重新加载
+	return repo.cache.Del(ctx, biz, bizID)
 }
 
 func (repo *interactionRepository) toEntity(inter domain.Interaction) dao.Interaction {
@@ -220,9 +364,11 @@ func (repo *interactionRepository) toEntity(inter domain.Interaction) dao.Intera
 		Biz:   inter.Biz,
 		BizID: inter.ID,
 
-		Favorites: inter.Favorites,
-		ReadCnt:   inter.Views,
-		Likes:     inter.Likes,
+		Favorites:   inter.Favorites,
+		ReadCnt:     inter.Views,
+		UniqueViews: inter.UniqueViews,
+		Likes:       inter.Likes,
+		Comments:    inter.Comments,
 
 		CTime: inter.CTime.UnixMilli(),
 		UTime: inter.UTime.UnixMilli(),
@@ -235,11 +381,46 @@ func (repo *interactionRepository) toDomain(entity dao.Interaction) domain.Inter
 		Biz:   entity.Biz,
 		BizID: entity.ID,
 
-		Favorites: entity.Favorites,
-		Views:     entity.ReadCnt,
-		Likes:     entity.Likes,
+		Favorites:   entity.Favorites,
+		Views:       entity.ReadCnt,
+		UniqueViews: entity.UniqueViews,
+		Likes:       entity.Likes,
+		Comments:    entity.Comments,
 
 		CTime: time.UnixMilli(entity.CTime),
 		UTime: time.UnixMilli(entity.UTime),
 	}
 }
+
+// toReactions 数据库中没有记录的表态数量是0
+func toReactions(reactions map[uint8]int64) map[domain.Reaction]int64 {
+	res := make(map[domain.Reaction]int64, len(domain.Reactions))
+	for _, r := range domain.Reactions {
+		res[r] = reactions[r.ToUint8()]
+	}
+	return res
+}
+
+func (repo *interactionRepository) Delete(ctx context.Context, biz string, bizID int64) error {
+	// 先清理用户的点赞缓存，删除点赞记录之后就查不到有哪些用户了
+	uids, err := repo.dao.ListLikers(ctx, biz, bizID)
+	if err != nil {
+		return err
+	}
+	if err = repo.likeCache.RemoveUsers(ctx, uids, biz, bizID); err != nil {
+		return err
+	}
+	err = repo.dao.Delete(ctx, biz, bizID)
+	if err != nil {
+		return err
+	}
+	// 先删数据库再删缓存，缓存删除失败时重试整个操作也是安全的。
+	// 缓冲区中的增量写入时会重新插入交互数据，也要删掉
+	if err = repo.counterCache.Del(ctx, biz, bizID); err != nil {
+		return err
+	}
+	if err = repo.viewCache.Del(ctx, biz, bizID); err != nil {
+		return err
+	}
+	return repo.cache.Del(ctx, biz, bizID)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"testing"
+	"time"
+)
+
+func TestInteractionRepository_DedupViews(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cmd := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewInteractionRepository(nil, nil, nil, cache.NewViewCache(cmd), nil)
+	ctx := context.Background()
+	now := time.Now()
+
+	views := []domain.View{
+		// 未登录用户不去重
+		{Biz: "article", BizID: 1, Time: now},
+		{Uid: 1001, Biz: "article", BizID: 1, Time: now},
+		{Biz: "article", BizID: 1, Time: now},
+		{Uid: 1001, Biz: "article", BizID: 1, Time: now},
+	}
+	res, err := repo.DedupViews(ctx, views)
+	require.NoError(t, err)
+	// 结果和阅读一一对应
+	assert.Equal(t, []domain.ViewResult{
+		{View: views[0], Counted: true},
+		{View: views[1], Counted: true, Unique: true},
+		{View: views[2], Counted: true},
+		{View: views[3]},
+	}, res)
+
+	// 只有未登录用户时不访问redis
+	mr.Close()
+	res, err = repo.DedupViews(ctx, views[:1])
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ViewResult{{View: views[0], Counted: true}}, res)
+}
+
+func TestInteractionRepository_BatchIncrReadCnt(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cmd := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	counterCache := cache.NewCounterCache(cmd)
+	repo := NewInteractionRepository(nil, nil, counterCache, nil, nil)
+	ctx := context.Background()
+	hour := time.Now().Truncate(time.Hour).Add(-time.Hour * 2)
+
+	err := repo.BatchIncrReadCnt(ctx, []domain.View{
+		{Biz: "article", BizID: 1, Time: hour.Add(time.Minute)},
+		{Biz: "article", BizID: 1, Time: hour.Add(time.Minute * 59)},
+		// 消息积压时，下一个小时的阅读不会合并到一起
+		{Biz: "article", BizID: 1, Time: hour.Add(time.Hour)},
+	})
+	require.NoError(t, err)
+
+	ids, err := counterCache.TakeBatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	batch, err := counterCache.GetBatch(ctx, ids[0])
+	require.NoError(t, err)
+	views := make(map[time.Time]int64, len(batch.Deltas))
+	for _, d := range batch.Deltas {
+		views[d.Bucket] = d.Views
+	}
+	assert.Equal(t, map[time.Time]int64{hour: 2, hour.Add(time.Hour): 1}, views)
+}
+
+// fakeCountDao 只实现批量查询计数用到的方法
+type fakeCountDao struct {
+	dao.InteractionDao
+	inters    []dao.Interaction
+	reactions map[int64]map[uint8]int64
+}
+
+func (d *fakeCountDao) GetByIDs(ctx context.Context, biz string, ids []int64) ([]dao.Interaction, error) {
+	return d.inters, nil
+}
+
+func (d *fakeCountDao) GetReactions(ctx context.Context, biz string, bizIDs []int64) (map[int64]map[uint8]int64, error) {
+	return d.reactions, nil
+}
+
+func TestInteractionRepository_GetByIDs(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cmd := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	counterCache := cache.NewCounterCache(cmd)
+	d := &fakeCountDao{
+		inters: []dao.Interaction{
+			{ID: 1, Biz: "article", BizID: 1, ReadCnt: 10, Likes: 2},
+			{ID: 2, Biz: "article", BizID: 2, ReadCnt: 3},
+		},
+		reactions: map[int64]map[uint8]int64{1: {domain.ReactionLike.ToUint8(): 2}},
+	}
+	repo := NewInteractionRepository(d, nil, counterCache, nil, nil)
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, counterCache.IncrReadCnt(ctx, "article", 1, 5, now))
+	require.NoError(t, counterCache.IncrReaction(ctx, "article", 1, domain.ReactionNone, domain.ReactionLove))
+	// 新资源还没有写入数据库
+	require.NoError(t, counterCache.IncrReadCnt(ctx, "article", 3, 1, now))
+
+	inters, err := repo.GetByIDs(ctx, "article", []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, inters, 3)
+	assert.Equal(t, int64(15), inters[0].Views)
+	assert.Equal(t, int64(3), inters[0].Likes)
+	assert.Equal(t, int64(2), inters[0].Reactions[domain.ReactionLike])
+	assert.Equal(t, int64(1), inters[0].Reactions[domain.ReactionLove])
+	assert.Equal(t, int64(3), inters[1].Views)
+	assert.Equal(t, int64(3), inters[2].BizID)
+	assert.Equal(t, int64(1), inters[2].Views)
+
+	// 缓冲区不可用时返回数据库中的计数
+	mr.Close()
+	inters, err = repo.GetByIDs(ctx, "article", []int64{1, 2, 3})
+	require.NoError(t, err)
+	require.Len(t, inters, 2)
+	assert.Equal(t, int64(10), inters[0].Views)
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"time"
+)
+
+func (repo *interactionRepository) ListUserLikes(ctx context.Context, uid int64, biz string,
+	beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error) {
+	var beforeUtime int64
+	if beforeID > 0 {
+		beforeUtime = beforeTime.UnixMilli()
+	}
+	likes, err := repo.dao.ListUserLikes(ctx, uid, biz, beforeUtime, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(likes, func(idx int, src dao.UserLike) domain.UserLike {
+		return repo.toLikeDomain(src)
+	}), nil
+}
+
+func (repo *interactionRepository) GetUserLiked(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error) {
+	liked, complete, err := repo.likeCache.Check(ctx, uid, biz, bizIDs)
+	if err == cache.ErrKeyNotExist {
+		liked, complete, err = repo.loadLikes(ctx, uid, biz, bizIDs)
+	}
+	if err != nil {
+		// 缓存不可用，全部查询数据库
+		liked, complete = make(map[int64]bool, len(bizIDs)), false
+	}
+	if complete {
+		return liked, nil
+	}
+	// 缓存中只有最近的点赞，不在缓存中的再查询数据库
+	misses := slice.FilterMap(bizIDs, func(idx int, src int64) (int64, bool) {
+		return src, !liked[src]
+	})
+	if len(misses) == 0 {
+		return liked, nil
+	}
+	likes, err := repo.dao.BatchGetUserLikes(ctx, uid, biz, misses)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range likes {
+		liked[l.BizID] = true
+	}
+	return liked, nil
+}
+
+// loadLikes 从数据库加载用户最近的点赞重建缓存，返回bizIDs中在最近点赞里的资源
+// 查询数据库期间用户可能点赞或者取消点赞，缓存用版本号判断查询结果是否已经旧了
+func (repo *interactionRepository) loadLikes(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, bool, error) {
+	// 先读版本号再查询数据库，之后的修改都会让版本号变化
+	version, err := repo.likeCache.Version(ctx, uid, biz)
+	if err != nil {
+		return nil, false, err
+	}
+	// 多查一条，判断最近的点赞是不是用户全部的点赞
+	recent, err := repo.dao.ListUserLikes(ctx, uid, biz, 0, 0, cache.MaxRecentLikes+1)
+	if err != nil {
+		return nil, false, err
+	}
+	complete := len(recent) <= cache.MaxRecentLikes
+	if !complete {
+		recent = recent[:cache.MaxRecentLikes]
+	}
+	likes := slice.Map(recent, func(idx int, src dao.UserLike) domain.UserLike {
+		return repo.toLikeDomain(src)
+	})
+	// 重建缓存失败不影响本次查询
+	_, _ = repo.likeCache.Set(ctx, uid, biz, likes, complete, version)
+
+	recentIDs := make(map[int64]bool, len(recent))
+	for _, l := range recent {
+		recentIDs[l.BizID] = true
+	}
+	liked := make(map[int64]bool, len(bizIDs))
+	for _, id := range bizIDs {
+		if recentIDs[id] {
+			liked[id] = true
+		}
+	}
+	return liked, complete, nil
+}
+
+func (repo *interactionRepository) GetUserCollected(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error) {
+	favorites, err := repo.dao.BatchGetUserFavorites(ctx, uid, biz, bizIDs)
+	if err != nil {
+		return nil, err
+	}
+	collected := make(map[int64]bool, len(favorites))
+	for _, f := range favorites {
+		collected[f.BizID] = true
+	}
+	return collected, nil
+}
+
+func (repo *interactionRepository) toLikeDomain(like dao.UserLike) domain.UserLike {
+	return domain.UserLike{
+		ID:       like.ID,
+		Biz:      like.Biz,
+		Uid:      like.Uid,
+		BizID:    like.BizID,
+		Reaction: domain.Reaction(like.Reaction),
+		CTime:    time.UnixMilli(like.CTime),
+		UTime:    time.UnixMilli(like.UTime),
+	}
+}
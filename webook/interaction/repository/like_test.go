@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/cache"
+	"learn_go/webook/interaction/repository/dao"
+	"testing"
+	"time"
+)
+
+// fakeLikeDao 只实现查询点赞用到的方法，记录查询数据库的资源
+type fakeLikeDao struct {
+	dao.InteractionDao
+	likes []dao.UserLike
+	// queried BatchGetUserLikes查询的资源
+	queried []int64
+	// onList 查询最近的点赞之后执行，模拟查询期间的并发修改
+	onList func()
+}
+
+func (d *fakeLikeDao) ListUserLikes(ctx context.Context, uid int64, biz string, beforeUtime int64, beforeID int64, limit int) ([]dao.UserLike, error) {
+	res := d.likes[:min(limit, len(d.likes))]
+	if d.onList != nil {
+		d.onList()
+	}
+	return res, nil
+}
+
+func (d *fakeLikeDao) BatchGetUserLikes(ctx context.Context, uid int64, biz string, bizIDs []int64) ([]dao.UserLike, error) {
+	d.queried = append(d.queried, bizIDs...)
+	var res []dao.UserLike
+	for _, l := range d.likes {
+		for _, id := range bizIDs {
+			if l.BizID == id {
+				res = append(res, l)
+			}
+		}
+	}
+	return res, nil
+}
+
+// newFakeLikes 用户的点赞，按照时间倒序，bizID从n到1
+func newFakeLikes(n int) []dao.UserLike {
+	now := time.Now().UnixMilli()
+	likes := make([]dao.UserLike, 0, n)
+	for i := n; i > 0; i-- {
+		likes = append(likes, dao.UserLike{ID: int64(i), Uid: 1001, Biz: "article", BizID: int64(i),
+			Status: dao.Liked, UTime: now - int64(n-i)})
+	}
+	return likes
+}
+
+func TestInteractionRepository_GetUserLiked(t *testing.T) {
+	testCases := []struct {
+		name  string
+		likes []dao.UserLike
+		ids   []int64
+
+		wantLiked   map[int64]bool
+		wantQueried []int64
+	}{
+		{
+			name:        "缓存是完整的，不在缓存中就是没有点赞",
+			likes:       newFakeLikes(3),
+			ids:         []int64{1, 3, 5},
+			wantLiked:   map[int64]bool{1: true, 3: true},
+			wantQueried: nil,
+		},
+		{
+			name:      "点赞超过上限，不在缓存中的查询数据库",
+			likes:     newFakeLikes(cache.MaxRecentLikes + 2),
+			ids:       []int64{1, 2, 3, cache.MaxRecentLikes + 2, cache.MaxRecentLikes + 5},
+			wantLiked: map[int64]bool{1: true, 2: true, 3: true, cache.MaxRecentLikes + 2: true},
+			// 最近的点赞中没有1、2
+			wantQueried: []int64{1, 2, cache.MaxRecentLikes + 5},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := miniredis.RunT(t)
+			likeCache := cache.NewLikeCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+			d := &fakeLikeDao{likes: tc.likes}
+			repo := NewInteractionRepository(d, nil, nil, nil, likeCache)
+			ctx := context.Background()
+
+			// 第一次从数据库重建缓存，第二次使用缓存，结果相同
+			for i := 0; i < 2; i++ {
+				d.queried = nil
+				liked, err := repo.GetUserLiked(ctx, 1001, "article", tc.ids)
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantLiked, liked)
+				assert.Equal(t, tc.wantQueried, d.queried)
+			}
+		})
+	}
+}
+
+func TestInteractionRepository_loadLikes(t *testing.T) {
+	mr := miniredis.RunT(t)
+	likeCache := cache.NewLikeCache(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+	d := &fakeLikeDao{likes: newFakeLikes(2)}
+	// 查询数据库之后、写入缓存之前用户点赞了3，缓存不存在，这次点赞只增加了版本号
+	d.onList = func() {
+		d.likes = append([]dao.UserLike{{Uid: 1001, Biz: "article", BizID: 3, UTime: time.Now().UnixMilli()}}, d.likes...)
+		require.NoError(t, likeCache.Add(ctx, domain.UserLike{Uid: 1001, Biz: "article", BizID: 3, UTime: time.Now()}))
+	}
+	repo := NewInteractionRepository(d, nil, nil, nil, likeCache)
+
+	liked, err := repo.GetUserLiked(ctx, 1001, "article", []int64{1, 3})
+	require.NoError(t, err)
+	// 查询结果是点赞之前的
+	assert.Equal(t, map[int64]bool{1: true}, liked)
+	// 旧的查询结果不会写入缓存，否则缓存是完整的，之后会认为没有点赞3
+	_, _, err = likeCache.Check(ctx, 1001, "article", nil)
+	assert.Equal(t, cache.ErrKeyNotExist, err)
+
+	d.onList = nil
+	liked, err = repo.GetUserLiked(ctx, 1001, "article", []int64{1, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[int64]bool{1: true, 3: true}, liked)
+	liked, complete, err := likeCache.Check(ctx, 1001, "article", []int64{3})
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, map[int64]bool{3: true}, liked)
+}
@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/repository/comment.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/repository/comment.go -package=repomocks -destination=interaction/repository/mocks/comment.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCommentRepository is a mock of CommentRepository interface.
+type MockCommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentRepositoryMockRecorder
+}
+
+// MockCommentRepositoryMockRecorder is the mock recorder for MockCommentRepository.
+type MockCommentRepositoryMockRecorder struct {
+	mock *MockCommentRepository
+}
+
+// NewMockCommentRepository creates a new mock instance.
+func NewMockCommentRepository(ctrl *gomock.Controller) *MockCommentRepository {
+	mock := &MockCommentRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentRepository) EXPECT() *MockCommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelLike mocks base method.
+func (m *MockCommentRepository) CancelLike(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLike", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelLike indicates an expected call of CancelLike.
+func (mr *MockCommentRepositoryMockRecorder) CancelLike(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockCommentRepository)(nil).CancelLike), ctx, uid, id)
+}
+
+// Create mocks base method.
+func (m *MockCommentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, c)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCommentRepositoryMockRecorder) Create(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCommentRepository)(nil).Create), ctx, c)
+}
+
+// Delete mocks base method.
+func (m *MockCommentRepository) Delete(ctx context.Context, c domain.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentRepositoryMockRecorder) Delete(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentRepository)(nil).Delete), ctx, c)
+}
+
+// GetByID mocks base method.
+func (m *MockCommentRepository) GetByID(ctx context.Context, id int64) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCommentRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCommentRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPinned mocks base method.
+func (m *MockCommentRepository) GetPinned(ctx context.Context, uid int64, biz string, bizID int64) (domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPinned", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPinned indicates an expected call of GetPinned.
+func (mr *MockCommentRepositoryMockRecorder) GetPinned(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPinned", reflect.TypeOf((*MockCommentRepository)(nil).GetPinned), ctx, uid, biz, bizID)
+}
+
+// Like mocks base method.
+func (m *MockCommentRepository) Like(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Like", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Like indicates an expected call of Like.
+func (mr *MockCommentRepositoryMockRecorder) Like(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockCommentRepository)(nil).Like), ctx, uid, id)
+}
+
+// ListPopularRoots mocks base method.
+func (m *MockCommentRepository) ListPopularRoots(ctx context.Context, uid int64, biz string, bizID int64, offset, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPopularRoots", ctx, uid, biz, bizID, offset, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPopularRoots indicates an expected call of ListPopularRoots.
+func (mr *MockCommentRepositoryMockRecorder) ListPopularRoots(ctx, uid, biz, bizID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPopularRoots", reflect.TypeOf((*MockCommentRepository)(nil).ListPopularRoots), ctx, uid, biz, bizID, offset, limit)
+}
+
+// ListReplies mocks base method.
+func (m *MockCommentRepository) ListReplies(ctx context.Context, uid, rootID, minID int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReplies", ctx, uid, rootID, minID, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReplies indicates an expected call of ListReplies.
+func (mr *MockCommentRepositoryMockRecorder) ListReplies(ctx, uid, rootID, minID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReplies", reflect.TypeOf((*MockCommentRepository)(nil).ListReplies), ctx, uid, rootID, minID, limit)
+}
+
+// ListRoots mocks base method.
+func (m *MockCommentRepository) ListRoots(ctx context.Context, uid int64, biz string, bizID, maxID int64, limit int) ([]domain.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRoots", ctx, uid, biz, bizID, maxID, limit)
+	ret0, _ := ret[0].([]domain.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRoots indicates an expected call of ListRoots.
+func (mr *MockCommentRepositoryMockRecorder) ListRoots(ctx, uid, biz, bizID, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRoots", reflect.TypeOf((*MockCommentRepository)(nil).ListRoots), ctx, uid, biz, bizID, maxID, limit)
+}
+
+// Pin mocks base method.
+func (m *MockCommentRepository) Pin(ctx context.Context, biz string, bizID, id int64, pinned bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pin", ctx, biz, bizID, id, pinned)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Pin indicates an expected call of Pin.
+func (mr *MockCommentRepositoryMockRecorder) Pin(ctx, biz, bizID, id, pinned any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pin", reflect.TypeOf((*MockCommentRepository)(nil).Pin), ctx, biz, bizID, id, pinned)
+}
+
+// UpdateContent mocks base method.
+func (m *MockCommentRepository) UpdateContent(ctx context.Context, id, uid int64, content string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContent", ctx, id, uid, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContent indicates an expected call of UpdateContent.
+func (mr *MockCommentRepositoryMockRecorder) UpdateContent(ctx, id, uid, content any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContent", reflect.TypeOf((*MockCommentRepository)(nil).UpdateContent), ctx, id, uid, content)
+}
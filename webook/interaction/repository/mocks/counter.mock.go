@@ -0,0 +1,173 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/repository/counter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/repository/counter.go -package=repomocks -destination=interaction/repository/mocks/counter.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCounterRepository is a mock of CounterRepository interface.
+type MockCounterRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterRepositoryMockRecorder
+}
+
+// MockCounterRepositoryMockRecorder is the mock recorder for MockCounterRepository.
+type MockCounterRepositoryMockRecorder struct {
+	mock *MockCounterRepository
+}
+
+// NewMockCounterRepository creates a new mock instance.
+func NewMockCounterRepository(ctrl *gomock.Controller) *MockCounterRepository {
+	mock := &MockCounterRepository{ctrl: ctrl}
+	mock.recorder = &MockCounterRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterRepository) EXPECT() *MockCounterRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ApplyBatch mocks base method.
+func (m *MockCounterRepository) ApplyBatch(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyBatch", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyBatch indicates an expected call of ApplyBatch.
+func (mr *MockCounterRepositoryMockRecorder) ApplyBatch(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBatch", reflect.TypeOf((*MockCounterRepository)(nil).ApplyBatch), ctx, id)
+}
+
+// CountActual mocks base method.
+func (m *MockCounterRepository) CountActual(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActual", ctx, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActual indicates an expected call of CountActual.
+func (mr *MockCounterRepositoryMockRecorder) CountActual(ctx, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActual", reflect.TypeOf((*MockCounterRepository)(nil).CountActual), ctx, biz, bizIDs)
+}
+
+// DelCached mocks base method.
+func (m *MockCounterRepository) DelCached(ctx context.Context, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelCached", ctx, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelCached indicates an expected call of DelCached.
+func (mr *MockCounterRepositoryMockRecorder) DelCached(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelCached", reflect.TypeOf((*MockCounterRepository)(nil).DelCached), ctx, biz, bizID)
+}
+
+// DeleteFlushLogs mocks base method.
+func (m *MockCounterRepository) DeleteFlushLogs(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlushLogs", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlushLogs indicates an expected call of DeleteFlushLogs.
+func (mr *MockCounterRepositoryMockRecorder) DeleteFlushLogs(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlushLogs", reflect.TypeOf((*MockCounterRepository)(nil).DeleteFlushLogs), ctx, before)
+}
+
+// GetCached mocks base method.
+func (m *MockCounterRepository) GetCached(ctx context.Context, biz string, bizID int64) (domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCached", ctx, biz, bizID)
+	ret0, _ := ret[0].(domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCached indicates an expected call of GetCached.
+func (mr *MockCounterRepositoryMockRecorder) GetCached(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCached", reflect.TypeOf((*MockCounterRepository)(nil).GetCached), ctx, biz, bizID)
+}
+
+// ListUpdated mocks base method.
+func (m *MockCounterRepository) ListUpdated(ctx context.Context, since time.Time, minID int64, limit int) ([]domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUpdated", ctx, since, minID, limit)
+	ret0, _ := ret[0].([]domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUpdated indicates an expected call of ListUpdated.
+func (mr *MockCounterRepositoryMockRecorder) ListUpdated(ctx, since, minID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUpdated", reflect.TypeOf((*MockCounterRepository)(nil).ListUpdated), ctx, since, minID, limit)
+}
+
+// Pending mocks base method.
+func (m *MockCounterRepository) Pending(ctx context.Context, biz string, bizID int64) (domain.CounterDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, biz, bizID)
+	ret0, _ := ret[0].(domain.CounterDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockCounterRepositoryMockRecorder) Pending(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockCounterRepository)(nil).Pending), ctx, biz, bizID)
+}
+
+// Repair mocks base method.
+func (m *MockCounterRepository) Repair(ctx context.Context, inter domain.Interaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Repair", ctx, inter)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Repair indicates an expected call of Repair.
+func (mr *MockCounterRepositoryMockRecorder) Repair(ctx, inter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockCounterRepository)(nil).Repair), ctx, inter)
+}
+
+// TakeBatches mocks base method.
+func (m *MockCounterRepository) TakeBatches(ctx context.Context) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeBatches", ctx)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeBatches indicates an expected call of TakeBatches.
+func (mr *MockCounterRepositoryMockRecorder) TakeBatches(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeBatches", reflect.TypeOf((*MockCounterRepository)(nil).TakeBatches), ctx)
+}
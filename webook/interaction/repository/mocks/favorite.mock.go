@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/repository/favorite.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/repository/favorite.go -package=repomocks -destination=interaction/repository/mocks/favorite.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFavoriteRepository is a mock of FavoriteRepository interface.
+type MockFavoriteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteRepositoryMockRecorder
+}
+
+// MockFavoriteRepositoryMockRecorder is the mock recorder for MockFavoriteRepository.
+type MockFavoriteRepositoryMockRecorder struct {
+	mock *MockFavoriteRepository
+}
+
+// NewMockFavoriteRepository creates a new mock instance.
+func NewMockFavoriteRepository(ctrl *gomock.Controller) *MockFavoriteRepository {
+	mock := &MockFavoriteRepository{ctrl: ctrl}
+	mock.recorder = &MockFavoriteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteRepository) EXPECT() *MockFavoriteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountFolders mocks base method.
+func (m *MockFavoriteRepository) CountFolders(ctx context.Context, uid int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFolders", ctx, uid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFolders indicates an expected call of CountFolders.
+func (mr *MockFavoriteRepositoryMockRecorder) CountFolders(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFolders", reflect.TypeOf((*MockFavoriteRepository)(nil).CountFolders), ctx, uid)
+}
+
+// CreateFolder mocks base method.
+func (m *MockFavoriteRepository) CreateFolder(ctx context.Context, f domain.FavoriteFolder) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, f)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockFavoriteRepositoryMockRecorder) CreateFolder(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockFavoriteRepository)(nil).CreateFolder), ctx, f)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockFavoriteRepository) DeleteFolder(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockFavoriteRepositoryMockRecorder) DeleteFolder(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockFavoriteRepository)(nil).DeleteFolder), ctx, uid, id)
+}
+
+// GetFolder mocks base method.
+func (m *MockFavoriteRepository) GetFolder(ctx context.Context, id int64) (domain.FavoriteFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolder", ctx, id)
+	ret0, _ := ret[0].(domain.FavoriteFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFolder indicates an expected call of GetFolder.
+func (mr *MockFavoriteRepositoryMockRecorder) GetFolder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolder", reflect.TypeOf((*MockFavoriteRepository)(nil).GetFolder), ctx, id)
+}
+
+// ListFolders mocks base method.
+func (m *MockFavoriteRepository) ListFolders(ctx context.Context, uid int64, includePrivate bool) ([]domain.FavoriteFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", ctx, uid, includePrivate)
+	ret0, _ := ret[0].([]domain.FavoriteFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockFavoriteRepositoryMockRecorder) ListFolders(ctx, uid, includePrivate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockFavoriteRepository)(nil).ListFolders), ctx, uid, includePrivate)
+}
+
+// ListItems mocks base method.
+func (m *MockFavoriteRepository) ListItems(ctx context.Context, folderID, maxID int64, limit int) ([]domain.UserFavorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, folderID, maxID, limit)
+	ret0, _ := ret[0].([]domain.UserFavorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockFavoriteRepositoryMockRecorder) ListItems(ctx, folderID, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockFavoriteRepository)(nil).ListItems), ctx, folderID, maxID, limit)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockFavoriteRepository) UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFolder", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockFavoriteRepositoryMockRecorder) UpdateFolder(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockFavoriteRepository)(nil).UpdateFolder), ctx, f)
+}
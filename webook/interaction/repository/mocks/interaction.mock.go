@@ -0,0 +1,274 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/repository/interaction.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/repository/interaction.go -package=repomocks -destination=interaction/repository/mocks/interaction.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractionRepository is a mock of InteractionRepository interface.
+type MockInteractionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractionRepositoryMockRecorder
+}
+
+// MockInteractionRepositoryMockRecorder is the mock recorder for MockInteractionRepository.
+type MockInteractionRepositoryMockRecorder struct {
+	mock *MockInteractionRepository
+}
+
+// NewMockInteractionRepository creates a new mock instance.
+func NewMockInteractionRepository(ctrl *gomock.Controller) *MockInteractionRepository {
+	mock := &MockInteractionRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractionRepository) EXPECT() *MockInteractionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFavoriteItem mocks base method.
+func (m *MockInteractionRepository) AddFavoriteItem(ctx context.Context, uid, favoriteID int64, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFavoriteItem", ctx, uid, favoriteID, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFavoriteItem indicates an expected call of AddFavoriteItem.
+func (mr *MockInteractionRepositoryMockRecorder) AddFavoriteItem(ctx, uid, favoriteID, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFavoriteItem", reflect.TypeOf((*MockInteractionRepository)(nil).AddFavoriteItem), ctx, uid, favoriteID, biz, bizID)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractionRepository) BatchIncrReadCnt(ctx context.Context, views []domain.View) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractionRepositoryMockRecorder) BatchIncrReadCnt(ctx, views any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractionRepository)(nil).BatchIncrReadCnt), ctx, views)
+}
+
+// BatchIncrUniqueViewCnt mocks base method.
+func (m *MockInteractionRepository) BatchIncrUniqueViewCnt(ctx context.Context, views []domain.View) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrUniqueViewCnt", ctx, views)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrUniqueViewCnt indicates an expected call of BatchIncrUniqueViewCnt.
+func (mr *MockInteractionRepositoryMockRecorder) BatchIncrUniqueViewCnt(ctx, views any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrUniqueViewCnt", reflect.TypeOf((*MockInteractionRepository)(nil).BatchIncrUniqueViewCnt), ctx, views)
+}
+
+// DecrLike mocks base method.
+func (m *MockInteractionRepository) DecrLike(ctx context.Context, uid int64, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrLike", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrLike indicates an expected call of DecrLike.
+func (mr *MockInteractionRepositoryMockRecorder) DecrLike(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrLike", reflect.TypeOf((*MockInteractionRepository)(nil).DecrLike), ctx, uid, biz, bizID)
+}
+
+// DedupViews mocks base method.
+func (m *MockInteractionRepository) DedupViews(ctx context.Context, views []domain.View) ([]domain.ViewResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DedupViews", ctx, views)
+	ret0, _ := ret[0].([]domain.ViewResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DedupViews indicates an expected call of DedupViews.
+func (mr *MockInteractionRepositoryMockRecorder) DedupViews(ctx, views any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedupViews", reflect.TypeOf((*MockInteractionRepository)(nil).DedupViews), ctx, views)
+}
+
+// Delete mocks base method.
+func (m *MockInteractionRepository) Delete(ctx context.Context, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractionRepositoryMockRecorder) Delete(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractionRepository)(nil).Delete), ctx, biz, bizID)
+}
+
+// DeleteFavoriteItem mocks base method.
+func (m *MockInteractionRepository) DeleteFavoriteItem(ctx context.Context, uid int64, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFavoriteItem", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFavoriteItem indicates an expected call of DeleteFavoriteItem.
+func (mr *MockInteractionRepositoryMockRecorder) DeleteFavoriteItem(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFavoriteItem", reflect.TypeOf((*MockInteractionRepository)(nil).DeleteFavoriteItem), ctx, uid, biz, bizID)
+}
+
+// Get mocks base method.
+func (m *MockInteractionRepository) Get(ctx context.Context, uid int64, biz string, bizID int64) (domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockInteractionRepositoryMockRecorder) Get(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInteractionRepository)(nil).Get), ctx, uid, biz, bizID)
+}
+
+// GetByIDs mocks base method.
+func (m *MockInteractionRepository) GetByIDs(ctx context.Context, biz string, ds []int64) ([]domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ctx, biz, ds)
+	ret0, _ := ret[0].([]domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockInteractionRepositoryMockRecorder) GetByIDs(ctx, biz, ds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockInteractionRepository)(nil).GetByIDs), ctx, biz, ds)
+}
+
+// GetUserCollected mocks base method.
+func (m *MockInteractionRepository) GetUserCollected(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCollected", ctx, uid, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCollected indicates an expected call of GetUserCollected.
+func (mr *MockInteractionRepositoryMockRecorder) GetUserCollected(ctx, uid, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCollected", reflect.TypeOf((*MockInteractionRepository)(nil).GetUserCollected), ctx, uid, biz, bizIDs)
+}
+
+// GetUserFavoriteInfo mocks base method.
+func (m *MockInteractionRepository) GetUserFavoriteInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserFavorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFavoriteInfo", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(domain.UserFavorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFavoriteInfo indicates an expected call of GetUserFavoriteInfo.
+func (mr *MockInteractionRepositoryMockRecorder) GetUserFavoriteInfo(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFavoriteInfo", reflect.TypeOf((*MockInteractionRepository)(nil).GetUserFavoriteInfo), ctx, uid, biz, bizID)
+}
+
+// GetUserLikeInfo mocks base method.
+func (m *MockInteractionRepository) GetUserLikeInfo(ctx context.Context, uid int64, biz string, bizID int64) (domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLikeInfo", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLikeInfo indicates an expected call of GetUserLikeInfo.
+func (mr *MockInteractionRepositoryMockRecorder) GetUserLikeInfo(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLikeInfo", reflect.TypeOf((*MockInteractionRepository)(nil).GetUserLikeInfo), ctx, uid, biz, bizID)
+}
+
+// GetUserLiked mocks base method.
+func (m *MockInteractionRepository) GetUserLiked(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLiked", ctx, uid, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLiked indicates an expected call of GetUserLiked.
+func (mr *MockInteractionRepositoryMockRecorder) GetUserLiked(ctx, uid, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLiked", reflect.TypeOf((*MockInteractionRepository)(nil).GetUserLiked), ctx, uid, biz, bizIDs)
+}
+
+// IncrLike mocks base method.
+func (m *MockInteractionRepository) IncrLike(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLike", ctx, uid, biz, bizID, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLike indicates an expected call of IncrLike.
+func (mr *MockInteractionRepositoryMockRecorder) IncrLike(ctx, uid, biz, bizID, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLike", reflect.TypeOf((*MockInteractionRepository)(nil).IncrLike), ctx, uid, biz, bizID, reaction)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractionRepository) IncrReadCnt(ctx context.Context, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractionRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractionRepository)(nil).IncrReadCnt), ctx, biz, bizID)
+}
+
+// ListUserLikes mocks base method.
+func (m *MockInteractionRepository) ListUserLikes(ctx context.Context, uid int64, biz string, beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLikes", ctx, uid, biz, beforeTime, beforeID, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLikes indicates an expected call of ListUserLikes.
+func (mr *MockInteractionRepositoryMockRecorder) ListUserLikes(ctx, uid, biz, beforeTime, beforeID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLikes", reflect.TypeOf((*MockInteractionRepository)(nil).ListUserLikes), ctx, uid, biz, beforeTime, beforeID, limit)
+}
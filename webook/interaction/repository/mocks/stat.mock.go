@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/repository/stat.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/repository/stat.go -package=repomocks -destination=interaction/repository/mocks/stat.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatRepository is a mock of StatRepository interface.
+type MockStatRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatRepositoryMockRecorder
+}
+
+// MockStatRepositoryMockRecorder is the mock recorder for MockStatRepository.
+type MockStatRepositoryMockRecorder struct {
+	mock *MockStatRepository
+}
+
+// NewMockStatRepository creates a new mock instance.
+func NewMockStatRepository(ctrl *gomock.Controller) *MockStatRepository {
+	mock := &MockStatRepository{ctrl: ctrl}
+	mock.recorder = &MockStatRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatRepository) EXPECT() *MockStatRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteHourStats mocks base method.
+func (m *MockStatRepository) DeleteHourStats(ctx context.Context, before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHourStats", ctx, before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHourStats indicates an expected call of DeleteHourStats.
+func (mr *MockStatRepositoryMockRecorder) DeleteHourStats(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHourStats", reflect.TypeOf((*MockStatRepository)(nil).DeleteHourStats), ctx, before)
+}
+
+// List mocks base method.
+func (m *MockStatRepository) List(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity, from, to time.Time) ([]domain.InteractionStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, biz, bizIDs, granularity, from, to)
+	ret0, _ := ret[0].([]domain.InteractionStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockStatRepositoryMockRecorder) List(ctx, biz, bizIDs, granularity, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockStatRepository)(nil).List), ctx, biz, bizIDs, granularity, from, to)
+}
+
+// Rollup mocks base method.
+func (m *MockStatRepository) Rollup(ctx context.Context, day time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollup", ctx, day)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollup indicates an expected call of Rollup.
+func (mr *MockStatRepositoryMockRecorder) Rollup(ctx, day any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollup", reflect.TypeOf((*MockStatRepository)(nil).Rollup), ctx, day)
+}
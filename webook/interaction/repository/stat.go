@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository/dao"
+	"time"
+)
+
+//go:generate mockgen -source=./stat.go -package=repomocks -destination=./mocks/stat.mock.go StatRepository
+type StatRepository interface {
+	// List 查询资源在[from, to)之间的时间桶，只返回有数据的桶
+	List(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity,
+		from time.Time, to time.Time) ([]domain.InteractionStat, error)
+	// Rollup 把day这一天的小时桶汇总成天桶，day是这一天的开始时间
+	Rollup(ctx context.Context, day time.Time) error
+	// DeleteHourStats 删除早于before的小时桶
+	DeleteHourStats(ctx context.Context, before time.Time) error
+}
+
+type statRepository struct {
+	dao dao.StatDao
+}
+
+func NewStatRepository(dao dao.StatDao) StatRepository {
+	return &statRepository{
+		dao: dao,
+	}
+}
+
+func (repo *statRepository) List(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity,
+	from time.Time, to time.Time) ([]domain.InteractionStat, error) {
+	stats, err := repo.dao.List(ctx, biz, bizIDs, string(granularity), from.UnixMilli(), to.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(stats, func(idx int, src dao.InteractionStat) domain.InteractionStat {
+		return domain.InteractionStat{
+			Biz:         src.Biz,
+			BizID:       src.BizID,
+			Granularity: domain.StatGranularity(src.Granularity),
+			Bucket:      time.UnixMilli(src.Bucket),
+			Views:       src.ReadCnt,
+			Likes:       src.Likes,
+			Favorites:   src.Favorites,
+		}
+	}), nil
+}
+
+func (repo *statRepository) Rollup(ctx context.Context, day time.Time) error {
+	return repo.dao.Rollup(ctx, day.UnixMilli(), domain.StatDay.Next(day).UnixMilli())
+}
+
+func (repo *statRepository) DeleteHourStats(ctx context.Context, before time.Time) error {
+	return repo.dao.DeleteBefore(ctx, dao.StatGranularityHour, before.UnixMilli())
+}
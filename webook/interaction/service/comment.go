@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/interaction/domain"
+	repository "learn_go/webook/interaction/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrInvalidComment 内容为空、太长，或者回复的评论不属于同一个资源
+	ErrInvalidComment = errors.New("invalid comment")
+	// ErrNoPermission 修改、删除其他用户的评论
+	ErrNoPermission = errors.New("no permission")
+)
+
+// maxCommentPageSize 每页最多查询的评论数量
+const maxCommentPageSize = 100
+
+//go:generate mockgen -source=./comment.go -package=svcmocks -destination=./mocks/comment.mock.go CommentService
+type CommentService interface {
+	// Create 发表评论，c.ParentID大于0时回复该评论，返回完整的评论
+	Create(ctx context.Context, c domain.Comment) (domain.Comment, error)
+	// Update 修改评论的内容，只有评论的作者可以修改
+	Update(ctx context.Context, uid int64, id int64, content string) error
+	// Delete 只有评论的作者可以删除，删除根评论时同时删除它的回复
+	Delete(ctx context.Context, uid int64, id int64) error
+	// Pin 置顶或者取消置顶根评论，由调用方校验资源所有者的身份
+	Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error
+
+	// List 查询资源的根评论，cursor为0时查询第一页，第一页的第一条是置顶评论。
+	// 按时间排序时cursor是上一页最后一条评论的id，按热度排序时cursor是偏移量
+	List(ctx context.Context, uid int64, biz string, bizID int64, sort domain.CommentSort, cursor int64, limit int) ([]domain.Comment, error)
+	// ListReplies 按照时间顺序查询根评论的回复，minID是上一页最后一条回复的id
+	ListReplies(ctx context.Context, uid int64, rootID int64, minID int64, limit int) ([]domain.Comment, error)
+
+	Like(ctx context.Context, uid int64, id int64) error
+	CancelLike(ctx context.Context, uid int64, id int64) error
+}
+
+type commentService struct {
+	repo repository.CommentRepository
+}
+
+func NewCommentService(repo repository.CommentRepository) CommentService {
+	return &commentService{
+		repo: repo,
+	}
+}
+
+func (svc *commentService) Create(ctx context.Context, c domain.Comment) (domain.Comment, error) {
+	var ok bool
+	c.Content, ok = svc.validContent(c.Content)
+	if !ok || c.Uid <= 0 || c.Biz == "" || c.BizID <= 0 {
+		return domain.Comment{}, ErrInvalidComment
+	}
+	c.RootID, c.ReplyToUid = 0, 0
+	if c.ParentID > 0 {
+		parent, err := svc.get(ctx, c.ParentID)
+		if err != nil {
+			return domain.Comment{}, err
+		}
+		if parent.Biz != c.Biz || parent.BizID != c.BizID {
+			return domain.Comment{}, ErrInvalidComment
+		}
+		// 回复都挂在根评论下面
+		c.RootID = parent.RootID
+		if parent.IsRoot() {
+			c.RootID = parent.ID
+		}
+		c.ReplyToUid = parent.Uid
+	}
+	c.Likes, c.Replies, c.Pinned = 0, 0, false
+
+	id, err := svc.repo.Create(ctx, c)
+	if err == repository.ErrNotFound {
+		// 根评论在回复的同时被删除了
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return svc.get(ctx, id)
+}
+
+func (svc *commentService) Update(ctx context.Context, uid int64, id int64, content string) error {
+	content, ok := svc.validContent(content)
+	if !ok {
+		return ErrInvalidComment
+	}
+	c, err := svc.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrNoPermission
+	}
+	err = svc.repo.UpdateContent(ctx, id, uid, content)
+	if err == repository.ErrNotFound {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (svc *commentService) Delete(ctx context.Context, uid int64, id int64) error {
+	c, err := svc.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.Uid != uid {
+		return ErrNoPermission
+	}
+	return svc.repo.Delete(ctx, c)
+}
+
+func (svc *commentService) Pin(ctx context.Context, biz string, bizID int64, id int64, pinned bool) error {
+	err := svc.repo.Pin(ctx, biz, bizID, id, pinned)
+	if err == repository.ErrNotFound {
+		// 评论不存在、不是根评论，或者不属于该资源
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (svc *commentService) List(ctx context.Context, uid int64, biz string, bizID int64,
+	sort domain.CommentSort, cursor int64, limit int) ([]domain.Comment, error) {
+	limit = min(max(limit, 1), maxCommentPageSize)
+	var (
+		res []domain.Comment
+		err error
+	)
+	if sort == domain.CommentSortPopular {
+		res, err = svc.repo.ListPopularRoots(ctx, uid, biz, bizID, int(cursor), limit)
+	} else {
+		res, err = svc.repo.ListRoots(ctx, uid, biz, bizID, cursor, limit)
+	}
+	if err != nil || cursor > 0 {
+		return res, err
+	}
+	// 置顶评论不在分页的结果中，只在第一页返回
+	pinned, err := svc.repo.GetPinned(ctx, uid, biz, bizID)
+	switch err {
+	case nil:
+		return append([]domain.Comment{pinned}, res...), nil
+	case repository.ErrNotFound:
+		return res, nil
+	default:
+		return nil, err
+	}
+}
+
+func (svc *commentService) ListReplies(ctx context.Context, uid int64, rootID int64, minID int64, limit int) ([]domain.Comment, error) {
+	limit = min(max(limit, 1), maxCommentPageSize)
+	return svc.repo.ListReplies(ctx, uid, rootID, minID, limit)
+}
+
+func (svc *commentService) Like(ctx context.Context, uid int64, id int64) error {
+	if _, err := svc.get(ctx, id); err != nil {
+		return err
+	}
+	return svc.repo.Like(ctx, uid, id)
+}
+
+func (svc *commentService) CancelLike(ctx context.Context, uid int64, id int64) error {
+	return svc.repo.CancelLike(ctx, uid, id)
+}
+
+func (svc *commentService) get(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := svc.repo.GetByID(ctx, id)
+	if err == repository.ErrNotFound {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return c, err
+}
+
+// validContent 去掉首尾的空白，内容不能为空，也不能超过domain.MaxCommentLen
+func (svc *commentService) validContent(content string) (string, bool) {
+	content = strings.TrimSpace(content)
+	if content == "" || !utf8.ValidString(content) || utf8.RuneCountInString(content) > domain.MaxCommentLen {
+		return "", false
+	}
+	return content, true
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"strings"
+	"testing"
+)
+
+func Test_commentService_Create(t *testing.T) {
+	root := domain.Comment{ID: 1, Uid: 100, Biz: "article", BizID: 10, Content: "根评论"}
+	reply := domain.Comment{ID: 2, Uid: 200, Biz: "article", BizID: 10, RootID: 1, ParentID: 1, ReplyToUid: 100, Content: "回复"}
+
+	testCases := []struct {
+		name    string
+		comment domain.Comment
+
+		mock func(ctrl *gomock.Controller) repository.CommentRepository
+
+		wantErr     error
+		wantComment domain.Comment
+	}{
+		{
+			name:    "发表根评论",
+			comment: domain.Comment{Uid: 100, Biz: "article", BizID: 10, Content: "  根评论\n"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{Uid: 100, Biz: "article", BizID: 10, Content: "根评论"}).
+					Return(int64(1), nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(root, nil)
+				return repo
+			},
+			wantComment: root,
+		},
+		{
+			name:    "回复根评论",
+			comment: domain.Comment{Uid: 200, Biz: "article", BizID: 10, ParentID: 1, Content: "回复"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(root, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Uid: 200, Biz: "article", BizID: 10, RootID: 1, ParentID: 1, ReplyToUid: 100, Content: "回复",
+				}).Return(int64(2), nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(reply, nil)
+				return repo
+			},
+			wantComment: reply,
+		},
+		{
+			name:    "回复一条回复，挂在同一个根评论下",
+			comment: domain.Comment{Uid: 300, Biz: "article", BizID: 10, ParentID: 2, Content: "再回复"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(reply, nil)
+				repo.EXPECT().Create(gomock.Any(), domain.Comment{
+					Uid: 300, Biz: "article", BizID: 10, RootID: 1, ParentID: 2, ReplyToUid: 200, Content: "再回复",
+				}).Return(int64(3), nil)
+				repo.EXPECT().GetByID(gomock.Any(), int64(3)).Return(domain.Comment{ID: 3}, nil)
+				return repo
+			},
+			wantComment: domain.Comment{ID: 3},
+		},
+		{
+			name:    "回复其他资源的评论",
+			comment: domain.Comment{Uid: 200, Biz: "article", BizID: 11, ParentID: 1, Content: "回复"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(root, nil)
+				return repo
+			},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name:    "回复的评论不存在",
+			comment: domain.Comment{Uid: 200, Biz: "article", BizID: 10, ParentID: 9, Content: "回复"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(9)).Return(domain.Comment{}, repository.ErrNotFound)
+				return repo
+			},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name:    "内容为空",
+			comment: domain.Comment{Uid: 100, Biz: "article", BizID: 10, Content: " \t"},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				return repomocks.NewMockCommentRepository(ctrl)
+			},
+			wantErr: ErrInvalidComment,
+		},
+		{
+			name:    "内容太长",
+			comment: domain.Comment{Uid: 100, Biz: "article", BizID: 10, Content: strings.Repeat("评", domain.MaxCommentLen+1)},
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				return repomocks.NewMockCommentRepository(ctrl)
+			},
+			wantErr: ErrInvalidComment,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewCommentService(tc.mock(ctrl))
+			c, err := svc.Create(context.Background(), tc.comment)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantComment, c)
+		})
+	}
+}
+
+func Test_commentService_List(t *testing.T) {
+	pinned := domain.Comment{ID: 1, Pinned: true}
+	roots := []domain.Comment{{ID: 3}, {ID: 2}}
+
+	testCases := []struct {
+		name   string
+		sort   domain.CommentSort
+		cursor int64
+
+		mock func(ctrl *gomock.Controller) repository.CommentRepository
+
+		want []domain.Comment
+	}{
+		{
+			name: "第一页返回置顶评论",
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().ListRoots(gomock.Any(), int64(100), "article", int64(10), int64(0), 20).Return(roots, nil)
+				repo.EXPECT().GetPinned(gomock.Any(), int64(100), "article", int64(10)).Return(pinned, nil)
+				return repo
+			},
+			want: []domain.Comment{pinned, {ID: 3}, {ID: 2}},
+		},
+		{
+			name: "没有置顶评论",
+			sort: domain.CommentSortPopular,
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().ListPopularRoots(gomock.Any(), int64(100), "article", int64(10), 0, 20).Return(roots, nil)
+				repo.EXPECT().GetPinned(gomock.Any(), int64(100), "article", int64(10)).Return(domain.Comment{}, repository.ErrNotFound)
+				return repo
+			},
+			want: roots,
+		},
+		{
+			name:   "后面的页不返回置顶评论",
+			cursor: 3,
+			mock: func(ctrl *gomock.Controller) repository.CommentRepository {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().ListRoots(gomock.Any(), int64(100), "article", int64(10), int64(3), 20).
+					Return([]domain.Comment{{ID: 2}}, nil)
+				return repo
+			},
+			want: []domain.Comment{{ID: 2}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewCommentService(tc.mock(ctrl))
+			res, err := svc.List(context.Background(), 100, "article", 10, tc.sort, tc.cursor, 20)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"learn_go/webook/interaction/domain"
+	repository "learn_go/webook/interaction/repository"
+	"time"
+)
+
+const (
+	// reconcileBatchSize 对账时每批查询的交互数据
+	reconcileBatchSize = 100
+	// flushLogRetention 批次记录的保留时间，遗留的批次每次写入时都会重试，远小于这个时间
+	flushLogRetention = time.Hour * 24 * 7
+)
+
+//go:generate mockgen -source=./counter.go -package=svcmocks -destination=./mocks/counter.mock.go CounterService
+type CounterService interface {
+	// Flush 把写回缓冲区中的阅读数、点赞数批量写入数据库，包括之前崩溃遗留的批次
+	Flush(ctx context.Context) error
+	// Reconcile 对账since之后修改过的资源：根据点赞、收藏记录修复数据库中的计数，删除和数据库不一致的缓存
+	Reconcile(ctx context.Context, since time.Time) error
+}
+
+type counterService struct {
+	repo repository.CounterRepository
+}
+
+func NewCounterService(repo repository.CounterRepository) CounterService {
+	return &counterService{
+		repo: repo,
+	}
+}
+
+func (svc *counterService) Flush(ctx context.Context) error {
+	ids, err := svc.repo.TakeBatches(ctx)
+	if err != nil {
+		return err
+	}
+	// 一个批次失败不影响其他批次，失败的批次留在缓冲区中下次重试
+	var firstErr error
+	for _, id := range ids {
+		err = svc.repo.ApplyBatch(ctx, id)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (svc *counterService) Reconcile(ctx context.Context, since time.Time) error {
+	// 先写入缓冲区中的增量，剩下的差异才是需要修复的
+	err := svc.Flush(ctx)
+	if err != nil {
+		return err
+	}
+	var minID int64
+	for {
+		inters, err := svc.repo.ListUpdated(ctx, since, minID, reconcileBatchSize)
+		if err != nil {
+			return err
+		}
+		bizIDs := make(map[string][]int64)
+		for _, inter := range inters {
+			bizIDs[inter.Biz] = append(bizIDs[inter.Biz], inter.BizID)
+		}
+		actual := make(map[string]map[int64]domain.Interaction, len(bizIDs))
+		for biz, ids := range bizIDs {
+			actual[biz], err = svc.repo.CountActual(ctx, biz, ids)
+			if err != nil {
+				return err
+			}
+		}
+		for _, inter := range inters {
+			err = svc.reconcile(ctx, inter, actual[inter.Biz][inter.BizID])
+			if err != nil {
+				return err
+			}
+		}
+		if len(inters) < reconcileBatchSize {
+			break
+		}
+		minID = inters[len(inters)-1].ID
+	}
+	return svc.repo.DeleteFlushLogs(ctx, time.Now().Add(-flushLogRetention))
+}
+
+func (svc *counterService) reconcile(ctx context.Context, inter domain.Interaction, actual domain.Interaction) error {
+	pending, err := svc.repo.Pending(ctx, inter.Biz, inter.BizID)
+	if err != nil {
+		return err
+	}
+	// 计数还在写回中（包括其他实例正在写入的批次），数据库本来就落后，下一次对账再处理
+	if !pending.IsZero() {
+		return nil
+	}
+	if inter.Likes != actual.Likes || inter.Favorites != actual.Favorites ||
+		!svc.sameReactions(inter.Reactions, actual.Reactions) {
+		inter.Likes, inter.Favorites, inter.Reactions = actual.Likes, actual.Favorites, actual.Reactions
+		return svc.repo.Repair(ctx, inter)
+	}
+	cached, err := svc.repo.GetCached(ctx, inter.Biz, inter.BizID)
+	if err != nil {
+		// 缓存不存在
+		return nil
+	}
+	if cached.Views != inter.Views || cached.UniqueViews != inter.UniqueViews || cached.Likes != inter.Likes ||
+		cached.Favorites != inter.Favorites || cached.Comments != inter.Comments ||
+		!svc.sameReactions(cached.Reactions, inter.Reactions) {
+		// 删除缓存，下次查询时从数据库重新加载
+		return svc.repo.DelCached(ctx, inter.Biz, inter.BizID)
+	}
+	return nil
+}
+
+// sameReactions 没有的表态当作0
+func (svc *counterService) sameReactions(a, b map[domain.Reaction]int64) bool {
+	for _, r := range domain.Reactions {
+		if a[r] != b[r] {
+			return false
+		}
+	}
+	return true
+}
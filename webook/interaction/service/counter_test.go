@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository"
+	"learn_go/webook/interaction/repository/cache"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"testing"
+	"time"
+)
+
+func Test_counterService_Flush(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.CounterRepository
+
+		wantErr error
+	}{
+		{
+			name: "写入所有批次",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := repomocks.NewMockCounterRepository(ctrl)
+				repo.EXPECT().TakeBatches(gomock.Any()).Return([]string{"0:1", "3:1"}, nil)
+				repo.EXPECT().ApplyBatch(gomock.Any(), "0:1").Return(nil)
+				repo.EXPECT().ApplyBatch(gomock.Any(), "3:1").Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "一个批次失败，继续写入其他批次",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := repomocks.NewMockCounterRepository(ctrl)
+				repo.EXPECT().TakeBatches(gomock.Any()).Return([]string{"0:1", "3:1"}, nil)
+				repo.EXPECT().ApplyBatch(gomock.Any(), "0:1").Return(errors.New("db error"))
+				repo.EXPECT().ApplyBatch(gomock.Any(), "3:1").Return(nil)
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewCounterService(tc.mock(ctrl))
+			err := svc.Flush(context.Background())
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_counterService_Reconcile(t *testing.T) {
+	since := time.UnixMilli(1000)
+	inter := domain.Interaction{ID: 1, Biz: "article", BizID: 10, Views: 100, Likes: 5, Favorites: 2}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.CounterRepository
+	}{
+		{
+			name: "点赞数和点赞记录不一致，修复数据库",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := reconcileMock(ctrl, since, inter, domain.Interaction{Likes: 6, Favorites: 2})
+				repo.EXPECT().Pending(gomock.Any(), "article", int64(10)).Return(domain.CounterDelta{}, nil)
+				repaired := inter
+				repaired.Likes = 6
+				repo.EXPECT().Repair(gomock.Any(), repaired).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "切换表态后表态数量不一致，修复数据库",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				actual := domain.Interaction{Likes: 5, Favorites: 2,
+					Reactions: map[domain.Reaction]int64{domain.ReactionLike: 4, domain.ReactionLove: 1}}
+				repo := reconcileMock(ctrl, since, inter, actual)
+				repo.EXPECT().Pending(gomock.Any(), "article", int64(10)).Return(domain.CounterDelta{}, nil)
+				repaired := inter
+				repaired.Reactions = actual.Reactions
+				repo.EXPECT().Repair(gomock.Any(), repaired).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "还有增量没有写入数据库，跳过",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := reconcileMock(ctrl, since, inter, domain.Interaction{Likes: 6, Favorites: 2})
+				repo.EXPECT().Pending(gomock.Any(), "article", int64(10)).
+					Return(domain.CounterDelta{Likes: 1}, nil)
+				return repo
+			},
+		},
+		{
+			name: "缓存和数据库不一致，删除缓存",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := reconcileMock(ctrl, since, inter, domain.Interaction{Likes: 5, Favorites: 2})
+				repo.EXPECT().Pending(gomock.Any(), "article", int64(10)).Return(domain.CounterDelta{}, nil)
+				repo.EXPECT().GetCached(gomock.Any(), "article", int64(10)).
+					Return(domain.Interaction{Views: 90, Likes: 5, Favorites: 2}, nil)
+				repo.EXPECT().DelCached(gomock.Any(), "article", int64(10)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "没有缓存",
+			mock: func(ctrl *gomock.Controller) repository.CounterRepository {
+				repo := reconcileMock(ctrl, since, inter, domain.Interaction{Likes: 5, Favorites: 2})
+				repo.EXPECT().Pending(gomock.Any(), "article", int64(10)).Return(domain.CounterDelta{}, nil)
+				repo.EXPECT().GetCached(gomock.Any(), "article", int64(10)).
+					Return(domain.Interaction{}, cache.ErrKeyNotExist)
+				return repo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewCounterService(tc.mock(ctrl))
+			err := svc.Reconcile(context.Background(), since)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// reconcileMock 对账前先写入缓冲区，然后查询出一条交互数据和它的真实计数
+func reconcileMock(ctrl *gomock.Controller, since time.Time,
+	inter domain.Interaction, actual domain.Interaction) *repomocks.MockCounterRepository {
+	repo := repomocks.NewMockCounterRepository(ctrl)
+	repo.EXPECT().TakeBatches(gomock.Any()).Return(nil, nil)
+	repo.EXPECT().ListUpdated(gomock.Any(), since, int64(0), reconcileBatchSize).
+		Return([]domain.Interaction{inter}, nil)
+	repo.EXPECT().CountActual(gomock.Any(), "article", []int64{10}).
+		Return(map[int64]domain.Interaction{10: actual}, nil)
+	repo.EXPECT().DeleteFlushLogs(gomock.Any(), gomock.Any()).Return(nil)
+	return repo
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/interaction/domain"
+	repository "learn_go/webook/interaction/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrFolderNotFound 收藏夹不存在、不属于该用户，或者是其他用户的私密收藏夹
+	ErrFolderNotFound = errors.New("favorite folder not found")
+	// ErrInvalidFolder 收藏夹名称为空或者太长
+	ErrInvalidFolder = errors.New("invalid favorite folder")
+	// ErrTooManyFolders 收藏夹数量达到了domain.MaxFavoriteFolders
+	ErrTooManyFolders = errors.New("too many favorite folders")
+)
+
+// maxFavoritePageSize 每页最多查询的收藏数量
+const maxFavoritePageSize = 100
+
+//go:generate mockgen -source=./favorite.go -package=svcmocks -destination=./mocks/favorite.mock.go FavoriteService
+type FavoriteService interface {
+	// CreateFolder 创建收藏夹，返回完整的收藏夹
+	CreateFolder(ctx context.Context, f domain.FavoriteFolder) (domain.FavoriteFolder, error)
+	// UpdateFolder 修改收藏夹的名称和是否私密，只有所有者可以修改
+	UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error
+	// DeleteFolder 删除收藏夹和其中的收藏，只有所有者可以删除
+	DeleteFolder(ctx context.Context, uid int64, id int64) error
+	// ListFolders uid查看owner的收藏夹，两者不同时只返回公开的收藏夹
+	ListFolders(ctx context.Context, uid int64, owner int64) ([]domain.FavoriteFolder, error)
+	// ListItems 按照收藏时间倒序查询收藏夹中的收藏，maxID是上一页最后一条收藏的id
+	ListItems(ctx context.Context, uid int64, folderID int64, maxID int64, limit int) ([]domain.UserFavorite, error)
+}
+
+type favoriteService struct {
+	repo repository.FavoriteRepository
+}
+
+func NewFavoriteService(repo repository.FavoriteRepository) FavoriteService {
+	return &favoriteService{
+		repo: repo,
+	}
+}
+
+func (svc *favoriteService) CreateFolder(ctx context.Context, f domain.FavoriteFolder) (domain.FavoriteFolder, error) {
+	var ok bool
+	f.Name, ok = svc.validName(f.Name)
+	if !ok || f.Uid <= 0 {
+		return domain.FavoriteFolder{}, ErrInvalidFolder
+	}
+	// 并发创建时可能略微超过上限
+	cnt, err := svc.repo.CountFolders(ctx, f.Uid)
+	if err != nil {
+		return domain.FavoriteFolder{}, err
+	}
+	if cnt >= domain.MaxFavoriteFolders {
+		return domain.FavoriteFolder{}, ErrTooManyFolders
+	}
+	f.ID, err = svc.repo.CreateFolder(ctx, f)
+	if err != nil {
+		return domain.FavoriteFolder{}, err
+	}
+	return svc.repo.GetFolder(ctx, f.ID)
+}
+
+func (svc *favoriteService) UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error {
+	var ok bool
+	f.Name, ok = svc.validName(f.Name)
+	if !ok {
+		return ErrInvalidFolder
+	}
+	err := svc.repo.UpdateFolder(ctx, f)
+	if err == repository.ErrNotFound {
+		return ErrFolderNotFound
+	}
+	return err
+}
+
+func (svc *favoriteService) DeleteFolder(ctx context.Context, uid int64, id int64) error {
+	err := svc.repo.DeleteFolder(ctx, uid, id)
+	if err == repository.ErrNotFound {
+		return ErrFolderNotFound
+	}
+	return err
+}
+
+func (svc *favoriteService) ListFolders(ctx context.Context, uid int64, owner int64) ([]domain.FavoriteFolder, error) {
+	return svc.repo.ListFolders(ctx, owner, uid == owner)
+}
+
+func (svc *favoriteService) ListItems(ctx context.Context, uid int64, folderID int64, maxID int64, limit int) ([]domain.UserFavorite, error) {
+	f, err := svc.repo.GetFolder(ctx, folderID)
+	switch {
+	case err == repository.ErrNotFound:
+		return nil, ErrFolderNotFound
+	case err != nil:
+		return nil, err
+	case !f.VisibleTo(uid):
+		// 不暴露其他用户私密收藏夹的存在
+		return nil, ErrFolderNotFound
+	}
+	limit = min(max(limit, 1), maxFavoritePageSize)
+	return svc.repo.ListItems(ctx, folderID, maxID, limit)
+}
+
+// validName 去掉首尾空白之后不能为空，也不能超过domain.MaxFavoriteFolderNameLen
+func (svc *favoriteService) validName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	return name, name != "" && utf8.RuneCountInString(name) <= domain.MaxFavoriteFolderNameLen
+}
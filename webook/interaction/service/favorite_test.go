@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"strings"
+	"testing"
+)
+
+func Test_favoriteService_CreateFolder(t *testing.T) {
+	folder := domain.FavoriteFolder{ID: 1, Uid: 100, Name: "默认收藏夹"}
+
+	testCases := []struct {
+		name   string
+		folder domain.FavoriteFolder
+
+		mock func(ctrl *gomock.Controller) repository.FavoriteRepository
+
+		wantErr    error
+		wantFolder domain.FavoriteFolder
+	}{
+		{
+			name:   "创建成功",
+			folder: domain.FavoriteFolder{Uid: 100, Name: "  默认收藏夹 "},
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().CountFolders(gomock.Any(), int64(100)).Return(int64(0), nil)
+				repo.EXPECT().CreateFolder(gomock.Any(), domain.FavoriteFolder{Uid: 100, Name: "默认收藏夹"}).
+					Return(int64(1), nil)
+				repo.EXPECT().GetFolder(gomock.Any(), int64(1)).Return(folder, nil)
+				return repo
+			},
+			wantFolder: folder,
+		},
+		{
+			name:   "名称为空",
+			folder: domain.FavoriteFolder{Uid: 100, Name: " "},
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				return repomocks.NewMockFavoriteRepository(ctrl)
+			},
+			wantErr: ErrInvalidFolder,
+		},
+		{
+			name:   "名称太长",
+			folder: domain.FavoriteFolder{Uid: 100, Name: strings.Repeat("收", domain.MaxFavoriteFolderNameLen+1)},
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				return repomocks.NewMockFavoriteRepository(ctrl)
+			},
+			wantErr: ErrInvalidFolder,
+		},
+		{
+			name:   "收藏夹太多",
+			folder: domain.FavoriteFolder{Uid: 100, Name: "默认收藏夹"},
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().CountFolders(gomock.Any(), int64(100)).Return(int64(domain.MaxFavoriteFolders), nil)
+				return repo
+			},
+			wantErr: ErrTooManyFolders,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewFavoriteService(tc.mock(ctrl))
+			f, err := svc.CreateFolder(context.Background(), tc.folder)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantFolder, f)
+		})
+	}
+}
+
+func Test_favoriteService_ListItems(t *testing.T) {
+	items := []domain.UserFavorite{{ID: 2, Uid: 100, Biz: "article", BizID: 10, FavoriteID: 1}}
+
+	testCases := []struct {
+		name string
+		uid  int64
+
+		mock func(ctrl *gomock.Controller) repository.FavoriteRepository
+
+		wantErr   error
+		wantItems []domain.UserFavorite
+	}{
+		{
+			name: "所有者查看私密收藏夹",
+			uid:  100,
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().GetFolder(gomock.Any(), int64(1)).
+					Return(domain.FavoriteFolder{ID: 1, Uid: 100, Private: true}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(1), int64(0), 10).Return(items, nil)
+				return repo
+			},
+			wantItems: items,
+		},
+		{
+			name: "其他用户查看公开收藏夹",
+			uid:  200,
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().GetFolder(gomock.Any(), int64(1)).
+					Return(domain.FavoriteFolder{ID: 1, Uid: 100}, nil)
+				repo.EXPECT().ListItems(gomock.Any(), int64(1), int64(0), 10).Return(items, nil)
+				return repo
+			},
+			wantItems: items,
+		},
+		{
+			name: "其他用户查看私密收藏夹",
+			uid:  200,
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().GetFolder(gomock.Any(), int64(1)).
+					Return(domain.FavoriteFolder{ID: 1, Uid: 100, Private: true}, nil)
+				return repo
+			},
+			wantErr: ErrFolderNotFound,
+		},
+		{
+			name: "收藏夹不存在",
+			uid:  100,
+			mock: func(ctrl *gomock.Controller) repository.FavoriteRepository {
+				repo := repomocks.NewMockFavoriteRepository(ctrl)
+				repo.EXPECT().GetFolder(gomock.Any(), int64(1)).Return(domain.FavoriteFolder{}, repository.ErrNotFound)
+				return repo
+			},
+			wantErr: ErrFolderNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewFavoriteService(tc.mock(ctrl))
+			res, err := svc.ListItems(context.Background(), tc.uid, 1, 0, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, res)
+		})
+	}
+}
+
+func Test_interactionService_Collected(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) repository.InteractionRepository
+
+		wantErr       error
+		wantCollected bool
+	}{
+		{
+			name: "已收藏",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().GetUserFavoriteInfo(gomock.Any(), int64(100), "article", int64(10)).
+					Return(domain.UserFavorite{ID: 1, Uid: 100, Biz: "article", BizID: 10, FavoriteID: 1}, nil)
+				return repo
+			},
+			wantCollected: true,
+		},
+		{
+			name: "没有收藏",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().GetUserFavoriteInfo(gomock.Any(), int64(100), "article", int64(10)).
+					Return(domain.UserFavorite{}, repository.ErrNotFound)
+				return repo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractionService(tc.mock(ctrl))
+			collected, err := svc.Collected(context.Background(), 100, "article", 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCollected, collected)
+		})
+	}
+}
@@ -2,17 +2,40 @@ package service
 
 import (
 	"context"
+	"errors"
+	"golang.org/x/sync/errgroup"
 	"learn_go/webook/interaction/domain"
 	repository "learn_go/webook/interaction/repository"
+	"time"
+)
+
+var (
+	// ErrTooManyBizIDs 批量查询、查询趋势的资源超过了maxBatchCheckSize
+	ErrTooManyBizIDs = errors.New("too many biz ids")
+	// ErrInvalidReaction 不支持的表态
+	ErrInvalidReaction = errors.New("invalid reaction")
+)
+
+const (
+	// maxLikePageSize 每页最多查询的点赞数量
+	maxLikePageSize = 100
+	// maxBatchCheckSize 一次最多查询的资源数量，和列表页的最大分页大小相同
+	maxBatchCheckSize = 100
 )
 
 //go:generate mockgen -source=./interaction.go -package=svcmocks -destination=./mocks/interaction.mock.go InteractionService
 type InteractionService interface {
 	View(ctx context.Context, biz string, bizID int64) error
 
-	Like(ctx context.Context, uid int64, biz string, bizID int64) error
+	// Like 设置用户的表态，ReactionNone映射到默认的点赞，已经有其他表态时切换过去
+	Like(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error
+	// CancelLike 取消用户的表态
 	CancelLike(ctx context.Context, uid int64, biz string, bizID int64) error
+	// Favorite 收藏到用户自己的favoriteID收藏夹，收藏夹不存在时返回ErrFolderNotFound；
+	// 已经收藏在其他收藏夹时移动过去
 	Favorite(ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64) error
+	// CancelFavorite 取消收藏，没有收藏时不会返回错误
+	CancelFavorite(ctx context.Context, uid int64, biz string, bizID int64) error
 
 	// Get 查询bizID的交互数据，以及用户id（uid）对应的交互数据
 	Get(ctx context.Context, uid int64, biz string, bizID int64) (domain.Interaction, error)
@@ -22,7 +45,15 @@ type InteractionService interface {
 	// Collected 用户是否收藏
 	Collected(ctx context.Context, uid int64, biz string, bizID int64) (bool, error)
 
+	// ListLiked 按照点赞时间倒序查询用户点赞的资源，beforeTime、beforeID是上一页最后一条点赞的位置，beforeID为0表示第一页
+	ListLiked(ctx context.Context, uid int64, biz string, beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error)
+	// BatchCheck 批量查询用户是否点赞、收藏了bizIDs，返回的Interaction中只有Biz、BizID、Liked、Collected
+	BatchCheck(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]domain.Interaction, error)
+
 	GetByIDs(ctx context.Context, biz string, bizIDs []int64) (map[int64]domain.Interaction, error)
+
+	// Delete 资源被彻底删除时，删除资源的全部交互数据
+	Delete(ctx context.Context, biz string, bizID int64) error
 }
 
 func (svc *interactionService) Liked(ctx context.Context, uid int64, biz string, bizID int64) (bool, error) {
@@ -39,12 +70,67 @@ func (svc *interactionService) Liked(ctx context.Context, uid int64, biz string,
 }
 
 func (svc *interactionService) Collected(ctx context.Context, uid int64, biz string, bizID int64) (bool, error) {
-	// TODO: implement me
-	return false, nil
+	_, err := svc.repo.GetUserFavoriteInfo(ctx, uid, biz, bizID)
+	switch err {
+	case nil:
+		return true, nil
+	case repository.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (svc *interactionService) ListLiked(ctx context.Context, uid int64, biz string,
+	beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error) {
+	limit = min(max(limit, 1), maxLikePageSize)
+	return svc.repo.ListUserLikes(ctx, uid, biz, beforeTime, beforeID, limit)
+}
+
+func (svc *interactionService) BatchCheck(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]domain.Interaction, error) {
+	if len(bizIDs) > maxBatchCheckSize {
+		return nil, ErrTooManyBizIDs
+	}
+	res := make(map[int64]domain.Interaction, len(bizIDs))
+	if len(bizIDs) == 0 {
+		return res, nil
+	}
+	var liked, collected map[int64]bool
+	var eg errgroup.Group
+	eg.Go(func() error {
+		var err error
+		liked, err = svc.repo.GetUserLiked(ctx, uid, biz, bizIDs)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		collected, err = svc.repo.GetUserCollected(ctx, uid, biz, bizIDs)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	for _, id := range bizIDs {
+		res[id] = domain.Interaction{
+			Biz:       biz,
+			BizID:     id,
+			Liked:     liked[id],
+			Collected: collected[id],
+		}
+	}
+	return res, nil
 }
 
 func (svc *interactionService) Favorite(ctx context.Context, uid int64, favoriteID int64, biz string, bizID int64) error {
-	return svc.repo.AddFavoriteItem(ctx, uid, favoriteID, biz, bizID)
+	err := svc.repo.AddFavoriteItem(ctx, uid, favoriteID, biz, bizID)
+	if err == repository.ErrNotFound {
+		return ErrFolderNotFound
+	}
+	return err
+}
+
+func (svc *interactionService) CancelFavorite(ctx context.Context, uid int64, biz string, bizID int64) error {
+	return svc.repo.DeleteFavoriteItem(ctx, uid, biz, bizID)
 }
 
 type interactionService struct {
@@ -67,8 +153,14 @@ func (svc *interactionService) Get(ctx context.Context, uid int64, biz string, b
 	return svc.repo.Get(ctx, uid, biz, bizID)
 }
 
-func (svc *interactionService) Like(ctx context.Context, uid int64, biz string, bizID int64) error {
-	return svc.repo.IncrLike(ctx, uid, biz, bizID)
+func (svc *interactionService) Like(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error {
+	if reaction == domain.ReactionNone {
+		reaction = domain.DefaultReaction
+	}
+	if !reaction.Valid() {
+		return ErrInvalidReaction
+	}
+	return svc.repo.IncrLike(ctx, uid, biz, bizID, reaction)
 }
 
 func (svc *interactionService) CancelLike(ctx context.Context, uid int64, biz string, bizID int64) error {
@@ -85,3 +177,7 @@ func NewInteractionService(repo repository.InteractionRepository) InteractionSer
 		repo: repo,
 	}
 }
+
+func (svc *interactionService) Delete(ctx context.Context, biz string, bizID int64) error {
+	return svc.repo.Delete(ctx, biz, bizID)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"testing"
+)
+
+func Test_interactionService_BatchCheck(t *testing.T) {
+	testCases := []struct {
+		name   string
+		bizIDs []int64
+
+		mock func(ctrl *gomock.Controller) repository.InteractionRepository
+
+		wantErr    error
+		wantStates map[int64]domain.Interaction
+	}{
+		{
+			name:   "合并点赞和收藏",
+			bizIDs: []int64{1, 2, 3},
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().GetUserLiked(gomock.Any(), int64(100), "article", []int64{1, 2, 3}).
+					Return(map[int64]bool{1: true, 2: true}, nil)
+				repo.EXPECT().GetUserCollected(gomock.Any(), int64(100), "article", []int64{1, 2, 3}).
+					Return(map[int64]bool{2: true}, nil)
+				return repo
+			},
+			wantStates: map[int64]domain.Interaction{
+				1: {Biz: "article", BizID: 1, Liked: true},
+				2: {Biz: "article", BizID: 2, Liked: true, Collected: true},
+				3: {Biz: "article", BizID: 3},
+			},
+		},
+		{
+			name: "没有资源",
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				return repomocks.NewMockInteractionRepository(ctrl)
+			},
+			wantStates: map[int64]domain.Interaction{},
+		},
+		{
+			name:   "资源太多",
+			bizIDs: make([]int64, maxBatchCheckSize+1),
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				return repomocks.NewMockInteractionRepository(ctrl)
+			},
+			wantErr: ErrTooManyBizIDs,
+		},
+		{
+			name:   "查询收藏失败",
+			bizIDs: []int64{1},
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().GetUserLiked(gomock.Any(), int64(100), "article", []int64{1}).
+					Return(map[int64]bool{}, nil)
+				repo.EXPECT().GetUserCollected(gomock.Any(), int64(100), "article", []int64{1}).
+					Return(nil, errors.New("db error"))
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractionService(tc.mock(ctrl))
+			states, err := svc.BatchCheck(context.Background(), 100, "article", tc.bizIDs)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantStates, states)
+		})
+	}
+}
+
+func Test_interactionService_Like(t *testing.T) {
+	testCases := []struct {
+		name     string
+		reaction domain.Reaction
+
+		mock func(ctrl *gomock.Controller) repository.InteractionRepository
+
+		wantErr error
+	}{
+		{
+			name:     "没有表态，映射到点赞",
+			reaction: domain.ReactionNone,
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().IncrLike(gomock.Any(), int64(100), "article", int64(1), domain.ReactionLike).
+					Return(nil)
+				return repo
+			},
+		},
+		{
+			name:     "其他表态",
+			reaction: domain.ReactionInsightful,
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				repo := repomocks.NewMockInteractionRepository(ctrl)
+				repo.EXPECT().IncrLike(gomock.Any(), int64(100), "article", int64(1), domain.ReactionInsightful).
+					Return(nil)
+				return repo
+			},
+		},
+		{
+			name:     "不支持的表态",
+			reaction: domain.Reaction(100),
+			mock: func(ctrl *gomock.Controller) repository.InteractionRepository {
+				return repomocks.NewMockInteractionRepository(ctrl)
+			},
+			wantErr: ErrInvalidReaction,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewInteractionService(tc.mock(ctrl))
+			err := svc.Like(context.Background(), 100, "article", 1, tc.reaction)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/service/counter.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/service/counter.go -package=svcmocks -destination=interaction/service/mocks/counter.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCounterService is a mock of CounterService interface.
+type MockCounterService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterServiceMockRecorder
+}
+
+// MockCounterServiceMockRecorder is the mock recorder for MockCounterService.
+type MockCounterServiceMockRecorder struct {
+	mock *MockCounterService
+}
+
+// NewMockCounterService creates a new mock instance.
+func NewMockCounterService(ctrl *gomock.Controller) *MockCounterService {
+	mock := &MockCounterService{ctrl: ctrl}
+	mock.recorder = &MockCounterServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterService) EXPECT() *MockCounterServiceMockRecorder {
+	return m.recorder
+}
+
+// Flush mocks base method.
+func (m *MockCounterService) Flush(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Flush", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockCounterServiceMockRecorder) Flush(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockCounterService)(nil).Flush), ctx)
+}
+
+// Reconcile mocks base method.
+func (m *MockCounterService) Reconcile(ctx context.Context, since time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile", ctx, since)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockCounterServiceMockRecorder) Reconcile(ctx, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockCounterService)(nil).Reconcile), ctx, since)
+}
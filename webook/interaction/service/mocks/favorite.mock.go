@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/service/favorite.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/service/favorite.go -package=svcmocks -destination=interaction/service/mocks/favorite.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFavoriteService is a mock of FavoriteService interface.
+type MockFavoriteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFavoriteServiceMockRecorder
+}
+
+// MockFavoriteServiceMockRecorder is the mock recorder for MockFavoriteService.
+type MockFavoriteServiceMockRecorder struct {
+	mock *MockFavoriteService
+}
+
+// NewMockFavoriteService creates a new mock instance.
+func NewMockFavoriteService(ctrl *gomock.Controller) *MockFavoriteService {
+	mock := &MockFavoriteService{ctrl: ctrl}
+	mock.recorder = &MockFavoriteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFavoriteService) EXPECT() *MockFavoriteServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateFolder mocks base method.
+func (m *MockFavoriteService) CreateFolder(ctx context.Context, f domain.FavoriteFolder) (domain.FavoriteFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, f)
+	ret0, _ := ret[0].(domain.FavoriteFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockFavoriteServiceMockRecorder) CreateFolder(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockFavoriteService)(nil).CreateFolder), ctx, f)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockFavoriteService) DeleteFolder(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockFavoriteServiceMockRecorder) DeleteFolder(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockFavoriteService)(nil).DeleteFolder), ctx, uid, id)
+}
+
+// ListFolders mocks base method.
+func (m *MockFavoriteService) ListFolders(ctx context.Context, uid, owner int64) ([]domain.FavoriteFolder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", ctx, uid, owner)
+	ret0, _ := ret[0].([]domain.FavoriteFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockFavoriteServiceMockRecorder) ListFolders(ctx, uid, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockFavoriteService)(nil).ListFolders), ctx, uid, owner)
+}
+
+// ListItems mocks base method.
+func (m *MockFavoriteService) ListItems(ctx context.Context, uid, folderID, maxID int64, limit int) ([]domain.UserFavorite, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListItems", ctx, uid, folderID, maxID, limit)
+	ret0, _ := ret[0].([]domain.UserFavorite)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListItems indicates an expected call of ListItems.
+func (mr *MockFavoriteServiceMockRecorder) ListItems(ctx, uid, folderID, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListItems", reflect.TypeOf((*MockFavoriteService)(nil).ListItems), ctx, uid, folderID, maxID, limit)
+}
+
+// UpdateFolder mocks base method.
+func (m *MockFavoriteService) UpdateFolder(ctx context.Context, f domain.FavoriteFolder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFolder", ctx, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFolder indicates an expected call of UpdateFolder.
+func (mr *MockFavoriteServiceMockRecorder) UpdateFolder(ctx, f any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFolder", reflect.TypeOf((*MockFavoriteService)(nil).UpdateFolder), ctx, f)
+}
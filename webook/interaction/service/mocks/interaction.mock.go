@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./interaction.go
+// Source: interaction/service/interaction.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./interaction.go -package=svcmocks -destination=./mocks/interaction.mock.go InteractionService
+//	mockgen -source=interaction/service/interaction.go -package=svcmocks -destination=interaction/service/mocks/interaction.mock.go
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -13,6 +13,7 @@ import (
 	context "context"
 	domain "learn_go/webook/interaction/domain"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +41,35 @@ func (m *MockInteractionService) EXPECT() *MockInteractionServiceMockRecorder {
 	return m.recorder
 }
 
+// BatchCheck mocks base method.
+func (m *MockInteractionService) BatchCheck(ctx context.Context, uid int64, biz string, bizIDs []int64) (map[int64]domain.Interaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCheck", ctx, uid, biz, bizIDs)
+	ret0, _ := ret[0].(map[int64]domain.Interaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCheck indicates an expected call of BatchCheck.
+func (mr *MockInteractionServiceMockRecorder) BatchCheck(ctx, uid, biz, bizIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCheck", reflect.TypeOf((*MockInteractionService)(nil).BatchCheck), ctx, uid, biz, bizIDs)
+}
+
+// CancelFavorite mocks base method.
+func (m *MockInteractionService) CancelFavorite(ctx context.Context, uid int64, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelFavorite", ctx, uid, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelFavorite indicates an expected call of CancelFavorite.
+func (mr *MockInteractionServiceMockRecorder) CancelFavorite(ctx, uid, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelFavorite", reflect.TypeOf((*MockInteractionService)(nil).CancelFavorite), ctx, uid, biz, bizID)
+}
+
 // CancelLike mocks base method.
 func (m *MockInteractionService) CancelLike(ctx context.Context, uid int64, biz string, bizID int64) error {
 	m.ctrl.T.Helper()
@@ -69,6 +99,20 @@ func (mr *MockInteractionServiceMockRecorder) Collected(ctx, uid, biz, bizID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractionService)(nil).Collected), ctx, uid, biz, bizID)
 }
 
+// Delete mocks base method.
+func (m *MockInteractionService) Delete(ctx context.Context, biz string, bizID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, biz, bizID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInteractionServiceMockRecorder) Delete(ctx, biz, bizID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInteractionService)(nil).Delete), ctx, biz, bizID)
+}
+
 // Favorite mocks base method.
 func (m *MockInteractionService) Favorite(ctx context.Context, uid, favoriteID int64, biz string, bizID int64) error {
 	m.ctrl.T.Helper()
@@ -114,17 +158,17 @@ func (mr *MockInteractionServiceMockRecorder) GetByIDs(ctx, biz, bizIDs any) *go
 }
 
 // Like mocks base method.
-func (m *MockInteractionService) Like(ctx context.Context, uid int64, biz string, bizID int64) error {
+func (m *MockInteractionService) Like(ctx context.Context, uid int64, biz string, bizID int64, reaction domain.Reaction) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Like", ctx, uid, biz, bizID)
+	ret := m.ctrl.Call(m, "Like", ctx, uid, biz, bizID, reaction)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Like indicates an expected call of Like.
-func (mr *MockInteractionServiceMockRecorder) Like(ctx, uid, biz, bizID any) *gomock.Call {
+func (mr *MockInteractionServiceMockRecorder) Like(ctx, uid, biz, bizID, reaction any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractionService)(nil).Like), ctx, uid, biz, bizID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractionService)(nil).Like), ctx, uid, biz, bizID, reaction)
 }
 
 // Liked mocks base method.
@@ -142,6 +186,21 @@ func (mr *MockInteractionServiceMockRecorder) Liked(ctx, uid, biz, bizID any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractionService)(nil).Liked), ctx, uid, biz, bizID)
 }
 
+// ListLiked mocks base method.
+func (m *MockInteractionService) ListLiked(ctx context.Context, uid int64, biz string, beforeTime time.Time, beforeID int64, limit int) ([]domain.UserLike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiked", ctx, uid, biz, beforeTime, beforeID, limit)
+	ret0, _ := ret[0].([]domain.UserLike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLiked indicates an expected call of ListLiked.
+func (mr *MockInteractionServiceMockRecorder) ListLiked(ctx, uid, biz, beforeTime, beforeID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiked", reflect.TypeOf((*MockInteractionService)(nil).ListLiked), ctx, uid, biz, beforeTime, beforeID, limit)
+}
+
 // View mocks base method.
 func (m *MockInteractionService) View(ctx context.Context, biz string, bizID int64) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: interaction/service/stat.go
+//
+// Generated by this command:
+//
+//	mockgen -source=interaction/service/stat.go -package=svcmocks -destination=interaction/service/mocks/stat.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	domain "learn_go/webook/interaction/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStatService is a mock of StatService interface.
+type MockStatService struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatServiceMockRecorder
+}
+
+// MockStatServiceMockRecorder is the mock recorder for MockStatService.
+type MockStatServiceMockRecorder struct {
+	mock *MockStatService
+}
+
+// NewMockStatService creates a new mock instance.
+func NewMockStatService(ctrl *gomock.Controller) *MockStatService {
+	mock := &MockStatService{ctrl: ctrl}
+	mock.recorder = &MockStatServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatService) EXPECT() *MockStatServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTrend mocks base method.
+func (m *MockStatService) GetTrend(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity, from, to time.Time) ([]domain.TrendPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrend", ctx, biz, bizIDs, granularity, from, to)
+	ret0, _ := ret[0].([]domain.TrendPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrend indicates an expected call of GetTrend.
+func (mr *MockStatServiceMockRecorder) GetTrend(ctx, biz, bizIDs, granularity, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrend", reflect.TypeOf((*MockStatService)(nil).GetTrend), ctx, biz, bizIDs, granularity, from, to)
+}
+
+// Rollup mocks base method.
+func (m *MockStatService) Rollup(ctx context.Context, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollup", ctx, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollup indicates an expected call of Rollup.
+func (mr *MockStatServiceMockRecorder) Rollup(ctx, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollup", reflect.TypeOf((*MockStatService)(nil).Rollup), ctx, now)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/interaction/domain"
+	repository "learn_go/webook/interaction/repository"
+	"time"
+)
+
+// ErrInvalidTrend 粒度不支持、时间范围为空或者时间桶太多
+var ErrInvalidTrend = errors.New("invalid trend query")
+
+// rollupDays 每次汇总最近几天的小时桶。重复汇总同一天是安全的，多汇总几天可以容忍任务偶尔失败
+const rollupDays = 2
+
+//go:generate mockgen -source=./stat.go -package=svcmocks -destination=./mocks/stat.mock.go StatService
+type StatService interface {
+	// GetTrend 查询资源在[from, to)之间每个时间桶新增的计数，多个资源时对同一个时间桶求和，没有数据的时间桶为0。
+	// 一次最多查询maxBatchCheckSize个资源，超过时返回ErrTooManyBizIDs
+	GetTrend(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity,
+		from time.Time, to time.Time) ([]domain.TrendPoint, error)
+	// Rollup 把最近几天的小时桶汇总成天桶，然后删除超过domain.HourStatRetention的小时桶
+	Rollup(ctx context.Context, now time.Time) error
+}
+
+type statService struct {
+	repo repository.StatRepository
+}
+
+func NewStatService(repo repository.StatRepository) StatService {
+	return &statService{
+		repo: repo,
+	}
+}
+
+func (svc *statService) GetTrend(ctx context.Context, biz string, bizIDs []int64, granularity domain.StatGranularity,
+	from time.Time, to time.Time) ([]domain.TrendPoint, error) {
+	if !granularity.Valid() || len(bizIDs) == 0 || !from.Before(to) {
+		return nil, ErrInvalidTrend
+	}
+	if len(bizIDs) > maxBatchCheckSize {
+		return nil, ErrTooManyBizIDs
+	}
+	// 每个时间桶一个点，from所在的桶也包括在内
+	idx := make(map[int64]int)
+	var points []domain.TrendPoint
+	for bucket := granularity.Truncate(from); bucket.Before(to); bucket = granularity.Next(bucket) {
+		if len(points) >= domain.MaxTrendPoints {
+			return nil, ErrInvalidTrend
+		}
+		idx[bucket.UnixMilli()] = len(points)
+		points = append(points, domain.TrendPoint{Time: bucket})
+	}
+
+	var stats []domain.InteractionStat
+	var err error
+	if granularity == domain.StatHour {
+		stats, err = svc.repo.List(ctx, biz, bizIDs, domain.StatHour, points[0].Time, to)
+	} else {
+		stats, err = svc.listDays(ctx, biz, bizIDs, points[0].Time, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range stats {
+		i, ok := idx[s.Bucket.UnixMilli()]
+		if !ok {
+			continue
+		}
+		points[i].Views += s.Views
+		points[i].Likes += s.Likes
+		points[i].Favorites += s.Favorites
+	}
+	return points, nil
+}
+
+// listDays 查询天桶，还没有汇总的那几天（包括今天）从小时桶中统计
+func (svc *statService) listDays(ctx context.Context, biz string, bizIDs []int64,
+	from time.Time, to time.Time) ([]domain.InteractionStat, error) {
+	days, err := svc.repo.List(ctx, biz, bizIDs, domain.StatDay, from, to)
+	if err != nil {
+		return nil, err
+	}
+	hourFrom := time.Now().Add(-domain.HourStatRetention)
+	if from.After(hourFrom) {
+		hourFrom = from
+	}
+	if !hourFrom.Before(to) {
+		return days, nil
+	}
+	hours, err := svc.repo.List(ctx, biz, bizIDs, domain.StatHour, hourFrom, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type dayKey struct {
+		bizID int64
+		day   int64
+	}
+	rolled := make(map[dayKey]bool, len(days))
+	for _, d := range days {
+		rolled[dayKey{bizID: d.BizID, day: d.Bucket.UnixMilli()}] = true
+	}
+	for _, h := range hours {
+		day := domain.StatDay.Truncate(h.Bucket)
+		// 已经汇总过的天以天桶为准
+		if rolled[dayKey{bizID: h.BizID, day: day.UnixMilli()}] {
+			continue
+		}
+		h.Granularity = domain.StatDay
+		h.Bucket = day
+		days = append(days, h)
+	}
+	return days, nil
+}
+
+func (svc *statService) Rollup(ctx context.Context, now time.Time) error {
+	// 只汇总已经结束的天，今天的数据还在变化
+	today := domain.StatDay.Truncate(now)
+	for day := today.AddDate(0, 0, -rollupDays); day.Before(today); day = domain.StatDay.Next(day) {
+		err := svc.repo.Rollup(ctx, day)
+		if err != nil {
+			return err
+		}
+	}
+	return svc.repo.DeleteHourStats(ctx, today.Add(-domain.HourStatRetention))
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/interaction/domain"
+	"learn_go/webook/interaction/repository"
+	repomocks "learn_go/webook/interaction/repository/mocks"
+	"testing"
+	"time"
+)
+
+func Test_statService_GetTrend(t *testing.T) {
+	hour := time.Date(2024, 5, 1, 10, 0, 0, 0, time.Local)
+	today := domain.StatDay.Truncate(time.Now())
+
+	testCases := []struct {
+		name        string
+		bizIDs      []int64
+		granularity domain.StatGranularity
+		from        time.Time
+		to          time.Time
+
+		mock func(ctrl *gomock.Controller) repository.StatRepository
+
+		wantErr    error
+		wantPoints []domain.TrendPoint
+	}{
+		{
+			name:        "按小时查询多篇文章，同一个时间桶求和",
+			bizIDs:      []int64{1, 2},
+			granularity: domain.StatHour,
+			from:        hour.Add(time.Minute * 30),
+			to:          hour.Add(time.Hour * 3),
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				repo := repomocks.NewMockStatRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), "article", []int64{1, 2}, domain.StatHour, hour, hour.Add(time.Hour*3)).
+					Return([]domain.InteractionStat{
+						{BizID: 1, Bucket: hour, Views: 10, Likes: 1},
+						{BizID: 2, Bucket: hour, Views: 5, Favorites: 1},
+						{BizID: 1, Bucket: hour.Add(time.Hour * 2), Views: 3, Likes: -1},
+					}, nil)
+				return repo
+			},
+			wantPoints: []domain.TrendPoint{
+				{Time: hour, Views: 15, Likes: 1, Favorites: 1},
+				{Time: hour.Add(time.Hour)},
+				{Time: hour.Add(time.Hour * 2), Views: 3, Likes: -1},
+			},
+		},
+		{
+			name:        "按天查询，还没有汇总的天从小时桶中统计",
+			bizIDs:      []int64{1},
+			granularity: domain.StatDay,
+			from:        today.AddDate(0, 0, -1),
+			to:          today.AddDate(0, 0, 1),
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				repo := repomocks.NewMockStatRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), "article", []int64{1}, domain.StatDay,
+					today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)).
+					Return([]domain.InteractionStat{{BizID: 1, Bucket: today.AddDate(0, 0, -1), Views: 100}}, nil)
+				repo.EXPECT().List(gomock.Any(), "article", []int64{1}, domain.StatHour,
+					today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)).
+					Return([]domain.InteractionStat{
+						// 昨天已经汇总过，以天桶为准
+						{BizID: 1, Bucket: today.AddDate(0, 0, -1).Add(time.Hour), Views: 99},
+						{BizID: 1, Bucket: today, Views: 7},
+						{BizID: 1, Bucket: today.Add(time.Hour), Views: 3, Likes: 2},
+					}, nil)
+				return repo
+			},
+			wantPoints: []domain.TrendPoint{
+				{Time: today.AddDate(0, 0, -1), Views: 100},
+				{Time: today, Views: 10, Likes: 2},
+			},
+		},
+		{
+			name:        "不支持的粒度",
+			bizIDs:      []int64{1},
+			granularity: "week",
+			from:        hour,
+			to:          hour.Add(time.Hour),
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				return repomocks.NewMockStatRepository(ctrl)
+			},
+			wantErr: ErrInvalidTrend,
+		},
+		{
+			name:        "时间范围为空",
+			bizIDs:      []int64{1},
+			granularity: domain.StatHour,
+			from:        hour,
+			to:          hour,
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				return repomocks.NewMockStatRepository(ctrl)
+			},
+			wantErr: ErrInvalidTrend,
+		},
+		{
+			name:        "时间桶太多",
+			bizIDs:      []int64{1},
+			granularity: domain.StatHour,
+			from:        hour,
+			to:          hour.Add(time.Hour * (domain.MaxTrendPoints + 1)),
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				return repomocks.NewMockStatRepository(ctrl)
+			},
+			wantErr: ErrInvalidTrend,
+		},
+		{
+			name:        "资源太多",
+			bizIDs:      make([]int64, maxBatchCheckSize+1),
+			granularity: domain.StatHour,
+			from:        hour,
+			to:          hour.Add(time.Hour),
+			mock: func(ctrl *gomock.Controller) repository.StatRepository {
+				return repomocks.NewMockStatRepository(ctrl)
+			},
+			wantErr: ErrTooManyBizIDs,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewStatService(tc.mock(ctrl))
+			points, err := svc.GetTrend(context.Background(), "article", tc.bizIDs, tc.granularity, tc.from, tc.to)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantPoints, points)
+		})
+	}
+}
+
+func Test_statService_Rollup(t *testing.T) {
+	now := time.Date(2024, 5, 3, 1, 5, 0, 0, time.Local)
+	today := time.Date(2024, 5, 3, 0, 0, 0, 0, time.Local)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockStatRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().Rollup(gomock.Any(), today.AddDate(0, 0, -2)).Return(nil),
+		repo.EXPECT().Rollup(gomock.Any(), today.AddDate(0, 0, -1)).Return(nil),
+		repo.EXPECT().DeleteHourStats(gomock.Any(), today.Add(-domain.HourStatRetention)).Return(nil),
+	)
+
+	svc := NewStatService(repo)
+	err := svc.Rollup(context.Background(), now)
+	assert.NoError(t, err)
+}
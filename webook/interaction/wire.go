@@ -29,12 +29,43 @@ var interactionSvcSet = wire.NewSet(
 	repository.NewInteractionRepository,
 	dao.NewInteractionDao,
 	cache.NewInteractionCache,
+	cache.NewCounterCache,
+	cache.NewViewCache,
+	cache.NewLikeCache,
+
+	service.NewFavoriteService,
+	repository.NewFavoriteRepository,
+	dao.NewFavoriteDao,
+
+	service.NewStatService,
+	repository.NewStatRepository,
+	dao.NewStatDao,
+)
+
+var counterSet = wire.NewSet(
+	service.NewCounterService,
+	repository.NewCounterRepository,
+	dao.NewCounterDao,
+
+	ioc.InitCounterFlushJob,
+	ioc.InitCounterReconcileJob,
+	ioc.InitStatRollupJob,
+	ioc.InitCron,
+)
+
+var commentSvcSet = wire.NewSet(
+	service.NewCommentService,
+	repository.NewCommentRepository,
+	dao.NewCommentDao,
 )
 
 func InitApp() *App {
 	wire.Build(thirdPartySet,
 		interactionSvcSet,
 		grpc2.NewInteractionServiceServer,
+		commentSvcSet,
+		grpc2.NewCommentServiceServer,
+		counterSet,
 
 		article.NewBatchReadEventConsumer,
 		ioc.NewConsumers,
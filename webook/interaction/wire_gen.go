@@ -27,15 +27,36 @@ func InitApp() *App {
 	interactionDao := dao.NewInteractionDao(db)
 	cmdable := ioc.NewRedis(loggerV2)
 	interactionCache := cache.NewInteractionCache(cmdable)
-	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache)
+	counterCache := cache.NewCounterCache(cmdable)
+	viewCache := cache.NewViewCache(cmdable)
+	likeCache := cache.NewLikeCache(cmdable)
+	interactionRepository := repository.NewInteractionRepository(interactionDao, interactionCache, counterCache, viewCache, likeCache)
 	batchReadEventConsumer := article.NewBatchReadEventConsumer(client, interactionRepository, loggerV2)
 	v := ioc.NewConsumers(batchReadEventConsumer)
 	interactionService := service.NewInteractionService(interactionRepository)
-	interactionServiceServer := grpc.NewInteractionServiceServer(interactionService)
-	server := ioc.InitGRPCServer(interactionServiceServer)
+	favoriteDao := dao.NewFavoriteDao(db)
+	favoriteRepository := repository.NewFavoriteRepository(favoriteDao, interactionCache)
+	favoriteService := service.NewFavoriteService(favoriteRepository)
+	statDao := dao.NewStatDao(db)
+	statRepository := repository.NewStatRepository(statDao)
+	statService := service.NewStatService(statRepository)
+	interactionServiceServer := grpc.NewInteractionServiceServer(interactionService, favoriteService, statService)
+	commentDao := dao.NewCommentDao(db)
+	commentRepository := repository.NewCommentRepository(commentDao, interactionCache)
+	commentService := service.NewCommentService(commentRepository)
+	commentServiceServer := grpc.NewCommentServiceServer(commentService)
+	server := ioc.InitGRPCServer(interactionServiceServer, commentServiceServer)
+	counterDao := dao.NewCounterDao(db)
+	counterRepository := repository.NewCounterRepository(counterDao, interactionCache, counterCache)
+	counterService := service.NewCounterService(counterRepository)
+	counterFlushJob := ioc.InitCounterFlushJob(counterService)
+	counterReconcileJob := ioc.InitCounterReconcileJob(counterService)
+	statRollupJob := ioc.InitStatRollupJob(statService)
+	cron := ioc.InitCron(loggerV2, counterFlushJob, counterReconcileJob, statRollupJob)
 	app := &App{
 		consumers: v,
 		server:    server,
+		cron:      cron,
 	}
 	return app
 }
@@ -45,4 +66,8 @@ func InitApp() *App {
 // 第三方依赖
 var thirdPartySet = wire.NewSet(ioc.NewLogger, ioc.NewDB, ioc.NewRedis, ioc.NewSaramaConfig, ioc.NewConsumerClient)
 
-var interactionSvcSet = wire.NewSet(service.NewInteractionService, repository.NewInteractionRepository, dao.NewInteractionDao, cache.NewInteractionCache)
+var interactionSvcSet = wire.NewSet(service.NewInteractionService, repository.NewInteractionRepository, dao.NewInteractionDao, cache.NewInteractionCache, cache.NewCounterCache, cache.NewViewCache, cache.NewLikeCache, service.NewFavoriteService, repository.NewFavoriteRepository, dao.NewFavoriteDao, service.NewStatService, repository.NewStatRepository, dao.NewStatDao)
+
+var counterSet = wire.NewSet(service.NewCounterService, repository.NewCounterRepository, dao.NewCounterDao, ioc.InitCounterFlushJob, ioc.InitCounterReconcileJob, ioc.InitStatRollupJob, ioc.InitCron)
+
+var commentSvcSet = wire.NewSet(service.NewCommentService, repository.NewCommentRepository, dao.NewCommentDao)
@@ -1,21 +1,55 @@
 package domain
 
-import "time"
+import (
+	"slices"
+	"time"
+)
 
 type Article struct {
 	ID      int64
 	Title   string
 	Content string
 	Author  Author
-	Status  ArticleStatus
+	// EditorID 本次修改文章的用户，为0时表示作者本人。协作者修改文章时Author仍然是文章的所有者
+	EditorID int64
+	// Version 乐观锁的版本号，修改草稿时带上读取到的版本号，为0时不校验
+	Version int64
+	// FromStatus 状态转换前文章应该处于的状态，更新时文章已经不是这个状态则失败，为ArticleStatusUnknown时不校验
+	FromStatus ArticleStatus
+	// Contributors 展示在已发布文章上的贡献者：所有者以及接受了邀请的共同所有者、编辑
+	Contributors []Author
+	Status       ArticleStatus
+	// Tags 为nil时表示不修改文章的标签
+	Tags []Tag
+	// Series 已发布的文章在系列中的导航，文章不属于任何系列时为nil
+	Series *SeriesNav
+
+	// HTML、Abstract、TOC、Images 由Content渲染得到，只有已发布的文章才有
+	HTML     string
+	Abstract string
+	TOC      []TOCItem
+	Images   []string
+
+	// PublishAt 定时发布的时间，只有状态为ArticleStatusPending时才有意义
+	PublishAt time.Time
 
 	CTime time.Time
 	UTime time.Time
+	// DTime 移入回收站的时间，零值表示没有被删除
+	DTime time.Time
 }
 
+// ArticleTrashTTL 文章在回收站中保留的时间，过期后被彻底删除
+const ArticleTrashTTL = time.Hour * 24 * 30
+
+// MaxDashboardArticles 创作者看板最多统计的文章数量，超过时只统计最新的文章
+const MaxDashboardArticles = 1000
+
 type Author struct {
 	ID   int64
 	Name string
+	// Role 作为文章的贡献者时在文章中的角色
+	Role ArticleRole
 }
 
 type ArticleStatus int8
@@ -29,4 +63,28 @@ const (
 	ArticleStatusUnpublished
 	ArticleStatusPublished
 	ArticleStatusPrivate
+	// ArticleStatusPending 等待定时发布
+	ArticleStatusPending
+	// ArticleStatusInReview 已提交审核，等待审核人员处理
+	ArticleStatusInReview
+	// ArticleStatusRejected 审核被驳回，修改后可以重新提交
+	ArticleStatusRejected
 )
+
+// articleTransitions 所有者、编辑可以进行的状态流转，key是当前状态。
+// 审核中的文章只能由审核人员通过或者驳回，作者只能保存（撤回审核）或者撤回线上的文章。
+// 旧数据没有记录状态，按照未发表处理
+var articleTransitions = map[ArticleStatus][]ArticleStatus{
+	ArticleStatusUnknown:     {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+	ArticleStatusUnpublished: {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+	ArticleStatusPublished:   {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+	ArticleStatusPrivate:     {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+	ArticleStatusPending:     {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+	ArticleStatusInReview:    {ArticleStatusUnpublished, ArticleStatusPrivate},
+	ArticleStatusRejected:    {ArticleStatusUnpublished, ArticleStatusPublished, ArticleStatusPrivate, ArticleStatusPending, ArticleStatusInReview},
+}
+
+// CanTransitTo 所有者、编辑能否将文章从当前状态改为to
+func (a ArticleStatus) CanTransitTo(to ArticleStatus) bool {
+	return slices.Contains(articleTransitions[a], to)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// ArticleRevision 文章的历史版本。每次保存、发布都会产生一个不可变的版本。
+type ArticleRevision struct {
+	ID        int64
+	ArticleID int64
+	AuthorID  int64
+
+	Title   string
+	Content string
+	Status  ArticleStatus
+
+	CTime time.Time
+}
+
+// RevisionDiff 俩个版本之间的行级差异
+type RevisionDiff struct {
+	From ArticleRevision
+	To   ArticleRevision
+
+	Lines []DiffLine
+}
+
+type DiffOp int8
+
+const (
+	DiffOpEqual DiffOp = iota
+	DiffOpInsert
+	DiffOpDelete
+)
+
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// RollbackTarget 回滚的目标：制作库（草稿）或者线上库（已发布的文章）
+type RollbackTarget int8
+
+const (
+	RollbackTargetDraft RollbackTarget = iota
+	RollbackTargetPublished
+)
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+const (
+	// ArticleAutosaveDebounce 自动保存停止这么久之后才写入制作库
+	ArticleAutosaveDebounce = time.Second * 30
+	// ArticleAutosaveMaxDelay 一直在自动保存时，最多间隔这么久写入一次制作库
+	ArticleAutosaveMaxDelay = time.Minute * 5
+	// ArticleAutosaveTTL 自动保存的快照保留的时间，用于恢复没有保存的草稿
+	ArticleAutosaveTTL = time.Hour * 24 * 7
+)
+
+// ArticleAutosave 自动保存的草稿快照，每个用户、每篇文章只保留最新的一份
+type ArticleAutosave struct {
+	ArticleID int64
+	// Uid 自动保存的用户，协作者的快照和所有者的快照互不影响
+	Uid     int64
+	Title   string
+	Content string
+	// BaseVersion 客户端编辑时基于的草稿版本号
+	BaseVersion int64
+	// FlushedVersion 基于BaseVersion的快照写入制作库后草稿的版本号，0表示还没有写入过
+	FlushedVersion int64
+	SavedAt        time.Time
+}
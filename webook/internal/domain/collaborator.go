@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// ArticleRole 用户在一篇文章中的角色。文章的作者总是所有者，其他用户需要被邀请并接受邀请后才有角色。
+type ArticleRole int8
+
+const (
+	// ArticleRoleNone 和文章没有关系
+	ArticleRoleNone ArticleRole = iota
+	// ArticleRoleOwner 所有者：编辑、发布、撤回、删除文章，邀请、移除协作者
+	ArticleRoleOwner
+	// ArticleRoleEditor 编辑：编辑、发布文章
+	ArticleRoleEditor
+	// ArticleRoleViewer 查看者：查看草稿和历史版本
+	ArticleRoleViewer
+)
+
+func (r ArticleRole) ToInt8() int8 {
+	return int8(r)
+}
+
+func (r ArticleRole) Valid() bool {
+	return r >= ArticleRoleOwner && r <= ArticleRoleViewer
+}
+
+func (r ArticleRole) CanView() bool {
+	return r.Valid()
+}
+
+func (r ArticleRole) CanEdit() bool {
+	return r == ArticleRoleOwner || r == ArticleRoleEditor
+}
+
+func (r ArticleRole) CanManage() bool {
+	return r == ArticleRoleOwner
+}
+
+func (r ArticleRole) String() string {
+	switch r {
+	case ArticleRoleOwner:
+		return "owner"
+	case ArticleRoleEditor:
+		return "editor"
+	case ArticleRoleViewer:
+		return "viewer"
+	default:
+		return ""
+	}
+}
+
+// ParseArticleRole 无法识别的角色返回ArticleRoleNone
+func ParseArticleRole(s string) ArticleRole {
+	switch s {
+	case "owner":
+		return ArticleRoleOwner
+	case "editor":
+		return ArticleRoleEditor
+	case "viewer":
+		return ArticleRoleViewer
+	default:
+		return ArticleRoleNone
+	}
+}
+
+type CollaboratorStatus int8
+
+func (s CollaboratorStatus) ToInt8() int8 {
+	return int8(s)
+}
+
+const (
+	CollaboratorStatusUnknown CollaboratorStatus = iota
+	// CollaboratorStatusPending 已邀请，等待接受
+	CollaboratorStatusPending
+	CollaboratorStatusAccepted
+)
+
+// Collaborator 文章的协作者
+type Collaborator struct {
+	ArticleID int64
+	User      Author
+	Role      ArticleRole
+	Status    CollaboratorStatus
+	// InviterID 发出邀请的用户
+	InviterID int64
+
+	CTime time.Time
+	UTime time.Time
+}
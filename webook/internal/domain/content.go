@@ -0,0 +1,9 @@
+package domain
+
+// TOCItem 文章目录中的一项，对应正文中的一个标题
+type TOCItem struct {
+	Level int
+	Text  string
+	// Anchor 标题在HTML中的id
+	Anchor string
+}
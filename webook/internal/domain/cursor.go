@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Cursor 按照(UTime, ID)倒序分页时上一页最后一条记录的位置，零值表示第一页。
+//
+// 相比offset分页，游标分页只需要从索引上定位到游标的位置，翻页的代价和页数无关；
+// 翻页期间有新的数据插入时，也不会出现重复或者遗漏的数据。
+type Cursor struct {
+	UTime time.Time
+	ID    int64
+}
+
+func (c Cursor) IsZero() bool {
+	return c.ID == 0 && c.UTime.IsZero()
+}
+
+// CursorOf 以article作为上一页的最后一条记录
+func CursorOf(article Article) Cursor {
+	return Cursor{UTime: article.UTime, ID: article.ID}
+}
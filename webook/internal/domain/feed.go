@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// FeedItem 关注流中的一条记录：关注的作者发布了一篇文章
+type FeedItem struct {
+	ArticleID int64
+	AuthorID  int64
+	Title     string
+	Abstract  string
+	// PublishedAt 文章第一次进入关注流的时间，修改后重新发布不会改变位置
+	PublishedAt time.Time
+}
+
+// CursorOfFeed 以item作为上一页的最后一条记录
+func CursorOfFeed(item FeedItem) Cursor {
+	return Cursor{UTime: item.PublishedAt, ID: item.ArticleID}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// MaxFollowees 一个用户最多关注的用户数量，读取关注流时需要查询全部关注的用户
+const MaxFollowees = 2000
+
+// FollowRelation Follower关注了Followee
+type FollowRelation struct {
+	ID       int64
+	Follower int64
+	Followee int64
+	CTime    time.Time
+}
+
+// FollowStatics 用户的粉丝数和关注数
+type FollowStatics struct {
+	Followers int64
+	Followees int64
+}
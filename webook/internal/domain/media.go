@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"mime"
+	"time"
+)
+
+const (
+	// MaxImageSize、MaxAttachmentSize 上传文件的最大字节数
+	MaxImageSize      = 10 << 20
+	MaxAttachmentSize = 20 << 20
+	// MaxImagePixels 图片的最大像素数，解码前先检查，避免解压炸弹耗尽内存
+	MaxImagePixels = 40_000_000
+	// ThumbnailSize 缩略图的最大宽高，比它小的图片不生成缩略图
+	ThumbnailSize = 320
+	// MaxArticleMedia 一篇文章最多上传的文件数量
+	MaxArticleMedia = 200
+)
+
+// MediaKind 上传文件的类型
+type MediaKind uint8
+
+const (
+	MediaKindUnknown MediaKind = iota
+	// MediaKindImage 图片，在文章中直接显示
+	MediaKindImage
+	// MediaKindAttachment 附件，在文章中以链接的形式下载
+	MediaKindAttachment
+)
+
+func (k MediaKind) ToInt8() int8 {
+	return int8(k)
+}
+
+// MaxSize 该类型文件的最大字节数
+func (k MediaKind) MaxSize() int64 {
+	if k == MediaKindImage {
+		return MaxImageSize
+	}
+	return MaxAttachmentSize
+}
+
+func (k MediaKind) String() string {
+	switch k {
+	case MediaKindImage:
+		return "image"
+	case MediaKindAttachment:
+		return "attachment"
+	default:
+		return "unknown"
+	}
+}
+
+type mediaType struct {
+	kind MediaKind
+	ext  string
+}
+
+// mediaTypes 允许上传的文件类型，key是根据文件内容嗅探出的MIME类型，不信任客户端上传的文件名和Content-Type
+var mediaTypes = map[string]mediaType{
+	"image/jpeg":      {kind: MediaKindImage, ext: ".jpg"},
+	"image/png":       {kind: MediaKindImage, ext: ".png"},
+	"image/gif":       {kind: MediaKindImage, ext: ".gif"},
+	"image/webp":      {kind: MediaKindImage, ext: ".webp"},
+	"application/pdf": {kind: MediaKindAttachment, ext: ".pdf"},
+	"application/zip": {kind: MediaKindAttachment, ext: ".zip"},
+	"text/plain":      {kind: MediaKindAttachment, ext: ".txt"},
+}
+
+// MediaTypeOf 返回contentType对应的文件类型和扩展名，不允许上传时返回MediaKindUnknown
+func MediaTypeOf(contentType string) (MediaKind, string) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return MediaKindUnknown, ""
+	}
+	t, ok := mediaTypes[mt]
+	if !ok {
+		return MediaKindUnknown, ""
+	}
+	return t.kind, t.ext
+}
+
+// Media 作者为文章上传的图片或者附件，文件存储在对象存储中。
+// 文章被彻底删除时，文章的文件也会被删除。
+type Media struct {
+	ID        int64
+	Uid       int64
+	ArticleID int64
+	Kind      MediaKind
+	// Name 上传时的文件名，只用于展示
+	Name        string
+	ContentType string
+	Size        int64
+	// Key 文件在对象存储中的key，ThumbKey是缩略图的key，图片比缩略图小或者无法解码时为空
+	Key      string
+	ThumbKey string
+	// Width、Height 图片的宽高，附件为0
+	Width  int
+	Height int
+
+	CTime time.Time
+}
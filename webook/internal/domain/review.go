@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// ArticleReview 一次提交审核的记录
+type ArticleReview struct {
+	ID        int64
+	ArticleID int64
+	// AuthorID 文章的所有者，SubmitterID 提交审核的用户，可能是编辑
+	AuthorID    int64
+	SubmitterID int64
+	// Title 提交时的标题，审核队列中直接展示
+	Title string
+
+	ReviewerID int64
+	Status     ReviewStatus
+	// Comment 审核意见，驳回时必须填写
+	Comment string
+
+	CTime time.Time
+	UTime time.Time
+}
+
+type ReviewStatus int8
+
+func (s ReviewStatus) ToInt8() int8 {
+	return int8(s)
+}
+
+func (s ReviewStatus) String() string {
+	switch s {
+	case ReviewStatusPending:
+		return "pending"
+	case ReviewStatusApproved:
+		return "approved"
+	case ReviewStatusRejected:
+		return "rejected"
+	case ReviewStatusCanceled:
+		return "canceled"
+	default:
+		return ""
+	}
+}
+
+const (
+	ReviewStatusUnknown ReviewStatus = iota
+	ReviewStatusPending
+	ReviewStatusApproved
+	ReviewStatusRejected
+	// ReviewStatusCanceled 作者重新提交或者修改了文章，之前的审核不再有效
+	ReviewStatusCanceled
+)
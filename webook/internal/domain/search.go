@@ -0,0 +1,18 @@
+package domain
+
+// SearchResult 文章搜索的结果
+type SearchResult struct {
+	// Total 命中的文章总数，用于分页
+	Total int64
+	Hits  []SearchHit
+}
+
+type SearchHit struct {
+	Article Article
+	// Score 相关度，不同的索引实现算分方式不同，只能用于同一次搜索内的比较
+	Score float64
+
+	// TitleHighlight、ContentHighlight 标记了命中词的标题、正文片段
+	TitleHighlight   string
+	ContentHighlight string
+}
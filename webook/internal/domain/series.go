@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxSeriesArticles 一个系列最多包含的文章数量
+	MaxSeriesArticles = 100
+	// 系列标题、简介的最大长度（rune）
+	MaxSeriesTitleLen       = 128
+	MaxSeriesDescriptionLen = 1024
+)
+
+// Series 作者将多篇文章按顺序组织成的系列，例如分成多个部分的教程。
+// 一篇文章最多属于一个系列，系列中只能加入作者自己的文章。
+type Series struct {
+	ID          int64
+	Author      Author
+	Title       string
+	Description string
+	// Articles 按照在系列中的顺序排列。作者查看时包含草稿，读者查看时只有已发布的文章
+	Articles []SeriesArticle
+
+	CTime time.Time
+	UTime time.Time
+}
+
+// Valid 标题不能为空，标题和简介不能超过最大长度
+func (s Series) Valid() bool {
+	title := strings.TrimSpace(s.Title)
+	return title != "" &&
+		utf8.RuneCountInString(title) <= MaxSeriesTitleLen &&
+		utf8.RuneCountInString(s.Description) <= MaxSeriesDescriptionLen
+}
+
+type SeriesArticle struct {
+	ID    int64
+	Title string
+}
+
+// SeriesNav 已发布的文章在系列中的位置，用于阅读时跳转到上一篇、下一篇
+type SeriesNav struct {
+	ID    int64
+	Title string
+	// Index 文章在系列已发布的文章中的位置，从1开始，Total 系列中已发布的文章数量
+	Index int
+	Total int
+	// Prev、Next 的ID为0表示没有上一篇、下一篇
+	Prev SeriesArticle
+	Next SeriesArticle
+}
+
+// Nav 返回文章在系列中的导航，文章不在系列中时返回false。s.Articles需要只包含已发布的文章
+func (s Series) Nav(articleID int64) (SeriesNav, bool) {
+	for i, art := range s.Articles {
+		if art.ID != articleID {
+			continue
+		}
+		nav := SeriesNav{
+			ID:    s.ID,
+			Title: s.Title,
+			Index: i + 1,
+			Total: len(s.Articles),
+		}
+		if i > 0 {
+			nav.Prev = s.Articles[i-1]
+		}
+		if i+1 < len(s.Articles) {
+			nav.Next = s.Articles[i+1]
+		}
+		return nav, true
+	}
+	return SeriesNav{}, false
+}
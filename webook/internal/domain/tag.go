@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxArticleTags 一篇文章最多的标签数量
+	MaxArticleTags = 10
+	// 标签名、slug的最大长度（rune）
+	maxTagLen = 32
+)
+
+// Tag 文章的标签。Slug是标签归一化后的唯一标识，例如"Go 语言"、"go-语言"的slug都是"go-语言"，
+// Name保留第一次创建标签时作者输入的名称，用于展示。
+type Tag struct {
+	ID   int64
+	Name string
+	Slug string
+}
+
+// TagCount 标签下已发布文章的数量
+type TagCount struct {
+	Tag   Tag
+	Count int64
+}
+
+// NewTags 将作者输入的标签名归一化，过滤掉无效、重复的标签，最多保留MaxArticleTags个。
+// 返回值不会是nil，空切片表示清空文章的标签。
+func NewTags(names []string) []Tag {
+	tags := make([]Tag, 0, min(len(names), MaxArticleTags))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		slug := TagSlug(name)
+		if slug == "" {
+			continue
+		}
+		if _, ok := seen[slug]; ok {
+			continue
+		}
+		seen[slug] = struct{}{}
+		tags = append(tags, Tag{
+			Name: truncate(strings.TrimSpace(name), maxTagLen),
+			Slug: slug,
+		})
+		if len(tags) == MaxArticleTags {
+			break
+		}
+	}
+	return tags
+}
+
+// TagSlug 归一化标签名：
+//  1. 字母转小写，保留字母（包括中文等）、数字
+//  2. 空白以及'-'、'_'、'.'、'/'视为分隔符，连续的分隔符合并成一个'-'
+//  3. 其他字符（标点、符号等）直接丢弃
+func TagSlug(name string) string {
+	var sb strings.Builder
+	sep := false
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if sep && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sep = false
+			sb.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || strings.ContainsRune("-_./", r):
+			sep = true
+		}
+	}
+	return truncate(sb.String(), maxTagLen)
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimRight(string(runes[:n]), "-")
+}
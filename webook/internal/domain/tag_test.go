@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewTags(t *testing.T) {
+	testCases := []struct {
+		name  string
+		names []string
+
+		want []Tag
+	}{
+		{
+			name:  "归一化并去重",
+			names: []string{" Go 语言 ", "go-语言", "Go_语言"},
+			want:  []Tag{{Name: "Go 语言", Slug: "go-语言"}},
+		},
+		{
+			name:  "丢弃标点和无效的标签",
+			names: []string{"C++", "!!!", "", "Node.js"},
+			want:  []Tag{{Name: "C++", Slug: "c"}, {Name: "Node.js", Slug: "node-js"}},
+		},
+		{
+			name:  "空数组表示清空",
+			names: []string{},
+			want:  []Tag{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, NewTags(tc.names))
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import "time"
+
+const (
+	// ArticleExportSyncLimit 文章数量不超过该值时直接下载，超过时在后台生成导出文件
+	ArticleExportSyncLimit = 50
+	// ArticleExportTTL 后台生成的导出文件保留的时间，过期后被删除
+	ArticleExportTTL = time.Hour * 24 * 7
+	// MaxImportArticles 一次最多导入的文章数量
+	MaxImportArticles = 100
+	// MaxImportFileSize 导入的zip中单篇文章解压后的最大字节数
+	MaxImportFileSize = 1 << 20
+)
+
+// ExportFormat 导出文件的格式
+type ExportFormat string
+
+const (
+	// ExportFormatMarkdown 每篇文章一个Markdown文件，标题、标签等元数据写在YAML front matter中，打包成zip
+	ExportFormatMarkdown ExportFormat = "markdown"
+	// ExportFormatHTML 每篇文章一个渲染后的HTML文件，打包成zip，只用于阅读，不能再导入
+	ExportFormatHTML ExportFormat = "html"
+	// ExportFormatJSON 全部文章写在一个JSON文件中
+	ExportFormatJSON ExportFormat = "json"
+)
+
+func (f ExportFormat) Valid() bool {
+	switch f {
+	case ExportFormatMarkdown, ExportFormatHTML, ExportFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// Ext 导出文件的扩展名
+func (f ExportFormat) Ext() string {
+	if f == ExportFormatJSON {
+		return ".json"
+	}
+	return ".zip"
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatJSON {
+		return "application/json"
+	}
+	return "application/zip"
+}
+
+// ArticleExport 在后台导出作者全部文章的任务
+type ArticleExport struct {
+	ID     int64
+	Uid    int64
+	Format ExportFormat
+	Status ExportStatus
+	// Key 导出文件在对象存储中的key，只有完成的任务才有
+	Key string
+	// Count 导出的文章数量，Size 导出文件的大小
+	Count int
+	Size  int64
+	// Error 失败的原因
+	Error string
+
+	CTime time.Time
+	UTime time.Time
+}
+
+type ExportStatus int8
+
+func (s ExportStatus) ToInt8() int8 {
+	return int8(s)
+}
+
+func (s ExportStatus) String() string {
+	switch s {
+	case ExportStatusPending:
+		return "pending"
+	case ExportStatusRunning:
+		return "running"
+	case ExportStatusDone:
+		return "done"
+	case ExportStatusFailed:
+		return "failed"
+	default:
+		return ""
+	}
+}
+
+const (
+	ExportStatusUnknown ExportStatus = iota
+	ExportStatusPending
+	ExportStatusRunning
+	ExportStatusDone
+	ExportStatusFailed
+)
+
+// ImportResult 导入的一篇文章的结果，Error为空表示导入成功，ID是新建的草稿
+type ImportResult struct {
+	// Name 文章在导入文件中的位置，zip中的文件名或者JSON中的下标
+	Name  string
+	Title string
+	ID    int64
+	Error string
+}
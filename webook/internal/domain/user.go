@@ -22,4 +22,9 @@ type User struct {
 	Ctime    time.Time
 
 	WechatInfo WechatInfo
+
+	// Reviewer 审核人员，可以通过、驳回提交审核的文章
+	Reviewer bool
+	// RequireReview 账号的文章必须经过审核才能发布，不能直接发布或者定时发布
+	RequireReview bool
 }
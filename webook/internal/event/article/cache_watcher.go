@@ -0,0 +1,123 @@
+package article
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"learn_go/webook/internal/repository/cache"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+/*
+CacheWatcher 监听mongo中文章集合的change stream，文档变更后删除对应的缓存。
+
+repository在写操作后也会删除缓存，但是绕过repository的修改（数据修复、其他服务直接写库等）
+只能依赖缓存过期。监听change stream可以让这些修改也尽快生效。
+
+change stream要求mongo以副本集或者分片集群的方式部署。
+*/
+type CacheWatcher struct {
+	db    *mongo.Database
+	cache cache.ArticleCache
+	l     logger.LoggerV2
+	// 重新打开change stream前等待的时间
+	retryInterval time.Duration
+}
+
+func NewCacheWatcher(db *mongo.Database, cache cache.ArticleCache, l logger.LoggerV2) *CacheWatcher {
+	return &CacheWatcher{
+		db:            db,
+		cache:         cache,
+		l:             l,
+		retryInterval: time.Second * 5,
+	}
+}
+
+// changeEvent change stream事件中需要的字段。
+// 删除事件没有fullDocument，集合开启了pre-image时可以从fullDocumentBeforeChange中拿到文章
+type changeEvent struct {
+	OperationType      string          `bson:"operationType"`
+	FullDocument       *changedArticle `bson:"fullDocument"`
+	FullDocumentBefore *changedArticle `bson:"fullDocumentBeforeChange"`
+}
+
+type changedArticle struct {
+	ID       int64 `bson:"id"`
+	AuthorID int64 `bson:"author_id"`
+}
+
+func (w *CacheWatcher) Start() error {
+	stream, err := w.watch(nil)
+	if err != nil {
+		return err
+	}
+	go w.run(stream)
+	return nil
+}
+
+func (w *CacheWatcher) watch(resumeToken bson.Raw) (*mongo.ChangeStream, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"ns.coll": bson.M{"$in": bson.A{"articles", "published_articles"}},
+		}}},
+	}
+	opts := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+	return w.db.Watch(context.Background(), pipeline, opts)
+}
+
+func (w *CacheWatcher) run(stream *mongo.ChangeStream) {
+	ctx := context.Background()
+	for {
+		for stream.Next(ctx) {
+			var evt changeEvent
+			err := stream.Decode(&evt)
+			if err != nil {
+				w.l.Error("解析change stream事件失败", logger.Error(err))
+				continue
+			}
+			w.evict(evt)
+		}
+		// 出错后从最后处理的位置继续监听，不会漏掉期间的变更
+		token := stream.ResumeToken()
+		w.l.Error("change stream中断，准备重新监听", logger.Error(stream.Err()))
+		_ = stream.Close(ctx)
+		for {
+			time.Sleep(w.retryInterval)
+			var err error
+			stream, err = w.watch(token)
+			if err == nil {
+				break
+			}
+			w.l.Error("重新打开change stream失败", logger.Error(err))
+		}
+	}
+}
+
+func (w *CacheWatcher) evict(evt changeEvent) {
+	art := evt.FullDocument
+	if art == nil {
+		art = evt.FullDocumentBefore
+	}
+	if art == nil {
+		// 文档已经被删除并且没有pre-image，只能等缓存过期
+		w.l.Warn("change stream事件中没有文章数据", logger.String("op", evt.OperationType))
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := w.cache.Del(ctx, art.ID)
+	if err != nil {
+		w.l.Error("删除文章缓存失败", logger.Int64("id", art.ID), logger.Error(err))
+	}
+	err = w.cache.RemoveFirstPage(ctx, art.AuthorID)
+	if err != nil {
+		w.l.Error("删除作者第一页缓存失败", logger.Int64("author_id", art.AuthorID), logger.Error(err))
+	}
+}
@@ -0,0 +1,86 @@
+package article
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/repository/cache"
+	cachemocks "learn_go/webook/internal/repository/cache/mocks"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+func TestCacheWatcher_evict(t *testing.T) {
+	testCases := []struct {
+		name string
+		// evt change stream中的原始事件
+		evt bson.M
+
+		mock func(ctrl *gomock.Controller) cache.ArticleCache
+	}{
+		{
+			name: "更新事件，使用fullDocument",
+			evt: bson.M{
+				"operationType": "update",
+				"fullDocument":  bson.M{"id": int64(1), "author_id": int64(2000), "title": "title"},
+			},
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+				c.EXPECT().RemoveFirstPage(gomock.Any(), int64(2000)).Return(nil)
+				return c
+			},
+		},
+		{
+			name: "删除事件，使用pre-image",
+			evt: bson.M{
+				"operationType":            "delete",
+				"fullDocumentBeforeChange": bson.M{"id": int64(1), "author_id": int64(2000)},
+			},
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().Del(gomock.Any(), int64(1)).Return(nil)
+				c.EXPECT().RemoveFirstPage(gomock.Any(), int64(2000)).Return(nil)
+				return c
+			},
+		},
+		{
+			name: "删除事件没有pre-image，只能等缓存过期",
+			evt:  bson.M{"operationType": "delete"},
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				return cachemocks.NewMockArticleCache(ctrl)
+			},
+		},
+		{
+			name: "删除文章缓存失败，仍然删除作者第一页缓存",
+			evt: bson.M{
+				"operationType": "replace",
+				"fullDocument":  bson.M{"id": int64(1), "author_id": int64(2000)},
+			},
+			mock: func(ctrl *gomock.Controller) cache.ArticleCache {
+				c := cachemocks.NewMockArticleCache(ctrl)
+				c.EXPECT().Del(gomock.Any(), int64(1)).Return(errors.New("redis error"))
+				c.EXPECT().RemoveFirstPage(gomock.Any(), int64(2000)).Return(nil)
+				return c
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			raw, err := bson.Marshal(tc.evt)
+			require.NoError(t, err)
+			var evt changeEvent
+			require.NoError(t, bson.Unmarshal(raw, &evt))
+			assert.Equal(t, tc.evt["operationType"], evt.OperationType)
+
+			w := NewCacheWatcher(nil, tc.mock(ctrl), logger.NewNopLogger())
+			w.evict(evt)
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./producer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./producer.go -package=evtmocks -destination=./mocks/producer.mock.go Producer
+//
+
+// Package evtmocks is a generated GoMock package.
+package evtmocks
+
+import (
+	article "learn_go/webook/internal/event/article"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProducer is a mock of Producer interface.
+type MockProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockProducerMockRecorder
+}
+
+// MockProducerMockRecorder is the mock recorder for MockProducer.
+type MockProducerMockRecorder struct {
+	mock *MockProducer
+}
+
+// NewMockProducer creates a new mock instance.
+func NewMockProducer(ctrl *gomock.Controller) *MockProducer {
+	mock := &MockProducer{ctrl: ctrl}
+	mock.recorder = &MockProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProducer) EXPECT() *MockProducerMockRecorder {
+	return m.recorder
+}
+
+// ProducePublishedEvent mocks base method.
+func (m *MockProducer) ProducePublishedEvent(event article.PublishedEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProducePublishedEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProducePublishedEvent indicates an expected call of ProducePublishedEvent.
+func (mr *MockProducerMockRecorder) ProducePublishedEvent(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProducePublishedEvent", reflect.TypeOf((*MockProducer)(nil).ProducePublishedEvent), event)
+}
+
+// ProduceReadEvent mocks base method.
+func (m *MockProducer) ProduceReadEvent(event article.ReadEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceReadEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceReadEvent indicates an expected call of ProduceReadEvent.
+func (mr *MockProducerMockRecorder) ProduceReadEvent(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceReadEvent", reflect.TypeOf((*MockProducer)(nil).ProduceReadEvent), event)
+}
+
+// ProduceReviewEvent mocks base method.
+func (m *MockProducer) ProduceReviewEvent(event article.ReviewEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceReviewEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceReviewEvent indicates an expected call of ProduceReviewEvent.
+func (mr *MockProducerMockRecorder) ProduceReviewEvent(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceReviewEvent", reflect.TypeOf((*MockProducer)(nil).ProduceReviewEvent), event)
+}
+
+// ProduceSyncEvent mocks base method.
+func (m *MockProducer) ProduceSyncEvent(event article.SyncEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceSyncEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProduceSyncEvent indicates an expected call of ProduceSyncEvent.
+func (mr *MockProducerMockRecorder) ProduceSyncEvent(event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceSyncEvent", reflect.TypeOf((*MockProducer)(nil).ProduceSyncEvent), event)
+}
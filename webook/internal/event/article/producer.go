@@ -3,19 +3,72 @@ package article
 import (
 	"encoding/json"
 	"github.com/IBM/sarama"
+	"strconv"
 )
 
 type ReadEvent struct {
 	Uid       int64 `json:"uid"`
 	ArticleID int64 `json:"article_id"`
+	// Time 阅读的时间（毫秒），消息可能积压，消费者按照这个时间去重、统计独立读者
+	Time int64 `json:"time"`
 }
 
 const TopicReadEvent = "article_read"
 
+// SyncEvent 线上库的文章发生变化（发布、撤回）时产生的事件，下游据此同步搜索索引等数据
+type SyncEvent struct {
+	ArticleID int64  `json:"article_id"`
+	AuthorID  int64  `json:"author_id"`
+	Title     string `json:"title"`
+	Content   string `json:"content"`
+	// Status 文章发布后的状态，非ArticleStatusPublished说明文章已经不可见了
+	Status int8  `json:"status"`
+	Utime  int64 `json:"u_time"`
+}
+
+const TopicSyncEvent = "article_sync"
+
+// ReviewEvent 文章的审核状态变化（提交、通过、驳回）时产生的事件，通知等下游据此提醒作者、审核人员
+type ReviewEvent struct {
+	ReviewID  int64 `json:"review_id"`
+	ArticleID int64 `json:"article_id"`
+	AuthorID  int64 `json:"author_id"`
+	// OperatorID 提交审核的用户或者审核人员
+	OperatorID int64 `json:"operator_id"`
+	// From、To 文章变化前后的状态
+	From    int8   `json:"from"`
+	To      int8   `json:"to"`
+	Comment string `json:"comment"`
+	Utime   int64  `json:"u_time"`
+}
+
+const TopicReviewEvent = "article_review"
+
+// PublishedEvent 文章发布（包括修改后重新发布）时产生的事件，关注流据此把文章分发给作者的粉丝。
+// 撤回、删除文章时Removed为true，和发布使用同一个topic，同一篇文章的发布和撤回按照顺序消费
+type PublishedEvent struct {
+	ArticleID   int64  `json:"article_id"`
+	AuthorID    int64  `json:"author_id"`
+	Title       string `json:"title"`
+	Abstract    string `json:"abstract"`
+	PublishedAt int64  `json:"published_at"`
+	Removed     bool   `json:"removed,omitempty"`
+}
+
+const TopicPublishedEvent = "article_published"
+
 // Producer 产生各种事件（事件即消息）
+//
+//go:generate mockgen -source=./producer.go -package=evtmocks -destination=./mocks/producer.mock.go Producer
 type Producer interface {
 	// ProduceReadEvent 产生一个用户读取文章的事件
 	ProduceReadEvent(event ReadEvent) error
+	// ProduceSyncEvent 产生一个线上库文章变化的事件
+	ProduceSyncEvent(event SyncEvent) error
+	// ProduceReviewEvent 产生一个文章审核状态变化的事件
+	ProduceReviewEvent(event ReviewEvent) error
+	// ProducePublishedEvent 产生一个文章发布的事件
+	ProducePublishedEvent(event PublishedEvent) error
 }
 
 type SaramaSyncProducer struct {
@@ -41,3 +94,47 @@ func (p *SaramaSyncProducer) ProduceReadEvent(event ReadEvent) error {
 	})
 	return err
 }
+
+func (p *SaramaSyncProducer) ProduceSyncEvent(event SyncEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// 用文章id作为key，同一篇文章的事件落在同一个分区，保证消费的顺序
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicSyncEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(event.ArticleID, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (p *SaramaSyncProducer) ProduceReviewEvent(event ReviewEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicReviewEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(event.ArticleID, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
+
+func (p *SaramaSyncProducer) ProducePublishedEvent(event PublishedEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// 用作者id作为key，同一个作者的文章按发布的顺序分发
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicPublishedEvent,
+		Key:   sarama.StringEncoder(strconv.FormatInt(event.AuthorID, 10)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
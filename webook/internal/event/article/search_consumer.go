@@ -0,0 +1,62 @@
+package article
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/search"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/saramax"
+	"time"
+)
+
+// SearchSyncConsumer 消费文章的发布、撤回事件，同步更新搜索索引
+type SearchSyncConsumer struct {
+	client sarama.Client
+	index  search.ArticleIndex
+	// 使用进程内的索引时，每个实例都需要消费全部的事件，因此每个实例使用独立的消费组
+	group string
+	l     logger.LoggerV2
+}
+
+func NewSearchSyncConsumer(client sarama.Client, index search.ArticleIndex, group string,
+	l logger.LoggerV2) *SearchSyncConsumer {
+	return &SearchSyncConsumer{
+		client: client,
+		index:  index,
+		group:  group,
+		l:      l,
+	}
+}
+
+func (c *SearchSyncConsumer) Start() error {
+	consumer, err := sarama.NewConsumerGroupFromClient(c.group, c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := consumer.Consume(context.Background(),
+			[]string{TopicSyncEvent},
+			saramax.NewHandler[SyncEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("搜索索引消费者退出", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (c *SearchSyncConsumer) Consume(message *sarama.ConsumerMessage, evt SyncEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if domain.ArticleStatus(evt.Status) != domain.ArticleStatusPublished {
+		return c.index.Delete(ctx, evt.ArticleID)
+	}
+	return c.index.Upsert(ctx, domain.Article{
+		ID:      evt.ArticleID,
+		Title:   evt.Title,
+		Content: evt.Content,
+		Author:  domain.Author{ID: evt.AuthorID},
+		Status:  domain.ArticleStatusPublished,
+		UTime:   time.UnixMilli(evt.Utime),
+	})
+}
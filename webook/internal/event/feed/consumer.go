@@ -0,0 +1,59 @@
+package feed
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/saramax"
+	"time"
+)
+
+// PublishedConsumer 消费文章发布事件，把文章分发到作者粉丝的关注流，文章撤回、删除时从关注流中移除
+type PublishedConsumer struct {
+	client sarama.Client
+	svc    service.FeedService
+	l      logger.LoggerV2
+}
+
+func NewPublishedConsumer(client sarama.Client, svc service.FeedService, l logger.LoggerV2) *PublishedConsumer {
+	return &PublishedConsumer{
+		client: client,
+		svc:    svc,
+		l:      l,
+	}
+}
+
+func (c *PublishedConsumer) Start() error {
+	consumer, err := sarama.NewConsumerGroupFromClient("group:feed", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := consumer.Consume(context.Background(),
+			[]string{article.TopicPublishedEvent},
+			saramax.NewHandler[article.PublishedEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("关注流消费者退出", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (c *PublishedConsumer) Consume(message *sarama.ConsumerMessage, evt article.PublishedEvent) error {
+	// 写扩散时需要分批写入所有粉丝的收件箱
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if evt.Removed {
+		return c.svc.Remove(ctx, evt.ArticleID)
+	}
+	return c.svc.Publish(ctx, domain.FeedItem{
+		ArticleID:   evt.ArticleID,
+		AuthorID:    evt.AuthorID,
+		Title:       evt.Title,
+		Abstract:    evt.Abstract,
+		PublishedAt: time.UnixMilli(evt.PublishedAt),
+	})
+}
@@ -0,0 +1,55 @@
+package feed
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/service"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func TestPublishedConsumer_Consume(t *testing.T) {
+	testCases := []struct {
+		name string
+		evt  article.PublishedEvent
+		mock func(ctrl *gomock.Controller) service.FeedService
+	}{
+		{
+			name: "发布事件，分发到关注流",
+			evt:  article.PublishedEvent{ArticleID: 1, AuthorID: 2000, Title: "title", Abstract: "abstract", PublishedAt: 1000},
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Publish(gomock.Any(), domain.FeedItem{
+					ArticleID:   1,
+					AuthorID:    2000,
+					Title:       "title",
+					Abstract:    "abstract",
+					PublishedAt: time.UnixMilli(1000),
+				}).Return(nil)
+				return svc
+			},
+		},
+		{
+			name: "撤回、删除事件，从关注流中移除",
+			evt:  article.PublishedEvent{ArticleID: 1, AuthorID: 2000, Removed: true},
+			mock: func(ctrl *gomock.Controller) service.FeedService {
+				svc := svcmocks.NewMockFeedService(ctrl)
+				svc.EXPECT().Remove(gomock.Any(), int64(1)).Return(nil)
+				return svc
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewPublishedConsumer(nil, tc.mock(ctrl), logger.NewNopLogger())
+			assert.NoError(t, c.Consume(nil, tc.evt))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/saramax"
+	"time"
+)
+
+// FixConsumer 消费校验产生的修复事件，修复时重新读取base的最新数据，所以重复消费、乱序消费都没有问题
+type FixConsumer struct {
+	client sarama.Client
+	src    dao.ArticleMigrationDao
+	dst    dao.ArticleMigrationDao
+	l      logger.LoggerV2
+}
+
+func NewFixConsumer(client sarama.Client, src dao.ArticleMigrationDao, dst dao.ArticleMigrationDao,
+	l logger.LoggerV2) *FixConsumer {
+	return &FixConsumer{
+		client: client,
+		src:    src,
+		dst:    dst,
+		l:      l,
+	}
+}
+
+func (c *FixConsumer) Start() error {
+	consumer, err := sarama.NewConsumerGroupFromClient("group:article_migration", c.client)
+	if err != nil {
+		return err
+	}
+	go func() {
+		err := consumer.Consume(context.Background(),
+			[]string{TopicFixEvent},
+			saramax.NewHandler[FixEvent](c.l, c.Consume))
+		if err != nil {
+			c.l.Error("迁移修复消费者退出", logger.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (c *FixConsumer) Consume(message *sarama.ConsumerMessage, evt FixEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	switch evt.Base {
+	case BaseSrc:
+		return dao.CopyArticles(ctx, evt.Table, c.src, c.dst, []int64{evt.ID})
+	case BaseDst:
+		return dao.CopyArticles(ctx, evt.Table, c.dst, c.src, []int64{evt.ID})
+	default:
+		return errors.New("unknown fix event base: " + evt.Base)
+	}
+}
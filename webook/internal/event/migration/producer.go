@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/IBM/sarama"
+)
+
+const TopicFixEvent = "article_migration_fix"
+
+const (
+	// BaseSrc 以src的数据为准修复dst
+	BaseSrc = "src"
+	// BaseDst 以dst的数据为准修复src
+	BaseDst = "dst"
+)
+
+// FixEvent 校验发现两边的数据不一致时产生的事件，消费者以Base一方的数据为准修复另一方
+type FixEvent struct {
+	Table string `json:"table"`
+	ID    int64  `json:"id"`
+	Base  string `json:"base"`
+	// Reason 不一致的原因，方便排查
+	Reason string `json:"reason"`
+}
+
+//go:generate mockgen -source=./producer.go -package=evtmocks -destination=./mocks/producer.mock.go Producer
+type Producer interface {
+	ProduceFixEvent(evt FixEvent) error
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+func NewSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{
+		producer: producer,
+	}
+}
+
+func (p *SaramaSyncProducer) ProduceFixEvent(evt FixEvent) error {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	// 同一条数据的修复事件落在同一个分区
+	_, _, err = p.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: TopicFixEvent,
+		Key:   sarama.StringEncoder(fmt.Sprintf("%s:%d", evt.Table, evt.ID)),
+		Value: sarama.ByteEncoder(data),
+	})
+	return err
+}
@@ -47,6 +47,14 @@ var (
 		web.NewJWTHandler,
 		web.NewTestHandler,
 
+		// 搜索
+		web.NewSearchHandler,
+		ioc.InitSearchService,
+		ioc.InitArticleIndex,
+		article.NewArticleRepository,
+		dao.NewArticleDao,
+		cache.NewArticleCache,
+
 		ioc.InitMiddlewares,
 		ioc.InitGin,
 	)
@@ -67,6 +75,7 @@ var (
 		event.NewSyncProducer,
 
 		web.NewArticleHandler,
+		ioc.InitCursorSigner,
 		service.NewArticleService,
 		service2.NewInteractionService,
 
@@ -85,6 +94,12 @@ var (
 
 		article.NewArticleAuthorRepository,
 		article.NewArticleReaderRepository,
+		article.NewRevisionRepository,
+		article.NewTagRepository,
+		article.NewCollaboratorRepository,
+		dao.NewArticleRevisionDao,
+		dao.NewTagDao,
+		dao.NewArticleCollaboratorDao,
 	)
 
 	articleProvidersV1 = wire.NewSet(
@@ -103,6 +118,7 @@ var (
 		event.NewSyncProducer,
 
 		web.NewArticleHandler,
+		ioc.InitCursorSigner,
 		service.NewArticleService,
 		service2.NewInteractionService,
 
@@ -120,6 +136,12 @@ var (
 
 		article.NewArticleAuthorRepository,
 		article.NewArticleReaderRepository,
+		article.NewRevisionRepository,
+		article.NewTagRepository,
+		article.NewCollaboratorRepository,
+		dao.NewArticleRevisionDao,
+		dao.NewTagDao,
+		dao.NewArticleCollaboratorDao,
 	)
 
 	schedulerProvider = wire.NewSet(
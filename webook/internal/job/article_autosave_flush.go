@@ -0,0 +1,70 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// ArticleAutosaveFlushExecutor 将缓冲区中到期的自动保存快照写入制作库
+type ArticleAutosaveFlushExecutor struct {
+	svc service.ArticleService
+	l   logger.LoggerV2
+
+	// 每批扫描的快照数量
+	batchSize int
+}
+
+func NewArticleAutosaveFlushExecutor(svc service.ArticleService, l logger.LoggerV2) *ArticleAutosaveFlushExecutor {
+	return &ArticleAutosaveFlushExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (e *ArticleAutosaveFlushExecutor) Name() string {
+	return "executor:article_autosave_flush"
+}
+
+// Job 返回该执行器对应的任务定义，每10秒扫描一次
+func (e *ArticleAutosaveFlushExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "article:autosave_flush",
+		Executor:   e.Name(),
+		Expression: "*/10 * * * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *ArticleAutosaveFlushExecutor) Exec(ctx context.Context, j domain.Job) error {
+	now := time.Now()
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		saves, err := e.svc.ListDueAutosaves(ctx, now, e.batchSize)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, save := range saves {
+			// 写入失败的快照留在缓冲区中，下一次执行时重试
+			err = e.svc.FlushAutosave(ctx, save)
+			if err != nil {
+				failed++
+				e.l.Error("写入自动保存的快照失败",
+					logger.Int64("article id", save.ArticleID),
+					logger.Int64("uid", save.Uid),
+					logger.Error(err))
+			}
+		}
+		// 写入成功的快照会被移出缓冲区，所以下一批总是从头开始查询。
+		// 查询出的数量不够一批，或者这一批全部失败（避免死循环）时结束。
+		if len(saves) < e.batchSize || failed == len(saves) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// ArticleContentSweepExecutor 定期清理对象存储中不再被引用的文章内容
+type ArticleContentSweepExecutor struct {
+	sweeper *dao.ArticleContentSweeper
+	l       logger.LoggerV2
+
+	// 只清理写入时间超过grace的对象
+	grace time.Duration
+}
+
+func NewArticleContentSweepExecutor(sweeper *dao.ArticleContentSweeper, l logger.LoggerV2) *ArticleContentSweepExecutor {
+	return &ArticleContentSweepExecutor{
+		sweeper: sweeper,
+		l:       l,
+		grace:   time.Minute * 10,
+	}
+}
+
+func (e *ArticleContentSweepExecutor) Name() string {
+	return "executor:article_content_sweep"
+}
+
+// Job 返回该执行器对应的任务定义，每小时执行一次
+func (e *ArticleContentSweepExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "article:content_sweep",
+		Executor:   e.Name(),
+		Expression: "0 0 * * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *ArticleContentSweepExecutor) Exec(ctx context.Context, j domain.Job) error {
+	deleted, err := e.sweeper.Sweep(ctx, time.Now().Add(-e.grace))
+	e.l.Info("清理文章内容对象", logger.Int("deleted", deleted))
+	return err
+}
@@ -0,0 +1,63 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// ArticleExportExecutor 执行文章的后台导出任务，并删除过期的导出文件
+type ArticleExportExecutor struct {
+	svc service.ArticleTransferService
+	l   logger.LoggerV2
+
+	// 每次执行最多处理的任务数量，导出比较耗时，一次不宜处理太多
+	batchSize int
+}
+
+func NewArticleExportExecutor(svc service.ArticleTransferService, l logger.LoggerV2) *ArticleExportExecutor {
+	return &ArticleExportExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 10,
+	}
+}
+
+func (e *ArticleExportExecutor) Name() string {
+	return "executor:article_export"
+}
+
+// Job 返回该执行器对应的任务定义，每30秒扫描一次
+func (e *ArticleExportExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "article:export",
+		Executor:   e.Name(),
+		Expression: "*/30 * * * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *ArticleExportExecutor) Exec(ctx context.Context, j domain.Job) error {
+	now := time.Now()
+	exports, err := e.svc.ListRunnableExports(ctx, now, e.batchSize)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// 失败的任务已经记录了结果，继续执行下一个
+		err = e.svc.RunExport(ctx, export)
+		if err != nil {
+			e.l.Error("执行导出任务失败", logger.Int64("export id", export.ID), logger.Error(err))
+		}
+	}
+	purged, err := e.svc.PurgeExpiredExports(ctx, now, e.batchSize)
+	if purged > 0 {
+		e.l.Info("删除过期的导出文件", logger.Int("purged", purged))
+	}
+	return err
+}
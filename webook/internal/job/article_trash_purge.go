@@ -0,0 +1,71 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// ArticleTrashPurgeExecutor 彻底删除在回收站中保留时间超过domain.ArticleTrashTTL的文章
+type ArticleTrashPurgeExecutor struct {
+	svc service.ArticleService
+	l   logger.LoggerV2
+
+	// 每批扫描的文章数量
+	batchSize int
+}
+
+func NewArticleTrashPurgeExecutor(svc service.ArticleService, l logger.LoggerV2) *ArticleTrashPurgeExecutor {
+	return &ArticleTrashPurgeExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (e *ArticleTrashPurgeExecutor) Name() string {
+	return "executor:article_trash_purge"
+}
+
+// Job 返回该执行器对应的任务定义，每天凌晨3点执行一次
+func (e *ArticleTrashPurgeExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "article:trash_purge",
+		Executor:   e.Name(),
+		Expression: "0 0 3 * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *ArticleTrashPurgeExecutor) Exec(ctx context.Context, j domain.Job) error {
+	now := time.Now()
+	purged := 0
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		arts, err := e.svc.ListExpiredTrash(ctx, now, e.batchSize)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, art := range arts {
+			// 失败的文章留在回收站中，下一次执行时重试
+			err = e.svc.Purge(ctx, art)
+			if err != nil {
+				failed++
+				e.l.Error("彻底删除文章失败", logger.Int64("article id", art.ID), logger.Error(err))
+				continue
+			}
+			purged++
+		}
+		// 删除成功的文章不会再被查询到，所以下一批总是从头开始查询。
+		// 查询出的数量不够一批，或者这一批全部失败（避免死循环）时结束。
+		if len(arts) < e.batchSize || failed == len(arts) {
+			e.l.Info("清理回收站", logger.Int("purged", purged))
+			return nil
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// MediaSweepExecutor 删除对象存储中已经删除的图片和附件，包括彻底删除的文章的文件
+type MediaSweepExecutor struct {
+	svc service.MediaService
+	l   logger.LoggerV2
+
+	// 每次执行最多删除的文件数量
+	batchSize int
+}
+
+func NewMediaSweepExecutor(svc service.MediaService, l logger.LoggerV2) *MediaSweepExecutor {
+	return &MediaSweepExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (e *MediaSweepExecutor) Name() string {
+	return "executor:media_sweep"
+}
+
+// Job 返回该执行器对应的任务定义，每5分钟执行一次
+func (e *MediaSweepExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "media:sweep",
+		Executor:   e.Name(),
+		Expression: "0 */5 * * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *MediaSweepExecutor) Exec(ctx context.Context, j domain.Job) error {
+	for ctx.Err() == nil {
+		n, err := e.svc.SweepDeleted(ctx, e.batchSize)
+		if n > 0 {
+			e.l.Info("删除已经删除的文件", logger.Int("count", n))
+		}
+		if err != nil {
+			return err
+		}
+		// 不足一批说明已经删除完了
+		if n < e.batchSize {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
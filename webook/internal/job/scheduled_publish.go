@@ -0,0 +1,69 @@
+package job
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// ScheduledPublishExecutor 定时发布文章的执行器。
+// 每次执行时分批扫描到期的文章，逐篇调用ArticleService发布。
+type ScheduledPublishExecutor struct {
+	svc service.ArticleService
+	l   logger.LoggerV2
+
+	// 每批扫描的文章数量
+	batchSize int
+}
+
+func NewScheduledPublishExecutor(svc service.ArticleService, l logger.LoggerV2) *ScheduledPublishExecutor {
+	return &ScheduledPublishExecutor{
+		svc:       svc,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (e *ScheduledPublishExecutor) Name() string {
+	return "executor:article_scheduled_publish"
+}
+
+// Job 返回该执行器对应的任务定义，每10秒扫描一次
+func (e *ScheduledPublishExecutor) Job() domain.Job {
+	return domain.Job{
+		Name:       "article:scheduled_publish",
+		Executor:   e.Name(),
+		Expression: "*/10 * * * * ?",
+		Nt:         time.Now(),
+	}
+}
+
+func (e *ScheduledPublishExecutor) Exec(ctx context.Context, j domain.Job) error {
+	// 固定扫描的截止时间，避免在一次执行中不停的追赶新到期的文章
+	now := time.Now()
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		arts, err := e.svc.ListDueScheduled(ctx, now, e.batchSize)
+		if err != nil {
+			return err
+		}
+		failed := 0
+		for _, art := range arts {
+			// 单篇文章发布失败不影响其他文章，失败的文章会清除租约，等待下一次调度
+			err = e.svc.PublishScheduled(ctx, art)
+			if err != nil {
+				failed++
+				e.l.Error("定时发布文章失败", logger.Int64("article id", art.ID), logger.Error(err))
+			}
+		}
+		// 发布成功的文章不再是pending状态，被抢占的文章在租约内也查询不到，所以下一批总是从头开始查询。
+		// 查询出的数量不够一批，或者这一批全部失败（避免死循环）时结束。
+		if len(arts) < e.batchSize || failed == len(arts) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package migrator
+
+import (
+	"context"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// FullCopier 按照(u_time, id)的顺序，分批把base中的全部文章复制到target。
+// 复制期间发生的修改由双写保证，复制完成后再通过校验修复遗漏的数据。
+type FullCopier struct {
+	base      dao.ArticleMigrationDao
+	target    dao.ArticleMigrationDao
+	l         logger.LoggerV2
+	batchSize int
+}
+
+func NewFullCopier(base dao.ArticleMigrationDao, target dao.ArticleMigrationDao, l logger.LoggerV2) *FullCopier {
+	return &FullCopier{
+		base:      base,
+		target:    target,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (c *FullCopier) Copy(ctx context.Context) error {
+	for _, table := range dao.MigrationTables {
+		cnt, err := c.copyTable(ctx, table)
+		if err != nil {
+			return err
+		}
+		c.l.Info("全量复制完成", logger.String("table", table), logger.Int("count", cnt))
+	}
+	return nil
+}
+
+func (c *FullCopier) copyTable(ctx context.Context, table string) (int, error) {
+	var (
+		afterUtime int64
+		afterID    int64
+		cnt        int
+	)
+	for {
+		if ctx.Err() != nil {
+			return cnt, ctx.Err()
+		}
+		dbCtx, cancel := context.WithTimeout(ctx, time.Second*3)
+		arts, err := c.base.ScanForMigration(dbCtx, table, afterUtime, afterID, c.batchSize)
+		if err == nil && len(arts) > 0 {
+			err = c.target.UpsertForMigration(dbCtx, table, arts)
+		}
+		cancel()
+		if err != nil {
+			return cnt, err
+		}
+		cnt += len(arts)
+		if len(arts) < c.batchSize {
+			return cnt, nil
+		}
+		last := arts[len(arts)-1]
+		afterUtime, afterID = last.Utime, last.ID
+	}
+}
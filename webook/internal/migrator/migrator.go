@@ -0,0 +1,178 @@
+package migrator
+
+import (
+	"context"
+	"errors"
+	"github.com/redis/go-redis/v9"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"sync"
+	"time"
+)
+
+var ErrTaskRunning = errors.New("migration task is running")
+
+const (
+	TaskFullCopy = "full_copy"
+	TaskVerify   = "verify"
+	TaskAuxCopy  = "aux_copy"
+)
+
+// patternKey 所有实例共享的双写模式，没有时使用各个实例配置的模式
+const patternKey = "migration:article:pattern"
+
+// Migrator 管理文章迁移：切换双写模式，在后台执行全量复制和校验任务。
+// 双写模式保存在redis中，每个实例通过SyncPattern定时同步。
+// 任务只在收到请求的实例上执行，同一个实例上同类任务只能有一个在运行。
+type Migrator struct {
+	dao      *dao.DoubleWriteArticleDao
+	aux      *dao.ArticleAuxCopier
+	producer migration.Producer
+	cmd      redis.Cmdable
+	l        logger.LoggerV2
+
+	lock  sync.Mutex
+	tasks map[string]context.CancelFunc
+}
+
+func NewMigrator(dao *dao.DoubleWriteArticleDao, aux *dao.ArticleAuxCopier, producer migration.Producer,
+	cmd redis.Cmdable, l logger.LoggerV2) *Migrator {
+	return &Migrator{
+		dao:      dao,
+		aux:      aux,
+		producer: producer,
+		cmd:      cmd,
+		l:        l,
+		tasks:    make(map[string]context.CancelFunc),
+	}
+}
+
+func (m *Migrator) Pattern() string {
+	return m.dao.Pattern()
+}
+
+// UpdatePattern 把模式写入redis，当前实例立即切换，其他实例在下一次同步时切换
+func (m *Migrator) UpdatePattern(ctx context.Context, pattern string) error {
+	if err := dao.CheckPattern(pattern); err != nil {
+		return err
+	}
+	if err := m.cmd.Set(ctx, patternKey, pattern, 0).Err(); err != nil {
+		return err
+	}
+	m.applyPattern(pattern)
+	return nil
+}
+
+// SyncPattern 每隔interval从redis同步一次双写模式，直到ctx结束
+func (m *Migrator) SyncPattern(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		m.syncPattern(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Migrator) syncPattern(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	pattern, err := m.cmd.Get(ctx, patternKey).Result()
+	switch err {
+	case nil:
+		m.applyPattern(pattern)
+	case redis.Nil:
+		// 还没有切换过，使用配置的模式
+	default:
+		m.l.Warn("同步双写模式失败", logger.Error(err))
+	}
+}
+
+func (m *Migrator) applyPattern(pattern string) {
+	if pattern == m.dao.Pattern() {
+		return
+	}
+	if err := m.dao.UpdatePattern(pattern); err != nil {
+		m.l.Error("双写模式错误", logger.String("pattern", pattern), logger.Error(err))
+		return
+	}
+	m.l.Info("切换双写模式", logger.String("pattern", pattern))
+}
+
+// RunningTasks 正在运行的任务
+func (m *Migrator) RunningTasks() []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	res := make([]string, 0, len(m.tasks))
+	for name := range m.tasks {
+		res = append(res, name)
+	}
+	return res
+}
+
+// StartFullCopy 以当前的base为准复制到target
+func (m *Migrator) StartFullCopy() error {
+	base, target := m.dao.Base()
+	copier := NewFullCopier(base, target, m.l)
+	return m.start(TaskFullCopy, copier.Copy)
+}
+
+// StartAuxCopy 把标签、版本记录等附属数据从mysql复制到mongo，文章全量复制完成、停止写入之后执行
+func (m *Migrator) StartAuxCopy() error {
+	return m.start(TaskAuxCopy, m.aux.Copy)
+}
+
+// StartVerify incremental为false时全量校验，否则从since开始增量校验，直到调用Stop
+func (m *Migrator) StartVerify(incremental bool, since int64) error {
+	base, target := m.dao.Base()
+	baseName := migration.BaseSrc
+	if base == m.dao.Dst() {
+		baseName = migration.BaseDst
+	}
+	verifier := NewVerifier(base, target, baseName, m.producer, m.l)
+	if incremental {
+		return m.start(TaskVerify, func(ctx context.Context) error {
+			return verifier.Incremental(ctx, since)
+		})
+	}
+	return m.start(TaskVerify, verifier.Full)
+}
+
+func (m *Migrator) Stop(task string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if cancel, ok := m.tasks[task]; ok {
+		cancel()
+		delete(m.tasks, task)
+	}
+}
+
+func (m *Migrator) start(name string, fn func(ctx context.Context) error) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if _, ok := m.tasks[name]; ok {
+		return ErrTaskRunning
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.tasks[name] = cancel
+	go func() {
+		err := fn(ctx)
+		if err != nil {
+			m.l.Error("迁移任务失败", logger.String("task", name), logger.Error(err))
+		} else {
+			m.l.Info("迁移任务结束", logger.String("task", name))
+		}
+		m.lock.Lock()
+		defer m.lock.Unlock()
+		// 任务被Stop之后可能又启动了同名的任务，只能删除自己
+		if ctx.Err() == nil {
+			delete(m.tasks, name)
+			cancel()
+		}
+	}()
+	return nil
+}
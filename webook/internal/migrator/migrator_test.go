@@ -0,0 +1,48 @@
+package migrator
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+func newPatternMigrator(t *testing.T, cmd redis.Cmdable) *Migrator {
+	d, err := dao.NewDoubleWriteArticleDao(nil, nil, dao.PatternSrcOnly, logger.NewNopLogger())
+	require.NoError(t, err)
+	return NewMigrator(d, nil, nil, cmd, logger.NewNopLogger())
+}
+
+func TestMigrator_UpdatePattern(t *testing.T) {
+	mr := miniredis.RunT(t)
+	cmd := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+	// 两个实例共享同一个redis
+	m1 := newPatternMigrator(t, cmd)
+	m2 := newPatternMigrator(t, cmd)
+
+	// redis中还没有模式时使用配置的模式
+	m2.syncPattern(ctx)
+	assert.Equal(t, dao.PatternSrcOnly, m2.Pattern())
+
+	assert.Equal(t, dao.ErrUnknownPattern, m1.UpdatePattern(ctx, "unknown"))
+	assert.False(t, mr.Exists(patternKey))
+
+	// 收到请求的实例立即切换，其他实例同步之后切换
+	require.NoError(t, m1.UpdatePattern(ctx, dao.PatternSrcFirst))
+	assert.Equal(t, dao.PatternSrcFirst, m1.Pattern())
+	assert.Equal(t, dao.PatternSrcOnly, m2.Pattern())
+	m2.syncPattern(ctx)
+	assert.Equal(t, dao.PatternSrcFirst, m2.Pattern())
+
+	// redis不可用时保持当前的模式
+	mr.Close()
+	assert.Error(t, m1.UpdatePattern(ctx, dao.PatternDstFirst))
+	assert.Equal(t, dao.PatternSrcFirst, m1.Pattern())
+	m2.syncPattern(ctx)
+	assert.Equal(t, dao.PatternSrcFirst, m2.Pattern())
+}
@@ -0,0 +1,189 @@
+package migrator
+
+import (
+	"context"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// Verifier 逐个字段比较base和target中的文章，不一致时产生修复事件
+type Verifier struct {
+	base     dao.ArticleMigrationDao
+	target   dao.ArticleMigrationDao
+	baseName string
+	producer migration.Producer
+	l        logger.LoggerV2
+
+	batchSize int
+	// 增量校验追上最新的数据后，等待一段时间再继续
+	interval time.Duration
+}
+
+func NewVerifier(base dao.ArticleMigrationDao, target dao.ArticleMigrationDao, baseName string,
+	producer migration.Producer, l logger.LoggerV2) *Verifier {
+	return &Verifier{
+		base:      base,
+		target:    target,
+		baseName:  baseName,
+		producer:  producer,
+		l:         l,
+		batchSize: 100,
+		interval:  time.Second,
+	}
+}
+
+// Full 全量校验：从头遍历base，然后反过来遍历target，找出target中多出来的数据
+func (v *Verifier) Full(ctx context.Context) error {
+	for _, table := range dao.MigrationTables {
+		err := v.baseToTarget(ctx, table, 0, false)
+		if err != nil {
+			return err
+		}
+		err = v.targetToBase(ctx, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Incremental 增量校验：校验u_time >= since的数据，追上之后持续校验新的修改，直到ctx被取消。
+// 数据的每次修改都会更新u_time，所以只需要沿着u_time往后遍历。
+func (v *Verifier) Incremental(ctx context.Context, since int64) error {
+	errCh := make(chan error, len(dao.MigrationTables))
+	for _, table := range dao.MigrationTables {
+		go func(table string) {
+			errCh <- v.baseToTarget(ctx, table, since, true)
+		}(table)
+	}
+	var err error
+	for range dao.MigrationTables {
+		if e := <-errCh; e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+func (v *Verifier) baseToTarget(ctx context.Context, table string, since int64, follow bool) error {
+	// since之前的数据不校验，所以从(since-1, MaxInt64)之后开始
+	afterUtime, afterID := since, int64(0)
+	if since > 0 {
+		afterUtime, afterID = since-1, 1<<63-1
+	}
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		dbCtx, cancel := context.WithTimeout(ctx, time.Second*3)
+		arts, err := v.base.ScanForMigration(dbCtx, table, afterUtime, afterID, v.batchSize)
+		if err == nil && len(arts) > 0 {
+			err = v.compare(dbCtx, table, arts)
+		}
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if len(arts) > 0 {
+			last := arts[len(arts)-1]
+			afterUtime, afterID = last.Utime, last.ID
+		}
+		if len(arts) < v.batchSize {
+			if !follow {
+				return nil
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(v.interval):
+			}
+		}
+	}
+}
+
+func (v *Verifier) compare(ctx context.Context, table string, baseArts []dao.Article) error {
+	ids := make([]int64, 0, len(baseArts))
+	for _, art := range baseArts {
+		ids = append(ids, art.ID)
+	}
+	targetArts, err := v.target.FindForMigration(ctx, table, ids)
+	if err != nil {
+		return err
+	}
+	targetMap := make(map[int64]dao.Article, len(targetArts))
+	for _, art := range targetArts {
+		targetMap[art.ID] = art
+	}
+	for _, art := range baseArts {
+		targetArt, ok := targetMap[art.ID]
+		switch {
+		case !ok:
+			v.notify(table, art.ID, "target_missing")
+		case targetArt != art:
+			v.notify(table, art.ID, "not_equal")
+		}
+	}
+	return nil
+}
+
+// targetToBase 找出target中存在，而base中已经不存在的数据
+func (v *Verifier) targetToBase(ctx context.Context, table string) error {
+	var afterUtime, afterID int64
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		dbCtx, cancel := context.WithTimeout(ctx, time.Second*3)
+		arts, err := v.target.ScanForMigration(dbCtx, table, afterUtime, afterID, v.batchSize)
+		var baseArts []dao.Article
+		if err == nil && len(arts) > 0 {
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.ID)
+			}
+			baseArts, err = v.base.FindForMigration(dbCtx, table, ids)
+		}
+		cancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		exists := make(map[int64]struct{}, len(baseArts))
+		for _, art := range baseArts {
+			exists[art.ID] = struct{}{}
+		}
+		for _, art := range arts {
+			if _, ok := exists[art.ID]; !ok {
+				v.notify(table, art.ID, "base_missing")
+			}
+		}
+		if len(arts) < v.batchSize {
+			return nil
+		}
+		last := arts[len(arts)-1]
+		afterUtime, afterID = last.Utime, last.ID
+	}
+}
+
+func (v *Verifier) notify(table string, id int64, reason string) {
+	err := v.producer.ProduceFixEvent(migration.FixEvent{
+		Table:  table,
+		ID:     id,
+		Base:   v.baseName,
+		Reason: reason,
+	})
+	if err != nil {
+		// 发送失败不影响校验，下一次校验还会发现这条数据
+		v.l.Error("发送修复事件失败",
+			logger.String("table", table),
+			logger.Int64("id", id),
+			logger.Error(err))
+	}
+}
@@ -0,0 +1,161 @@
+package migrator
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/event/migration"
+	evtmocks "learn_go/webook/internal/event/migration/mocks"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"sort"
+	"testing"
+)
+
+// memoryMigrationDao 只实现迁移需要的方法
+type memoryMigrationDao struct {
+	dao.ArticleDao
+	tables map[string]map[int64]dao.Article
+}
+
+func newMemoryMigrationDao(arts []dao.Article, pubArts []dao.Article) *memoryMigrationDao {
+	d := &memoryMigrationDao{tables: map[string]map[int64]dao.Article{
+		dao.MigrationTableArticles:  {},
+		dao.MigrationTablePublished: {},
+	}}
+	_ = d.UpsertForMigration(context.Background(), dao.MigrationTableArticles, arts)
+	_ = d.UpsertForMigration(context.Background(), dao.MigrationTablePublished, pubArts)
+	return d
+}
+
+func (d *memoryMigrationDao) ScanForMigration(ctx context.Context, table string, afterUtime int64, afterID int64, limit int) ([]dao.Article, error) {
+	var res []dao.Article
+	for _, art := range d.tables[table] {
+		if art.Utime > afterUtime || (art.Utime == afterUtime && art.ID > afterID) {
+			res = append(res, art)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Utime == res[j].Utime {
+			return res[i].ID < res[j].ID
+		}
+		return res[i].Utime < res[j].Utime
+	})
+	return res[:min(limit, len(res))], nil
+}
+
+func (d *memoryMigrationDao) FindForMigration(ctx context.Context, table string, ids []int64) ([]dao.Article, error) {
+	var res []dao.Article
+	for _, id := range ids {
+		if art, ok := d.tables[table][id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func (d *memoryMigrationDao) UpsertForMigration(ctx context.Context, table string, arts []dao.Article) error {
+	for _, art := range arts {
+		d.tables[table][art.ID] = art
+	}
+	return nil
+}
+
+func (d *memoryMigrationDao) DeleteForMigration(ctx context.Context, table string, ids []int64) error {
+	for _, id := range ids {
+		delete(d.tables[table], id)
+	}
+	return nil
+}
+
+func TestVerifier(t *testing.T) {
+	same := dao.Article{ID: 1, Title: "title", AuthorID: 1, Status: 2, Ctime: 100, Utime: 100}
+	changed := dao.Article{ID: 2, Title: "new title", AuthorID: 1, Status: 2, Ctime: 100, Utime: 300}
+	missing := dao.Article{ID: 3, Title: "title", AuthorID: 1, Status: 1, Ctime: 100, Utime: 200}
+	extra := dao.Article{ID: 4, Title: "title", AuthorID: 1, Status: 1, Ctime: 100, Utime: 200}
+	staleChanged := changed
+	staleChanged.Title = "title"
+	staleChanged.Utime = 150
+
+	testCases := []struct {
+		name        string
+		incremental bool
+		since       int64
+		wantEvents  []migration.FixEvent
+	}{
+		{
+			name: "全量校验",
+			wantEvents: []migration.FixEvent{
+				{Table: dao.MigrationTableArticles, ID: 3, Base: migration.BaseSrc, Reason: "target_missing"},
+				{Table: dao.MigrationTableArticles, ID: 2, Base: migration.BaseSrc, Reason: "not_equal"},
+				{Table: dao.MigrationTableArticles, ID: 4, Base: migration.BaseSrc, Reason: "base_missing"},
+				{Table: dao.MigrationTablePublished, ID: 2, Base: migration.BaseSrc, Reason: "not_equal"},
+			},
+		},
+		{
+			// 只校验u_time >= 250的数据，不会发现更早的数据不一致，也不会反向校验
+			name:        "增量校验",
+			incremental: true,
+			since:       250,
+			wantEvents: []migration.FixEvent{
+				{Table: dao.MigrationTableArticles, ID: 2, Base: migration.BaseSrc, Reason: "not_equal"},
+				{Table: dao.MigrationTablePublished, ID: 2, Base: migration.BaseSrc, Reason: "not_equal"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			src := newMemoryMigrationDao([]dao.Article{same, changed, missing}, []dao.Article{same, changed})
+			dst := newMemoryMigrationDao([]dao.Article{same, staleChanged, extra}, []dao.Article{same, staleChanged})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var events []migration.FixEvent
+			producer := evtmocks.NewMockProducer(ctrl)
+			producer.EXPECT().ProduceFixEvent(gomock.Any()).DoAndReturn(func(evt migration.FixEvent) error {
+				events = append(events, evt)
+				// 增量校验收到全部事件后停止
+				if tc.incremental && len(events) == len(tc.wantEvents) {
+					cancel()
+				}
+				return nil
+			}).Times(len(tc.wantEvents))
+
+			verifier := NewVerifier(src, dst, migration.BaseSrc, producer, logger.NewNopLogger())
+			var err error
+			if tc.incremental {
+				// 两张表串行校验，保证事件的顺序稳定
+				verifier.interval = 0
+				err = verifier.baseToTarget(ctx, dao.MigrationTableArticles, tc.since, false)
+				require.NoError(t, err)
+				err = verifier.baseToTarget(ctx, dao.MigrationTablePublished, tc.since, true)
+			} else {
+				err = verifier.Full(ctx)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantEvents, events)
+		})
+	}
+}
+
+func TestFullCopier(t *testing.T) {
+	arts := []dao.Article{
+		{ID: 1, Title: "a", Ctime: 100, Utime: 100},
+		{ID: 2, Title: "b", Ctime: 100, Utime: 100},
+		{ID: 3, Title: "c", Ctime: 100, Utime: 50},
+	}
+	src := newMemoryMigrationDao(arts, arts[:1])
+	dst := newMemoryMigrationDao(nil, nil)
+
+	copier := NewFullCopier(src, dst, logger.NewNopLogger())
+	// 每批两条，覆盖分批的逻辑
+	copier.batchSize = 2
+	err := copier.Copy(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, src.tables, dst.tables)
+}
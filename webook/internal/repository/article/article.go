@@ -11,10 +11,20 @@ import (
 	"time"
 )
 
+var (
+	// ErrVersionConflict 草稿已经被其他请求修改过了
+	ErrVersionConflict = dao.ErrVersionConflict
+	// ErrStatusConflict 文章的状态已经不是article.FromStatus
+	ErrStatusConflict = dao.ErrStatusConflict
+)
+
 type ArticleRepository interface {
 	Create(ctx context.Context, article domain.Article) (int64, error)
+	// Update 更新草稿，article.Version大于0且和当前的版本号不一致时返回ErrVersionConflict，
+	// article.FromStatus不是ArticleStatusUnknown且和当前的状态不一致时返回ErrStatusConflict
 	Update(ctx context.Context, article domain.Article) error
 
+	// Sync 同步草稿和线上库，版本号、状态的校验和Update相同
 	Sync(ctx context.Context, article domain.Article) (int64, error)
 
 	SyncStatus(ctx context.Context, id int64, authorID int64, status int8) error
@@ -22,10 +32,50 @@ type ArticleRepository interface {
 	// SyncV1 在repository层同步数据
 	SyncV1(ctx context.Context, article domain.Article) (int64, error)
 
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// GetByAuthor 按照(utime, id)倒序查询作者的文章，cursor为零值时查询第一页
+	GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error)
+	// ListIDsByAuthor 按照id倒序查询作者最新的limit篇文章的id，不包括回收站中的文章
+	ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
-	ListPub(ctx context.Context, t time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPub 按照(utime, id)倒序查询已发布的文章
+	ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
 	GetPubByID(ctx context.Context, id int64) (domain.Article, error)
+	// GetPubByIDs 批量查询线上库的文章，不走缓存，不包括内容和作者的名字，不存在的文章不返回
+	GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error)
+	// CachePub 缓存已发布的文章，用于缓存读取时渲染的结果
+	CachePub(ctx context.Context, article domain.Article) error
+	// ScanPub 按照id升序遍历线上库，不走缓存
+	ScanPub(ctx context.Context, startID int64, limit int) ([]domain.Article, error)
+
+	// ListDueScheduled 查询到期的定时发布文章
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error)
+	// ClaimScheduled 抢占一篇到期的定时发布文章，租约在now+lease到期。返回false说明已经被其他实例抢占
+	ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error)
+	// ReleaseScheduled 清除抢占的租约，发布失败时等待下一次调度
+	ReleaseScheduled(ctx context.Context, id int64) error
+	CancelScheduled(ctx context.Context, id int64, authorID int64) error
+
+	// Delete 将文章移入回收站
+	Delete(ctx context.Context, id int64, authorID int64) error
+	// ListTrash 按照删除时间倒序查询作者回收站中的文章
+	ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]domain.Article, error)
+	// Restore 将回收站中的文章恢复成草稿
+	Restore(ctx context.Context, id int64, authorID int64) error
+	// Purge 彻底删除回收站中的文章，文章不在回收站中时返回ErrArticleNotInTrash
+	Purge(ctx context.Context, id int64, authorID int64) error
+	// ListExpiredTrash 查询在before之前移入回收站的文章
+	ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error)
+
+	// Autosave 将自动保存的快照写入缓冲区，由定时任务写入制作库
+	Autosave(ctx context.Context, save domain.ArticleAutosave) error
+	// GetAutosave 查询用户在文章上最新的快照，没有时返回ErrAutosaveNotFound
+	GetAutosave(ctx context.Context, uid int64, articleID int64) (domain.ArticleAutosave, error)
+	// ListDueAutosaves 查询到期需要写入制作库的快照
+	ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error)
+	// AckAutosave 快照写入制作库之后调用，save.FlushedVersion为0表示放弃写入
+	AckAutosave(ctx context.Context, save domain.ArticleAutosave) error
+	// DelAutosave 删除用户在文章上的快照
+	DelAutosave(ctx context.Context, uid int64, articleID int64) error
 }
 
 func NewArticleRepository(articleDao dao.ArticleDao, articleCache cache.ArticleCache, userRepo repository.UserRepository, log logger.LoggerV2) ArticleRepository {
@@ -69,22 +119,27 @@ func (repo *articleRepository) GetPubByID(ctx context.Context, id int64) (domain
 	article := repo.toDomain(dao.Article(pubArt))
 	article.Author.Name = user.Nickname
 
-	// 3. 重新载入缓存
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+	// 3. 数据库中只有Markdown，由service渲染之后通过CachePub重新载入缓存
+	return article, nil
+}
 
-		err := repo.articleCache.SetPub(ctx, article)
-		if err != nil {
-			// 记录日志
-		}
-	}()
+func (repo *articleRepository) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	arts, err := repo.articleDao.GetPubByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishArticle) domain.Article {
+		return repo.toDomain(dao.Article(src))
+	}), nil
+}
 
-	return article, nil
+func (repo *articleRepository) CachePub(ctx context.Context, article domain.Article) error {
+	return repo.articleCache.SetPub(ctx, article)
 }
 
-func (repo *articleRepository) ListPub(ctx context.Context, t time.Time, offset int, limit int) ([]domain.Article, error) {
-	arts, err := repo.articleDao.ListPub(ctx, t, offset, limit)
+func (repo *articleRepository) ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	beforeUtime, beforeID := repo.keyset(cursor)
+	arts, err := repo.articleDao.ListPub(ctx, beforeUtime, beforeID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -94,13 +149,28 @@ func (repo *articleRepository) ListPub(ctx context.Context, t time.Time, offset
 	return newArts, nil
 }
 
+func (repo *articleRepository) ScanPub(ctx context.Context, startID int64, limit int) ([]domain.Article, error) {
+	arts, err := repo.articleDao.ScanPub(ctx, startID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishArticle) domain.Article {
+		return repo.toDomain(dao.Article(src))
+	}), nil
+}
+
 // GetByAuthor
 // 如何实现缓存?
 // 因为offset、limit是可变的，这个接口很难做缓存，因此我们已uid作为key，只缓存作者的第一页数据。
 // 什么时候清除缓存?
 // Create、Update、Sync，当作者执行这三个方法时，需要清除缓存。
-func (repo *articleRepository) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	if offset == 0 && limit <= 100 {
+func (repo *articleRepository) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	return repo.articleDao.ListIDsByAuthor(ctx, uid, limit)
+}
+
+func (repo *articleRepository) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	firstPage := cursor.IsZero() && limit <= 100
+	if firstPage {
 		res, err := repo.articleCache.GetFirstPage(ctx, uid)
 		if err == nil {
 			return res, nil
@@ -114,7 +184,8 @@ func (repo *articleRepository) GetByAuthor(ctx context.Context, uid int64, offse
 			//}
 		}
 	}
-	arts, err := repo.articleDao.GetByAuthor(ctx, uid, offset, limit)
+	beforeUtime, beforeID := repo.keyset(cursor)
+	arts, err := repo.articleDao.GetByAuthor(ctx, uid, beforeUtime, beforeID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +197,7 @@ func (repo *articleRepository) GetByAuthor(ctx context.Context, uid int64, offse
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*4)
 		defer cancel()
 
-		if offset == 0 && limit <= 100 {
+		if firstPage {
 			err = repo.articleCache.SetFirstPage(ctx, uid, res)
 			// 记录错误日志并上报
 			if err != nil {
@@ -141,7 +212,7 @@ func (repo *articleRepository) GetByAuthor(ctx context.Context, uid int64, offse
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
 		defer cancel()
-		if offset == 0 && limit <= 100 {
+		if firstPage {
 			repo.preCache(ctx, res)
 		}
 	}()
@@ -274,26 +345,82 @@ func (repo *articleRepository) Update(ctx context.Context, article domain.Articl
 	return err
 }
 
+func (repo *articleRepository) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	arts, err := repo.articleDao.ListDueScheduled(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *articleRepository) ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error) {
+	return repo.articleDao.ClaimScheduled(ctx, id, now, lease)
+}
+
+func (repo *articleRepository) ReleaseScheduled(ctx context.Context, id int64) error {
+	return repo.articleDao.ReleaseScheduled(ctx, id)
+}
+
+func (repo *articleRepository) CancelScheduled(ctx context.Context, id int64, authorID int64) error {
+	err := repo.articleDao.CancelScheduled(ctx, id, authorID)
+	if err == nil {
+		// 文章状态变了，删除作者的列表缓存
+		go func() {
+			err := repo.articleCache.RemoveFirstPage(ctx, authorID)
+			if err != nil {
+				repo.log.Error("从缓存中移除文章列表失败", logger.Error(err))
+			}
+		}()
+	}
+	return err
+}
+
+// keyset 将游标转换为dao层的(utime, id)，零值游标对应(0, 0)
+func (repo *articleRepository) keyset(cursor domain.Cursor) (int64, int64) {
+	if cursor.IsZero() {
+		return 0, 0
+	}
+	return cursor.UTime.UnixMilli(), cursor.ID
+}
+
 func (repo *articleRepository) toDomain(src dao.Article) domain.Article {
-	return domain.Article{
+	art := domain.Article{
 		ID:      src.ID,
 		Title:   src.Title,
 		Content: src.Content,
 		Author: domain.Author{
 			ID: src.AuthorID,
 		},
-		Status: domain.ArticleStatus(src.Status),
-		CTime:  time.UnixMilli(src.Ctime),
-		UTime:  time.UnixMilli(src.Utime),
+		EditorID: src.EditorID,
+		Version:  src.Version,
+		Status:   domain.ArticleStatus(src.Status),
+		CTime:    time.UnixMilli(src.Ctime),
+		UTime:    time.UnixMilli(src.Utime),
 	}
+	if src.PublishAt > 0 {
+		art.PublishAt = time.UnixMilli(src.PublishAt)
+	}
+	if src.Dtime > 0 {
+		art.DTime = time.UnixMilli(src.Dtime)
+	}
+	return art
 }
 
 func (repo *articleRepository) toEntity(article domain.Article) dao.Article {
-	return dao.Article{
-		Title:    article.Title,
-		Content:  article.Content,
-		ID:       article.ID,
-		AuthorID: article.Author.ID,
-		Status:   article.Status.ToInt8(),
+	entity := dao.Article{
+		Title:      article.Title,
+		Content:    article.Content,
+		ID:         article.ID,
+		AuthorID:   article.Author.ID,
+		EditorID:   article.EditorID,
+		Version:    article.Version,
+		Status:     article.Status.ToInt8(),
+		FromStatus: article.FromStatus.ToInt8(),
+	}
+	if !article.PublishAt.IsZero() {
+		entity.PublishAt = article.PublishAt.UnixMilli()
 	}
+	return entity
 }
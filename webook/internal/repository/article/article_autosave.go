@@ -0,0 +1,36 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/cache"
+	"time"
+)
+
+// ErrAutosaveNotFound 没有自动保存的快照，或者快照已经过期
+var ErrAutosaveNotFound = errors.New("autosave not found")
+
+func (repo *articleRepository) Autosave(ctx context.Context, save domain.ArticleAutosave) error {
+	return repo.articleCache.SetAutosave(ctx, save)
+}
+
+func (repo *articleRepository) GetAutosave(ctx context.Context, uid int64, articleID int64) (domain.ArticleAutosave, error) {
+	save, err := repo.articleCache.GetAutosave(ctx, uid, articleID)
+	if err == cache.ErrKeyNotExist {
+		return domain.ArticleAutosave{}, ErrAutosaveNotFound
+	}
+	return save, err
+}
+
+func (repo *articleRepository) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	return repo.articleCache.ListDueAutosaves(ctx, now, limit)
+}
+
+func (repo *articleRepository) AckAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	return repo.articleCache.AckAutosave(ctx, save)
+}
+
+func (repo *articleRepository) DelAutosave(ctx context.Context, uid int64, articleID int64) error {
+	return repo.articleCache.DelAutosave(ctx, uid, articleID)
+}
@@ -0,0 +1,76 @@
+package article
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+type RevisionRepository interface {
+	Create(ctx context.Context, revision domain.ArticleRevision) (int64, error)
+	List(ctx context.Context, articleID int64, authorID int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	GetByID(ctx context.Context, articleID int64, authorID int64, id int64) (domain.ArticleRevision, error)
+	// DeleteByArticle 删除文章的全部版本记录
+	DeleteByArticle(ctx context.Context, articleID int64, authorID int64) error
+}
+
+type articleRevisionRepository struct {
+	dao dao.ArticleRevisionDao
+}
+
+func NewRevisionRepository(dao dao.ArticleRevisionDao) RevisionRepository {
+	return &articleRevisionRepository{
+		dao: dao,
+	}
+}
+
+func (repo *articleRevisionRepository) Create(ctx context.Context, revision domain.ArticleRevision) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(revision))
+}
+
+func (repo *articleRevisionRepository) List(ctx context.Context, articleID int64, authorID int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	revisions, err := repo.dao.ListByArticle(ctx, articleID, authorID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(revisions, func(idx int, src dao.ArticleRevision) domain.ArticleRevision {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *articleRevisionRepository) GetByID(ctx context.Context, articleID int64, authorID int64, id int64) (domain.ArticleRevision, error) {
+	revision, err := repo.dao.GetByID(ctx, articleID, authorID, id)
+	if err != nil {
+		return domain.ArticleRevision{}, err
+	}
+	return repo.toDomain(revision), nil
+}
+
+func (repo *articleRevisionRepository) toDomain(src dao.ArticleRevision) domain.ArticleRevision {
+	return domain.ArticleRevision{
+		ID:        src.ID,
+		ArticleID: src.ArticleID,
+		AuthorID:  src.AuthorID,
+		Title:     src.Title,
+		Content:   src.Content,
+		Status:    domain.ArticleStatus(src.Status),
+		CTime:     time.UnixMilli(src.Ctime),
+	}
+}
+
+func (repo *articleRevisionRepository) toEntity(revision domain.ArticleRevision) dao.ArticleRevision {
+	return dao.ArticleRevision{
+		ID:        revision.ID,
+		ArticleID: revision.ArticleID,
+		AuthorID:  revision.AuthorID,
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Status:    revision.Status.ToInt8(),
+	}
+}
+
+func (repo *articleRevisionRepository) DeleteByArticle(ctx context.Context, articleID int64, authorID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID, authorID)
+}
@@ -0,0 +1,86 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+var (
+	// ErrArticleNotFound 文章不存在、不属于该作者或者已经在回收站中
+	ErrArticleNotFound   = errors.New("article not found")
+	ErrArticleNotInTrash = errors.New("article not in trash")
+)
+
+func (repo *articleRepository) Delete(ctx context.Context, id int64, authorID int64) error {
+	err := repo.articleDao.SoftDelete(ctx, id, authorID, time.Now())
+	if err == dao.ErrNotFound {
+		return ErrArticleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	// 线上库中的文章已经删除，缓存中的文章详情、作者的列表都要删除
+	repo.evict(ctx, id, authorID)
+	return nil
+}
+
+func (repo *articleRepository) ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := repo.articleDao.ListTrash(ctx, authorID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *articleRepository) Restore(ctx context.Context, id int64, authorID int64) error {
+	err := repo.articleDao.Restore(ctx, id, authorID)
+	if err == dao.ErrNotFound {
+		return ErrArticleNotInTrash
+	}
+	if err != nil {
+		return err
+	}
+	repo.evict(ctx, id, authorID)
+	return nil
+}
+
+func (repo *articleRepository) Purge(ctx context.Context, id int64, authorID int64) error {
+	err := repo.articleDao.Purge(ctx, id, authorID)
+	if err == dao.ErrNotFound {
+		return ErrArticleNotInTrash
+	}
+	if err != nil {
+		return err
+	}
+	repo.evict(ctx, id, authorID)
+	return nil
+}
+
+func (repo *articleRepository) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	arts, err := repo.articleDao.ListExpiredTrash(ctx, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.Article) domain.Article {
+		return repo.toDomain(src)
+	}), nil
+}
+
+// evict 删除文章的缓存，失败只记录日志，缓存会在过期后失效
+func (repo *articleRepository) evict(ctx context.Context, id int64, authorID int64) {
+	err := repo.articleCache.Del(ctx, id)
+	if err != nil {
+		repo.log.Error("删除文章缓存失败", logger.Int64("article id", id), logger.Error(err))
+	}
+	err = repo.articleCache.RemoveFirstPage(ctx, authorID)
+	if err != nil {
+		repo.log.Error("从缓存中移除文章列表失败", logger.Error(err))
+	}
+}
@@ -0,0 +1,144 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+var (
+	// ErrCollaboratorNotFound 用户不是文章的协作者，或者没有待接受的邀请
+	ErrCollaboratorNotFound = errors.New("collaborator not found")
+)
+
+type CollaboratorRepository interface {
+	// Invite 邀请用户成为协作者，已经是协作者时修改角色
+	Invite(ctx context.Context, c domain.Collaborator) error
+	Get(ctx context.Context, articleID int64, uid int64) (domain.Collaborator, error)
+	// List 查询文章的全部协作者，包括还没有接受邀请的
+	List(ctx context.Context, articleID int64) ([]domain.Collaborator, error)
+	// ListInvitations 按照邀请时间倒序查询用户待接受的邀请
+	ListInvitations(ctx context.Context, uid int64, offset int, limit int) ([]domain.Collaborator, error)
+	Accept(ctx context.Context, articleID int64, uid int64) error
+	// Remove 拒绝邀请、移除协作者
+	Remove(ctx context.Context, articleID int64, uid int64) error
+	// DeleteByArticle 删除文章的全部协作者
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type collaboratorRepository struct {
+	dao      dao.ArticleCollaboratorDao
+	userRepo repository.UserRepository
+}
+
+func NewCollaboratorRepository(dao dao.ArticleCollaboratorDao, userRepo repository.UserRepository) CollaboratorRepository {
+	return &collaboratorRepository{
+		dao:      dao,
+		userRepo: userRepo,
+	}
+}
+
+func (repo *collaboratorRepository) Invite(ctx context.Context, c domain.Collaborator) error {
+	return repo.dao.Upsert(ctx, repo.toEntity(c))
+}
+
+func (repo *collaboratorRepository) Get(ctx context.Context, articleID int64, uid int64) (domain.Collaborator, error) {
+	c, err := repo.dao.Get(ctx, articleID, uid)
+	if err == dao.ErrNotFound {
+		return domain.Collaborator{}, ErrCollaboratorNotFound
+	}
+	if err != nil {
+		return domain.Collaborator{}, err
+	}
+	return repo.toDomain(c), nil
+}
+
+func (repo *collaboratorRepository) List(ctx context.Context, articleID int64) ([]domain.Collaborator, error) {
+	cs, err := repo.dao.ListByArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map(cs, func(idx int, src dao.ArticleCollaborator) domain.Collaborator {
+		return repo.toDomain(src)
+	})
+	repo.fillNames(ctx, res)
+	return res, nil
+}
+
+func (repo *collaboratorRepository) ListInvitations(ctx context.Context, uid int64, offset int, limit int) ([]domain.Collaborator, error) {
+	cs, err := repo.dao.ListByUser(ctx, uid, dao.CollaboratorStatusPending, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	res := slice.Map(cs, func(idx int, src dao.ArticleCollaborator) domain.Collaborator {
+		return repo.toDomain(src)
+	})
+	repo.fillNames(ctx, res)
+	return res, nil
+}
+
+func (repo *collaboratorRepository) Accept(ctx context.Context, articleID int64, uid int64) error {
+	err := repo.dao.Accept(ctx, articleID, uid)
+	if err == dao.ErrNotFound {
+		return ErrCollaboratorNotFound
+	}
+	return err
+}
+
+func (repo *collaboratorRepository) Remove(ctx context.Context, articleID int64, uid int64) error {
+	err := repo.dao.Delete(ctx, articleID, uid)
+	if err == dao.ErrNotFound {
+		return ErrCollaboratorNotFound
+	}
+	return err
+}
+
+func (repo *collaboratorRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
+
+// fillNames 批量填充协作者的昵称，查询失败时只保留id
+func (repo *collaboratorRepository) fillNames(ctx context.Context, cs []domain.Collaborator) {
+	if len(cs) == 0 {
+		return
+	}
+	uids := slice.Map(cs, func(idx int, src domain.Collaborator) int64 {
+		return src.User.ID
+	})
+	users, err := repo.userRepo.FindByIDs(ctx, uids)
+	if err != nil {
+		return
+	}
+	for i := range cs {
+		cs[i].User.Name = users[cs[i].User.ID].Nickname
+	}
+}
+
+func (repo *collaboratorRepository) toDomain(src dao.ArticleCollaborator) domain.Collaborator {
+	return domain.Collaborator{
+		ArticleID: src.ArticleID,
+		User: domain.Author{
+			ID:   src.Uid,
+			Role: domain.ArticleRole(src.Role),
+		},
+		Role:      domain.ArticleRole(src.Role),
+		Status:    domain.CollaboratorStatus(src.Status),
+		InviterID: src.InviterID,
+		CTime:     time.UnixMilli(src.Ctime),
+		UTime:     time.UnixMilli(src.Utime),
+	}
+}
+
+func (repo *collaboratorRepository) toEntity(c domain.Collaborator) dao.ArticleCollaborator {
+	return dao.ArticleCollaborator{
+		ArticleID: c.ArticleID,
+		Uid:       c.User.ID,
+		Role:      c.Role.ToInt8(),
+		Status:    c.Status.ToInt8(),
+		InviterID: c.InviterID,
+	}
+}
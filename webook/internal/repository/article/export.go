@@ -0,0 +1,111 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+var ErrExportNotFound = errors.New("export not found")
+
+type ExportRepository interface {
+	Create(ctx context.Context, e domain.ArticleExport) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.ArticleExport, error)
+	// ListRunnable 查询等待执行的任务，以及在staleBefore之前开始执行但是还没有结束的任务
+	ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]domain.ArticleExport, error)
+	// Claim 抢占任务，返回false说明已经被其他实例抢占了
+	Claim(ctx context.Context, id int64, staleBefore time.Time) (bool, error)
+	// Finish 写入执行的结果
+	Finish(ctx context.Context, e domain.ArticleExport) error
+	// ListExpired 查询在before之前结束的任务
+	ListExpired(ctx context.Context, before time.Time, limit int) ([]domain.ArticleExport, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type exportRepository struct {
+	dao dao.ArticleExportDao
+}
+
+func NewExportRepository(dao dao.ArticleExportDao) ExportRepository {
+	return &exportRepository{
+		dao: dao,
+	}
+}
+
+func (repo *exportRepository) Create(ctx context.Context, e domain.ArticleExport) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(e))
+}
+
+func (repo *exportRepository) GetByID(ctx context.Context, id int64) (domain.ArticleExport, error) {
+	e, err := repo.dao.GetByID(ctx, id)
+	if err == dao.ErrNotFound {
+		return domain.ArticleExport{}, ErrExportNotFound
+	}
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	return repo.toDomain(e), nil
+}
+
+func (repo *exportRepository) ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]domain.ArticleExport, error) {
+	res, err := repo.dao.ListRunnable(ctx, staleBefore.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.ArticleExport) domain.ArticleExport {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *exportRepository) Claim(ctx context.Context, id int64, staleBefore time.Time) (bool, error) {
+	return repo.dao.Claim(ctx, id, staleBefore.UnixMilli())
+}
+
+func (repo *exportRepository) Finish(ctx context.Context, e domain.ArticleExport) error {
+	return repo.dao.Finish(ctx, repo.toEntity(e))
+}
+
+func (repo *exportRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]domain.ArticleExport, error) {
+	res, err := repo.dao.ListExpired(ctx, before.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.ArticleExport) domain.ArticleExport {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *exportRepository) Delete(ctx context.Context, id int64) error {
+	return repo.dao.Delete(ctx, id)
+}
+
+func (repo *exportRepository) toEntity(e domain.ArticleExport) dao.ArticleExport {
+	return dao.ArticleExport{
+		ID:        e.ID,
+		Uid:       e.Uid,
+		Format:    string(e.Format),
+		Status:    e.Status.ToInt8(),
+		ObjectKey: e.Key,
+		Count:     e.Count,
+		Size:      e.Size,
+		ErrMsg:    e.Error,
+	}
+}
+
+func (repo *exportRepository) toDomain(e dao.ArticleExport) domain.ArticleExport {
+	return domain.ArticleExport{
+		ID:     e.ID,
+		Uid:    e.Uid,
+		Format: domain.ExportFormat(e.Format),
+		Status: domain.ExportStatus(e.Status),
+		Key:    e.ObjectKey,
+		Count:  e.Count,
+		Size:   e.Size,
+		Error:  e.ErrMsg,
+		CTime:  time.UnixMilli(e.Ctime),
+		UTime:  time.UnixMilli(e.Utime),
+	}
+}
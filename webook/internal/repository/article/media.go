@@ -0,0 +1,121 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+var ErrMediaNotFound = errors.New("media not found")
+
+type MediaRepository interface {
+	Create(ctx context.Context, m domain.Media) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.Media, error)
+	ListByArticle(ctx context.Context, articleID int64) ([]domain.Media, error)
+	CountByArticle(ctx context.Context, articleID int64) (int64, error)
+	// Delete 删除文件，对象存储中的文件由定时任务删除
+	Delete(ctx context.Context, id int64) error
+	// DeleteByArticle 删除文章全部的文件
+	DeleteByArticle(ctx context.Context, articleID int64) error
+	// ListDeleted 查询已经删除，但是对象存储中的文件还没有删除的记录
+	ListDeleted(ctx context.Context, limit int) ([]domain.Media, error)
+	// Remove 对象存储中的文件删除后，删除记录
+	Remove(ctx context.Context, id int64) error
+}
+
+type mediaRepository struct {
+	dao dao.MediaDao
+}
+
+func NewMediaRepository(dao dao.MediaDao) MediaRepository {
+	return &mediaRepository{
+		dao: dao,
+	}
+}
+
+func (repo *mediaRepository) Create(ctx context.Context, m domain.Media) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(m))
+}
+
+func (repo *mediaRepository) GetByID(ctx context.Context, id int64) (domain.Media, error) {
+	m, err := repo.dao.GetByID(ctx, id)
+	if err == dao.ErrNotFound {
+		return domain.Media{}, ErrMediaNotFound
+	}
+	if err != nil {
+		return domain.Media{}, err
+	}
+	return repo.toDomain(m), nil
+}
+
+func (repo *mediaRepository) ListByArticle(ctx context.Context, articleID int64) ([]domain.Media, error) {
+	res, err := repo.dao.ListByArticle(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Media) domain.Media {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *mediaRepository) CountByArticle(ctx context.Context, articleID int64) (int64, error) {
+	return repo.dao.CountByArticle(ctx, articleID)
+}
+
+func (repo *mediaRepository) Delete(ctx context.Context, id int64) error {
+	return repo.dao.Delete(ctx, id)
+}
+
+func (repo *mediaRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
+
+func (repo *mediaRepository) ListDeleted(ctx context.Context, limit int) ([]domain.Media, error) {
+	res, err := repo.dao.ListDeleted(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Media) domain.Media {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *mediaRepository) Remove(ctx context.Context, id int64) error {
+	return repo.dao.Remove(ctx, id)
+}
+
+func (repo *mediaRepository) toEntity(m domain.Media) dao.Media {
+	return dao.Media{
+		ID:          m.ID,
+		Uid:         m.Uid,
+		ArticleID:   m.ArticleID,
+		Kind:        m.Kind.ToInt8(),
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		ObjectKey:   m.Key,
+		ThumbKey:    m.ThumbKey,
+		Width:       m.Width,
+		Height:      m.Height,
+	}
+}
+
+func (repo *mediaRepository) toDomain(m dao.Media) domain.Media {
+	return domain.Media{
+		ID:          m.ID,
+		Uid:         m.Uid,
+		ArticleID:   m.ArticleID,
+		Kind:        domain.MediaKind(m.Kind),
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Key:         m.ObjectKey,
+		ThumbKey:    m.ThumbKey,
+		Width:       m.Width,
+		Height:      m.Height,
+		CTime:       time.UnixMilli(m.Ctime),
+	}
+}
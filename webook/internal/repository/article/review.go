@@ -0,0 +1,118 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+var (
+	ErrReviewNotFound = errors.New("review not found")
+	// ErrReviewDecided 审核记录已经被处理过了，可能是其他审核人员
+	ErrReviewDecided = errors.New("review already decided")
+)
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review domain.ArticleReview) (int64, error)
+	GetByID(ctx context.Context, id int64) (domain.ArticleReview, error)
+	// ListPending 按照提交的先后顺序查询待审核的记录
+	ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error)
+	// ListByArticle 按照提交时间倒序查询文章的审核记录
+	ListByArticle(ctx context.Context, articleID int64, offset int, limit int) ([]domain.ArticleReview, error)
+	// Decide 写入审核结果，已经被处理过时返回ErrReviewDecided
+	Decide(ctx context.Context, review domain.ArticleReview) error
+	// Reopen 审核通过、驳回后更新文章失败，恢复成待审核
+	Reopen(ctx context.Context, id int64) error
+	// CancelPending 取消文章全部待审核的记录
+	CancelPending(ctx context.Context, articleID int64) error
+	// DeleteByArticle 删除文章的全部审核记录
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type reviewRepository struct {
+	dao dao.ArticleReviewDao
+}
+
+func NewReviewRepository(dao dao.ArticleReviewDao) ReviewRepository {
+	return &reviewRepository{
+		dao: dao,
+	}
+}
+
+func (repo *reviewRepository) Create(ctx context.Context, review domain.ArticleReview) (int64, error) {
+	return repo.dao.Insert(ctx, dao.ArticleReview{
+		ArticleID:   review.ArticleID,
+		AuthorID:    review.AuthorID,
+		SubmitterID: review.SubmitterID,
+		Title:       review.Title,
+	})
+}
+
+func (repo *reviewRepository) GetByID(ctx context.Context, id int64) (domain.ArticleReview, error) {
+	review, err := repo.dao.GetByID(ctx, id)
+	if err == dao.ErrNotFound {
+		return domain.ArticleReview{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return domain.ArticleReview{}, err
+	}
+	return repo.toDomain(review), nil
+}
+
+func (repo *reviewRepository) ListPending(ctx context.Context, offset int, limit int) ([]domain.ArticleReview, error) {
+	reviews, err := repo.dao.ListPending(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(reviews, func(idx int, src dao.ArticleReview) domain.ArticleReview {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *reviewRepository) ListByArticle(ctx context.Context, articleID int64, offset int, limit int) ([]domain.ArticleReview, error) {
+	reviews, err := repo.dao.ListByArticle(ctx, articleID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(reviews, func(idx int, src dao.ArticleReview) domain.ArticleReview {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *reviewRepository) Decide(ctx context.Context, review domain.ArticleReview) error {
+	err := repo.dao.Decide(ctx, review.ID, review.ReviewerID, review.Status.ToInt8(), review.Comment)
+	if err == dao.ErrNotFound {
+		return ErrReviewDecided
+	}
+	return err
+}
+
+func (repo *reviewRepository) Reopen(ctx context.Context, id int64) error {
+	return repo.dao.Reopen(ctx, id)
+}
+
+func (repo *reviewRepository) CancelPending(ctx context.Context, articleID int64) error {
+	return repo.dao.CancelPending(ctx, articleID)
+}
+
+func (repo *reviewRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
+
+func (repo *reviewRepository) toDomain(src dao.ArticleReview) domain.ArticleReview {
+	return domain.ArticleReview{
+		ID:          src.ID,
+		ArticleID:   src.ArticleID,
+		AuthorID:    src.AuthorID,
+		SubmitterID: src.SubmitterID,
+		Title:       src.Title,
+		ReviewerID:  src.ReviewerID,
+		Status:      domain.ReviewStatus(src.Status),
+		Comment:     src.Comment,
+		CTime:       time.UnixMilli(src.Ctime),
+		UTime:       time.UnixMilli(src.Utime),
+	}
+}
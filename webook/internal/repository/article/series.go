@@ -0,0 +1,134 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+var (
+	ErrSeriesNotFound = errors.New("series not found")
+	// ErrSeriesArticleDuplicate 文章已经在某个系列中了
+	ErrSeriesArticleDuplicate = dao.ErrSeriesArticleDuplicate
+	// ErrSeriesArticleMismatch 调整顺序时传入的文章和系列中的文章不一致
+	ErrSeriesArticleMismatch = dao.ErrSeriesArticleMismatch
+)
+
+// SeriesRepository 返回的Series.Articles只有文章的id
+type SeriesRepository interface {
+	Create(ctx context.Context, s domain.Series) (int64, error)
+	// Update 修改标题和简介，系列不属于s.Author时返回ErrSeriesNotFound
+	Update(ctx context.Context, s domain.Series) error
+	GetByID(ctx context.Context, id int64) (domain.Series, error)
+	// GetByArticle 查询文章所属的系列，文章不属于任何系列时返回ErrSeriesNotFound
+	GetByArticle(ctx context.Context, articleID int64) (domain.Series, error)
+	// ListByAuthor 按照修改时间倒序查询作者的系列
+	ListByAuthor(ctx context.Context, authorID int64, offset int, limit int) ([]domain.Series, error)
+	Delete(ctx context.Context, id int64, authorID int64) error
+
+	// AddArticle 将文章追加到系列的末尾
+	AddArticle(ctx context.Context, id int64, authorID int64, articleID int64) error
+	// RemoveArticle 从系列中移除文章，文章不在系列中时返回ErrSeriesNotFound
+	RemoveArticle(ctx context.Context, id int64, authorID int64, articleID int64) error
+	// Reorder 按照articleIDs的顺序重新排列系列中的文章
+	Reorder(ctx context.Context, id int64, authorID int64, articleIDs []int64) error
+	// DeleteByArticle 从所属的系列中移除文章
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type seriesRepository struct {
+	dao dao.SeriesDao
+}
+
+func NewSeriesRepository(dao dao.SeriesDao) SeriesRepository {
+	return &seriesRepository{
+		dao: dao,
+	}
+}
+
+func (repo *seriesRepository) Create(ctx context.Context, s domain.Series) (int64, error) {
+	return repo.dao.Insert(ctx, repo.toEntity(s))
+}
+
+func (repo *seriesRepository) Update(ctx context.Context, s domain.Series) error {
+	return repo.notFound(repo.dao.Update(ctx, repo.toEntity(s)))
+}
+
+func (repo *seriesRepository) GetByID(ctx context.Context, id int64) (domain.Series, error) {
+	s, err := repo.dao.GetByID(ctx, id)
+	if err != nil {
+		return domain.Series{}, repo.notFound(err)
+	}
+	return repo.toDomain(s), nil
+}
+
+func (repo *seriesRepository) GetByArticle(ctx context.Context, articleID int64) (domain.Series, error) {
+	s, err := repo.dao.GetByArticle(ctx, articleID)
+	if err != nil {
+		return domain.Series{}, repo.notFound(err)
+	}
+	return repo.toDomain(s), nil
+}
+
+func (repo *seriesRepository) ListByAuthor(ctx context.Context, authorID int64, offset int, limit int) ([]domain.Series, error) {
+	res, err := repo.dao.ListByAuthor(ctx, authorID, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.Series) domain.Series {
+		return repo.toDomain(src)
+	}), nil
+}
+
+func (repo *seriesRepository) Delete(ctx context.Context, id int64, authorID int64) error {
+	return repo.notFound(repo.dao.Delete(ctx, id, authorID))
+}
+
+func (repo *seriesRepository) AddArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	return repo.notFound(repo.dao.AddArticle(ctx, id, authorID, articleID))
+}
+
+func (repo *seriesRepository) RemoveArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	return repo.notFound(repo.dao.RemoveArticle(ctx, id, authorID, articleID))
+}
+
+func (repo *seriesRepository) Reorder(ctx context.Context, id int64, authorID int64, articleIDs []int64) error {
+	return repo.notFound(repo.dao.Reorder(ctx, id, authorID, articleIDs))
+}
+
+func (repo *seriesRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
+
+func (repo *seriesRepository) notFound(err error) error {
+	if err == dao.ErrNotFound {
+		return ErrSeriesNotFound
+	}
+	return err
+}
+
+func (repo *seriesRepository) toEntity(s domain.Series) dao.Series {
+	return dao.Series{
+		ID:          s.ID,
+		AuthorID:    s.Author.ID,
+		Title:       s.Title,
+		Description: s.Description,
+	}
+}
+
+func (repo *seriesRepository) toDomain(s dao.Series) domain.Series {
+	return domain.Series{
+		ID:          s.ID,
+		Author:      domain.Author{ID: s.AuthorID},
+		Title:       s.Title,
+		Description: s.Description,
+		Articles: slice.Map(s.ArticleIDs, func(idx int, src int64) domain.SeriesArticle {
+			return domain.SeriesArticle{ID: src}
+		}),
+		CTime: time.UnixMilli(s.Ctime),
+		UTime: time.UnixMilli(s.Utime),
+	}
+}
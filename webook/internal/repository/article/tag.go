@@ -0,0 +1,100 @@
+package article
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+type TagRepository interface {
+	SetArticleTags(ctx context.Context, articleID int64, tags []domain.Tag) error
+	GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]domain.Tag, error)
+	// ListPubByTag 按照id倒序查询标签下已发布的文章，cursor为上一页最后一篇文章的id
+	ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error)
+	CountPub(ctx context.Context, limit int) ([]domain.TagCount, error)
+	// DeleteByArticle 删除文章的标签
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type tagRepository struct {
+	dao dao.TagDao
+}
+
+func NewTagRepository(dao dao.TagDao) TagRepository {
+	return &tagRepository{
+		dao: dao,
+	}
+}
+
+func (repo *tagRepository) SetArticleTags(ctx context.Context, articleID int64, tags []domain.Tag) error {
+	return repo.dao.SetArticleTags(ctx, articleID, slice.Map(tags, func(idx int, src domain.Tag) dao.Tag {
+		return repo.toEntity(src)
+	}))
+}
+
+func (repo *tagRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]domain.Tag, error) {
+	tags, err := repo.dao.GetByArticleIDs(ctx, articleIDs)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]domain.Tag, len(tags))
+	for id, ts := range tags {
+		res[id] = slice.Map(ts, func(idx int, src dao.Tag) domain.Tag {
+			return repo.toDomain(src)
+		})
+	}
+	return res, nil
+}
+
+func (repo *tagRepository) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error) {
+	arts, err := repo.dao.ListPubByTag(ctx, slug, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(arts, func(idx int, src dao.PublishArticle) domain.Article {
+		return domain.Article{
+			ID:      src.ID,
+			Title:   src.Title,
+			Content: src.Content,
+			Author:  domain.Author{ID: src.AuthorID},
+			Status:  domain.ArticleStatus(src.Status),
+			CTime:   time.UnixMilli(src.Ctime),
+			UTime:   time.UnixMilli(src.Utime),
+		}
+	}), nil
+}
+
+func (repo *tagRepository) CountPub(ctx context.Context, limit int) ([]domain.TagCount, error) {
+	counts, err := repo.dao.CountPub(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(counts, func(idx int, src dao.TagCount) domain.TagCount {
+		return domain.TagCount{
+			Tag:   domain.Tag{Name: src.Name, Slug: src.Slug},
+			Count: src.Count,
+		}
+	}), nil
+}
+
+func (repo *tagRepository) toDomain(src dao.Tag) domain.Tag {
+	return domain.Tag{
+		ID:   src.ID,
+		Name: src.Name,
+		Slug: src.Slug,
+	}
+}
+
+func (repo *tagRepository) toEntity(src domain.Tag) dao.Tag {
+	return dao.Tag{
+		ID:   src.ID,
+		Name: src.Name,
+		Slug: src.Slug,
+	}
+}
+
+func (repo *tagRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
@@ -19,6 +19,19 @@ type ArticleCache interface {
 	Get(ctx context.Context, id int64) (domain.Article, error)
 	GetPub(ctx context.Context, id int64) (domain.Article, error)
 	SetPub(ctx context.Context, article domain.Article) error
+	// Del 删除制作库、线上库的文章详情缓存
+	Del(ctx context.Context, id int64) error
+
+	// SetAutosave 保存自动保存的快照，并推迟写入制作库的时间
+	SetAutosave(ctx context.Context, save domain.ArticleAutosave) error
+	// GetAutosave 查询用户在文章上最新的快照，没有时返回ErrKeyNotExist
+	GetAutosave(ctx context.Context, uid int64, articleID int64) (domain.ArticleAutosave, error)
+	// ListDueAutosaves 按照应该写入的时间查询到期的快照
+	ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error)
+	// AckAutosave 快照写入制作库之后调用，save.FlushedVersion是写入后的版本号，为0表示放弃写入
+	AckAutosave(ctx context.Context, save domain.ArticleAutosave) error
+	// DelAutosave 删除快照，用户主动保存之后快照就没有用了
+	DelAutosave(ctx context.Context, uid int64, articleID int64) error
 }
 
 type articleCache struct {
@@ -110,3 +123,7 @@ func (cache *articleCache) SetFirstPage(ctx context.Context, uid int64, arts []d
 func (cache *articleCache) RemoveFirstPage(ctx context.Context, id int64) error {
 	return cache.cmd.Del(ctx, cache.listKey(id)).Err()
 }
+
+func (cache *articleCache) Del(ctx context.Context, id int64) error {
+	return cache.cmd.Del(ctx, cache.articleKey(id), cache.pubArticleKey(id)).Err()
+}
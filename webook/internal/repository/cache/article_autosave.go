@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"learn_go/webook/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+自动保存的缓冲区。
+
+	每个用户、每篇文章的最新快照保存在一个hash中，等待写入制作库的快照记录在一个有序集合中，score是应该写入的时间。
+	每次自动保存都会推迟写入的时间（防抖），但是最多推迟domain.ArticleAutosaveMaxDelay。
+	写入制作库后通过AckAutosave移出有序集合，写入期间又有新的快照时保留在有序集合中，等待下一次写入。
+*/
+
+var (
+	//go:embed lua/set_autosave.lua
+	setAutosaveScript string
+
+	//go:embed lua/ack_autosave.lua
+	ackAutosaveScript string
+)
+
+const autosaveDirtyKey = "article:autosave:dirty"
+
+func (cache *articleCache) autosaveKey(uid int64, articleID int64) string {
+	return fmt.Sprintf("article:autosave:%d:%d", uid, articleID)
+}
+
+func (cache *articleCache) autosaveMember(uid int64, articleID int64) string {
+	return fmt.Sprintf("%d:%d", uid, articleID)
+}
+
+func (cache *articleCache) SetAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	return cache.cmd.Eval(ctx, setAutosaveScript,
+		[]string{cache.autosaveKey(save.Uid, save.ArticleID), autosaveDirtyKey},
+		cache.autosaveMember(save.Uid, save.ArticleID),
+		save.ArticleID,
+		save.Title,
+		save.Content,
+		save.BaseVersion,
+		save.SavedAt.UnixMilli(),
+		domain.ArticleAutosaveDebounce.Milliseconds(),
+		domain.ArticleAutosaveMaxDelay.Milliseconds(),
+		int64(domain.ArticleAutosaveTTL.Seconds()),
+	).Err()
+}
+
+func (cache *articleCache) GetAutosave(ctx context.Context, uid int64, articleID int64) (domain.ArticleAutosave, error) {
+	vals, err := cache.cmd.HGetAll(ctx, cache.autosaveKey(uid, articleID)).Result()
+	if err != nil {
+		return domain.ArticleAutosave{}, err
+	}
+	if len(vals) == 0 {
+		return domain.ArticleAutosave{}, ErrKeyNotExist
+	}
+	return cache.toAutosave(uid, vals), nil
+}
+
+func (cache *articleCache) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	members, err := cache.cmd.ZRangeByScore(ctx, autosaveDirtyKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	saves := make([]domain.ArticleAutosave, 0, len(members))
+	for _, member := range members {
+		uid, articleID, ok := cache.parseAutosaveMember(member)
+		if !ok {
+			cache.cmd.ZRem(ctx, autosaveDirtyKey, member)
+			continue
+		}
+		save, err := cache.GetAutosave(ctx, uid, articleID)
+		if err == ErrKeyNotExist {
+			// 快照已经过期了
+			cache.cmd.ZRem(ctx, autosaveDirtyKey, member)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		saves = append(saves, save)
+	}
+	return saves, nil
+}
+
+func (cache *articleCache) AckAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	return cache.cmd.Eval(ctx, ackAutosaveScript,
+		[]string{cache.autosaveKey(save.Uid, save.ArticleID), autosaveDirtyKey},
+		cache.autosaveMember(save.Uid, save.ArticleID),
+		save.BaseVersion,
+		save.FlushedVersion,
+		save.SavedAt.UnixMilli(),
+	).Err()
+}
+
+func (cache *articleCache) DelAutosave(ctx context.Context, uid int64, articleID int64) error {
+	pipe := cache.cmd.TxPipeline()
+	pipe.Del(ctx, cache.autosaveKey(uid, articleID))
+	pipe.ZRem(ctx, autosaveDirtyKey, cache.autosaveMember(uid, articleID))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (cache *articleCache) parseAutosaveMember(member string) (int64, int64, bool) {
+	uidStr, idStr, ok := strings.Cut(member, ":")
+	if !ok {
+		return 0, 0, false
+	}
+	uid, err := strconv.ParseInt(uidStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	articleID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uid, articleID, true
+}
+
+func (cache *articleCache) toAutosave(uid int64, vals map[string]string) domain.ArticleAutosave {
+	articleID, _ := strconv.ParseInt(vals["article_id"], 10, 64)
+	baseVersion, _ := strconv.ParseInt(vals["base_version"], 10, 64)
+	flushedVersion, _ := strconv.ParseInt(vals["flushed_version"], 10, 64)
+	savedAt, _ := strconv.ParseInt(vals["saved_at"], 10, 64)
+	return domain.ArticleAutosave{
+		ArticleID:      articleID,
+		Uid:            uid,
+		Title:          vals["title"],
+		Content:        vals["content"],
+		BaseVersion:    baseVersion,
+		FlushedVersion: flushedVersion,
+		SavedAt:        time.UnixMilli(savedAt),
+	}
+}
@@ -0,0 +1,204 @@
+package cache
+
+import (
+	"context"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/internal/domain"
+	"testing"
+	"time"
+)
+
+// newMiniredis lua脚本需要真正执行，使用miniredis而不是mock
+func newMiniredis(t *testing.T) (*miniredis.Miniredis, redis.Cmdable) {
+	mr := miniredis.RunT(t)
+	return mr, redis.NewClient(&redis.Options{Addr: mr.Addr()})
+}
+
+func TestArticleCache_SetAutosave(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	save := domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "title", Content: "content", BaseVersion: 3}
+
+	testCases := []struct {
+		name string
+		// before 之前的快照，按照顺序保存
+		before []domain.ArticleAutosave
+		save   domain.ArticleAutosave
+
+		wantDue         time.Time
+		wantFlushed     int64
+		wantDirtySince  string
+		wantBaseVersion int64
+	}{
+		{
+			name: "第一次保存，debounce之后写入",
+			save: withSavedAt(save, start),
+
+			wantDue:         start.Add(domain.ArticleAutosaveDebounce),
+			wantDirtySince:  "1700000000000",
+			wantBaseVersion: 3,
+		},
+		{
+			name:   "持续保存时推迟写入",
+			before: []domain.ArticleAutosave{withSavedAt(save, start)},
+			save:   withSavedAt(save, start.Add(time.Second*10)),
+
+			wantDue:         start.Add(time.Second*10 + domain.ArticleAutosaveDebounce),
+			wantDirtySince:  "1700000000000",
+			wantBaseVersion: 3,
+		},
+		{
+			name:   "最多推迟maxDelay",
+			before: []domain.ArticleAutosave{withSavedAt(save, start)},
+			save:   withSavedAt(save, start.Add(domain.ArticleAutosaveMaxDelay)),
+
+			wantDue:         start.Add(domain.ArticleAutosaveMaxDelay),
+			wantDirtySince:  "1700000000000",
+			wantBaseVersion: 3,
+		},
+		{
+			name: "基于新的版本号，重新计算",
+			before: []domain.ArticleAutosave{
+				withSavedAt(save, start),
+			},
+			save: func() domain.ArticleAutosave {
+				s := withSavedAt(save, start.Add(domain.ArticleAutosaveMaxDelay))
+				s.BaseVersion = 5
+				return s
+			}(),
+
+			wantDue:         start.Add(domain.ArticleAutosaveMaxDelay + domain.ArticleAutosaveDebounce),
+			wantDirtySince:  "1700000300000",
+			wantBaseVersion: 5,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mr, cmd := newMiniredis(t)
+			cache := NewArticleCache(cmd).(*articleCache)
+			ctx := context.Background()
+			for _, s := range tc.before {
+				require.NoError(t, cache.SetAutosave(ctx, s))
+			}
+			require.NoError(t, cache.SetAutosave(ctx, tc.save))
+
+			score, err := mr.ZScore(autosaveDirtyKey, "2000:1")
+			require.NoError(t, err)
+			assert.Equal(t, float64(tc.wantDue.UnixMilli()), score)
+			key := cache.autosaveKey(2000, 1)
+			assert.Equal(t, tc.wantDirtySince, mr.HGet(key, "dirty_since"))
+			assert.True(t, mr.TTL(key) > 0)
+
+			got, err := cache.GetAutosave(ctx, 2000, 1)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantBaseVersion, got.BaseVersion)
+			assert.Equal(t, tc.wantFlushed, got.FlushedVersion)
+			assert.Equal(t, tc.save.SavedAt.UnixMilli(), got.SavedAt.UnixMilli())
+		})
+	}
+}
+
+func TestArticleCache_AckAutosave(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	save := domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "title", Content: "content", BaseVersion: 3}
+
+	t.Run("写入后移出待写入的集合，之后基于同一个版本号的快照保留写入后的版本号", func(t *testing.T) {
+		mr, cmd := newMiniredis(t)
+		cache := NewArticleCache(cmd).(*articleCache)
+		ctx := context.Background()
+		require.NoError(t, cache.SetAutosave(ctx, withSavedAt(save, start)))
+
+		flushed := withSavedAt(save, start)
+		flushed.FlushedVersion = 4
+		require.NoError(t, cache.AckAutosave(ctx, flushed))
+		_, err := mr.ZScore(autosaveDirtyKey, "2000:1")
+		assert.Equal(t, miniredis.ErrKeyNotFound, err)
+		assert.Equal(t, "0", mr.HGet(cache.autosaveKey(2000, 1), "dirty_since"))
+
+		next := withSavedAt(save, start.Add(time.Minute))
+		require.NoError(t, cache.SetAutosave(ctx, next))
+		got, err := cache.GetAutosave(ctx, 2000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), got.FlushedVersion)
+		// 写入后重新开始计算最长推迟的时间
+		score, err := mr.ZScore(autosaveDirtyKey, "2000:1")
+		require.NoError(t, err)
+		assert.Equal(t, float64(start.Add(time.Minute+domain.ArticleAutosaveDebounce).UnixMilli()), score)
+	})
+
+	t.Run("写入期间有新的快照，留在待写入的集合中", func(t *testing.T) {
+		mr, cmd := newMiniredis(t)
+		cache := NewArticleCache(cmd).(*articleCache)
+		ctx := context.Background()
+		require.NoError(t, cache.SetAutosave(ctx, withSavedAt(save, start)))
+		require.NoError(t, cache.SetAutosave(ctx, withSavedAt(save, start.Add(time.Second))))
+
+		flushed := withSavedAt(save, start)
+		flushed.FlushedVersion = 4
+		require.NoError(t, cache.AckAutosave(ctx, flushed))
+		_, err := mr.ZScore(autosaveDirtyKey, "2000:1")
+		assert.NoError(t, err)
+		got, err := cache.GetAutosave(ctx, 2000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), got.FlushedVersion)
+	})
+
+	t.Run("放弃写入不修改写入后的版本号", func(t *testing.T) {
+		_, cmd := newMiniredis(t)
+		cache := NewArticleCache(cmd).(*articleCache)
+		ctx := context.Background()
+		first := withSavedAt(save, start)
+		require.NoError(t, cache.SetAutosave(ctx, first))
+		first.FlushedVersion = 4
+		require.NoError(t, cache.AckAutosave(ctx, first))
+
+		next := withSavedAt(save, start.Add(time.Minute))
+		require.NoError(t, cache.SetAutosave(ctx, next))
+		require.NoError(t, cache.AckAutosave(ctx, next))
+		got, err := cache.GetAutosave(ctx, 2000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), got.FlushedVersion)
+	})
+
+	t.Run("快照已经过期", func(t *testing.T) {
+		mr, cmd := newMiniredis(t)
+		cache := NewArticleCache(cmd).(*articleCache)
+		ctx := context.Background()
+		_, err := mr.ZAdd(autosaveDirtyKey, 1, "2000:1")
+		require.NoError(t, err)
+		require.NoError(t, cache.AckAutosave(ctx, withSavedAt(save, start)))
+		assert.False(t, mr.Exists(autosaveDirtyKey))
+	})
+}
+
+func TestArticleCache_ListDueAutosaves(t *testing.T) {
+	start := time.UnixMilli(1700000000000)
+	mr, cmd := newMiniredis(t)
+	cache := NewArticleCache(cmd).(*articleCache)
+	ctx := context.Background()
+
+	require.NoError(t, cache.SetAutosave(ctx, withSavedAt(domain.ArticleAutosave{ArticleID: 1, Uid: 2000, BaseVersion: 3}, start)))
+	require.NoError(t, cache.SetAutosave(ctx, withSavedAt(domain.ArticleAutosave{ArticleID: 2, Uid: 2000, BaseVersion: 1}, start.Add(time.Minute))))
+	// 快照过期了，但是还在待写入的集合中
+	_, err := mr.ZAdd(autosaveDirtyKey, 1, "2000:3")
+	require.NoError(t, err)
+	_, err = mr.ZAdd(autosaveDirtyKey, 1, "invalid")
+	require.NoError(t, err)
+
+	saves, err := cache.ListDueAutosaves(ctx, start.Add(domain.ArticleAutosaveDebounce), 10)
+	require.NoError(t, err)
+	require.Len(t, saves, 1)
+	assert.Equal(t, int64(1), saves[0].ArticleID)
+	assert.Equal(t, int64(2000), saves[0].Uid)
+	members, err := mr.ZMembers(autosaveDirtyKey)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2000:1", "2000:2"}, members)
+}
+
+func withSavedAt(save domain.ArticleAutosave, savedAt time.Time) domain.ArticleAutosave {
+	save.SavedAt = savedAt
+	return save
+}
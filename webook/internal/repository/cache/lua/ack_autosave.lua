@@ -0,0 +1,25 @@
+-- 快照写入制作库之后调用，写入期间又有新的快照时不移出待写入的集合
+local key = KEYS[1]
+local dirtyKey = KEYS[2]
+
+local member = ARGV[1]
+local baseVersion = ARGV[2]
+local flushedVersion = ARGV[3]
+local savedAt = ARGV[4]
+
+if redis.call("exists", key) == 0 then
+    redis.call("zrem", dirtyKey, member)
+    return 0
+end
+
+-- 新的快照基于同一个版本号时，之后写入要使用写入后的版本号
+if flushedVersion ~= "0" and redis.call("hget", key, "base_version") == baseVersion then
+    redis.call("hset", key, "flushed_version", flushedVersion)
+end
+
+if redis.call("hget", key, "saved_at") == savedAt then
+    redis.call("hset", key, "dirty_since", "0")
+    redis.call("zrem", dirtyKey, member)
+    return 1
+end
+return 0
@@ -0,0 +1,32 @@
+-- 保存自动保存的快照，并推迟写入制作库的时间
+local key = KEYS[1]
+local dirtyKey = KEYS[2]
+
+local member = ARGV[1]
+local baseVersion = ARGV[5]
+local savedAt = ARGV[6]
+local debounce = tonumber(ARGV[7])
+local maxDelay = tonumber(ARGV[8])
+local ttl = ARGV[9]
+
+-- 基于同一个版本号的快照，保留已经写入的版本号和第一次没有写入的时间
+local flushed = "0"
+local dirtySince = savedAt
+if redis.call("hget", key, "base_version") == baseVersion then
+    flushed = redis.call("hget", key, "flushed_version") or "0"
+    local since = redis.call("hget", key, "dirty_since")
+    if since and since ~= "0" then
+        dirtySince = since
+    end
+end
+
+redis.call("hset", key,
+        "article_id", ARGV[2], "title", ARGV[3], "content", ARGV[4],
+        "base_version", baseVersion, "flushed_version", flushed,
+        "saved_at", savedAt, "dirty_since", dirtySince)
+redis.call("expire", key, ttl)
+
+-- 停止保存debounce之后写入，但是最多推迟到第一次没有写入之后的maxDelay
+local due = math.min(tonumber(savedAt) + debounce, tonumber(dirtySince) + maxDelay)
+redis.call("zadd", dirtyKey, due, member)
+return 0
@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/article.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/article.go -package=cachemocks -destination=./internal/repository/cache/mocks/article.mock.go
+//
+
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleCache is a mock of ArticleCache interface.
+type MockArticleCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleCacheMockRecorder
+}
+
+// MockArticleCacheMockRecorder is the mock recorder for MockArticleCache.
+type MockArticleCacheMockRecorder struct {
+	mock *MockArticleCache
+}
+
+// NewMockArticleCache creates a new mock instance.
+func NewMockArticleCache(ctrl *gomock.Controller) *MockArticleCache {
+	mock := &MockArticleCache{ctrl: ctrl}
+	mock.recorder = &MockArticleCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleCache) EXPECT() *MockArticleCacheMockRecorder {
+	return m.recorder
+}
+
+// AckAutosave mocks base method.
+func (m *MockArticleCache) AckAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AckAutosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckAutosave indicates an expected call of AckAutosave.
+func (mr *MockArticleCacheMockRecorder) AckAutosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckAutosave", reflect.TypeOf((*MockArticleCache)(nil).AckAutosave), ctx, save)
+}
+
+// Del mocks base method.
+func (m *MockArticleCache) Del(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Del", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Del indicates an expected call of Del.
+func (mr *MockArticleCacheMockRecorder) Del(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockArticleCache)(nil).Del), ctx, id)
+}
+
+// DelAutosave mocks base method.
+func (m *MockArticleCache) DelAutosave(ctx context.Context, uid, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelAutosave", ctx, uid, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelAutosave indicates an expected call of DelAutosave.
+func (mr *MockArticleCacheMockRecorder) DelAutosave(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelAutosave", reflect.TypeOf((*MockArticleCache)(nil).DelAutosave), ctx, uid, articleID)
+}
+
+// Get mocks base method.
+func (m *MockArticleCache) Get(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockArticleCacheMockRecorder) Get(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockArticleCache)(nil).Get), ctx, id)
+}
+
+// GetAutosave mocks base method.
+func (m *MockArticleCache) GetAutosave(ctx context.Context, uid, articleID int64) (domain.ArticleAutosave, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutosave", ctx, uid, articleID)
+	ret0, _ := ret[0].(domain.ArticleAutosave)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutosave indicates an expected call of GetAutosave.
+func (mr *MockArticleCacheMockRecorder) GetAutosave(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutosave", reflect.TypeOf((*MockArticleCache)(nil).GetAutosave), ctx, uid, articleID)
+}
+
+// GetFirstPage mocks base method.
+func (m *MockArticleCache) GetFirstPage(ctx context.Context, uid int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFirstPage", ctx, uid)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFirstPage indicates an expected call of GetFirstPage.
+func (mr *MockArticleCacheMockRecorder) GetFirstPage(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFirstPage", reflect.TypeOf((*MockArticleCache)(nil).GetFirstPage), ctx, uid)
+}
+
+// GetPub mocks base method.
+func (m *MockArticleCache) GetPub(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPub", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPub indicates an expected call of GetPub.
+func (mr *MockArticleCacheMockRecorder) GetPub(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPub", reflect.TypeOf((*MockArticleCache)(nil).GetPub), ctx, id)
+}
+
+// ListDueAutosaves mocks base method.
+func (m *MockArticleCache) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueAutosaves", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ArticleAutosave)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueAutosaves indicates an expected call of ListDueAutosaves.
+func (mr *MockArticleCacheMockRecorder) ListDueAutosaves(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueAutosaves", reflect.TypeOf((*MockArticleCache)(nil).ListDueAutosaves), ctx, now, limit)
+}
+
+// RemoveFirstPage mocks base method.
+func (m *MockArticleCache) RemoveFirstPage(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFirstPage", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFirstPage indicates an expected call of RemoveFirstPage.
+func (mr *MockArticleCacheMockRecorder) RemoveFirstPage(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFirstPage", reflect.TypeOf((*MockArticleCache)(nil).RemoveFirstPage), ctx, id)
+}
+
+// Set mocks base method.
+func (m *MockArticleCache) Set(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockArticleCacheMockRecorder) Set(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockArticleCache)(nil).Set), ctx, article)
+}
+
+// SetAutosave mocks base method.
+func (m *MockArticleCache) SetAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAutosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAutosave indicates an expected call of SetAutosave.
+func (mr *MockArticleCacheMockRecorder) SetAutosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutosave", reflect.TypeOf((*MockArticleCache)(nil).SetAutosave), ctx, save)
+}
+
+// SetFirstPage mocks base method.
+func (m *MockArticleCache) SetFirstPage(ctx context.Context, uid int64, arts []domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFirstPage", ctx, uid, arts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFirstPage indicates an expected call of SetFirstPage.
+func (mr *MockArticleCacheMockRecorder) SetFirstPage(ctx, uid, arts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFirstPage", reflect.TypeOf((*MockArticleCache)(nil).SetFirstPage), ctx, uid, arts)
+}
+
+// SetPub mocks base method.
+func (m *MockArticleCache) SetPub(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPub", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPub indicates an expected call of SetPub.
+func (mr *MockArticleCacheMockRecorder) SetPub(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPub", reflect.TypeOf((*MockArticleCache)(nil).SetPub), ctx, article)
+}
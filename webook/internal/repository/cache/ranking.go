@@ -51,3 +51,26 @@ func (c *RedisRanking) Get(ctx context.Context) ([]domain.Article, error) {
 	}
 	return arts, nil
 }
+
+func (c *RedisRanking) tagsKey() string {
+	return "ranking:top_tags"
+}
+
+// SetTags 缓存热门标签，和榜单一样不依赖过期时间以外的失效机制
+func (c *RedisRanking) SetTags(ctx context.Context, tags []domain.TagCount) error {
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+	return c.redis.Set(ctx, c.tagsKey(), data, c.expiration).Err()
+}
+
+func (c *RedisRanking) GetTags(ctx context.Context) ([]domain.TagCount, error) {
+	data, err := c.redis.Get(ctx, c.tagsKey()).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var tags []domain.TagCount
+	err = json.Unmarshal(data, &tags)
+	return tags, err
+}
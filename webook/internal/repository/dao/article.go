@@ -13,12 +13,28 @@ type Article struct {
 	ID      int64  `gorm:"primaryKey,authIncrement" bson:"id,omitempty"`
 	Title   string `gorm:"type=varchar(1024)"  bson:"title,omitempty"`
 	Content string `gorm:"type:blob"  bson:"content,omitempty"`
-	Status  int8   `gorm:"type:tinyint"  bson:"status,omitempty"`
+	Status  int8   `gorm:"type:tinyint;index:idx_status_publish_at;index:idx_status_utime,priority:1"  bson:"status,omitempty"`
 
-	AuthorID int64 `gorm:"index"  bson:"author_id,omitempty"`
+	AuthorID int64 `gorm:"index;index:idx_author_utime,priority:1;index:idx_author_dtime,priority:1"  bson:"author_id,omitempty"`
+	// EditorID 最后一次修改文章的用户，0表示作者本人
+	EditorID int64 `gorm:"column:editor_id" bson:"editor_id,omitempty"`
+
+	// PublishAt 定时发布的时间，定时任务通过status、publish_at来扫描到期的文章
+	PublishAt int64 `gorm:"column:publish_at;index:idx_status_publish_at" bson:"publish_at,omitempty"`
+	// ClaimUntil 定时发布的租约到期时间，实例抢占文章后在租约内发布，崩溃时租约过期，其他实例重新抢占
+	ClaimUntil int64 `gorm:"column:claim_until;not null;default:0" bson:"claim_until,omitempty"`
 
 	Ctime int64 `json:"c_time" gorm:"column:c_time"  bson:"c_time,omitempty"`
-	Utime int64 `json:"u_time" gorm:"column:u_time"  bson:"u_time,omitempty"`
+	// (author_id, u_time)、(status, u_time)索引用于游标分页，InnoDB的二级索引中隐含了主键id
+	Utime int64 `json:"u_time" gorm:"column:u_time;index:idx_author_utime,priority:2;index:idx_status_utime,priority:2"  bson:"u_time,omitempty"`
+	// Version 乐观锁的版本号，旧数据按照1处理
+	Version int64 `gorm:"column:version;not null;default:1" bson:"version,omitempty"`
+	// Dtime 移入回收站的时间，0表示没有被删除。线上库中的文章删除时直接移除，所以线上库的Dtime总是0。
+	// 查询条件都是d_time = 0，所以不能是NULL，旧数据由InitTable补成0
+	Dtime int64 `json:"d_time" gorm:"column:d_time;not null;default:0;index:idx_author_dtime,priority:2;index:idx_dtime" bson:"d_time,omitempty"`
+
+	// FromStatus 不落库。UpdateByID、Sync传入的FromStatus大于0时只更新当前状态是FromStatus的文章，用于审核等状态转换
+	FromStatus int8 `gorm:"-" bson:"-"`
 }
 
 type PublishArticle Article
@@ -36,10 +52,39 @@ type ArticleDao interface {
 	Sync(ctx context.Context, article Article) (int64, error)
 	// SyncStatus 同步制作库、线上库的文章状态
 	SyncStatus(ctx context.Context, id int64, authorID int64, status int8) error
-	GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error)
+	// GetByAuthor 按照(u_time, id)倒序查询作者的文章，beforeUtime、beforeID是上一页最后一篇文章的位置，都为0表示第一页
+	GetByAuthor(ctx context.Context, uid int64, beforeUtime int64, beforeID int64, limit int) ([]Article, error)
+	// ListIDsByAuthor 按照id倒序查询作者最新的limit篇文章的id，不包括回收站中的文章
+	ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error)
 	GetByID(ctx context.Context, id int64) (Article, error)
-	ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error)
+	// ListPub 按照(u_time, id)倒序查询已发布的文章，分页方式和GetByAuthor相同
+	ListPub(ctx context.Context, beforeUtime int64, beforeID int64, limit int) ([]Article, error)
 	GetPubByID(ctx context.Context, id int64) (PublishArticle, error)
+	// GetPubByIDs 批量查询线上库的文章，不查询内容，不存在的文章不返回
+	GetPubByIDs(ctx context.Context, ids []int64) ([]PublishArticle, error)
+	// ScanPub 按照id升序遍历线上库，包括已经撤回的文章，用于重建搜索索引等离线任务
+	ScanPub(ctx context.Context, startID int64, limit int) ([]PublishArticle, error)
+
+	// ListDueScheduled 查询到期并且没有被抢占（或者租约已经过期）的定时发布文章
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]Article, error)
+	// ClaimScheduled 抢占一篇到期的定时发布文章，租约在now+lease到期，文章仍然是pending状态。
+	// 返回false说明已经被其他实例抢占了，并且租约还没有过期。
+	ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error)
+	// ReleaseScheduled 清除抢占的租约。发布成功之后清除，发布失败时清除后等待下一次调度
+	ReleaseScheduled(ctx context.Context, id int64) error
+	// CancelScheduled 作者取消定时发布
+	CancelScheduled(ctx context.Context, id int64, authorID int64) error
+
+	// SoftDelete 将文章移入回收站，文章变回草稿，同时删除线上库中的文章
+	SoftDelete(ctx context.Context, id int64, authorID int64, now time.Time) error
+	// ListTrash 按照删除时间倒序查询作者回收站中的文章
+	ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]Article, error)
+	// Restore 将文章从回收站中恢复成草稿
+	Restore(ctx context.Context, id int64, authorID int64) error
+	// Purge 彻底删除回收站中的文章
+	Purge(ctx context.Context, id int64, authorID int64) error
+	// ListExpiredTrash 按照删除时间升序查询在before之前移入回收站的文章
+	ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]Article, error)
 }
 
 type ArticleGORMDao struct {
@@ -53,37 +98,67 @@ func NewArticleDao(db *gorm.DB) ArticleDao {
 }
 
 const (
-	ArticleStatusPublished = 2
+	ArticleStatusUnpublished = 1
+	ArticleStatusPublished   = 2
+	ArticleStatusPending     = 4
 )
 
+var ErrScheduleNotFound = errors.New("scheduled article not found")
+
 func (dao *ArticleGORMDao) GetPubByID(ctx context.Context, id int64) (PublishArticle, error) {
 	var article PublishArticle
 	err := dao.db.WithContext(ctx).Model(&PublishArticle{}).Where("id=?", id).First(&article).Error
 	return article, err
 }
 
-func (dao *ArticleGORMDao) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
-	var articles []Article
+func (dao *ArticleGORMDao) GetPubByIDs(ctx context.Context, ids []int64) ([]PublishArticle, error) {
+	var articles []PublishArticle
+	err := dao.db.WithContext(ctx).Model(&PublishArticle{}).
+		Select("id", "title", "author_id", "status", "c_time", "u_time").
+		Where("id in ?", ids).
+		Find(&articles).Error
+	return articles, err
+}
 
-	err := dao.db.WithContext(ctx).Model(&Article{}).
-		Where("c_time < ? and status = ?", start.UnixMilli(), ArticleStatusPublished).
-		Offset(offset).
+func (dao *ArticleGORMDao) ScanPub(ctx context.Context, startID int64, limit int) ([]PublishArticle, error) {
+	var articles []PublishArticle
+	err := dao.db.WithContext(ctx).Model(&PublishArticle{}).
+		Where("id > ?", startID).
+		Order("id").
 		Limit(limit).
 		Find(&articles).Error
 	return articles, err
 }
 
+func (dao *ArticleGORMDao) ListPub(ctx context.Context, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
+	var articles []Article
+	err := keyset(dao.db.WithContext(ctx).Model(&Article{}), beforeUtime, beforeID).
+		Where("status = ?", ArticleStatusPublished).
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+// keyset 游标分页：(u_time, id) < (beforeUtime, beforeID)，按照u_time、id倒序。
+// 没有使用行构造器(u_time, id) < (?, ?)，因为mysql对它的索引优化不稳定。
+func keyset(db *gorm.DB, beforeUtime int64, beforeID int64) *gorm.DB {
+	if beforeUtime > 0 || beforeID > 0 {
+		db = db.Where("u_time < ? or (u_time = ? and id < ?)", beforeUtime, beforeUtime, beforeID)
+	}
+	return db.Order("u_time desc, id desc")
+}
+
 func (dao *ArticleGORMDao) GetByID(ctx context.Context, id int64) (Article, error) {
 	var article Article
 	err := dao.db.WithContext(ctx).Model(&Article{}).Where("id = ? ", id).First(&article).Error
 	return article, err
 }
 
-func (dao *ArticleGORMDao) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
+func (dao *ArticleGORMDao) GetByAuthor(ctx context.Context, uid int64, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
 	var articles []Article
 
-	res := dao.db.WithContext(ctx).Model(&Article{}).Where("author_id = ?", uid).
-		Offset(offset).
+	res := keyset(dao.db.WithContext(ctx).Model(&Article{}), beforeUtime, beforeID).
+		Where("author_id = ? and d_time = 0", uid).
 		Limit(limit).
 		Find(&articles)
 	if res.Error != nil {
@@ -92,6 +167,15 @@ func (dao *ArticleGORMDao) GetByAuthor(ctx context.Context, uid int64, offset in
 	return articles, nil
 }
 
+func (dao *ArticleGORMDao) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? and d_time = 0", uid).
+		Order("id desc").Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
 func (dao *ArticleGORMDao) Sync(ctx context.Context, article Article) (int64, error) {
 	var (
 		id  = article.ID
@@ -118,10 +202,11 @@ func (dao *ArticleGORMDao) Sync(ctx context.Context, article Article) (int64, er
 		err = tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":   pubArt.Title,
-				"content": pubArt.Content,
-				"u_time":  pubArt.Utime,
-				"status":  pubArt.Status,
+				"title":     pubArt.Title,
+				"content":   pubArt.Content,
+				"u_time":    pubArt.Utime,
+				"status":    pubArt.Status,
+				"editor_id": pubArt.EditorID,
 			}),
 		}).Create(&pubArt).Error
 		return err
@@ -134,7 +219,7 @@ func (dao *ArticleGORMDao) SyncStatus(ctx context.Context, id int64, authorID in
 
 	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 更新制作库
-		res := tx.Model(&Article{}).Where("id=? and author_id=?", id, authorID).
+		res := tx.Model(&Article{}).Where("id=? and author_id=? and d_time = 0", id, authorID).
 			Updates(map[string]interface{}{
 				"status": status,
 				"u_time": now,
@@ -160,6 +245,7 @@ func (dao *ArticleGORMDao) Insert(ctx context.Context, article Article) (int64,
 
 	article.Ctime = now.UnixMilli()
 	article.Utime = now.UnixMilli()
+	article.Version = 1
 
 	err := dao.db.WithContext(ctx).Create(&article).Error
 	return article.ID, err
@@ -175,19 +261,73 @@ func (dao *ArticleGORMDao) UpdateByID(ctx context.Context, article Article) erro
 
 	// tip:
 	// 通过ID更新帖子。一般都是更新帖子的内容，id和作者id肯定是对应的，因此方法可以命名为UpdateByID，不要UpdateByIDAndAuthorID
-	res := dao.db.WithContext(ctx).Model(&Article{}).
-		Where("id = ? and author_id=?", article.ID, article.AuthorID).
+	// 回收站中的文章需要先恢复才能修改
+	// 协作者修改时还要校验协作者的角色
+	// 带上版本号时只更新版本号一致的文章
+	res := whereStatus(whereVersion(whereEditable(dao.db.WithContext(ctx).Model(&Article{}), article), article), article).
 		Updates(map[string]any{
-			"title":   article.Title,
-			"content": article.Content,
-			"u_time":  article.Utime,
-			"status":  article.Status,
+			"title":      article.Title,
+			"content":    article.Content,
+			"u_time":     article.Utime,
+			"status":     article.Status,
+			"publish_at": article.PublishAt,
+			"editor_id":  article.EditorID,
+			"version":    gorm.Expr("version + 1"),
 		})
 	if res.Error != nil {
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
+		if err := versionConflict(ctx, dao.db, article); err != nil {
+			return err
+		}
 		return fmt.Errorf("unauthorized operation, article_id: %v author_id: %v", article.ID, article.AuthorID)
 	}
 	return nil
 }
+
+func (dao *ArticleGORMDao) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]Article, error) {
+	var articles []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("status = ? and publish_at <= ? and claim_until < ?", ArticleStatusPending, now.UnixMilli(), now.UnixMilli()).
+		Order("publish_at").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+func (dao *ArticleGORMDao) ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error) {
+	// 利用行锁设置租约，多个实例同时抢占时只有一个能成功。
+	// 不修改状态，抢占的实例在发布前崩溃时，租约过期后文章会被重新抢占
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? and status = ? and publish_at <= ? and claim_until < ?",
+			id, ArticleStatusPending, now.UnixMilli(), now.UnixMilli()).
+		Update("claim_until", now.Add(lease).UnixMilli())
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (dao *ArticleGORMDao) ReleaseScheduled(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? and claim_until > 0", id).
+		Update("claim_until", 0).Error
+}
+
+func (dao *ArticleGORMDao) CancelScheduled(ctx context.Context, id int64, authorID int64) error {
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? and author_id = ? and status = ?", id, authorID, ArticleStatusPending).
+		Updates(map[string]any{
+			"status":     ArticleStatusUnpublished,
+			"publish_at": 0,
+			"u_time":     time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
@@ -15,18 +15,23 @@ type ArticleAuthorDao interface {
 
 func (dao *articleAuthorDao) UpdateByID(ctx context.Context, article Article) error {
 	now := time.Now().UnixMilli()
-	res := dao.db.WithContext(ctx).Model(&article).
-		Where("id = ? and author_id = ?", article.ID, article.AuthorID).
+	res := whereStatus(whereVersion(whereEditable(dao.db.WithContext(ctx).Model(&Article{}), article), article), article).
 		Updates(map[string]interface{}{
-			"title":   article.Title,
-			"content": article.Content,
-			"status":  article.Status,
-			"u_time":  now,
+			"title":      article.Title,
+			"content":    article.Content,
+			"status":     article.Status,
+			"publish_at": article.PublishAt,
+			"editor_id":  article.EditorID,
+			"version":    gorm.Expr("version + 1"),
+			"u_time":     now,
 		})
 	if res.Error != nil {
 		return res.Error
 	}
 	if res.RowsAffected == 0 {
+		if err := versionConflict(ctx, dao.db, article); err != nil {
+			return err
+		}
 		// 要记录日志
 		return errors.New("failed to update, no affected rows")
 	}
@@ -37,6 +42,7 @@ func (dao *articleAuthorDao) Insert(ctx context.Context, article Article) (int64
 	now := time.Now().UnixMilli()
 	article.Ctime = now
 	article.Utime = now
+	article.Version = 1
 	err := dao.db.WithContext(ctx).Create(&article).Error
 	return article.ID, err
 }
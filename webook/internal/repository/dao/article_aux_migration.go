@@ -0,0 +1,234 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+文章附属数据的迁移
+
+	双写只迁移文章，标签、版本记录、协作者、审核记录、系列、导出任务、媒体在迁移期间继续读写mysql。
+	mongo中标签内嵌在文章里，mysql的标签查询又要JOIN线上库，所以这些数据不能单独留在mysql，
+	切换到mongo存储之前，需要在文章全量复制完成、停止写入之后执行一次ArticleAuxCopier.Copy。
+
+	复制按照id覆盖，可以重复执行。复制完成后在mongo中写入完成标记，
+	mysql中有附属数据但是没有完成标记时，CheckAuxCopied返回ErrAuxNotCopied，切换到mongo存储会启动失败，而不是静默丢失数据。
+*/
+
+var ErrAuxNotCopied = errors.New("article auxiliary data has not been copied to mongo")
+
+const (
+	mongoMigrationCollection = "migrations"
+	auxCopiedMarker          = "article_auxiliary"
+)
+
+type ArticleAuxCopier struct {
+	db        *gorm.DB
+	mdb       *mongo.Database
+	batchSize int
+}
+
+func NewArticleAuxCopier(db *gorm.DB, mdb *mongo.Database) *ArticleAuxCopier {
+	return &ArticleAuxCopier{
+		db:        db,
+		mdb:       mdb,
+		batchSize: 100,
+	}
+}
+
+// Copy 复制全部附属数据，文章需要先复制到mongo，否则内嵌的标签找不到文章
+func (c *ArticleAuxCopier) Copy(ctx context.Context) error {
+	steps := []func(ctx context.Context) error{
+		func(ctx context.Context) error {
+			return copyAuxTable[Tag](ctx, c.db, c.mdb.Collection("tags"), c.batchSize,
+				func(src Tag) int64 { return src.ID }, nil)
+		},
+		c.copyArticleTags,
+		func(ctx context.Context) error {
+			return copyAuxTable[ArticleRevision](ctx, c.db, c.mdb.Collection("article_revisions"), c.batchSize,
+				func(src ArticleRevision) int64 { return src.ID }, nil)
+		},
+		func(ctx context.Context) error {
+			err := copyAuxTable[ArticleCollaborator](ctx, c.db, c.mdb.Collection(mongoCollaboratorCollection), c.batchSize,
+				func(src ArticleCollaborator) int64 { return src.ID }, nil)
+			if err != nil {
+				return err
+			}
+			// mysql中通过子查询校验协作者，mongo中需要冗余到文章的editors
+			return syncMongoEditors(ctx, c.mdb, true)
+		},
+		func(ctx context.Context) error {
+			return copyAuxTable[ArticleReview](ctx, c.db, c.mdb.Collection("article_reviews"), c.batchSize,
+				func(src ArticleReview) int64 { return src.ID }, nil)
+		},
+		func(ctx context.Context) error {
+			return copyAuxTable[Series](ctx, c.db, c.mdb.Collection(mongoSeriesCollection), c.batchSize,
+				func(src Series) int64 { return src.ID }, c.fillSeriesArticles)
+		},
+		func(ctx context.Context) error {
+			return copyAuxTable[ArticleExport](ctx, c.db, c.mdb.Collection(mongoExportCollection), c.batchSize,
+				func(src ArticleExport) int64 { return src.ID }, nil)
+		},
+		func(ctx context.Context) error {
+			return copyAuxTable[Media](ctx, c.db, c.mdb.Collection(mongoMediaCollection), c.batchSize,
+				func(src Media) int64 { return src.ID }, nil)
+		},
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	_, err := c.mdb.Collection(mongoMigrationCollection).UpdateOne(ctx,
+		bson.M{"_id": auxCopiedMarker},
+		bson.M{"$set": bson.M{"c_time": time.Now().UnixMilli()}},
+		options.Update().SetUpsert(true))
+	return err
+}
+
+// CheckAuxCopied mysql中没有附属数据，或者已经复制过时返回nil
+func (c *ArticleAuxCopier) CheckAuxCopied(ctx context.Context) error {
+	err := c.mdb.Collection(mongoMigrationCollection).FindOne(ctx, bson.M{"_id": auxCopiedMarker}).Err()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	models := []any{&Tag{}, &ArticleRevision{}, &ArticleCollaborator{}, &ArticleReview{},
+		&Series{}, &ArticleExport{}, &Media{}}
+	for _, model := range models {
+		if !c.db.Migrator().HasTable(model) {
+			continue
+		}
+		var ids []int64
+		err = c.db.WithContext(ctx).Model(model).Limit(1).Pluck("id", &ids).Error
+		if err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			return ErrAuxNotCopied
+		}
+	}
+	return nil
+}
+
+// copyArticleTags 按照文章分批，把文章的标签内嵌到制作库和线上库的文档中
+func (c *ArticleAuxCopier) copyArticleTags(ctx context.Context) error {
+	var after int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var articleIDs []int64
+		err := c.db.WithContext(ctx).Model(&ArticleTag{}).
+			Distinct("article_id").
+			Where("article_id > ?", after).
+			Order("article_id").
+			Limit(c.batchSize).
+			Pluck("article_id", &articleIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(articleIDs) == 0 {
+			return nil
+		}
+		tags, err := NewTagDao(c.db).GetByArticleIDs(ctx, articleIDs)
+		if err != nil {
+			return err
+		}
+		models := slice.Map(articleIDs, func(idx int, src int64) mongo.WriteModel {
+			embedded := slice.Map(tags[src], func(idx int, src Tag) Tag {
+				return Tag{ID: src.ID, Slug: src.Slug, Name: src.Name}
+			})
+			return mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"id": src}).
+				SetUpdate(bson.M{"$set": bson.M{"tags": embedded}})
+		})
+		for _, col := range []string{"articles", "published_articles"} {
+			_, err = c.mdb.Collection(col).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+			if err != nil {
+				return err
+			}
+		}
+		if len(articleIDs) < c.batchSize {
+			return nil
+		}
+		after = articleIDs[len(articleIDs)-1]
+	}
+}
+
+// fillSeriesArticles mysql中系列的文章保存在series_articles中，mongo中按照顺序内嵌在系列里
+func (c *ArticleAuxCopier) fillSeriesArticles(ctx context.Context, series []Series) error {
+	ids := slice.Map(series, func(idx int, src Series) int64 {
+		return src.ID
+	})
+	var relations []SeriesArticle
+	err := c.db.WithContext(ctx).
+		Where("series_id in ?", ids).
+		Order("series_id, position").
+		Find(&relations).Error
+	if err != nil {
+		return err
+	}
+	articleIDs := make(map[int64][]int64, len(series))
+	for _, r := range relations {
+		articleIDs[r.SeriesID] = append(articleIDs[r.SeriesID], r.ArticleID)
+	}
+	for i := range series {
+		series[i].ArticleIDs = articleIDs[series[i].ID]
+		if series[i].ArticleIDs == nil {
+			series[i].ArticleIDs = []int64{}
+		}
+	}
+	return nil
+}
+
+// copyAuxTable 按照id升序分批复制一张表，fill用来补充不在这张表中的字段
+func copyAuxTable[T any](ctx context.Context, db *gorm.DB, col *mongo.Collection, batchSize int,
+	id func(src T) int64, fill func(ctx context.Context, batch []T) error) error {
+	var after int64
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var batch []T
+		err := db.WithContext(ctx).
+			Where("id > ?", after).
+			Order("id").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+		if fill != nil {
+			if err = fill(ctx, batch); err != nil {
+				return err
+			}
+		}
+		models := slice.Map(batch, func(idx int, src T) mongo.WriteModel {
+			return mongo.NewReplaceOneModel().
+				SetFilter(bson.M{"id": id(src)}).
+				SetReplacement(src).
+				SetUpsert(true)
+		})
+		_, err = col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return err
+		}
+		if len(batch) < batchSize {
+			return nil
+		}
+		after = id(batch[len(batch)-1])
+	}
+}
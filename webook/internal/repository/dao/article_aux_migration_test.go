@@ -0,0 +1,69 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func TestArticleAuxCopier_CopySeries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("系列的文章按照顺序内嵌", func(mt *mtest.T) {
+		sqlDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mock.ExpectQuery("SELECT \\* FROM `series` WHERE id > \\? ORDER BY id LIMIT \\?").
+			WithArgs(int64(0), 100).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "title"}).
+				AddRow(1, 2000, "系列1").
+				AddRow(2, 2000, "系列2"))
+		mock.ExpectQuery("SELECT \\* FROM `series_articles` WHERE series_id in \\(\\?,\\?\\) ORDER BY series_id, position").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "series_id", "article_id", "position"}).
+				AddRow(1, 1, 12, 0).
+				AddRow(2, 1, 11, 1))
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}))
+
+		c := NewArticleAuxCopier(newMockGORM(t, sqlDB), mt.DB)
+		err = copyAuxTable[Series](context.Background(), c.db, c.mdb.Collection(mongoSeriesCollection), c.batchSize,
+			func(src Series) int64 { return src.ID }, c.fillSeriesArticles)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		evt := mt.GetStartedEvent()
+		assert.Equal(t, mongoSeriesCollection, evt.Command.Lookup("update").StringValue())
+		updates, err := evt.Command.Lookup("updates").Array().Values()
+		require.NoError(t, err)
+		require.Len(t, updates, 2)
+		first := updates[0].Document()
+		assert.Equal(t, int64(1), first.Lookup("q", "id").Int64())
+		assert.True(t, first.Lookup("upsert").Boolean())
+		ids, err := first.Lookup("u", "article_ids").Array().Values()
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), ids[0].Int64())
+		assert.Equal(t, int64(11), ids[1].Int64())
+		// 没有文章的系列写入空数组，不能是null
+		empty, err := updates[1].Document().Lookup("u", "article_ids").Array().Values()
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+}
+
+func TestArticleAuxCopier_CheckAuxCopied(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("已经复制过", func(mt *mtest.T) {
+		sqlDB, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.migrations", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: auxCopiedMarker}}))
+
+		err = NewArticleAuxCopier(newMockGORM(t, sqlDB), mt.DB).CheckAuxCopied(context.Background())
+		assert.NoError(t, err)
+		// 有完成标记时不需要查询mysql
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,347 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+文章的协作者。
+
+	文章的作者(author_id)就是文章的所有者，不记录在协作者中。所有者可以邀请其他用户成为共同所有者、编辑或者查看者，
+	被邀请的用户接受邀请后才拥有对应的权限。
+
+	协作者修改文章时，Article.AuthorID仍然是文章的所有者，Article.EditorID是实际修改文章的用户。
+	更新制作库时如果EditorID不是所有者，要求EditorID是接受了邀请的共同所有者或者编辑，否则不会更新任何数据。
+
+存储的选择和版本记录相同，mysql中更新文章时通过子查询校验协作者，所以协作者表要和制作库在同一个库中。
+mongo不支持跨集合的条件更新，制作库的文档中冗余了可以修改文章的协作者editors，由协作者的变更同步维护，
+更新文章时把EditorID in editors作为更新条件，校验和更新是原子的。
+*/
+
+const (
+	CollaboratorRoleOwner int8 = iota + 1
+	CollaboratorRoleEditor
+	CollaboratorRoleViewer
+)
+
+const (
+	CollaboratorStatusPending int8 = iota + 1
+	CollaboratorStatusAccepted
+)
+
+// collaboratorEditRoles 可以修改文章的角色
+var collaboratorEditRoles = []int8{CollaboratorRoleOwner, CollaboratorRoleEditor}
+
+type ArticleCollaborator struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleID int64 `gorm:"uniqueIndex:uk_article_uid" bson:"article_id,omitempty"`
+	Uid       int64 `gorm:"uniqueIndex:uk_article_uid;index:idx_uid_status,priority:1" bson:"uid,omitempty"`
+	Role      int8  `gorm:"type:tinyint" bson:"role,omitempty"`
+	Status    int8  `gorm:"type:tinyint;index:idx_uid_status,priority:2" bson:"status,omitempty"`
+	InviterID int64 `bson:"inviter_id,omitempty"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+	Utime int64 `json:"u_time" gorm:"column:u_time" bson:"u_time,omitempty"`
+}
+
+type ArticleCollaboratorDao interface {
+	// Upsert 邀请协作者。已经存在时只修改角色和邀请人，已经接受的邀请不需要重新接受
+	Upsert(ctx context.Context, c ArticleCollaborator) error
+	Get(ctx context.Context, articleID int64, uid int64) (ArticleCollaborator, error)
+	// ListByArticle 按照邀请时间升序返回文章的全部协作者
+	ListByArticle(ctx context.Context, articleID int64) ([]ArticleCollaborator, error)
+	// ListByUser 按照邀请时间倒序返回用户指定状态的邀请
+	ListByUser(ctx context.Context, uid int64, status int8, offset int, limit int) ([]ArticleCollaborator, error)
+	// Accept 接受邀请，没有待接受的邀请时返回ErrNotFound
+	Accept(ctx context.Context, articleID int64, uid int64) error
+	// Delete 拒绝邀请、移除协作者，没有对应的协作者时返回ErrNotFound
+	Delete(ctx context.Context, articleID int64, uid int64) error
+	// DeleteByArticle 删除文章的全部协作者
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type ArticleCollaboratorGORMDao struct {
+	db *gorm.DB
+}
+
+func NewArticleCollaboratorDao(db *gorm.DB) ArticleCollaboratorDao {
+	return &ArticleCollaboratorGORMDao{
+		db: db,
+	}
+}
+
+func (dao *ArticleCollaboratorGORMDao) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	c.Status = CollaboratorStatusPending
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "article_id"}, {Name: "uid"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"role":       c.Role,
+			"inviter_id": c.InviterID,
+			"u_time":     now,
+		}),
+	}).Create(&c).Error
+}
+
+func (dao *ArticleCollaboratorGORMDao) Get(ctx context.Context, articleID int64, uid int64) (ArticleCollaborator, error) {
+	var c ArticleCollaborator
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ? and uid = ?", articleID, uid).
+		First(&c).Error
+	return c, err
+}
+
+func (dao *ArticleCollaboratorGORMDao) ListByArticle(ctx context.Context, articleID int64) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Order("id").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *ArticleCollaboratorGORMDao) ListByUser(ctx context.Context, uid int64, status int8, offset int, limit int) ([]ArticleCollaborator, error) {
+	var res []ArticleCollaborator
+	err := dao.db.WithContext(ctx).
+		Where("uid = ? and status = ?", uid, status).
+		Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *ArticleCollaboratorGORMDao) Accept(ctx context.Context, articleID int64, uid int64) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleCollaborator{}).
+		Where("article_id = ? and uid = ? and status = ?", articleID, uid, CollaboratorStatusPending).
+		Updates(map[string]any{
+			"status": CollaboratorStatusAccepted,
+			"u_time": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *ArticleCollaboratorGORMDao) Delete(ctx context.Context, articleID int64, uid int64) error {
+	res := dao.db.WithContext(ctx).
+		Where("article_id = ? and uid = ?", articleID, uid).
+		Delete(&ArticleCollaborator{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *ArticleCollaboratorGORMDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Delete(&ArticleCollaborator{}).Error
+}
+
+// whereEditable 限制只能更新EditorID有权修改的文章：EditorID为0或者是所有者本人，或者是接受了邀请的共同所有者、编辑
+func whereEditable(db *gorm.DB, article Article) *gorm.DB {
+	db = db.Where("id = ? and author_id = ? and d_time = 0", article.ID, article.AuthorID)
+	if article.EditorID == 0 || article.EditorID == article.AuthorID {
+		return db
+	}
+	return db.Where("exists (?)", db.Session(&gorm.Session{NewDB: true}).
+		Model(&ArticleCollaborator{}).
+		Select("1").
+		Where("article_id = ? and uid = ? and status = ? and role in ?",
+			article.ID, article.EditorID, CollaboratorStatusAccepted, collaboratorEditRoles))
+}
+
+// MangoDBArticleCollaboratorDao 协作者的mongo存储实现
+type MangoDBArticleCollaboratorDao struct {
+	col    *mongo.Collection
+	artCol *mongo.Collection
+	node   *snowflake.Node
+}
+
+func NewMongoArticleCollaboratorDao(db *mongo.Database, node *snowflake.Node) ArticleCollaboratorDao {
+	return &MangoDBArticleCollaboratorDao{
+		col:    db.Collection(mongoCollaboratorCollection),
+		artCol: db.Collection("articles"),
+		node:   node,
+	}
+}
+
+const mongoCollaboratorCollection = "article_collaborators"
+
+func (dao *MangoDBArticleCollaboratorDao) Upsert(ctx context.Context, c ArticleCollaborator) error {
+	now := time.Now().UnixMilli()
+	var updated ArticleCollaborator
+	err := dao.col.FindOneAndUpdate(ctx,
+		bson.M{"article_id": c.ArticleID, "uid": c.Uid},
+		bson.M{
+			"$set": bson.M{
+				"role":       c.Role,
+				"inviter_id": c.InviterID,
+				"u_time":     now,
+			},
+			"$setOnInsert": bson.M{
+				"id":     dao.node.Generate().Int64(),
+				"status": CollaboratorStatusPending,
+				"c_time": now,
+			},
+		},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&updated)
+	if err != nil {
+		return err
+	}
+	// 已经接受邀请的协作者修改了角色
+	return dao.syncEditor(ctx, updated)
+}
+
+func (dao *MangoDBArticleCollaboratorDao) Get(ctx context.Context, articleID int64, uid int64) (ArticleCollaborator, error) {
+	var c ArticleCollaborator
+	err := dao.col.FindOne(ctx, bson.M{"article_id": articleID, "uid": uid}).Decode(&c)
+	if err == mongo.ErrNoDocuments {
+		return ArticleCollaborator{}, ErrNotFound
+	}
+	return c, err
+}
+
+func (dao *MangoDBArticleCollaboratorDao) ListByArticle(ctx context.Context, articleID int64) ([]ArticleCollaborator, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: 1}})
+	return dao.find(ctx, bson.M{"article_id": articleID}, opts)
+}
+
+func (dao *MangoDBArticleCollaboratorDao) ListByUser(ctx context.Context, uid int64, status int8, offset int, limit int) ([]ArticleCollaborator, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return dao.find(ctx, bson.M{"uid": uid, "status": status}, opts)
+}
+
+func (dao *MangoDBArticleCollaboratorDao) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]ArticleCollaborator, error) {
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleCollaborator
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (dao *MangoDBArticleCollaboratorDao) Accept(ctx context.Context, articleID int64, uid int64) error {
+	filter := bson.M{"article_id": articleID, "uid": uid, "status": CollaboratorStatusPending}
+	var accepted ArticleCollaborator
+	err := dao.col.FindOneAndUpdate(ctx, filter, bson.M{
+		"$set": bson.M{
+			"status": CollaboratorStatusAccepted,
+			"u_time": time.Now().UnixMilli(),
+		},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&accepted)
+	if err == mongo.ErrNoDocuments {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return dao.syncEditor(ctx, accepted)
+}
+
+func (dao *MangoDBArticleCollaboratorDao) Delete(ctx context.Context, articleID int64, uid int64) error {
+	res, err := dao.col.DeleteOne(ctx, bson.M{"article_id": articleID, "uid": uid})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return dao.syncEditor(ctx, ArticleCollaborator{ArticleID: articleID, Uid: uid})
+}
+
+func (dao *MangoDBArticleCollaboratorDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	_, err := dao.col.DeleteMany(ctx, bson.M{"article_id": articleID})
+	if err != nil {
+		return err
+	}
+	_, err = dao.artCol.UpdateOne(ctx, bson.M{"id": articleID}, bson.M{"$set": bson.M{"editors": bson.A{}}})
+	return err
+}
+
+// syncEditor 按照协作者当前的状态和角色，把协作者加入或者移出文章的editors
+func (dao *MangoDBArticleCollaboratorDao) syncEditor(ctx context.Context, c ArticleCollaborator) error {
+	op := "$pull"
+	if canEditRole(c) {
+		op = "$addToSet"
+	}
+	_, err := dao.artCol.UpdateOne(ctx, bson.M{"id": c.ArticleID}, bson.M{op: bson.M{"editors": c.Uid}})
+	return err
+}
+
+func canEditRole(c ArticleCollaborator) bool {
+	if c.Status != CollaboratorStatusAccepted {
+		return false
+	}
+	for _, role := range collaboratorEditRoles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// syncMongoEditors 根据协作者集合重新计算制作库的editors。
+// overwrite为false时只补齐还没有editors的文章，用于兼容冗余editors之前的数据
+func syncMongoEditors(ctx context.Context, db *mongo.Database, overwrite bool) error {
+	cursor, err := db.Collection(mongoCollaboratorCollection).Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"status": CollaboratorStatusAccepted,
+			"role":   bson.M{"$in": collaboratorEditRoles},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$article_id", "editors": bson.M{"$push": "$uid"}}}},
+	})
+	if err != nil {
+		return err
+	}
+	var groups []struct {
+		ArticleID int64   `bson:"_id"`
+		Editors   []int64 `bson:"editors"`
+	}
+	err = cursor.All(ctx, &groups)
+	if err != nil || len(groups) == 0 {
+		return err
+	}
+	models := make([]mongo.WriteModel, 0, len(groups))
+	for _, g := range groups {
+		filter := bson.M{"id": g.ArticleID}
+		if !overwrite {
+			filter["editors"] = bson.M{"$exists": false}
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(filter).
+			SetUpdate(bson.M{"$set": bson.M{"editors": g.Editors}}))
+	}
+	_, err = db.Collection("articles").BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// whereMongoEditable 和whereEditable相同，EditorID不是所有者时要求EditorID在editors中
+func whereMongoEditable(filter bson.D, article Article) bson.D {
+	if article.EditorID == 0 || article.EditorID == article.AuthorID {
+		return filter
+	}
+	return append(filter, bson.E{Key: "editors", Value: article.EditorID})
+}
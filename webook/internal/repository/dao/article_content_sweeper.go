@@ -0,0 +1,119 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"learn_go/webook/pkg/objectstore"
+	"time"
+)
+
+// ArticleContentSweeper 删除对象存储中不再被已发布文章引用的文章内容
+type ArticleContentSweeper struct {
+	db        *gorm.DB
+	store     objectstore.ObjectStore
+	batchSize int
+}
+
+func NewArticleContentSweeper(db *gorm.DB, store objectstore.ObjectStore) *ArticleContentSweeper {
+	return &ArticleContentSweeper{
+		db:        db,
+		store:     store,
+		batchSize: 100,
+	}
+}
+
+// Sweep 只清理before之前写入的对象：Sync先写对象再提交事务，刚写入的对象可能还没有被数据库引用。
+// 内容相同的对象key相同，遍历之后Sync可能再次写入同一个对象并引用它，
+// 所以删除每个对象之前都要重新确认没有被引用，并且这段时间内没有被重新写入。
+// 返回删除的对象数量。
+func (s *ArticleContentSweeper) Sweep(ctx context.Context, before time.Time) (int, error) {
+	var (
+		deleted int
+		batch   = make([]string, 0, s.batchSize)
+	)
+	err := s.store.List(ctx, ArticleContentNamespace+"/", func(obj objectstore.Object) error {
+		if !obj.ModTime.Before(before) {
+			return nil
+		}
+		batch = append(batch, obj.Key)
+		if len(batch) < s.batchSize {
+			return nil
+		}
+		cnt, err := s.sweepBatch(ctx, batch, before)
+		deleted += cnt
+		batch = batch[:0]
+		return err
+	})
+	if err != nil {
+		return deleted, err
+	}
+	if len(batch) > 0 {
+		cnt, err := s.sweepBatch(ctx, batch, before)
+		deleted += cnt
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+func (s *ArticleContentSweeper) sweepBatch(ctx context.Context, keys []string, before time.Time) (int, error) {
+	referenced, err := s.referenced(ctx, keys)
+	if err != nil {
+		return 0, err
+	}
+	deleted := 0
+	for _, key := range keys {
+		if _, ok := referenced[key]; ok {
+			continue
+		}
+		ok, err := s.sweepable(ctx, key, before)
+		if err != nil {
+			return deleted, err
+		}
+		if !ok {
+			continue
+		}
+		err = s.store.Delete(ctx, key)
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// sweepable 删除前重新确认：先查引用再查写入时间。
+// Sync先写对象再提交事务，查引用之后才提交的Sync，写对象的时间只要在查写入时间之前，就会被宽限期跳过，
+// 剩下的只有查写入时间和删除之间这一个很短的窗口
+func (s *ArticleContentSweeper) sweepable(ctx context.Context, key string, before time.Time) (bool, error) {
+	referenced, err := s.referenced(ctx, []string{key})
+	if err != nil || len(referenced) > 0 {
+		return false, err
+	}
+	obj, err := s.store.Stat(ctx, key)
+	if errors.Is(err, objectstore.ErrObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return obj.ModTime.Before(before), nil
+}
+
+func (s *ArticleContentSweeper) referenced(ctx context.Context, keys []string) (map[string]struct{}, error) {
+	var referenced []string
+	err := s.db.WithContext(ctx).Model(&PublishedArticleV2{}).
+		Where("content_key in ? and status = ?", keys, ArticleStatusPublished).
+		Distinct().
+		Pluck("content_key", &referenced).Error
+	if err != nil {
+		return nil, err
+	}
+	refSet := make(map[string]struct{}, len(referenced))
+	for _, key := range referenced {
+		refSet[key] = struct{}{}
+	}
+	return refSet, nil
+}
@@ -0,0 +1,133 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"learn_go/webook/pkg/objectstore"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestArticleContentSweeper_Sweep(t *testing.T) {
+	ctx := context.Background()
+	store, err := objectstore.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	put := func(content string) string {
+		key := objectstore.ContentKey(ArticleContentNamespace, []byte(content))
+		require.NoError(t, store.Put(ctx, key, []byte(content), "text/plain"))
+		return key
+	}
+	published := put("published")
+	private := put("private")
+	// 其他命名空间的对象不会被清理
+	require.NoError(t, store.Put(ctx, "images/1", []byte("image"), "image/png"))
+
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2` WHERE content_key in .*").
+		WillReturnRows(sqlmock.NewRows([]string{"content_key"}).AddRow(published))
+	// 删除前重新确认没有被引用
+	mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2` WHERE content_key in .*").
+		WithArgs(private, ArticleStatusPublished).
+		WillReturnRows(sqlmock.NewRows([]string{"content_key"}))
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	sweeper := NewArticleContentSweeper(db, store)
+	deleted, err := sweeper.Sweep(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	_, err = store.Get(ctx, published)
+	assert.NoError(t, err)
+	_, err = store.Get(ctx, private)
+	assert.Equal(t, objectstore.ErrObjectNotFound, err)
+	_, err = store.Get(ctx, "images/1")
+	assert.NoError(t, err)
+
+	// 刚写入的对象不会被清理，也不需要查询数据库
+	put("new")
+	deleted, err = sweeper.Sweep(ctx, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+}
+
+func TestArticleContentSweeper_Recheck(t *testing.T) {
+	ctx := context.Background()
+	before := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name string
+		// 遍历之后、删除之前发生的事情
+		mock  func(mock sqlmock.Sqlmock, key string)
+		touch bool
+
+		wantDeleted int
+	}{
+		{
+			name: "删除前被引用了",
+			mock: func(mock sqlmock.Sqlmock, key string) {
+				mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2`").
+					WillReturnRows(sqlmock.NewRows([]string{"content_key"}).AddRow(key))
+			},
+		},
+		{
+			name: "删除前被重新写入了",
+			mock: func(mock sqlmock.Sqlmock, key string) {
+				mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2`").
+					WillReturnRows(sqlmock.NewRows([]string{"content_key"}))
+			},
+			touch: true,
+		},
+		{
+			name: "仍然没有被引用",
+			mock: func(mock sqlmock.Sqlmock, key string) {
+				mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2`").
+					WillReturnRows(sqlmock.NewRows([]string{"content_key"}))
+			},
+			wantDeleted: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			store, err := objectstore.NewLocalStore(root)
+			require.NoError(t, err)
+			content := []byte("content")
+			key := objectstore.ContentKey(ArticleContentNamespace, content)
+			require.NoError(t, store.Put(ctx, key, content, "text/plain"))
+			if !tc.touch {
+				path := filepath.Join(root, filepath.FromSlash(key))
+				require.NoError(t, os.Chtimes(path, before.Add(-time.Hour), before.Add(-time.Hour)))
+			}
+
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			// 批量查询时没有被引用
+			mock.ExpectQuery("SELECT DISTINCT `content_key` FROM `published_article_v2`").
+				WillReturnRows(sqlmock.NewRows([]string{"content_key"}))
+			tc.mock(mock, key)
+
+			sweeper := NewArticleContentSweeper(newMockGORM(t, sqlDB), store)
+			deleted, err := sweeper.sweepBatch(ctx, []string{key}, before)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantDeleted, deleted)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/pkg/logger"
+	"sync/atomic"
+	"time"
+)
+
+/*
+DoubleWriteArticleDao 文章从src（GORM）迁移到dst（Mongo）时使用的双写装饰器。
+
+迁移分为四个阶段，通过UpdatePattern在运行时切换：
+	src_only：只读写src
+	src_first：读src，先写src再写dst
+	dst_first：读dst，先写dst再写src
+	dst_only：只读写dst
+
+双写时第二次写入不重复执行业务的更新逻辑，而是把第一次写入后的数据按照id复制过去，
+保证两边的id、c_time、u_time完全一致。第二次写入失败只记录日志，不影响业务，
+由校验任务发现不一致的数据后修复。
+*/
+
+const (
+	PatternSrcOnly  = "src_only"
+	PatternSrcFirst = "src_first"
+	PatternDstFirst = "dst_first"
+	PatternDstOnly  = "dst_only"
+)
+
+var ErrUnknownPattern = errors.New("unknown double write pattern")
+
+type DoubleWriteArticleDao struct {
+	src     ArticleMigrationDao
+	dst     ArticleMigrationDao
+	pattern atomic.Value
+	l       logger.LoggerV2
+}
+
+func NewDoubleWriteArticleDao(src ArticleMigrationDao, dst ArticleMigrationDao, pattern string,
+	l logger.LoggerV2) (*DoubleWriteArticleDao, error) {
+	dao := &DoubleWriteArticleDao{
+		src: src,
+		dst: dst,
+		l:   l,
+	}
+	err := dao.UpdatePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return dao, nil
+}
+
+// CheckPattern 检查是不是支持的双写模式
+func CheckPattern(pattern string) error {
+	switch pattern {
+	case PatternSrcOnly, PatternSrcFirst, PatternDstFirst, PatternDstOnly:
+		return nil
+	default:
+		return ErrUnknownPattern
+	}
+}
+
+func (dao *DoubleWriteArticleDao) UpdatePattern(pattern string) error {
+	if err := CheckPattern(pattern); err != nil {
+		return err
+	}
+	dao.pattern.Store(pattern)
+	return nil
+}
+
+func (dao *DoubleWriteArticleDao) Pattern() string {
+	return dao.pattern.Load().(string)
+}
+
+func (dao *DoubleWriteArticleDao) Src() ArticleMigrationDao {
+	return dao.src
+}
+
+func (dao *DoubleWriteArticleDao) Dst() ArticleMigrationDao {
+	return dao.dst
+}
+
+// Base 当前以哪一边的数据为准（读哪一边），target是另一边
+func (dao *DoubleWriteArticleDao) Base() (base ArticleMigrationDao, target ArticleMigrationDao) {
+	base, target, _ = dao.route()
+	return base, target
+}
+
+// route 读取一次模式，返回base、target以及是否需要双写，避免同一次操作中间切换模式
+func (dao *DoubleWriteArticleDao) route() (base ArticleMigrationDao, target ArticleMigrationDao, double bool) {
+	switch dao.Pattern() {
+	case PatternSrcFirst:
+		return dao.src, dao.dst, true
+	case PatternDstFirst:
+		return dao.dst, dao.src, true
+	case PatternDstOnly:
+		return dao.dst, dao.src, false
+	default:
+		return dao.src, dao.dst, false
+	}
+}
+
+// write 在base上执行写操作，成功后把文章复制到target
+func (dao *DoubleWriteArticleDao) write(ctx context.Context, fn func(base ArticleMigrationDao) (int64, error),
+	tables ...string) (int64, error) {
+	base, target, double := dao.route()
+	id, err := fn(base)
+	if err != nil || !double || id == 0 {
+		return id, err
+	}
+	// 业务的ctx可能已经快超时了，复制使用独立的超时时间
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	for _, table := range tables {
+		err = CopyArticles(ctx, table, base, target, []int64{id})
+		if err != nil {
+			dao.l.Error("双写失败",
+				logger.String("table", table),
+				logger.Int64("id", id),
+				logger.Error(err))
+		}
+	}
+	return id, nil
+}
+
+func (dao *DoubleWriteArticleDao) Insert(ctx context.Context, data Article) (int64, error) {
+	return dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return base.Insert(ctx, data)
+	}, MigrationTableArticles)
+}
+
+func (dao *DoubleWriteArticleDao) UpdateByID(ctx context.Context, article Article) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return article.ID, base.UpdateByID(ctx, article)
+	}, MigrationTableArticles)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) Sync(ctx context.Context, article Article) (int64, error) {
+	return dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return base.Sync(ctx, article)
+	}, MigrationTables...)
+}
+
+func (dao *DoubleWriteArticleDao) SyncStatus(ctx context.Context, id int64, authorID int64, status int8) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.SyncStatus(ctx, id, authorID, status)
+	}, MigrationTables...)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) GetByAuthor(ctx context.Context, uid int64, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
+	base, _ := dao.Base()
+	return base.GetByAuthor(ctx, uid, beforeUtime, beforeID, limit)
+}
+
+func (dao *DoubleWriteArticleDao) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	base, _ := dao.Base()
+	return base.ListIDsByAuthor(ctx, uid, limit)
+}
+
+func (dao *DoubleWriteArticleDao) GetByID(ctx context.Context, id int64) (Article, error) {
+	base, _ := dao.Base()
+	return base.GetByID(ctx, id)
+}
+
+func (dao *DoubleWriteArticleDao) ListPub(ctx context.Context, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
+	base, _ := dao.Base()
+	return base.ListPub(ctx, beforeUtime, beforeID, limit)
+}
+
+func (dao *DoubleWriteArticleDao) GetPubByID(ctx context.Context, id int64) (PublishArticle, error) {
+	base, _ := dao.Base()
+	return base.GetPubByID(ctx, id)
+}
+
+func (dao *DoubleWriteArticleDao) GetPubByIDs(ctx context.Context, ids []int64) ([]PublishArticle, error) {
+	base, _ := dao.Base()
+	return base.GetPubByIDs(ctx, ids)
+}
+
+func (dao *DoubleWriteArticleDao) ScanPub(ctx context.Context, startID int64, limit int) ([]PublishArticle, error) {
+	base, _ := dao.Base()
+	return base.ScanPub(ctx, startID, limit)
+}
+
+func (dao *DoubleWriteArticleDao) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]Article, error) {
+	base, _ := dao.Base()
+	return base.ListDueScheduled(ctx, now, limit)
+}
+
+func (dao *DoubleWriteArticleDao) ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error) {
+	// 只在base上抢占，保证只有一个实例能抢占成功
+	var ok bool
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		var err error
+		ok, err = base.ClaimScheduled(ctx, id, now, lease)
+		if !ok {
+			// 没有抢占到，不需要复制
+			return 0, err
+		}
+		return id, err
+	}, MigrationTableArticles)
+	return ok, err
+}
+
+func (dao *DoubleWriteArticleDao) ReleaseScheduled(ctx context.Context, id int64) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.ReleaseScheduled(ctx, id)
+	}, MigrationTableArticles)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) CancelScheduled(ctx context.Context, id int64, authorID int64) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.CancelScheduled(ctx, id, authorID)
+	}, MigrationTableArticles)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) SoftDelete(ctx context.Context, id int64, authorID int64, now time.Time) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.SoftDelete(ctx, id, authorID, now)
+	}, MigrationTables...)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]Article, error) {
+	base, _ := dao.Base()
+	return base.ListTrash(ctx, authorID, offset, limit)
+}
+
+func (dao *DoubleWriteArticleDao) Restore(ctx context.Context, id int64, authorID int64) error {
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.Restore(ctx, id, authorID)
+	}, MigrationTableArticles)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) Purge(ctx context.Context, id int64, authorID int64) error {
+	// base中已经不存在的文章，复制时会从target中删除
+	_, err := dao.write(ctx, func(base ArticleMigrationDao) (int64, error) {
+		return id, base.Purge(ctx, id, authorID)
+	}, MigrationTableArticles)
+	return err
+}
+
+func (dao *DoubleWriteArticleDao) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	base, _ := dao.Base()
+	return base.ListExpiredTrash(ctx, before, limit)
+}
+
+var _ ArticleDao = (*DoubleWriteArticleDao)(nil)
@@ -0,0 +1,194 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+// fakeMigrationDao 只实现双写用到的方法，按照调用顺序记录操作
+type fakeMigrationDao struct {
+	ArticleMigrationDao
+	name  string
+	calls *[]string
+	arts  map[int64]Article
+	err   error
+}
+
+func newFakeMigrationDao(name string, calls *[]string) *fakeMigrationDao {
+	return &fakeMigrationDao{name: name, calls: calls, arts: map[int64]Article{}}
+}
+
+func (dao *fakeMigrationDao) record(op string) {
+	*dao.calls = append(*dao.calls, dao.name+"."+op)
+}
+
+func (dao *fakeMigrationDao) Insert(ctx context.Context, data Article) (int64, error) {
+	dao.record("Insert")
+	if dao.err != nil {
+		return 0, dao.err
+	}
+	dao.arts[data.ID] = data
+	return data.ID, nil
+}
+
+func (dao *fakeMigrationDao) Purge(ctx context.Context, id int64, authorID int64) error {
+	dao.record("Purge")
+	delete(dao.arts, id)
+	return dao.err
+}
+
+func (dao *fakeMigrationDao) GetByID(ctx context.Context, id int64) (Article, error) {
+	dao.record("GetByID")
+	return dao.arts[id], nil
+}
+
+func (dao *fakeMigrationDao) FindForMigration(ctx context.Context, table string, ids []int64) ([]Article, error) {
+	dao.record("FindForMigration")
+	var res []Article
+	for _, id := range ids {
+		if art, ok := dao.arts[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func (dao *fakeMigrationDao) UpsertForMigration(ctx context.Context, table string, arts []Article) error {
+	dao.record("UpsertForMigration")
+	if dao.err != nil {
+		return dao.err
+	}
+	for _, art := range arts {
+		dao.arts[art.ID] = art
+	}
+	return nil
+}
+
+func (dao *fakeMigrationDao) DeleteForMigration(ctx context.Context, table string, ids []int64) error {
+	dao.record("DeleteForMigration")
+	for _, id := range ids {
+		delete(dao.arts, id)
+	}
+	return nil
+}
+
+func TestDoubleWriteArticleDao_Insert(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		srcErr  error
+		dstErr  error
+
+		wantErr   error
+		wantCalls []string
+		wantSrc   bool
+		wantDst   bool
+	}{
+		{
+			name:      "只写src",
+			pattern:   PatternSrcOnly,
+			wantCalls: []string{"src.Insert"},
+			wantSrc:   true,
+		},
+		{
+			name:      "先写src再复制到dst",
+			pattern:   PatternSrcFirst,
+			wantCalls: []string{"src.Insert", "src.FindForMigration", "dst.UpsertForMigration"},
+			wantSrc:   true,
+			wantDst:   true,
+		},
+		{
+			name:      "先写dst再复制到src",
+			pattern:   PatternDstFirst,
+			wantCalls: []string{"dst.Insert", "dst.FindForMigration", "src.UpsertForMigration"},
+			wantSrc:   true,
+			wantDst:   true,
+		},
+		{
+			name:      "只写dst",
+			pattern:   PatternDstOnly,
+			wantCalls: []string{"dst.Insert"},
+			wantDst:   true,
+		},
+		{
+			name:      "第一次写入失败不复制",
+			pattern:   PatternSrcFirst,
+			srcErr:    errors.New("mock db error"),
+			wantErr:   errors.New("mock db error"),
+			wantCalls: []string{"src.Insert"},
+		},
+		{
+			name:      "复制失败不影响业务",
+			pattern:   PatternSrcFirst,
+			dstErr:    errors.New("mock mongo error"),
+			wantCalls: []string{"src.Insert", "src.FindForMigration", "dst.UpsertForMigration"},
+			wantSrc:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			src := newFakeMigrationDao("src", &calls)
+			src.err = tc.srcErr
+			dst := newFakeMigrationDao("dst", &calls)
+			dst.err = tc.dstErr
+			dao, err := NewDoubleWriteArticleDao(src, dst, tc.pattern, logger.NewNopLogger())
+			require.NoError(t, err)
+
+			id, err := dao.Insert(context.Background(), Article{ID: 1, Title: "标题", Utime: 100})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCalls, calls)
+			_, ok := src.arts[1]
+			assert.Equal(t, tc.wantSrc, ok)
+			_, ok = dst.arts[1]
+			assert.Equal(t, tc.wantDst, ok)
+			if tc.wantErr == nil {
+				assert.Equal(t, int64(1), id)
+			}
+			if tc.wantSrc && tc.wantDst {
+				// 复制过去的数据和base完全一致
+				assert.Equal(t, src.arts[1], dst.arts[1])
+			}
+		})
+	}
+}
+
+func TestDoubleWriteArticleDao_Purge(t *testing.T) {
+	var calls []string
+	src := newFakeMigrationDao("src", &calls)
+	dst := newFakeMigrationDao("dst", &calls)
+	src.arts[1] = Article{ID: 1}
+	dst.arts[1] = Article{ID: 1}
+	dao, err := NewDoubleWriteArticleDao(src, dst, PatternDstFirst, logger.NewNopLogger())
+	require.NoError(t, err)
+
+	err = dao.Purge(context.Background(), 1, 2000)
+	require.NoError(t, err)
+	// base中已经不存在的文章从target中删除
+	assert.Equal(t, []string{"dst.Purge", "dst.FindForMigration", "src.DeleteForMigration"}, calls)
+	assert.Empty(t, src.arts)
+	assert.Empty(t, dst.arts)
+}
+
+func TestDoubleWriteArticleDao_UpdatePattern(t *testing.T) {
+	var calls []string
+	src := newFakeMigrationDao("src", &calls)
+	dst := newFakeMigrationDao("dst", &calls)
+	dao, err := NewDoubleWriteArticleDao(src, dst, PatternSrcFirst, logger.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = dao.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, ErrUnknownPattern, dao.UpdatePattern("unknown"))
+	assert.Equal(t, PatternSrcFirst, dao.Pattern())
+
+	require.NoError(t, dao.UpdatePattern(PatternDstOnly))
+	_, err = dao.GetByID(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src.GetByID", "dst.GetByID"}, calls)
+}
@@ -0,0 +1,237 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+文章的导出任务。
+
+	作者的文章太多时不能在一次请求中导出，先插入一条pending的任务，由定时任务抢占后生成导出文件，写入对象存储。
+	抢占的方式和定时发布相同：通过status的条件更新，只有一个实例能抢占成功。
+	执行任务的实例崩溃时任务会一直是running，u_time超过staleBefore的running任务可以被重新抢占。
+
+存储的选择和版本记录相同。
+*/
+
+const (
+	ExportStatusPending int8 = iota + 1
+	ExportStatusRunning
+	ExportStatusDone
+	ExportStatusFailed
+)
+
+type ArticleExport struct {
+	ID        int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Uid       int64  `gorm:"index" bson:"uid,omitempty"`
+	Format    string `gorm:"type:varchar(16)" bson:"format,omitempty"`
+	Status    int8   `gorm:"type:tinyint;index:idx_status_utime,priority:1" bson:"status,omitempty"`
+	ObjectKey string `gorm:"type:varchar(255)" bson:"object_key,omitempty"`
+	Count     int    `bson:"count,omitempty"`
+	Size      int64  `bson:"size,omitempty"`
+	ErrMsg    string `gorm:"type:varchar(1024)" bson:"err_msg,omitempty"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+	Utime int64 `json:"u_time" gorm:"column:u_time;index:idx_status_utime,priority:2" bson:"u_time,omitempty"`
+}
+
+type ArticleExportDao interface {
+	Insert(ctx context.Context, e ArticleExport) (int64, error)
+	GetByID(ctx context.Context, id int64) (ArticleExport, error)
+	// ListRunnable 按照创建的先后查询等待执行的任务，以及在staleBefore之前开始执行但是还没有结束的任务
+	ListRunnable(ctx context.Context, staleBefore int64, limit int) ([]ArticleExport, error)
+	// Claim 抢占任务，返回false说明已经被其他实例抢占了
+	Claim(ctx context.Context, id int64, staleBefore int64) (bool, error)
+	// Finish 写入执行的结果，e.Status是done或者failed
+	Finish(ctx context.Context, e ArticleExport) error
+	// ListExpired 查询在before之前结束的任务
+	ListExpired(ctx context.Context, before int64, limit int) ([]ArticleExport, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type ArticleExportGORMDao struct {
+	db *gorm.DB
+}
+
+func NewArticleExportDao(db *gorm.DB) ArticleExportDao {
+	return &ArticleExportGORMDao{
+		db: db,
+	}
+}
+
+func (dao *ArticleExportGORMDao) Insert(ctx context.Context, e ArticleExport) (int64, error) {
+	now := time.Now().UnixMilli()
+	e.Ctime = now
+	e.Utime = now
+	e.Status = ExportStatusPending
+	err := dao.db.WithContext(ctx).Create(&e).Error
+	return e.ID, err
+}
+
+func (dao *ArticleExportGORMDao) GetByID(ctx context.Context, id int64) (ArticleExport, error) {
+	var e ArticleExport
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&e).Error
+	return e, err
+}
+
+func (dao *ArticleExportGORMDao) ListRunnable(ctx context.Context, staleBefore int64, limit int) ([]ArticleExport, error) {
+	var res []ArticleExport
+	err := dao.runnable(dao.db.WithContext(ctx), staleBefore).
+		Order("id").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *ArticleExportGORMDao) Claim(ctx context.Context, id int64, staleBefore int64) (bool, error) {
+	res := dao.runnable(dao.db.WithContext(ctx).Model(&ArticleExport{}), staleBefore).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status": ExportStatusRunning,
+			"u_time": time.Now().UnixMilli(),
+		})
+	return res.RowsAffected > 0, res.Error
+}
+
+func (dao *ArticleExportGORMDao) runnable(db *gorm.DB, staleBefore int64) *gorm.DB {
+	return db.Where("status = ? or (status = ? and u_time < ?)",
+		ExportStatusPending, ExportStatusRunning, staleBefore)
+}
+
+func (dao *ArticleExportGORMDao) Finish(ctx context.Context, e ArticleExport) error {
+	return dao.db.WithContext(ctx).Model(&ArticleExport{}).
+		Where("id = ? and status = ?", e.ID, ExportStatusRunning).
+		Updates(map[string]any{
+			"status":     e.Status,
+			"object_key": e.ObjectKey,
+			"count":      e.Count,
+			"size":       e.Size,
+			"err_msg":    e.ErrMsg,
+			"u_time":     time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *ArticleExportGORMDao) ListExpired(ctx context.Context, before int64, limit int) ([]ArticleExport, error) {
+	var res []ArticleExport
+	err := dao.db.WithContext(ctx).
+		Where("status in ? and u_time < ?", []int8{ExportStatusDone, ExportStatusFailed}, before).
+		Order("id").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *ArticleExportGORMDao) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Where("id = ?", id).Delete(&ArticleExport{}).Error
+}
+
+// MangoDBArticleExportDao 导出任务的mongo存储实现
+type MangoDBArticleExportDao struct {
+	col  *mongo.Collection
+	node *snowflake.Node
+}
+
+const mongoExportCollection = "article_exports"
+
+func NewMongoArticleExportDao(db *mongo.Database, node *snowflake.Node) ArticleExportDao {
+	return &MangoDBArticleExportDao{
+		col:  db.Collection(mongoExportCollection),
+		node: node,
+	}
+}
+
+func (dao *MangoDBArticleExportDao) Insert(ctx context.Context, e ArticleExport) (int64, error) {
+	e.ID = dao.node.Generate().Int64()
+	now := time.Now().UnixMilli()
+	e.Ctime = now
+	e.Utime = now
+	e.Status = ExportStatusPending
+	_, err := dao.col.InsertOne(ctx, e)
+	if err != nil {
+		return 0, err
+	}
+	return e.ID, nil
+}
+
+func (dao *MangoDBArticleExportDao) GetByID(ctx context.Context, id int64) (ArticleExport, error) {
+	var e ArticleExport
+	err := dao.col.FindOne(ctx, bson.M{"id": id}).Decode(&e)
+	if err == mongo.ErrNoDocuments {
+		return ArticleExport{}, ErrNotFound
+	}
+	return e, err
+}
+
+func (dao *MangoDBArticleExportDao) ListRunnable(ctx context.Context, staleBefore int64, limit int) ([]ArticleExport, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	return dao.find(ctx, dao.runnable(staleBefore), opts)
+}
+
+func (dao *MangoDBArticleExportDao) Claim(ctx context.Context, id int64, staleBefore int64) (bool, error) {
+	filter := dao.runnable(staleBefore)
+	filter["id"] = id
+	res, err := dao.col.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"status": ExportStatusRunning,
+			"u_time": time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (dao *MangoDBArticleExportDao) runnable(staleBefore int64) bson.M {
+	return bson.M{"$or": bson.A{
+		bson.M{"status": ExportStatusPending},
+		bson.M{"status": ExportStatusRunning, "u_time": bson.M{"$lt": staleBefore}},
+	}}
+}
+
+func (dao *MangoDBArticleExportDao) Finish(ctx context.Context, e ArticleExport) error {
+	_, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": e.ID, "status": ExportStatusRunning},
+		bson.M{"$set": bson.M{
+			"status":     e.Status,
+			"object_key": e.ObjectKey,
+			"count":      e.Count,
+			"size":       e.Size,
+			"err_msg":    e.ErrMsg,
+			"u_time":     time.Now().UnixMilli(),
+		}})
+	return err
+}
+
+func (dao *MangoDBArticleExportDao) ListExpired(ctx context.Context, before int64, limit int) ([]ArticleExport, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	return dao.find(ctx, bson.M{
+		"status": bson.M{"$in": bson.A{ExportStatusDone, ExportStatusFailed}},
+		"u_time": bson.M{"$lt": before},
+	}, opts)
+}
+
+func (dao *MangoDBArticleExportDao) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]ArticleExport, error) {
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []ArticleExport
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (dao *MangoDBArticleExportDao) Delete(ctx context.Context, id int64) error {
+	_, err := dao.col.DeleteOne(ctx, bson.M{"id": id})
+	return err
+}
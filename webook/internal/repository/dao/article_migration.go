@@ -0,0 +1,168 @@
+package dao
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm/clause"
+)
+
+/*
+文章在GORM和Mongo存储之间迁移时需要的操作。
+
+迁移时制作库、线上库分开处理，table取MigrationTableArticles或MigrationTablePublished。
+PublishArticle和Article的结构相同，所以统一使用Article传递数据。
+写入时按照id覆盖，保留原来的c_time、u_time，迁移前后的数据完全一致才能进行校验。
+*/
+
+const (
+	MigrationTableArticles  = "articles"
+	MigrationTablePublished = "published_articles"
+)
+
+var MigrationTables = []string{MigrationTableArticles, MigrationTablePublished}
+
+type ArticleMigrationDao interface {
+	ArticleDao
+	// ScanForMigration 按照(u_time, id)升序遍历，afterUtime、afterID是上一批最后一条数据的位置，都为0表示从头开始
+	ScanForMigration(ctx context.Context, table string, afterUtime int64, afterID int64, limit int) ([]Article, error)
+	FindForMigration(ctx context.Context, table string, ids []int64) ([]Article, error)
+	UpsertForMigration(ctx context.Context, table string, arts []Article) error
+	DeleteForMigration(ctx context.Context, table string, ids []int64) error
+}
+
+// CopyArticles 以base中的数据为准覆盖target，base中已经不存在的数据从target中删除
+func CopyArticles(ctx context.Context, table string, base ArticleMigrationDao, target ArticleMigrationDao, ids []int64) error {
+	arts, err := base.FindForMigration(ctx, table, ids)
+	if err != nil {
+		return err
+	}
+	if len(arts) > 0 {
+		err = target.UpsertForMigration(ctx, table, arts)
+		if err != nil {
+			return err
+		}
+	}
+	if len(arts) == len(ids) {
+		return nil
+	}
+	found := make(map[int64]struct{}, len(arts))
+	for _, art := range arts {
+		found[art.ID] = struct{}{}
+	}
+	missing := slice.FilterMap(ids, func(idx int, src int64) (int64, bool) {
+		_, ok := found[src]
+		return src, !ok
+	})
+	return target.DeleteForMigration(ctx, table, missing)
+}
+
+func (dao *ArticleGORMDao) migrationModel(table string) any {
+	if table == MigrationTablePublished {
+		return &PublishArticle{}
+	}
+	return &Article{}
+}
+
+func (dao *ArticleGORMDao) ScanForMigration(ctx context.Context, table string, afterUtime int64, afterID int64, limit int) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).Model(dao.migrationModel(table)).
+		Where("u_time > ? or (u_time = ? and id > ?)", afterUtime, afterUtime, afterID).
+		Order("u_time, id").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *ArticleGORMDao) FindForMigration(ctx context.Context, table string, ids []int64) ([]Article, error) {
+	var arts []Article
+	err := dao.db.WithContext(ctx).Model(dao.migrationModel(table)).
+		Where("id in ?", ids).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (dao *ArticleGORMDao) UpsertForMigration(ctx context.Context, table string, arts []Article) error {
+	db := dao.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true})
+	if table == MigrationTablePublished {
+		pubArts := slice.Map(arts, func(idx int, src Article) PublishArticle {
+			return PublishArticle(src)
+		})
+		return db.Create(&pubArts).Error
+	}
+	return db.Create(&arts).Error
+}
+
+func (dao *ArticleGORMDao) DeleteForMigration(ctx context.Context, table string, ids []int64) error {
+	return dao.db.WithContext(ctx).
+		Where("id in ?", ids).
+		Delete(dao.migrationModel(table)).Error
+}
+
+func (dao *MangoDBArticleDao) migrationCol(table string) *mongo.Collection {
+	if table == MigrationTablePublished {
+		return dao.publishedArtCol
+	}
+	return dao.artCol
+}
+
+func (dao *MangoDBArticleDao) ScanForMigration(ctx context.Context, table string, afterUtime int64, afterID int64, limit int) ([]Article, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"u_time": bson.M{"$gt": afterUtime}},
+		bson.M{"u_time": afterUtime, "id": bson.M{"$gt": afterID}},
+	}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "u_time", Value: 1}, {Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.migrationCol(table).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (dao *MangoDBArticleDao) FindForMigration(ctx context.Context, table string, ids []int64) ([]Article, error) {
+	cursor, err := dao.migrationCol(table).Find(ctx, bson.M{"id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, err
+	}
+	var arts []Article
+	err = cursor.All(ctx, &arts)
+	return arts, err
+}
+
+func (dao *MangoDBArticleDao) UpsertForMigration(ctx context.Context, table string, arts []Article) error {
+	// 只覆盖文章的字段，不能整个替换文档，否则会丢掉内嵌的标签
+	models := slice.Map(arts, func(idx int, src Article) mongo.WriteModel {
+		return mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"id": src.ID}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"id":         src.ID,
+				"title":      src.Title,
+				"content":    src.Content,
+				"status":     src.Status,
+				"author_id":  src.AuthorID,
+				"publish_at": src.PublishAt,
+				"c_time":     src.Ctime,
+				"u_time":     src.Utime,
+				"d_time":     src.Dtime,
+			}}).
+			SetUpsert(true)
+	})
+	_, err := dao.migrationCol(table).BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (dao *MangoDBArticleDao) DeleteForMigration(ctx context.Context, table string, ids []int64) error {
+	_, err := dao.migrationCol(table).DeleteMany(ctx, bson.M{"id": bson.M{"$in": ids}})
+	return err
+}
+
+var (
+	_ ArticleMigrationDao = (*ArticleGORMDao)(nil)
+	_ ArticleMigrationDao = (*MangoDBArticleDao)(nil)
+)
@@ -0,0 +1,260 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+文章的审核记录。
+
+	每次提交审核插入一条pending的记录，审核人员通过Decide抢占记录并写入结果，同一条记录只能被处理一次。
+	审核队列就是全部pending的记录，按照提交的先后顺序处理。
+
+存储的选择和版本记录相同。
+*/
+
+const (
+	ReviewStatusPending int8 = iota + 1
+	ReviewStatusApproved
+	ReviewStatusRejected
+	ReviewStatusCanceled
+)
+
+type ArticleReview struct {
+	ID          int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleID   int64  `gorm:"index" bson:"article_id,omitempty"`
+	AuthorID    int64  `bson:"author_id,omitempty"`
+	SubmitterID int64  `bson:"submitter_id,omitempty"`
+	Title       string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+
+	ReviewerID int64  `bson:"reviewer_id,omitempty"`
+	Status     int8   `gorm:"type:tinyint;index" bson:"status,omitempty"`
+	Comment    string `gorm:"type=varchar(1024)" bson:"comment,omitempty"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+	Utime int64 `json:"u_time" gorm:"column:u_time" bson:"u_time,omitempty"`
+}
+
+type ArticleReviewDao interface {
+	Insert(ctx context.Context, review ArticleReview) (int64, error)
+	GetByID(ctx context.Context, id int64) (ArticleReview, error)
+	// ListPending 按照提交的先后顺序查询待审核的记录
+	ListPending(ctx context.Context, offset int, limit int) ([]ArticleReview, error)
+	// ListByArticle 按照提交时间倒序查询文章的审核记录
+	ListByArticle(ctx context.Context, articleID int64, offset int, limit int) ([]ArticleReview, error)
+	// Decide 写入审核结果，记录已经被处理过时返回ErrNotFound
+	Decide(ctx context.Context, id int64, reviewerID int64, status int8, comment string) error
+	// Reopen 审核通过、驳回后更新文章失败，将记录恢复成pending
+	Reopen(ctx context.Context, id int64) error
+	// CancelPending 取消文章全部待审核的记录
+	CancelPending(ctx context.Context, articleID int64) error
+	// DeleteByArticle 删除文章的全部审核记录
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type ArticleReviewGORMDao struct {
+	db *gorm.DB
+}
+
+func NewArticleReviewDao(db *gorm.DB) ArticleReviewDao {
+	return &ArticleReviewGORMDao{
+		db: db,
+	}
+}
+
+func (dao *ArticleReviewGORMDao) Insert(ctx context.Context, review ArticleReview) (int64, error) {
+	now := time.Now().UnixMilli()
+	review.Ctime = now
+	review.Utime = now
+	review.Status = ReviewStatusPending
+	err := dao.db.WithContext(ctx).Create(&review).Error
+	return review.ID, err
+}
+
+func (dao *ArticleReviewGORMDao) GetByID(ctx context.Context, id int64) (ArticleReview, error) {
+	var review ArticleReview
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&review).Error
+	return review, err
+}
+
+func (dao *ArticleReviewGORMDao) ListPending(ctx context.Context, offset int, limit int) ([]ArticleReview, error) {
+	var reviews []ArticleReview
+	err := dao.db.WithContext(ctx).
+		Where("status = ?", ReviewStatusPending).
+		Order("id").
+		Offset(offset).
+		Limit(limit).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+func (dao *ArticleReviewGORMDao) ListByArticle(ctx context.Context, articleID int64, offset int, limit int) ([]ArticleReview, error) {
+	var reviews []ArticleReview
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&reviews).Error
+	return reviews, err
+}
+
+func (dao *ArticleReviewGORMDao) Decide(ctx context.Context, id int64, reviewerID int64, status int8, comment string) error {
+	res := dao.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("id = ? and status = ?", id, ReviewStatusPending).
+		Updates(map[string]any{
+			"reviewer_id": reviewerID,
+			"status":      status,
+			"comment":     comment,
+			"u_time":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *ArticleReviewGORMDao) Reopen(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("id = ? and status in ?", id, []int8{ReviewStatusApproved, ReviewStatusRejected}).
+		Updates(map[string]any{
+			"reviewer_id": 0,
+			"status":      ReviewStatusPending,
+			"comment":     "",
+			"u_time":      time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *ArticleReviewGORMDao) CancelPending(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).Model(&ArticleReview{}).
+		Where("article_id = ? and status = ?", articleID, ReviewStatusPending).
+		Updates(map[string]any{
+			"status": ReviewStatusCanceled,
+			"u_time": time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *ArticleReviewGORMDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ?", articleID).
+		Delete(&ArticleReview{}).Error
+}
+
+// MangoDBArticleReviewDao 审核记录的mongo存储实现
+type MangoDBArticleReviewDao struct {
+	col  *mongo.Collection
+	node *snowflake.Node
+}
+
+func NewMongoArticleReviewDao(db *mongo.Database, node *snowflake.Node) ArticleReviewDao {
+	return &MangoDBArticleReviewDao{
+		col:  db.Collection("article_reviews"),
+		node: node,
+	}
+}
+
+func (dao *MangoDBArticleReviewDao) Insert(ctx context.Context, review ArticleReview) (int64, error) {
+	// 雪花算法生成的id是递增的，可以直接用id来排序
+	review.ID = dao.node.Generate().Int64()
+	now := time.Now().UnixMilli()
+	review.Ctime = now
+	review.Utime = now
+	review.Status = ReviewStatusPending
+	_, err := dao.col.InsertOne(ctx, review)
+	if err != nil {
+		return 0, err
+	}
+	return review.ID, nil
+}
+
+func (dao *MangoDBArticleReviewDao) GetByID(ctx context.Context, id int64) (ArticleReview, error) {
+	var review ArticleReview
+	err := dao.col.FindOne(ctx, bson.M{"id": id}).Decode(&review)
+	if err == mongo.ErrNoDocuments {
+		return ArticleReview{}, ErrNotFound
+	}
+	return review, err
+}
+
+func (dao *MangoDBArticleReviewDao) ListPending(ctx context.Context, offset int, limit int) ([]ArticleReview, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: 1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return dao.find(ctx, bson.M{"status": ReviewStatusPending}, opts)
+}
+
+func (dao *MangoDBArticleReviewDao) ListByArticle(ctx context.Context, articleID int64, offset int, limit int) ([]ArticleReview, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return dao.find(ctx, bson.M{"article_id": articleID}, opts)
+}
+
+func (dao *MangoDBArticleReviewDao) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]ArticleReview, error) {
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var reviews []ArticleReview
+	err = cursor.All(ctx, &reviews)
+	return reviews, err
+}
+
+func (dao *MangoDBArticleReviewDao) Decide(ctx context.Context, id int64, reviewerID int64, status int8, comment string) error {
+	res, err := dao.col.UpdateOne(ctx, bson.M{"id": id, "status": ReviewStatusPending}, bson.M{
+		"$set": bson.M{
+			"reviewer_id": reviewerID,
+			"status":      status,
+			"comment":     comment,
+			"u_time":      time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBArticleReviewDao) Reopen(ctx context.Context, id int64) error {
+	_, err := dao.col.UpdateOne(ctx, bson.M{
+		"id":     id,
+		"status": bson.M{"$in": []int8{ReviewStatusApproved, ReviewStatusRejected}},
+	}, bson.M{
+		"$set": bson.M{
+			"reviewer_id": 0,
+			"status":      ReviewStatusPending,
+			"comment":     "",
+			"u_time":      time.Now().UnixMilli(),
+		},
+	})
+	return err
+}
+
+func (dao *MangoDBArticleReviewDao) CancelPending(ctx context.Context, articleID int64) error {
+	_, err := dao.col.UpdateMany(ctx, bson.M{"article_id": articleID, "status": ReviewStatusPending}, bson.M{
+		"$set": bson.M{
+			"status": ReviewStatusCanceled,
+			"u_time": time.Now().UnixMilli(),
+		},
+	})
+	return err
+}
+
+func (dao *MangoDBArticleReviewDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	_, err := dao.col.DeleteMany(ctx, bson.M{"article_id": articleID})
+	return err
+}
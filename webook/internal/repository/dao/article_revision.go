@@ -0,0 +1,138 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+文章的版本记录。
+
+版本只会插入，不会更新，因此不需要考虑并发更新的问题。
+
+存储的选择：
+	GORM：制作库、线上库都在mysql中，版本表也放在同一个库中。
+	S3：ArticleS3DAO的元数据仍然存储在mysql中，只有线上库的内容存储在oss中，所以版本记录直接复用GORM的实现。
+	Mongo：版本记录存储在article_revisions集合中。
+*/
+
+type ArticleRevision struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	ArticleID int64 `gorm:"index:idx_article_author" bson:"article_id,omitempty"`
+	AuthorID  int64 `gorm:"index:idx_article_author" bson:"author_id,omitempty"`
+
+	Title   string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+	Content string `gorm:"type:blob" bson:"content,omitempty"`
+	Status  int8   `gorm:"type:tinyint" bson:"status,omitempty"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+}
+
+type ArticleRevisionDao interface {
+	Insert(ctx context.Context, revision ArticleRevision) (int64, error)
+	// ListByArticle 按照版本的创建时间倒序返回
+	ListByArticle(ctx context.Context, articleID int64, authorID int64, offset int, limit int) ([]ArticleRevision, error)
+	GetByID(ctx context.Context, articleID int64, authorID int64, id int64) (ArticleRevision, error)
+	// DeleteByArticle 删除文章的全部版本记录
+	DeleteByArticle(ctx context.Context, articleID int64, authorID int64) error
+}
+
+type ArticleRevisionGORMDao struct {
+	db *gorm.DB
+}
+
+func NewArticleRevisionDao(db *gorm.DB) ArticleRevisionDao {
+	return &ArticleRevisionGORMDao{
+		db: db,
+	}
+}
+
+func (dao *ArticleRevisionGORMDao) Insert(ctx context.Context, revision ArticleRevision) (int64, error) {
+	revision.Ctime = time.Now().UnixMilli()
+	err := dao.db.WithContext(ctx).Create(&revision).Error
+	return revision.ID, err
+}
+
+func (dao *ArticleRevisionGORMDao) ListByArticle(ctx context.Context, articleID int64, authorID int64, offset int, limit int) ([]ArticleRevision, error) {
+	var revisions []ArticleRevision
+	err := dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("article_id = ? and author_id = ?", articleID, authorID).
+		Order("id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&revisions).Error
+	return revisions, err
+}
+
+func (dao *ArticleRevisionGORMDao) GetByID(ctx context.Context, articleID int64, authorID int64, id int64) (ArticleRevision, error) {
+	var revision ArticleRevision
+	err := dao.db.WithContext(ctx).Model(&ArticleRevision{}).
+		Where("id = ? and article_id = ? and author_id = ?", id, articleID, authorID).
+		First(&revision).Error
+	return revision, err
+}
+
+func (dao *ArticleRevisionGORMDao) DeleteByArticle(ctx context.Context, articleID int64, authorID int64) error {
+	return dao.db.WithContext(ctx).
+		Where("article_id = ? and author_id = ?", articleID, authorID).
+		Delete(&ArticleRevision{}).Error
+}
+
+// MangoDBArticleRevisionDao 版本记录的mongo存储实现
+type MangoDBArticleRevisionDao struct {
+	col  *mongo.Collection
+	node *snowflake.Node
+}
+
+func NewMongoArticleRevisionDao(db *mongo.Database, node *snowflake.Node) ArticleRevisionDao {
+	return &MangoDBArticleRevisionDao{
+		col:  db.Collection("article_revisions"),
+		node: node,
+	}
+}
+
+func (dao *MangoDBArticleRevisionDao) Insert(ctx context.Context, revision ArticleRevision) (int64, error) {
+	// 雪花算法生成的id是递增的，可以直接用id来排序
+	revision.ID = dao.node.Generate().Int64()
+	revision.Ctime = time.Now().UnixMilli()
+	_, err := dao.col.InsertOne(ctx, revision)
+	if err != nil {
+		return 0, err
+	}
+	return revision.ID, nil
+}
+
+func (dao *MangoDBArticleRevisionDao) ListByArticle(ctx context.Context, articleID int64, authorID int64, offset int, limit int) ([]ArticleRevision, error) {
+	filter := bson.M{"article_id": articleID, "author_id": authorID}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []ArticleRevision
+	err = cursor.All(ctx, &revisions)
+	return revisions, err
+}
+
+func (dao *MangoDBArticleRevisionDao) GetByID(ctx context.Context, articleID int64, authorID int64, id int64) (ArticleRevision, error) {
+	var revision ArticleRevision
+	filter := bson.M{"id": id, "article_id": articleID, "author_id": authorID}
+	err := dao.col.FindOne(ctx, filter).Decode(&revision)
+	if err == mongo.ErrNoDocuments {
+		return ArticleRevision{}, ErrNotFound
+	}
+	return revision, err
+}
+
+func (dao *MangoDBArticleRevisionDao) DeleteByArticle(ctx context.Context, articleID int64, authorID int64) error {
+	_, err := dao.col.DeleteMany(ctx, bson.M{"article_id": articleID, "author_id": authorID})
+	return err
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestArticleGORMDao_ClaimScheduled(t *testing.T) {
+	now := time.UnixMilli(10_000)
+	lease := time.Minute
+
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		wantOk  bool
+		wantErr error
+	}{
+		{
+			name: "抢占成功，设置租约，不修改状态",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET `claim_until`=\\? WHERE id = \\? and status = \\? and publish_at <= \\? and claim_until < \\?").
+					WithArgs(now.Add(lease).UnixMilli(), int64(1), ArticleStatusPending, now.UnixMilli(), now.UnixMilli()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db
+			},
+			wantOk: true,
+		},
+		{
+			name: "其他实例的租约还没有过期",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET `claim_until`=\\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				return db
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db := newMockGORM(t, tc.mock(t))
+			ok, err := NewArticleDao(db).ClaimScheduled(context.Background(), 1, now, lease)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantOk, ok)
+		})
+	}
+}
+
+// newMockGORM 使用sqlmock创建gorm.DB，不自动开启事务
+func newMockGORM(t *testing.T, sqlDB *sql.DB) *gorm.DB {
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db
+}
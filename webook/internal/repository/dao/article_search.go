@@ -0,0 +1,90 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+基于MySQL FULLTEXT索引的文章搜索。
+
+ngram解析器会把中文按照ngram_token_size（默认2）切分，和英文一起建立全文索引。
+只保存已发布的文章，文章撤回时删除对应的记录。
+*/
+
+type ArticleSearch struct {
+	// ID 即文章的id
+	ID       int64  `gorm:"primaryKey"`
+	AuthorID int64  `gorm:"index"`
+	Title    string `gorm:"type:varchar(1024);index:idx_title_content,class:FULLTEXT,option:WITH PARSER ngram"`
+	Content  string `gorm:"type:longtext;index:idx_title_content,class:FULLTEXT,option:WITH PARSER ngram"`
+
+	// Score 搜索时计算出来的相关度，不是表中的列
+	Score float64 `gorm:"->;-:migration"`
+
+	Ctime int64 `gorm:"column:c_time"`
+	Utime int64 `gorm:"column:u_time"`
+}
+
+type ArticleSearchDao interface {
+	Upsert(ctx context.Context, article ArticleSearch) error
+	Delete(ctx context.Context, id int64) error
+	// Search 按照相关度倒序返回命中的文章，以及命中的总数
+	Search(ctx context.Context, query string, offset int, limit int) ([]ArticleSearch, int64, error)
+}
+
+type ArticleSearchGORMDao struct {
+	db *gorm.DB
+}
+
+func NewArticleSearchDao(db *gorm.DB) ArticleSearchDao {
+	return &ArticleSearchGORMDao{
+		db: db,
+	}
+}
+
+func (dao *ArticleSearchGORMDao) Upsert(ctx context.Context, article ArticleSearch) error {
+	now := time.Now().UnixMilli()
+	if article.Ctime == 0 {
+		article.Ctime = now
+	}
+	if article.Utime == 0 {
+		article.Utime = now
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"title":   article.Title,
+			"content": article.Content,
+			"u_time":  article.Utime,
+		}),
+	}).Create(&article).Error
+}
+
+func (dao *ArticleSearchGORMDao) Delete(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Where("id = ?", id).Delete(&ArticleSearch{}).Error
+}
+
+func (dao *ArticleSearchGORMDao) Search(ctx context.Context, query string, offset int, limit int) ([]ArticleSearch, int64, error) {
+	const match = "MATCH(title, content) AGAINST(? IN NATURAL LANGUAGE MODE)"
+
+	var total int64
+	err := dao.db.WithContext(ctx).Model(&ArticleSearch{}).
+		Where(match, query).
+		Count(&total).Error
+	if err != nil || total == 0 {
+		return nil, total, err
+	}
+
+	var articles []ArticleSearch
+	err = dao.db.WithContext(ctx).Model(&ArticleSearch{}).
+		Select("*, "+match+" AS score", query).
+		Where(match, query).
+		Order("score desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&articles).Error
+	return articles, total, err
+}
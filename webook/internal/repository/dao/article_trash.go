@@ -0,0 +1,164 @@
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+回收站
+
+	删除文章时只在制作库中记录删除时间d_time，文章变回草稿（定时发布也一并取消），线上库中的文章直接删除。
+	回收站中的文章不会出现在作者的文章列表中，也不能被修改，恢复后是一篇草稿，需要重新发布。
+	彻底删除时才删除制作库中的文章，只能彻底删除回收站中的文章。
+*/
+
+// mongoNotDeleted 匹配没有被删除的文章，d_time为0或者不存在
+var mongoNotDeleted = bson.M{"$not": bson.M{"$gt": 0}}
+
+func (dao *ArticleGORMDao) SoftDelete(ctx context.Context, id int64, authorID int64, now time.Time) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? and author_id = ? and d_time = 0", id, authorID).
+			Updates(map[string]any{
+				"d_time":     now.UnixMilli(),
+				"status":     ArticleStatusUnpublished,
+				"publish_at": 0,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("id = ?", id).Delete(&PublishArticle{}).Error
+	})
+}
+
+func (dao *ArticleGORMDao) ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]Article, error) {
+	var articles []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("author_id = ? and d_time > 0", authorID).
+		Order("d_time desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+func (dao *ArticleGORMDao) Restore(ctx context.Context, id int64, authorID int64) error {
+	res := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("id = ? and author_id = ? and d_time > 0", id, authorID).
+		Updates(map[string]any{
+			"d_time": 0,
+			"u_time": time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *ArticleGORMDao) Purge(ctx context.Context, id int64, authorID int64) error {
+	res := dao.db.WithContext(ctx).
+		Where("id = ? and author_id = ? and d_time > 0", id, authorID).
+		Delete(&Article{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *ArticleGORMDao) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	var articles []Article
+	err := dao.db.WithContext(ctx).Model(&Article{}).
+		Where("d_time > 0 and d_time < ?", before.UnixMilli()).
+		Order("d_time").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+func (dao *MangoDBArticleDao) SoftDelete(ctx context.Context, id int64, authorID int64, now time.Time) error {
+	filter := bson.M{"id": id, "author_id": authorID, "d_time": mongoNotDeleted}
+	res, err := dao.artCol.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"d_time":     now.UnixMilli(),
+			"status":     ArticleStatusUnpublished,
+			"publish_at": 0,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	_, err = dao.publishedArtCol.DeleteOne(ctx, bson.M{"id": id})
+	return err
+}
+
+func (dao *MangoDBArticleDao) ListTrash(ctx context.Context, authorID int64, offset int, limit int) ([]Article, error) {
+	filter := bson.M{"author_id": authorID, "d_time": bson.M{"$gt": 0}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "d_time", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	return dao.findTrash(ctx, filter, opts)
+}
+
+func (dao *MangoDBArticleDao) Restore(ctx context.Context, id int64, authorID int64) error {
+	filter := bson.M{"id": id, "author_id": authorID, "d_time": bson.M{"$gt": 0}}
+	res, err := dao.artCol.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"d_time": 0,
+			"u_time": time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBArticleDao) Purge(ctx context.Context, id int64, authorID int64) error {
+	filter := bson.M{"id": id, "author_id": authorID, "d_time": bson.M{"$gt": 0}}
+	res, err := dao.artCol.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBArticleDao) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]Article, error) {
+	filter := bson.M{"d_time": bson.M{"$gt": 0, "$lt": before.UnixMilli()}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "d_time", Value: 1}}).
+		SetLimit(int64(limit))
+	return dao.findTrash(ctx, filter, opts)
+}
+
+func (dao *MangoDBArticleDao) findTrash(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]Article, error) {
+	cursor, err := dao.artCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cursor.All(ctx, &articles)
+	return articles, err
+}
@@ -0,0 +1,100 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+)
+
+/*
+文章的版本号，用于乐观锁。
+
+	插入时版本号是1，每次通过UpdateByID修改制作库（包括Sync）版本号加一。
+	UpdateByID传入的Version大于0时只更新版本号一致的文章，不一致时返回ErrVersionConflict。
+	Version为0表示不校验，用于定时发布、审核通过等服务端发起的更新，它们更新的文章是刚刚查询出来的。
+	只修改状态的操作（撤回、删除等）不改变版本号，线上库中的版本号没有意义。
+	所以审核通过、驳回除了带上读取到的版本号，还要带上FromStatus，状态已经变化时返回ErrStatusConflict。
+*/
+
+var (
+	// ErrVersionConflict 文章已经被其他请求修改过了
+	ErrVersionConflict = errors.New("article version conflict")
+	// ErrStatusConflict 文章的状态已经被其他请求修改过了
+	ErrStatusConflict = errors.New("article status conflict")
+)
+
+// whereVersion 限制只更新版本号一致的文章
+func whereVersion(db *gorm.DB, article Article) *gorm.DB {
+	if article.Version > 0 {
+		db = db.Where("version = ?", article.Version)
+	}
+	return db
+}
+
+// whereStatus 限制只更新状态是FromStatus的文章
+func whereStatus(db *gorm.DB, article Article) *gorm.DB {
+	if article.FromStatus > 0 {
+		db = db.Where("status = ?", article.FromStatus)
+	}
+	return db
+}
+
+// versionConflict 更新没有影响任何行时，区分是版本号、状态冲突还是没有权限，没有冲突时返回nil，查询失败时返回查询的错误
+func versionConflict(ctx context.Context, db *gorm.DB, article Article) error {
+	if article.Version == 0 && article.FromStatus == 0 {
+		return nil
+	}
+	var current Article
+	err := db.WithContext(ctx).Model(&Article{}).Select("version", "status").
+		Where("id = ? and author_id = ? and d_time = 0", article.ID, article.AuthorID).
+		First(&current).Error
+	if err == gorm.ErrRecordNotFound {
+		// 文章不存在、不属于该作者或者已经移入回收站
+		return notFoundConflict(article)
+	}
+	if err != nil {
+		return err
+	}
+	return conflictWith(current, article)
+}
+
+// versionConflict 和GORM实现中的versionConflict相同
+func (dao *MangoDBArticleDao) versionConflict(ctx context.Context, article Article) error {
+	if article.Version == 0 && article.FromStatus == 0 {
+		return nil
+	}
+	var current Article
+	err := dao.artCol.FindOne(ctx, bson.M{
+		"id":        article.ID,
+		"author_id": article.AuthorID,
+		"d_time":    mongoNotDeleted,
+	}).Decode(&current)
+	if err == mongo.ErrNoDocuments {
+		return notFoundConflict(article)
+	}
+	if err != nil {
+		return err
+	}
+	return conflictWith(current, article)
+}
+
+// notFoundConflict 查询不到文章时，校验状态的更新视为状态冲突，其他情况按照没有权限处理
+func notFoundConflict(article Article) error {
+	if article.FromStatus > 0 {
+		return ErrStatusConflict
+	}
+	return nil
+}
+
+// conflictWith 比较当前的文章和更新时期望的版本号、状态
+func conflictWith(current Article, article Article) error {
+	if article.Version > 0 && current.Version != article.Version {
+		return ErrVersionConflict
+	}
+	if article.FromStatus > 0 && current.Status != article.FromStatus {
+		return ErrStatusConflict
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestArticleGORMDao_UpdateByIDVersion(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		mock func(t *testing.T) *sql.DB
+
+		article Article
+
+		wantErr error
+	}{
+		{
+			name: "版本号一致",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*`version`=version \\+ 1.* AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3},
+		},
+		{
+			name: "版本号冲突",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version`,`status` FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "status"}).AddRow(4, 1))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3},
+			wantErr: ErrVersionConflict,
+		},
+		{
+			name: "版本号一致但是没有权限",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version`,`status` FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "status"}).AddRow(3, 1))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3},
+			wantErr: errors.New("unauthorized operation, article_id: 1 author_id: 2000"),
+		},
+		{
+			name: "状态已经变化",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* AND version = \\? AND status = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				// 取消定时发布不改变版本号
+				mock.ExpectQuery("SELECT `version`,`status` FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "status"}).AddRow(3, ArticleStatusUnpublished))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3, FromStatus: ArticleStatusPending},
+			wantErr: ErrStatusConflict,
+		},
+		{
+			name: "文章已经移入回收站",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* AND status = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version`,`status` FROM `articles`").
+					WillReturnRows(sqlmock.NewRows([]string{"version", "status"}))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3, FromStatus: ArticleStatusPending},
+			wantErr: ErrStatusConflict,
+		},
+		{
+			name: "区分冲突时查询失败",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .* AND version = \\?").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery("SELECT `version`,`status` FROM `articles`").
+					WillReturnError(errors.New("mock db error"))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000, Version: 3},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "不校验版本号",
+			mock: func(t *testing.T) *sql.DB {
+				db, mock, err := sqlmock.New()
+				require.NoError(t, err)
+				mock.ExpectExec("UPDATE `articles` SET .*d_time = 0\\)?$").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				return db
+			},
+			article: Article{ID: 1, AuthorID: 2000},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB := tc.mock(t)
+
+			db, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      sqlDB,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				DisableAutomaticPing:   true,
+				SkipDefaultTransaction: true,
+			})
+			require.NoError(t, err)
+
+			err = NewArticleDao(db).UpdateByID(context.Background(), tc.article)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+关注流。
+
+	粉丝少的作者发布文章时写扩散：给每个粉丝的收件箱（feed_inboxes）插入一条记录。
+	粉丝多的作者发布文章时读扩散：只写入作者的发件箱（feed_outboxes），粉丝读取时再去查询。
+	同一篇文章重新发布时只更新标题和摘要，published_at保持第一次发布的时间。
+	文章撤回、删除时从收件箱和发件箱中删除，之后再次发布会重新分发。
+*/
+
+type FeedInbox struct {
+	ID int64 `gorm:"primaryKey,autoIncrement"`
+	// 按照(published_at, article_id)倒序查询用户的收件箱，按照article_id删除文章
+	Uid         int64  `gorm:"uniqueIndex:idx_uid_article_id,priority:1;index:idx_uid_published_at,priority:1"`
+	ArticleID   int64  `gorm:"uniqueIndex:idx_uid_article_id,priority:2;index:idx_uid_published_at,priority:3;index:idx_article_id"`
+	AuthorID    int64  `gorm:"index"`
+	Title       string `gorm:"type:varchar(1024)"`
+	Abstract    string `gorm:"type:varchar(1024)"`
+	PublishedAt int64  `gorm:"index:idx_uid_published_at,priority:2"`
+}
+
+type FeedOutbox struct {
+	ID          int64  `gorm:"primaryKey,autoIncrement"`
+	AuthorID    int64  `gorm:"index:idx_author_published_at,priority:1"`
+	ArticleID   int64  `gorm:"uniqueIndex"`
+	Title       string `gorm:"type:varchar(1024)"`
+	Abstract    string `gorm:"type:varchar(1024)"`
+	PublishedAt int64  `gorm:"index:idx_author_published_at,priority:2"`
+}
+
+type FeedDao interface {
+	// InsertInbox 批量写入粉丝的收件箱，已经存在时只更新标题和摘要
+	InsertInbox(ctx context.Context, items []FeedInbox) error
+	// UpsertOutbox 写入作者的发件箱，已经存在时只更新标题和摘要
+	UpsertOutbox(ctx context.Context, item FeedOutbox) error
+	// Exists 文章是否在收件箱、发件箱中
+	Exists(ctx context.Context, articleID int64) (inbox bool, outbox bool, err error)
+	// DeleteByArticle 从收件箱和发件箱中删除文章
+	DeleteByArticle(ctx context.Context, articleID int64) error
+
+	// ListInbox 按照(published_at, article_id)倒序查询uid的收件箱中authorIDs发布的文章，
+	// beforeTime、beforeID是上一页最后一条记录的位置，都为0表示第一页
+	ListInbox(ctx context.Context, uid int64, authorIDs []int64, beforeTime int64, beforeID int64, limit int) ([]FeedInbox, error)
+	// ListOutbox 查询authorIDs的发件箱，分页方式和ListInbox相同
+	ListOutbox(ctx context.Context, authorIDs []int64, beforeTime int64, beforeID int64, limit int) ([]FeedOutbox, error)
+}
+
+type GORMFeedDao struct {
+	db *gorm.DB
+}
+
+func NewFeedDao(db *gorm.DB) FeedDao {
+	return &GORMFeedDao{
+		db: db,
+	}
+}
+
+func (dao *GORMFeedDao) InsertInbox(ctx context.Context, items []FeedInbox) error {
+	if len(items) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "abstract"}),
+	}).Create(&items).Error
+}
+
+func (dao *GORMFeedDao) UpsertOutbox(ctx context.Context, item FeedOutbox) error {
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"title", "abstract"}),
+	}).Create(&item).Error
+}
+
+func (dao *GORMFeedDao) Exists(ctx context.Context, articleID int64) (bool, bool, error) {
+	db := dao.db.WithContext(ctx)
+	var inbox, outbox []int64
+	err := db.Model(&FeedInbox{}).Where("article_id = ?", articleID).Limit(1).Pluck("id", &inbox).Error
+	if err != nil {
+		return false, false, err
+	}
+	err = db.Model(&FeedOutbox{}).Where("article_id = ?", articleID).Limit(1).Pluck("id", &outbox).Error
+	return len(inbox) > 0, len(outbox) > 0, err
+}
+
+func (dao *GORMFeedDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("article_id = ?", articleID).Delete(&FeedInbox{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("article_id = ?", articleID).Delete(&FeedOutbox{}).Error
+	})
+}
+
+func (dao *GORMFeedDao) ListInbox(ctx context.Context, uid int64, authorIDs []int64,
+	beforeTime int64, beforeID int64, limit int) ([]FeedInbox, error) {
+	var res []FeedInbox
+	if len(authorIDs) == 0 {
+		return res, nil
+	}
+	err := feedKeyset(dao.db.WithContext(ctx), beforeTime, beforeID).
+		Where("uid = ? and author_id in ?", uid, authorIDs).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFeedDao) ListOutbox(ctx context.Context, authorIDs []int64,
+	beforeTime int64, beforeID int64, limit int) ([]FeedOutbox, error) {
+	var res []FeedOutbox
+	if len(authorIDs) == 0 {
+		return res, nil
+	}
+	err := feedKeyset(dao.db.WithContext(ctx), beforeTime, beforeID).
+		Where("author_id in ?", authorIDs).
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+// feedKeyset 和keyset相同，排序键是(published_at, article_id)
+func feedKeyset(db *gorm.DB, beforeTime int64, beforeID int64) *gorm.DB {
+	if beforeTime > 0 || beforeID > 0 {
+		db = db.Where("published_at < ? or (published_at = ? and article_id < ?)", beforeTime, beforeTime, beforeID)
+	}
+	return db.Order("published_at desc, article_id desc")
+}
@@ -0,0 +1,126 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGORMFeedDao_Exists(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantInbox  bool
+		wantOutbox bool
+		wantErr    error
+	}{
+		{
+			name: "写扩散过",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id` FROM `feed_inboxes` WHERE article_id = \\? LIMIT \\?").
+					WithArgs(int64(1), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+				mock.ExpectQuery("SELECT `id` FROM `feed_outboxes` WHERE article_id = \\? LIMIT \\?").
+					WithArgs(int64(1), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantInbox: true,
+		},
+		{
+			name: "读扩散过",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id` FROM `feed_inboxes`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectQuery("SELECT `id` FROM `feed_outboxes`").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+			},
+			wantOutbox: true,
+		},
+		{
+			name: "查询失败",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id` FROM `feed_inboxes`").
+					WillReturnError(errors.New("mock db error"))
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			inbox, outbox, err := NewFeedDao(newMockGORM(t, sqlDB)).Exists(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantInbox, inbox)
+			assert.Equal(t, tc.wantOutbox, outbox)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMFeedDao_DeleteByArticle(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantErr error
+	}{
+		{
+			name: "从收件箱和发件箱中删除",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `feed_inboxes` WHERE article_id = \\?").
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM `feed_outboxes` WHERE article_id = \\?").
+					WithArgs(int64(1)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "删除失败时回滚",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `feed_inboxes`").
+					WillReturnError(errors.New("mock db error"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			err = NewFeedDao(newMockGORM(t, sqlDB)).DeleteByArticle(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGORMFeedDao_InsertInbox(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	// 重新发布时只更新标题和摘要，不改变published_at，也不会插入重复的记录
+	mock.ExpectExec("INSERT INTO `feed_inboxes` .* ON DUPLICATE KEY UPDATE `title`=VALUES\\(`title`\\),`abstract`=VALUES\\(`abstract`\\)$").
+		WillReturnResult(sqlmock.NewResult(1, 2))
+
+	err = NewFeedDao(newMockGORM(t, sqlDB)).InsertInbox(context.Background(), []FeedInbox{
+		{Uid: 1001, ArticleID: 1, AuthorID: 100, Title: "title", PublishedAt: 1000},
+		{Uid: 1002, ArticleID: 1, AuthorID: 100, Title: "title", PublishedAt: 1000},
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,159 @@
+package dao
+
+import (
+	"context"
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+关注关系。
+
+	follow_relations中一行表示follower关注了followee，取消关注时直接删除。
+	follow_statics冗余了用户的粉丝数和关注数，和关注关系在同一个事务中修改。
+	关注时锁住关注者的统计数据再检查关注数，同时关注多个用户也不会超过上限。
+*/
+
+type FollowRelation struct {
+	ID int64 `gorm:"primaryKey,autoIncrement"`
+	// 查询关注列表：where follower = ? order by id desc
+	Follower int64 `gorm:"uniqueIndex:idx_follower_followee,priority:1"`
+	// 查询粉丝列表：where followee = ? order by id desc
+	Followee int64 `gorm:"uniqueIndex:idx_follower_followee,priority:2;index"`
+	Ctime    int64 `gorm:"column:c_time"`
+}
+
+type FollowStatics struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex"`
+	Followers int64
+	Followees int64
+	Ctime     int64 `gorm:"column:c_time"`
+	Utime     int64 `gorm:"column:u_time"`
+}
+
+type FollowDao interface {
+	// Insert 关注，已经关注过时返回false，关注数已经达到maxFollowees时返回ErrTooManyFollowees
+	Insert(ctx context.Context, follower int64, followee int64, maxFollowees int64) (bool, error)
+	// Delete 取消关注，没有关注时返回false
+	Delete(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Get 没有关注时返回ErrNotFound
+	Get(ctx context.Context, follower int64, followee int64) (FollowRelation, error)
+
+	// ListFollowers 按照关注时间倒序查询粉丝，maxID是上一页最后一条记录的id，为0时查询第一页
+	ListFollowers(ctx context.Context, followee int64, maxID int64, limit int) ([]FollowRelation, error)
+	// ListFollowees 按照关注时间倒序查询关注的用户，分页方式和ListFollowers相同
+	ListFollowees(ctx context.Context, follower int64, maxID int64, limit int) ([]FollowRelation, error)
+	// GetStatics 用户还没有关注、被关注过时返回零值
+	GetStatics(ctx context.Context, uid int64) (FollowStatics, error)
+}
+
+type GORMFollowDao struct {
+	db *gorm.DB
+}
+
+func NewFollowDao(db *gorm.DB) FollowDao {
+	return &GORMFollowDao{
+		db: db,
+	}
+}
+
+func (dao *GORMFollowDao) Insert(ctx context.Context, follower int64, followee int64, maxFollowees int64) (bool, error) {
+	var inserted bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var statics FollowStatics
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ?", follower).Limit(1).Find(&statics).Error
+		if err != nil {
+			return err
+		}
+		if statics.Followees >= maxFollowees {
+			return ErrTooManyFollowees
+		}
+		now := time.Now().UnixMilli()
+		err = tx.Create(&FollowRelation{Follower: follower, Followee: followee, Ctime: now}).Error
+		if me, ok := err.(*mysql.MySQLError); ok && me.Number == 1062 {
+			// 已经关注过了
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		inserted = true
+		if err = dao.incrStatics(tx, follower, "followees", 1, now); err != nil {
+			return err
+		}
+		return dao.incrStatics(tx, followee, "followers", 1, now)
+	})
+	return inserted, err
+}
+
+func (dao *GORMFollowDao) Delete(ctx context.Context, follower int64, followee int64) (bool, error) {
+	var deleted bool
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("follower = ? and followee = ?", follower, followee).Delete(&FollowRelation{})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		deleted = true
+		now := time.Now().UnixMilli()
+		if err := dao.incrStatics(tx, follower, "followees", -1, now); err != nil {
+			return err
+		}
+		return dao.incrStatics(tx, followee, "followers", -1, now)
+	})
+	return deleted, err
+}
+
+// incrStatics 修改用户的粉丝数或者关注数，用户还没有统计数据时插入
+func (dao *GORMFollowDao) incrStatics(tx *gorm.DB, uid int64, column string, delta int64, now int64) error {
+	statics := FollowStatics{Uid: uid, Ctime: now, Utime: now}
+	if column == "followers" {
+		statics.Followers = max(delta, 0)
+	} else {
+		statics.Followees = max(delta, 0)
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			column:   gorm.Expr(column+" + ?", delta),
+			"u_time": now,
+		}),
+	}).Create(&statics).Error
+}
+
+func (dao *GORMFollowDao) Get(ctx context.Context, follower int64, followee int64) (FollowRelation, error) {
+	var res FollowRelation
+	err := dao.db.WithContext(ctx).
+		Where("follower = ? and followee = ?", follower, followee).
+		First(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDao) ListFollowers(ctx context.Context, followee int64, maxID int64, limit int) ([]FollowRelation, error) {
+	return dao.list(ctx, "followee", followee, maxID, limit)
+}
+
+func (dao *GORMFollowDao) ListFollowees(ctx context.Context, follower int64, maxID int64, limit int) ([]FollowRelation, error) {
+	return dao.list(ctx, "follower", follower, maxID, limit)
+}
+
+func (dao *GORMFollowDao) list(ctx context.Context, column string, uid int64, maxID int64, limit int) ([]FollowRelation, error) {
+	q := dao.db.WithContext(ctx).Where(column+" = ?", uid)
+	if maxID > 0 {
+		q = q.Where("id < ?", maxID)
+	}
+	var res []FollowRelation
+	err := q.Order("id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (dao *GORMFollowDao) GetStatics(ctx context.Context, uid int64) (FollowStatics, error) {
+	var res FollowStatics
+	err := dao.db.WithContext(ctx).Where("uid = ?", uid).First(&res).Error
+	if err == ErrNotFound {
+		return FollowStatics{Uid: uid}, nil
+	}
+	return res, err
+}
@@ -0,0 +1,76 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGORMFollowDao_Insert(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(mock sqlmock.Sqlmock)
+
+		wantInserted bool
+		wantErr      error
+	}{
+		{
+			name: "关注成功",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `follow_statics` WHERE uid = \\? LIMIT \\? FOR UPDATE").
+					WithArgs(int64(100), 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "followees"}).AddRow(1, 100, 9))
+				mock.ExpectExec("INSERT INTO `follow_relations`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE `followees`=followees \\+ \\?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec("INSERT INTO `follow_statics` .* ON DUPLICATE KEY UPDATE `followers`=followers \\+ \\?").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			wantInserted: true,
+		},
+		{
+			name: "第一次关注，还没有统计数据",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `follow_statics`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "followees"}))
+				mock.ExpectExec("INSERT INTO `follow_relations`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `follow_statics`").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantInserted: true,
+		},
+		{
+			name: "关注数达到上限",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `follow_statics`").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid", "followees"}).AddRow(1, 100, 10))
+				mock.ExpectRollback()
+			},
+			wantErr: ErrTooManyFollowees,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sqlDB, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			tc.mock(mock)
+
+			inserted, err := NewFollowDao(newMockGORM(t, sqlDB)).Insert(context.Background(), 100, 200, 10)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantInserted, inserted)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,10 +5,48 @@ import (
 )
 
 func InitTable(db *gorm.DB) error {
+	err := backfillArticleDtime(db)
+	if err != nil {
+		return err
+	}
 	return db.AutoMigrate(
 		&User{},
 		&Article{},
 		&PublishArticle{},
+		&ArticleRevision{},
+		&ArticleCollaborator{},
+		&ArticleReview{},
+		&Series{},
+		&SeriesArticle{},
+		&ArticleExport{},
+		&Media{},
+		&ArticleSearch{},
+		&Tag{},
+		&ArticleTag{},
+		&PublishedArticleV2{},
 		&Job{},
+		&FollowRelation{},
+		&FollowStatics{},
+		&FeedInbox{},
+		&FeedOutbox{},
 	)
 }
+
+// backfillArticleDtime 回收站上线之前的文章d_time是NULL，d_time = 0匹配不到这些文章，
+// AutoMigrate把d_time改成NOT NULL之前先补成0
+func backfillArticleDtime(db *gorm.DB) error {
+	for _, model := range []any{&Article{}, &PublishArticle{}} {
+		if !db.Migrator().HasColumn(model, "d_time") {
+			continue
+		}
+		err := fillArticleDtime(db, model)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func fillArticleDtime(db *gorm.DB, model any) error {
+	return db.Model(model).Where("d_time IS NULL").UpdateColumn("d_time", 0).Error
+}
@@ -0,0 +1,33 @@
+package dao
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// 回收站上线之前插入的文章没有d_time，补成0之后才能被d_time = 0查询到
+func TestFillArticleDtime(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	for _, table := range []string{"articles", "publish_articles"} {
+		mock.ExpectExec("UPDATE `" + table + "` SET `d_time`=\\? WHERE d_time IS NULL").
+			WithArgs(0).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	// 补齐之后旧文章出现在作者的列表中
+	mock.ExpectQuery("SELECT \\* FROM `articles` WHERE author_id = \\? and d_time = 0").
+		WithArgs(int64(2000), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "author_id", "title", "d_time"}).
+			AddRow(1, 2000, "旧文章", 0))
+
+	db := newMockGORM(t, sqlDB)
+	require.NoError(t, fillArticleDtime(db, &Article{}))
+	require.NoError(t, fillArticleDtime(db, &PublishArticle{}))
+	arts, err := NewArticleDao(db).GetByAuthor(context.Background(), 2000, 0, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Article{{ID: 1, AuthorID: 2000, Title: "旧文章"}}, arts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package dao
 import (
 	"context"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"time"
 )
 
@@ -16,7 +17,7 @@ const (
 type Job struct {
 	ID int64 `json:"id" gorm:"primaryKey, autoincrement"`
 
-	Name string `json:"name" gorm:"unique_index"`
+	Name string `json:"name" gorm:"type:varchar(128);uniqueIndex"`
 
 	Cfg string `json:"cfg"`
 
@@ -36,6 +37,9 @@ type Job struct {
 }
 
 type JobDao interface {
+	// Insert 新建任务，同名的任务已经存在时不做任何处理
+	Insert(ctx context.Context, job Job) error
+
 	Preempt(ctx context.Context) (Job, error)
 
 	UpdateUTime(ctx context.Context, id int64, now time.Time) error
@@ -48,6 +52,15 @@ type jobDao struct {
 	db *gorm.DB
 }
 
+func (dao *jobDao) Insert(ctx context.Context, job Job) error {
+	now := time.Now().UnixMilli()
+	job.Status = int8(jobStatusWaiting)
+	job.CTime = now
+	job.UTime = now
+	// 多个实例启动时都会尝试注册同一个任务，依赖name的唯一索引去重
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&job).Error
+}
+
 func (dao *jobDao) Release(ctx context.Context, id int64) error {
 	return dao.db.WithContext(ctx).Model(&Job{}).Where("id=?", id).
 		Where("id = ? and status = ?", id, jobStatusRunning).
@@ -19,18 +19,50 @@ type MangoDBArticleDao struct {
 }
 
 func (dao *MangoDBArticleDao) GetByID(ctx context.Context, id int64) (Article, error) {
-	//TODO implement me
-	panic("implement me")
+	var article Article
+	err := dao.artCol.FindOne(ctx, bson.M{"id": id}).Decode(&article)
+	if err == mongo.ErrNoDocuments {
+		return Article{}, ErrNotFound
+	}
+	return article, err
 }
 
-func (dao *MangoDBArticleDao) ListPub(ctx context.Context, start time.Time, offset int, limit int) ([]Article, error) {
-	//TODO implement me
-	panic("implement me")
+func (dao *MangoDBArticleDao) ListPub(ctx context.Context, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
+	filter := mongoKeyset(bson.M{"status": ArticleStatusPublished}, beforeUtime, beforeID)
+	return dao.findArticles(ctx, filter, limit)
 }
 
 func (dao *MangoDBArticleDao) GetPubByID(ctx context.Context, id int64) (PublishArticle, error) {
-	//TODO implement me
-	panic("implement me")
+	var article PublishArticle
+	err := dao.publishedArtCol.FindOne(ctx, bson.M{"id": id}).Decode(&article)
+	if err == mongo.ErrNoDocuments {
+		return PublishArticle{}, ErrNotFound
+	}
+	return article, err
+}
+
+func (dao *MangoDBArticleDao) GetPubByIDs(ctx context.Context, ids []int64) ([]PublishArticle, error) {
+	opts := options.Find().SetProjection(bson.M{"content": 0})
+	cursor, err := dao.publishedArtCol.Find(ctx, bson.M{"id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishArticle
+	err = cursor.All(ctx, &articles)
+	return articles, err
+}
+
+func (dao *MangoDBArticleDao) ScanPub(ctx context.Context, startID int64, limit int) ([]PublishArticle, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.publishedArtCol.Find(ctx, bson.M{"id": bson.M{"$gt": startID}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishArticle
+	err = cursor.All(ctx, &articles)
+	return articles, err
 }
 
 func NewMongoArticleDao(db *mongo.Database, node *snowflake.Node) ArticleDao {
@@ -42,8 +74,55 @@ func NewMongoArticleDao(db *mongo.Database, node *snowflake.Node) ArticleDao {
 	}
 }
 
-func (dao *MangoDBArticleDao) GetByAuthor(ctx context.Context, uid int64, offset int, limit int) ([]Article, error) {
-	panic("implement me")
+func (dao *MangoDBArticleDao) GetByAuthor(ctx context.Context, uid int64, beforeUtime int64, beforeID int64, limit int) ([]Article, error) {
+	filter := mongoKeyset(bson.M{"author_id": uid, "d_time": mongoNotDeleted}, beforeUtime, beforeID)
+	return dao.findArticles(ctx, filter, limit)
+}
+
+func (dao *MangoDBArticleDao) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"id": 1}).
+		SetSort(bson.M{"id": -1}).
+		SetLimit(int64(limit))
+	cursor, err := dao.artCol.Find(ctx, bson.M{"author_id": uid, "d_time": mongoNotDeleted}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cursor.All(ctx, &articles)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(articles))
+	for _, art := range articles {
+		ids = append(ids, art.ID)
+	}
+	return ids, nil
+}
+
+// findArticles 在制作库中按照(u_time, id)倒序查询
+func (dao *MangoDBArticleDao) findArticles(ctx context.Context, filter bson.M, limit int) ([]Article, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "u_time", Value: -1}, {Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.artCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cursor.All(ctx, &articles)
+	return articles, err
+}
+
+// mongoKeyset 游标分页的过滤条件，和GORM实现中的keyset语义相同
+func mongoKeyset(filter bson.M, beforeUtime int64, beforeID int64) bson.M {
+	if beforeUtime > 0 || beforeID > 0 {
+		filter["$or"] = bson.A{
+			bson.M{"u_time": bson.M{"$lt": beforeUtime}},
+			bson.M{"u_time": beforeUtime, "id": bson.M{"$lt": beforeID}},
+		}
+	}
+	return filter
 }
 
 func (dao *MangoDBArticleDao) Insert(ctx context.Context, article Article) (int64, error) {
@@ -51,6 +130,7 @@ func (dao *MangoDBArticleDao) Insert(ctx context.Context, article Article) (int6
 	now := time.Now().UnixMilli()
 	article.Ctime = now
 	article.Utime = now
+	article.Version = 1
 
 	_, err := dao.artCol.InsertOne(ctx, article)
 	if err != nil {
@@ -62,20 +142,33 @@ func (dao *MangoDBArticleDao) Insert(ctx context.Context, article Article) (int6
 func (dao *MangoDBArticleDao) UpdateByID(ctx context.Context, article Article) error {
 	now := time.Now().UnixMilli()
 	article.Utime = now
-	filter := bson.D{{"id", article.ID}, {"author_id", article.AuthorID}}
+	filter := bson.D{{"id", article.ID}, {"author_id", article.AuthorID}, {Key: "d_time", Value: mongoNotDeleted}}
+	filter = whereMongoEditable(filter, article)
+	if article.Version > 0 {
+		filter = append(filter, bson.E{Key: "version", Value: article.Version})
+	}
+	if article.FromStatus > 0 {
+		filter = append(filter, bson.E{Key: "status", Value: article.FromStatus})
+	}
 	set := bson.D{
 		{"$set", bson.D{
 			{"title", article.Title},
 			{"content", article.Content},
 			{"status", article.Status},
+			{"publish_at", article.PublishAt},
+			{Key: "editor_id", Value: article.EditorID},
 			{"u_time", now},
 		}},
+		{Key: "$inc", Value: bson.M{"version": 1}},
 	}
 	res, err := dao.artCol.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
 	if res.ModifiedCount != 1 {
+		if err = dao.versionConflict(ctx, article); err != nil {
+			return err
+		}
 		return errors.New("failed to update article")
 	}
 	return nil
@@ -113,6 +206,7 @@ func (dao *MangoDBArticleDao) Sync(ctx context.Context, article Article) (int64,
 			{"title", pubArt.Title},
 			{"content", pubArt.Content},
 			{"status", pubArt.Status},
+			{Key: "editor_id", Value: pubArt.EditorID},
 			{"u_time", pubArt.Utime},
 		}},
 	}
@@ -133,6 +227,7 @@ func (dao *MangoDBArticleDao) SyncStatus(ctx context.Context, id int64, authorID
 	filter := bson.D{
 		{"id", id},
 		{"author_id", authorID},
+		{Key: "d_time", Value: mongoNotDeleted},
 	}
 	set := bson.D{
 		{"$set", bson.D{
@@ -140,7 +235,7 @@ func (dao *MangoDBArticleDao) SyncStatus(ctx context.Context, id int64, authorID
 			{"u_time", now},
 		}},
 	}
-	artRes, err := dao.artCol.UpdateOne(ctx, filter, status)
+	artRes, err := dao.artCol.UpdateOne(ctx, filter, set)
 	if err != nil {
 		return err
 	}
@@ -154,3 +249,76 @@ func (dao *MangoDBArticleDao) SyncStatus(ctx context.Context, id int64, authorID
 	}, set)
 	return err
 }
+
+func (dao *MangoDBArticleDao) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]Article, error) {
+	filter := bson.M{
+		"status":     ArticleStatusPending,
+		"publish_at": bson.M{"$lte": now.UnixMilli()},
+		// 旧文档没有claim_until，$not也能匹配到
+		"claim_until": bson.M{"$not": bson.M{"$gte": now.UnixMilli()}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "publish_at", Value: 1}}).
+		SetLimit(int64(limit))
+	cursor, err := dao.artCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []Article
+	err = cursor.All(ctx, &articles)
+	return articles, err
+}
+
+func (dao *MangoDBArticleDao) ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error) {
+	// 单文档的更新是原子的，多个实例同时抢占时只有一个能匹配到租约已经过期的文档
+	filter := bson.M{
+		"id":          id,
+		"status":      ArticleStatusPending,
+		"publish_at":  bson.M{"$lte": now.UnixMilli()},
+		"claim_until": bson.M{"$not": bson.M{"$gte": now.UnixMilli()}},
+	}
+	res, err := dao.artCol.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"claim_until": now.Add(lease).UnixMilli(),
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+func (dao *MangoDBArticleDao) ReleaseScheduled(ctx context.Context, id int64) error {
+	filter := bson.M{
+		"id":          id,
+		"claim_until": bson.M{"$gt": 0},
+	}
+	_, err := dao.artCol.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"claim_until": 0,
+		},
+	})
+	return err
+}
+
+func (dao *MangoDBArticleDao) CancelScheduled(ctx context.Context, id int64, authorID int64) error {
+	filter := bson.M{
+		"id":        id,
+		"author_id": authorID,
+		"status":    ArticleStatusPending,
+	}
+	res, err := dao.artCol.UpdateOne(ctx, filter, bson.M{
+		"$set": bson.M{
+			"status":     ArticleStatusUnpublished,
+			"publish_at": 0,
+			"u_time":     time.Now().UnixMilli(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if res.ModifiedCount == 0 {
+		return ErrScheduleNotFound
+	}
+	return nil
+}
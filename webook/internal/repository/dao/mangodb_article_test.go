@@ -0,0 +1,228 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func TestMongoKeyset(t *testing.T) {
+	testCases := []struct {
+		name        string
+		beforeUtime int64
+		beforeID    int64
+
+		wantFilter bson.M
+	}{
+		{
+			name:       "第一页",
+			wantFilter: bson.M{"author_id": int64(1)},
+		},
+		{
+			name:        "下一页",
+			beforeUtime: 100,
+			beforeID:    10,
+			wantFilter: bson.M{
+				"author_id": int64(1),
+				"$or": bson.A{
+					bson.M{"u_time": bson.M{"$lt": int64(100)}},
+					bson.M{"u_time": int64(100), "id": bson.M{"$lt": int64(10)}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := mongoKeyset(bson.M{"author_id": int64(1)}, tc.beforeUtime, tc.beforeID)
+			assert.Equal(t, tc.wantFilter, filter)
+		})
+	}
+}
+
+func TestMangoDBArticleDao_GetByID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("查询成功", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.articles", mtest.FirstBatch,
+			bson.D{{Key: "id", Value: int64(1)}, {Key: "title", Value: "title"}, {Key: "author_id", Value: int64(2000)}}))
+		art, err := NewMongoArticleDao(mt.DB, nil).GetByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, Article{ID: 1, Title: "title", AuthorID: 2000}, art)
+		assert.Equal(t, "articles", mt.GetStartedEvent().Command.Lookup("find").StringValue())
+	})
+
+	mt.Run("文章不存在", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.articles", mtest.FirstBatch))
+		_, err := NewMongoArticleDao(mt.DB, nil).GetByID(context.Background(), 1)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestMangoDBArticleDao_GetPubByID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("查询线上库", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.published_articles", mtest.FirstBatch,
+			bson.D{{Key: "id", Value: int64(1)}, {Key: "status", Value: int32(ArticleStatusPublished)}}))
+		art, err := NewMongoArticleDao(mt.DB, nil).GetPubByID(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, PublishArticle{ID: 1, Status: ArticleStatusPublished}, art)
+		assert.Equal(t, "published_articles", mt.GetStartedEvent().Command.Lookup("find").StringValue())
+	})
+
+	mt.Run("文章不存在", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.published_articles", mtest.FirstBatch))
+		_, err := NewMongoArticleDao(mt.DB, nil).GetPubByID(context.Background(), 1)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestMangoDBArticleDao_GetByAuthor(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("按照u_time、id倒序查询作者没有删除的文章", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.articles", mtest.FirstBatch,
+			bson.D{{Key: "id", Value: int64(2)}, {Key: "author_id", Value: int64(2000)}, {Key: "u_time", Value: int64(200)}},
+			bson.D{{Key: "id", Value: int64(1)}, {Key: "author_id", Value: int64(2000)}, {Key: "u_time", Value: int64(100)}}))
+		arts, err := NewMongoArticleDao(mt.DB, nil).GetByAuthor(context.Background(), 2000, 0, 0, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []Article{
+			{ID: 2, AuthorID: 2000, Utime: 200},
+			{ID: 1, AuthorID: 2000, Utime: 100},
+		}, arts)
+
+		cmd := mt.GetStartedEvent().Command
+		assert.Equal(t, int64(2000), cmd.Lookup("filter", "author_id").Int64())
+		_, err = cmd.LookupErr("filter", "d_time", "$not")
+		assert.NoError(t, err)
+		assert.Equal(t, int32(-1), cmd.Lookup("sort", "u_time").Int32())
+		assert.Equal(t, int32(-1), cmd.Lookup("sort", "id").Int32())
+		assert.Equal(t, int64(10), cmd.Lookup("limit").Int64())
+	})
+}
+
+func TestMangoDBArticleDao_SyncStatus(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("同时修改制作库和线上库", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoArticleDao(mt.DB, nil).SyncStatus(context.Background(), 1, 2000, ArticleStatusUnpublished)
+		require.NoError(t, err)
+
+		art := mt.GetStartedEvent().Command
+		assert.Equal(t, "articles", art.Lookup("update").StringValue())
+		update := art.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int32(ArticleStatusUnpublished), update.Lookup("u", "$set", "status").Int32())
+		pub := mt.GetStartedEvent().Command
+		assert.Equal(t, "published_articles", pub.Lookup("update").StringValue())
+	})
+
+	mt.Run("不是作者的文章", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}))
+		err := NewMongoArticleDao(mt.DB, nil).SyncStatus(context.Background(), 1, 3000, ArticleStatusUnpublished)
+		assert.Equal(t, errors.New("failed to update status"), err)
+		// 制作库没有修改时不修改线上库
+		mt.GetStartedEvent()
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+}
+
+func TestMangoDBArticleDao_UpdateByID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("作者修改不校验协作者", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoArticleDao(mt.DB, nil).UpdateByID(context.Background(), Article{ID: 1, AuthorID: 2000, Version: 1})
+		require.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		_, err = update.Lookup("q").Document().LookupErr("editors")
+		assert.Error(t, err)
+	})
+
+	mt.Run("协作者修改时校验和更新是同一个操作", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoArticleDao(mt.DB, nil).UpdateByID(context.Background(),
+			Article{ID: 1, AuthorID: 2000, EditorID: 3000, Version: 1})
+		require.NoError(t, err)
+		cmd := mt.GetStartedEvent().Command
+		assert.Equal(t, "articles", cmd.Lookup("update").StringValue())
+		update := cmd.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int64(3000), update.Lookup("q", "editors").Int64())
+		// 没有单独查询协作者集合
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("版本号冲突", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+			mtest.CreateCursorResponse(0, "webook.articles", mtest.FirstBatch,
+				bson.D{{Key: "id", Value: int64(1)}, {Key: "version", Value: int64(2)}}))
+		err := NewMongoArticleDao(mt.DB, nil).UpdateByID(context.Background(), Article{ID: 1, AuthorID: 2000, Version: 1})
+		assert.Equal(t, ErrVersionConflict, err)
+	})
+
+	mt.Run("区分冲突时查询失败", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+			mtest.CreateCommandErrorResponse(mtest.CommandError{Code: 1, Message: "mock mongo error"}))
+		err := NewMongoArticleDao(mt.DB, nil).UpdateByID(context.Background(), Article{ID: 1, AuthorID: 2000, Version: 1})
+		// 返回查询的错误，而不是当成没有权限
+		assert.ErrorContains(t, err, "mock mongo error")
+	})
+}
+
+func TestMangoDBArticleCollaboratorDao_SyncEditor(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("接受邀请的编辑加入editors", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+				{Key: "article_id", Value: int64(1)},
+				{Key: "uid", Value: int64(3000)},
+				{Key: "role", Value: int32(CollaboratorRoleEditor)},
+				{Key: "status", Value: int32(CollaboratorStatusAccepted)},
+			}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoArticleCollaboratorDao(mt.DB, nil).Accept(context.Background(), 1, 3000)
+		require.NoError(t, err)
+		assert.Equal(t, mongoCollaboratorCollection, mt.GetStartedEvent().Command.Lookup("findAndModify").StringValue())
+		cmd := mt.GetStartedEvent().Command
+		assert.Equal(t, "articles", cmd.Lookup("update").StringValue())
+		update := cmd.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int64(3000), update.Lookup("u", "$addToSet", "editors").Int64())
+	})
+
+	mt.Run("接受邀请的查看者不能修改文章", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{
+				{Key: "article_id", Value: int64(1)},
+				{Key: "uid", Value: int64(3000)},
+				{Key: "role", Value: int32(CollaboratorRoleViewer)},
+				{Key: "status", Value: int32(CollaboratorStatusAccepted)},
+			}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 0}))
+		err := NewMongoArticleCollaboratorDao(mt.DB, nil).Accept(context.Background(), 1, 3000)
+		require.NoError(t, err)
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int64(3000), update.Lookup("u", "$pull", "editors").Int64())
+	})
+
+	mt.Run("移除协作者", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoArticleCollaboratorDao(mt.DB, nil).Delete(context.Background(), 1, 3000)
+		require.NoError(t, err)
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int64(3000), update.Lookup("u", "$pull", "editors").Int64())
+	})
+}
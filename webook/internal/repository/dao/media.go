@@ -0,0 +1,223 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"time"
+)
+
+/*
+文章上传的图片和附件。
+
+	文件本身存储在对象存储中，这里只保存元数据和对象的key。
+	删除时先标记为deleted，由定时任务删除对象存储中的文件后再删除记录，
+	这样删除文件失败时不会留下找不到的文件，彻底删除文章时也不需要等待对象存储。
+
+存储的选择和版本记录相同。
+*/
+
+const (
+	MediaStatusActive int8 = iota + 1
+	MediaStatusDeleted
+)
+
+type Media struct {
+	ID          int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Uid         int64  `bson:"uid,omitempty"`
+	ArticleID   int64  `gorm:"index:idx_article_status,priority:1" bson:"article_id,omitempty"`
+	Kind        int8   `gorm:"type:tinyint" bson:"kind,omitempty"`
+	Name        string `gorm:"type:varchar(255)" bson:"name,omitempty"`
+	ContentType string `gorm:"type:varchar(64)" bson:"content_type,omitempty"`
+	Size        int64  `bson:"size,omitempty"`
+	ObjectKey   string `gorm:"type:varchar(255)" bson:"object_key,omitempty"`
+	ThumbKey    string `gorm:"type:varchar(255)" bson:"thumb_key,omitempty"`
+	Width       int    `bson:"width,omitempty"`
+	Height      int    `bson:"height,omitempty"`
+	Status      int8   `gorm:"type:tinyint;index:idx_article_status,priority:2;index:idx_status_utime,priority:1" bson:"status,omitempty"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+	Utime int64 `json:"u_time" gorm:"column:u_time;index:idx_status_utime,priority:2" bson:"u_time,omitempty"`
+}
+
+type MediaDao interface {
+	Insert(ctx context.Context, m Media) (int64, error)
+	// GetByID 查询没有被删除的文件
+	GetByID(ctx context.Context, id int64) (Media, error)
+	// ListByArticle 按照上传的先后查询文章没有被删除的文件
+	ListByArticle(ctx context.Context, articleID int64) ([]Media, error)
+	CountByArticle(ctx context.Context, articleID int64) (int64, error)
+	// Delete 将文件标记为deleted
+	Delete(ctx context.Context, id int64) error
+	// DeleteByArticle 将文章全部的文件标记为deleted
+	DeleteByArticle(ctx context.Context, articleID int64) error
+	// ListDeleted 查询标记为deleted的文件
+	ListDeleted(ctx context.Context, limit int) ([]Media, error)
+	// Remove 删除记录，对象存储中的文件已经删除了
+	Remove(ctx context.Context, id int64) error
+}
+
+type MediaGORMDao struct {
+	db *gorm.DB
+}
+
+func NewMediaDao(db *gorm.DB) MediaDao {
+	return &MediaGORMDao{
+		db: db,
+	}
+}
+
+func (dao *MediaGORMDao) Insert(ctx context.Context, m Media) (int64, error) {
+	now := time.Now().UnixMilli()
+	m.Ctime = now
+	m.Utime = now
+	m.Status = MediaStatusActive
+	err := dao.db.WithContext(ctx).Create(&m).Error
+	return m.ID, err
+}
+
+func (dao *MediaGORMDao) GetByID(ctx context.Context, id int64) (Media, error) {
+	var m Media
+	err := dao.db.WithContext(ctx).
+		Where("id = ? and status = ?", id, MediaStatusActive).
+		First(&m).Error
+	return m, err
+}
+
+func (dao *MediaGORMDao) ListByArticle(ctx context.Context, articleID int64) ([]Media, error) {
+	var res []Media
+	err := dao.db.WithContext(ctx).
+		Where("article_id = ? and status = ?", articleID, MediaStatusActive).
+		Order("id").
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *MediaGORMDao) CountByArticle(ctx context.Context, articleID int64) (int64, error) {
+	var cnt int64
+	err := dao.db.WithContext(ctx).Model(&Media{}).
+		Where("article_id = ? and status = ?", articleID, MediaStatusActive).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+func (dao *MediaGORMDao) Delete(ctx context.Context, id int64) error {
+	return dao.markDeleted(dao.db.WithContext(ctx).Where("id = ?", id))
+}
+
+func (dao *MediaGORMDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.markDeleted(dao.db.WithContext(ctx).Where("article_id = ?", articleID))
+}
+
+func (dao *MediaGORMDao) markDeleted(db *gorm.DB) error {
+	return db.Model(&Media{}).
+		Where("status = ?", MediaStatusActive).
+		Updates(map[string]any{
+			"status": MediaStatusDeleted,
+			"u_time": time.Now().UnixMilli(),
+		}).Error
+}
+
+func (dao *MediaGORMDao) ListDeleted(ctx context.Context, limit int) ([]Media, error) {
+	var res []Media
+	err := dao.db.WithContext(ctx).
+		Where("status = ?", MediaStatusDeleted).
+		Order("u_time").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (dao *MediaGORMDao) Remove(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).
+		Where("id = ? and status = ?", id, MediaStatusDeleted).
+		Delete(&Media{}).Error
+}
+
+// MangoDBMediaDao 上传文件的mongo存储实现
+type MangoDBMediaDao struct {
+	col  *mongo.Collection
+	node *snowflake.Node
+}
+
+const mongoMediaCollection = "media"
+
+func NewMongoMediaDao(db *mongo.Database, node *snowflake.Node) MediaDao {
+	return &MangoDBMediaDao{
+		col:  db.Collection(mongoMediaCollection),
+		node: node,
+	}
+}
+
+func (dao *MangoDBMediaDao) Insert(ctx context.Context, m Media) (int64, error) {
+	m.ID = dao.node.Generate().Int64()
+	now := time.Now().UnixMilli()
+	m.Ctime = now
+	m.Utime = now
+	m.Status = MediaStatusActive
+	_, err := dao.col.InsertOne(ctx, m)
+	if err != nil {
+		return 0, err
+	}
+	return m.ID, nil
+}
+
+func (dao *MangoDBMediaDao) GetByID(ctx context.Context, id int64) (Media, error) {
+	var m Media
+	err := dao.col.FindOne(ctx, bson.M{"id": id, "status": MediaStatusActive}).Decode(&m)
+	if err == mongo.ErrNoDocuments {
+		return Media{}, ErrNotFound
+	}
+	return m, err
+}
+
+func (dao *MangoDBMediaDao) ListByArticle(ctx context.Context, articleID int64) ([]Media, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: 1}})
+	return dao.find(ctx, bson.M{"article_id": articleID, "status": MediaStatusActive}, opts)
+}
+
+func (dao *MangoDBMediaDao) CountByArticle(ctx context.Context, articleID int64) (int64, error) {
+	return dao.col.CountDocuments(ctx, bson.M{"article_id": articleID, "status": MediaStatusActive})
+}
+
+func (dao *MangoDBMediaDao) Delete(ctx context.Context, id int64) error {
+	_, err := dao.col.UpdateOne(ctx, bson.M{"id": id, "status": MediaStatusActive}, dao.markDeleted())
+	return err
+}
+
+func (dao *MangoDBMediaDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	_, err := dao.col.UpdateMany(ctx, bson.M{"article_id": articleID, "status": MediaStatusActive}, dao.markDeleted())
+	return err
+}
+
+func (dao *MangoDBMediaDao) markDeleted() bson.M {
+	return bson.M{"$set": bson.M{
+		"status": MediaStatusDeleted,
+		"u_time": time.Now().UnixMilli(),
+	}}
+}
+
+func (dao *MangoDBMediaDao) ListDeleted(ctx context.Context, limit int) ([]Media, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "u_time", Value: 1}}).
+		SetLimit(int64(limit))
+	return dao.find(ctx, bson.M{"status": MediaStatusDeleted}, opts)
+}
+
+func (dao *MangoDBMediaDao) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]Media, error) {
+	cursor, err := dao.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Media
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (dao *MangoDBMediaDao) Remove(ctx context.Context, id int64) error {
+	_, err := dao.col.DeleteOne(ctx, bson.M{"id": id, "status": MediaStatusDeleted})
+	return err
+}
@@ -70,6 +70,21 @@ func (mr *MockUserDaoMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserDao)(nil).FindByID), ctx, id)
 }
 
+// FindByIDs mocks base method.
+func (m *MockUserDao) FindByIDs(ctx context.Context, ids []int64) ([]dao.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]dao.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserDaoMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserDao)(nil).FindByIDs), ctx, ids)
+}
+
 // FindByOpenId mocks base method.
 func (m *MockUserDao) FindByOpenId(ctx context.Context, openId string) (dao.User, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,158 @@
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// InitCollections 创建mongo存储需要的索引，CreateMany是幂等的，已经存在的索引不会重复创建
+func InitCollections(db *mongo.Database) error {
+	ctx := context.Background()
+	// 制作库：按id查询、作者的文章列表、已发布文章列表、定时发布扫描、回收站
+	_, err := db.Collection("articles").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "u_time", Value: -1}, {Key: "id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "u_time", Value: -1}, {Key: "id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "publish_at", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "d_time", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "d_time", Value: 1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	// 没有版本号的旧文章补上初始版本号
+	_, err = db.Collection("articles").UpdateMany(ctx,
+		bson.M{"version": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"version": 1}})
+	if err != nil {
+		return err
+	}
+
+	// 线上库：按id查询、按标签查询
+	_, err = db.Collection("published_articles").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "u_time", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "tags.slug", Value: 1}, {Key: "status", Value: 1}, {Key: "id", Value: -1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("tags").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("article_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "author_id", Value: 1}, {Key: "id", Value: -1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 协作者：按文章查询、用户的邀请列表
+	_, err = db.Collection(mongoCollaboratorCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "article_id", Value: 1}, {Key: "uid", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "uid", Value: 1}, {Key: "status", Value: 1}, {Key: "id", Value: -1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 冗余editors之前已经接受邀请的协作者
+	err = syncMongoEditors(ctx, db, false)
+	if err != nil {
+		return err
+	}
+
+	// 审核记录：审核队列、文章的审核记录
+	_, err = db.Collection("article_reviews").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "id", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "id", Value: -1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 系列：作者的系列列表，一篇文章最多属于一个系列。空系列不参与唯一索引，否则多个空系列会冲突
+	_, err = db.Collection(mongoSeriesCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "author_id", Value: 1}, {Key: "u_time", Value: -1}, {Key: "id", Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: "article_ids", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"article_ids.0": bson.M{"$exists": true}}),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 导出任务：查询待执行、过期的任务
+	_, err = db.Collection(mongoExportCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "u_time", Value: 1}},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// 上传的文件：查询文章的文件、待删除的文件
+	_, err = db.Collection(mongoMediaCollection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "article_id", Value: 1}, {Key: "status", Value: 1}},
+		},
+		{
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "u_time", Value: 1}},
+		},
+	})
+	return err
+}
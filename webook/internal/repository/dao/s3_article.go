@@ -1,29 +1,58 @@
 package dao
 
 import (
-	"bytes"
 	"context"
 	"errors"
-	"github.com/aws/aws-sdk-go/service/s3"
-	"github.com/ecodeclub/ekit"
+	"github.com/ecodeclub/ekit/slice"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
-	"strconv"
+	"learn_go/webook/pkg/objectstore"
 	"time"
 )
 
+/*
+ArticleS3DAO 线上库的文章内容存储在对象存储中，mysql中只保存元数据和内容的key。
+
+内容使用内容寻址的key：相同的内容只存一份，修改内容后写入新的对象，旧的对象不会被覆盖。
+写入时先写对象再提交数据库事务，数据库中的key对应的对象一定存在。
+不再被已发布的文章引用的对象（撤回、删除、修改内容）由ArticleContentSweeper统一清理。
+*/
+
+// ArticleContentNamespace 文章内容在对象存储中的key前缀
+const ArticleContentNamespace = "articles"
+
 type ArticleS3DAO struct {
 	ArticleGORMDao
-	oss *s3.S3
+	store objectstore.ObjectStore
+}
+
+func NewArticleS3DAO(db *gorm.DB, store objectstore.ObjectStore) *ArticleS3DAO {
+	return &ArticleS3DAO{
+		ArticleGORMDao: ArticleGORMDao{db: db},
+		store:          store,
+	}
+}
+
+type PublishedArticleV2 struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Title string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
+	// 我要根据创作者ID来查询
+	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
+	Status   int8  `bson:"status,omitempty"`
+	// ContentKey 内容在对象存储中的key，清理对象时按照key查询是否还被引用
+	ContentKey string `gorm:"type:varchar(128);index" bson:"content_key,omitempty"`
+	Ctime      int64  `bson:"ctime,omitempty"`
+	// 更新时间
+	Utime int64 `bson:"utime,omitempty"`
 }
 
-func (a *ArticleS3DAO) SyncStatus(ctx context.Context, uid int64, id int64, status uint8) error {
+func (a *ArticleS3DAO) SyncStatus(ctx context.Context, id int64, authorID int64, status int8) error {
 	now := time.Now().UnixMilli()
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		res := tx.Model(&Article{}).
-			Where("id = ? and author_id = ?", uid, id).
+			Where("id = ? and author_id = ? and d_time = 0", id, authorID).
 			Updates(map[string]any{
-				"utime":  now,
+				"u_time": now,
 				"status": status,
 			})
 		if res.Error != nil {
@@ -32,29 +61,46 @@ func (a *ArticleS3DAO) SyncStatus(ctx context.Context, uid int64, id int64, stat
 		if res.RowsAffected != 1 {
 			return errors.New("ID 不对或者创作者不对")
 		}
+		// 对象不在这里删除，相同内容的对象可能还被其他文章引用
 		return tx.Model(&PublishedArticleV2{}).
-			Where("id = ?", uid).
+			Where("id = ?", id).
 			Updates(map[string]any{
 				"utime":  now,
 				"status": status,
 			}).Error
 	})
-	if err != nil {
-		return err
-	}
-	const statusPrivate = 3
-	if status == statusPrivate {
-		_, err = a.oss.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
-			Bucket: ekit.ToPtr[string]("webook-1314583317"),
-			Key:    ekit.ToPtr[string](strconv.FormatInt(id, 10)),
-		})
-	}
-	return err
+}
+
+// SoftDelete 线上库中的文章存储在PublishedArticleV2中，对象由ArticleContentSweeper清理
+func (a *ArticleS3DAO) SoftDelete(ctx context.Context, id int64, authorID int64, now time.Time) error {
+	return a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Article{}).
+			Where("id = ? and author_id = ? and d_time = 0", id, authorID).
+			Updates(map[string]any{
+				"d_time":     now.UnixMilli(),
+				"status":     ArticleStatusUnpublished,
+				"publish_at": 0,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("id = ?", id).Delete(&PublishedArticleV2{}).Error
+	})
 }
 
 func (a *ArticleS3DAO) Sync(ctx context.Context, art Article) (int64, error) {
+	content := []byte(art.Content)
+	key := objectstore.ContentKey(ArticleContentNamespace, content)
+	err := a.store.Put(ctx, key, content, "text/plain;charset=utf-8")
+	if err != nil {
+		return 0, err
+	}
+
 	var id = art.ID
-	err := a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err = a.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var (
 			err error
 		)
@@ -70,51 +116,88 @@ func (a *ArticleS3DAO) Sync(ctx context.Context, art Article) (int64, error) {
 		art.ID = id
 		now := time.Now().UnixMilli()
 		pubArt := PublishedArticleV2{
-			Id:       art.ID,
-			Title:    art.Title,
-			AuthorId: art.AuthorID,
-			Ctime:    now,
-			Utime:    now,
-			Status:   art.Status,
+			Id:         art.ID,
+			Title:      art.Title,
+			AuthorId:   art.AuthorID,
+			Status:     art.Status,
+			ContentKey: key,
+			Ctime:      now,
+			Utime:      now,
 		}
-		pubArt.Ctime = now
-		pubArt.Utime = now
-		err = tx.Clauses(clause.OnConflict{
+		return tx.Clauses(clause.OnConflict{
 			Columns: []clause.Column{{Name: "id"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"title":  pubArt.Title,
-				"utime":  now,
-				"status": pubArt.Status,
+				"title":       pubArt.Title,
+				"utime":       now,
+				"status":      pubArt.Status,
+				"content_key": key,
 			}),
 		}).Create(&pubArt).Error
-		return err
 	})
+	return id, err
+}
+
+func (a *ArticleS3DAO) GetPubByID(ctx context.Context, id int64) (PublishArticle, error) {
+	var pubArt PublishedArticleV2
+	err := a.db.WithContext(ctx).Where("id = ?", id).First(&pubArt).Error
 	if err != nil {
-		return 0, err
+		return PublishArticle{}, err
 	}
-	_, err = a.oss.PutObjectWithContext(ctx, &s3.PutObjectInput{
-		Bucket:      ekit.ToPtr[string]("webook-1314583317"),
-		Key:         ekit.ToPtr[string](strconv.FormatInt(art.ID, 10)),
-		Body:        bytes.NewReader([]byte(art.Content)),
-		ContentType: ekit.ToPtr[string]("text/plain;charset=utf-8"),
-	})
-	return id, err
+	return a.toPublishArticle(ctx, pubArt)
 }
 
-func NewArticleS3DAO(db *gorm.DB, oss *s3.S3) *ArticleS3DAO {
-	return &ArticleS3DAO{
-		ArticleGORMDao: ArticleGORMDao{db: db},
-		oss:            oss,
+// GetPubByIDs 不查询内容，所以不需要读取对象存储
+func (a *ArticleS3DAO) GetPubByIDs(ctx context.Context, ids []int64) ([]PublishArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).Where("id in ?", ids).Find(&pubArts).Error
+	if err != nil {
+		return nil, err
 	}
+	return slice.Map(pubArts, func(idx int, src PublishedArticleV2) PublishArticle {
+		return PublishArticle{
+			ID:       src.Id,
+			Title:    src.Title,
+			AuthorID: src.AuthorId,
+			Status:   src.Status,
+			Ctime:    src.Ctime,
+			Utime:    src.Utime,
+		}
+	}), nil
 }
 
-type PublishedArticleV2 struct {
-	Id    int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
-	Title string `gorm:"type=varchar(4096)" bson:"title,omitempty"`
-	// 我要根据创作者ID来查询
-	AuthorId int64 `gorm:"index" bson:"author_id,omitempty"`
-	Status   int8  `bson:"status,omitempty"`
-	Ctime    int64 `bson:"ctime,omitempty"`
-	// 更新时间
-	Utime int64 `bson:"utime,omitempty"`
+func (a *ArticleS3DAO) ScanPub(ctx context.Context, startID int64, limit int) ([]PublishArticle, error) {
+	var pubArts []PublishedArticleV2
+	err := a.db.WithContext(ctx).
+		Where("id > ?", startID).
+		Order("id").
+		Limit(limit).
+		Find(&pubArts).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make([]PublishArticle, 0, len(pubArts))
+	for _, pubArt := range pubArts {
+		art, err := a.toPublishArticle(ctx, pubArt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+func (a *ArticleS3DAO) toPublishArticle(ctx context.Context, pubArt PublishedArticleV2) (PublishArticle, error) {
+	content, err := a.store.Get(ctx, pubArt.ContentKey)
+	if err != nil {
+		return PublishArticle{}, err
+	}
+	return PublishArticle{
+		ID:       pubArt.Id,
+		Title:    pubArt.Title,
+		Content:  string(content),
+		AuthorID: pubArt.AuthorId,
+		Status:   pubArt.Status,
+		Ctime:    pubArt.Ctime,
+		Utime:    pubArt.Utime,
+	}, nil
 }
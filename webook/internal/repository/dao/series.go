@@ -0,0 +1,468 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/snowflake"
+	"github.com/go-sql-driver/mysql"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+文章的系列。
+
+	mysql中系列和文章的关系存储在series_articles表中，position是文章在系列中的顺序，移除文章之后position可以不连续。
+	article_id上的唯一索引保证一篇文章最多属于一个系列。修改系列中的文章时先锁住系列，避免并发追加时position重复。
+
+	mongo中文章按顺序内嵌在系列的article_ids中，article_ids上的唯一索引保证一篇文章最多属于一个系列。
+
+存储的选择和版本记录相同。
+*/
+
+var (
+	// ErrSeriesArticleDuplicate 文章已经在某个系列中了
+	ErrSeriesArticleDuplicate = errors.New("article already in series")
+	// ErrSeriesArticleMismatch 调整顺序时传入的文章和系列中的文章不一致
+	ErrSeriesArticleMismatch = errors.New("series articles mismatch")
+)
+
+type Series struct {
+	ID          int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	AuthorID    int64  `gorm:"index:idx_author_utime,priority:1" bson:"author_id,omitempty"`
+	Title       string `gorm:"type=varchar(1024)" bson:"title,omitempty"`
+	Description string `gorm:"type=varchar(4096)" bson:"description,omitempty"`
+	// ArticleIDs 按照顺序排列的文章，mysql中存储在series_articles表中
+	ArticleIDs []int64 `gorm:"-" bson:"article_ids"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time" bson:"c_time,omitempty"`
+	Utime int64 `json:"u_time" gorm:"column:u_time;index:idx_author_utime,priority:2" bson:"u_time,omitempty"`
+}
+
+type SeriesArticle struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement"`
+	SeriesID  int64 `gorm:"index:idx_series_position,priority:1"`
+	ArticleID int64 `gorm:"uniqueIndex"`
+	Position  int   `gorm:"index:idx_series_position,priority:2"`
+
+	Ctime int64 `json:"c_time" gorm:"column:c_time"`
+}
+
+type SeriesDao interface {
+	Insert(ctx context.Context, s Series) (int64, error)
+	// Update 修改标题和简介，系列不属于s.AuthorID时返回ErrNotFound
+	Update(ctx context.Context, s Series) error
+	GetByID(ctx context.Context, id int64) (Series, error)
+	// GetByArticle 查询文章所属的系列，文章不属于任何系列时返回ErrNotFound
+	GetByArticle(ctx context.Context, articleID int64) (Series, error)
+	// ListByAuthor 按照修改时间倒序查询作者的系列
+	ListByAuthor(ctx context.Context, authorID int64, offset int, limit int) ([]Series, error)
+	// Delete 删除系列，系列中的文章不受影响
+	Delete(ctx context.Context, id int64, authorID int64) error
+
+	// AddArticle 将文章追加到系列的末尾，文章已经在系列中时返回ErrSeriesArticleDuplicate
+	AddArticle(ctx context.Context, id int64, authorID int64, articleID int64) error
+	// RemoveArticle 从系列中移除文章，文章不在系列中时返回ErrNotFound
+	RemoveArticle(ctx context.Context, id int64, authorID int64, articleID int64) error
+	// Reorder 按照articleIDs的顺序重新排列，articleIDs必须和系列中的文章一致，否则返回ErrSeriesArticleMismatch
+	Reorder(ctx context.Context, id int64, authorID int64, articleIDs []int64) error
+	// DeleteByArticle 从所属的系列中移除文章，彻底删除文章时调用
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type SeriesGORMDao struct {
+	db *gorm.DB
+}
+
+func NewSeriesDao(db *gorm.DB) SeriesDao {
+	return &SeriesGORMDao{
+		db: db,
+	}
+}
+
+func (dao *SeriesGORMDao) Insert(ctx context.Context, s Series) (int64, error) {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	err := dao.db.WithContext(ctx).Create(&s).Error
+	return s.ID, err
+}
+
+func (dao *SeriesGORMDao) Update(ctx context.Context, s Series) error {
+	res := dao.db.WithContext(ctx).Model(&Series{}).
+		Where("id = ? and author_id = ?", s.ID, s.AuthorID).
+		Updates(map[string]any{
+			"title":       s.Title,
+			"description": s.Description,
+			"u_time":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *SeriesGORMDao) GetByID(ctx context.Context, id int64) (Series, error) {
+	var s Series
+	err := dao.db.WithContext(ctx).Where("id = ?", id).First(&s).Error
+	if err != nil {
+		return Series{}, err
+	}
+	s.ArticleIDs, err = dao.articleIDs(dao.db.WithContext(ctx), id)
+	return s, err
+}
+
+func (dao *SeriesGORMDao) GetByArticle(ctx context.Context, articleID int64) (Series, error) {
+	var sa SeriesArticle
+	err := dao.db.WithContext(ctx).Where("article_id = ?", articleID).First(&sa).Error
+	if err != nil {
+		return Series{}, err
+	}
+	return dao.GetByID(ctx, sa.SeriesID)
+}
+
+func (dao *SeriesGORMDao) ListByAuthor(ctx context.Context, authorID int64, offset int, limit int) ([]Series, error) {
+	var res []Series
+	err := dao.db.WithContext(ctx).
+		Where("author_id = ?", authorID).
+		Order("u_time desc, id desc").
+		Offset(offset).
+		Limit(limit).
+		Find(&res).Error
+	if err != nil || len(res) == 0 {
+		return res, err
+	}
+	ids := make([]int64, 0, len(res))
+	for _, s := range res {
+		ids = append(ids, s.ID)
+	}
+	var sas []SeriesArticle
+	err = dao.db.WithContext(ctx).
+		Where("series_id in ?", ids).
+		Order("series_id, position").
+		Find(&sas).Error
+	if err != nil {
+		return nil, err
+	}
+	articles := make(map[int64][]int64, len(res))
+	for _, sa := range sas {
+		articles[sa.SeriesID] = append(articles[sa.SeriesID], sa.ArticleID)
+	}
+	for i := range res {
+		res[i].ArticleIDs = articles[res[i].ID]
+	}
+	return res, nil
+}
+
+func (dao *SeriesGORMDao) Delete(ctx context.Context, id int64, authorID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? and author_id = ?", id, authorID).Delete(&Series{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("series_id = ?", id).Delete(&SeriesArticle{}).Error
+	})
+}
+
+func (dao *SeriesGORMDao) AddArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		if err := dao.lock(tx, id, authorID, now); err != nil {
+			return err
+		}
+		var position int
+		err := tx.Model(&SeriesArticle{}).
+			Where("series_id = ?", id).
+			Select("COALESCE(MAX(position), 0)").
+			Scan(&position).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&SeriesArticle{
+			SeriesID:  id,
+			ArticleID: articleID,
+			Position:  position + 1,
+			Ctime:     now,
+		}).Error
+		if me, ok := err.(*mysql.MySQLError); ok {
+			const duplicateErr uint16 = 1062
+			if me.Number == duplicateErr {
+				return ErrSeriesArticleDuplicate
+			}
+		}
+		return err
+	})
+}
+
+func (dao *SeriesGORMDao) RemoveArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := dao.lock(tx, id, authorID, time.Now().UnixMilli()); err != nil {
+			return err
+		}
+		res := tx.Where("series_id = ? and article_id = ?", id, articleID).Delete(&SeriesArticle{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+func (dao *SeriesGORMDao) Reorder(ctx context.Context, id int64, authorID int64, articleIDs []int64) error {
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := dao.lock(tx, id, authorID, time.Now().UnixMilli()); err != nil {
+			return err
+		}
+		current, err := dao.articleIDs(tx, id)
+		if err != nil {
+			return err
+		}
+		if !sameArticles(current, articleIDs) {
+			return ErrSeriesArticleMismatch
+		}
+		for i, articleID := range articleIDs {
+			err = tx.Model(&SeriesArticle{}).
+				Where("series_id = ? and article_id = ?", id, articleID).
+				Update("position", i+1).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (dao *SeriesGORMDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).Where("article_id = ?", articleID).Delete(&SeriesArticle{}).Error
+}
+
+// lock 锁住作者的系列并更新修改时间，系列不属于作者时返回ErrNotFound
+func (dao *SeriesGORMDao) lock(tx *gorm.DB, id int64, authorID int64, now int64) error {
+	var s Series
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? and author_id = ?", id, authorID).
+		First(&s).Error
+	if err != nil {
+		return err
+	}
+	return tx.Model(&Series{}).Where("id = ?", id).Update("u_time", now).Error
+}
+
+func (dao *SeriesGORMDao) articleIDs(db *gorm.DB, id int64) ([]int64, error) {
+	var res []int64
+	err := db.Model(&SeriesArticle{}).
+		Where("series_id = ?", id).
+		Order("position").
+		Pluck("article_id", &res).Error
+	return res, err
+}
+
+// sameArticles current和target是否包含相同的文章，target中不能有重复的文章
+func sameArticles(current []int64, target []int64) bool {
+	if len(current) != len(target) {
+		return false
+	}
+	set := make(map[int64]struct{}, len(current))
+	for _, id := range current {
+		set[id] = struct{}{}
+	}
+	for _, id := range target {
+		if _, ok := set[id]; !ok {
+			return false
+		}
+		delete(set, id)
+	}
+	return true
+}
+
+// distinct ids中没有重复的id
+func distinct(ids []int64) bool {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		if _, ok := set[id]; ok {
+			return false
+		}
+		set[id] = struct{}{}
+	}
+	return true
+}
+
+// MangoDBSeriesDao 系列的mongo存储实现
+type MangoDBSeriesDao struct {
+	col  *mongo.Collection
+	node *snowflake.Node
+}
+
+const mongoSeriesCollection = "series"
+
+func NewMongoSeriesDao(db *mongo.Database, node *snowflake.Node) SeriesDao {
+	return &MangoDBSeriesDao{
+		col:  db.Collection(mongoSeriesCollection),
+		node: node,
+	}
+}
+
+func (dao *MangoDBSeriesDao) Insert(ctx context.Context, s Series) (int64, error) {
+	s.ID = dao.node.Generate().Int64()
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	// $push要求article_ids是数组，不能是null
+	s.ArticleIDs = []int64{}
+	_, err := dao.col.InsertOne(ctx, s)
+	if err != nil {
+		return 0, err
+	}
+	return s.ID, nil
+}
+
+func (dao *MangoDBSeriesDao) Update(ctx context.Context, s Series) error {
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": s.ID, "author_id": s.AuthorID},
+		bson.M{"$set": bson.M{
+			"title":       s.Title,
+			"description": s.Description,
+			"u_time":      time.Now().UnixMilli(),
+		}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBSeriesDao) GetByID(ctx context.Context, id int64) (Series, error) {
+	return dao.findOne(ctx, bson.M{"id": id})
+}
+
+func (dao *MangoDBSeriesDao) GetByArticle(ctx context.Context, articleID int64) (Series, error) {
+	return dao.findOne(ctx, bson.M{"article_ids": articleID})
+}
+
+func (dao *MangoDBSeriesDao) findOne(ctx context.Context, filter bson.M) (Series, error) {
+	var s Series
+	err := dao.col.FindOne(ctx, filter).Decode(&s)
+	if err == mongo.ErrNoDocuments {
+		return Series{}, ErrNotFound
+	}
+	return s, err
+}
+
+func (dao *MangoDBSeriesDao) ListByAuthor(ctx context.Context, authorID int64, offset int, limit int) ([]Series, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "u_time", Value: -1}, {Key: "id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := dao.col.Find(ctx, bson.M{"author_id": authorID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var res []Series
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+func (dao *MangoDBSeriesDao) Delete(ctx context.Context, id int64, authorID int64) error {
+	res, err := dao.col.DeleteOne(ctx, bson.M{"id": id, "author_id": authorID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBSeriesDao) AddArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": id, "author_id": authorID, "article_ids": bson.M{"$ne": articleID}},
+		bson.M{
+			"$push": bson.M{"article_ids": articleID},
+			"$set":  bson.M{"u_time": time.Now().UnixMilli()},
+		})
+	if mongo.IsDuplicateKeyError(err) {
+		// 文章已经在其他系列中了
+		return ErrSeriesArticleDuplicate
+	}
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return dao.missReason(ctx, id, authorID, ErrSeriesArticleDuplicate)
+	}
+	return nil
+}
+
+func (dao *MangoDBSeriesDao) RemoveArticle(ctx context.Context, id int64, authorID int64, articleID int64) error {
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{"id": id, "author_id": authorID, "article_ids": articleID},
+		bson.M{
+			"$pull": bson.M{"article_ids": articleID},
+			"$set":  bson.M{"u_time": time.Now().UnixMilli()},
+		})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (dao *MangoDBSeriesDao) Reorder(ctx context.Context, id int64, authorID int64, articleIDs []int64) error {
+	// $all不区分重复的id，[1, 1]也能匹配[1, 2]，需要先排除重复的id
+	if !distinct(articleIDs) {
+		return dao.missReason(ctx, id, authorID, ErrSeriesArticleMismatch)
+	}
+	// 没有重复、数量相同并且包含全部的文章，说明只是调整了顺序
+	res, err := dao.col.UpdateOne(ctx,
+		bson.M{
+			"id":          id,
+			"author_id":   authorID,
+			"article_ids": bson.M{"$size": len(articleIDs), "$all": articleIDs},
+		},
+		bson.M{"$set": bson.M{
+			"article_ids": articleIDs,
+			"u_time":      time.Now().UnixMilli(),
+		}})
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return dao.missReason(ctx, id, authorID, ErrSeriesArticleMismatch)
+	}
+	return nil
+}
+
+func (dao *MangoDBSeriesDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	_, err := dao.col.UpdateMany(ctx,
+		bson.M{"article_ids": articleID},
+		bson.M{"$pull": bson.M{"article_ids": articleID}})
+	return err
+}
+
+// missReason 条件更新没有匹配到系列时区分是系列不存在，还是文章不满足条件
+func (dao *MangoDBSeriesDao) missReason(ctx context.Context, id int64, authorID int64, reason error) error {
+	cnt, err := dao.col.CountDocuments(ctx, bson.M{"id": id, "author_id": authorID})
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return ErrNotFound
+	}
+	return reason
+}
@@ -0,0 +1,38 @@
+package dao
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"testing"
+)
+
+func TestMangoDBSeriesDao_Reorder(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("调整顺序", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}))
+		err := NewMongoSeriesDao(mt.DB, nil).Reorder(context.Background(), 10, 2000, []int64{2, 1})
+		assert.NoError(t, err)
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		assert.Equal(t, int32(2), update.Lookup("q", "article_ids", "$size").Int32())
+	})
+
+	mt.Run("重复的id", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.series", mtest.FirstBatch,
+			bson.D{{Key: "n", Value: int32(1)}}))
+		// 系列中是[1, 2]时，$size和$all都能匹配[1, 1]
+		err := NewMongoSeriesDao(mt.DB, nil).Reorder(context.Background(), 10, 2000, []int64{1, 1})
+		assert.Equal(t, ErrSeriesArticleMismatch, err)
+		// 没有执行更新，只查询了系列是否存在
+		assert.Equal(t, mongoSeriesCollection, mt.GetStartedEvent().Command.Lookup("aggregate").StringValue())
+		assert.Nil(t, mt.GetStartedEvent())
+	})
+
+	mt.Run("重复的id，系列不存在", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "webook.series", mtest.FirstBatch))
+		err := NewMongoSeriesDao(mt.DB, nil).Reorder(context.Background(), 10, 2000, []int64{1, 1})
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
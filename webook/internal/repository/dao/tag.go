@@ -0,0 +1,278 @@
+package dao
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"github.com/ecodeclub/ekit/slice"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+/*
+文章的标签，标签和文章是多对多的关系。
+
+标签挂在文章上，制作库、线上库的文章id相同，所以草稿和已发布的文章共享同一份标签。
+按标签查询文章时只返回线上库中状态为已发布的文章。
+
+存储：
+	GORM：tags保存标签，article_tags保存文章和标签的关联关系。
+	Mongo：tags集合保存标签，文章的标签直接内嵌在articles、published_articles的tags字段中，
+		按标签查询、聚合都只需要访问published_articles。
+*/
+
+type Tag struct {
+	ID   int64  `gorm:"primaryKey,autoIncrement" bson:"id,omitempty"`
+	Slug string `gorm:"type:varchar(128);uniqueIndex" bson:"slug,omitempty"`
+	Name string `gorm:"type:varchar(128)" bson:"name,omitempty"`
+
+	Ctime int64 `gorm:"column:c_time" bson:"c_time,omitempty"`
+}
+
+type ArticleTag struct {
+	ID        int64 `gorm:"primaryKey,autoIncrement"`
+	ArticleID int64 `gorm:"uniqueIndex:uk_article_tag"`
+	TagID     int64 `gorm:"uniqueIndex:uk_article_tag;index"`
+
+	Ctime int64 `gorm:"column:c_time"`
+}
+
+// TagCount 标签下已发布文章的数量
+type TagCount struct {
+	Slug  string `bson:"_id"`
+	Name  string `bson:"name"`
+	Count int64  `bson:"count"`
+}
+
+type TagDao interface {
+	// SetArticleTags 覆盖文章的标签，不存在的标签会被创建
+	SetArticleTags(ctx context.Context, articleID int64, tags []Tag) error
+	GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]Tag, error)
+	// ListPubByTag 按照id倒序查询标签下已发布的文章，cursor为上一页最后一篇文章的id，0表示从头开始
+	ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]PublishArticle, error)
+	// CountPub 统计每个标签下已发布文章的数量，按数量倒序返回前limit个
+	CountPub(ctx context.Context, limit int) ([]TagCount, error)
+	// DeleteByArticle 彻底删除文章时删除文章的标签，标签本身保留
+	DeleteByArticle(ctx context.Context, articleID int64) error
+}
+
+type TagGORMDao struct {
+	db *gorm.DB
+}
+
+func NewTagDao(db *gorm.DB) TagDao {
+	return &TagGORMDao{
+		db: db,
+	}
+}
+
+func (dao *TagGORMDao) SetArticleTags(ctx context.Context, articleID int64, tags []Tag) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var tagIDs []int64
+		if len(tags) > 0 {
+			for i := range tags {
+				tags[i].Ctime = now
+			}
+			// 标签已经存在时保留原来的名称
+			err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&tags).Error
+			if err != nil {
+				return err
+			}
+			slugs := slice.Map(tags, func(idx int, src Tag) string {
+				return src.Slug
+			})
+			err = tx.Model(&Tag{}).Where("slug in ?", slugs).Pluck("id", &tagIDs).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		del := tx.Where("article_id = ?", articleID)
+		if len(tagIDs) > 0 {
+			del = del.Where("tag_id not in ?", tagIDs)
+		}
+		err := del.Delete(&ArticleTag{}).Error
+		if err != nil || len(tagIDs) == 0 {
+			return err
+		}
+
+		relations := slice.Map(tagIDs, func(idx int, src int64) ArticleTag {
+			return ArticleTag{ArticleID: articleID, TagID: src, Ctime: now}
+		})
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&relations).Error
+	})
+}
+
+func (dao *TagGORMDao) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]Tag, error) {
+	type row struct {
+		ArticleID int64
+		Tag
+	}
+	var rows []row
+	err := dao.db.WithContext(ctx).Model(&ArticleTag{}).
+		Select("article_tags.article_id, tags.id, tags.slug, tags.name, tags.c_time").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("article_tags.article_id in ?", articleIDs).
+		Order("article_tags.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]Tag, len(articleIDs))
+	for _, r := range rows {
+		res[r.ArticleID] = append(res[r.ArticleID], r.Tag)
+	}
+	return res, nil
+}
+
+func (dao *TagGORMDao) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]PublishArticle, error) {
+	query := dao.db.WithContext(ctx).Model(&PublishArticle{}).
+		Joins("JOIN article_tags ON article_tags.article_id = publish_articles.id").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Where("tags.slug = ? and publish_articles.status = ?", slug, ArticleStatusPublished)
+	if cursor > 0 {
+		query = query.Where("publish_articles.id < ?", cursor)
+	}
+	var articles []PublishArticle
+	err := query.Order("publish_articles.id desc").
+		Limit(limit).
+		Find(&articles).Error
+	return articles, err
+}
+
+func (dao *TagGORMDao) CountPub(ctx context.Context, limit int) ([]TagCount, error) {
+	var res []TagCount
+	err := dao.db.WithContext(ctx).Model(&ArticleTag{}).
+		Select("tags.slug, tags.name, count(*) as count").
+		Joins("JOIN tags ON tags.id = article_tags.tag_id").
+		Joins("JOIN publish_articles ON publish_articles.id = article_tags.article_id").
+		Where("publish_articles.status = ?", ArticleStatusPublished).
+		Group("tags.id").
+		Order("count desc").
+		Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (dao *TagGORMDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return dao.db.WithContext(ctx).Where("article_id = ?", articleID).Delete(&ArticleTag{}).Error
+}
+
+// MongoTagDao 标签的mongo存储实现
+type MongoTagDao struct {
+	tagCol          *mongo.Collection
+	artCol          *mongo.Collection
+	publishedArtCol *mongo.Collection
+	node            *snowflake.Node
+}
+
+func NewMongoTagDao(db *mongo.Database, node *snowflake.Node) TagDao {
+	return &MongoTagDao{
+		tagCol:          db.Collection("tags"),
+		artCol:          db.Collection("articles"),
+		publishedArtCol: db.Collection("published_articles"),
+		node:            node,
+	}
+}
+
+// articleTags 内嵌在文章中的标签
+type articleTags struct {
+	ID   int64 `bson:"id"`
+	Tags []Tag `bson:"tags"`
+}
+
+func (dao *MongoTagDao) SetArticleTags(ctx context.Context, articleID int64, tags []Tag) error {
+	now := time.Now().UnixMilli()
+	embedded := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		var stored Tag
+		err := dao.tagCol.FindOneAndUpdate(ctx,
+			bson.M{"slug": tag.Slug},
+			bson.M{"$setOnInsert": bson.M{
+				"id":     dao.node.Generate().Int64(),
+				"slug":   tag.Slug,
+				"name":   tag.Name,
+				"c_time": now,
+			}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&stored)
+		if err != nil {
+			return err
+		}
+		embedded = append(embedded, Tag{ID: stored.ID, Slug: stored.Slug, Name: stored.Name})
+	}
+
+	set := bson.M{"$set": bson.M{"tags": embedded}}
+	_, err := dao.artCol.UpdateOne(ctx, bson.M{"id": articleID}, set)
+	if err != nil {
+		return err
+	}
+	// 文章还没有发布时匹配不到线上库的文档，发布时Sync只更新指定的字段，不会覆盖tags
+	_, err = dao.publishedArtCol.UpdateOne(ctx, bson.M{"id": articleID}, set)
+	return err
+}
+
+func (dao *MongoTagDao) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]Tag, error) {
+	opts := options.Find().SetProjection(bson.M{"id": 1, "tags": 1})
+	cursor, err := dao.artCol.Find(ctx, bson.M{"id": bson.M{"$in": articleIDs}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	var arts []articleTags
+	err = cursor.All(ctx, &arts)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64][]Tag, len(arts))
+	for _, art := range arts {
+		res[art.ID] = art.Tags
+	}
+	return res, nil
+}
+
+func (dao *MongoTagDao) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]PublishArticle, error) {
+	filter := bson.M{"tags.slug": slug, "status": ArticleStatusPublished}
+	if cursor > 0 {
+		filter["id"] = bson.M{"$lt": cursor}
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "id", Value: -1}}).
+		SetLimit(int64(limit))
+	res, err := dao.publishedArtCol.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	var articles []PublishArticle
+	err = res.All(ctx, &articles)
+	return articles, err
+}
+
+func (dao *MongoTagDao) CountPub(ctx context.Context, limit int) ([]TagCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"status": ArticleStatusPublished}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$tags.slug",
+			"name":  bson.M{"$first": "$tags.name"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+	cursor, err := dao.publishedArtCol.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	var res []TagCount
+	err = cursor.All(ctx, &res)
+	return res, err
+}
+
+// DeleteByArticle 标签内嵌在文章中，随文章一起删除
+func (dao *MongoTagDao) DeleteByArticle(ctx context.Context, articleID int64) error {
+	return nil
+}
@@ -1,7 +1,12 @@
 package dao
 
-import "gorm.io/gorm"
+import (
+	"errors"
+	"gorm.io/gorm"
+)
 
 var (
 	ErrNotFound = gorm.ErrRecordNotFound
+	// ErrTooManyFollowees 关注数已经达到上限
+	ErrTooManyFollowees = errors.New("too many followees")
 )
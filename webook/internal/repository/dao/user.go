@@ -18,6 +18,8 @@ var (
 type UserDao interface {
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	FindByID(ctx context.Context, id int64) (User, error)
+	// FindByIDs 批量查询用户，不存在的用户不返回
+	FindByIDs(ctx context.Context, ids []int64) ([]User, error)
 	FindByEmail(ctx context.Context, email string) (User, error)
 	FindByOpenId(ctx context.Context, openId string) (User, error)
 	Insert(ctx context.Context, u User) error
@@ -40,6 +42,12 @@ func (dao *GORMUserDao) FindByID(ctx context.Context, id int64) (User, error) {
 	return user, err
 }
 
+func (dao *GORMUserDao) FindByIDs(ctx context.Context, ids []int64) ([]User, error) {
+	var users []User
+	err := dao.db.WithContext(ctx).Where("`id` in ?", ids).Find(&users).Error
+	return users, err
+}
+
 func (dao *GORMUserDao) FindByEmail(ctx context.Context, email string) (User, error) {
 	var user User
 	err := dao.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
@@ -99,6 +107,10 @@ type User struct {
 	UnionId sql.NullString `gorm:"unique"`
 	OpenId  sql.NullString `gorm:"unique"`
 
+	// Reviewer、RequireReview 审核相关的标记，由运营直接在库中设置
+	Reviewer      bool
+	RequireReview bool
+
 	CTime int64
 	UTime int64
 }
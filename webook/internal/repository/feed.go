@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+type FeedRepository interface {
+	// AddToInboxes 把item写入粉丝们的收件箱
+	AddToInboxes(ctx context.Context, uids []int64, item domain.FeedItem) error
+	// AddToOutbox 把item写入作者的发件箱
+	AddToOutbox(ctx context.Context, item domain.FeedItem) error
+	// Distributed 文章是否已经写入过收件箱、发件箱
+	Distributed(ctx context.Context, articleID int64) (pushed bool, pulled bool, err error)
+	// Remove 从收件箱和发件箱中删除文章
+	Remove(ctx context.Context, articleID int64) error
+	// ListInbox 按照(PublishedAt, ArticleID)倒序查询uid收件箱中authorIDs发布的文章
+	ListInbox(ctx context.Context, uid int64, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error)
+	// ListOutbox 按照(PublishedAt, ArticleID)倒序查询authorIDs的发件箱
+	ListOutbox(ctx context.Context, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error)
+}
+
+type feedRepository struct {
+	dao dao.FeedDao
+}
+
+func NewFeedRepository(dao dao.FeedDao) FeedRepository {
+	return &feedRepository{
+		dao: dao,
+	}
+}
+
+func (repo *feedRepository) AddToInboxes(ctx context.Context, uids []int64, item domain.FeedItem) error {
+	return repo.dao.InsertInbox(ctx, slice.Map(uids, func(idx int, uid int64) dao.FeedInbox {
+		return dao.FeedInbox{
+			Uid:         uid,
+			ArticleID:   item.ArticleID,
+			AuthorID:    item.AuthorID,
+			Title:       item.Title,
+			Abstract:    item.Abstract,
+			PublishedAt: item.PublishedAt.UnixMilli(),
+		}
+	}))
+}
+
+func (repo *feedRepository) AddToOutbox(ctx context.Context, item domain.FeedItem) error {
+	return repo.dao.UpsertOutbox(ctx, dao.FeedOutbox{
+		AuthorID:    item.AuthorID,
+		ArticleID:   item.ArticleID,
+		Title:       item.Title,
+		Abstract:    item.Abstract,
+		PublishedAt: item.PublishedAt.UnixMilli(),
+	})
+}
+
+func (repo *feedRepository) Distributed(ctx context.Context, articleID int64) (bool, bool, error) {
+	return repo.dao.Exists(ctx, articleID)
+}
+
+func (repo *feedRepository) Remove(ctx context.Context, articleID int64) error {
+	return repo.dao.DeleteByArticle(ctx, articleID)
+}
+
+func (repo *feedRepository) ListInbox(ctx context.Context, uid int64, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	beforeTime, beforeID := repo.position(cursor)
+	res, err := repo.dao.ListInbox(ctx, uid, authorIDs, beforeTime, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.FeedInbox) domain.FeedItem {
+		return domain.FeedItem{
+			ArticleID:   src.ArticleID,
+			AuthorID:    src.AuthorID,
+			Title:       src.Title,
+			Abstract:    src.Abstract,
+			PublishedAt: time.UnixMilli(src.PublishedAt),
+		}
+	}), nil
+}
+
+func (repo *feedRepository) ListOutbox(ctx context.Context, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	beforeTime, beforeID := repo.position(cursor)
+	res, err := repo.dao.ListOutbox(ctx, authorIDs, beforeTime, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, func(idx int, src dao.FeedOutbox) domain.FeedItem {
+		return domain.FeedItem{
+			ArticleID:   src.ArticleID,
+			AuthorID:    src.AuthorID,
+			Title:       src.Title,
+			Abstract:    src.Abstract,
+			PublishedAt: time.UnixMilli(src.PublishedAt),
+		}
+	}), nil
+}
+
+// position 游标的零值表示第一页
+func (repo *feedRepository) position(cursor domain.Cursor) (int64, int64) {
+	if cursor.IsZero() {
+		return 0, 0
+	}
+	return cursor.UTime.UnixMilli(), cursor.ID
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+type FollowRepository interface {
+	// Follow 关注，已经关注过时返回false，关注数已经达到domain.MaxFollowees时返回ErrTooManyFollowees
+	Follow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Unfollow 取消关注，没有关注时返回false
+	Unfollow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Get 没有关注时返回ErrNotFound
+	Get(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error)
+	ListFollowers(ctx context.Context, followee int64, maxID int64, limit int) ([]domain.FollowRelation, error)
+	ListFollowees(ctx context.Context, follower int64, maxID int64, limit int) ([]domain.FollowRelation, error)
+	GetStatics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followRepository struct {
+	dao dao.FollowDao
+}
+
+func NewFollowRepository(dao dao.FollowDao) FollowRepository {
+	return &followRepository{
+		dao: dao,
+	}
+}
+
+func (repo *followRepository) Follow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	return repo.dao.Insert(ctx, follower, followee, domain.MaxFollowees)
+}
+
+func (repo *followRepository) Unfollow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	return repo.dao.Delete(ctx, follower, followee)
+}
+
+func (repo *followRepository) Get(ctx context.Context, follower int64, followee int64) (domain.FollowRelation, error) {
+	r, err := repo.dao.Get(ctx, follower, followee)
+	if err != nil {
+		return domain.FollowRelation{}, err
+	}
+	return repo.toDomain(0, r), nil
+}
+
+func (repo *followRepository) ListFollowers(ctx context.Context, followee int64, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	res, err := repo.dao.ListFollowers(ctx, followee, maxID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, repo.toDomain), nil
+}
+
+func (repo *followRepository) ListFollowees(ctx context.Context, follower int64, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	res, err := repo.dao.ListFollowees(ctx, follower, maxID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(res, repo.toDomain), nil
+}
+
+func (repo *followRepository) GetStatics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	s, err := repo.dao.GetStatics(ctx, uid)
+	if err != nil {
+		return domain.FollowStatics{}, err
+	}
+	return domain.FollowStatics{
+		Followers: s.Followers,
+		Followees: s.Followees,
+	}, nil
+}
+
+func (repo *followRepository) toDomain(idx int, r dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		ID:       r.ID,
+		Follower: r.Follower,
+		Followee: r.Followee,
+		CTime:    time.UnixMilli(r.Ctime),
+	}
+}
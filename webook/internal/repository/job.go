@@ -8,6 +8,8 @@ import (
 )
 
 type JobRepository interface {
+	Create(ctx context.Context, job domain.Job) error
+
 	// Preempt 抢占一个任务
 	Preempt(ctx context.Context) (domain.Job, error)
 
@@ -22,6 +24,16 @@ type CronJobRepository struct {
 	dao dao.JobDao
 }
 
+func (repo *CronJobRepository) Create(ctx context.Context, job domain.Job) error {
+	return repo.dao.Insert(ctx, dao.Job{
+		Name:       job.Name,
+		Cfg:        job.Cfg,
+		Executor:   job.Executor,
+		Expression: job.Expression,
+		NextTime:   job.Nt.UnixMilli(),
+	})
+}
+
 func (repo *CronJobRepository) UpdateUTime(ctx context.Context, id int64, now time.Time) error {
 	return repo.dao.UpdateUTime(ctx, id, now)
 }
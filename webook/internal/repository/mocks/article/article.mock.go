@@ -0,0 +1,436 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/article.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/article.go -package=artrepomocks -destination=internal/repository/mocks/article/article.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleRepository is a mock of ArticleRepository interface.
+type MockArticleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleRepositoryMockRecorder
+}
+
+// MockArticleRepositoryMockRecorder is the mock recorder for MockArticleRepository.
+type MockArticleRepositoryMockRecorder struct {
+	mock *MockArticleRepository
+}
+
+// NewMockArticleRepository creates a new mock instance.
+func NewMockArticleRepository(ctrl *gomock.Controller) *MockArticleRepository {
+	mock := &MockArticleRepository{ctrl: ctrl}
+	mock.recorder = &MockArticleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleRepository) EXPECT() *MockArticleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AckAutosave mocks base method.
+func (m *MockArticleRepository) AckAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AckAutosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AckAutosave indicates an expected call of AckAutosave.
+func (mr *MockArticleRepositoryMockRecorder) AckAutosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AckAutosave", reflect.TypeOf((*MockArticleRepository)(nil).AckAutosave), ctx, save)
+}
+
+// Autosave mocks base method.
+func (m *MockArticleRepository) Autosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Autosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Autosave indicates an expected call of Autosave.
+func (mr *MockArticleRepositoryMockRecorder) Autosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Autosave", reflect.TypeOf((*MockArticleRepository)(nil).Autosave), ctx, save)
+}
+
+// CachePub mocks base method.
+func (m *MockArticleRepository) CachePub(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CachePub", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CachePub indicates an expected call of CachePub.
+func (mr *MockArticleRepositoryMockRecorder) CachePub(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CachePub", reflect.TypeOf((*MockArticleRepository)(nil).CachePub), ctx, article)
+}
+
+// CancelScheduled mocks base method.
+func (m *MockArticleRepository) CancelScheduled(ctx context.Context, id, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduled", ctx, id, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduled indicates an expected call of CancelScheduled.
+func (mr *MockArticleRepositoryMockRecorder) CancelScheduled(ctx, id, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduled", reflect.TypeOf((*MockArticleRepository)(nil).CancelScheduled), ctx, id, authorID)
+}
+
+// ClaimScheduled mocks base method.
+func (m *MockArticleRepository) ClaimScheduled(ctx context.Context, id int64, now time.Time, lease time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduled", ctx, id, now, lease)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduled indicates an expected call of ClaimScheduled.
+func (mr *MockArticleRepositoryMockRecorder) ClaimScheduled(ctx, id, now, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduled", reflect.TypeOf((*MockArticleRepository)(nil).ClaimScheduled), ctx, id, now, lease)
+}
+
+// Create mocks base method.
+func (m *MockArticleRepository) Create(ctx context.Context, article domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, article)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockArticleRepositoryMockRecorder) Create(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockArticleRepository)(nil).Create), ctx, article)
+}
+
+// DelAutosave mocks base method.
+func (m *MockArticleRepository) DelAutosave(ctx context.Context, uid, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DelAutosave", ctx, uid, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DelAutosave indicates an expected call of DelAutosave.
+func (mr *MockArticleRepositoryMockRecorder) DelAutosave(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DelAutosave", reflect.TypeOf((*MockArticleRepository)(nil).DelAutosave), ctx, uid, articleID)
+}
+
+// Delete mocks base method.
+func (m *MockArticleRepository) Delete(ctx context.Context, id, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleRepositoryMockRecorder) Delete(ctx, id, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleRepository)(nil).Delete), ctx, id, authorID)
+}
+
+// GetAutosave mocks base method.
+func (m *MockArticleRepository) GetAutosave(ctx context.Context, uid, articleID int64) (domain.ArticleAutosave, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAutosave", ctx, uid, articleID)
+	ret0, _ := ret[0].(domain.ArticleAutosave)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAutosave indicates an expected call of GetAutosave.
+func (mr *MockArticleRepositoryMockRecorder) GetAutosave(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAutosave", reflect.TypeOf((*MockArticleRepository)(nil).GetAutosave), ctx, uid, articleID)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleRepository) GetByAuthor(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleRepositoryMockRecorder) GetByAuthor(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleRepository)(nil).GetByAuthor), ctx, uid, cursor, limit)
+}
+
+// GetByID mocks base method.
+func (m *MockArticleRepository) GetByID(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockArticleRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockArticleRepository)(nil).GetByID), ctx, id)
+}
+
+// GetPubByID mocks base method.
+func (m *MockArticleRepository) GetPubByID(ctx context.Context, id int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByID", ctx, id)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByID indicates an expected call of GetPubByID.
+func (mr *MockArticleRepositoryMockRecorder) GetPubByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByID", reflect.TypeOf((*MockArticleRepository)(nil).GetPubByID), ctx, id)
+}
+
+// GetPubByIDs mocks base method.
+func (m *MockArticleRepository) GetPubByIDs(ctx context.Context, ids []int64) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByIDs", ctx, ids)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByIDs indicates an expected call of GetPubByIDs.
+func (mr *MockArticleRepositoryMockRecorder) GetPubByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByIDs", reflect.TypeOf((*MockArticleRepository)(nil).GetPubByIDs), ctx, ids)
+}
+
+// ListDueAutosaves mocks base method.
+func (m *MockArticleRepository) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueAutosaves", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ArticleAutosave)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueAutosaves indicates an expected call of ListDueAutosaves.
+func (mr *MockArticleRepositoryMockRecorder) ListDueAutosaves(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueAutosaves", reflect.TypeOf((*MockArticleRepository)(nil).ListDueAutosaves), ctx, now, limit)
+}
+
+// ListDueScheduled mocks base method.
+func (m *MockArticleRepository) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduled", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduled indicates an expected call of ListDueScheduled.
+func (mr *MockArticleRepositoryMockRecorder) ListDueScheduled(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduled", reflect.TypeOf((*MockArticleRepository)(nil).ListDueScheduled), ctx, now, limit)
+}
+
+// ListExpiredTrash mocks base method.
+func (m *MockArticleRepository) ListExpiredTrash(ctx context.Context, before time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredTrash", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredTrash indicates an expected call of ListExpiredTrash.
+func (mr *MockArticleRepositoryMockRecorder) ListExpiredTrash(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredTrash", reflect.TypeOf((*MockArticleRepository)(nil).ListExpiredTrash), ctx, before, limit)
+}
+
+// ListIDsByAuthor mocks base method.
+func (m *MockArticleRepository) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIDsByAuthor", ctx, uid, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIDsByAuthor indicates an expected call of ListIDsByAuthor.
+func (mr *MockArticleRepositoryMockRecorder) ListIDsByAuthor(ctx, uid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIDsByAuthor", reflect.TypeOf((*MockArticleRepository)(nil).ListIDsByAuthor), ctx, uid, limit)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleRepository) ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleRepositoryMockRecorder) ListPub(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleRepository)(nil).ListPub), ctx, cursor, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleRepository) ListTrash(ctx context.Context, authorID int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, authorID, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleRepositoryMockRecorder) ListTrash(ctx, authorID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleRepository)(nil).ListTrash), ctx, authorID, offset, limit)
+}
+
+// Purge mocks base method.
+func (m *MockArticleRepository) Purge(ctx context.Context, id, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleRepositoryMockRecorder) Purge(ctx, id, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleRepository)(nil).Purge), ctx, id, authorID)
+}
+
+// ReleaseScheduled mocks base method.
+func (m *MockArticleRepository) ReleaseScheduled(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseScheduled", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseScheduled indicates an expected call of ReleaseScheduled.
+func (mr *MockArticleRepositoryMockRecorder) ReleaseScheduled(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseScheduled", reflect.TypeOf((*MockArticleRepository)(nil).ReleaseScheduled), ctx, id)
+}
+
+// Restore mocks base method.
+func (m *MockArticleRepository) Restore(ctx context.Context, id, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleRepositoryMockRecorder) Restore(ctx, id, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleRepository)(nil).Restore), ctx, id, authorID)
+}
+
+// ScanPub mocks base method.
+func (m *MockArticleRepository) ScanPub(ctx context.Context, startID int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanPub", ctx, startID, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScanPub indicates an expected call of ScanPub.
+func (mr *MockArticleRepositoryMockRecorder) ScanPub(ctx, startID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanPub", reflect.TypeOf((*MockArticleRepository)(nil).ScanPub), ctx, startID, limit)
+}
+
+// Sync mocks base method.
+func (m *MockArticleRepository) Sync(ctx context.Context, article domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, article)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockArticleRepositoryMockRecorder) Sync(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleRepository)(nil).Sync), ctx, article)
+}
+
+// SyncStatus mocks base method.
+func (m *MockArticleRepository) SyncStatus(ctx context.Context, id, authorID int64, status int8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, authorID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockArticleRepositoryMockRecorder) SyncStatus(ctx, id, authorID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleRepository)(nil).SyncStatus), ctx, id, authorID, status)
+}
+
+// SyncV1 mocks base method.
+func (m *MockArticleRepository) SyncV1(ctx context.Context, article domain.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncV1", ctx, article)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncV1 indicates an expected call of SyncV1.
+func (mr *MockArticleRepositoryMockRecorder) SyncV1(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncV1", reflect.TypeOf((*MockArticleRepository)(nil).SyncV1), ctx, article)
+}
+
+// Update mocks base method.
+func (m *MockArticleRepository) Update(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockArticleRepositoryMockRecorder) Update(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockArticleRepository)(nil).Update), ctx, article)
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/article_revision.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/article_revision.go -package=artrepomocks -destination=internal/repository/mocks/article/article_revision.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevisionRepository is a mock of RevisionRepository interface.
+type MockRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRepositoryMockRecorder
+}
+
+// MockRevisionRepositoryMockRecorder is the mock recorder for MockRevisionRepository.
+type MockRevisionRepositoryMockRecorder struct {
+	mock *MockRevisionRepository
+}
+
+// NewMockRevisionRepository creates a new mock instance.
+func NewMockRevisionRepository(ctrl *gomock.Controller) *MockRevisionRepository {
+	mock := &MockRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRepository) EXPECT() *MockRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRevisionRepository) Create(ctx context.Context, revision domain.ArticleRevision) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, revision)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRevisionRepositoryMockRecorder) Create(ctx, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRevisionRepository)(nil).Create), ctx, revision)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockRevisionRepository) DeleteByArticle(ctx context.Context, articleID, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockRevisionRepositoryMockRecorder) DeleteByArticle(ctx, articleID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockRevisionRepository)(nil).DeleteByArticle), ctx, articleID, authorID)
+}
+
+// GetByID mocks base method.
+func (m *MockRevisionRepository) GetByID(ctx context.Context, articleID, authorID, id int64) (domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, articleID, authorID, id)
+	ret0, _ := ret[0].(domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockRevisionRepositoryMockRecorder) GetByID(ctx, articleID, authorID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockRevisionRepository)(nil).GetByID), ctx, articleID, authorID, id)
+}
+
+// List mocks base method.
+func (m *MockRevisionRepository) List(ctx context.Context, articleID, authorID int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, articleID, authorID, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockRevisionRepositoryMockRecorder) List(ctx, articleID, authorID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRevisionRepository)(nil).List), ctx, articleID, authorID, offset, limit)
+}
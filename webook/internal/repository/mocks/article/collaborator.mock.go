@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/collaborator.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/collaborator.go -package=artrepomocks -destination=internal/repository/mocks/article/collaborator.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCollaboratorRepository is a mock of CollaboratorRepository interface.
+type MockCollaboratorRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollaboratorRepositoryMockRecorder
+}
+
+// MockCollaboratorRepositoryMockRecorder is the mock recorder for MockCollaboratorRepository.
+type MockCollaboratorRepositoryMockRecorder struct {
+	mock *MockCollaboratorRepository
+}
+
+// NewMockCollaboratorRepository creates a new mock instance.
+func NewMockCollaboratorRepository(ctrl *gomock.Controller) *MockCollaboratorRepository {
+	mock := &MockCollaboratorRepository{ctrl: ctrl}
+	mock.recorder = &MockCollaboratorRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollaboratorRepository) EXPECT() *MockCollaboratorRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockCollaboratorRepository) Accept(ctx context.Context, articleID, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", ctx, articleID, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockCollaboratorRepositoryMockRecorder) Accept(ctx, articleID, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockCollaboratorRepository)(nil).Accept), ctx, articleID, uid)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockCollaboratorRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockCollaboratorRepositoryMockRecorder) DeleteByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockCollaboratorRepository)(nil).DeleteByArticle), ctx, articleID)
+}
+
+// Get mocks base method.
+func (m *MockCollaboratorRepository) Get(ctx context.Context, articleID, uid int64) (domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, articleID, uid)
+	ret0, _ := ret[0].(domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCollaboratorRepositoryMockRecorder) Get(ctx, articleID, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCollaboratorRepository)(nil).Get), ctx, articleID, uid)
+}
+
+// Invite mocks base method.
+func (m *MockCollaboratorRepository) Invite(ctx context.Context, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockCollaboratorRepositoryMockRecorder) Invite(ctx, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockCollaboratorRepository)(nil).Invite), ctx, c)
+}
+
+// List mocks base method.
+func (m *MockCollaboratorRepository) List(ctx context.Context, articleID int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, articleID)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCollaboratorRepositoryMockRecorder) List(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCollaboratorRepository)(nil).List), ctx, articleID)
+}
+
+// ListInvitations mocks base method.
+func (m *MockCollaboratorRepository) ListInvitations(ctx context.Context, uid int64, offset, limit int) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockCollaboratorRepositoryMockRecorder) ListInvitations(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockCollaboratorRepository)(nil).ListInvitations), ctx, uid, offset, limit)
+}
+
+// Remove mocks base method.
+func (m *MockCollaboratorRepository) Remove(ctx context.Context, articleID, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, articleID, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockCollaboratorRepositoryMockRecorder) Remove(ctx, articleID, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockCollaboratorRepository)(nil).Remove), ctx, articleID, uid)
+}
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/export.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/export.go -package=artrepomocks -destination=internal/repository/mocks/article/export.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExportRepository is a mock of ExportRepository interface.
+type MockExportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExportRepositoryMockRecorder
+}
+
+// MockExportRepositoryMockRecorder is the mock recorder for MockExportRepository.
+type MockExportRepositoryMockRecorder struct {
+	mock *MockExportRepository
+}
+
+// NewMockExportRepository creates a new mock instance.
+func NewMockExportRepository(ctrl *gomock.Controller) *MockExportRepository {
+	mock := &MockExportRepository{ctrl: ctrl}
+	mock.recorder = &MockExportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExportRepository) EXPECT() *MockExportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockExportRepository) Claim(ctx context.Context, id int64, staleBefore time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, staleBefore)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockExportRepositoryMockRecorder) Claim(ctx, id, staleBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockExportRepository)(nil).Claim), ctx, id, staleBefore)
+}
+
+// Create mocks base method.
+func (m *MockExportRepository) Create(ctx context.Context, e domain.ArticleExport) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, e)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockExportRepositoryMockRecorder) Create(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockExportRepository)(nil).Create), ctx, e)
+}
+
+// Delete mocks base method.
+func (m *MockExportRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExportRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExportRepository)(nil).Delete), ctx, id)
+}
+
+// Finish mocks base method.
+func (m *MockExportRepository) Finish(ctx context.Context, e domain.ArticleExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockExportRepositoryMockRecorder) Finish(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockExportRepository)(nil).Finish), ctx, e)
+}
+
+// GetByID mocks base method.
+func (m *MockExportRepository) GetByID(ctx context.Context, id int64) (domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockExportRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockExportRepository)(nil).GetByID), ctx, id)
+}
+
+// ListExpired mocks base method.
+func (m *MockExportRepository) ListExpired(ctx context.Context, before time.Time, limit int) ([]domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpired", ctx, before, limit)
+	ret0, _ := ret[0].([]domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpired indicates an expected call of ListExpired.
+func (mr *MockExportRepositoryMockRecorder) ListExpired(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpired", reflect.TypeOf((*MockExportRepository)(nil).ListExpired), ctx, before, limit)
+}
+
+// ListRunnable mocks base method.
+func (m *MockExportRepository) ListRunnable(ctx context.Context, staleBefore time.Time, limit int) ([]domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRunnable", ctx, staleBefore, limit)
+	ret0, _ := ret[0].([]domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRunnable indicates an expected call of ListRunnable.
+func (mr *MockExportRepositoryMockRecorder) ListRunnable(ctx, staleBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRunnable", reflect.TypeOf((*MockExportRepository)(nil).ListRunnable), ctx, staleBefore, limit)
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/media.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/media.go -package=artrepomocks -destination=internal/repository/mocks/article/media.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMediaRepository is a mock of MediaRepository interface.
+type MockMediaRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMediaRepositoryMockRecorder
+}
+
+// MockMediaRepositoryMockRecorder is the mock recorder for MockMediaRepository.
+type MockMediaRepositoryMockRecorder struct {
+	mock *MockMediaRepository
+}
+
+// NewMockMediaRepository creates a new mock instance.
+func NewMockMediaRepository(ctrl *gomock.Controller) *MockMediaRepository {
+	mock := &MockMediaRepository{ctrl: ctrl}
+	mock.recorder = &MockMediaRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMediaRepository) EXPECT() *MockMediaRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByArticle mocks base method.
+func (m *MockMediaRepository) CountByArticle(ctx context.Context, articleID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByArticle", ctx, articleID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByArticle indicates an expected call of CountByArticle.
+func (mr *MockMediaRepositoryMockRecorder) CountByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByArticle", reflect.TypeOf((*MockMediaRepository)(nil).CountByArticle), ctx, articleID)
+}
+
+// Create mocks base method.
+func (m_2 *MockMediaRepository) Create(ctx context.Context, m domain.Media) (int64, error) {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "Create", ctx, m)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMediaRepositoryMockRecorder) Create(ctx, m any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMediaRepository)(nil).Create), ctx, m)
+}
+
+// Delete mocks base method.
+func (m *MockMediaRepository) Delete(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMediaRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMediaRepository)(nil).Delete), ctx, id)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockMediaRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockMediaRepositoryMockRecorder) DeleteByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockMediaRepository)(nil).DeleteByArticle), ctx, articleID)
+}
+
+// GetByID mocks base method.
+func (m *MockMediaRepository) GetByID(ctx context.Context, id int64) (domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMediaRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMediaRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByArticle mocks base method.
+func (m *MockMediaRepository) ListByArticle(ctx context.Context, articleID int64) ([]domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticle", ctx, articleID)
+	ret0, _ := ret[0].([]domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticle indicates an expected call of ListByArticle.
+func (mr *MockMediaRepositoryMockRecorder) ListByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticle", reflect.TypeOf((*MockMediaRepository)(nil).ListByArticle), ctx, articleID)
+}
+
+// ListDeleted mocks base method.
+func (m *MockMediaRepository) ListDeleted(ctx context.Context, limit int) ([]domain.Media, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", ctx, limit)
+	ret0, _ := ret[0].([]domain.Media)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockMediaRepositoryMockRecorder) ListDeleted(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockMediaRepository)(nil).ListDeleted), ctx, limit)
+}
+
+// Remove mocks base method.
+func (m *MockMediaRepository) Remove(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockMediaRepositoryMockRecorder) Remove(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockMediaRepository)(nil).Remove), ctx, id)
+}
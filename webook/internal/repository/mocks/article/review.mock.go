@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/review.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/review.go -package=artrepomocks -destination=internal/repository/mocks/article/review.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CancelPending mocks base method.
+func (m *MockReviewRepository) CancelPending(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelPending", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelPending indicates an expected call of CancelPending.
+func (mr *MockReviewRepositoryMockRecorder) CancelPending(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelPending", reflect.TypeOf((*MockReviewRepository)(nil).CancelPending), ctx, articleID)
+}
+
+// Create mocks base method.
+func (m *MockReviewRepository) Create(ctx context.Context, review domain.ArticleReview) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, review)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockReviewRepositoryMockRecorder) Create(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReviewRepository)(nil).Create), ctx, review)
+}
+
+// Decide mocks base method.
+func (m *MockReviewRepository) Decide(ctx context.Context, review domain.ArticleReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decide", ctx, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decide indicates an expected call of Decide.
+func (mr *MockReviewRepositoryMockRecorder) Decide(ctx, review any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decide", reflect.TypeOf((*MockReviewRepository)(nil).Decide), ctx, review)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockReviewRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockReviewRepositoryMockRecorder) DeleteByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockReviewRepository)(nil).DeleteByArticle), ctx, articleID)
+}
+
+// GetByID mocks base method.
+func (m *MockReviewRepository) GetByID(ctx context.Context, id int64) (domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockReviewRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockReviewRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByArticle mocks base method.
+func (m *MockReviewRepository) ListByArticle(ctx context.Context, articleID int64, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByArticle", ctx, articleID, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByArticle indicates an expected call of ListByArticle.
+func (mr *MockReviewRepositoryMockRecorder) ListByArticle(ctx, articleID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByArticle", reflect.TypeOf((*MockReviewRepository)(nil).ListByArticle), ctx, articleID, offset, limit)
+}
+
+// ListPending mocks base method.
+func (m *MockReviewRepository) ListPending(ctx context.Context, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockReviewRepositoryMockRecorder) ListPending(ctx, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockReviewRepository)(nil).ListPending), ctx, offset, limit)
+}
+
+// Reopen mocks base method.
+func (m *MockReviewRepository) Reopen(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockReviewRepositoryMockRecorder) Reopen(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockReviewRepository)(nil).Reopen), ctx, id)
+}
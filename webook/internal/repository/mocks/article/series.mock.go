@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/series.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/series.go -package=artrepomocks -destination=internal/repository/mocks/article/series.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSeriesRepository is a mock of SeriesRepository interface.
+type MockSeriesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeriesRepositoryMockRecorder
+}
+
+// MockSeriesRepositoryMockRecorder is the mock recorder for MockSeriesRepository.
+type MockSeriesRepositoryMockRecorder struct {
+	mock *MockSeriesRepository
+}
+
+// NewMockSeriesRepository creates a new mock instance.
+func NewMockSeriesRepository(ctrl *gomock.Controller) *MockSeriesRepository {
+	mock := &MockSeriesRepository{ctrl: ctrl}
+	mock.recorder = &MockSeriesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeriesRepository) EXPECT() *MockSeriesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddArticle mocks base method.
+func (m *MockSeriesRepository) AddArticle(ctx context.Context, id, authorID, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddArticle", ctx, id, authorID, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddArticle indicates an expected call of AddArticle.
+func (mr *MockSeriesRepositoryMockRecorder) AddArticle(ctx, id, authorID, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddArticle", reflect.TypeOf((*MockSeriesRepository)(nil).AddArticle), ctx, id, authorID, articleID)
+}
+
+// Create mocks base method.
+func (m *MockSeriesRepository) Create(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSeriesRepositoryMockRecorder) Create(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSeriesRepository)(nil).Create), ctx, s)
+}
+
+// Delete mocks base method.
+func (m *MockSeriesRepository) Delete(ctx context.Context, id, authorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id, authorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeriesRepositoryMockRecorder) Delete(ctx, id, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeriesRepository)(nil).Delete), ctx, id, authorID)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockSeriesRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockSeriesRepositoryMockRecorder) DeleteByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockSeriesRepository)(nil).DeleteByArticle), ctx, articleID)
+}
+
+// GetByArticle mocks base method.
+func (m *MockSeriesRepository) GetByArticle(ctx context.Context, articleID int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticle", ctx, articleID)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticle indicates an expected call of GetByArticle.
+func (mr *MockSeriesRepositoryMockRecorder) GetByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticle", reflect.TypeOf((*MockSeriesRepository)(nil).GetByArticle), ctx, articleID)
+}
+
+// GetByID mocks base method.
+func (m *MockSeriesRepository) GetByID(ctx context.Context, id int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSeriesRepositoryMockRecorder) GetByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSeriesRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByAuthor mocks base method.
+func (m *MockSeriesRepository) ListByAuthor(ctx context.Context, authorID int64, offset, limit int) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAuthor", ctx, authorID, offset, limit)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAuthor indicates an expected call of ListByAuthor.
+func (mr *MockSeriesRepositoryMockRecorder) ListByAuthor(ctx, authorID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthor", reflect.TypeOf((*MockSeriesRepository)(nil).ListByAuthor), ctx, authorID, offset, limit)
+}
+
+// RemoveArticle mocks base method.
+func (m *MockSeriesRepository) RemoveArticle(ctx context.Context, id, authorID, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveArticle", ctx, id, authorID, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveArticle indicates an expected call of RemoveArticle.
+func (mr *MockSeriesRepositoryMockRecorder) RemoveArticle(ctx, id, authorID, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveArticle", reflect.TypeOf((*MockSeriesRepository)(nil).RemoveArticle), ctx, id, authorID, articleID)
+}
+
+// Reorder mocks base method.
+func (m *MockSeriesRepository) Reorder(ctx context.Context, id, authorID int64, articleIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, id, authorID, articleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockSeriesRepositoryMockRecorder) Reorder(ctx, id, authorID, articleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockSeriesRepository)(nil).Reorder), ctx, id, authorID, articleIDs)
+}
+
+// Update mocks base method.
+func (m *MockSeriesRepository) Update(ctx context.Context, s domain.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeriesRepositoryMockRecorder) Update(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeriesRepository)(nil).Update), ctx, s)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/repository/article/tag.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/repository/article/tag.go -package=artrepomocks -destination=internal/repository/mocks/article/tag.mock.go
+//
+
+// Package artrepomocks is a generated GoMock package.
+package artrepomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountPub mocks base method.
+func (m *MockTagRepository) CountPub(ctx context.Context, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPub", ctx, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPub indicates an expected call of CountPub.
+func (mr *MockTagRepositoryMockRecorder) CountPub(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPub", reflect.TypeOf((*MockTagRepository)(nil).CountPub), ctx, limit)
+}
+
+// DeleteByArticle mocks base method.
+func (m *MockTagRepository) DeleteByArticle(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByArticle", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByArticle indicates an expected call of DeleteByArticle.
+func (mr *MockTagRepositoryMockRecorder) DeleteByArticle(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByArticle", reflect.TypeOf((*MockTagRepository)(nil).DeleteByArticle), ctx, articleID)
+}
+
+// GetByArticleIDs mocks base method.
+func (m *MockTagRepository) GetByArticleIDs(ctx context.Context, articleIDs []int64) (map[int64][]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArticleIDs", ctx, articleIDs)
+	ret0, _ := ret[0].(map[int64][]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArticleIDs indicates an expected call of GetByArticleIDs.
+func (mr *MockTagRepositoryMockRecorder) GetByArticleIDs(ctx, articleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArticleIDs", reflect.TypeOf((*MockTagRepository)(nil).GetByArticleIDs), ctx, articleIDs)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockTagRepository) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, slug, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockTagRepositoryMockRecorder) ListPubByTag(ctx, slug, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockTagRepository)(nil).ListPubByTag), ctx, slug, cursor, limit)
+}
+
+// SetArticleTags mocks base method.
+func (m *MockTagRepository) SetArticleTags(ctx context.Context, articleID int64, tags []domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetArticleTags", ctx, articleID, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetArticleTags indicates an expected call of SetArticleTags.
+func (mr *MockTagRepositoryMockRecorder) SetArticleTags(ctx, articleID, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetArticleTags", reflect.TypeOf((*MockTagRepository)(nil).SetArticleTags), ctx, articleID, tags)
+}
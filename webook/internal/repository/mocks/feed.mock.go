@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/feed.go -package=repomocks -destination=./internal/repository/mocks/feed.mock.go
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToInboxes mocks base method.
+func (m *MockFeedRepository) AddToInboxes(ctx context.Context, uids []int64, item domain.FeedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToInboxes", ctx, uids, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToInboxes indicates an expected call of AddToInboxes.
+func (mr *MockFeedRepositoryMockRecorder) AddToInboxes(ctx, uids, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToInboxes", reflect.TypeOf((*MockFeedRepository)(nil).AddToInboxes), ctx, uids, item)
+}
+
+// AddToOutbox mocks base method.
+func (m *MockFeedRepository) AddToOutbox(ctx context.Context, item domain.FeedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToOutbox", ctx, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToOutbox indicates an expected call of AddToOutbox.
+func (mr *MockFeedRepositoryMockRecorder) AddToOutbox(ctx, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToOutbox", reflect.TypeOf((*MockFeedRepository)(nil).AddToOutbox), ctx, item)
+}
+
+// Distributed mocks base method.
+func (m *MockFeedRepository) Distributed(ctx context.Context, articleID int64) (bool, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Distributed", ctx, articleID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Distributed indicates an expected call of Distributed.
+func (mr *MockFeedRepositoryMockRecorder) Distributed(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Distributed", reflect.TypeOf((*MockFeedRepository)(nil).Distributed), ctx, articleID)
+}
+
+// ListInbox mocks base method.
+func (m *MockFeedRepository) ListInbox(ctx context.Context, uid int64, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInbox", ctx, uid, authorIDs, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInbox indicates an expected call of ListInbox.
+func (mr *MockFeedRepositoryMockRecorder) ListInbox(ctx, uid, authorIDs, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInbox", reflect.TypeOf((*MockFeedRepository)(nil).ListInbox), ctx, uid, authorIDs, cursor, limit)
+}
+
+// ListOutbox mocks base method.
+func (m *MockFeedRepository) ListOutbox(ctx context.Context, authorIDs []int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOutbox", ctx, authorIDs, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOutbox indicates an expected call of ListOutbox.
+func (mr *MockFeedRepositoryMockRecorder) ListOutbox(ctx, authorIDs, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOutbox", reflect.TypeOf((*MockFeedRepository)(nil).ListOutbox), ctx, authorIDs, cursor, limit)
+}
+
+// Remove mocks base method.
+func (m *MockFeedRepository) Remove(ctx context.Context, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFeedRepositoryMockRecorder) Remove(ctx, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFeedRepository)(nil).Remove), ctx, articleID)
+}
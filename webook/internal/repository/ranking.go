@@ -10,6 +10,10 @@ type RankingRepository interface {
 	Get(ctx context.Context) ([]domain.Article, error)
 
 	ReplaceTopN(ctx context.Context, arts []domain.Article) error
+
+	// GetTopTags 查询热门标签
+	GetTopTags(ctx context.Context) ([]domain.TagCount, error)
+	ReplaceTopTags(ctx context.Context, tags []domain.TagCount) error
 }
 
 func (c *cacheRankingRepository) Get(ctx context.Context) ([]domain.Article, error) {
@@ -24,6 +28,14 @@ func (c *cacheRankingRepository) ReplaceTopN(ctx context.Context, articles []dom
 	return c.redisCache.Set(ctx, articles)
 }
 
+func (c *cacheRankingRepository) GetTopTags(ctx context.Context) ([]domain.TagCount, error) {
+	return c.redisCache.GetTags(ctx)
+}
+
+func (c *cacheRankingRepository) ReplaceTopTags(ctx context.Context, tags []domain.TagCount) error {
+	return c.redisCache.SetTags(ctx, tags)
+}
+
 func (c *cacheRankingRepository) GetV1(ctx context.Context) ([]domain.Article, error) {
 	arts, err := c.localCache.Get(ctx)
 	if err == nil {
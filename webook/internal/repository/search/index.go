@@ -0,0 +1,28 @@
+package search
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+)
+
+/*
+文章搜索的索引。
+
+只有已发布的文章会进入索引，索引通过消费ArticleService产生的发布、撤回事件来更新，
+因此索引和线上库之间是最终一致的。
+
+实现：
+	MemoryArticleIndex：进程内的倒排索引，每个实例各自维护一份完整的索引，
+		实例启动时需要从线上库重建，适合数据量不大或者开发测试的场景。
+	MySQLArticleIndex：基于MySQL FULLTEXT（ngram解析器）索引，多个实例共享，可以通过离线命令重建。
+*/
+
+//go:generate mockgen -source=./index.go -package=searchmocks -destination=./mocks/index.mock.go ArticleIndex
+type ArticleIndex interface {
+	// Upsert 新增或者更新一篇文章的索引
+	Upsert(ctx context.Context, article domain.Article) error
+	// Delete 删除一篇文章的索引，文章不存在时不返回错误
+	Delete(ctx context.Context, id int64) error
+	// Search 按照相关度倒序返回命中的文章，SearchHit中的高亮片段由上层生成
+	Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error)
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/searchx"
+	"math"
+	"sort"
+	"sync"
+)
+
+// 标题命中的权重
+const titleBoost = 3
+
+type posting struct {
+	titleTF   int
+	contentTF int
+}
+
+// MemoryArticleIndex 进程内的倒排索引
+//
+// postings: term => 文章id => 词频
+// 查询时对所有的词取交集（AND），按照 Σ idf * (titleBoost*titleTF + contentTF) 算分。
+type MemoryArticleIndex struct {
+	lock     sync.RWMutex
+	docs     map[int64]domain.Article
+	postings map[string]map[int64]posting
+}
+
+func NewMemoryArticleIndex() *MemoryArticleIndex {
+	return &MemoryArticleIndex{
+		docs:     make(map[int64]domain.Article),
+		postings: make(map[string]map[int64]posting),
+	}
+}
+
+func (idx *MemoryArticleIndex) Upsert(ctx context.Context, article domain.Article) error {
+	terms := make(map[string]posting)
+	for _, token := range searchx.Tokenize(article.Title) {
+		p := terms[token.Term]
+		p.titleTF++
+		terms[token.Term] = p
+	}
+	for _, token := range searchx.Tokenize(article.Content) {
+		p := terms[token.Term]
+		p.contentTF++
+		terms[token.Term] = p
+	}
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.remove(article.ID)
+	idx.docs[article.ID] = article
+	for term, p := range terms {
+		docs, ok := idx.postings[term]
+		if !ok {
+			docs = make(map[int64]posting)
+			idx.postings[term] = docs
+		}
+		docs[article.ID] = p
+	}
+	return nil
+}
+
+func (idx *MemoryArticleIndex) Delete(ctx context.Context, id int64) error {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.remove(id)
+	return nil
+}
+
+// remove 调用者需要持有写锁
+func (idx *MemoryArticleIndex) remove(id int64) {
+	old, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	delete(idx.docs, id)
+	for _, term := range searchx.Terms(old.Title + "\n" + old.Content) {
+		docs := idx.postings[term]
+		delete(docs, id)
+		if len(docs) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+}
+
+func (idx *MemoryArticleIndex) Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error) {
+	terms := searchx.Terms(query)
+	if len(terms) == 0 {
+		return domain.SearchResult{}, nil
+	}
+
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	// 从文档最少的词开始求交集
+	lists := make([]map[int64]posting, 0, len(terms))
+	for _, term := range terms {
+		docs, ok := idx.postings[term]
+		if !ok {
+			return domain.SearchResult{}, nil
+		}
+		lists = append(lists, docs)
+	}
+	sort.Slice(lists, func(i, j int) bool {
+		return len(lists[i]) < len(lists[j])
+	})
+
+	n := float64(len(idx.docs))
+	hits := make([]domain.SearchHit, 0, len(lists[0]))
+	for id := range lists[0] {
+		var score float64
+		matched := true
+		for _, docs := range lists {
+			p, ok := docs[id]
+			if !ok {
+				matched = false
+				break
+			}
+			idf := math.Log(1 + n/float64(len(docs)))
+			score += idf * float64(titleBoost*p.titleTF+p.contentTF)
+		}
+		if matched {
+			hits = append(hits, domain.SearchHit{Article: idx.docs[id], Score: score})
+		}
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Article.ID > hits[j].Article.ID
+	})
+
+	res := domain.SearchResult{Total: int64(len(hits))}
+	if offset < len(hits) {
+		res.Hits = hits[offset:min(offset+limit, len(hits))]
+	}
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"learn_go/webook/internal/domain"
+	"testing"
+)
+
+func TestMemoryArticleIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	idx := NewMemoryArticleIndex()
+	arts := []domain.Article{
+		{ID: 1, Title: "Go语言入门", Content: "介绍Go语言的基础语法"},
+		{ID: 2, Title: "全文检索", Content: "基于倒排索引实现Go的全文检索"},
+		{ID: 3, Title: "Redis实战", Content: "缓存的常见问题"},
+	}
+	for _, art := range arts {
+		require.NoError(t, idx.Upsert(ctx, art))
+	}
+
+	testCases := []struct {
+		name   string
+		before func(t *testing.T)
+		query  string
+		offset int
+		limit  int
+
+		wantTotal int64
+		wantIDs   []int64
+	}{
+		{
+			name:      "标题命中的排在前面",
+			query:     "go",
+			limit:     10,
+			wantTotal: 2,
+			wantIDs:   []int64{1, 2},
+		},
+		{
+			name:      "多个词取交集",
+			query:     "Go 全文检索",
+			limit:     10,
+			wantTotal: 1,
+			wantIDs:   []int64{2},
+		},
+		{
+			name:      "分页",
+			query:     "go",
+			offset:    1,
+			limit:     1,
+			wantTotal: 2,
+			wantIDs:   []int64{2},
+		},
+		{
+			name:      "没有命中",
+			query:     "kafka",
+			limit:     10,
+			wantTotal: 0,
+		},
+		{
+			name: "更新后旧的词不再命中",
+			before: func(t *testing.T) {
+				require.NoError(t, idx.Upsert(ctx, domain.Article{ID: 3, Title: "Kafka实战", Content: "消息队列"}))
+			},
+			query:     "redis",
+			limit:     10,
+			wantTotal: 0,
+		},
+		{
+			name: "删除",
+			before: func(t *testing.T) {
+				require.NoError(t, idx.Delete(ctx, 1))
+			},
+			query:     "go",
+			limit:     10,
+			wantTotal: 1,
+			wantIDs:   []int64{2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.before != nil {
+				tc.before(t)
+			}
+			res, err := idx.Search(ctx, tc.query, tc.offset, tc.limit)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTotal, res.Total)
+			var ids []int64
+			for _, hit := range res.Hits {
+				ids = append(ids, hit.Article.ID)
+			}
+			assert.Equal(t, tc.wantIDs, ids)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package search
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/dao"
+	"time"
+)
+
+// MySQLArticleIndex 基于MySQL FULLTEXT索引实现
+type MySQLArticleIndex struct {
+	dao dao.ArticleSearchDao
+}
+
+func NewMySQLArticleIndex(dao dao.ArticleSearchDao) *MySQLArticleIndex {
+	return &MySQLArticleIndex{
+		dao: dao,
+	}
+}
+
+func (idx *MySQLArticleIndex) Upsert(ctx context.Context, article domain.Article) error {
+	entity := dao.ArticleSearch{
+		ID:       article.ID,
+		AuthorID: article.Author.ID,
+		Title:    article.Title,
+		Content:  article.Content,
+	}
+	if !article.CTime.IsZero() {
+		entity.Ctime = article.CTime.UnixMilli()
+	}
+	if !article.UTime.IsZero() {
+		entity.Utime = article.UTime.UnixMilli()
+	}
+	return idx.dao.Upsert(ctx, entity)
+}
+
+func (idx *MySQLArticleIndex) Delete(ctx context.Context, id int64) error {
+	return idx.dao.Delete(ctx, id)
+}
+
+func (idx *MySQLArticleIndex) Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error) {
+	arts, total, err := idx.dao.Search(ctx, query, offset, limit)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	return domain.SearchResult{
+		Total: total,
+		Hits: slice.Map(arts, func(i int, src dao.ArticleSearch) domain.SearchHit {
+			return domain.SearchHit{
+				Article: domain.Article{
+					ID:      src.ID,
+					Title:   src.Title,
+					Content: src.Content,
+					Author:  domain.Author{ID: src.AuthorID},
+					Status:  domain.ArticleStatusPublished,
+					CTime:   time.UnixMilli(src.Ctime),
+					UTime:   time.UnixMilli(src.Utime),
+				},
+				Score: src.Score,
+			}
+		}),
+	}, nil
+}
@@ -3,5 +3,6 @@ package repository
 import "learn_go/webook/internal/repository/dao"
 
 var (
-	ErrNotFound = dao.ErrNotFound
+	ErrNotFound         = dao.ErrNotFound
+	ErrTooManyFollowees = dao.ErrTooManyFollowees
 )
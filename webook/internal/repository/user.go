@@ -19,6 +19,8 @@ var (
 
 type UserRepository interface {
 	FindByID(ctx context.Context, id int64) (domain.User, error)
+	// FindByIDs 批量查询用户，直接查询数据库，不存在的用户不在结果中
+	FindByIDs(ctx context.Context, ids []int64) (map[int64]domain.User, error)
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindByOpenId(ctx context.Context, openId string) (domain.User, error)
@@ -84,6 +86,21 @@ func (repo *userRepository) FindByID(ctx context.Context, id int64) (domain.User
 	return domain.User{}, err
 }
 
+func (repo *userRepository) FindByIDs(ctx context.Context, ids []int64) (map[int64]domain.User, error) {
+	if len(ids) == 0 {
+		return map[int64]domain.User{}, nil
+	}
+	users, err := repo.ud.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]domain.User, len(users))
+	for _, u := range users {
+		res[u.ID] = toDomain(u)
+	}
+	return res, nil
+}
+
 func (repo *userRepository) UpdateNonZeroFields(ctx context.Context, u domain.User) error {
 	// note: 个人感觉应该过滤nil字段，才符合该方法名的定义。
 	return repo.ud.UpdateById(ctx, toEntity(u))
@@ -113,6 +130,8 @@ func toDomain(user dao.User) domain.User {
 			UnionId: user.UnionId.String,
 			OpenId:  user.OpenId.String,
 		},
+		Reviewer:      user.Reviewer,
+		RequireReview: user.RequireReview,
 	}
 }
 
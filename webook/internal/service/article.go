@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
 	"learn_go/webook/internal/domain"
 	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/repository"
 	"learn_go/webook/internal/repository/article"
 	"learn_go/webook/pkg/logger"
 	"time"
@@ -41,23 +43,118 @@ note: 如果俩个数据源的结构相似度较高，代码相似度较高，
 
 */
 
+// ErrVersionConflict 保存、发布时带上的版本号已经过期，草稿被其他请求修改过了
+var ErrVersionConflict = article.ErrVersionConflict
+
 //go:generate mockgen -source=./article.go -package=svcmocks -destination=./mocks/article.mock.go ArticleService
 type ArticleService interface {
-	Save(ctx context.Context, article domain.Article) (int64, error)
+	// Save 保存草稿，返回保存后的文章，Version是新的版本号。article.Version为0（不校验版本号）时返回的Version也是0
+	Save(ctx context.Context, article domain.Article) (domain.Article, error)
 
-	Publish(ctx context.Context, article domain.Article) (int64, error)
+	// Publish 发布文章，返回值和Save相同
+	Publish(ctx context.Context, article domain.Article) (domain.Article, error)
 
 	PublishV1(ctx context.Context, article domain.Article) (int64, error)
 
 	Withdraw(ctx context.Context, article domain.Article) error
 
-	// GetList 根据作者id查询文章列表
-	GetList(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// GetList 根据作者id查询文章列表，按照(utime, id)倒序游标分页，cursor为零值时查询第一页
+	GetList(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error)
 	GetByID(ctx context.Context, id int64) (domain.Article, error)
+	// ListIDsByAuthor 按照id倒序查询作者最新的limit篇文章的id，不包括回收站中的文章，用于统计作者的数据
+	ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error)
 
-	ListPub(c context.Context, time time.Time, offset int, limit int) ([]domain.Article, error)
+	// ListPub 查询已发布的文章，分页方式和GetList相同
+	ListPub(c context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error)
 	// GetPubArticle 根据id、uid查询已发布的文章
 	GetPubArticle(ctx context.Context, uid, id int64) (domain.Article, error)
+	// IsPublished 文章是否已经发布，撤回的文章返回false。不走缓存，也不记录阅读
+	IsPublished(ctx context.Context, id int64) (bool, error)
+
+	// ListRevisions 查询作者某篇文章的历史版本
+	ListRevisions(ctx context.Context, uid int64, articleID int64, offset int, limit int) ([]domain.ArticleRevision, error)
+	// DiffRevisions 对比俩个历史版本的差异
+	DiffRevisions(ctx context.Context, uid int64, articleID int64, fromID int64, toID int64) (domain.RevisionDiff, error)
+	// Rollback 将草稿或者已发布的文章回滚到指定的历史版本
+	Rollback(ctx context.Context, uid int64, articleID int64, revisionID int64, target domain.RollbackTarget) (int64, error)
+
+	// Schedule 保存文章，并在article.PublishAt时自动发布，返回值和Save相同
+	Schedule(ctx context.Context, article domain.Article) (domain.Article, error)
+	// CancelSchedule 取消定时发布，文章回到草稿状态
+	CancelSchedule(ctx context.Context, article domain.Article) error
+	// ListDueScheduled 查询到期的定时发布文章
+	ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error)
+	// PublishScheduled 发布一篇到期的定时发布文章，多个实例同时执行时只会发布一次
+	PublishScheduled(ctx context.Context, article domain.Article) error
+
+	// ListPubByTag 按照id倒序查询标签下已发布的文章，cursor为上一页最后一篇文章的id，0表示第一页
+	ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error)
+	// CountTags 统计每个标签下已发布文章的数量，按数量倒序返回前limit个
+	CountTags(ctx context.Context, limit int) ([]domain.TagCount, error)
+
+	// Delete 将文章移入回收站
+	Delete(ctx context.Context, article domain.Article) error
+	// ListTrash 按照删除时间倒序查询作者回收站中的文章
+	ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error)
+	// Restore 将回收站中的文章恢复成草稿
+	Restore(ctx context.Context, article domain.Article) error
+	// Purge 彻底删除回收站中的文章，并清理文章的交互数据、标签、版本记录和上传的文件
+	Purge(ctx context.Context, article domain.Article) error
+	// ListExpiredTrash 查询在回收站中保留时间超过domain.ArticleTrashTTL的文章
+	ListExpiredTrash(ctx context.Context, now time.Time, limit int) ([]domain.Article, error)
+
+	// GetDraft 查询制作库中的文章，所有者和协作者都可以查看
+	GetDraft(ctx context.Context, uid int64, articleID int64) (domain.Article, error)
+	// Role 查询用户对文章的角色，文章不存在时返回ErrArticleNotFound
+	Role(ctx context.Context, uid int64, articleID int64) (domain.ArticleRole, error)
+	// Invite 所有者邀请用户成为文章的协作者，已经是协作者时修改角色
+	Invite(ctx context.Context, uid int64, c domain.Collaborator) error
+	AcceptInvitation(ctx context.Context, uid int64, articleID int64) error
+	DeclineInvitation(ctx context.Context, uid int64, articleID int64) error
+	// RemoveCollaborator 所有者移除协作者，或者协作者自己退出
+	RemoveCollaborator(ctx context.Context, uid int64, articleID int64, collaboratorID int64) error
+	// ListCollaborators 查询文章的全部协作者，包括还没有接受邀请的
+	ListCollaborators(ctx context.Context, uid int64, articleID int64) ([]domain.Collaborator, error)
+	// ListInvitations 查询用户待接受的邀请
+	ListInvitations(ctx context.Context, uid int64, offset int, limit int) ([]domain.Collaborator, error)
+
+	// Submit 保存文章并提交审核，返回值和Save相同
+	Submit(ctx context.Context, article domain.Article) (domain.Article, error)
+	// ListReviewQueue 审核人员按照提交的先后顺序查询待审核的记录
+	ListReviewQueue(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleReview, error)
+	// ListReviews 按照提交时间倒序查询文章的审核记录
+	ListReviews(ctx context.Context, uid int64, articleID int64, offset int, limit int) ([]domain.ArticleReview, error)
+	// Approve 审核通过并发布文章
+	Approve(ctx context.Context, uid int64, reviewID int64, comment string) error
+	// Reject 驳回，必须填写审核意见
+	Reject(ctx context.Context, uid int64, reviewID int64, comment string) error
+
+	// Autosave 将草稿的快照写入缓冲区，由定时任务写入制作库
+	Autosave(ctx context.Context, save domain.ArticleAutosave) error
+	// RecoverAutosave 查询比制作库更新的快照，没有时返回ErrAutosaveNotFound
+	RecoverAutosave(ctx context.Context, uid int64, articleID int64) (domain.Article, error)
+	// ListDueAutosaves 查询到期需要写入制作库的快照
+	ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error)
+	// FlushAutosave 将快照写入制作库，返回error时等待下一次重试
+	FlushAutosave(ctx context.Context, save domain.ArticleAutosave) error
+
+	// CreateSeries 创建系列，s.Author是系列的作者
+	CreateSeries(ctx context.Context, s domain.Series) (int64, error)
+	// UpdateSeries 修改系列的标题和简介
+	UpdateSeries(ctx context.Context, s domain.Series) error
+	// DeleteSeries 删除系列，系列中的文章不受影响
+	DeleteSeries(ctx context.Context, uid int64, id int64) error
+	// ListSeries 按照修改时间倒序查询作者的系列，Series.Articles只有文章的id
+	ListSeries(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error)
+	// GetSeries 作者查看系列，包括草稿
+	GetSeries(ctx context.Context, uid int64, id int64) (domain.Series, error)
+	// AddSeriesArticle 将作者的文章追加到系列的末尾
+	AddSeriesArticle(ctx context.Context, uid int64, seriesID int64, articleID int64) error
+	RemoveSeriesArticle(ctx context.Context, uid int64, seriesID int64, articleID int64) error
+	// ReorderSeries 按照articleIDs的顺序重新排列系列中的文章，articleIDs必须包含系列中全部的文章
+	ReorderSeries(ctx context.Context, uid int64, seriesID int64, articleIDs []int64) error
+	// GetPubSeries 读者查看系列，只返回已发布的文章
+	GetPubSeries(ctx context.Context, id int64) (domain.Series, error)
 }
 
 type articleService struct {
@@ -68,28 +165,67 @@ type articleService struct {
 	// 与ArticleRepository互斥
 	articleAuthorRepo article.AuthorRepository
 	articleReaderRepo article.ReaderRepository
+	revisionRepo      article.RevisionRepository
+	tagRepo           article.TagRepository
+	collaboratorRepo  article.CollaboratorRepository
+	reviewRepo        article.ReviewRepository
+	seriesRepo        article.SeriesRepository
+	mediaRepo         article.MediaRepository
+	userRepo          repository.UserRepository
 	producer          event.Producer
+	interSvc          intrv1.InteractionServiceClient
+}
+
+func (svc *articleService) ListPub(ctx context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	arts, err := svc.articleRepo.ListPub(ctx, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	fillAbstracts(arts)
+	return arts, nil
 }
 
-func (svc *articleService) ListPub(ctx context.Context, t time.Time, offset int, limit int) ([]domain.Article, error) {
-	return svc.articleRepo.ListPub(ctx, t, offset, limit)
+func (svc *articleService) GetList(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
+	arts, err := svc.articleRepo.GetByAuthor(ctx, uid, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	svc.fillTags(ctx, arts)
+	fillAbstracts(arts)
+	return arts, nil
 }
 
-func (svc *articleService) GetList(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
-	return svc.articleRepo.GetByAuthor(ctx, uid, offset, limit)
+func (svc *articleService) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	return svc.articleRepo.ListIDsByAuthor(ctx, uid, limit)
 }
 
 func (svc *articleService) GetByID(ctx context.Context, articleID int64) (domain.Article, error) {
-	return svc.articleRepo.GetByID(ctx, articleID)
+	art, err := svc.articleRepo.GetByID(ctx, articleID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	arts := []domain.Article{art}
+	svc.fillTags(ctx, arts)
+	return arts[0], nil
 }
 
 func (svc *articleService) GetPubArticle(ctx context.Context, uid, articleID int64) (domain.Article, error) {
 	art, err := svc.articleRepo.GetPubByID(ctx, articleID)
+	if err == nil {
+		svc.renderPub(ctx, &art)
+		arts := []domain.Article{art}
+		svc.fillTags(ctx, arts)
+		art = arts[0]
+		svc.fillContributors(ctx, &art)
+		svc.fillSeries(ctx, &art)
+	}
+	readAt := time.Now()
 	go func() {
 		// TODO 生产者也可以批量发送消息，减少kafka broker的压力。实现类型ProduceReadEvents([]event.ReadEvent)的接口。
 		err := svc.producer.ProduceReadEvent(event.ReadEvent{
 			Uid:       uid,
 			ArticleID: articleID,
+			Time:      readAt.UnixMilli(),
 		})
 		if err != nil {
 			// 记录日志
@@ -98,13 +234,123 @@ func (svc *articleService) GetPubArticle(ctx context.Context, uid, articleID int
 	return art, err
 }
 
+func (svc *articleService) IsPublished(ctx context.Context, id int64) (bool, error) {
+	arts, err := svc.articleRepo.GetPubByIDs(ctx, []int64{id})
+	if err != nil {
+		return false, err
+	}
+	return len(arts) > 0 && arts[0].Status == domain.ArticleStatusPublished, nil
+}
+
 func (svc *articleService) Withdraw(ctx context.Context, article domain.Article) error {
-	return svc.articleRepo.SyncStatus(ctx, article.ID, article.Author.ID, domain.ArticleStatusPrivate)
+	article.Status = domain.ArticleStatusPrivate
+	article, err := svc.actAs(ctx, article, domain.ArticleRole.CanManage)
+	if err != nil {
+		return err
+	}
+	err = svc.articleRepo.SyncStatus(ctx, article.ID, article.Author.ID, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	svc.produceSyncEvent(article)
+	svc.produceRemovedEvent(article)
+	return nil
 }
 
-func (svc *articleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
+func (svc *articleService) Publish(ctx context.Context, article domain.Article) (domain.Article, error) {
 	article.Status = domain.ArticleStatusPublished
-	return svc.articleRepo.Sync(ctx, article)
+	uid := article.Author.ID
+	if article.ID > 0 {
+		var err error
+		article, err = svc.actAs(ctx, svc.rebaseAutosave(ctx, article), domain.ArticleRole.CanEdit)
+		if err != nil {
+			return domain.Article{}, err
+		}
+	}
+	// actAs之后Author是文章的所有者
+	if err := svc.checkDirectPublish(ctx, article.Author.ID); err != nil {
+		return domain.Article{}, err
+	}
+	version := nextVersion(article)
+	id, err := svc.publish(ctx, article)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	article.ID = id
+	svc.discardAutosave(ctx, uid, article)
+	article.Version = version
+	return article, nil
+}
+
+// nextVersion 保存成功后文章的版本号：新建的文章是1，校验了版本号的更新加一，不校验版本号时不知道新的版本号，返回0
+func nextVersion(article domain.Article) int64 {
+	if article.ID == 0 {
+		return 1
+	}
+	if article.Version > 0 {
+		return article.Version + 1
+	}
+	return 0
+}
+
+// publish 同步到线上库，调用方已经完成了权限和状态的校验
+func (svc *articleService) publish(ctx context.Context, article domain.Article) (int64, error) {
+	article.Status = domain.ArticleStatusPublished
+	// 发布时渲染，渲染结果随文章一起写入缓存
+	render(&article)
+	id, err := svc.articleRepo.Sync(ctx, article)
+	if err != nil {
+		return 0, err
+	}
+	article.ID = id
+	if err = svc.saveTags(ctx, article); err != nil {
+		return 0, err
+	}
+	svc.recordRevision(ctx, article)
+	svc.produceSyncEvent(article)
+	svc.producePublishedEvent(article)
+	return id, nil
+}
+
+// produceSyncEvent 线上库变化后通知下游（搜索索引等），失败只记录日志，不影响发布、撤回的结果
+func (svc *articleService) produceSyncEvent(article domain.Article) {
+	err := svc.producer.ProduceSyncEvent(event.SyncEvent{
+		ArticleID: article.ID,
+		AuthorID:  article.Author.ID,
+		Title:     article.Title,
+		Content:   article.Content,
+		Status:    article.Status.ToInt8(),
+		Utime:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		svc.log.Error("发送文章同步事件失败", logger.Int64("article id", article.ID), logger.Error(err))
+	}
+}
+
+// produceRemovedEvent 通知关注流移除已经不可见的文章，失败只记录日志
+func (svc *articleService) produceRemovedEvent(article domain.Article) {
+	err := svc.producer.ProducePublishedEvent(event.PublishedEvent{
+		ArticleID: article.ID,
+		AuthorID:  article.Author.ID,
+		Removed:   true,
+	})
+	if err != nil {
+		svc.log.Error("发送文章移除事件失败", logger.Int64("article id", article.ID), logger.Error(err))
+	}
+}
+
+// producePublishedEvent 通知关注流分发文章，失败只记录日志，粉丝在关注流中看不到这次发布
+func (svc *articleService) producePublishedEvent(article domain.Article) {
+	err := svc.producer.ProducePublishedEvent(event.PublishedEvent{
+		ArticleID:   article.ID,
+		AuthorID:    article.Author.ID,
+		Title:       article.Title,
+		Abstract:    article.Abstract,
+		PublishedAt: time.Now().UnixMilli(),
+	})
+	if err != nil {
+		svc.log.Error("发送文章发布事件失败", logger.Int64("article id", article.ID), logger.Error(err))
+	}
 }
 
 func (svc *articleService) PublishV1(ctx context.Context, article domain.Article) (int64, error) {
@@ -151,23 +397,68 @@ func NewArticleService(
 	articleRepo article.ArticleRepository,
 	articleAuthorRepo article.AuthorRepository,
 	articleReaderRepo article.ReaderRepository,
+	revisionRepo article.RevisionRepository,
+	tagRepo article.TagRepository,
+	collaboratorRepo article.CollaboratorRepository,
+	reviewRepo article.ReviewRepository,
+	seriesRepo article.SeriesRepository,
+	mediaRepo article.MediaRepository,
+	userRepo repository.UserRepository,
 	producer event.Producer,
+	interSvc intrv1.InteractionServiceClient,
 	log logger.LoggerV2) ArticleService {
 	return &articleService{
 		producer:          producer,
+		interSvc:          interSvc,
 		log:               log,
 		articleRepo:       articleRepo,
 		articleAuthorRepo: articleAuthorRepo,
 		articleReaderRepo: articleReaderRepo,
+		revisionRepo:      revisionRepo,
+		tagRepo:           tagRepo,
+		collaboratorRepo:  collaboratorRepo,
+		reviewRepo:        reviewRepo,
+		seriesRepo:        seriesRepo,
+		mediaRepo:         mediaRepo,
+		userRepo:          userRepo,
 	}
 }
 
 // Save status = unpublish，
-func (svc *articleService) Save(ctx context.Context, article domain.Article) (int64, error) {
+func (svc *articleService) Save(ctx context.Context, article domain.Article) (domain.Article, error) {
 	article.Status = domain.ArticleStatusUnpublished
+	article, _, err := svc.saveDraft(ctx, article)
+	return article, err
+}
+
+// saveDraft 保存制作库中的文章，article.Status是保存后的状态。
+// 返回保存后的文章（Version是新的版本号）和文章原来的状态，新建的文章原来的状态是ArticleStatusUnknown
+func (svc *articleService) saveDraft(ctx context.Context, article domain.Article) (domain.Article, domain.ArticleStatus, error) {
+	var (
+		from    = domain.ArticleStatus(domain.ArticleStatusUnknown)
+		uid     = article.Author.ID
+		version = nextVersion(article)
+		err     error
+	)
 	if article.ID > 0 {
-		err := svc.articleRepo.Update(ctx, article)
-		return article.ID, err
+		article, from, err = svc.transit(ctx, svc.rebaseAutosave(ctx, article), domain.ArticleRole.CanEdit)
+		if err != nil {
+			return domain.Article{}, from, err
+		}
+		// 版本号可能被自动保存的快照更新过
+		version = nextVersion(article)
+		err = svc.articleRepo.Update(ctx, article)
+	} else {
+		article.ID, err = svc.articleRepo.Create(ctx, article)
+	}
+	if err != nil {
+		return domain.Article{}, from, err
+	}
+	if err = svc.saveTags(ctx, article); err != nil {
+		return domain.Article{}, from, err
 	}
-	return svc.articleRepo.Create(ctx, article)
+	svc.recordRevision(ctx, article)
+	svc.discardAutosave(ctx, uid, article)
+	article.Version = version
+	return article, from, nil
 }
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+/*
+自动保存
+
+	客户端定期把正在编辑的草稿提交到缓冲区（redis），由定时任务在作者停止编辑一段时间后写入制作库，避免浏览器崩溃时丢失内容。
+	快照带上客户端编辑时基于的版本号，写入时和主动保存一样校验版本号，草稿被其他人修改过时放弃写入，快照留在缓冲区中等待恢复。
+	快照写入后草稿的版本号加一，客户端手里的版本号就过期了。缓冲区记录了写入后的版本号，
+	客户端之后自动保存或者主动保存时，如果版本号之后只有自己的快照写入过，就使用写入后的版本号，不算冲突。
+	写入时只修改标题和内容，不修改文章的状态，也不记录历史版本，这些由主动保存决定。
+	审核中的文章不能修改，快照不写入制作库，同样留在缓冲区中等待恢复。
+	主动保存、发布成功后删除快照。
+*/
+
+var (
+	ErrInvalidAutosave  = errors.New("invalid autosave")
+	ErrAutosaveNotFound = article.ErrAutosaveNotFound
+)
+
+func (svc *articleService) Autosave(ctx context.Context, save domain.ArticleAutosave) error {
+	// 新建的文章需要先主动保存一次
+	if save.ArticleID <= 0 || save.BaseVersion <= 0 {
+		return ErrInvalidAutosave
+	}
+	_, err := svc.authorize(ctx, save.ArticleID, save.Uid, domain.ArticleRole.CanEdit)
+	if err != nil {
+		return err
+	}
+	save.SavedAt = time.Now()
+	return svc.articleRepo.Autosave(ctx, save)
+}
+
+func (svc *articleService) RecoverAutosave(ctx context.Context, uid int64, articleID int64) (domain.Article, error) {
+	art, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanEdit)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	save, err := svc.articleRepo.GetAutosave(ctx, uid, articleID)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	// 快照已经写入制作库，或者之后又保存过
+	if !save.SavedAt.After(art.UTime) {
+		return domain.Article{}, ErrAutosaveNotFound
+	}
+	// 版本号使用草稿当前的版本号，客户端确认恢复后可以直接保存
+	art.Title = save.Title
+	art.Content = save.Content
+	art.UTime = save.SavedAt
+	return art, nil
+}
+
+func (svc *articleService) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	return svc.articleRepo.ListDueAutosaves(ctx, now, limit)
+}
+
+func (svc *articleService) FlushAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	version := save.BaseVersion
+	if save.FlushedVersion > 0 {
+		version = save.FlushedVersion
+	}
+	err := svc.flushAutosave(ctx, save, version)
+	switch err {
+	case nil:
+		save.FlushedVersion = version + 1
+	case ErrArticleNotFound, ErrNoPermission, ErrVersionConflict, ErrInvalidStatusTransition:
+		// 重试也不会成功，放弃写入，快照留在缓冲区中等待恢复
+		svc.log.Warn("放弃写入自动保存的快照",
+			logger.Int64("article id", save.ArticleID),
+			logger.Int64("uid", save.Uid),
+			logger.Error(err))
+		save.FlushedVersion = 0
+	default:
+		return err
+	}
+	return svc.articleRepo.AckAutosave(ctx, save)
+}
+
+func (svc *articleService) flushAutosave(ctx context.Context, save domain.ArticleAutosave, version int64) error {
+	art, err := svc.authorize(ctx, save.ArticleID, save.Uid, domain.ArticleRole.CanEdit)
+	if err != nil {
+		return err
+	}
+	if !art.DTime.IsZero() {
+		return ErrArticleNotFound
+	}
+	if art.Version != version {
+		return ErrVersionConflict
+	}
+	// 写入不改变状态，相当于把文章保存成当前的状态。审核中的文章不能修改，否则审核人员看到的内容会被替换
+	if !art.Status.CanTransitTo(art.Status) {
+		return ErrInvalidStatusTransition
+	}
+	art.Title = save.Title
+	art.Content = save.Content
+	art.EditorID = save.Uid
+	// 撤回等操作只修改状态、不改变版本号，写入时不能把状态改回去
+	art.FromStatus = art.Status
+	return svc.articleRepo.Update(ctx, art)
+}
+
+// rebaseAutosave 客户端的版本号之后只有自己的快照写入过制作库时，换成写入后的版本号
+func (svc *articleService) rebaseAutosave(ctx context.Context, art domain.Article) domain.Article {
+	if art.ID <= 0 || art.Version <= 0 {
+		return art
+	}
+	save, err := svc.articleRepo.GetAutosave(ctx, art.Author.ID, art.ID)
+	if err != nil {
+		if err != ErrAutosaveNotFound {
+			svc.log.Error("查询自动保存的快照失败", logger.Int64("article id", art.ID), logger.Error(err))
+		}
+		return art
+	}
+	if save.BaseVersion == art.Version && save.FlushedVersion > 0 {
+		art.Version = save.FlushedVersion
+	}
+	return art
+}
+
+// discardAutosave 主动保存之后快照就没有用了，删除失败只记录日志
+func (svc *articleService) discardAutosave(ctx context.Context, uid int64, art domain.Article) {
+	if art.Version <= 0 {
+		return
+	}
+	if err := svc.articleRepo.DelAutosave(ctx, uid, art.ID); err != nil {
+		svc.log.Error("删除自动保存的快照失败", logger.Int64("article id", art.ID), logger.Error(err))
+	}
+}
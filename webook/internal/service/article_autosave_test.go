@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleService_FlushAutosave(t *testing.T) {
+	savedAt := time.UnixMilli(1700000000000)
+	draft := domain.Article{
+		ID:      1,
+		Title:   "title",
+		Content: "content",
+		Author:  domain.Author{ID: 2000},
+		Status:  domain.ArticleStatusPending,
+		Version: 3,
+	}
+
+	testCases := []struct {
+		name string
+		save domain.ArticleAutosave
+
+		mock func(ctrl *gomock.Controller) article.ArticleRepository
+
+		wantErr error
+	}{
+		{
+			name: "写入制作库，保留文章的状态",
+			save: domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "new title", Content: "new content", BaseVersion: 3, SavedAt: savedAt},
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+					ID:       1,
+					Title:    "new title",
+					Content:  "new content",
+					Author:   domain.Author{ID: 2000},
+					EditorID: 2000,
+					Status:   domain.ArticleStatusPending,
+					Version:  3,
+					// 写入期间状态被修改过时失败
+					FromStatus: domain.ArticleStatusPending,
+				}).Return(nil)
+				artRepo.EXPECT().AckAutosave(gomock.Any(), domain.ArticleAutosave{
+					ArticleID: 1, Uid: 2000, Title: "new title", Content: "new content",
+					BaseVersion: 3, FlushedVersion: 4, SavedAt: savedAt,
+				}).Return(nil)
+				return artRepo
+			},
+		},
+		{
+			name: "之前写入过，使用写入后的版本号",
+			save: domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "new title", Content: "new content", BaseVersion: 2, FlushedVersion: 3, SavedAt: savedAt},
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				artRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil)
+				artRepo.EXPECT().AckAutosave(gomock.Any(), domain.ArticleAutosave{
+					ArticleID: 1, Uid: 2000, Title: "new title", Content: "new content",
+					BaseVersion: 2, FlushedVersion: 4, SavedAt: savedAt,
+				}).Return(nil)
+				return artRepo
+			},
+		},
+		{
+			name: "草稿被其他人修改过，放弃写入",
+			save: domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "new title", BaseVersion: 2, SavedAt: savedAt},
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				artRepo.EXPECT().AckAutosave(gomock.Any(), domain.ArticleAutosave{
+					ArticleID: 1, Uid: 2000, Title: "new title", BaseVersion: 2, SavedAt: savedAt,
+				}).Return(nil)
+				return artRepo
+			},
+		},
+		{
+			name: "审核中的文章不能修改，放弃写入",
+			save: domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "new title", BaseVersion: 3, SavedAt: savedAt},
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				inReview := draft
+				inReview.Status = domain.ArticleStatusInReview
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(inReview, nil)
+				artRepo.EXPECT().AckAutosave(gomock.Any(), domain.ArticleAutosave{
+					ArticleID: 1, Uid: 2000, Title: "new title", BaseVersion: 3, SavedAt: savedAt,
+				}).Return(nil)
+				return artRepo
+			},
+		},
+		{
+			name: "写入失败，等待重试",
+			save: domain.ArticleAutosave{ArticleID: 1, Uid: 2000, Title: "new title", BaseVersion: 3, SavedAt: savedAt},
+			mock: func(ctrl *gomock.Controller) article.ArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(draft, nil)
+				artRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				return artRepo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, logger.NewNopLogger())
+			err := svc.FlushAutosave(context.Background(), tc.save)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_SaveAfterAutosave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+	revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+	// 客户端基于版本2编辑，自动保存写入后版本号变成了3
+	artRepo.EXPECT().GetAutosave(gomock.Any(), int64(2000), int64(1)).Return(domain.ArticleAutosave{
+		ArticleID: 1, Uid: 2000, BaseVersion: 2, FlushedVersion: 3,
+	}, nil)
+	artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{
+		ID: 1, Author: domain.Author{ID: 2000}, Status: domain.ArticleStatusUnpublished, Version: 3,
+	}, nil)
+	artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+		ID:       1,
+		Title:    "title",
+		Content:  "content",
+		Author:   domain.Author{ID: 2000},
+		EditorID: 2000,
+		Status:   domain.ArticleStatusUnpublished,
+		Version:  3,
+	}).Return(nil)
+	revisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+	artRepo.EXPECT().DelAutosave(gomock.Any(), int64(2000), int64(1)).Return(nil)
+
+	svc := NewArticleService(artRepo, nil, nil, revisionRepo, nil, nil, nil, nil, nil, nil, nil, nil, logger.NewNopLogger())
+	art, err := svc.Save(context.Background(), domain.Article{
+		ID:      1,
+		Title:   "title",
+		Content: "content",
+		Author:  domain.Author{ID: 2000},
+		Version: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), art.ID)
+	// 基于快照写入后的版本号更新，返回新的版本号
+	assert.Equal(t, int64(4), art.Version)
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"html"
+	"io"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/markdownx"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+导入、导出文件的格式
+
+	markdown：zip中每篇文章一个.md文件，文件名是"id-标题"，元数据写在YAML front matter中：
+		---
+		id: 1
+		title: 标题
+		tags: [go, web]
+		status: published
+		created: 2024-01-01T00:00:00+08:00
+		updated: 2024-01-01T00:00:00+08:00
+		---
+
+		正文
+	html：zip中每篇文章一个渲染后的.html文件，只用于阅读。
+	json：{"version": 1, "articles": [...]}，每篇文章的字段和front matter相同，正文在content中。
+
+	导入支持markdown的zip、单个.md文件以及json，只使用title、tags、content，其余字段只是为了方便阅读，导入后都是草稿。
+*/
+
+const bundleVersion = 1
+
+var (
+	errInvalidBundle      = errors.New("invalid bundle")
+	errBundleFileTooLarge = errors.New("bundle file too large")
+)
+
+type bundleArticle struct {
+	ID      int64    `yaml:"id,omitempty" json:"id,omitempty"`
+	Title   string   `yaml:"title" json:"title"`
+	Tags    []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Status  string   `yaml:"status,omitempty" json:"status,omitempty"`
+	Created string   `yaml:"created,omitempty" json:"created,omitempty"`
+	Updated string   `yaml:"updated,omitempty" json:"updated,omitempty"`
+	// Content 只有json中有，markdown的正文在front matter之后
+	Content string `yaml:"-" json:"content"`
+}
+
+type bundle struct {
+	Version  int             `json:"version"`
+	Articles []bundleArticle `json:"articles"`
+}
+
+// bundleEntry 从导入文件中解析出的一篇文章，Err不为nil时说明这篇文章的格式有问题
+type bundleEntry struct {
+	Name    string
+	Article domain.Article
+	Err     error
+}
+
+// writeBundle 将文章按照format写入w
+func writeBundle(w io.Writer, format domain.ExportFormat, arts []domain.Article) error {
+	switch format {
+	case domain.ExportFormatJSON:
+		b := bundle{Version: bundleVersion, Articles: make([]bundleArticle, 0, len(arts))}
+		for _, art := range arts {
+			ba := toBundleArticle(art)
+			ba.Content = art.Content
+			b.Articles = append(b.Articles, ba)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(b)
+	case domain.ExportFormatMarkdown, domain.ExportFormatHTML:
+		zw := zip.NewWriter(w)
+		for _, art := range arts {
+			var (
+				name string
+				data []byte
+				err  error
+			)
+			if format == domain.ExportFormatMarkdown {
+				name = bundleFileName(art, ".md")
+				data, err = markdownFile(art)
+			} else {
+				name, data = bundleFileName(art, ".html"), htmlFile(art)
+			}
+			if err != nil {
+				return err
+			}
+			f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: art.UTime})
+			if err != nil {
+				return err
+			}
+			if _, err = f.Write(data); err != nil {
+				return err
+			}
+		}
+		return zw.Close()
+	default:
+		return errors.New("unknown export format: " + string(format))
+	}
+}
+
+// readBundle 根据文件的扩展名解析导入的文件
+func readBundle(name string, data []byte) ([]bundleEntry, error) {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json":
+		var b bundle
+		if err := json.Unmarshal(data, &b); err != nil {
+			return nil, errInvalidBundle
+		}
+		res := make([]bundleEntry, 0, len(b.Articles))
+		for i, ba := range b.Articles {
+			res = append(res, bundleEntry{
+				Name:    strconv.Itoa(i),
+				Article: fromBundleArticle(ba, ba.Content),
+			})
+		}
+		return res, nil
+	case ".md", ".markdown":
+		return []bundleEntry{parseMarkdownFile(name, data)}, nil
+	case ".zip":
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, errInvalidBundle
+		}
+		var res []bundleEntry
+		for _, f := range zr.File {
+			ext := strings.ToLower(path.Ext(f.Name))
+			if f.FileInfo().IsDir() || (ext != ".md" && ext != ".markdown") {
+				continue
+			}
+			// 最多只解析MaxImportArticles+1篇，超出的部分由调用方拒绝，避免解压过多的数据
+			if len(res) > domain.MaxImportArticles {
+				break
+			}
+			res = append(res, readZipFile(f))
+		}
+		return res, nil
+	default:
+		return nil, errInvalidBundle
+	}
+}
+
+// readZipFile 单个文件超过domain.MaxImportFileSize时不解压。
+// 头部记录的大小可以伪造，所以读取时也要限制，读到的数据超过限制说明文件被篡改过
+func readZipFile(f *zip.File) bundleEntry {
+	if f.UncompressedSize64 > domain.MaxImportFileSize {
+		return bundleEntry{Name: f.Name, Err: errBundleFileTooLarge}
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return bundleEntry{Name: f.Name, Err: err}
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, domain.MaxImportFileSize+1))
+	if err != nil {
+		return bundleEntry{Name: f.Name, Err: err}
+	}
+	if len(data) > domain.MaxImportFileSize {
+		return bundleEntry{Name: f.Name, Err: errBundleFileTooLarge}
+	}
+	return parseMarkdownFile(f.Name, data)
+}
+
+func markdownFile(art domain.Article) ([]byte, error) {
+	fm, err := yaml.Marshal(toBundleArticle(art))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	buf.WriteString("---\n")
+	buf.Write(fm)
+	buf.WriteString("---\n\n")
+	buf.WriteString(art.Content)
+	return buf.Bytes(), nil
+}
+
+// parseMarkdownFile 解析front matter，没有front matter或者没有标题时使用文件名作为标题
+func parseMarkdownFile(name string, data []byte) bundleEntry {
+	if !utf8.Valid(data) {
+		return bundleEntry{Name: name, Err: errInvalidBundle}
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	var ba bundleArticle
+	if rest, ok := strings.CutPrefix(content, "---\n"); ok {
+		fm, body, found := strings.Cut(rest, "\n---\n")
+		if !found {
+			return bundleEntry{Name: name, Err: errInvalidBundle}
+		}
+		if err := yaml.Unmarshal([]byte(fm), &ba); err != nil {
+			return bundleEntry{Name: name, Err: errInvalidBundle}
+		}
+		content = strings.TrimLeft(body, "\n")
+	}
+	if strings.TrimSpace(ba.Title) == "" {
+		ba.Title = strings.TrimSuffix(path.Base(name), path.Ext(name))
+	}
+	return bundleEntry{Name: name, Article: fromBundleArticle(ba, content)}
+}
+
+func htmlFile(art domain.Article) []byte {
+	doc := markdownx.Render(art.Content)
+	title := html.EscapeString(art.Title)
+	return []byte(fmt.Sprintf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n<h1>%s</h1>\n%s</body>\n</html>\n",
+		title, title, doc.HTML))
+}
+
+// bundleFileName 文件名以id开头，避免标题相同的文章冲突
+func bundleFileName(art domain.Article, ext string) string {
+	slug := markdownx.Slug(art.Title)
+	if utf8.RuneCountInString(slug) > 50 {
+		slug = string([]rune(slug)[:50])
+	}
+	if slug == "" {
+		return strconv.FormatInt(art.ID, 10) + ext
+	}
+	return strconv.FormatInt(art.ID, 10) + "-" + slug + ext
+}
+
+func toBundleArticle(art domain.Article) bundleArticle {
+	ba := bundleArticle{
+		ID:      art.ID,
+		Title:   art.Title,
+		Status:  bundleStatus(art.Status),
+		Created: art.CTime.Format(time.RFC3339),
+		Updated: art.UTime.Format(time.RFC3339),
+	}
+	for _, tag := range art.Tags {
+		ba.Tags = append(ba.Tags, tag.Name)
+	}
+	return ba
+}
+
+func fromBundleArticle(ba bundleArticle, content string) domain.Article {
+	return domain.Article{
+		Title:   strings.TrimSpace(ba.Title),
+		Content: content,
+		Tags:    domain.NewTags(ba.Tags),
+	}
+}
+
+func bundleStatus(status domain.ArticleStatus) string {
+	switch status {
+	case domain.ArticleStatusPublished:
+		return "published"
+	case domain.ArticleStatusPrivate:
+		return "private"
+	default:
+		return "draft"
+	}
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/logger"
+)
+
+/*
+协作者
+
+	文章的作者是所有者，所有者邀请其他用户成为共同所有者、编辑或者查看者，被邀请的用户接受后才拥有对应的权限。
+	协作者操作文章时，service先校验角色，再将文章的作者替换成所有者，实际操作的用户记录在EditorID中。
+	所以制作库、线上库、版本记录中的author_id始终是所有者，协作者的修改都记录在所有者名下。
+	更新制作库时dao还会根据EditorID再校验一次角色，避免校验之后角色被移除。
+
+	回收站只属于文章的作者，共同所有者可以删除文章，但是恢复、彻底删除只能由作者操作。
+*/
+
+var (
+	ErrNoPermission         = errors.New("no permission")
+	ErrInvalidCollaborator  = errors.New("invalid collaborator")
+	ErrCollaboratorNotFound = article.ErrCollaboratorNotFound
+)
+
+// authorize 校验uid对文章的权限，返回的文章中Author是文章的所有者
+func (svc *articleService) authorize(ctx context.Context, articleID int64, uid int64,
+	allow func(domain.ArticleRole) bool) (domain.Article, error) {
+	art, err := svc.articleRepo.GetByID(ctx, articleID)
+	if err == ErrNotFound {
+		return domain.Article{}, ErrArticleNotFound
+	}
+	if err != nil {
+		return domain.Article{}, err
+	}
+	role, err := svc.role(ctx, art, uid)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if !allow(role) {
+		return domain.Article{}, ErrNoPermission
+	}
+	return art, nil
+}
+
+func (svc *articleService) role(ctx context.Context, art domain.Article, uid int64) (domain.ArticleRole, error) {
+	if art.Author.ID == uid {
+		return domain.ArticleRoleOwner, nil
+	}
+	c, err := svc.collaboratorRepo.Get(ctx, art.ID, uid)
+	switch {
+	case err == ErrCollaboratorNotFound:
+		return domain.ArticleRoleNone, nil
+	case err != nil:
+		return domain.ArticleRoleNone, err
+	case c.Status != domain.CollaboratorStatusAccepted:
+		return domain.ArticleRoleNone, nil
+	default:
+		return c.Role, nil
+	}
+}
+
+// actAs 以article.Author的身份操作文章，校验通过后Author替换成所有者，EditorID记录实际操作的用户
+func (svc *articleService) actAs(ctx context.Context, art domain.Article,
+	allow func(domain.ArticleRole) bool) (domain.Article, error) {
+	art, _, err := svc.transit(ctx, art, allow)
+	return art, err
+}
+
+// transit 和actAs相同，art.Status不是ArticleStatusUnknown时还会校验状态流转，返回文章修改前的状态
+func (svc *articleService) transit(ctx context.Context, art domain.Article,
+	allow func(domain.ArticleRole) bool) (domain.Article, domain.ArticleStatus, error) {
+	owner, err := svc.authorize(ctx, art.ID, art.Author.ID, allow)
+	if err != nil {
+		return domain.Article{}, domain.ArticleStatusUnknown, err
+	}
+	if art.Status != domain.ArticleStatusUnknown && !owner.Status.CanTransitTo(art.Status) {
+		return domain.Article{}, owner.Status, ErrInvalidStatusTransition
+	}
+	art.EditorID = art.Author.ID
+	art.Author = owner.Author
+	return art, owner.Status, nil
+}
+
+func (svc *articleService) GetDraft(ctx context.Context, uid int64, articleID int64) (domain.Article, error) {
+	art, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanView)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	arts := []domain.Article{art}
+	svc.fillTags(ctx, arts)
+	art = arts[0]
+	svc.fillContributors(ctx, &art)
+	return art, nil
+}
+
+func (svc *articleService) Role(ctx context.Context, uid int64, articleID int64) (domain.ArticleRole, error) {
+	art, err := svc.articleRepo.GetByID(ctx, articleID)
+	if err == ErrNotFound {
+		return domain.ArticleRoleNone, ErrArticleNotFound
+	}
+	if err != nil {
+		return domain.ArticleRoleNone, err
+	}
+	return svc.role(ctx, art, uid)
+}
+
+func (svc *articleService) Invite(ctx context.Context, uid int64, c domain.Collaborator) error {
+	art, err := svc.authorize(ctx, c.ArticleID, uid, domain.ArticleRole.CanManage)
+	if err != nil {
+		return err
+	}
+	if !c.Role.Valid() || c.User.ID <= 0 || c.User.ID == art.Author.ID {
+		return ErrInvalidCollaborator
+	}
+	c.InviterID = uid
+	c.Status = domain.CollaboratorStatusPending
+	return svc.collaboratorRepo.Invite(ctx, c)
+}
+
+func (svc *articleService) AcceptInvitation(ctx context.Context, uid int64, articleID int64) error {
+	return svc.collaboratorRepo.Accept(ctx, articleID, uid)
+}
+
+func (svc *articleService) DeclineInvitation(ctx context.Context, uid int64, articleID int64) error {
+	c, err := svc.collaboratorRepo.Get(ctx, articleID, uid)
+	if err != nil {
+		return err
+	}
+	if c.Status != domain.CollaboratorStatusPending {
+		return ErrCollaboratorNotFound
+	}
+	return svc.collaboratorRepo.Remove(ctx, articleID, uid)
+}
+
+func (svc *articleService) RemoveCollaborator(ctx context.Context, uid int64, articleID int64, collaboratorID int64) error {
+	// 协作者可以自己退出
+	if uid != collaboratorID {
+		_, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanManage)
+		if err != nil {
+			return err
+		}
+	}
+	return svc.collaboratorRepo.Remove(ctx, articleID, collaboratorID)
+}
+
+func (svc *articleService) ListCollaborators(ctx context.Context, uid int64, articleID int64) ([]domain.Collaborator, error) {
+	_, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanView)
+	if err != nil {
+		return nil, err
+	}
+	return svc.collaboratorRepo.List(ctx, articleID)
+}
+
+func (svc *articleService) ListInvitations(ctx context.Context, uid int64, offset int, limit int) ([]domain.Collaborator, error) {
+	return svc.collaboratorRepo.ListInvitations(ctx, uid, offset, limit)
+}
+
+// fillContributors 所有者排在第一位，之后是接受了邀请的共同所有者、编辑。查询失败时只展示所有者
+func (svc *articleService) fillContributors(ctx context.Context, art *domain.Article) {
+	owner := art.Author
+	owner.Role = domain.ArticleRoleOwner
+	art.Contributors = []domain.Author{owner}
+	cs, err := svc.collaboratorRepo.List(ctx, art.ID)
+	if err != nil {
+		svc.log.Error("查询文章的协作者失败", logger.Int64("article id", art.ID), logger.Error(err))
+		return
+	}
+	art.Contributors = append(art.Contributors, slice.FilterMap(cs, func(idx int, src domain.Collaborator) (domain.Author, bool) {
+		return src.User, src.Status == domain.CollaboratorStatusAccepted && src.Role.CanEdit()
+	})...)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+func Test_articleService_SaveByCollaborator(t *testing.T) {
+	owned := domain.Article{ID: 1, Author: domain.Author{ID: 2000}}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository)
+
+		wantErr error
+		wantId  int64
+	}{
+		{
+			name: "编辑修改文章，作者仍然是所有者",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleEditor,
+					Status: domain.CollaboratorStatusAccepted,
+				}, nil)
+				artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+					ID:       1,
+					Title:    "title",
+					Content:  "content",
+					Author:   domain.Author{ID: 2000},
+					EditorID: 3000,
+					Status:   domain.ArticleStatusUnpublished,
+				}).Return(nil)
+				revisionRepo.EXPECT().Create(gomock.Any(), domain.ArticleRevision{
+					ArticleID: 1,
+					AuthorID:  2000,
+					Title:     "title",
+					Content:   "content",
+					Status:    domain.ArticleStatusUnpublished,
+				}).Return(int64(10), nil)
+				return artRepo, revisionRepo, collaboratorRepo
+			},
+			wantId: 1,
+		},
+		{
+			name: "查看者不能修改",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleViewer,
+					Status: domain.CollaboratorStatusAccepted,
+				}, nil)
+				return artRepo, nil, collaboratorRepo
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "没有接受邀请",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleEditor,
+					Status: domain.CollaboratorStatusPending,
+				}, nil)
+				return artRepo, nil, collaboratorRepo
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "不是协作者",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).
+					Return(domain.Collaborator{}, ErrCollaboratorNotFound)
+				return artRepo, nil, collaboratorRepo
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "文章不存在",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{}, repository.ErrNotFound)
+				return artRepo, nil, nil
+			},
+			wantErr: ErrArticleNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, revisionRepo, collaboratorRepo := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, revisionRepo, nil, collaboratorRepo, nil, nil, nil, nil, nil, nil, logger.NewNopLogger())
+			art, err := svc.Save(context.Background(), domain.Article{
+				ID:      1,
+				Title:   "title",
+				Content: "content",
+				Author:  domain.Author{ID: 3000},
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, art.ID)
+		})
+	}
+}
+
+func Test_articleService_Invite(t *testing.T) {
+	owned := domain.Article{ID: 1, Author: domain.Author{ID: 2000}}
+
+	testCases := []struct {
+		name string
+		uid  int64
+		c    domain.Collaborator
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository)
+
+		wantErr error
+	}{
+		{
+			name: "所有者邀请编辑",
+			uid:  2000,
+			c:    domain.Collaborator{ArticleID: 1, User: domain.Author{ID: 3000}, Role: domain.ArticleRoleEditor},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Invite(gomock.Any(), domain.Collaborator{
+					ArticleID: 1,
+					User:      domain.Author{ID: 3000},
+					Role:      domain.ArticleRoleEditor,
+					Status:    domain.CollaboratorStatusPending,
+					InviterID: 2000,
+				}).Return(nil)
+				return artRepo, collaboratorRepo
+			},
+		},
+		{
+			name: "共同所有者也可以邀请",
+			uid:  3000,
+			c:    domain.Collaborator{ArticleID: 1, User: domain.Author{ID: 4000}, Role: domain.ArticleRoleViewer},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleOwner,
+					Status: domain.CollaboratorStatusAccepted,
+				}, nil)
+				collaboratorRepo.EXPECT().Invite(gomock.Any(), gomock.Any()).Return(nil)
+				return artRepo, collaboratorRepo
+			},
+		},
+		{
+			name: "编辑不能邀请",
+			uid:  3000,
+			c:    domain.Collaborator{ArticleID: 1, User: domain.Author{ID: 4000}, Role: domain.ArticleRoleViewer},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleEditor,
+					Status: domain.CollaboratorStatusAccepted,
+				}, nil)
+				return artRepo, collaboratorRepo
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "不能邀请所有者自己",
+			uid:  2000,
+			c:    domain.Collaborator{ArticleID: 1, User: domain.Author{ID: 2000}, Role: domain.ArticleRoleEditor},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				return artRepo, nil
+			},
+			wantErr: ErrInvalidCollaborator,
+		},
+		{
+			name: "未知的角色",
+			uid:  2000,
+			c:    domain.Collaborator{ArticleID: 1, User: domain.Author{ID: 3000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				return artRepo, nil
+			},
+			wantErr: ErrInvalidCollaborator,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, collaboratorRepo := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, nil, nil, collaboratorRepo, nil, nil, nil, nil, nil, nil, logger.NewNopLogger())
+			err := svc.Invite(context.Background(), tc.uid, tc.c)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/markdownx"
+)
+
+/*
+文章内容的渲染
+
+	文章的Content是Markdown，发布时渲染成HTML、目录、摘要以及引用的图片，随文章一起写入缓存，
+	读者查看文章时直接使用缓存中渲染好的结果。
+	缓存未命中时（缓存过期、发布之前的文章）在读取时重新渲染，并重新写入缓存。
+
+	列表只需要摘要，摘要从渲染后的纯文本中截取，不包含Markdown的格式和代码块。
+*/
+
+// abstractLen 摘要的最大字符数
+const abstractLen = 200
+
+// render 渲染文章的内容
+func render(art *domain.Article) {
+	doc := markdownx.Render(art.Content)
+	art.HTML = doc.HTML
+	art.Abstract = markdownx.Abstract(doc.Text, abstractLen)
+	art.Images = doc.Images
+	if len(doc.TOC) > 0 {
+		art.TOC = slice.Map(doc.TOC, func(idx int, src markdownx.Heading) domain.TOCItem {
+			return domain.TOCItem{Level: src.Level, Text: src.Text, Anchor: src.Anchor}
+		})
+	}
+}
+
+// fillAbstracts 为列表中的文章生成摘要
+func fillAbstracts(arts []domain.Article) {
+	for i := range arts {
+		if arts[i].Abstract != "" {
+			continue
+		}
+		doc := markdownx.Render(arts[i].Content)
+		arts[i].Abstract = markdownx.Abstract(doc.Text, abstractLen)
+	}
+}
+
+// renderPub 渲染缓存中没有渲染结果的文章，并重新写入缓存
+func (svc *articleService) renderPub(ctx context.Context, art *domain.Article) {
+	if art.HTML != "" || art.Content == "" {
+		return
+	}
+	render(art)
+	err := svc.articleRepo.CachePub(ctx, *art)
+	if err != nil {
+		svc.log.Error("缓存渲染后的文章失败", logger.Int64("article id", art.ID), logger.Error(err))
+	}
+}
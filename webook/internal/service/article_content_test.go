@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	evtmocks "learn_go/webook/internal/event/article/mocks"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+func Test_articleService_GetPubArticle(t *testing.T) {
+	rendered := domain.Article{
+		ID:       1,
+		Title:    "title",
+		Content:  "# Go\n\n**hello** ![a](https://a.com/a.png)",
+		Author:   domain.Author{ID: 2000},
+		HTML:     "<h1 id=\"go\">Go</h1>\n<p><strong>hello</strong> <img src=\"https://a.com/a.png\" alt=\"a\"></p>\n",
+		Abstract: "Go hello a",
+		TOC:      []domain.TOCItem{{Level: 1, Text: "Go", Anchor: "go"}},
+		Images:   []string{"https://a.com/a.png"},
+	}
+	raw := rendered
+	raw.HTML, raw.Abstract, raw.TOC, raw.Images = "", "", nil, nil
+	// 只有接受了邀请的共同所有者、编辑才是贡献者
+	collaborators := []domain.Collaborator{
+		{ArticleID: 1, User: domain.Author{ID: 3000, Name: "editor", Role: domain.ArticleRoleEditor},
+			Role: domain.ArticleRoleEditor, Status: domain.CollaboratorStatusAccepted},
+		{ArticleID: 1, User: domain.Author{ID: 4000, Role: domain.ArticleRoleEditor},
+			Role: domain.ArticleRoleEditor, Status: domain.CollaboratorStatusPending},
+		{ArticleID: 1, User: domain.Author{ID: 5000, Role: domain.ArticleRoleViewer},
+			Role: domain.ArticleRoleViewer, Status: domain.CollaboratorStatusAccepted},
+	}
+	wantArt := rendered
+	wantArt.Contributors = []domain.Author{
+		{ID: 2000, Role: domain.ArticleRoleOwner},
+		{ID: 3000, Name: "editor", Role: domain.ArticleRoleEditor},
+	}
+
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) *artrepomocks.MockArticleRepository
+
+		wantArt domain.Article
+		wantErr error
+	}{
+		{
+			name: "缓存中已经渲染过",
+			mock: func(ctrl *gomock.Controller) *artrepomocks.MockArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(rendered, nil)
+				return artRepo
+			},
+			wantArt: wantArt,
+		},
+		{
+			name: "没有渲染结果，渲染后写入缓存",
+			mock: func(ctrl *gomock.Controller) *artrepomocks.MockArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(raw, nil)
+				artRepo.EXPECT().CachePub(gomock.Any(), rendered).Return(nil)
+				return artRepo
+			},
+			wantArt: wantArt,
+		},
+		{
+			name: "写入缓存失败不影响结果",
+			mock: func(ctrl *gomock.Controller) *artrepomocks.MockArticleRepository {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByID(gomock.Any(), int64(1)).Return(raw, nil)
+				artRepo.EXPECT().CachePub(gomock.Any(), rendered).Return(errors.New("mock redis error"))
+				return artRepo
+			},
+			wantArt: wantArt,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tagRepo := artrepomocks.NewMockTagRepository(ctrl)
+			tagRepo.EXPECT().GetByArticleIDs(gomock.Any(), []int64{1}).Return(map[int64][]domain.Tag{}, nil)
+			producer := evtmocks.NewMockProducer(ctrl)
+			// 阅读事件是异步发送的
+			producer.EXPECT().ProduceReadEvent(gomock.Any()).Return(nil).AnyTimes()
+
+			collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+			collaboratorRepo.EXPECT().List(gomock.Any(), int64(1)).Return(collaborators, nil)
+
+			svc := NewArticleService(tc.mock(ctrl), nil, nil, nil, tagRepo, collaboratorRepo, nil, nil, nil, nil, producer, nil, logger.NewNopLogger())
+			art, err := svc.GetPubArticle(context.Background(), 2000, 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArt, art)
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/internal/domain"
+	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+/*
+审核
+
+	作者（所有者、编辑）保存文章后提交审核，文章进入ArticleStatusInReview，同时产生一条待审核的记录。
+	审核人员从审核队列中取出记录：
+		通过：抢占记录后发布文章，发布失败时恢复成待审核，和定时发布的处理方式相同。
+		驳回：抢占记录后文章进入ArticleStatusRejected，作者修改后重新提交。
+	审核中的文章作者只能保存（即撤回审核）或者撤回线上的文章，此时记录还在队列中，审核人员处理时发现文章已经不在审核中，取消这条记录。
+
+	标记了RequireReview的账号的文章只能通过审核发布，协作者也不能直接发布、定时发布。
+	定时发布之后账号才被标记的，到期时文章转为提交审核，而不是发布。
+	提交、通过、驳回都会产生ReviewEvent，通知等下游据此提醒作者、审核人员。
+*/
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid article status transition")
+	ErrReviewRequired          = errors.New("article must be reviewed before publishing")
+	ErrArticleNotInReview      = errors.New("article not in review")
+	ErrReviewCommentRequired   = errors.New("review comment required")
+	ErrReviewNotFound          = article.ErrReviewNotFound
+	ErrReviewDecided           = article.ErrReviewDecided
+)
+
+// checkDirectPublish 文章的所有者需要审核时不能直接发布，ownerID是文章所有者的id，不是操作的协作者
+func (svc *articleService) checkDirectPublish(ctx context.Context, ownerID int64) error {
+	user, err := svc.userRepo.FindByID(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	if user.RequireReview {
+		return ErrReviewRequired
+	}
+	return nil
+}
+
+func (svc *articleService) checkReviewer(ctx context.Context, uid int64) error {
+	user, err := svc.userRepo.FindByID(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if !user.Reviewer {
+		return ErrNoPermission
+	}
+	return nil
+}
+
+func (svc *articleService) Submit(ctx context.Context, art domain.Article) (domain.Article, error) {
+	submitter := art.Author.ID
+	art.Status = domain.ArticleStatusInReview
+	art, from, err := svc.saveDraft(ctx, art)
+	if err != nil {
+		return domain.Article{}, err
+	}
+	if err = svc.createReview(ctx, art, submitter, from); err != nil {
+		return domain.Article{}, err
+	}
+	return art, nil
+}
+
+// createReview 文章进入审核后产生待审核的记录
+func (svc *articleService) createReview(ctx context.Context, art domain.Article, submitter int64, from domain.ArticleStatus) error {
+	// 之前提交后又撤回的记录不再有效
+	if err := svc.reviewRepo.CancelPending(ctx, art.ID); err != nil {
+		return err
+	}
+	review := domain.ArticleReview{
+		ArticleID:   art.ID,
+		AuthorID:    art.Author.ID,
+		SubmitterID: submitter,
+		Title:       art.Title,
+	}
+	var err error
+	review.ID, err = svc.reviewRepo.Create(ctx, review)
+	if err != nil {
+		return err
+	}
+	svc.produceReviewEvent(review, submitter, from, domain.ArticleStatusInReview)
+	return nil
+}
+
+func (svc *articleService) ListReviewQueue(ctx context.Context, uid int64, offset int, limit int) ([]domain.ArticleReview, error) {
+	if err := svc.checkReviewer(ctx, uid); err != nil {
+		return nil, err
+	}
+	return svc.reviewRepo.ListPending(ctx, offset, limit)
+}
+
+func (svc *articleService) ListReviews(ctx context.Context, uid int64, articleID int64, offset int, limit int) ([]domain.ArticleReview, error) {
+	_, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanView)
+	if err != nil {
+		return nil, err
+	}
+	return svc.reviewRepo.ListByArticle(ctx, articleID, offset, limit)
+}
+
+func (svc *articleService) Approve(ctx context.Context, uid int64, reviewID int64, comment string) error {
+	review, art, err := svc.claimReview(ctx, uid, reviewID, domain.ReviewStatusApproved, comment)
+	if err != nil {
+		return err
+	}
+	// 发布的是claimReview读取到的文章，期间被修改、撤回、删除时Sync失败
+	_, err = svc.publish(ctx, art)
+	if err != nil {
+		svc.log.Error("审核通过后发布文章失败", logger.Int64("article id", art.ID), logger.Error(err))
+		return svc.abortReview(ctx, review, err)
+	}
+	svc.produceReviewEvent(review, uid, domain.ArticleStatusInReview, domain.ArticleStatusPublished)
+	return nil
+}
+
+func (svc *articleService) Reject(ctx context.Context, uid int64, reviewID int64, comment string) error {
+	if comment == "" {
+		return ErrReviewCommentRequired
+	}
+	review, art, err := svc.claimReview(ctx, uid, reviewID, domain.ReviewStatusRejected, comment)
+	if err != nil {
+		return err
+	}
+	art.Status = domain.ArticleStatusRejected
+	if err = svc.articleRepo.Update(ctx, art); err != nil {
+		svc.log.Error("驳回后更新文章失败", logger.Int64("article id", art.ID), logger.Error(err))
+		return svc.abortReview(ctx, review, err)
+	}
+	svc.produceReviewEvent(review, uid, domain.ArticleStatusInReview, domain.ArticleStatusRejected)
+	return nil
+}
+
+// claimReview 校验审核人员和文章的状态，然后抢占审核记录。
+// 返回的文章以所有者的身份更新，并且带上读取到的版本号和FromStatus，只有文章仍然是读取时的样子才能更新成功
+func (svc *articleService) claimReview(ctx context.Context, uid int64, reviewID int64,
+	status domain.ReviewStatus, comment string) (domain.ArticleReview, domain.Article, error) {
+	if err := svc.checkReviewer(ctx, uid); err != nil {
+		return domain.ArticleReview{}, domain.Article{}, err
+	}
+	review, err := svc.reviewRepo.GetByID(ctx, reviewID)
+	if err != nil {
+		return domain.ArticleReview{}, domain.Article{}, err
+	}
+	if review.Status != domain.ReviewStatusPending {
+		return domain.ArticleReview{}, domain.Article{}, ErrReviewDecided
+	}
+	// 不能审核自己的文章
+	if uid == review.AuthorID || uid == review.SubmitterID {
+		return domain.ArticleReview{}, domain.Article{}, ErrNoPermission
+	}
+	art, err := svc.articleRepo.GetByID(ctx, review.ArticleID)
+	if err == ErrNotFound {
+		return domain.ArticleReview{}, domain.Article{}, ErrArticleNotFound
+	}
+	if err != nil {
+		return domain.ArticleReview{}, domain.Article{}, err
+	}
+	if art.Status != domain.ArticleStatusInReview || !art.DTime.IsZero() {
+		// 作者已经撤回了审核，记录不再有效
+		if er := svc.reviewRepo.CancelPending(ctx, art.ID); er != nil {
+			svc.log.Error("取消审核记录失败", logger.Int64("article id", art.ID), logger.Error(er))
+		}
+		return domain.ArticleReview{}, domain.Article{}, ErrArticleNotInReview
+	}
+
+	review.ReviewerID = uid
+	review.Status = status
+	review.Comment = comment
+	if err = svc.reviewRepo.Decide(ctx, review); err != nil {
+		return domain.ArticleReview{}, domain.Article{}, err
+	}
+	// 审核人员不是文章的协作者，以所有者的身份更新文章
+	art.EditorID = art.Author.ID
+	art.FromStatus = domain.ArticleStatusInReview
+	return review, art, nil
+}
+
+// abortReview 抢占审核记录后更新文章失败，恢复审核记录。
+// 文章已经被修改或者不在审核中时，这条记录不再有效，恢复后直接取消
+func (svc *articleService) abortReview(ctx context.Context, review domain.ArticleReview, err error) error {
+	if er := svc.reviewRepo.Reopen(ctx, review.ID); er != nil {
+		svc.log.Error("恢复审核记录失败", logger.Int64("review id", review.ID), logger.Error(er))
+		return err
+	}
+	if err != ErrVersionConflict && err != article.ErrStatusConflict {
+		return err
+	}
+	if er := svc.reviewRepo.CancelPending(ctx, review.ArticleID); er != nil {
+		svc.log.Error("取消审核记录失败", logger.Int64("article id", review.ArticleID), logger.Error(er))
+	}
+	return ErrArticleNotInReview
+}
+
+// produceReviewEvent 失败只记录日志，不影响审核的结果
+func (svc *articleService) produceReviewEvent(review domain.ArticleReview, operator int64, from, to domain.ArticleStatus) {
+	err := svc.producer.ProduceReviewEvent(event.ReviewEvent{
+		ReviewID:   review.ID,
+		ArticleID:  review.ArticleID,
+		AuthorID:   review.AuthorID,
+		OperatorID: operator,
+		From:       from.ToInt8(),
+		To:         to.ToInt8(),
+		Comment:    review.Comment,
+		Utime:      time.Now().UnixMilli(),
+	})
+	if err != nil {
+		svc.log.Error("发送文章审核事件失败", logger.Int64("article id", review.ArticleID), logger.Error(err))
+	}
+}
@@ -0,0 +1,363 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	event "learn_go/webook/internal/event/article"
+	evtmocks "learn_go/webook/internal/event/article/mocks"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleService_Approve(t *testing.T) {
+	review := domain.ArticleReview{
+		ID:          10,
+		ArticleID:   1,
+		AuthorID:    2000,
+		SubmitterID: 3000,
+		Title:       "title",
+		Status:      domain.ReviewStatusPending,
+	}
+	inReview := domain.Article{
+		ID:      1,
+		Title:   "title",
+		Content: "content",
+		Author:  domain.Author{ID: 2000},
+		Status:  domain.ArticleStatusInReview,
+	}
+	reviewer := domain.User{ID: 5000, Reviewer: true}
+
+	testCases := []struct {
+		name string
+		uid  int64
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+			article.ReviewRepository, repository.UserRepository, event.Producer)
+
+		wantErr error
+	}{
+		{
+			name: "审核通过并发布",
+			uid:  5000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(reviewer, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(review, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(inReview, nil)
+				decided := review
+				decided.ReviewerID = 5000
+				decided.Status = domain.ReviewStatusApproved
+				decided.Comment = "ok"
+				reviewRepo.EXPECT().Decide(gomock.Any(), decided).Return(nil)
+				artRepo.EXPECT().Sync(gomock.Any(), domain.Article{
+					ID:       1,
+					Title:    "title",
+					Content:  "content",
+					HTML:     "<p>content</p>\n",
+					Abstract: "content",
+					Author:   domain.Author{ID: 2000},
+					EditorID: 2000,
+					Status:   domain.ArticleStatusPublished,
+					// 只有文章仍然在审核中才发布
+					FromStatus: domain.ArticleStatusInReview,
+				}).Return(int64(1), nil)
+				revisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(11), nil)
+				producer.EXPECT().ProduceSyncEvent(gomock.Any()).Return(nil)
+				producer.EXPECT().ProducePublishedEvent(gomock.Any()).Return(nil)
+				producer.EXPECT().ProduceReviewEvent(gomock.Any()).Return(nil)
+				return artRepo, revisionRepo, reviewRepo, userRepo, producer
+			},
+		},
+		{
+			name: "不是审核人员",
+			uid:  4000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(4000)).Return(domain.User{ID: 4000}, nil)
+				return nil, nil, nil, userRepo, nil
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "不能审核自己提交的文章",
+			uid:  3000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(3000)).Return(domain.User{ID: 3000, Reviewer: true}, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(review, nil)
+				return nil, nil, reviewRepo, userRepo, nil
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "已经被其他审核人员处理",
+			uid:  5000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(reviewer, nil)
+				decided := review
+				decided.Status = domain.ReviewStatusRejected
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(decided, nil)
+				return nil, nil, reviewRepo, userRepo, nil
+			},
+			wantErr: ErrReviewDecided,
+		},
+		{
+			name: "作者已经撤回审核",
+			uid:  5000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(reviewer, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(review, nil)
+				withdrawn := inReview
+				withdrawn.Status = domain.ArticleStatusUnpublished
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(withdrawn, nil)
+				reviewRepo.EXPECT().CancelPending(gomock.Any(), int64(1)).Return(nil)
+				return artRepo, nil, reviewRepo, userRepo, nil
+			},
+			wantErr: ErrArticleNotInReview,
+		},
+		{
+			name: "发布失败，恢复成待审核",
+			uid:  5000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(reviewer, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(review, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(inReview, nil)
+				reviewRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).Return(nil)
+				artRepo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("mock db error"))
+				reviewRepo.EXPECT().Reopen(gomock.Any(), int64(10)).Return(nil)
+				return artRepo, nil, reviewRepo, userRepo, nil
+			},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "抢占记录后作者撤回了审核，取消记录",
+			uid:  5000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(reviewer, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(review, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(inReview, nil)
+				reviewRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).Return(nil)
+				artRepo.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), article.ErrStatusConflict)
+				reviewRepo.EXPECT().Reopen(gomock.Any(), int64(10)).Return(nil)
+				reviewRepo.EXPECT().CancelPending(gomock.Any(), int64(1)).Return(nil)
+				return artRepo, nil, reviewRepo, userRepo, nil
+			},
+			wantErr: ErrArticleNotInReview,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, revisionRepo, reviewRepo, userRepo, producer := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, revisionRepo, nil, nil, reviewRepo, nil, nil, userRepo, producer, nil, logger.NewNopLogger())
+			err := svc.Approve(context.Background(), tc.uid, 10, "ok")
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_Reject(t *testing.T) {
+	testCases := []struct {
+		name    string
+		comment string
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.ReviewRepository,
+			repository.UserRepository, event.Producer)
+
+		wantErr error
+	}{
+		{
+			name:    "驳回",
+			comment: "需要补充引用",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.ReviewRepository,
+				repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(domain.User{ID: 5000, Reviewer: true}, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(domain.ArticleReview{
+					ID:        10,
+					ArticleID: 1,
+					AuthorID:  2000,
+					Status:    domain.ReviewStatusPending,
+				}, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 2000},
+					Status: domain.ArticleStatusInReview,
+				}, nil)
+				reviewRepo.EXPECT().Decide(gomock.Any(), domain.ArticleReview{
+					ID:         10,
+					ArticleID:  1,
+					AuthorID:   2000,
+					ReviewerID: 5000,
+					Status:     domain.ReviewStatusRejected,
+					Comment:    "需要补充引用",
+				}).Return(nil)
+				artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+					ID:       1,
+					Author:   domain.Author{ID: 2000},
+					EditorID: 2000,
+					Status:   domain.ArticleStatusRejected,
+					// 只有文章仍然在审核中才驳回
+					FromStatus: domain.ArticleStatusInReview,
+				}).Return(nil)
+				producer.EXPECT().ProduceReviewEvent(gomock.Any()).Return(nil)
+				return artRepo, reviewRepo, userRepo, producer
+			},
+		},
+		{
+			name:    "更新文章失败，恢复审核记录",
+			comment: "需要补充引用",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.ReviewRepository,
+				repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(5000)).Return(domain.User{ID: 5000, Reviewer: true}, nil)
+				reviewRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(domain.ArticleReview{
+					ID:        10,
+					ArticleID: 1,
+					AuthorID:  2000,
+					Status:    domain.ReviewStatusPending,
+				}, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 2000},
+					Status: domain.ArticleStatusInReview,
+				}, nil)
+				reviewRepo.EXPECT().Decide(gomock.Any(), gomock.Any()).Return(nil)
+				artRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(errors.New("mock db error"))
+				reviewRepo.EXPECT().Reopen(gomock.Any(), int64(10)).Return(nil)
+				return artRepo, reviewRepo, userRepo, nil
+			},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "驳回必须填写意见",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.ReviewRepository,
+				repository.UserRepository, event.Producer) {
+				return nil, nil, nil, nil
+			},
+			wantErr: ErrReviewCommentRequired,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, reviewRepo, userRepo, producer := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, nil, nil, nil, reviewRepo, nil, nil, userRepo, producer, nil, logger.NewNopLogger())
+			err := svc.Reject(context.Background(), 5000, 10, tc.comment)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_PublishRequireReview(t *testing.T) {
+	testCases := []struct {
+		name string
+		uid  int64
+
+		// Publish、Schedule各调用一次
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository, repository.UserRepository)
+	}{
+		{
+			name: "所有者需要审核",
+			uid:  2000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository, repository.UserRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 2000},
+					Status: domain.ArticleStatusUnpublished,
+				}, nil).Times(2)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000, RequireReview: true}, nil).Times(2)
+				return artRepo, nil, userRepo
+			},
+		},
+		{
+			name: "编辑不需要审核，但是所有者需要审核",
+			uid:  3000,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.CollaboratorRepository, repository.UserRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{
+					ID:     1,
+					Author: domain.Author{ID: 2000},
+					Status: domain.ArticleStatusUnpublished,
+				}, nil).Times(2)
+				collaboratorRepo.EXPECT().Get(gomock.Any(), int64(1), int64(3000)).Return(domain.Collaborator{
+					Role:   domain.ArticleRoleEditor,
+					Status: domain.CollaboratorStatusAccepted,
+				}, nil).Times(2)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000, RequireReview: true}, nil).Times(2)
+				return artRepo, collaboratorRepo, userRepo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, collaboratorRepo, userRepo := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, nil, nil, collaboratorRepo, nil, nil, nil, userRepo, nil, nil, logger.NewNopLogger())
+			_, err := svc.Publish(context.Background(), domain.Article{
+				ID:     1,
+				Author: domain.Author{ID: tc.uid},
+			})
+			assert.Equal(t, ErrReviewRequired, err)
+
+			_, err = svc.Schedule(context.Background(), domain.Article{
+				ID:        1,
+				Author:    domain.Author{ID: tc.uid},
+				PublishAt: time.Now().Add(time.Hour),
+			})
+			assert.Equal(t, ErrReviewRequired, err)
+		})
+	}
+}
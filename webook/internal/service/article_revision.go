@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"golang.org/x/sync/errgroup"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/diffx"
+	"learn_go/webook/pkg/logger"
+)
+
+/*
+文章的版本记录
+
+每次Save、Publish成功后都会记录一个版本，版本是不可变的。
+
+回滚
+	回滚本质上是用历史版本的标题、内容重新执行一次Save或者Publish，所以回滚本身也会产生一个新的版本，回滚操作也是可以被撤销的。
+	注：Publish会同时更新制作库和线上库，因此回滚线上库时草稿也会被覆盖，被覆盖的草稿仍然可以在版本记录中找到。
+*/
+
+var ErrUnknownRollbackTarget = errors.New("unknown rollback target")
+
+// recordRevision 记录一个版本。版本记录失败不影响保存、发布的结果，只记录日志。
+func (svc *articleService) recordRevision(ctx context.Context, article domain.Article) {
+	_, err := svc.revisionRepo.Create(ctx, domain.ArticleRevision{
+		ArticleID: article.ID,
+		AuthorID:  article.Author.ID,
+		Title:     article.Title,
+		Content:   article.Content,
+		Status:    article.Status,
+	})
+	if err != nil {
+		svc.log.Error("记录文章版本失败", logger.Int64("article id", article.ID), logger.Error(err))
+	}
+}
+
+func (svc *articleService) ListRevisions(ctx context.Context, uid int64, articleID int64, offset int, limit int) ([]domain.ArticleRevision, error) {
+	// 版本记录在所有者名下
+	art, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanView)
+	if err != nil {
+		return nil, err
+	}
+	return svc.revisionRepo.List(ctx, articleID, art.Author.ID, offset, limit)
+}
+
+func (svc *articleService) DiffRevisions(ctx context.Context, uid int64, articleID int64, fromID int64, toID int64) (domain.RevisionDiff, error) {
+	art, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanView)
+	if err != nil {
+		return domain.RevisionDiff{}, err
+	}
+	var (
+		eg       errgroup.Group
+		from, to domain.ArticleRevision
+	)
+	eg.Go(func() error {
+		var err error
+		from, err = svc.revisionRepo.GetByID(ctx, articleID, art.Author.ID, fromID)
+		return err
+	})
+	eg.Go(func() error {
+		var err error
+		to, err = svc.revisionRepo.GetByID(ctx, articleID, art.Author.ID, toID)
+		return err
+	})
+	if err := eg.Wait(); err != nil {
+		return domain.RevisionDiff{}, err
+	}
+
+	// 标题也参与对比，作为diff的第一行
+	lines := diffx.Diff(
+		append([]string{from.Title}, diffx.Split(from.Content)...),
+		append([]string{to.Title}, diffx.Split(to.Content)...),
+	)
+	return domain.RevisionDiff{
+		From: from,
+		To:   to,
+		Lines: slice.Map(lines, func(idx int, src diffx.Line) domain.DiffLine {
+			return domain.DiffLine{
+				Op:   domain.DiffOp(src.Op),
+				Text: src.Text,
+			}
+		}),
+	}, nil
+}
+
+func (svc *articleService) Rollback(ctx context.Context, uid int64, articleID int64, revisionID int64, target domain.RollbackTarget) (int64, error) {
+	owner, err := svc.authorize(ctx, articleID, uid, domain.ArticleRole.CanEdit)
+	if err != nil {
+		return 0, err
+	}
+	// 查询时带上了所有者id，查询不到说明版本不存在或者不属于该文章
+	revision, err := svc.revisionRepo.GetByID(ctx, articleID, owner.Author.ID, revisionID)
+	if err != nil {
+		return 0, err
+	}
+	art := domain.Article{
+		ID:      articleID,
+		Title:   revision.Title,
+		Content: revision.Content,
+		Author: domain.Author{
+			ID: uid,
+		},
+	}
+	switch target {
+	case domain.RollbackTargetDraft:
+		art, err = svc.Save(ctx, art)
+	case domain.RollbackTargetPublished:
+		art, err = svc.Publish(ctx, art)
+	default:
+		return 0, ErrUnknownRollbackTarget
+	}
+	return art.ID, err
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	event "learn_go/webook/internal/event/article"
+	evtmocks "learn_go/webook/internal/event/article/mocks"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+)
+
+func Test_articleService_Rollback(t *testing.T) {
+	revision := domain.ArticleRevision{
+		ID:        10,
+		ArticleID: 1,
+		AuthorID:  2000,
+		Title:     "old title",
+		Content:   "old content",
+		Status:    domain.ArticleStatusPublished,
+	}
+	// 回滚前先校验权限，回滚时保存、发布还会再校验一次
+	owned := domain.Article{ID: 1, Author: domain.Author{ID: 2000}}
+
+	testCases := []struct {
+		name string
+
+		target domain.RollbackTarget
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, repository.UserRepository, event.Producer)
+
+		wantErr error
+		wantId  int64
+	}{
+		{
+			name:   "回滚草稿",
+			target: domain.RollbackTargetDraft,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil).Times(2)
+				revisionRepo.EXPECT().GetByID(gomock.Any(), int64(1), int64(2000), int64(10)).Return(revision, nil)
+				artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+					ID:       1,
+					Title:    "old title",
+					Content:  "old content",
+					Author:   domain.Author{ID: 2000},
+					EditorID: 2000,
+					Status:   domain.ArticleStatusUnpublished,
+				}).Return(nil)
+				// 回滚也会产生一个新的版本
+				revisionRepo.EXPECT().Create(gomock.Any(), domain.ArticleRevision{
+					ArticleID: 1,
+					AuthorID:  2000,
+					Title:     "old title",
+					Content:   "old content",
+					Status:    domain.ArticleStatusUnpublished,
+				}).Return(int64(11), nil)
+				return artRepo, revisionRepo, nil, producer
+			},
+			wantId: 1,
+		},
+		{
+			name:   "回滚线上库",
+			target: domain.RollbackTargetPublished,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil).Times(2)
+				revisionRepo.EXPECT().GetByID(gomock.Any(), int64(1), int64(2000), int64(10)).Return(revision, nil)
+				// 回滚线上库和直接发布一样，需要审核的账号不能回滚
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000}, nil)
+				artRepo.EXPECT().Sync(gomock.Any(), domain.Article{
+					ID:       1,
+					Title:    "old title",
+					Content:  "old content",
+					HTML:     "<p>old content</p>\n",
+					Abstract: "old content",
+					Author:   domain.Author{ID: 2000},
+					EditorID: 2000,
+					Status:   domain.ArticleStatusPublished,
+				}).Return(int64(1), nil)
+				revisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(11), nil)
+				producer.EXPECT().ProduceSyncEvent(gomock.Any()).Return(nil)
+				producer.EXPECT().ProducePublishedEvent(gomock.Any()).Return(nil)
+				return artRepo, revisionRepo, userRepo, producer
+			},
+			wantId: 1,
+		},
+		{
+			name:   "版本不存在或者不属于该作者",
+			target: domain.RollbackTargetDraft,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				revisionRepo.EXPECT().GetByID(gomock.Any(), int64(1), int64(2000), int64(10)).
+					Return(domain.ArticleRevision{}, errors.New("record not found"))
+				return artRepo, revisionRepo, nil, producer
+			},
+			wantErr: errors.New("record not found"),
+		},
+		{
+			name:   "未知的回滚目标",
+			target: domain.RollbackTarget(10),
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(owned, nil)
+				revisionRepo.EXPECT().GetByID(gomock.Any(), int64(1), int64(2000), int64(10)).Return(revision, nil)
+				return artRepo, revisionRepo, nil, producer
+			},
+			wantErr: ErrUnknownRollbackTarget,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, revisionRepo, userRepo, producer := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, revisionRepo, nil, nil, nil, nil, nil, userRepo, producer, nil, logger.NewNopLogger())
+			id, err := svc.Rollback(context.Background(), 2000, 1, 10, tc.target)
+
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+/*
+定时发布
+
+	作者设置一个未来的发布时间，文章进入pending状态。由job.Scheduler调度的定时任务扫描到期的文章并调用Publish发布。
+	发布前和直接发布一样检查所有者是否需要审核，需要审核时文章提交审核。
+
+如何保证只发布一次？
+	1. job.Scheduler通过JobDao抢占任务，同一时刻只有一个实例在执行扫描任务。
+	2. 任务续约失败或者执行超时，其他实例仍然可能抢占到同一个任务，所以在发布每篇文章之前，
+	   还要通过ClaimScheduled给文章设置租约，只有设置成功的实例才能发布。
+	3. 抢占时不修改状态，文章仍然是pending。发布成功后文章变成published，再清除租约；
+	   发布失败时清除租约，等待下一次调度；实例在发布前崩溃时，租约过期后其他实例会重新抢占。
+	   所以定时发布是至少一次的，Sync是幂等的，重复发布不会产生多篇文章。
+
+注：定时发布之后，作者再次Save会将文章变回草稿，即取消了定时发布。
+*/
+
+var ErrInvalidPublishTime = errors.New("publish time must be in the future")
+
+// scheduleClaimLease 抢占定时发布文章的租约，足够完成一次发布
+const scheduleClaimLease = time.Minute * 5
+
+func (svc *articleService) Schedule(ctx context.Context, article domain.Article) (domain.Article, error) {
+	if !article.PublishAt.After(time.Now()) {
+		return domain.Article{}, ErrInvalidPublishTime
+	}
+	// 定时发布不经过审核，所有者需要审核的文章不能定时发布
+	ownerID := article.Author.ID
+	if article.ID > 0 {
+		owner, err := svc.authorize(ctx, article.ID, article.Author.ID, domain.ArticleRole.CanEdit)
+		if err != nil {
+			return domain.Article{}, err
+		}
+		ownerID = owner.Author.ID
+	}
+	if err := svc.checkDirectPublish(ctx, ownerID); err != nil {
+		return domain.Article{}, err
+	}
+	article.Status = domain.ArticleStatusPending
+	article, _, err := svc.saveDraft(ctx, article)
+	return article, err
+}
+
+func (svc *articleService) CancelSchedule(ctx context.Context, article domain.Article) error {
+	article, err := svc.actAs(ctx, article, domain.ArticleRole.CanEdit)
+	if err != nil {
+		return err
+	}
+	return svc.articleRepo.CancelScheduled(ctx, article.ID, article.Author.ID)
+}
+
+func (svc *articleService) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	return svc.articleRepo.ListDueScheduled(ctx, now, limit)
+}
+
+func (svc *articleService) PublishScheduled(ctx context.Context, article domain.Article) error {
+	ok, err := svc.articleRepo.ClaimScheduled(ctx, article.ID, time.Now(), scheduleClaimLease)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// 被其他实例抢占或者发布了，或者作者已经取消了定时发布
+		svc.log.Debug("定时发布的文章已被抢占", logger.Int64("article id", article.ID))
+		return nil
+	}
+
+	// 发布后清空定时发布的时间。文章的所有者在定时之后被要求审核时，转为提交审核
+	article.PublishAt = time.Time{}
+	// 抢占之后作者取消定时发布、删除文章不改变版本号，需要校验状态
+	article.FromStatus = domain.ArticleStatusPending
+	err = svc.checkDirectPublish(ctx, article.Author.ID)
+	switch err {
+	case nil:
+		_, err = svc.publish(ctx, article)
+	case ErrReviewRequired:
+		err = svc.submitScheduled(ctx, article)
+	}
+	if err != nil {
+		svc.log.Error("定时发布文章失败", logger.Int64("article id", article.ID), logger.Error(err))
+	}
+	// 不管发布是否成功都清除租约。清除失败时，租约过期后失败的文章会被重新抢占
+	if er := svc.articleRepo.ReleaseScheduled(ctx, article.ID); er != nil {
+		svc.log.Error("释放定时发布的文章失败", logger.Int64("article id", article.ID), logger.Error(er))
+	}
+	return err
+}
+
+// submitScheduled 到期的定时发布文章需要审核，提交审核而不是发布
+func (svc *articleService) submitScheduled(ctx context.Context, article domain.Article) error {
+	article.Status = domain.ArticleStatusInReview
+	if err := svc.articleRepo.Update(ctx, article); err != nil {
+		return err
+	}
+	submitter := article.EditorID
+	if submitter == 0 {
+		submitter = article.Author.ID
+	}
+	return svc.createReview(ctx, article, submitter, domain.ArticleStatusPending)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	event "learn_go/webook/internal/event/article"
+	evtmocks "learn_go/webook/internal/event/article/mocks"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleService_PublishScheduled(t *testing.T) {
+	art := domain.Article{
+		ID:        1,
+		Title:     "title",
+		Content:   "content",
+		Author:    domain.Author{ID: 2000},
+		Status:    domain.ArticleStatusPending,
+		PublishAt: time.Now().Add(-time.Minute),
+	}
+	published := domain.Article{
+		ID:      1,
+		Title:   "title",
+		Content: "content",
+		Author:  domain.Author{ID: 2000},
+		Status:  domain.ArticleStatusPublished,
+		// 发布时渲染的结果
+		HTML:     "<p>content</p>\n",
+		Abstract: "content",
+		// 抢占之后作者可能取消了定时发布
+		FromStatus: domain.ArticleStatusPending,
+	}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+			article.ReviewRepository, repository.UserRepository, event.Producer)
+
+		wantErr error
+	}{
+		{
+			name: "抢占成功并发布",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+
+				artRepo.EXPECT().ClaimScheduled(gomock.Any(), int64(1), gomock.Any(), scheduleClaimLease).Return(true, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000}, nil)
+				artRepo.EXPECT().Sync(gomock.Any(), published).Return(int64(1), nil)
+				revisionRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				producer.EXPECT().ProduceSyncEvent(gomock.Any()).Return(nil)
+				producer.EXPECT().ProducePublishedEvent(gomock.Any()).Return(nil)
+				// 发布成功后清除租约
+				artRepo.EXPECT().ReleaseScheduled(gomock.Any(), int64(1)).Return(nil)
+				return artRepo, revisionRepo, nil, userRepo, producer
+			},
+		},
+		{
+			name: "已被其他实例抢占",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().ClaimScheduled(gomock.Any(), int64(1), gomock.Any(), scheduleClaimLease).Return(false, nil)
+				return artRepo, revisionRepo, nil, nil, producer
+			},
+		},
+		{
+			name: "发布失败，释放文章",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+
+				artRepo.EXPECT().ClaimScheduled(gomock.Any(), int64(1), gomock.Any(), scheduleClaimLease).Return(true, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000}, nil)
+				artRepo.EXPECT().Sync(gomock.Any(), published).Return(int64(0), errors.New("mock db error"))
+				artRepo.EXPECT().ReleaseScheduled(gomock.Any(), int64(1)).Return(nil)
+				return artRepo, revisionRepo, nil, userRepo, producer
+			},
+			wantErr: errors.New("mock db error"),
+		},
+		{
+			name: "所有者被要求审核，转为提交审核",
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.RevisionRepository,
+				article.ReviewRepository, repository.UserRepository, event.Producer) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				producer := evtmocks.NewMockProducer(ctrl)
+
+				artRepo.EXPECT().ClaimScheduled(gomock.Any(), int64(1), gomock.Any(), scheduleClaimLease).Return(true, nil)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000, RequireReview: true}, nil)
+				artRepo.EXPECT().Update(gomock.Any(), domain.Article{
+					ID:         1,
+					Title:      "title",
+					Content:    "content",
+					Author:     domain.Author{ID: 2000},
+					Status:     domain.ArticleStatusInReview,
+					FromStatus: domain.ArticleStatusPending,
+				}).Return(nil)
+				reviewRepo.EXPECT().CancelPending(gomock.Any(), int64(1)).Return(nil)
+				reviewRepo.EXPECT().Create(gomock.Any(), domain.ArticleReview{
+					ArticleID:   1,
+					AuthorID:    2000,
+					SubmitterID: 2000,
+					Title:       "title",
+				}).Return(int64(10), nil)
+				producer.EXPECT().ProduceReviewEvent(gomock.Any()).Return(nil)
+				artRepo.EXPECT().ReleaseScheduled(gomock.Any(), int64(1)).Return(nil)
+				return artRepo, nil, reviewRepo, userRepo, producer
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, revisionRepo, reviewRepo, userRepo, producer := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, revisionRepo, nil, nil, reviewRepo, nil, nil, userRepo, producer, nil, logger.NewNopLogger())
+			err := svc.PublishScheduled(context.Background(), art)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
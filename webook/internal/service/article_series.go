@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/logger"
+	"strings"
+)
+
+/*
+系列
+
+	作者把多篇自己的文章按顺序组织成系列，一篇文章最多属于一个系列。系列只属于作者本人，协作者不能修改。
+	作者查看系列时可以看到草稿，读者只能看到已发布的文章，阅读已发布的文章时附带在系列中的上一篇、下一篇。
+	文章移入回收站时仍然保留在系列中，读者看不到，恢复之后回到原来的位置，彻底删除时才从系列中移除。
+*/
+
+var (
+	ErrInvalidSeries          = errors.New("invalid series")
+	ErrSeriesFull             = errors.New("series is full")
+	ErrSeriesNotFound         = article.ErrSeriesNotFound
+	ErrSeriesArticleDuplicate = article.ErrSeriesArticleDuplicate
+	ErrSeriesArticleMismatch  = article.ErrSeriesArticleMismatch
+)
+
+func (svc *articleService) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	if !s.Valid() {
+		return 0, ErrInvalidSeries
+	}
+	s.Title = strings.TrimSpace(s.Title)
+	return svc.seriesRepo.Create(ctx, s)
+}
+
+func (svc *articleService) UpdateSeries(ctx context.Context, s domain.Series) error {
+	if !s.Valid() {
+		return ErrInvalidSeries
+	}
+	s.Title = strings.TrimSpace(s.Title)
+	return svc.seriesRepo.Update(ctx, s)
+}
+
+func (svc *articleService) DeleteSeries(ctx context.Context, uid int64, id int64) error {
+	return svc.seriesRepo.Delete(ctx, id, uid)
+}
+
+func (svc *articleService) ListSeries(ctx context.Context, uid int64, offset int, limit int) ([]domain.Series, error) {
+	return svc.seriesRepo.ListByAuthor(ctx, uid, offset, limit)
+}
+
+func (svc *articleService) GetSeries(ctx context.Context, uid int64, id int64) (domain.Series, error) {
+	s, err := svc.ownSeries(ctx, uid, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	for i, sa := range s.Articles {
+		art, err := svc.articleRepo.GetByID(ctx, sa.ID)
+		if err != nil {
+			svc.log.Error("查询系列中的文章失败", logger.Int64("series id", id),
+				logger.Int64("article id", sa.ID), logger.Error(err))
+			continue
+		}
+		s.Articles[i].Title = art.Title
+	}
+	return s, nil
+}
+
+func (svc *articleService) AddSeriesArticle(ctx context.Context, uid int64, seriesID int64, articleID int64) error {
+	s, err := svc.ownSeries(ctx, uid, seriesID)
+	if err != nil {
+		return err
+	}
+	if len(s.Articles) >= domain.MaxSeriesArticles {
+		return ErrSeriesFull
+	}
+	art, err := svc.articleRepo.GetByID(ctx, articleID)
+	if err == ErrNotFound {
+		return ErrArticleNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if art.Author.ID != uid {
+		return ErrNoPermission
+	}
+	if !art.DTime.IsZero() {
+		return ErrArticleNotFound
+	}
+	return svc.seriesRepo.AddArticle(ctx, seriesID, uid, articleID)
+}
+
+func (svc *articleService) RemoveSeriesArticle(ctx context.Context, uid int64, seriesID int64, articleID int64) error {
+	return svc.seriesRepo.RemoveArticle(ctx, seriesID, uid, articleID)
+}
+
+func (svc *articleService) ReorderSeries(ctx context.Context, uid int64, seriesID int64, articleIDs []int64) error {
+	if len(articleIDs) == 0 || len(articleIDs) > domain.MaxSeriesArticles {
+		return ErrSeriesArticleMismatch
+	}
+	return svc.seriesRepo.Reorder(ctx, seriesID, uid, articleIDs)
+}
+
+func (svc *articleService) GetPubSeries(ctx context.Context, id int64) (domain.Series, error) {
+	s, err := svc.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	return svc.pubSeries(ctx, s)
+}
+
+// ownSeries 查询作者的系列，系列不属于作者时返回ErrSeriesNotFound
+func (svc *articleService) ownSeries(ctx context.Context, uid int64, id int64) (domain.Series, error) {
+	s, err := svc.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	if s.Author.ID != uid {
+		return domain.Series{}, ErrSeriesNotFound
+	}
+	return s, nil
+}
+
+// pubSeries 只保留系列中已发布的文章，标题使用线上库中的标题
+func (svc *articleService) pubSeries(ctx context.Context, s domain.Series) (domain.Series, error) {
+	if len(s.Articles) == 0 {
+		return s, nil
+	}
+	ids := slice.Map(s.Articles, func(idx int, src domain.SeriesArticle) int64 {
+		return src.ID
+	})
+	pubs, err := svc.articleRepo.GetPubByIDs(ctx, ids)
+	if err != nil {
+		return domain.Series{}, err
+	}
+	published := make(map[int64]domain.Article, len(pubs))
+	for _, art := range pubs {
+		if art.Status == domain.ArticleStatusPublished {
+			published[art.ID] = art
+		}
+	}
+	arts := make([]domain.SeriesArticle, 0, len(published))
+	for _, sa := range s.Articles {
+		if art, ok := published[sa.ID]; ok {
+			arts = append(arts, domain.SeriesArticle{ID: art.ID, Title: art.Title})
+		}
+	}
+	s.Articles = arts
+	if len(arts) > 0 {
+		// 系列中的文章都属于作者本人
+		author, err := svc.userRepo.FindByID(ctx, s.Author.ID)
+		if err != nil {
+			return domain.Series{}, err
+		}
+		s.Author.Name = author.Nickname
+	}
+	return s, nil
+}
+
+// fillSeries 填充已发布的文章在系列中的导航，查询失败时不展示导航
+func (svc *articleService) fillSeries(ctx context.Context, art *domain.Article) {
+	if art.Status != domain.ArticleStatusPublished {
+		return
+	}
+	s, err := svc.seriesRepo.GetByArticle(ctx, art.ID)
+	if err == ErrSeriesNotFound {
+		return
+	}
+	if err == nil {
+		s, err = svc.pubSeries(ctx, s)
+	}
+	if err != nil {
+		svc.log.Error("查询文章所属的系列失败", logger.Int64("article id", art.ID), logger.Error(err))
+		return
+	}
+	if nav, ok := s.Nav(art.ID); ok {
+		art.Series = &nav
+	}
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleService_AddSeriesArticle(t *testing.T) {
+	series := domain.Series{
+		ID:       10,
+		Author:   domain.Author{ID: 2000},
+		Title:    "Go入门",
+		Articles: []domain.SeriesArticle{{ID: 1}},
+	}
+
+	testCases := []struct {
+		name      string
+		uid       int64
+		articleID int64
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository)
+
+		wantErr error
+	}{
+		{
+			name:      "追加文章",
+			uid:       2000,
+			articleID: 2,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(series, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Author: domain.Author{ID: 2000},
+				}, nil)
+				seriesRepo.EXPECT().AddArticle(gomock.Any(), int64(10), int64(2000), int64(2)).Return(nil)
+				return artRepo, seriesRepo
+			},
+		},
+		{
+			name:      "不是自己的系列",
+			uid:       3000,
+			articleID: 2,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository) {
+				seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(series, nil)
+				return nil, seriesRepo
+			},
+			wantErr: ErrSeriesNotFound,
+		},
+		{
+			name:      "不能加入其他作者的文章",
+			uid:       2000,
+			articleID: 3,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(series, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(3)).Return(domain.Article{
+					ID:     3,
+					Author: domain.Author{ID: 3000},
+				}, nil)
+				return artRepo, seriesRepo
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name:      "回收站中的文章",
+			uid:       2000,
+			articleID: 2,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(series, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Author: domain.Author{ID: 2000},
+					DTime:  time.UnixMilli(100),
+				}, nil)
+				return artRepo, seriesRepo
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name:      "文章已经在其他系列中",
+			uid:       2000,
+			articleID: 2,
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.SeriesRepository) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+				seriesRepo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(series, nil)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(2)).Return(domain.Article{
+					ID:     2,
+					Author: domain.Author{ID: 2000},
+				}, nil)
+				seriesRepo.EXPECT().AddArticle(gomock.Any(), int64(10), int64(2000), int64(2)).
+					Return(ErrSeriesArticleDuplicate)
+				return artRepo, seriesRepo
+			},
+			wantErr: ErrSeriesArticleDuplicate,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, seriesRepo := tc.mock(ctrl)
+			svc := NewArticleService(artRepo, nil, nil, nil, nil, nil, nil, seriesRepo, nil, nil, nil, nil, logger.NewNopLogger())
+			err := svc.AddSeriesArticle(context.Background(), tc.uid, 10, tc.articleID)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_articleService_fillSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+	seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+	seriesRepo.EXPECT().GetByArticle(gomock.Any(), int64(3)).Return(domain.Series{
+		ID:       10,
+		Author:   domain.Author{ID: 2000},
+		Title:    "Go入门",
+		Articles: []domain.SeriesArticle{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}},
+	}, nil)
+	// 2还没有发布，4已经撤回，导航中跳过这俩篇。一次查询全部的文章，返回的顺序和系列中的顺序无关
+	artRepo.EXPECT().GetPubByIDs(gomock.Any(), []int64{1, 2, 3, 4, 5}).Return([]domain.Article{
+		{ID: 5, Title: "第五篇", Status: domain.ArticleStatusPublished},
+		{ID: 1, Title: "第一篇", Status: domain.ArticleStatusPublished},
+		{ID: 4, Title: "第四篇", Status: domain.ArticleStatusPrivate},
+		{ID: 3, Title: "第三篇", Status: domain.ArticleStatusPublished},
+	}, nil)
+	userRepo := repomocks.NewMockUserRepository(ctrl)
+	userRepo.EXPECT().FindByID(gomock.Any(), int64(2000)).Return(domain.User{ID: 2000, Nickname: "作者"}, nil)
+
+	svc := NewArticleService(artRepo, nil, nil, nil, nil, nil, nil, seriesRepo, nil, userRepo, nil, nil, logger.NewNopLogger())
+	art := domain.Article{ID: 3, Status: domain.ArticleStatusPublished}
+	svc.(*articleService).fillSeries(context.Background(), &art)
+	assert.Equal(t, &domain.SeriesNav{
+		ID:    10,
+		Title: "Go入门",
+		Index: 2,
+		Total: 3,
+		Prev:  domain.SeriesArticle{ID: 1, Title: "第一篇"},
+		Next:  domain.SeriesArticle{ID: 5, Title: "第五篇"},
+	}, art.Series)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/logger"
+)
+
+// saveTags article.Tags为nil时不修改文章的标签
+func (svc *articleService) saveTags(ctx context.Context, article domain.Article) error {
+	if article.Tags == nil {
+		return nil
+	}
+	return svc.tagRepo.SetArticleTags(ctx, article.ID, article.Tags)
+}
+
+// fillTags 查询文章的标签，标签只是附加信息，查询失败只记录日志
+func (svc *articleService) fillTags(ctx context.Context, arts []domain.Article) {
+	if len(arts) == 0 {
+		return
+	}
+	ids := slice.Map(arts, func(idx int, src domain.Article) int64 {
+		return src.ID
+	})
+	tags, err := svc.tagRepo.GetByArticleIDs(ctx, ids)
+	if err != nil {
+		svc.log.Error("查询文章标签失败", logger.Error(err))
+		return
+	}
+	for i := range arts {
+		arts[i].Tags = tags[arts[i].ID]
+	}
+}
+
+func (svc *articleService) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error) {
+	arts, err := svc.tagRepo.ListPubByTag(ctx, domain.TagSlug(slug), cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	svc.fillTags(ctx, arts)
+	fillAbstracts(arts)
+	return arts, nil
+}
+
+func (svc *articleService) CountTags(ctx context.Context, limit int) ([]domain.TagCount, error) {
+	return svc.tagRepo.CountPub(ctx, limit)
+}
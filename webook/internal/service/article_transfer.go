@@ -0,0 +1,260 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/objectstore"
+	"time"
+)
+
+/*
+文章的导入、导出
+
+	导出作者全部的文章（不包括回收站中的文章）。文章不超过domain.ArticleExportSyncLimit篇时直接下载，
+	否则创建后台任务，由定时任务生成导出文件写入对象存储，作者查询到任务完成后再下载。
+	导出文件保留domain.ArticleExportTTL，过期后由定时任务删除。
+
+	导入时每篇文章都通过ArticleService.Save保存成草稿，和作者在编辑器中新建文章完全一样。
+	一篇文章失败不影响其他文章，每篇文章的结果单独返回。
+*/
+
+var (
+	ErrInvalidExportFormat = errors.New("invalid export format")
+	ErrExportNotFound      = article.ErrExportNotFound
+	// ErrExportNotReady 后台任务还没有完成，或者已经失败了
+	ErrExportNotReady = errors.New("export not ready")
+	ErrInvalidBundle  = errInvalidBundle
+	ErrTooManyImports = errors.New("too many articles to import")
+)
+
+// exportTimeout 后台任务执行超过该时间仍然没有结束，认为执行任务的实例已经崩溃，任务可以被重新抢占
+const exportTimeout = time.Minute * 10
+
+//go:generate mockgen -source=./article_transfer.go -package=svcmocks -destination=./mocks/article_transfer.mock.go ArticleTransferService
+type ArticleTransferService interface {
+	// Export 文章不超过domain.ArticleExportSyncLimit篇时直接将导出文件写入w；
+	// 否则创建后台任务，返回pending的任务，不会写入w
+	Export(ctx context.Context, uid int64, format domain.ExportFormat, w io.Writer) (domain.ArticleExport, error)
+	// GetExport 查询作者的导出任务
+	GetExport(ctx context.Context, uid int64, id int64) (domain.ArticleExport, error)
+	// Download 下载后台任务生成的导出文件，任务没有完成时返回ErrExportNotReady
+	Download(ctx context.Context, uid int64, id int64) (domain.ArticleExport, []byte, error)
+
+	// ListRunnableExports 查询需要执行的后台任务
+	ListRunnableExports(ctx context.Context, now time.Time, limit int) ([]domain.ArticleExport, error)
+	// RunExport 执行后台任务，多个实例同时执行时只会执行一次
+	RunExport(ctx context.Context, e domain.ArticleExport) error
+	// PurgeExpiredExports 删除过期的任务和导出文件，返回删除的数量
+	PurgeExpiredExports(ctx context.Context, now time.Time, limit int) (int, error)
+
+	// Import 将导入文件中的文章保存成草稿，name是上传的文件名，根据扩展名判断格式
+	Import(ctx context.Context, uid int64, name string, data []byte) ([]domain.ImportResult, error)
+}
+
+type articleTransferService struct {
+	artSvc ArticleService
+	repo   article.ExportRepository
+	store  objectstore.ObjectStore
+	l      logger.LoggerV2
+
+	// 导出时每次查询的文章数量
+	batchSize int
+}
+
+func NewArticleTransferService(artSvc ArticleService, repo article.ExportRepository,
+	store objectstore.ObjectStore, l logger.LoggerV2) ArticleTransferService {
+	return &articleTransferService{
+		artSvc:    artSvc,
+		repo:      repo,
+		store:     store,
+		l:         l,
+		batchSize: 100,
+	}
+}
+
+func (svc *articleTransferService) Export(ctx context.Context, uid int64, format domain.ExportFormat, w io.Writer) (domain.ArticleExport, error) {
+	if !format.Valid() {
+		return domain.ArticleExport{}, ErrInvalidExportFormat
+	}
+	arts, more, err := svc.listAll(ctx, uid, domain.ArticleExportSyncLimit)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	e := domain.ArticleExport{Uid: uid, Format: format}
+	if more {
+		e.Status = domain.ExportStatusPending
+		e.ID, err = svc.repo.Create(ctx, e)
+		return e, err
+	}
+	// 边生成边写入，不在内存中保留完整的导出文件
+	cw := &countingWriter{w: w}
+	if err = writeBundle(cw, format, arts); err != nil {
+		return domain.ArticleExport{}, err
+	}
+	e.Status = domain.ExportStatusDone
+	e.Count = len(arts)
+	e.Size = cw.n
+	return e, nil
+}
+
+func (svc *articleTransferService) GetExport(ctx context.Context, uid int64, id int64) (domain.ArticleExport, error) {
+	e, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.ArticleExport{}, err
+	}
+	if e.Uid != uid {
+		return domain.ArticleExport{}, ErrExportNotFound
+	}
+	return e, nil
+}
+
+func (svc *articleTransferService) Download(ctx context.Context, uid int64, id int64) (domain.ArticleExport, []byte, error) {
+	e, err := svc.GetExport(ctx, uid, id)
+	if err != nil {
+		return domain.ArticleExport{}, nil, err
+	}
+	if e.Status != domain.ExportStatusDone {
+		return domain.ArticleExport{}, nil, ErrExportNotReady
+	}
+	data, err := svc.store.Get(ctx, e.Key)
+	if err == objectstore.ErrObjectNotFound {
+		return domain.ArticleExport{}, nil, ErrExportNotFound
+	}
+	return e, data, err
+}
+
+func (svc *articleTransferService) ListRunnableExports(ctx context.Context, now time.Time, limit int) ([]domain.ArticleExport, error) {
+	return svc.repo.ListRunnable(ctx, now.Add(-exportTimeout), limit)
+}
+
+func (svc *articleTransferService) RunExport(ctx context.Context, e domain.ArticleExport) error {
+	ok, err := svc.repo.Claim(ctx, e.ID, time.Now().Add(-exportTimeout))
+	if err != nil || !ok {
+		return err
+	}
+	err = svc.runExport(ctx, &e)
+	if err != nil {
+		// 失败之后不再重试，作者可以重新发起导出
+		e.Status = domain.ExportStatusFailed
+		e.Error = "failed to export"
+		svc.l.Error("导出文章失败", logger.Int64("export id", e.ID), logger.Error(err))
+	} else {
+		e.Status = domain.ExportStatusDone
+	}
+	// 任务超时导致的失败也要写入结果，否则任务会一直是running，直到被重新抢占
+	return svc.repo.Finish(context.WithoutCancel(ctx), e)
+}
+
+func (svc *articleTransferService) runExport(ctx context.Context, e *domain.ArticleExport) error {
+	arts, _, err := svc.listAll(ctx, e.Uid, 0)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err = writeBundle(&buf, e.Format, arts); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("exports/%d/%d%s", e.Uid, e.ID, e.Format.Ext())
+	if err = svc.store.Put(ctx, key, buf.Bytes(), e.Format.ContentType()); err != nil {
+		return err
+	}
+	e.Key = key
+	e.Count = len(arts)
+	e.Size = int64(buf.Len())
+	return nil
+}
+
+func (svc *articleTransferService) PurgeExpiredExports(ctx context.Context, now time.Time, limit int) (int, error) {
+	exports, err := svc.repo.ListExpired(ctx, now.Add(-domain.ArticleExportTTL), limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, e := range exports {
+		// 先删除文件再删除任务，删除文件失败时任务还在，下一次重试
+		if e.Key != "" {
+			if err = svc.store.Delete(ctx, e.Key); err != nil {
+				return i, err
+			}
+		}
+		if err = svc.repo.Delete(ctx, e.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(exports), nil
+}
+
+// listAll 按照修改时间倒序查询作者全部的文章，limit大于0时最多查询limit篇，超过limit篇时返回true
+func (svc *articleTransferService) listAll(ctx context.Context, uid int64, limit int) ([]domain.Article, bool, error) {
+	var (
+		res    []domain.Article
+		cursor domain.Cursor
+	)
+	for {
+		arts, err := svc.artSvc.GetList(ctx, uid, cursor, svc.batchSize)
+		if err != nil {
+			return nil, false, err
+		}
+		res = append(res, arts...)
+		if limit > 0 && len(res) > limit {
+			return nil, true, nil
+		}
+		if len(arts) < svc.batchSize {
+			return res, false, nil
+		}
+		cursor = domain.CursorOf(arts[len(arts)-1])
+	}
+}
+
+func (svc *articleTransferService) Import(ctx context.Context, uid int64, name string, data []byte) ([]domain.ImportResult, error) {
+	entries, err := readBundle(name, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, ErrInvalidBundle
+	}
+	if len(entries) > domain.MaxImportArticles {
+		return nil, ErrTooManyImports
+	}
+	res := make([]domain.ImportResult, 0, len(entries))
+	for _, entry := range entries {
+		r := domain.ImportResult{Name: entry.Name, Title: entry.Article.Title}
+		switch {
+		case entry.Err == errBundleFileTooLarge:
+			r.Error = "file too large"
+		case entry.Err != nil:
+			r.Error = "invalid file"
+		case entry.Article.Title == "":
+			r.Error = "title required"
+		default:
+			art := entry.Article
+			art.Author = domain.Author{ID: uid}
+			art, err = svc.artSvc.Save(ctx, art)
+			r.ID = art.ID
+			if err != nil {
+				r.Error = "failed to save"
+				svc.l.Error("导入文章失败", logger.Int64("uid", uid), logger.String("name", entry.Name), logger.Error(err))
+			}
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// countingWriter 记录写入的字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
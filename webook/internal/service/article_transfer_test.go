@@ -0,0 +1,302 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleTransferService_Import(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	arts := []domain.Article{
+		{
+			ID:      1,
+			Title:   "Go入门",
+			Content: "# 变量\n\n---\n\n分隔线之后的正文",
+			Tags:    domain.NewTags([]string{"go", "web"}),
+			Status:  domain.ArticleStatusPublished,
+			CTime:   now,
+			UTime:   now,
+		},
+		{
+			ID:      2,
+			Title:   "Go进阶",
+			Content: "并发",
+			CTime:   now,
+			UTime:   now,
+		},
+	}
+	bundleOf := func(format domain.ExportFormat) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, writeBundle(&buf, format, arts))
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		name     string
+		fileName string
+		data     []byte
+
+		mock func(ctrl *gomock.Controller) ArticleService
+
+		wantRes []domain.ImportResult
+		wantErr error
+	}{
+		{
+			name:     "导入markdown zip，一篇保存失败",
+			fileName: "articles.zip",
+			data:     bundleOf(domain.ExportFormatMarkdown),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "Go入门",
+					Content: "# 变量\n\n---\n\n分隔线之后的正文",
+					Author:  domain.Author{ID: 123},
+					Tags:    domain.NewTags([]string{"go", "web"}),
+				}).Return(domain.Article{ID: 10}, nil)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "Go进阶",
+					Content: "并发",
+					Author:  domain.Author{ID: 123},
+					Tags:    []domain.Tag{},
+				}).Return(domain.Article{}, errors.New("mock db error"))
+				return artSvc
+			},
+			wantRes: []domain.ImportResult{
+				{Name: "1-go入门.md", Title: "Go入门", ID: 10},
+				{Name: "2-go进阶.md", Title: "Go进阶", Error: "failed to save"},
+			},
+		},
+		{
+			name:     "导入json",
+			fileName: "articles.json",
+			data:     bundleOf(domain.ExportFormatJSON),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Article{ID: 10}, nil)
+				artSvc.EXPECT().Save(gomock.Any(), gomock.Any()).Return(domain.Article{ID: 11}, nil)
+				return artSvc
+			},
+			wantRes: []domain.ImportResult{
+				{Name: "0", Title: "Go入门", ID: 10},
+				{Name: "1", Title: "Go进阶", ID: 11},
+			},
+		},
+		{
+			name:     "没有front matter时使用文件名作为标题",
+			fileName: "笔记.md",
+			data:     []byte("正文"),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Save(gomock.Any(), domain.Article{
+					Title:   "笔记",
+					Content: "正文",
+					Author:  domain.Author{ID: 123},
+					Tags:    []domain.Tag{},
+				}).Return(domain.Article{ID: 10}, nil)
+				return artSvc
+			},
+			wantRes: []domain.ImportResult{
+				{Name: "笔记.md", Title: "笔记", ID: 10},
+			},
+		},
+		{
+			name:     "front matter没有结束",
+			fileName: "a.md",
+			data:     []byte("---\ntitle: a\n正文"),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			wantRes: []domain.ImportResult{
+				{Name: "a.md", Error: "invalid file"},
+			},
+		},
+		{
+			name:     "html不能导入",
+			fileName: "articles.zip",
+			data:     bundleOf(domain.ExportFormatHTML),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: ErrInvalidBundle,
+		},
+		{
+			name:     "不支持的文件",
+			fileName: "articles.txt",
+			data:     []byte("正文"),
+			mock: func(ctrl *gomock.Controller) ArticleService {
+				return svcmocks.NewMockArticleService(ctrl)
+			},
+			wantErr: ErrInvalidBundle,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			svc := NewArticleTransferService(tc.mock(ctrl), nil, nil, logger.NewNopLogger())
+			res, err := svc.Import(context.Background(), 123, tc.fileName, tc.data)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func Test_articleTransferService_Export(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	arts := []domain.Article{
+		{ID: 1, Title: "Go入门", Content: "变量", CTime: now, UTime: now},
+		{ID: 2, Title: "Go进阶", Content: "并发", CTime: now, UTime: now},
+	}
+	testCases := []struct {
+		name   string
+		format domain.ExportFormat
+
+		mock func(ctrl *gomock.Controller) (ArticleService, *artrepomocks.MockExportRepository)
+
+		wantExport domain.ArticleExport
+		// wantFiles 写入的zip中的文件名，nil表示没有写入
+		wantFiles []string
+		wantErr   error
+	}{
+		{
+			name:   "直接导出",
+			format: domain.ExportFormatMarkdown,
+			mock: func(ctrl *gomock.Controller) (ArticleService, *artrepomocks.MockExportRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().GetList(gomock.Any(), int64(123), domain.Cursor{}, 100).Return(arts, nil)
+				return artSvc, artrepomocks.NewMockExportRepository(ctrl)
+			},
+			wantExport: domain.ArticleExport{Uid: 123, Format: domain.ExportFormatMarkdown, Status: domain.ExportStatusDone, Count: 2},
+			wantFiles:  []string{"1-go入门.md", "2-go进阶.md"},
+		},
+		{
+			name:   "文章太多，创建后台任务",
+			format: domain.ExportFormatJSON,
+			mock: func(ctrl *gomock.Controller) (ArticleService, *artrepomocks.MockExportRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				many := make([]domain.Article, domain.ArticleExportSyncLimit+1)
+				artSvc.EXPECT().GetList(gomock.Any(), int64(123), domain.Cursor{}, 100).Return(many, nil)
+				repo := artrepomocks.NewMockExportRepository(ctrl)
+				repo.EXPECT().Create(gomock.Any(), domain.ArticleExport{
+					Uid: 123, Format: domain.ExportFormatJSON, Status: domain.ExportStatusPending,
+				}).Return(int64(10), nil)
+				return artSvc, repo
+			},
+			wantExport: domain.ArticleExport{ID: 10, Uid: 123, Format: domain.ExportFormatJSON, Status: domain.ExportStatusPending},
+		},
+		{
+			name:   "不支持的格式",
+			format: "pdf",
+			mock: func(ctrl *gomock.Controller) (ArticleService, *artrepomocks.MockExportRepository) {
+				return svcmocks.NewMockArticleService(ctrl), artrepomocks.NewMockExportRepository(ctrl)
+			},
+			wantErr: ErrInvalidExportFormat,
+		},
+		{
+			name:   "查询文章失败",
+			format: domain.ExportFormatMarkdown,
+			mock: func(ctrl *gomock.Controller) (ArticleService, *artrepomocks.MockExportRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().GetList(gomock.Any(), int64(123), domain.Cursor{}, 100).Return(nil, errors.New("mock db error"))
+				return artSvc, artrepomocks.NewMockExportRepository(ctrl)
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artSvc, repo := tc.mock(ctrl)
+			svc := NewArticleTransferService(artSvc, repo, nil, logger.NewNopLogger())
+			var buf bytes.Buffer
+			e, err := svc.Export(context.Background(), 123, tc.format, &buf)
+			assert.Equal(t, tc.wantErr, err)
+			if tc.wantFiles == nil {
+				assert.Equal(t, tc.wantExport, e)
+				assert.Zero(t, buf.Len())
+				return
+			}
+			// 大小是实际写入的字节数
+			tc.wantExport.Size = int64(buf.Len())
+			assert.Equal(t, tc.wantExport, e)
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			var files []string
+			for _, f := range zr.File {
+				files = append(files, f.Name)
+			}
+			assert.Equal(t, tc.wantFiles, files)
+		})
+	}
+}
+
+func Test_readZipFile(t *testing.T) {
+	zipOf := func(t *testing.T, content []byte, declared uint64) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		if declared == 0 {
+			f, err := zw.Create("a.md")
+			require.NoError(t, err)
+			_, err = f.Write(content)
+			require.NoError(t, err)
+		} else {
+			// 直接写入未压缩的数据，头部记录的大小和实际的大小不一致
+			f, err := zw.CreateRaw(&zip.FileHeader{Name: "a.md", Method: zip.Store,
+				CompressedSize64: uint64(len(content)), UncompressedSize64: declared})
+			require.NoError(t, err)
+			_, err = f.Write(content)
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+	large := bytes.Repeat([]byte("a"), domain.MaxImportFileSize+1)
+
+	testCases := []struct {
+		name string
+		data []byte
+
+		wantErr error
+	}{
+		{
+			name: "正常的文件",
+			data: zipOf(t, []byte("正文"), 0),
+		},
+		{
+			name:    "解压后超过限制",
+			data:    zipOf(t, large, 0),
+			wantErr: errBundleFileTooLarge,
+		},
+		{
+			// 按照头部记录的大小读取，超出的部分不会被读入内存
+			name:    "头部记录的大小被篡改",
+			data:    zipOf(t, large, 10),
+			wantErr: zip.ErrFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			zr, err := zip.NewReader(bytes.NewReader(tc.data), int64(len(tc.data)))
+			require.NoError(t, err)
+			entry := readZipFile(zr.File[0])
+			assert.Equal(t, tc.wantErr, entry.Err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"time"
+)
+
+/*
+回收站
+
+	删除：文章移入回收站并变回草稿，线上库中的文章、缓存立即删除，并通知搜索等下游文章已经不可见。
+	恢复：回收站中的文章恢复成草稿，需要重新发布。
+	彻底删除：作者手动彻底删除，或者由定时任务清理在回收站中超过domain.ArticleTrashTTL的文章。
+		先清理文章的附属数据（交互服务中的计数、标签、版本记录、协作者、审核记录），最后删除文章。
+		附属数据的清理都是幂等的，任何一步失败都会保留回收站中的文章，下次重试时重新清理。
+
+注：文章内容存储在对象存储中时，删除线上库的文章后对象就不再被引用，由ArticleContentSweeper清理。
+*/
+
+var (
+	ErrArticleNotFound   = article.ErrArticleNotFound
+	ErrArticleNotInTrash = article.ErrArticleNotInTrash
+)
+
+// articleBiz 文章在交互服务中的业务标识
+const articleBiz = "article"
+
+func (svc *articleService) Delete(ctx context.Context, art domain.Article) error {
+	art, err := svc.actAs(ctx, art, domain.ArticleRole.CanManage)
+	if err != nil {
+		return err
+	}
+	err = svc.articleRepo.Delete(ctx, art.ID, art.Author.ID)
+	if err != nil {
+		return err
+	}
+	art.Status = domain.ArticleStatusUnpublished
+	svc.produceSyncEvent(art)
+	svc.produceRemovedEvent(art)
+	return nil
+}
+
+func (svc *articleService) ListTrash(ctx context.Context, uid int64, offset int, limit int) ([]domain.Article, error) {
+	arts, err := svc.articleRepo.ListTrash(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	fillAbstracts(arts)
+	return arts, nil
+}
+
+func (svc *articleService) Restore(ctx context.Context, art domain.Article) error {
+	return svc.articleRepo.Restore(ctx, art.ID, art.Author.ID)
+}
+
+func (svc *articleService) Purge(ctx context.Context, art domain.Article) error {
+	// 先确认文章在作者的回收站中，避免清理了正常文章的附属数据
+	trashed, err := svc.articleRepo.GetByID(ctx, art.ID)
+	if err == ErrNotFound {
+		return ErrArticleNotInTrash
+	}
+	if err != nil {
+		return err
+	}
+	if trashed.Author.ID != art.Author.ID || trashed.DTime.IsZero() {
+		return ErrArticleNotInTrash
+	}
+
+	_, err = svc.interSvc.Delete(ctx, &intrv1.DeleteReq{Biz: articleBiz, BizId: art.ID})
+	if err != nil {
+		return err
+	}
+	if err = svc.tagRepo.DeleteByArticle(ctx, art.ID); err != nil {
+		return err
+	}
+	if err = svc.revisionRepo.DeleteByArticle(ctx, art.ID, art.Author.ID); err != nil {
+		return err
+	}
+	if err = svc.collaboratorRepo.DeleteByArticle(ctx, art.ID); err != nil {
+		return err
+	}
+	if err = svc.reviewRepo.DeleteByArticle(ctx, art.ID); err != nil {
+		return err
+	}
+	if err = svc.seriesRepo.DeleteByArticle(ctx, art.ID); err != nil {
+		return err
+	}
+	if err = svc.mediaRepo.DeleteByArticle(ctx, art.ID); err != nil {
+		return err
+	}
+	if err = svc.articleRepo.Purge(ctx, art.ID, art.Author.ID); err != nil {
+		return err
+	}
+	// 移入回收站时已经通知过，这里再通知一次，避免之前的消息丢失后关注流中一直留着已经删除的文章
+	svc.produceRemovedEvent(art)
+	return nil
+}
+
+func (svc *articleService) ListExpiredTrash(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	return svc.articleRepo.ListExpiredTrash(ctx, now.Add(-domain.ArticleTrashTTL), limit)
+}
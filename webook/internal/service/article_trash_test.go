@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	grpc2 "learn_go/webook/interaction/grpc"
+	intrsvcmocks "learn_go/webook/interaction/service/mocks"
+	"learn_go/webook/internal/domain"
+	event "learn_go/webook/internal/event/article"
+	evtmocks "learn_go/webook/internal/event/article/mocks"
+	"learn_go/webook/internal/repository"
+	"learn_go/webook/internal/repository/article"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	"learn_go/webook/internal/web/client"
+	"learn_go/webook/pkg/logger"
+	"testing"
+	"time"
+)
+
+func Test_articleService_Purge(t *testing.T) {
+	trashed := domain.Article{
+		ID:     1,
+		Author: domain.Author{ID: 2000},
+		DTime:  time.Now().Add(-time.Hour),
+	}
+
+	testCases := []struct {
+		name string
+		art  domain.Article
+
+		mock func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+			article.RevisionRepository, *intrsvcmocks.MockInteractionService)
+
+		wantErr error
+	}{
+		{
+			name: "彻底删除成功",
+			art:  domain.Article{ID: 1, Author: domain.Author{ID: 2000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+				article.RevisionRepository, *intrsvcmocks.MockInteractionService) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				tagRepo := artrepomocks.NewMockTagRepository(ctrl)
+				revisionRepo := artrepomocks.NewMockRevisionRepository(ctrl)
+				intrSvc := intrsvcmocks.NewMockInteractionService(ctrl)
+
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(trashed, nil)
+				intrSvc.EXPECT().Delete(gomock.Any(), "article", int64(1)).Return(nil)
+				tagRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				revisionRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1), int64(2000)).Return(nil)
+				artRepo.EXPECT().Purge(gomock.Any(), int64(1), int64(2000)).Return(nil)
+				return artRepo, tagRepo, revisionRepo, intrSvc
+			},
+		},
+		{
+			name: "文章不在回收站中",
+			art:  domain.Article{ID: 1, Author: domain.Author{ID: 2000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+				article.RevisionRepository, *intrsvcmocks.MockInteractionService) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{ID: 1, Author: domain.Author{ID: 2000}}, nil)
+				return artRepo, nil, nil, intrsvcmocks.NewMockInteractionService(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+		{
+			name: "不是作者的文章",
+			art:  domain.Article{ID: 1, Author: domain.Author{ID: 3000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+				article.RevisionRepository, *intrsvcmocks.MockInteractionService) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(trashed, nil)
+				return artRepo, nil, nil, intrsvcmocks.NewMockInteractionService(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+		{
+			name: "文章不存在",
+			art:  domain.Article{ID: 1, Author: domain.Author{ID: 2000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+				article.RevisionRepository, *intrsvcmocks.MockInteractionService) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(domain.Article{}, repository.ErrNotFound)
+				return artRepo, nil, nil, intrsvcmocks.NewMockInteractionService(ctrl)
+			},
+			wantErr: ErrArticleNotInTrash,
+		},
+		{
+			name: "清理交互数据失败，保留文章",
+			art:  domain.Article{ID: 1, Author: domain.Author{ID: 2000}},
+			mock: func(ctrl *gomock.Controller) (article.ArticleRepository, article.TagRepository,
+				article.RevisionRepository, *intrsvcmocks.MockInteractionService) {
+				artRepo := artrepomocks.NewMockArticleRepository(ctrl)
+				intrSvc := intrsvcmocks.NewMockInteractionService(ctrl)
+				artRepo.EXPECT().GetByID(gomock.Any(), int64(1)).Return(trashed, nil)
+				intrSvc.EXPECT().Delete(gomock.Any(), "article", int64(1)).Return(errors.New("mock rpc error"))
+				return artRepo, nil, nil, intrSvc
+			},
+			wantErr: errors.New("mock rpc error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artRepo, tagRepo, revisionRepo, intrSvc := tc.mock(ctrl)
+			collaboratorRepo := artrepomocks.NewMockCollaboratorRepository(ctrl)
+			reviewRepo := artrepomocks.NewMockReviewRepository(ctrl)
+			seriesRepo := artrepomocks.NewMockSeriesRepository(ctrl)
+			mediaRepo := artrepomocks.NewMockMediaRepository(ctrl)
+			producer := evtmocks.NewMockProducer(ctrl)
+			if tc.wantErr == nil {
+				collaboratorRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				reviewRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				seriesRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				mediaRepo.EXPECT().DeleteByArticle(gomock.Any(), int64(1)).Return(nil)
+				// 从关注流中移除
+				producer.EXPECT().ProducePublishedEvent(event.PublishedEvent{
+					ArticleID: 1, AuthorID: 2000, Removed: true,
+				}).Return(nil)
+			}
+			svc := NewArticleService(artRepo, nil, nil, revisionRepo, tagRepo, collaboratorRepo, reviewRepo, seriesRepo, mediaRepo, nil, producer,
+				client.NewInteractionServiceAdapter(grpc2.NewInteractionServiceServer(intrSvc, nil, nil)), logger.NewNopLogger())
+			err := svc.Purge(context.Background(), tc.art)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
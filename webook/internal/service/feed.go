@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+	"sort"
+)
+
+const (
+	// feedPushThreshold 粉丝数不超过该值的作者发布文章时写扩散，超过时读扩散
+	feedPushThreshold = 1000
+	// feedBatchSize 分批查询粉丝、关注的用户
+	feedBatchSize = 500
+)
+
+//go:generate mockgen -source=./feed.go -package=svcmocks -destination=./mocks/feed.mock.go FeedService
+type FeedService interface {
+	// Publish 把作者发布的文章分发到粉丝的关注流，重新发布时只更新标题和摘要
+	Publish(ctx context.Context, item domain.FeedItem) error
+	// Remove 从所有的关注流中移除文章，文章撤回、删除后调用
+	Remove(ctx context.Context, articleID int64) error
+	// Feed 按照发布时间倒序查询uid关注的作者发布的文章，cursor为零值时查询第一页
+	Feed(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error)
+}
+
+type feedService struct {
+	repo       repository.FeedRepository
+	followRepo repository.FollowRepository
+}
+
+func NewFeedService(repo repository.FeedRepository, followRepo repository.FollowRepository) FeedService {
+	return &feedService{
+		repo:       repo,
+		followRepo: followRepo,
+	}
+}
+
+func (svc *feedService) Publish(ctx context.Context, item domain.FeedItem) error {
+	// 重新发布时沿用第一次发布的方式，作者的粉丝数跨过阈值后，同一篇文章也不会同时出现在收件箱和发件箱中
+	pushed, pulled, err := svc.repo.Distributed(ctx, item.ArticleID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case pulled:
+		return svc.repo.AddToOutbox(ctx, item)
+	case pushed:
+		return svc.push(ctx, item)
+	}
+	statics, err := svc.followRepo.GetStatics(ctx, item.AuthorID)
+	if err != nil {
+		return err
+	}
+	if statics.Followers > feedPushThreshold {
+		// 读扩散，粉丝读取关注流时再从发件箱中拉取
+		return svc.repo.AddToOutbox(ctx, item)
+	}
+	return svc.push(ctx, item)
+}
+
+// push 写扩散，分批写入粉丝的收件箱，已经写入的收件箱只更新标题和摘要
+func (svc *feedService) push(ctx context.Context, item domain.FeedItem) error {
+	var maxID int64
+	for {
+		followers, err := svc.followRepo.ListFollowers(ctx, item.AuthorID, maxID, feedBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(followers) == 0 {
+			return nil
+		}
+		uids := slice.Map(followers, func(idx int, src domain.FollowRelation) int64 {
+			return src.Follower
+		})
+		if err = svc.repo.AddToInboxes(ctx, uids, item); err != nil {
+			return err
+		}
+		if len(followers) < feedBatchSize {
+			return nil
+		}
+		maxID = followers[len(followers)-1].ID
+	}
+}
+
+func (svc *feedService) Remove(ctx context.Context, articleID int64) error {
+	return svc.repo.Remove(ctx, articleID)
+}
+
+func (svc *feedService) Feed(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.FeedItem, error) {
+	followees, err := svc.followees(ctx, uid)
+	if err != nil || len(followees) == 0 {
+		return nil, err
+	}
+	// 收件箱中只查询仍然关注的作者，取消关注之后之前推送的文章不再出现
+	pushed, err := svc.repo.ListInbox(ctx, uid, followees, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	pulled, err := svc.repo.ListOutbox(ctx, followees, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return svc.merge(pushed, pulled, limit), nil
+}
+
+// followees 查询uid关注的全部用户，关注时已经限制了数量，不超过domain.MaxFollowees
+func (svc *feedService) followees(ctx context.Context, uid int64) ([]int64, error) {
+	var (
+		res   []int64
+		maxID int64
+	)
+	for {
+		batch, err := svc.followRepo.ListFollowees(ctx, uid, maxID, feedBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range batch {
+			res = append(res, r.Followee)
+		}
+		if len(batch) < feedBatchSize {
+			break
+		}
+		maxID = batch[len(batch)-1].ID
+	}
+	return res, nil
+}
+
+// merge 合并两个有序的结果。同一篇文章只会写入收件箱或者发件箱中的一个，按文章去重只是兜底
+func (svc *feedService) merge(pushed []domain.FeedItem, pulled []domain.FeedItem, limit int) []domain.FeedItem {
+	items := append(pushed, pulled...)
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].PublishedAt.Equal(items[j].PublishedAt) {
+			return items[i].PublishedAt.After(items[j].PublishedAt)
+		}
+		return items[i].ArticleID > items[j].ArticleID
+	})
+	res := make([]domain.FeedItem, 0, min(len(items), limit))
+	seen := make(map[int64]struct{}, len(items))
+	for _, item := range items {
+		if len(res) == limit {
+			break
+		}
+		if _, ok := seen[item.ArticleID]; ok {
+			continue
+		}
+		seen[item.ArticleID] = struct{}{}
+		res = append(res, item)
+	}
+	return res
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	"testing"
+	"time"
+)
+
+func Test_feedService_Publish(t *testing.T) {
+	item := domain.FeedItem{ArticleID: 1, AuthorID: 100, Title: "title", PublishedAt: time.UnixMilli(1000)}
+	followersOf := func(startID int64, n int) []domain.FollowRelation {
+		res := make([]domain.FollowRelation, 0, n)
+		for i := 0; i < n; i++ {
+			id := startID - int64(i)
+			res = append(res, domain.FollowRelation{ID: id, Follower: 1000 + id, Followee: 100})
+		}
+		return res
+	}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository)
+
+		wantErr error
+	}{
+		{
+			name: "粉丝多的作者只写发件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Distributed(gomock.Any(), int64(1)).Return(false, false, nil)
+				followRepo.EXPECT().GetStatics(gomock.Any(), int64(100)).
+					Return(domain.FollowStatics{Followers: feedPushThreshold + 1}, nil)
+				repo.EXPECT().AddToOutbox(gomock.Any(), item).Return(nil)
+				return repo, followRepo
+			},
+		},
+		{
+			name: "粉丝少的作者分批写入粉丝的收件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Distributed(gomock.Any(), int64(1)).Return(false, false, nil)
+				followRepo.EXPECT().GetStatics(gomock.Any(), int64(100)).
+					Return(domain.FollowStatics{Followers: feedBatchSize + 2}, nil)
+				first := followersOf(feedBatchSize+2, feedBatchSize)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(100), int64(0), feedBatchSize).Return(first, nil)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(100), int64(3), feedBatchSize).
+					Return(followersOf(2, 2), nil)
+				repo.EXPECT().AddToInboxes(gomock.Any(), gomock.Len(feedBatchSize), item).Return(nil)
+				repo.EXPECT().AddToInboxes(gomock.Any(), []int64{1002, 1001}, item).Return(nil)
+				return repo, followRepo
+			},
+		},
+		{
+			name: "没有粉丝",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Distributed(gomock.Any(), int64(1)).Return(false, false, nil)
+				followRepo.EXPECT().GetStatics(gomock.Any(), int64(100)).Return(domain.FollowStatics{}, nil)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(100), int64(0), feedBatchSize).Return(nil, nil)
+				return repo, followRepo
+			},
+		},
+		{
+			name: "重新发布，已经在发件箱中，粉丝数降到阈值以下也只更新发件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				repo.EXPECT().Distributed(gomock.Any(), int64(1)).Return(false, true, nil)
+				repo.EXPECT().AddToOutbox(gomock.Any(), item).Return(nil)
+				return repo, repomocks.NewMockFollowRepository(ctrl)
+			},
+		},
+		{
+			name: "重新发布，已经在收件箱中，粉丝数超过阈值也继续写收件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Distributed(gomock.Any(), int64(1)).Return(true, false, nil)
+				followRepo.EXPECT().ListFollowers(gomock.Any(), int64(100), int64(0), feedBatchSize).
+					Return(followersOf(2, 2), nil)
+				repo.EXPECT().AddToInboxes(gomock.Any(), []int64{1002, 1001}, item).Return(nil)
+				return repo, followRepo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, followRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, followRepo)
+			err := svc.Publish(context.Background(), item)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func Test_feedService_Feed(t *testing.T) {
+	itemOf := func(articleID int64, authorID int64, publishedAt int64) domain.FeedItem {
+		return domain.FeedItem{ArticleID: articleID, AuthorID: authorID, PublishedAt: time.UnixMilli(publishedAt)}
+	}
+	followees := []domain.FollowRelation{{ID: 2, Followee: 100}, {ID: 1, Followee: 200}}
+
+	testCases := []struct {
+		name string
+
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository)
+
+		want []domain.FeedItem
+	}{
+		{
+			name: "合并收件箱和发件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				followRepo.EXPECT().ListFollowees(gomock.Any(), int64(1), int64(0), feedBatchSize).Return(followees, nil)
+				repo.EXPECT().ListInbox(gomock.Any(), int64(1), []int64{100, 200}, domain.Cursor{}, 3).
+					Return([]domain.FeedItem{itemOf(5, 100, 50), itemOf(3, 100, 30), itemOf(1, 100, 10)}, nil)
+				repo.EXPECT().ListOutbox(gomock.Any(), []int64{100, 200}, domain.Cursor{}, 3).
+					Return([]domain.FeedItem{itemOf(4, 200, 40), itemOf(3, 100, 30)}, nil)
+				return repo, followRepo
+			},
+			want: []domain.FeedItem{itemOf(5, 100, 50), itemOf(4, 200, 40), itemOf(3, 100, 30)},
+		},
+		{
+			name: "没有关注任何人",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				followRepo.EXPECT().ListFollowees(gomock.Any(), int64(1), int64(0), feedBatchSize).Return(nil, nil)
+				return repomocks.NewMockFeedRepository(ctrl), followRepo
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, followRepo := tc.mock(ctrl)
+			svc := NewFeedService(repo, followRepo)
+			res, err := svc.Feed(context.Background(), 1, domain.Cursor{}, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+)
+
+var (
+	ErrFollowSelf = errors.New("can not follow yourself")
+	// ErrTooManyFollowees 关注的用户达到了domain.MaxFollowees
+	ErrTooManyFollowees = repository.ErrTooManyFollowees
+)
+
+//go:generate mockgen -source=./follow.go -package=svcmocks -destination=./mocks/follow.mock.go FollowService
+type FollowService interface {
+	// Follow 关注用户，重复关注不会返回错误
+	Follow(ctx context.Context, follower int64, followee int64) error
+	// Unfollow 取消关注，没有关注时不会返回错误
+	Unfollow(ctx context.Context, follower int64, followee int64) error
+	// Following follower是否关注了followee
+	Following(ctx context.Context, follower int64, followee int64) (bool, error)
+
+	// ListFollowers 按照关注时间倒序查询粉丝，maxID是上一页最后一条关注关系的id
+	ListFollowers(ctx context.Context, uid int64, maxID int64, limit int) ([]domain.FollowRelation, error)
+	// ListFollowees 按照关注时间倒序查询关注的用户，分页方式和ListFollowers相同
+	ListFollowees(ctx context.Context, uid int64, maxID int64, limit int) ([]domain.FollowRelation, error)
+	Statics(ctx context.Context, uid int64) (domain.FollowStatics, error)
+}
+
+type followService struct {
+	repo     repository.FollowRepository
+	userRepo repository.UserRepository
+}
+
+func NewFollowService(repo repository.FollowRepository, userRepo repository.UserRepository) FollowService {
+	return &followService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+func (svc *followService) Follow(ctx context.Context, follower int64, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	_, err := svc.userRepo.FindByID(ctx, followee)
+	if err != nil {
+		return err
+	}
+	// 关注数由repository在关注的事务中检查，同时关注多个用户也不会超过上限
+	_, err = svc.repo.Follow(ctx, follower, followee)
+	return err
+}
+
+func (svc *followService) Unfollow(ctx context.Context, follower int64, followee int64) error {
+	_, err := svc.repo.Unfollow(ctx, follower, followee)
+	return err
+}
+
+func (svc *followService) Following(ctx context.Context, follower int64, followee int64) (bool, error) {
+	_, err := svc.repo.Get(ctx, follower, followee)
+	switch err {
+	case nil:
+		return true, nil
+	case repository.ErrNotFound:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (svc *followService) ListFollowers(ctx context.Context, uid int64, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	return svc.repo.ListFollowers(ctx, uid, maxID, limit)
+}
+
+func (svc *followService) ListFollowees(ctx context.Context, uid int64, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	return svc.repo.ListFollowees(ctx, uid, maxID, limit)
+}
+
+func (svc *followService) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	return svc.repo.GetStatics(ctx, uid)
+}
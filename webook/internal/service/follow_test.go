@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository"
+	repomocks "learn_go/webook/internal/repository/mocks"
+	"testing"
+)
+
+func Test_followService_Follow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		followee int64
+
+		mock func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository)
+
+		wantErr error
+	}{
+		{
+			name:     "关注成功",
+			followee: 200,
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository) {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(200)).Return(domain.User{ID: 200}, nil)
+				repo.EXPECT().Follow(gomock.Any(), int64(100), int64(200)).Return(true, nil)
+				return repo, userRepo
+			},
+		},
+		{
+			name:     "不能关注自己",
+			followee: 100,
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository) {
+				return repomocks.NewMockFollowRepository(ctrl), repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: ErrFollowSelf,
+		},
+		{
+			name:     "用户不存在",
+			followee: 200,
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository) {
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(200)).Return(domain.User{}, repository.ErrUserNotFound)
+				return repomocks.NewMockFollowRepository(ctrl), userRepo
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name:     "关注的用户太多",
+			followee: 200,
+			mock: func(ctrl *gomock.Controller) (repository.FollowRepository, repository.UserRepository) {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				userRepo.EXPECT().FindByID(gomock.Any(), int64(200)).Return(domain.User{ID: 200}, nil)
+				repo.EXPECT().Follow(gomock.Any(), int64(100), int64(200)).Return(false, repository.ErrTooManyFollowees)
+				return repo, userRepo
+			},
+			wantErr: ErrTooManyFollowees,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, userRepo := tc.mock(ctrl)
+			svc := NewFollowService(repo, userRepo)
+			err := svc.Follow(context.Background(), 100, tc.followee)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
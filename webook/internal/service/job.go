@@ -25,6 +25,9 @@ import (
 */
 
 type JobService interface {
+	// AddJob 注册一个任务，任务已经存在时忽略
+	AddJob(ctx context.Context, job domain.Job) error
+
 	// Preempt 抢占一个任务
 	Preempt(ctx context.Context) (domain.Job, error)
 
@@ -41,6 +44,10 @@ func NewJobService(repo repository.JobRepository, l logger.LoggerV2) JobService
 	}
 }
 
+func (svc *jobService) AddJob(ctx context.Context, job domain.Job) error {
+	return svc.repo.Create(ctx, job)
+}
+
 func (svc *jobService) Preempt(ctx context.Context) (domain.Job, error) {
 	j, err := svc.repo.Preempt(ctx)
 	if err != nil {
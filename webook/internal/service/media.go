@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/pkg/imagex"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/objectstore"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+文章的图片和附件
+
+	文件的类型根据内容嗅探，不信任客户端上传的文件名和Content-Type，只允许domain中列出的类型。
+	图片在上传时解码一次，记录宽高，并生成缩略图；webp等标准库无法解码的图片只保存原图。
+	对象的key是随机生成的，同一个文件上传两次也是两个对象，删除时不需要考虑引用计数。
+
+	上传、删除需要文章的编辑权限，查看文章的文件列表需要查看权限。
+	读取文件内容时，文章已经发布则只需要登录，否则需要文章的查看权限；没有权限和文件不存在一样返回ErrMediaNotFound。
+*/
+
+var (
+	ErrMediaNotFound    = article.ErrMediaNotFound
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+	ErrMediaTooLarge    = errors.New("media too large")
+	ErrTooManyMedia     = errors.New("too many media")
+	// ErrInvalidImage 图片无法解码，或者像素数超过了domain.MaxImagePixels
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// maxMediaNameLen 文件名的最大长度（rune），超过时截断
+const maxMediaNameLen = 128
+
+//go:generate mockgen -source=./media.go -package=svcmocks -destination=./mocks/media.mock.go MediaService
+type MediaService interface {
+	// Upload 为文章上传文件，name是上传时的文件名
+	Upload(ctx context.Context, uid int64, articleID int64, name string, data []byte) (domain.Media, error)
+	// List 按照上传的先后查询文章的文件
+	List(ctx context.Context, uid int64, articleID int64) ([]domain.Media, error)
+	// Open 读取文件的内容，thumbnail为true时读取缩略图，没有缩略图的图片返回原图
+	Open(ctx context.Context, uid int64, id int64, thumbnail bool) (domain.Media, []byte, error)
+	Delete(ctx context.Context, uid int64, id int64) error
+	// SweepDeleted 删除对象存储中已经删除的文件，返回删除的数量
+	SweepDeleted(ctx context.Context, limit int) (int, error)
+}
+
+type mediaService struct {
+	artSvc ArticleService
+	repo   article.MediaRepository
+	store  objectstore.ObjectStore
+	l      logger.LoggerV2
+}
+
+func NewMediaService(artSvc ArticleService, repo article.MediaRepository,
+	store objectstore.ObjectStore, l logger.LoggerV2) MediaService {
+	return &mediaService{
+		artSvc: artSvc,
+		repo:   repo,
+		store:  store,
+		l:      l,
+	}
+}
+
+func (svc *mediaService) Upload(ctx context.Context, uid int64, articleID int64, name string, data []byte) (domain.Media, error) {
+	if err := svc.authorize(ctx, uid, articleID, domain.ArticleRole.CanEdit); err != nil {
+		return domain.Media{}, err
+	}
+	contentType := http.DetectContentType(data)
+	kind, ext := domain.MediaTypeOf(contentType)
+	if kind == domain.MediaKindUnknown {
+		return domain.Media{}, ErrUnsupportedMedia
+	}
+	if int64(len(data)) > kind.MaxSize() {
+		return domain.Media{}, ErrMediaTooLarge
+	}
+	cnt, err := svc.repo.CountByArticle(ctx, articleID)
+	if err != nil {
+		return domain.Media{}, err
+	}
+	if cnt >= domain.MaxArticleMedia {
+		return domain.Media{}, ErrTooManyMedia
+	}
+
+	m := domain.Media{
+		Uid:         uid,
+		ArticleID:   articleID,
+		Kind:        kind,
+		Name:        mediaName(name, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		CTime:       time.Now(),
+	}
+	m.Key, err = mediaKey(articleID, ext)
+	if err != nil {
+		return domain.Media{}, err
+	}
+	var thumb []byte
+	if kind == domain.MediaKindImage {
+		thumb, err = svc.thumbnail(&m, data)
+		if err != nil {
+			return domain.Media{}, err
+		}
+	}
+
+	if err = svc.store.Put(ctx, m.Key, data, m.ContentType); err != nil {
+		return domain.Media{}, err
+	}
+	if thumb != nil {
+		if err = svc.store.Put(ctx, m.ThumbKey, thumb, mime.TypeByExtension(path.Ext(m.ThumbKey))); err != nil {
+			svc.removeObjects(ctx, m)
+			return domain.Media{}, err
+		}
+	}
+	m.ID, err = svc.repo.Create(ctx, m)
+	if err != nil {
+		svc.removeObjects(ctx, m)
+		return domain.Media{}, err
+	}
+	return m, nil
+}
+
+// thumbnail 解码图片，记录宽高并生成缩略图，不需要缩略图或者标准库不支持该格式时返回nil
+func (svc *mediaService) thumbnail(m *domain.Media, data []byte) ([]byte, error) {
+	img, format, err := imagex.Decode(data, domain.MaxImagePixels)
+	switch {
+	case err == imagex.ErrTooLarge:
+		return nil, ErrInvalidImage
+	case err != nil && m.ContentType == "image/webp":
+		return nil, nil
+	case err != nil:
+		return nil, ErrInvalidImage
+	}
+	m.Width, m.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	thumb, ok := imagex.Thumbnail(img, domain.ThumbnailSize)
+	if !ok {
+		return nil, nil
+	}
+	res, contentType, err := imagex.Encode(thumb, format)
+	if err != nil {
+		return nil, err
+	}
+	_, ext := domain.MediaTypeOf(contentType)
+	m.ThumbKey = strings.TrimSuffix(m.Key, path.Ext(m.Key)) + "_thumb" + ext
+	return res, nil
+}
+
+func (svc *mediaService) List(ctx context.Context, uid int64, articleID int64) ([]domain.Media, error) {
+	if err := svc.authorize(ctx, uid, articleID, domain.ArticleRole.CanView); err != nil {
+		return nil, err
+	}
+	return svc.repo.ListByArticle(ctx, articleID)
+}
+
+func (svc *mediaService) Open(ctx context.Context, uid int64, id int64, thumbnail bool) (domain.Media, []byte, error) {
+	m, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return domain.Media{}, nil, err
+	}
+	if err = svc.canOpen(ctx, uid, m.ArticleID); err != nil {
+		return domain.Media{}, nil, err
+	}
+	key := m.Key
+	if thumbnail {
+		if m.Kind != domain.MediaKindImage {
+			return domain.Media{}, nil, ErrMediaNotFound
+		}
+		if m.ThumbKey != "" {
+			// 缩略图的格式可能和原图不同，例如gif的缩略图是png
+			key = m.ThumbKey
+			m.ContentType = mime.TypeByExtension(path.Ext(key))
+		}
+	}
+	data, err := svc.store.Get(ctx, key)
+	if err == objectstore.ErrObjectNotFound {
+		return domain.Media{}, nil, ErrMediaNotFound
+	}
+	if err != nil {
+		return domain.Media{}, nil, err
+	}
+	return m, data, nil
+}
+
+func (svc *mediaService) Delete(ctx context.Context, uid int64, id int64) error {
+	m, err := svc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = svc.authorize(ctx, uid, m.ArticleID, domain.ArticleRole.CanEdit); err != nil {
+		return err
+	}
+	return svc.repo.Delete(ctx, id)
+}
+
+func (svc *mediaService) SweepDeleted(ctx context.Context, limit int) (int, error) {
+	deleted, err := svc.repo.ListDeleted(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	for i, m := range deleted {
+		// 先删除文件再删除记录，删除文件失败时记录还在，下一次重试
+		if err = svc.store.Delete(ctx, m.Key); err != nil {
+			return i, err
+		}
+		if m.ThumbKey != "" {
+			if err = svc.store.Delete(ctx, m.ThumbKey); err != nil {
+				return i, err
+			}
+		}
+		if err = svc.repo.Remove(ctx, m.ID); err != nil {
+			return i, err
+		}
+	}
+	return len(deleted), nil
+}
+
+func (svc *mediaService) authorize(ctx context.Context, uid int64, articleID int64,
+	allow func(domain.ArticleRole) bool) error {
+	role, err := svc.artSvc.Role(ctx, uid, articleID)
+	if err != nil {
+		return err
+	}
+	if !allow(role) {
+		return ErrNoPermission
+	}
+	return nil
+}
+
+// canOpen 已发布文章的文件所有人都可以读取，否则需要文章的查看权限
+func (svc *mediaService) canOpen(ctx context.Context, uid int64, articleID int64) error {
+	published, err := svc.artSvc.IsPublished(ctx, articleID)
+	if err != nil || published {
+		return err
+	}
+	err = svc.authorize(ctx, uid, articleID, domain.ArticleRole.CanView)
+	if err == ErrArticleNotFound || err == ErrNoPermission {
+		return ErrMediaNotFound
+	}
+	return err
+}
+
+// removeObjects 上传失败时尽量删除已经写入的文件，失败了只记录日志
+func (svc *mediaService) removeObjects(ctx context.Context, m domain.Media) {
+	ctx = context.WithoutCancel(ctx)
+	for _, key := range []string{m.Key, m.ThumbKey} {
+		if key == "" {
+			continue
+		}
+		if err := svc.store.Delete(ctx, key); err != nil {
+			svc.l.Error("删除上传失败的文件失败", logger.String("key", key), logger.Error(err))
+		}
+	}
+}
+
+// mediaKey 对象的key：media/文章id/随机字符串.扩展名
+func mediaKey(articleID int64, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("media/%d/%s%s", articleID, hex.EncodeToString(b), ext), nil
+}
+
+// mediaName 清理上传时的文件名，只保留最后一级，为空时使用扩展名
+func mediaName(name string, ext string) string {
+	name = strings.TrimSpace(path.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" || !utf8.ValidString(name) {
+		return "file" + ext
+	}
+	if utf8.RuneCountInString(name) > maxMediaNameLen {
+		name = string([]rune(name)[:maxMediaNameLen])
+	}
+	return name
+}
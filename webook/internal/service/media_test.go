@@ -0,0 +1,244 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"image"
+	"image/png"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	artrepomocks "learn_go/webook/internal/repository/mocks/article"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/objectstore"
+	"testing"
+)
+
+func Test_mediaService_Upload(t *testing.T) {
+	pngOf := func(w, h int) []byte {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))))
+		return buf.Bytes()
+	}
+
+	testCases := []struct {
+		name string
+		data []byte
+
+		mock func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository)
+
+		wantErr    error
+		wantMedia  domain.Media
+		wantThumb  bool
+		wantObject int
+	}{
+		{
+			name: "上传图片并生成缩略图",
+			data: pngOf(640, 320),
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleEditor, nil)
+				repo.EXPECT().CountByArticle(gomock.Any(), int64(1)).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+				return artSvc, repo
+			},
+			wantMedia: domain.Media{
+				ID:          10,
+				Uid:         123,
+				ArticleID:   1,
+				Kind:        domain.MediaKindImage,
+				Name:        "a.png",
+				ContentType: "image/png",
+				Width:       640,
+				Height:      320,
+			},
+			wantThumb:  true,
+			wantObject: 2,
+		},
+		{
+			name: "小图片不生成缩略图",
+			data: pngOf(100, 100),
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleOwner, nil)
+				repo.EXPECT().CountByArticle(gomock.Any(), int64(1)).Return(int64(0), nil)
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+				return artSvc, repo
+			},
+			wantMedia: domain.Media{
+				ID:          10,
+				Uid:         123,
+				ArticleID:   1,
+				Kind:        domain.MediaKindImage,
+				Name:        "a.png",
+				ContentType: "image/png",
+				Width:       100,
+				Height:      100,
+			},
+			wantObject: 1,
+		},
+		{
+			name: "不允许的文件类型",
+			data: []byte("<html><body>hello</body></html>"),
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleOwner, nil)
+				return artSvc, artrepomocks.NewMockMediaRepository(ctrl)
+			},
+			wantErr: ErrUnsupportedMedia,
+		},
+		{
+			name: "查看者不能上传",
+			data: pngOf(100, 100),
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleViewer, nil)
+				return artSvc, artrepomocks.NewMockMediaRepository(ctrl)
+			},
+			wantErr: ErrNoPermission,
+		},
+		{
+			name: "文章的文件太多",
+			data: pngOf(100, 100),
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleOwner, nil)
+				repo.EXPECT().CountByArticle(gomock.Any(), int64(1)).Return(int64(domain.MaxArticleMedia), nil)
+				return artSvc, repo
+			},
+			wantErr: ErrTooManyMedia,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store, err := objectstore.NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			artSvc, repo := tc.mock(ctrl)
+			svc := NewMediaService(artSvc, repo, store, logger.NewNopLogger())
+
+			m, err := svc.Upload(context.Background(), 123, 1, "dir/a.png", tc.data)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.wantThumb, m.ThumbKey != "")
+			assert.NotEmpty(t, m.Key)
+			m.Key, m.ThumbKey, m.CTime = "", "", tc.wantMedia.CTime
+			tc.wantMedia.Size = int64(len(tc.data))
+			assert.Equal(t, tc.wantMedia, m)
+
+			var objects int
+			err = store.List(context.Background(), "media/1/", func(obj objectstore.Object) error {
+				objects++
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantObject, objects)
+		})
+	}
+}
+
+func Test_mediaService_Open(t *testing.T) {
+	img := domain.Media{ID: 10, ArticleID: 1, Kind: domain.MediaKindImage, ContentType: "image/png",
+		Key: "media/1/a.png", ThumbKey: "media/1/a_thumb.png"}
+
+	testCases := []struct {
+		name      string
+		thumbnail bool
+
+		mock func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository)
+
+		wantErr  error
+		wantData string
+	}{
+		{
+			name: "已发布的文章，只需要登录",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(img, nil)
+				artSvc.EXPECT().IsPublished(gomock.Any(), int64(1)).Return(true, nil)
+				return artSvc, repo
+			},
+			wantData: "原图",
+		},
+		{
+			name:      "未发布的文章，协作者读取缩略图",
+			thumbnail: true,
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(img, nil)
+				artSvc.EXPECT().IsPublished(gomock.Any(), int64(1)).Return(false, nil)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleViewer, nil)
+				return artSvc, repo
+			},
+			wantData: "缩略图",
+		},
+		{
+			name: "未发布的文章，没有权限",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(img, nil)
+				artSvc.EXPECT().IsPublished(gomock.Any(), int64(1)).Return(false, nil)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleNone, nil)
+				return artSvc, repo
+			},
+			wantErr: ErrMediaNotFound,
+		},
+		{
+			name: "文章已经删除",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(img, nil)
+				artSvc.EXPECT().IsPublished(gomock.Any(), int64(1)).Return(false, nil)
+				artSvc.EXPECT().Role(gomock.Any(), int64(123), int64(1)).Return(domain.ArticleRoleNone, ErrArticleNotFound)
+				return artSvc, repo
+			},
+			wantErr: ErrMediaNotFound,
+		},
+		{
+			name: "查询发布状态失败",
+			mock: func(ctrl *gomock.Controller) (ArticleService, article.MediaRepository) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				repo := artrepomocks.NewMockMediaRepository(ctrl)
+				repo.EXPECT().GetByID(gomock.Any(), int64(10)).Return(img, nil)
+				artSvc.EXPECT().IsPublished(gomock.Any(), int64(1)).Return(false, errors.New("mock db error"))
+				return artSvc, repo
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store, err := objectstore.NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			ctx := context.Background()
+			require.NoError(t, store.Put(ctx, img.Key, []byte("原图"), img.ContentType))
+			require.NoError(t, store.Put(ctx, img.ThumbKey, []byte("缩略图"), img.ContentType))
+			artSvc, repo := tc.mock(ctrl)
+			svc := NewMediaService(artSvc, repo, store, logger.NewNopLogger())
+
+			_, data, err := svc.Open(ctx, 123, 10, tc.thumbnail)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantData, string(data))
+		})
+	}
+}
@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: ./article.go
+// Source: internal/service/article.go
 //
 // Generated by this command:
 //
-//	mockgen -source=./article.go -package=svcmocks -destination=./mocks/article.mock.go ArticleService
+//	mockgen -source=internal/service/article.go -package=svcmocks -destination=internal/service/mocks/article.mock.go
 //
 
 // Package svcmocks is a generated GoMock package.
@@ -41,6 +41,177 @@ func (m *MockArticleService) EXPECT() *MockArticleServiceMockRecorder {
 	return m.recorder
 }
 
+// AcceptInvitation mocks base method.
+func (m *MockArticleService) AcceptInvitation(ctx context.Context, uid, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptInvitation", ctx, uid, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AcceptInvitation indicates an expected call of AcceptInvitation.
+func (mr *MockArticleServiceMockRecorder) AcceptInvitation(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptInvitation", reflect.TypeOf((*MockArticleService)(nil).AcceptInvitation), ctx, uid, articleID)
+}
+
+// AddSeriesArticle mocks base method.
+func (m *MockArticleService) AddSeriesArticle(ctx context.Context, uid, seriesID, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSeriesArticle", ctx, uid, seriesID, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSeriesArticle indicates an expected call of AddSeriesArticle.
+func (mr *MockArticleServiceMockRecorder) AddSeriesArticle(ctx, uid, seriesID, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSeriesArticle", reflect.TypeOf((*MockArticleService)(nil).AddSeriesArticle), ctx, uid, seriesID, articleID)
+}
+
+// Approve mocks base method.
+func (m *MockArticleService) Approve(ctx context.Context, uid, reviewID int64, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Approve", ctx, uid, reviewID, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Approve indicates an expected call of Approve.
+func (mr *MockArticleServiceMockRecorder) Approve(ctx, uid, reviewID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Approve", reflect.TypeOf((*MockArticleService)(nil).Approve), ctx, uid, reviewID, comment)
+}
+
+// Autosave mocks base method.
+func (m *MockArticleService) Autosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Autosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Autosave indicates an expected call of Autosave.
+func (mr *MockArticleServiceMockRecorder) Autosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Autosave", reflect.TypeOf((*MockArticleService)(nil).Autosave), ctx, save)
+}
+
+// CancelSchedule mocks base method.
+func (m *MockArticleService) CancelSchedule(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockArticleServiceMockRecorder) CancelSchedule(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockArticleService)(nil).CancelSchedule), ctx, article)
+}
+
+// CountTags mocks base method.
+func (m *MockArticleService) CountTags(ctx context.Context, limit int) ([]domain.TagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTags", ctx, limit)
+	ret0, _ := ret[0].([]domain.TagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTags indicates an expected call of CountTags.
+func (mr *MockArticleServiceMockRecorder) CountTags(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTags", reflect.TypeOf((*MockArticleService)(nil).CountTags), ctx, limit)
+}
+
+// CreateSeries mocks base method.
+func (m *MockArticleService) CreateSeries(ctx context.Context, s domain.Series) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeries", ctx, s)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSeries indicates an expected call of CreateSeries.
+func (mr *MockArticleServiceMockRecorder) CreateSeries(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeries", reflect.TypeOf((*MockArticleService)(nil).CreateSeries), ctx, s)
+}
+
+// DeclineInvitation mocks base method.
+func (m *MockArticleService) DeclineInvitation(ctx context.Context, uid, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeclineInvitation", ctx, uid, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeclineInvitation indicates an expected call of DeclineInvitation.
+func (mr *MockArticleServiceMockRecorder) DeclineInvitation(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeclineInvitation", reflect.TypeOf((*MockArticleService)(nil).DeclineInvitation), ctx, uid, articleID)
+}
+
+// Delete mocks base method.
+func (m *MockArticleService) Delete(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockArticleServiceMockRecorder) Delete(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockArticleService)(nil).Delete), ctx, article)
+}
+
+// DeleteSeries mocks base method.
+func (m *MockArticleService) DeleteSeries(ctx context.Context, uid, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, uid, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockArticleServiceMockRecorder) DeleteSeries(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockArticleService)(nil).DeleteSeries), ctx, uid, id)
+}
+
+// DiffRevisions mocks base method.
+func (m *MockArticleService) DiffRevisions(ctx context.Context, uid, articleID, fromID, toID int64) (domain.RevisionDiff, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiffRevisions", ctx, uid, articleID, fromID, toID)
+	ret0, _ := ret[0].(domain.RevisionDiff)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiffRevisions indicates an expected call of DiffRevisions.
+func (mr *MockArticleServiceMockRecorder) DiffRevisions(ctx, uid, articleID, fromID, toID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiffRevisions", reflect.TypeOf((*MockArticleService)(nil).DiffRevisions), ctx, uid, articleID, fromID, toID)
+}
+
+// FlushAutosave mocks base method.
+func (m *MockArticleService) FlushAutosave(ctx context.Context, save domain.ArticleAutosave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushAutosave", ctx, save)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlushAutosave indicates an expected call of FlushAutosave.
+func (mr *MockArticleServiceMockRecorder) FlushAutosave(ctx, save any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushAutosave", reflect.TypeOf((*MockArticleService)(nil).FlushAutosave), ctx, save)
+}
+
 // GetByID mocks base method.
 func (m *MockArticleService) GetByID(ctx context.Context, id int64) (domain.Article, error) {
 	m.ctrl.T.Helper()
@@ -56,19 +227,34 @@ func (mr *MockArticleServiceMockRecorder) GetByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockArticleService)(nil).GetByID), ctx, id)
 }
 
+// GetDraft mocks base method.
+func (m *MockArticleService) GetDraft(ctx context.Context, uid, articleID int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", ctx, uid, articleID)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockArticleServiceMockRecorder) GetDraft(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockArticleService)(nil).GetDraft), ctx, uid, articleID)
+}
+
 // GetList mocks base method.
-func (m *MockArticleService) GetList(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) GetList(ctx context.Context, uid int64, cursor domain.Cursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", ctx, uid, offset, limit)
+	ret := m.ctrl.Call(m, "GetList", ctx, uid, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetList indicates an expected call of GetList.
-func (mr *MockArticleServiceMockRecorder) GetList(ctx, uid, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) GetList(ctx, uid, cursor, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockArticleService)(nil).GetList), ctx, uid, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockArticleService)(nil).GetList), ctx, uid, cursor, limit)
 }
 
 // GetPubArticle mocks base method.
@@ -86,26 +272,265 @@ func (mr *MockArticleServiceMockRecorder) GetPubArticle(ctx, uid, id any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubArticle", reflect.TypeOf((*MockArticleService)(nil).GetPubArticle), ctx, uid, id)
 }
 
+// GetPubSeries mocks base method.
+func (m *MockArticleService) GetPubSeries(ctx context.Context, id int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubSeries", ctx, id)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubSeries indicates an expected call of GetPubSeries.
+func (mr *MockArticleServiceMockRecorder) GetPubSeries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubSeries", reflect.TypeOf((*MockArticleService)(nil).GetPubSeries), ctx, id)
+}
+
+// GetSeries mocks base method.
+func (m *MockArticleService) GetSeries(ctx context.Context, uid, id int64) (domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSeries", ctx, uid, id)
+	ret0, _ := ret[0].(domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSeries indicates an expected call of GetSeries.
+func (mr *MockArticleServiceMockRecorder) GetSeries(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSeries", reflect.TypeOf((*MockArticleService)(nil).GetSeries), ctx, uid, id)
+}
+
+// Invite mocks base method.
+func (m *MockArticleService) Invite(ctx context.Context, uid int64, c domain.Collaborator) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, uid, c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockArticleServiceMockRecorder) Invite(ctx, uid, c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockArticleService)(nil).Invite), ctx, uid, c)
+}
+
+// IsPublished mocks base method.
+func (m *MockArticleService) IsPublished(ctx context.Context, id int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsPublished", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsPublished indicates an expected call of IsPublished.
+func (mr *MockArticleServiceMockRecorder) IsPublished(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsPublished", reflect.TypeOf((*MockArticleService)(nil).IsPublished), ctx, id)
+}
+
+// ListCollaborators mocks base method.
+func (m *MockArticleService) ListCollaborators(ctx context.Context, uid, articleID int64) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollaborators", ctx, uid, articleID)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollaborators indicates an expected call of ListCollaborators.
+func (mr *MockArticleServiceMockRecorder) ListCollaborators(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollaborators", reflect.TypeOf((*MockArticleService)(nil).ListCollaborators), ctx, uid, articleID)
+}
+
+// ListDueAutosaves mocks base method.
+func (m *MockArticleService) ListDueAutosaves(ctx context.Context, now time.Time, limit int) ([]domain.ArticleAutosave, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueAutosaves", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ArticleAutosave)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueAutosaves indicates an expected call of ListDueAutosaves.
+func (mr *MockArticleServiceMockRecorder) ListDueAutosaves(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueAutosaves", reflect.TypeOf((*MockArticleService)(nil).ListDueAutosaves), ctx, now, limit)
+}
+
+// ListDueScheduled mocks base method.
+func (m *MockArticleService) ListDueScheduled(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueScheduled", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueScheduled indicates an expected call of ListDueScheduled.
+func (mr *MockArticleServiceMockRecorder) ListDueScheduled(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueScheduled", reflect.TypeOf((*MockArticleService)(nil).ListDueScheduled), ctx, now, limit)
+}
+
+// ListExpiredTrash mocks base method.
+func (m *MockArticleService) ListExpiredTrash(ctx context.Context, now time.Time, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredTrash", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListExpiredTrash indicates an expected call of ListExpiredTrash.
+func (mr *MockArticleServiceMockRecorder) ListExpiredTrash(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredTrash", reflect.TypeOf((*MockArticleService)(nil).ListExpiredTrash), ctx, now, limit)
+}
+
+// ListIDsByAuthor mocks base method.
+func (m *MockArticleService) ListIDsByAuthor(ctx context.Context, uid int64, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIDsByAuthor", ctx, uid, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIDsByAuthor indicates an expected call of ListIDsByAuthor.
+func (mr *MockArticleServiceMockRecorder) ListIDsByAuthor(ctx, uid, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIDsByAuthor", reflect.TypeOf((*MockArticleService)(nil).ListIDsByAuthor), ctx, uid, limit)
+}
+
+// ListInvitations mocks base method.
+func (m *MockArticleService) ListInvitations(ctx context.Context, uid int64, offset, limit int) ([]domain.Collaborator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListInvitations", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Collaborator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListInvitations indicates an expected call of ListInvitations.
+func (mr *MockArticleServiceMockRecorder) ListInvitations(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInvitations", reflect.TypeOf((*MockArticleService)(nil).ListInvitations), ctx, uid, offset, limit)
+}
+
 // ListPub mocks base method.
-func (m *MockArticleService) ListPub(c context.Context, time time.Time, offset, limit int) ([]domain.Article, error) {
+func (m *MockArticleService) ListPub(c context.Context, cursor domain.Cursor, limit int) ([]domain.Article, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPub", c, time, offset, limit)
+	ret := m.ctrl.Call(m, "ListPub", c, cursor, limit)
 	ret0, _ := ret[0].([]domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListPub indicates an expected call of ListPub.
-func (mr *MockArticleServiceMockRecorder) ListPub(c, time, offset, limit any) *gomock.Call {
+func (mr *MockArticleServiceMockRecorder) ListPub(c, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), c, cursor, limit)
+}
+
+// ListPubByTag mocks base method.
+func (m *MockArticleService) ListPubByTag(ctx context.Context, slug string, cursor int64, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPubByTag", ctx, slug, cursor, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPubByTag indicates an expected call of ListPubByTag.
+func (mr *MockArticleServiceMockRecorder) ListPubByTag(ctx, slug, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPubByTag", reflect.TypeOf((*MockArticleService)(nil).ListPubByTag), ctx, slug, cursor, limit)
+}
+
+// ListReviewQueue mocks base method.
+func (m *MockArticleService) ListReviewQueue(ctx context.Context, uid int64, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewQueue", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewQueue indicates an expected call of ListReviewQueue.
+func (mr *MockArticleServiceMockRecorder) ListReviewQueue(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewQueue", reflect.TypeOf((*MockArticleService)(nil).ListReviewQueue), ctx, uid, offset, limit)
+}
+
+// ListReviews mocks base method.
+func (m *MockArticleService) ListReviews(ctx context.Context, uid, articleID int64, offset, limit int) ([]domain.ArticleReview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviews", ctx, uid, articleID, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleReview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviews indicates an expected call of ListReviews.
+func (mr *MockArticleServiceMockRecorder) ListReviews(ctx, uid, articleID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviews", reflect.TypeOf((*MockArticleService)(nil).ListReviews), ctx, uid, articleID, offset, limit)
+}
+
+// ListRevisions mocks base method.
+func (m *MockArticleService) ListRevisions(ctx context.Context, uid, articleID int64, offset, limit int) ([]domain.ArticleRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevisions", ctx, uid, articleID, offset, limit)
+	ret0, _ := ret[0].([]domain.ArticleRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevisions indicates an expected call of ListRevisions.
+func (mr *MockArticleServiceMockRecorder) ListRevisions(ctx, uid, articleID, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevisions", reflect.TypeOf((*MockArticleService)(nil).ListRevisions), ctx, uid, articleID, offset, limit)
+}
+
+// ListSeries mocks base method.
+func (m *MockArticleService) ListSeries(ctx context.Context, uid int64, offset, limit int) ([]domain.Series, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeries", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Series)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeries indicates an expected call of ListSeries.
+func (mr *MockArticleServiceMockRecorder) ListSeries(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeries", reflect.TypeOf((*MockArticleService)(nil).ListSeries), ctx, uid, offset, limit)
+}
+
+// ListTrash mocks base method.
+func (m *MockArticleService) ListTrash(ctx context.Context, uid int64, offset, limit int) ([]domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockArticleServiceMockRecorder) ListTrash(ctx, uid, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleService)(nil).ListPub), c, time, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockArticleService)(nil).ListTrash), ctx, uid, offset, limit)
 }
 
 // Publish mocks base method.
-func (m *MockArticleService) Publish(ctx context.Context, article domain.Article) (int64, error) {
+func (m *MockArticleService) Publish(ctx context.Context, article domain.Article) (domain.Article, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Publish", ctx, article)
-	ret0, _ := ret[0].(int64)
+	ret0, _ := ret[0].(domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -116,6 +541,20 @@ func (mr *MockArticleServiceMockRecorder) Publish(ctx, article any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockArticleService)(nil).Publish), ctx, article)
 }
 
+// PublishScheduled mocks base method.
+func (m *MockArticleService) PublishScheduled(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockArticleServiceMockRecorder) PublishScheduled(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockArticleService)(nil).PublishScheduled), ctx, article)
+}
+
 // PublishV1 mocks base method.
 func (m *MockArticleService) PublishV1(ctx context.Context, article domain.Article) (int64, error) {
 	m.ctrl.T.Helper()
@@ -131,11 +570,140 @@ func (mr *MockArticleServiceMockRecorder) PublishV1(ctx, article any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishV1", reflect.TypeOf((*MockArticleService)(nil).PublishV1), ctx, article)
 }
 
+// Purge mocks base method.
+func (m *MockArticleService) Purge(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockArticleServiceMockRecorder) Purge(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockArticleService)(nil).Purge), ctx, article)
+}
+
+// RecoverAutosave mocks base method.
+func (m *MockArticleService) RecoverAutosave(ctx context.Context, uid, articleID int64) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverAutosave", ctx, uid, articleID)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverAutosave indicates an expected call of RecoverAutosave.
+func (mr *MockArticleServiceMockRecorder) RecoverAutosave(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverAutosave", reflect.TypeOf((*MockArticleService)(nil).RecoverAutosave), ctx, uid, articleID)
+}
+
+// Reject mocks base method.
+func (m *MockArticleService) Reject(ctx context.Context, uid, reviewID int64, comment string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reject", ctx, uid, reviewID, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reject indicates an expected call of Reject.
+func (mr *MockArticleServiceMockRecorder) Reject(ctx, uid, reviewID, comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reject", reflect.TypeOf((*MockArticleService)(nil).Reject), ctx, uid, reviewID, comment)
+}
+
+// RemoveCollaborator mocks base method.
+func (m *MockArticleService) RemoveCollaborator(ctx context.Context, uid, articleID, collaboratorID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCollaborator", ctx, uid, articleID, collaboratorID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCollaborator indicates an expected call of RemoveCollaborator.
+func (mr *MockArticleServiceMockRecorder) RemoveCollaborator(ctx, uid, articleID, collaboratorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCollaborator", reflect.TypeOf((*MockArticleService)(nil).RemoveCollaborator), ctx, uid, articleID, collaboratorID)
+}
+
+// RemoveSeriesArticle mocks base method.
+func (m *MockArticleService) RemoveSeriesArticle(ctx context.Context, uid, seriesID, articleID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSeriesArticle", ctx, uid, seriesID, articleID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSeriesArticle indicates an expected call of RemoveSeriesArticle.
+func (mr *MockArticleServiceMockRecorder) RemoveSeriesArticle(ctx, uid, seriesID, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSeriesArticle", reflect.TypeOf((*MockArticleService)(nil).RemoveSeriesArticle), ctx, uid, seriesID, articleID)
+}
+
+// ReorderSeries mocks base method.
+func (m *MockArticleService) ReorderSeries(ctx context.Context, uid, seriesID int64, articleIDs []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderSeries", ctx, uid, seriesID, articleIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderSeries indicates an expected call of ReorderSeries.
+func (mr *MockArticleServiceMockRecorder) ReorderSeries(ctx, uid, seriesID, articleIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderSeries", reflect.TypeOf((*MockArticleService)(nil).ReorderSeries), ctx, uid, seriesID, articleIDs)
+}
+
+// Restore mocks base method.
+func (m *MockArticleService) Restore(ctx context.Context, article domain.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, article)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockArticleServiceMockRecorder) Restore(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockArticleService)(nil).Restore), ctx, article)
+}
+
+// Role mocks base method.
+func (m *MockArticleService) Role(ctx context.Context, uid, articleID int64) (domain.ArticleRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Role", ctx, uid, articleID)
+	ret0, _ := ret[0].(domain.ArticleRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Role indicates an expected call of Role.
+func (mr *MockArticleServiceMockRecorder) Role(ctx, uid, articleID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Role", reflect.TypeOf((*MockArticleService)(nil).Role), ctx, uid, articleID)
+}
+
+// Rollback mocks base method.
+func (m *MockArticleService) Rollback(ctx context.Context, uid, articleID, revisionID int64, target domain.RollbackTarget) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", ctx, uid, articleID, revisionID, target)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockArticleServiceMockRecorder) Rollback(ctx, uid, articleID, revisionID, target any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockArticleService)(nil).Rollback), ctx, uid, articleID, revisionID, target)
+}
+
 // Save mocks base method.
-func (m *MockArticleService) Save(ctx context.Context, article domain.Article) (int64, error) {
+func (m *MockArticleService) Save(ctx context.Context, article domain.Article) (domain.Article, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Save", ctx, article)
-	ret0, _ := ret[0].(int64)
+	ret0, _ := ret[0].(domain.Article)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -146,6 +714,50 @@ func (mr *MockArticleServiceMockRecorder) Save(ctx, article any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockArticleService)(nil).Save), ctx, article)
 }
 
+// Schedule mocks base method.
+func (m *MockArticleService) Schedule(ctx context.Context, article domain.Article) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, article)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockArticleServiceMockRecorder) Schedule(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockArticleService)(nil).Schedule), ctx, article)
+}
+
+// Submit mocks base method.
+func (m *MockArticleService) Submit(ctx context.Context, article domain.Article) (domain.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Submit", ctx, article)
+	ret0, _ := ret[0].(domain.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Submit indicates an expected call of Submit.
+func (mr *MockArticleServiceMockRecorder) Submit(ctx, article any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Submit", reflect.TypeOf((*MockArticleService)(nil).Submit), ctx, article)
+}
+
+// UpdateSeries mocks base method.
+func (m *MockArticleService) UpdateSeries(ctx context.Context, s domain.Series) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSeries", ctx, s)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSeries indicates an expected call of UpdateSeries.
+func (mr *MockArticleServiceMockRecorder) UpdateSeries(ctx, s any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSeries", reflect.TypeOf((*MockArticleService)(nil).UpdateSeries), ctx, s)
+}
+
 // Withdraw mocks base method.
 func (m *MockArticleService) Withdraw(ctx context.Context, article domain.Article) error {
 	m.ctrl.T.Helper()
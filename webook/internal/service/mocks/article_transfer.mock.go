@@ -0,0 +1,148 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/article_transfer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/article_transfer.go -package=svcmocks -destination=internal/service/mocks/article_transfer.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	io "io"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleTransferService is a mock of ArticleTransferService interface.
+type MockArticleTransferService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleTransferServiceMockRecorder
+}
+
+// MockArticleTransferServiceMockRecorder is the mock recorder for MockArticleTransferService.
+type MockArticleTransferServiceMockRecorder struct {
+	mock *MockArticleTransferService
+}
+
+// NewMockArticleTransferService creates a new mock instance.
+func NewMockArticleTransferService(ctrl *gomock.Controller) *MockArticleTransferService {
+	mock := &MockArticleTransferService{ctrl: ctrl}
+	mock.recorder = &MockArticleTransferServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleTransferService) EXPECT() *MockArticleTransferServiceMockRecorder {
+	return m.recorder
+}
+
+// Download mocks base method.
+func (m *MockArticleTransferService) Download(ctx context.Context, uid, id int64) (domain.ArticleExport, []byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Download", ctx, uid, id)
+	ret0, _ := ret[0].(domain.ArticleExport)
+	ret1, _ := ret[1].([]byte)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Download indicates an expected call of Download.
+func (mr *MockArticleTransferServiceMockRecorder) Download(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Download", reflect.TypeOf((*MockArticleTransferService)(nil).Download), ctx, uid, id)
+}
+
+// Export mocks base method.
+func (m *MockArticleTransferService) Export(ctx context.Context, uid int64, format domain.ExportFormat, w io.Writer) (domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, uid, format, w)
+	ret0, _ := ret[0].(domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockArticleTransferServiceMockRecorder) Export(ctx, uid, format, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockArticleTransferService)(nil).Export), ctx, uid, format, w)
+}
+
+// GetExport mocks base method.
+func (m *MockArticleTransferService) GetExport(ctx context.Context, uid, id int64) (domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExport", ctx, uid, id)
+	ret0, _ := ret[0].(domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExport indicates an expected call of GetExport.
+func (mr *MockArticleTransferServiceMockRecorder) GetExport(ctx, uid, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExport", reflect.TypeOf((*MockArticleTransferService)(nil).GetExport), ctx, uid, id)
+}
+
+// Import mocks base method.
+func (m *MockArticleTransferService) Import(ctx context.Context, uid int64, name string, data []byte) ([]domain.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, uid, name, data)
+	ret0, _ := ret[0].([]domain.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockArticleTransferServiceMockRecorder) Import(ctx, uid, name, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockArticleTransferService)(nil).Import), ctx, uid, name, data)
+}
+
+// ListRunnableExports mocks base method.
+func (m *MockArticleTransferService) ListRunnableExports(ctx context.Context, now time.Time, limit int) ([]domain.ArticleExport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRunnableExports", ctx, now, limit)
+	ret0, _ := ret[0].([]domain.ArticleExport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRunnableExports indicates an expected call of ListRunnableExports.
+func (mr *MockArticleTransferServiceMockRecorder) ListRunnableExports(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRunnableExports", reflect.TypeOf((*MockArticleTransferService)(nil).ListRunnableExports), ctx, now, limit)
+}
+
+// PurgeExpiredExports mocks base method.
+func (m *MockArticleTransferService) PurgeExpiredExports(ctx context.Context, now time.Time, limit int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredExports", ctx, now, limit)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpiredExports indicates an expected call of PurgeExpiredExports.
+func (mr *MockArticleTransferServiceMockRecorder) PurgeExpiredExports(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredExports", reflect.TypeOf((*MockArticleTransferService)(nil).PurgeExpiredExports), ctx, now, limit)
+}
+
+// RunExport mocks base method.
+func (m *MockArticleTransferService) RunExport(ctx context.Context, e domain.ArticleExport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunExport", ctx, e)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RunExport indicates an expected call of RunExport.
+func (mr *MockArticleTransferServiceMockRecorder) RunExport(ctx, e any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunExport", reflect.TypeOf((*MockArticleTransferService)(nil).RunExport), ctx, e)
+}
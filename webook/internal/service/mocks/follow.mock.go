@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/service/follow.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/service/follow.go -package=svcmocks -destination=internal/service/mocks/follow.mock.go
+//
+
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	domain "learn_go/webook/internal/domain"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFollowService is a mock of FollowService interface.
+type MockFollowService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowServiceMockRecorder
+}
+
+// MockFollowServiceMockRecorder is the mock recorder for MockFollowService.
+type MockFollowServiceMockRecorder struct {
+	mock *MockFollowService
+}
+
+// NewMockFollowService creates a new mock instance.
+func NewMockFollowService(ctrl *gomock.Controller) *MockFollowService {
+	mock := &MockFollowService{ctrl: ctrl}
+	mock.recorder = &MockFollowServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollowService) EXPECT() *MockFollowServiceMockRecorder {
+	return m.recorder
+}
+
+// Follow mocks base method.
+func (m *MockFollowService) Follow(ctx context.Context, follower, followee int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Follow", ctx, follower, followee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Follow indicates an expected call of Follow.
+func (mr *MockFollowServiceMockRecorder) Follow(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Follow", reflect.TypeOf((*MockFollowService)(nil).Follow), ctx, follower, followee)
+}
+
+// Following mocks base method.
+func (m *MockFollowService) Following(ctx context.Context, follower, followee int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Following", ctx, follower, followee)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Following indicates an expected call of Following.
+func (mr *MockFollowServiceMockRecorder) Following(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Following", reflect.TypeOf((*MockFollowService)(nil).Following), ctx, follower, followee)
+}
+
+// ListFollowees mocks base method.
+func (m *MockFollowService) ListFollowees(ctx context.Context, uid, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowees", ctx, uid, maxID, limit)
+	ret0, _ := ret[0].([]domain.FollowRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowees indicates an expected call of ListFollowees.
+func (mr *MockFollowServiceMockRecorder) ListFollowees(ctx, uid, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowees", reflect.TypeOf((*MockFollowService)(nil).ListFollowees), ctx, uid, maxID, limit)
+}
+
+// ListFollowers mocks base method.
+func (m *MockFollowService) ListFollowers(ctx context.Context, uid, maxID int64, limit int) ([]domain.FollowRelation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFollowers", ctx, uid, maxID, limit)
+	ret0, _ := ret[0].([]domain.FollowRelation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFollowers indicates an expected call of ListFollowers.
+func (mr *MockFollowServiceMockRecorder) ListFollowers(ctx, uid, maxID, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFollowers", reflect.TypeOf((*MockFollowService)(nil).ListFollowers), ctx, uid, maxID, limit)
+}
+
+// Statics mocks base method.
+func (m *MockFollowService) Statics(ctx context.Context, uid int64) (domain.FollowStatics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Statics", ctx, uid)
+	ret0, _ := ret[0].(domain.FollowStatics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Statics indicates an expected call of Statics.
+func (mr *MockFollowServiceMockRecorder) Statics(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statics", reflect.TypeOf((*MockFollowService)(nil).Statics), ctx, uid)
+}
+
+// Unfollow mocks base method.
+func (m *MockFollowService) Unfollow(ctx context.Context, follower, followee int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unfollow", ctx, follower, followee)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unfollow indicates an expected call of Unfollow.
+func (mr *MockFollowServiceMockRecorder) Unfollow(ctx, follower, followee any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unfollow", reflect.TypeOf((*MockFollowService)(nil).Unfollow), ctx, follower, followee)
+}
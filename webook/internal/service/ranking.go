@@ -52,8 +52,10 @@ import (
 
 // RankingService 定义榜单服务接口，除非你的榜单业务很复杂，那么可以抽象成单独的一个接口
 type RankingService interface {
-	// TopN 计算出N个排名
+	// TopN 计算出N个排名，同时统计热门标签
 	TopN(ctx context.Context) error
+	// TopTags 查询热门标签，按标签下已发布文章的数量倒序
+	TopTags(ctx context.Context) ([]domain.TagCount, error)
 }
 
 //type compareFn[T any] func(src T, dst T)
@@ -66,6 +68,8 @@ type rankingService struct {
 
 	// 榜单长度
 	length int
+	// 热门标签的数量
+	tagLength int
 
 	interSvc intrv1.InteractionServiceClient
 	artSvc   ArticleService
@@ -87,6 +91,7 @@ func NewRankingService(
 		repo:      repo,
 		batchSize: 500,
 		length:    10,
+		tagLength: 20,
 		artSvc:    artSvc,
 		interSvc:  interSvc,
 	}
@@ -105,14 +110,27 @@ func (svc *rankingService) TopN(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	return svc.repo.ReplaceTopN(ctx, arts)
+	err = svc.repo.ReplaceTopN(ctx, arts)
+	if err != nil {
+		return err
+	}
+
+	// 热门标签直接使用标签下已发布文章的数量，由存储层聚合
+	tags, err := svc.artSvc.CountTags(ctx, svc.tagLength)
+	if err != nil {
+		return err
+	}
+	return svc.repo.ReplaceTopTags(ctx, tags)
+}
+
+func (svc *rankingService) TopTags(ctx context.Context) ([]domain.TagCount, error) {
+	return svc.repo.GetTopTags(ctx)
 }
 
 // TopN 文章的TopN计算
 func (svc *rankingService) topN(ctx context.Context) ([]domain.Article, error) {
 	// 为了让榜单的数据稳点一些，我们只计算7天内的数据。
 	now := time.Now()
-	offset := 0
 	deadline := now.Add(-24 * 7 * time.Hour)
 
 	container := queue.NewPriorityQueue[node](svc.length, func(src node, dst node) int {
@@ -125,9 +143,12 @@ func (svc *rankingService) topN(ctx context.Context) ([]domain.Article, error) {
 		}
 	})
 
+	// 从now开始按照(utime, id)倒序遍历：
+	// 游标定位的是上一批最后一篇文章，遍历期间新发布、更新的文章utime都大于now，不会影响后续的批次，
+	// 因此不会像offset分页那样重复或者遗漏文章。
+	cursor := domain.Cursor{UTime: now}
 	for {
-		// now：now是为了秒顶记录，where time < ?，这样多个循环查询出来的记录总是相同的。
-		articles, err := svc.artSvc.ListPub(ctx, now, offset, svc.batchSize)
+		articles, err := svc.artSvc.ListPub(ctx, cursor, svc.batchSize)
 		if err != nil {
 			return nil, err
 		}
@@ -172,7 +193,7 @@ func (svc *rankingService) topN(ctx context.Context) ([]domain.Article, error) {
 		if lastArt.UTime.Before(deadline) {
 			break
 		}
-		offset += svc.length
+		cursor = domain.CursorOf(lastArt)
 	}
 
 	l := container.Len()
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/internal/repository/search"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/searchx"
+)
+
+const (
+	// 高亮片段的最大长度
+	titleHighlightLen   = 64
+	contentHighlightLen = 160
+
+	reindexBatchSize = 200
+)
+
+//go:generate mockgen -source=./search.go -package=svcmocks -destination=./mocks/search.mock.go SearchService
+type SearchService interface {
+	// Search 搜索已发布的文章，返回带有高亮片段的结果
+	Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error)
+	// Reindex 遍历线上库重建索引，已发布的文章写入索引，其他状态的文章从索引中删除
+	Reindex(ctx context.Context) error
+}
+
+type searchService struct {
+	index       search.ArticleIndex
+	articleRepo article.ArticleRepository
+	l           logger.LoggerV2
+}
+
+func NewSearchService(index search.ArticleIndex, articleRepo article.ArticleRepository, l logger.LoggerV2) SearchService {
+	return &searchService{
+		index:       index,
+		articleRepo: articleRepo,
+		l:           l,
+	}
+}
+
+func (svc *searchService) Search(ctx context.Context, query string, offset int, limit int) (domain.SearchResult, error) {
+	res, err := svc.index.Search(ctx, query, offset, limit)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	terms := searchx.Terms(query)
+	for i := range res.Hits {
+		hit := &res.Hits[i]
+		hit.TitleHighlight = searchx.Highlight(hit.Article.Title, terms, titleHighlightLen)
+		hit.ContentHighlight = searchx.Highlight(hit.Article.Content, terms, contentHighlightLen)
+	}
+	return res, nil
+}
+
+func (svc *searchService) Reindex(ctx context.Context) error {
+	var (
+		startID int64
+		indexed int
+		removed int
+	)
+	for {
+		arts, err := svc.articleRepo.ScanPub(ctx, startID, reindexBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			if art.Status == domain.ArticleStatusPublished {
+				err = svc.index.Upsert(ctx, art)
+				indexed++
+			} else {
+				err = svc.index.Delete(ctx, art.ID)
+				removed++
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if len(arts) < reindexBatchSize {
+			break
+		}
+		startID = arts[len(arts)-1].ID
+	}
+	svc.l.Info("重建搜索索引完成",
+		logger.Int("indexed", indexed),
+		logger.Int("removed", removed))
+	return nil
+}
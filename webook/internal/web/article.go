@@ -4,9 +4,12 @@ import (
 	"errors"
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	intrv1 "learn_go/webook/api/proto/gen/intr"
 	"learn_go/webook/internal/domain"
 	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/cursorx"
 	"learn_go/webook/pkg/ginx"
 	"learn_go/webook/pkg/logger"
 	"net/http"
@@ -18,31 +21,49 @@ type ArticleHandler struct {
 	log      logger.LoggerV2
 	svc      service.ArticleService
 	interSvc intrv1.InteractionServiceClient
+	// 评论保存在interaction服务中
+	commentSvc intrv1.CommentServiceClient
+	// 对分页游标签名
+	cursor *cursorx.Signer
 
 	biz string
 }
 
-func NewArticleHandler(svc service.ArticleService, interSvc intrv1.InteractionServiceClient, l logger.LoggerV2) *ArticleHandler {
+func NewArticleHandler(svc service.ArticleService, interSvc intrv1.InteractionServiceClient,
+	commentSvc intrv1.CommentServiceClient, cursor *cursorx.Signer, l logger.LoggerV2) *ArticleHandler {
 	return &ArticleHandler{
-		log:      l,
-		svc:      svc,
-		interSvc: interSvc,
-		biz:      "article",
+		log:        l,
+		svc:        svc,
+		interSvc:   interSvc,
+		commentSvc: commentSvc,
+		cursor:     cursor,
+		biz:        "article",
 	}
 }
 
 func (handler *ArticleHandler) Publish(c *gin.Context, req ArticleReq, claims *UserClaims) (ginx.Result, error) {
-	articleID, err := handler.svc.Publish(c, req.toDomain(claims.Uid))
-	if err != nil {
+	if versionMissing(req.ID, req.Version) {
+		return ginx.Result{Code: 4, Msg: "version required"}, nil
+	}
+	art, err := handler.svc.Publish(c, req.toDomain(claims.Uid))
+	switch err {
+	case service.ErrVersionConflict:
+		return handler.conflictResult(c, claims.Uid, req.ID), nil
+	case nil:
+		return ginx.Result{
+			Msg:  "ok",
+			Data: toSavedVO(art),
+		}, nil
+	case service.ErrReviewRequired:
+		return ginx.Result{Code: 4, Msg: "review required"}, nil
+	case service.ErrInvalidStatusTransition:
+		return ginx.Result{Code: 4, Msg: "invalid status transition"}, nil
+	default:
 		return ginx.Result{
 			Code: 5,
 			Msg:  "failed",
 		}, err
 	}
-	return ginx.Result{
-		Msg:  "ok",
-		Data: articleID,
-	}, nil
 }
 
 func (handler *ArticleHandler) Withdraw(c *gin.Context) {
@@ -88,6 +109,13 @@ func (handler *ArticleHandler) Edit(c *gin.Context) {
 		handler.log.Info("a binding error occurred while editing the article.")
 		return
 	}
+	if versionMissing(req.ID, req.Version) {
+		c.JSON(http.StatusOK, Result{
+			Code: 4,
+			Msg:  "version required",
+		})
+		return
+	}
 
 	claimsVal, _ := c.Get("user")
 	userClaims, ok := claimsVal.(*UserClaims)
@@ -97,7 +125,11 @@ func (handler *ArticleHandler) Edit(c *gin.Context) {
 		return
 	}
 
-	articleID, err := handler.svc.Save(c, req.toDomain(userClaims.Uid))
+	art, err := handler.svc.Save(c, req.toDomain(userClaims.Uid))
+	if err == service.ErrVersionConflict {
+		c.JSON(http.StatusOK, handler.conflictResult(c, userClaims.Uid, req.ID))
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusOK, Result{
 			Code: 5,
@@ -108,24 +140,22 @@ func (handler *ArticleHandler) Edit(c *gin.Context) {
 
 	c.JSON(http.StatusOK, Result{
 		Msg:  "ok",
-		Data: articleID,
+		Data: toSavedVO(art),
 	})
 }
 
 // List 创作者获取文章列表
 func (handler *ArticleHandler) List(c *gin.Context, req ListReq, userClaims *UserClaims) (ginx.Result, error) {
-	// offset, limit
-	arts, err := handler.svc.GetList(c, userClaims.Uid, req.Offset, req.Limit)
+	cursor, err := handler.decodeCursor(req.Cursor)
 	if err != nil {
-		handler.log.Info("get list error during article publication.", logger.Error(err))
+		return ginx.Result{Code: 4, Msg: "invalid cursor"}, nil
 	}
-	vos := slice.Map(arts, func(idx int, src domain.Article) ArticleVO {
-		return handler.ToVO(src)
-	})
-	return ginx.Result{
-		Msg:  "ok",
-		Data: vos,
-	}, nil
+	limit := pageLimit(req.Limit)
+	arts, err := handler.svc.GetList(c, userClaims.Uid, cursor, limit)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: handler.toPageVO(arts, limit)}, nil
 }
 
 func (handler *ArticleHandler) Detail(c *gin.Context) {
@@ -141,15 +171,6 @@ func (handler *ArticleHandler) Detail(c *gin.Context) {
 		return
 	}
 
-	art, err := handler.svc.GetByID(c, articleID)
-	if err != nil {
-		c.JSON(200, ginx.Result{
-			Code: 5,
-			Msg:  "failed",
-		})
-		//记录日志
-		return
-	}
 	claims, ok := c.MustGet("user").(*UserClaims)
 	if !ok {
 		c.JSON(200, ginx.Result{
@@ -159,7 +180,9 @@ func (handler *ArticleHandler) Detail(c *gin.Context) {
 		handler.log.Warn("get user claims error")
 		return
 	}
-	if claims.Uid != art.Author.ID {
+	// 所有者和协作者都可以查看草稿
+	art, err := handler.svc.GetDraft(c, claims.Uid, articleID)
+	if err == service.ErrNoPermission {
 		c.JSON(200, ginx.Result{
 			Code: 5,
 			Msg:  "auth error",
@@ -167,53 +190,32 @@ func (handler *ArticleHandler) Detail(c *gin.Context) {
 		// 记录日志
 		return
 	}
+	if err != nil {
+		c.JSON(200, ginx.Result{
+			Code: 5,
+			Msg:  "failed",
+		})
+		//记录日志
+		return
+	}
 	c.JSON(200, ginx.Result{
 		Msg:  "ok",
 		Data: handler.ToVO(art),
 	})
 }
 
-// GetPublished 获取已发布的文章
-func (handler *ArticleHandler) GetPublished(c *gin.Context) {
-	startStr := c.Query("start")
-	loc, err := time.LoadLocation("Asis/Shanghai")
+// GetPublished 获取已发布的文章，按照更新时间倒序游标分页
+func (handler *ArticleHandler) GetPublished(c *gin.Context, req ListReq, claims *UserClaims) (ginx.Result, error) {
+	cursor, err := handler.decodeCursor(req.Cursor)
 	if err != nil {
-		c.JSON(200, ginx.Result{Code: 5, Msg: "parse time location error"})
-		return
+		return ginx.Result{Code: 4, Msg: "invalid cursor"}, nil
 	}
-	start, err := time.ParseInLocation(time.DateTime, startStr, loc)
+	limit := pageLimit(req.Limit)
+	arts, err := handler.svc.ListPub(c, cursor, limit)
 	if err != nil {
-		c.JSON(200, ginx.Result{Code: 5, Msg: "parse time error"})
-		return
+		return ginx.Result{Code: 5, Msg: "internal server error"}, err
 	}
-
-	offsetStr := c.Query("offset")
-	offset, err := strconv.ParseInt(offsetStr, 10, 0)
-	if err != nil {
-		c.JSON(200, ginx.Result{Code: 5, Msg: "params error"})
-		return
-	}
-	limitStr := c.Query("limit")
-	limit, err := strconv.ParseInt(limitStr, 10, 0)
-	if err != nil {
-		c.JSON(200, ginx.Result{Code: 5, Msg: "params error"})
-		return
-	}
-
-	arts, err := handler.svc.ListPub(c, start, int(offset), int(limit))
-	if err != nil {
-		c.JSON(200, ginx.Result{
-			Code: 5,
-			Msg:  "internal server error",
-		})
-		return
-	}
-	c.JSON(200, ginx.Result{
-		Msg: "ok",
-		Data: slice.Map[domain.Article, ArticleVO](arts, func(idx int, src domain.Article) ArticleVO {
-			return handler.ToVO(src)
-		}),
-	})
+	return ginx.Result{Msg: "ok", Data: handler.toPageVO(arts, limit)}, nil
 }
 
 func (handler *ArticleHandler) PubDetail(c *gin.Context) {
@@ -235,7 +237,7 @@ func (handler *ArticleHandler) PubDetail(c *gin.Context) {
 		c.JSON(200, ginx.Result{Code: 5, Msg: "internal server error."})
 		return
 	}
-	vo := handler.ToVO(art)
+	vo := handler.toPubVO(art)
 
 	// 查询文章的点赞数、收藏数和观看书
 	resp, err := handler.interSvc.Get(c, &intrv1.GetReq{
@@ -249,9 +251,13 @@ func (handler *ArticleHandler) PubDetail(c *gin.Context) {
 	} else {
 		// 查询用户是否点赞、收藏
 		vo.Views = resp.Inter.Views
+		vo.UniqueViews = resp.Inter.UniqueViews
 		vo.Favorites = resp.Inter.Favorites
 		vo.Likes = resp.Inter.Likes
+		vo.Comments = resp.Inter.Comments
+		vo.Reactions = resp.Inter.Reactions
 		vo.Liked = resp.Inter.Liked
+		vo.Reaction = resp.Inter.Reaction
 		vo.Collected = resp.Inter.Collected
 	}
 	//ok, _ = handler.interSvc.Liked(c, userClaims.Uid, handler.biz, articleID)
@@ -280,9 +286,10 @@ func (handler *ArticleHandler) Like(c *gin.Context, req LikeReq, userClaims *Use
 	if req.Action == ArticleLike {
 		_, err = handler.interSvc.Like(c, &intrv1.LikeReq{
 			//userClaims.Uid, handler.biz, req.ArticleID
-			Uid:   userClaims.Uid,
-			Biz:   handler.biz,
-			BizId: req.ArticleID,
+			Uid:      userClaims.Uid,
+			Biz:      handler.biz,
+			BizId:    req.ArticleID,
+			Reaction: req.Reaction,
 		})
 	} else {
 		_, err = handler.interSvc.CancelLike(c, &intrv1.CancelLikeReq{
@@ -292,7 +299,11 @@ func (handler *ArticleHandler) Like(c *gin.Context, req LikeReq, userClaims *Use
 			BizId: req.ArticleID,
 		})
 	}
-	if err != nil {
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "不支持的表态"}, nil
+	default:
 		return ginx.Result{
 			Code: 5,
 			Msg:  "failed",
@@ -303,7 +314,11 @@ func (handler *ArticleHandler) Like(c *gin.Context, req LikeReq, userClaims *Use
 	}, nil
 }
 
+// Favorite 收藏到自己的收藏夹，已经收藏在其他收藏夹时移动过去
 func (handler *ArticleHandler) Favorite(ctx *gin.Context, req FavoriteReq, userClaims *UserClaims) (ginx.Result, error) {
+	if res, ok, err := handler.checkPublished(ctx, req.ArticleID); !ok {
+		return res, err
+	}
 	_, err := handler.interSvc.Favorite(ctx, &intrv1.FavoriteReq{
 		//userClaims.Uid, req.FavoriteID, handler.biz, req.ArticleID
 		Uid:        userClaims.Uid,
@@ -312,39 +327,179 @@ func (handler *ArticleHandler) Favorite(ctx *gin.Context, req FavoriteReq, userC
 		BizId:      req.ArticleID,
 	})
 	if err != nil {
-		return ginx.Result{Code: 5, Msg: "save error"}, errors.New("failed")
+		return favoriteResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *ArticleHandler) CancelFavorite(ctx *gin.Context, req CancelFavoriteReq, userClaims *UserClaims) (ginx.Result, error) {
+	_, err := handler.interSvc.CancelFavorite(ctx, &intrv1.CancelFavoriteReq{
+		Uid:   userClaims.Uid,
+		Biz:   handler.biz,
+		BizId: req.ArticleID,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// Schedule 保存文章并设置定时发布
+func (handler *ArticleHandler) Schedule(c *gin.Context, req ScheduleReq, claims *UserClaims) (ginx.Result, error) {
+	if versionMissing(req.ID, req.Version) {
+		return ginx.Result{Code: 4, Msg: "version required"}, nil
+	}
+	art, err := handler.svc.Schedule(c, req.toDomain(claims.Uid))
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok", Data: toSavedVO(art)}, nil
+	case service.ErrVersionConflict:
+		return handler.conflictResult(c, claims.Uid, req.ID), nil
+	case service.ErrInvalidPublishTime:
+		return ginx.Result{Code: 4, Msg: "publish time must be in the future"}, nil
+	case service.ErrReviewRequired:
+		return ginx.Result{Code: 4, Msg: "review required"}, nil
+	case service.ErrInvalidStatusTransition:
+		return ginx.Result{Code: 4, Msg: "invalid status transition"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+// CancelSchedule 取消定时发布
+func (handler *ArticleHandler) CancelSchedule(c *gin.Context, req CancelScheduleReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.CancelSchedule(c, domain.Article{
+		ID:     req.ID,
+		Author: domain.Author{ID: claims.Uid},
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
 	}
 	return ginx.Result{Msg: "ok"}, nil
 }
 
 func (handler *ArticleHandler) ToVO(src domain.Article) ArticleVO {
-	return ArticleVO{
+	vo := ArticleVO{
 		ID:      src.ID,
 		Title:   src.Title,
 		Content: src.Content,
 		CTime:   src.CTime.Format(time.DateTime),
 		UTime:   src.UTime.Format(time.DateTime),
+		Version: src.Version,
+	}
+	if !src.PublishAt.IsZero() {
+		vo.PublishAt = src.PublishAt.Format(time.DateTime)
 	}
+	vo.Tags = slice.Map(src.Tags, func(idx int, src domain.Tag) TagVO {
+		return TagVO{Name: src.Name, Slug: src.Slug}
+	})
+	vo.Contributors = slice.Map(src.Contributors, func(idx int, src domain.Author) ContributorVO {
+		return ContributorVO{ID: src.ID, Name: src.Name, Role: src.Role.String()}
+	})
+	return vo
+}
+
+// toPubVO 读者看到的是渲染后的HTML，不返回Markdown原文
+func (handler *ArticleHandler) toPubVO(src domain.Article) ArticleVO {
+	vo := handler.ToVO(src)
+	vo.Content = ""
+	vo.Version = 0
+	vo.HTML = src.HTML
+	vo.Images = src.Images
+	vo.TOC = slice.Map(src.TOC, func(idx int, src domain.TOCItem) TOCItemVO {
+		return TOCItemVO{Level: src.Level, Text: src.Text, Anchor: src.Anchor}
+	})
+	vo.Series = handler.toSeriesNavVO(src.Series)
+	return vo
+}
+
+// toListVO 列表只返回摘要
+func (handler *ArticleHandler) toListVO(src domain.Article) ArticleVO {
+	vo := handler.ToVO(src)
+	vo.Content = ""
+	vo.Version = 0
+	vo.Abstract = src.Abstract
+	return vo
 }
 
 func (handler *ArticleHandler) RegisterRoutes(server *gin.Engine) {
 	g := server.Group("/articles")
 	g.POST("/edit", handler.Edit)
+	// 自动保存和恢复没有保存的草稿
+	g.POST("/autosave", ginx.WrapBodyAndClaims(handler.Autosave))
+	g.GET("/autosave", ginx.WrapBodyAndClaims(handler.RecoverAutosave))
 	g.POST("/publish", ginx.WrapBodyAndClaims(handler.Publish))
+	// 定时发布
+	g.POST("/schedule", ginx.WrapBodyAndClaims(handler.Schedule))
+	g.POST("/schedule/cancel", ginx.WrapBodyAndClaims(handler.CancelSchedule))
 
 	// 查询作者的文章列表
 	g.GET("/list", ginx.WrapBodyAndClaims(handler.List))
 	// 查询作者的文章详情
 	g.GET("/detail/:id", handler.Detail)
 
+	// 文章的数据趋势和创作者看板
+	g.GET("/trend", ginx.WrapBodyAndClaims(handler.Trend))
+	g.GET("/dashboard", ginx.WrapBodyAndClaims(handler.Dashboard))
+
+	// 文章的历史版本
+	g.GET("/revisions", ginx.WrapBodyAndClaims(handler.ListRevisions))
+	g.GET("/revisions/diff", ginx.WrapBodyAndClaims(handler.DiffRevisions))
+	g.POST("/revisions/rollback", ginx.WrapBodyAndClaims(handler.Rollback))
+
+	// 删除文章和回收站
+	g.POST("/delete", ginx.WrapBodyAndClaims(handler.Delete))
+	g.GET("/trash", ginx.WrapBodyAndClaims(handler.ListTrash))
+	g.POST("/trash/restore", ginx.WrapBodyAndClaims(handler.Restore))
+	g.POST("/trash/purge", ginx.WrapBodyAndClaims(handler.Purge))
+	// 协作者
+	g.GET("/collaborators", ginx.WrapBodyAndClaims(handler.ListCollaborators))
+	g.POST("/collaborators/invite", ginx.WrapBodyAndClaims(handler.Invite))
+	g.POST("/collaborators/remove", ginx.WrapBodyAndClaims(handler.RemoveCollaborator))
+	g.GET("/invitations", ginx.WrapBodyAndClaims(handler.ListInvitations))
+	g.POST("/invitations/accept", ginx.WrapBodyAndClaims(handler.AcceptInvitation))
+	g.POST("/invitations/decline", ginx.WrapBodyAndClaims(handler.DeclineInvitation))
+	// 审核
+	g.POST("/submit", ginx.WrapBodyAndClaims(handler.Submit))
+	g.GET("/reviews", ginx.WrapBodyAndClaims(handler.ListReviews))
+	g.GET("/review/queue", ginx.WrapBodyAndClaims(handler.ListReviewQueue))
+	g.POST("/review/approve", ginx.WrapBodyAndClaims(handler.Approve))
+	g.POST("/review/reject", ginx.WrapBodyAndClaims(handler.Reject))
+	// 系列
+	g.GET("/series", ginx.WrapBodyAndClaims(handler.ListSeries))
+	g.GET("/series/detail", ginx.WrapBodyAndClaims(handler.SeriesDetail))
+	g.POST("/series/create", ginx.WrapBodyAndClaims(handler.CreateSeries))
+	g.POST("/series/update", ginx.WrapBodyAndClaims(handler.UpdateSeries))
+	g.POST("/series/delete", ginx.WrapBodyAndClaims(handler.DeleteSeries))
+	g.POST("/series/add", ginx.WrapBodyAndClaims(handler.AddSeriesArticle))
+	g.POST("/series/remove", ginx.WrapBodyAndClaims(handler.RemoveSeriesArticle))
+	g.POST("/series/reorder", ginx.WrapBodyAndClaims(handler.ReorderSeries))
+
 	// 已发布文章接口
 	pub := g.Group("/pub")
 	pub.GET("/details/:id", handler.PubDetail)
+	pub.GET("/list", ginx.WrapBodyAndClaims(handler.GetPublished))
+	// 按标签查询已发布的文章
+	pub.GET("/tags/:slug", ginx.WrapBodyAndClaims(handler.ListByTag))
+	// 读者查看系列
+	pub.GET("/series/:id", handler.PubSeries)
+	// 评论
+	pub.GET("/comments", ginx.WrapBodyAndClaims(handler.ListComments))
+	pub.GET("/comments/replies", ginx.WrapBodyAndClaims(handler.ListReplies))
+	g.POST("/comments/create", ginx.WrapBodyAndClaims(handler.CreateComment))
+	g.POST("/comments/update", ginx.WrapBodyAndClaims(handler.UpdateComment))
+	g.POST("/comments/delete", ginx.WrapBodyAndClaims(handler.DeleteComment))
+	g.POST("/comments/pin", ginx.WrapBodyAndClaims(handler.PinComment))
+	g.POST("/comments/like", ginx.WrapBodyAndClaims(handler.LikeComment))
 
 	// 点赞接口
 	g.POST("/like", ginx.WrapBodyAndClaims[LikeReq, *UserClaims](handler.Like))
+	// 我点赞的文章，以及列表页批量查询是否点赞、收藏
+	g.GET("/likes", ginx.WrapBodyAndClaims(handler.ListLiked))
+	g.GET("/states", ginx.WrapBodyAndClaims(handler.States))
 
 	g.POST("/favorite", ginx.WrapBodyAndClaims[FavoriteReq, *UserClaims](handler.Favorite))
+	g.POST("/favorite/cancel", ginx.WrapBodyAndClaims(handler.CancelFavorite))
 }
 
 /*
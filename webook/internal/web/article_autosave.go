@@ -0,0 +1,48 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+)
+
+// Autosave 客户端编辑时定期调用，快照先写入缓冲区，作者停止编辑一段时间后才写入制作库
+func (handler *ArticleHandler) Autosave(c *gin.Context, req AutosaveReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Autosave(c, domain.ArticleAutosave{
+		ArticleID:   req.ID,
+		Uid:         claims.Uid,
+		Title:       req.Title,
+		Content:     req.Content,
+		BaseVersion: req.Version,
+	})
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrInvalidAutosave:
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+// RecoverAutosave 查询比制作库更新的快照，没有需要恢复的内容时data为null
+func (handler *ArticleHandler) RecoverAutosave(c *gin.Context, req AutosaveRecoverReq, claims *UserClaims) (ginx.Result, error) {
+	art, err := handler.svc.RecoverAutosave(c, claims.Uid, req.ID)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok", Data: handler.ToVO(art)}, nil
+	case service.ErrAutosaveNotFound:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
@@ -0,0 +1,90 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// Invite 所有者邀请协作者，已经是协作者时修改角色
+func (handler *ArticleHandler) Invite(c *gin.Context, req InviteReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Invite(c, claims.Uid, domain.Collaborator{
+		ArticleID: req.ArticleID,
+		User:      domain.Author{ID: req.Uid},
+		Role:      domain.ParseArticleRole(req.Role),
+	})
+	return handler.collaboratorResult(err)
+}
+
+// RemoveCollaborator 所有者移除协作者，uid是自己时表示退出协作
+func (handler *ArticleHandler) RemoveCollaborator(c *gin.Context, req CollaboratorReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.RemoveCollaborator(c, claims.Uid, req.ArticleID, req.Uid)
+	return handler.collaboratorResult(err)
+}
+
+// ListCollaborators 查询文章的协作者，包括还没有接受邀请的
+func (handler *ArticleHandler) ListCollaborators(c *gin.Context, req CollaboratorListReq, claims *UserClaims) (ginx.Result, error) {
+	cs, err := handler.svc.ListCollaborators(c, claims.Uid, req.ArticleID)
+	if err != nil {
+		return handler.collaboratorResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(cs, handler.toCollaboratorVO)}, nil
+}
+
+// ListInvitations 查询待接受的邀请
+func (handler *ArticleHandler) ListInvitations(c *gin.Context, req InvitationListReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	cs, err := handler.svc.ListInvitations(c, claims.Uid, req.Offset, pageLimit(req.Limit))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(cs, handler.toCollaboratorVO)}, nil
+}
+
+func (handler *ArticleHandler) AcceptInvitation(c *gin.Context, req InvitationReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.AcceptInvitation(c, claims.Uid, req.ArticleID)
+	return handler.collaboratorResult(err)
+}
+
+func (handler *ArticleHandler) DeclineInvitation(c *gin.Context, req InvitationReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.DeclineInvitation(c, claims.Uid, req.ArticleID)
+	return handler.collaboratorResult(err)
+}
+
+func (handler *ArticleHandler) collaboratorResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	case service.ErrInvalidCollaborator:
+		return ginx.Result{Code: 4, Msg: "invalid collaborator"}, nil
+	case service.ErrCollaboratorNotFound:
+		return ginx.Result{Code: 4, Msg: "collaborator not found"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleHandler) toCollaboratorVO(idx int, src domain.Collaborator) CollaboratorVO {
+	status := "pending"
+	if src.Status == domain.CollaboratorStatusAccepted {
+		status = "accepted"
+	}
+	return CollaboratorVO{
+		ArticleID: src.ArticleID,
+		Uid:       src.User.ID,
+		Name:      src.User.Name,
+		Role:      src.Role.String(),
+		Status:    status,
+		InviterID: src.InviterID,
+		CTime:     src.CTime.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,192 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// 已发布文章的评论，评论保存在interaction服务中
+
+// ListComments 查询文章的根评论，第一页的第一条是作者置顶的评论
+func (handler *ArticleHandler) ListComments(c *gin.Context, req CommentListReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Cursor < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	if res, ok, err := handler.checkPublished(c, req.ArticleID); !ok {
+		return res, err
+	}
+	sort := intrv1.CommentSort_COMMENT_SORT_TIME
+	switch req.Sort {
+	case "", "time":
+	case "popular":
+		sort = intrv1.CommentSort_COMMENT_SORT_POPULAR
+	default:
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	resp, err := handler.commentSvc.ListComments(c, &intrv1.ListCommentsReq{
+		Uid:    claims.Uid,
+		Biz:    handler.biz,
+		BizId:  req.ArticleID,
+		Sort:   sort,
+		Cursor: req.Cursor,
+		Limit:  int32(pageLimit(req.Limit)),
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(resp.GetComments(), handler.toCommentVO)}, nil
+}
+
+// ListReplies 按照时间顺序查询根评论的回复，和ListComments一样只能查询已发布文章的评论
+func (handler *ArticleHandler) ListReplies(c *gin.Context, req CommentReplyListReq, claims *UserClaims) (ginx.Result, error) {
+	if res, ok, err := handler.checkPublished(c, req.ArticleID); !ok {
+		return res, err
+	}
+	resp, err := handler.commentSvc.ListReplies(c, &intrv1.ListRepliesReq{
+		Uid:    claims.Uid,
+		RootId: req.RootID,
+		MinId:  req.MinID,
+		Limit:  int32(pageLimit(req.Limit)),
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	// 根评论不属于这篇文章时，不能通过已发布的文章查询其他资源的评论
+	for _, r := range resp.GetReplies() {
+		if r.GetBiz() != handler.biz || r.GetBizId() != req.ArticleID {
+			return ginx.Result{Code: 4, Msg: "comment not found"}, nil
+		}
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(resp.GetReplies(), handler.toCommentVO)}, nil
+}
+
+// CreateComment 评论文章，parent_id不为0时回复该评论
+func (handler *ArticleHandler) CreateComment(c *gin.Context, req CommentReq, claims *UserClaims) (ginx.Result, error) {
+	if res, ok, err := handler.checkPublished(c, req.ArticleID); !ok {
+		return res, err
+	}
+	resp, err := handler.commentSvc.CreateComment(c, &intrv1.CreateCommentReq{
+		Uid:      claims.Uid,
+		Biz:      handler.biz,
+		BizId:    req.ArticleID,
+		ParentId: req.ParentID,
+		Content:  req.Content,
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: handler.toCommentVO(0, resp.GetComment())}, nil
+}
+
+func (handler *ArticleHandler) UpdateComment(c *gin.Context, req CommentUpdateReq, claims *UserClaims) (ginx.Result, error) {
+	_, err := handler.commentSvc.UpdateComment(c, &intrv1.UpdateCommentReq{
+		Uid:     claims.Uid,
+		Id:      req.ID,
+		Content: req.Content,
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *ArticleHandler) DeleteComment(c *gin.Context, req CommentDeleteReq, claims *UserClaims) (ginx.Result, error) {
+	_, err := handler.commentSvc.DeleteComment(c, &intrv1.DeleteCommentReq{
+		Uid: claims.Uid,
+		Id:  req.ID,
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// PinComment 只有文章的所有者可以置顶评论，一篇文章只有一条置顶评论
+func (handler *ArticleHandler) PinComment(c *gin.Context, req CommentPinReq, claims *UserClaims) (ginx.Result, error) {
+	role, err := handler.svc.Role(c, claims.Uid, req.ArticleID)
+	switch {
+	case err == service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case err != nil:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	case !role.CanManage():
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	}
+	_, err = handler.commentSvc.PinComment(c, &intrv1.PinCommentReq{
+		Biz:    handler.biz,
+		BizId:  req.ArticleID,
+		Id:     req.ID,
+		Pinned: req.Pinned,
+	})
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// LikeComment 点赞或者取消点赞评论
+func (handler *ArticleHandler) LikeComment(c *gin.Context, req CommentLikeReq, claims *UserClaims) (ginx.Result, error) {
+	var err error
+	if req.Like {
+		_, err = handler.commentSvc.LikeComment(c, &intrv1.LikeCommentReq{Uid: claims.Uid, Id: req.ID})
+	} else {
+		_, err = handler.commentSvc.CancelLikeComment(c, &intrv1.CancelLikeCommentReq{Uid: claims.Uid, Id: req.ID})
+	}
+	if err != nil {
+		return handler.commentResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// checkPublished 只有已发布的文章可以评论
+func (handler *ArticleHandler) checkPublished(c *gin.Context, articleID int64) (ginx.Result, bool, error) {
+	art, err := handler.svc.GetByID(c, articleID)
+	switch {
+	case err == service.ErrNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, false, nil
+	case err != nil:
+		return ginx.Result{Code: 5, Msg: "failed"}, false, err
+	case art.Status != domain.ArticleStatusPublished:
+		return ginx.Result{Code: 4, Msg: "article not found"}, false, nil
+	}
+	return ginx.Result{}, true, nil
+}
+
+// commentResult 本地调用和rpc调用都用grpc的状态码表示业务错误
+func (handler *ArticleHandler) commentResult(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "comment not found"}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "invalid comment"}, nil
+	case codes.PermissionDenied:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleHandler) toCommentVO(idx int, c *intrv1.Comment) CommentVO {
+	return CommentVO{
+		ID:         c.GetId(),
+		Uid:        c.GetUid(),
+		ArticleID:  c.GetBizId(),
+		RootID:     c.GetRootId(),
+		ParentID:   c.GetParentId(),
+		ReplyToUid: c.GetReplyToUid(),
+		Content:    c.GetContent(),
+		Likes:      c.GetLikes(),
+		Replies:    c.GetReplies(),
+		Pinned:     c.GetPinned(),
+		Liked:      c.GetLiked(),
+		CTime:      time.UnixMilli(c.GetCTime()).Format(time.DateTime),
+		UTime:      time.UnixMilli(c.GetUTime()).Format(time.DateTime),
+	}
+}
@@ -0,0 +1,108 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/cursorx"
+	"learn_go/webook/pkg/logger"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeCommentClient 只实现ListReplies，记录是否被调用
+type fakeCommentClient struct {
+	intrv1.CommentServiceClient
+	replies []*intrv1.Comment
+	called  bool
+}
+
+func (f *fakeCommentClient) ListReplies(ctx context.Context, in *intrv1.ListRepliesReq, opts ...grpc.CallOption) (*intrv1.ListRepliesResp, error) {
+	f.called = true
+	return &intrv1.ListRepliesResp{Replies: f.replies}, nil
+}
+
+func TestArticleHandler_ListReplies(t *testing.T) {
+	testCases := []struct {
+		name    string
+		replies []*intrv1.Comment
+
+		mock func(ctrl *gomock.Controller) service.ArticleService
+
+		wantCalled bool
+		wantCode   int
+		wantLen    int
+	}{
+		{
+			name:    "查询已发布文章的回复",
+			replies: []*intrv1.Comment{{Id: 3, Biz: "article", BizId: 1, RootId: 2}},
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil)
+				return svc
+			},
+			wantCalled: true,
+			wantLen:    1,
+		},
+		{
+			name: "文章已经撤回",
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{ID: 1, Status: domain.ArticleStatusPrivate}, nil)
+				return svc
+			},
+			wantCode: 4,
+		},
+		{
+			name:    "根评论不属于这篇文章",
+			replies: []*intrv1.Comment{{Id: 3, Biz: "article", BizId: 5, RootId: 2}},
+			mock: func(ctrl *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(ctrl)
+				svc.EXPECT().GetByID(gomock.Any(), int64(1)).
+					Return(domain.Article{ID: 1, Status: domain.ArticleStatusPublished}, nil)
+				return svc
+			},
+			wantCalled: true,
+			wantCode:   4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", &UserClaims{Uid: 2001})
+			})
+			commentSvc := &fakeCommentClient{replies: tc.replies}
+			NewArticleHandler(tc.mock(ctrl), nil, commentSvc, cursorx.NewSigner([]byte("test key")),
+				logger.NewNopLogger()).RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/articles/pub/comments/replies?article_id=1&root_id=2", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			var res Result
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, tc.wantCalled, commentSvc.called)
+			assert.Equal(t, tc.wantCode, res.Code)
+			if tc.wantCode == 0 {
+				assert.Len(t, res.Data, tc.wantLen)
+			}
+		})
+	}
+}
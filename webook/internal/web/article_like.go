@@ -0,0 +1,75 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// ListLiked 按照点赞时间倒序查询用户点赞的文章，分页方式和List相同
+func (handler *ArticleHandler) ListLiked(c *gin.Context, req ListReq, claims *UserClaims) (ginx.Result, error) {
+	cursor, err := handler.decodeCursor(req.Cursor)
+	if err != nil {
+		return ginx.Result{Code: 4, Msg: "invalid cursor"}, nil
+	}
+	limit := pageLimit(req.Limit)
+	resp, err := handler.interSvc.ListLikes(c, &intrv1.ListLikesReq{
+		Uid:        claims.Uid,
+		Biz:        handler.biz,
+		BeforeTime: cursor.UTime.UnixMilli(),
+		BeforeId:   cursor.ID,
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	likes := resp.GetLikes()
+	vo := LikedPageVO{Likes: make([]LikedArticleVO, 0, len(likes))}
+	for _, l := range likes {
+		vo.Likes = append(vo.Likes, LikedArticleVO{
+			ArticleID: l.GetBizId(),
+			Reaction:  l.GetReaction(),
+			LikedAt:   time.UnixMilli(l.GetTime()).Format(time.DateTime),
+		})
+	}
+	if len(likes) < limit {
+		return ginx.Result{Msg: "ok", Data: vo}, nil
+	}
+	last := likes[len(likes)-1]
+	vo.NextCursor, err = encodeCursor(handler.cursor, domain.Cursor{UTime: time.UnixMilli(last.GetTime()), ID: last.GetId()})
+	if err != nil {
+		handler.log.Error("生成分页游标失败")
+	}
+	return ginx.Result{Msg: "ok", Data: vo}, nil
+}
+
+// States 批量查询用户是否点赞、收藏了列表页中的文章
+func (handler *ArticleHandler) States(c *gin.Context, req ArticleStatesReq, claims *UserClaims) (ginx.Result, error) {
+	resp, err := handler.interSvc.BatchCheck(c, &intrv1.BatchCheckReq{
+		Uid:    claims.Uid,
+		Biz:    handler.biz,
+		BizIds: req.IDs,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "too many articles"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	states := resp.GetStates()
+	vos := make([]ArticleStateVO, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		st := states[id]
+		vos = append(vos, ArticleStateVO{
+			ArticleID: id,
+			Liked:     st.GetLiked(),
+			Collected: st.GetCollected(),
+		})
+	}
+	return ginx.Result{Msg: "ok", Data: vos}, nil
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// Submit 保存文章并提交审核
+func (handler *ArticleHandler) Submit(c *gin.Context, req ArticleReq, claims *UserClaims) (ginx.Result, error) {
+	if versionMissing(req.ID, req.Version) {
+		return ginx.Result{Code: 4, Msg: "version required"}, nil
+	}
+	art, err := handler.svc.Submit(c, req.toDomain(claims.Uid))
+	if err == service.ErrVersionConflict {
+		return handler.conflictResult(c, claims.Uid, req.ID), nil
+	}
+	if err != nil {
+		return handler.reviewResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: toSavedVO(art)}, nil
+}
+
+// ListReviews 查询文章的审核记录，所有者和协作者都可以查看
+func (handler *ArticleHandler) ListReviews(c *gin.Context, req ReviewListReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	reviews, err := handler.svc.ListReviews(c, claims.Uid, req.ArticleID, req.Offset, pageLimit(req.Limit))
+	if err != nil {
+		return handler.reviewResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(reviews, handler.toReviewVO)}, nil
+}
+
+// ListReviewQueue 审核人员查询待审核的记录
+func (handler *ArticleHandler) ListReviewQueue(c *gin.Context, req ReviewQueueReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	reviews, err := handler.svc.ListReviewQueue(c, claims.Uid, req.Offset, pageLimit(req.Limit))
+	if err != nil {
+		return handler.reviewResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(reviews, handler.toReviewVO)}, nil
+}
+
+func (handler *ArticleHandler) Approve(c *gin.Context, req ReviewDecisionReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Approve(c, claims.Uid, req.ReviewID, req.Comment)
+	return handler.reviewResult(err)
+}
+
+func (handler *ArticleHandler) Reject(c *gin.Context, req ReviewDecisionReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Reject(c, claims.Uid, req.ReviewID, req.Comment)
+	return handler.reviewResult(err)
+}
+
+func (handler *ArticleHandler) reviewResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	case service.ErrInvalidStatusTransition:
+		return ginx.Result{Code: 4, Msg: "invalid status transition"}, nil
+	case service.ErrReviewNotFound:
+		return ginx.Result{Code: 4, Msg: "review not found"}, nil
+	case service.ErrReviewDecided:
+		return ginx.Result{Code: 4, Msg: "review already decided"}, nil
+	case service.ErrArticleNotInReview:
+		return ginx.Result{Code: 4, Msg: "article not in review"}, nil
+	case service.ErrReviewCommentRequired:
+		return ginx.Result{Code: 4, Msg: "comment required"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleHandler) toReviewVO(idx int, src domain.ArticleReview) ReviewVO {
+	return ReviewVO{
+		ID:          src.ID,
+		ArticleID:   src.ArticleID,
+		AuthorID:    src.AuthorID,
+		SubmitterID: src.SubmitterID,
+		Title:       src.Title,
+		ReviewerID:  src.ReviewerID,
+		Status:      src.Status.String(),
+		Comment:     src.Comment,
+		CTime:       src.CTime.Format(time.DateTime),
+		UTime:       src.UTime.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,87 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// ListRevisions 查询文章的历史版本
+func (handler *ArticleHandler) ListRevisions(c *gin.Context, req RevisionListReq, claims *UserClaims) (ginx.Result, error) {
+	revisions, err := handler.svc.ListRevisions(c, claims.Uid, req.ArticleID, req.Offset, req.Limit)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{
+		Msg: "ok",
+		Data: slice.Map(revisions, func(idx int, src domain.ArticleRevision) RevisionVO {
+			return handler.toRevisionVO(src)
+		}),
+	}, nil
+}
+
+// DiffRevisions 对比俩个历史版本
+func (handler *ArticleHandler) DiffRevisions(c *gin.Context, req RevisionDiffReq, claims *UserClaims) (ginx.Result, error) {
+	diff, err := handler.svc.DiffRevisions(c, claims.Uid, req.ArticleID, req.From, req.To)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{
+		Msg: "ok",
+		Data: RevisionDiffVO{
+			From: handler.toRevisionVO(diff.From),
+			To:   handler.toRevisionVO(diff.To),
+			Lines: slice.Map(diff.Lines, func(idx int, src domain.DiffLine) DiffLineVO {
+				return DiffLineVO{
+					Op:   handler.diffOp(src.Op),
+					Text: src.Text,
+				}
+			}),
+		},
+	}, nil
+}
+
+// Rollback 将草稿或者已发布的文章回滚到某个历史版本
+func (handler *ArticleHandler) Rollback(c *gin.Context, req RollbackReq, claims *UserClaims) (ginx.Result, error) {
+	var target domain.RollbackTarget
+	switch req.Target {
+	case RollbackTargetDraft:
+		target = domain.RollbackTargetDraft
+	case RollbackTargetPublished:
+		target = domain.RollbackTargetPublished
+	default:
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+
+	articleID, err := handler.svc.Rollback(c, claims.Uid, req.ArticleID, req.RevisionID, target)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{
+		Msg:  "ok",
+		Data: articleID,
+	}, nil
+}
+
+func (handler *ArticleHandler) toRevisionVO(src domain.ArticleRevision) RevisionVO {
+	return RevisionVO{
+		ID:        src.ID,
+		ArticleID: src.ArticleID,
+		Title:     src.Title,
+		Status:    src.Status.ToInt8(),
+		CTime:     src.CTime.Format(time.DateTime),
+	}
+}
+
+func (handler *ArticleHandler) diffOp(op domain.DiffOp) string {
+	switch op {
+	case domain.DiffOpInsert:
+		return "+"
+	case domain.DiffOpDelete:
+		return "-"
+	default:
+		return " "
+	}
+}
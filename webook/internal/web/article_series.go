@@ -0,0 +1,154 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"strconv"
+	"time"
+)
+
+func (handler *ArticleHandler) CreateSeries(c *gin.Context, req SeriesReq, claims *UserClaims) (ginx.Result, error) {
+	id, err := handler.svc.CreateSeries(c, domain.Series{
+		Author:      domain.Author{ID: claims.Uid},
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		return handler.seriesResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: id}, nil
+}
+
+func (handler *ArticleHandler) UpdateSeries(c *gin.Context, req SeriesReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.UpdateSeries(c, domain.Series{
+		ID:          req.ID,
+		Author:      domain.Author{ID: claims.Uid},
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	return handler.seriesResult(err)
+}
+
+// DeleteSeries 删除系列，系列中的文章不受影响
+func (handler *ArticleHandler) DeleteSeries(c *gin.Context, req SeriesIDReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.DeleteSeries(c, claims.Uid, req.ID)
+	return handler.seriesResult(err)
+}
+
+// ListSeries 按照修改时间倒序查询作者的系列，只返回文章的id
+func (handler *ArticleHandler) ListSeries(c *gin.Context, req SeriesListReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	res, err := handler.svc.ListSeries(c, claims.Uid, req.Offset, pageLimit(req.Limit))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(res, handler.toSeriesVO)}, nil
+}
+
+// SeriesDetail 作者查看系列，包括还没有发布的文章
+func (handler *ArticleHandler) SeriesDetail(c *gin.Context, req SeriesDetailReq, claims *UserClaims) (ginx.Result, error) {
+	s, err := handler.svc.GetSeries(c, claims.Uid, req.ID)
+	if err != nil {
+		return handler.seriesResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: handler.toSeriesVO(0, s)}, nil
+}
+
+// AddSeriesArticle 将文章追加到系列的末尾
+func (handler *ArticleHandler) AddSeriesArticle(c *gin.Context, req SeriesArticleReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.AddSeriesArticle(c, claims.Uid, req.SeriesID, req.ArticleID)
+	return handler.seriesResult(err)
+}
+
+func (handler *ArticleHandler) RemoveSeriesArticle(c *gin.Context, req SeriesArticleReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.RemoveSeriesArticle(c, claims.Uid, req.SeriesID, req.ArticleID)
+	return handler.seriesResult(err)
+}
+
+// ReorderSeries 调整系列中文章的顺序，需要带上系列中全部的文章
+func (handler *ArticleHandler) ReorderSeries(c *gin.Context, req SeriesReorderReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.ReorderSeries(c, claims.Uid, req.SeriesID, req.ArticleIDs)
+	return handler.seriesResult(err)
+}
+
+// PubSeries 读者查看系列，只返回已发布的文章
+func (handler *ArticleHandler) PubSeries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(200, ginx.Result{Code: 4, Msg: "params error"})
+		return
+	}
+	s, err := handler.svc.GetPubSeries(c, id)
+	if err != nil {
+		res, err := handler.seriesResult(err)
+		if err != nil {
+			handler.log.Error("查询系列失败", logger.Int64("series id", id), logger.Error(err))
+		}
+		c.JSON(200, res)
+		return
+	}
+	c.JSON(200, ginx.Result{Msg: "ok", Data: handler.toSeriesVO(0, s)})
+}
+
+func (handler *ArticleHandler) seriesResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrInvalidSeries:
+		return ginx.Result{Code: 4, Msg: "invalid series"}, nil
+	case service.ErrSeriesNotFound:
+		return ginx.Result{Code: 4, Msg: "series not found"}, nil
+	case service.ErrSeriesFull:
+		return ginx.Result{Code: 4, Msg: "series is full"}, nil
+	case service.ErrSeriesArticleDuplicate:
+		return ginx.Result{Code: 4, Msg: "article already in series"}, nil
+	case service.ErrSeriesArticleMismatch:
+		return ginx.Result{Code: 4, Msg: "series articles mismatch"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleHandler) toSeriesVO(idx int, src domain.Series) SeriesVO {
+	return SeriesVO{
+		ID:          src.ID,
+		AuthorID:    src.Author.ID,
+		AuthorName:  src.Author.Name,
+		Title:       src.Title,
+		Description: src.Description,
+		Articles: slice.Map(src.Articles, func(idx int, src domain.SeriesArticle) SeriesArticleVO {
+			return SeriesArticleVO{ID: src.ID, Title: src.Title}
+		}),
+		CTime: src.CTime.Format(time.DateTime),
+		UTime: src.UTime.Format(time.DateTime),
+	}
+}
+
+func (handler *ArticleHandler) toSeriesNavVO(src *domain.SeriesNav) *SeriesNavVO {
+	if src == nil {
+		return nil
+	}
+	vo := &SeriesNavVO{
+		ID:    src.ID,
+		Title: src.Title,
+		Index: src.Index,
+		Total: src.Total,
+	}
+	if src.Prev.ID > 0 {
+		vo.Prev = &SeriesArticleVO{ID: src.Prev.ID, Title: src.Prev.Title}
+	}
+	if src.Next.ID > 0 {
+		vo.Next = &SeriesArticleVO{ID: src.Next.ID, Title: src.Next.Title}
+	}
+	return vo
+}
@@ -0,0 +1,136 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// Trend 查询文章每个时间桶新增的阅读数、点赞数、收藏数，作者和协作者可以查看
+func (handler *ArticleHandler) Trend(c *gin.Context, req TrendReq, claims *UserClaims) (ginx.Result, error) {
+	role, err := handler.svc.Role(c, claims.Uid, req.ArticleID)
+	switch {
+	case err == service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case err != nil:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	case !role.CanView():
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	}
+	handler.trendRange(&req)
+	resp, err := handler.interSvc.GetTrend(c, &intrv1.GetTrendReq{
+		Biz:         handler.biz,
+		BizId:       req.ArticleID,
+		Granularity: req.Granularity,
+		From:        req.From,
+		To:          req.To,
+	})
+	if err != nil {
+		return handler.trendResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(resp.GetPoints(), handler.toTrendPointVO)}, nil
+}
+
+// dashboardBatchSize 看板分批查询交互数据和趋势，和interaction服务一次最多查询的资源数量相同
+const dashboardBatchSize = 100
+
+// Dashboard 创作者看板，汇总作者最新的domain.MaxDashboardArticles篇文章的累计数据和趋势
+func (handler *ArticleHandler) Dashboard(c *gin.Context, req TrendReq, claims *UserClaims) (ginx.Result, error) {
+	// 多查一篇，判断是否还有更早的文章没有统计
+	ids, err := handler.svc.ListIDsByAuthor(c, claims.Uid, domain.MaxDashboardArticles+1)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	vo := DashboardVO{Trend: []TrendPointVO{}}
+	if len(ids) > domain.MaxDashboardArticles {
+		ids = ids[:domain.MaxDashboardArticles]
+		vo.Truncated = true
+	}
+	vo.Articles = len(ids)
+	if len(ids) == 0 {
+		return ginx.Result{Msg: "ok", Data: vo}, nil
+	}
+
+	handler.trendRange(&req)
+	var trend []*intrv1.TrendPoint
+	for start := 0; start < len(ids); start += dashboardBatchSize {
+		batch := ids[start:min(start+dashboardBatchSize, len(ids))]
+		inters, err := handler.interSvc.GetByIDs(c, &intrv1.GetByIDsReq{Biz: handler.biz, BizIds: batch})
+		if err != nil {
+			return ginx.Result{Code: 5, Msg: "failed"}, err
+		}
+		for _, inter := range inters.GetInters() {
+			vo.Views += inter.GetViews()
+			vo.UniqueViews += inter.GetUniqueViews()
+			vo.Likes += inter.GetLikes()
+			vo.Favorites += inter.GetFavorites()
+			vo.Comments += inter.GetComments()
+		}
+
+		resp, err := handler.interSvc.GetAggregatedTrend(c, &intrv1.GetAggregatedTrendReq{
+			Biz:         handler.biz,
+			BizIds:      batch,
+			Granularity: req.Granularity,
+			From:        req.From,
+			To:          req.To,
+		})
+		if err != nil {
+			return handler.trendResult(err)
+		}
+		trend = handler.mergeTrend(trend, resp.GetPoints())
+	}
+	vo.Trend = slice.Map(trend, handler.toTrendPointVO)
+	return ginx.Result{Msg: "ok", Data: vo}, nil
+}
+
+// mergeTrend 把一批文章的趋势累加到trend上，每一批的时间范围相同，时间桶一一对应
+func (handler *ArticleHandler) mergeTrend(trend []*intrv1.TrendPoint, points []*intrv1.TrendPoint) []*intrv1.TrendPoint {
+	if trend == nil {
+		return points
+	}
+	for i := 0; i < len(trend) && i < len(points); i++ {
+		trend[i].Views += points[i].GetViews()
+		trend[i].Likes += points[i].GetLikes()
+		trend[i].Favorites += points[i].GetFavorites()
+	}
+	return trend
+}
+
+// trendRange 补全默认的粒度和时间范围：按天查询最近30天，按小时查询最近24小时
+func (handler *ArticleHandler) trendRange(req *TrendReq) {
+	if req.Granularity == "" {
+		req.Granularity = "day"
+	}
+	if req.To <= 0 {
+		req.To = time.Now().UnixMilli()
+	}
+	if req.From <= 0 {
+		span := time.Hour * 24 * 30
+		if req.Granularity == "hour" {
+			span = time.Hour * 24
+		}
+		req.From = time.UnixMilli(req.To).Add(-span).UnixMilli()
+	}
+}
+
+func (handler *ArticleHandler) trendResult(err error) (ginx.Result, error) {
+	if status.Code(err) == codes.InvalidArgument {
+		return ginx.Result{Code: 4, Msg: "invalid granularity or time range"}, nil
+	}
+	return ginx.Result{Code: 5, Msg: "failed"}, err
+}
+
+func (handler *ArticleHandler) toTrendPointVO(idx int, p *intrv1.TrendPoint) TrendPointVO {
+	return TrendPointVO{
+		Time:      time.UnixMilli(p.GetTime()).Format(time.DateTime),
+		Views:     p.GetViews(),
+		Likes:     p.GetLikes(),
+		Favorites: p.GetFavorites(),
+	}
+}
@@ -0,0 +1,31 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/ginx"
+)
+
+// ListByTag 按照发布的先后倒序查询标签下的文章，使用游标分页
+func (handler *ArticleHandler) ListByTag(c *gin.Context, req TagListReq, claims *UserClaims) (ginx.Result, error) {
+	slug := domain.TagSlug(c.Param("slug"))
+	if slug == "" || req.Cursor < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	limit := pageLimit(req.Limit)
+
+	arts, err := handler.svc.ListPubByTag(c, slug, req.Cursor, limit)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	vo := TagListVO{
+		Articles: slice.Map(arts, func(idx int, src domain.Article) ArticleVO {
+			return handler.toListVO(src)
+		}),
+	}
+	if len(arts) == limit {
+		vo.NextCursor = arts[len(arts)-1].ID
+	}
+	return ginx.Result{Msg: "ok", Data: vo}, nil
+}
@@ -11,10 +11,12 @@ import (
 	"learn_go/webook/internal/domain"
 	"learn_go/webook/internal/service"
 	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/cursorx"
 	"learn_go/webook/pkg/logger"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 /*
@@ -53,7 +55,7 @@ func TestArticleHandler_Edit(t *testing.T) {
 			wantCode: http.StatusOK,
 			wantRes: Result{
 				Msg:  "ok",
-				Data: float64(1),
+				Data: jsonValue(t, ArticleSavedVO{ID: 1, Version: 1}),
 			},
 
 			mock: func(controller *gomock.Controller) service.ArticleService {
@@ -67,7 +69,7 @@ func TestArticleHandler_Edit(t *testing.T) {
 					Author: domain.Author{
 						ID: 2001,
 					},
-				}).Return(int64(1), nil)
+				}).Return(domain.Article{ID: 1, Version: 1}, nil)
 
 				return svc
 			},
@@ -97,11 +99,78 @@ func TestArticleHandler_Edit(t *testing.T) {
 					Author: domain.Author{
 						ID: 2001,
 					},
-				}).Return(int64(0), errors.New("mock db error"))
+				}).Return(domain.Article{}, errors.New("mock db error"))
 
 				return svc
 			},
 		},
+		{
+			name: "修改文章时没有带上版本号",
+			body: `
+{
+	"id": 1,
+	"title": "This is my title",
+	"content": "This is my content"
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: Result{
+				Code: 4,
+				Msg:  "version required",
+			},
+
+			mock: func(controller *gomock.Controller) service.ArticleService {
+				return svcmocks.NewMockArticleService(controller)
+			},
+		},
+		{
+			name: "版本号冲突，返回服务端当前的草稿",
+			body: `
+{
+	"id": 1,
+	"title": "This is my title",
+	"content": "This is my content",
+	"version": 2
+}
+`,
+			wantCode: http.StatusOK,
+			wantRes: Result{
+				Code: 4,
+				Msg:  "version conflict",
+				Data: jsonValue(t, ArticleVO{
+					ID:      1,
+					Title:   "server title",
+					Content: "server content",
+					CTime:   time.Time{}.Format(time.DateTime),
+					UTime:   time.Time{}.Format(time.DateTime),
+					Tags:    []TagVO{},
+					Version: 3,
+				}),
+			},
+
+			mock: func(controller *gomock.Controller) service.ArticleService {
+				svc := svcmocks.NewMockArticleService(controller)
+				svc.EXPECT().Save(gomock.Any(), domain.Article{
+					ID:      1,
+					Title:   "This is my title",
+					Content: "This is my content",
+					Author: domain.Author{
+						ID: 2001,
+					},
+					Version: 2,
+				}).Return(domain.Article{}, service.ErrVersionConflict)
+				svc.EXPECT().GetDraft(gomock.Any(), int64(2001), int64(1)).Return(domain.Article{
+					ID:      1,
+					Title:   "server title",
+					Content: "server content",
+					Author: domain.Author{
+						ID: 2001,
+					},
+					Version: 3,
+				}, nil)
+				return svc
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -117,7 +186,7 @@ func TestArticleHandler_Edit(t *testing.T) {
 			})
 
 			articleSvc := testCase.mock(ctrl)
-			articleHandler := NewArticleHandler(articleSvc, nil, logger.NewNopLogger())
+			articleHandler := NewArticleHandler(articleSvc, nil, nil, cursorx.NewSigner([]byte("test key")), logger.NewNopLogger())
 			articleHandler.RegisterRoutes(server)
 
 			// 构建请求
@@ -144,3 +213,12 @@ func TestArticleHandler_Edit(t *testing.T) {
 	}
 
 }
+
+// jsonValue 将期望的响应数据转换成json解码后的形式，和解析出来的Result.Data比较
+func jsonValue(t *testing.T, v any) any {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var res any
+	require.NoError(t, json.Unmarshal(data, &res))
+	return res
+}
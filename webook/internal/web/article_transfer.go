@@ -0,0 +1,188 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"io"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxImportSize 导入文件的最大字节数
+const maxImportSize = 10 << 20
+
+// ArticleTransferHandler 文章的导入、导出
+type ArticleTransferHandler struct {
+	svc service.ArticleTransferService
+	l   logger.LoggerV2
+}
+
+func NewArticleTransferHandler(svc service.ArticleTransferService, l logger.LoggerV2) *ArticleTransferHandler {
+	return &ArticleTransferHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (handler *ArticleTransferHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles")
+	// 文章不多时直接下载，否则返回后台任务，通过/export/task查询任务的进度
+	g.GET("/export", handler.Export)
+	g.GET("/export/task", ginx.WrapBodyAndClaims(handler.ExportTask))
+	g.GET("/export/download", handler.Download)
+	g.POST("/import", ginx.WrapClaims(handler.Import))
+}
+
+// Export format：markdown、html、json，默认markdown
+func (handler *ArticleTransferHandler) Export(c *gin.Context) {
+	claims, ok := c.MustGet("user").(*UserClaims)
+	if !ok {
+		c.JSON(http.StatusOK, ginx.Result{Code: 5, Msg: "auth error"})
+		return
+	}
+	format := domain.ExportFormat(c.DefaultQuery("format", string(domain.ExportFormatMarkdown)))
+	w := &attachmentWriter{c: c, format: format}
+	e, err := handler.svc.Export(c, claims.Uid, format, w)
+	switch {
+	case err != nil && w.written:
+		// 响应已经开始写入，只能中断连接，客户端会收到不完整的文件
+		handler.l.Error("导出文章失败", logger.Int64("uid", claims.Uid), logger.Error(err))
+		c.Abort()
+	case err == service.ErrInvalidExportFormat:
+		c.JSON(http.StatusOK, ginx.Result{Code: 4, Msg: "invalid export format"})
+	case err != nil:
+		handler.l.Error("导出文章失败", logger.Int64("uid", claims.Uid), logger.Error(err))
+		c.JSON(http.StatusOK, ginx.Result{Code: 5, Msg: "failed"})
+	case e.Status == domain.ExportStatusPending:
+		c.JSON(http.StatusAccepted, ginx.Result{Msg: "ok", Data: handler.toExportVO(e)})
+	}
+}
+
+// ExportTask 查询后台导出任务的进度，完成后返回下载链接
+func (handler *ArticleTransferHandler) ExportTask(c *gin.Context, req ExportTaskReq, claims *UserClaims) (ginx.Result, error) {
+	e, err := handler.svc.GetExport(c, claims.Uid, req.ID)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok", Data: handler.toExportVO(e)}, nil
+	case service.ErrExportNotFound:
+		return ginx.Result{Code: 4, Msg: "export not found"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleTransferHandler) Download(c *gin.Context) {
+	claims, ok := c.MustGet("user").(*UserClaims)
+	if !ok {
+		c.JSON(http.StatusOK, ginx.Result{Code: 5, Msg: "auth error"})
+		return
+	}
+	id, err := strconv.ParseInt(c.Query("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusOK, ginx.Result{Code: 4, Msg: "params error"})
+		return
+	}
+	e, data, err := handler.svc.Download(c, claims.Uid, id)
+	switch err {
+	case nil:
+		handler.attachment(c, e, data)
+	case service.ErrExportNotFound:
+		c.JSON(http.StatusOK, ginx.Result{Code: 4, Msg: "export not found"})
+	case service.ErrExportNotReady:
+		c.JSON(http.StatusOK, ginx.Result{Code: 4, Msg: "export not ready"})
+	default:
+		handler.l.Error("下载导出文件失败", logger.Int64("export id", id), logger.Error(err))
+		c.JSON(http.StatusOK, ginx.Result{Code: 5, Msg: "failed"})
+	}
+}
+
+// Import 上传的文件放在表单的file字段中，支持markdown的zip、单个.md文件以及导出的json
+func (handler *ArticleTransferHandler) Import(c *gin.Context, claims *UserClaims) (ginx.Result, error) {
+	// 在解析表单之前限制请求体的大小，避免读取超大的请求，表单的其他部分预留1MB
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize+1<<20)
+	fh, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ginx.Result{Code: 4, Msg: "file too large"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	if fh.Size > maxImportSize {
+		return ginx.Result{Code: 4, Msg: "file too large"}, nil
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxImportSize))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+
+	res, err := handler.svc.Import(c, claims.Uid, fh.Filename, data)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok", Data: slice.Map(res, func(idx int, src domain.ImportResult) ImportResultVO {
+			return ImportResultVO{Name: src.Name, Title: src.Title, ID: src.ID, Error: src.Error}
+		})}, nil
+	case service.ErrInvalidBundle:
+		return ginx.Result{Code: 4, Msg: "invalid file"}, nil
+	case service.ErrTooManyImports:
+		return ginx.Result{Code: 4, Msg: "too many articles"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *ArticleTransferHandler) attachment(c *gin.Context, e domain.ArticleExport, data []byte) {
+	setAttachmentHeader(c, e.Format)
+	c.Data(http.StatusOK, e.Format.ContentType(), data)
+}
+
+func setAttachmentHeader(c *gin.Context, format domain.ExportFormat) {
+	name := "articles-" + time.Now().Format("20060102") + format.Ext()
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+}
+
+// attachmentWriter 第一次写入时才设置下载的响应头，没有写入时还可以返回JSON
+type attachmentWriter struct {
+	c       *gin.Context
+	format  domain.ExportFormat
+	written bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	if !w.written {
+		w.written = true
+		setAttachmentHeader(w.c, w.format)
+		w.c.Header("Content-Type", w.format.ContentType())
+		w.c.Status(http.StatusOK)
+	}
+	return w.c.Writer.Write(p)
+}
+
+func (handler *ArticleTransferHandler) toExportVO(e domain.ArticleExport) ExportVO {
+	vo := ExportVO{
+		ID:     e.ID,
+		Format: string(e.Format),
+		Status: e.Status.String(),
+		Count:  e.Count,
+		Size:   e.Size,
+		Error:  e.Error,
+		CTime:  e.CTime.Format(time.DateTime),
+	}
+	if e.Status == domain.ExportStatusDone {
+		vo.DownloadURL = "/articles/export/download?id=" + strconv.FormatInt(e.ID, 10)
+		vo.ExpireAt = e.UTime.Add(domain.ArticleExportTTL).Format(time.DateTime)
+	}
+	return vo
+}
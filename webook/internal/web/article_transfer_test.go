@@ -0,0 +1,199 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"io"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/logger"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestArticleTransferHandler_Export(t *testing.T) {
+	testCases := []struct {
+		name  string
+		query string
+
+		mock func(ctrl *gomock.Controller) service.ArticleTransferService
+
+		wantCode int
+		// wantBody 下载的文件内容，为空时响应是JSON
+		wantBody        string
+		wantContentType string
+		wantRes         Result
+	}{
+		{
+			name:  "直接下载",
+			query: "format=json",
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Export(gomock.Any(), int64(2001), domain.ExportFormatJSON, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, uid int64, format domain.ExportFormat, w io.Writer) (domain.ArticleExport, error) {
+						_, err := io.WriteString(w, `{"version":1}`)
+						return domain.ArticleExport{Uid: uid, Format: format, Status: domain.ExportStatusDone}, err
+					})
+				return svc
+			},
+			wantCode:        http.StatusOK,
+			wantBody:        `{"version":1}`,
+			wantContentType: domain.ExportFormatJSON.ContentType(),
+		},
+		{
+			name: "创建后台任务",
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Export(gomock.Any(), int64(2001), domain.ExportFormatMarkdown, gomock.Any()).
+					Return(domain.ArticleExport{ID: 10, Format: domain.ExportFormatMarkdown,
+						Status: domain.ExportStatusPending, CTime: time.UnixMilli(1700000000000)}, nil)
+				return svc
+			},
+			wantCode: http.StatusAccepted,
+			wantRes: Result{Msg: "ok", Data: map[string]any{
+				"id": float64(10), "format": "markdown", "status": domain.ExportStatusPending.String(),
+				"c_time": time.UnixMilli(1700000000000).Format(time.DateTime),
+			}},
+		},
+		{
+			name:  "不支持的格式",
+			query: "format=pdf",
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Export(gomock.Any(), int64(2001), domain.ExportFormat("pdf"), gomock.Any()).
+					Return(domain.ArticleExport{}, service.ErrInvalidExportFormat)
+				return svc
+			},
+			wantCode: http.StatusOK,
+			wantRes:  Result{Code: 4, Msg: "invalid export format"},
+		},
+		{
+			name: "写入之前失败",
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Export(gomock.Any(), int64(2001), domain.ExportFormatMarkdown, gomock.Any()).
+					Return(domain.ArticleExport{}, errors.New("mock db error"))
+				return svc
+			},
+			wantCode: http.StatusOK,
+			wantRes:  Result{Code: 5, Msg: "failed"},
+		},
+		{
+			name:  "写入之后失败，不能再返回JSON",
+			query: "format=json",
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Export(gomock.Any(), int64(2001), domain.ExportFormatJSON, gomock.Any()).
+					DoAndReturn(func(ctx context.Context, uid int64, format domain.ExportFormat, w io.Writer) (domain.ArticleExport, error) {
+						_, _ = io.WriteString(w, `{"version"`)
+						return domain.ArticleExport{}, errors.New("mock write error")
+					})
+				return svc
+			},
+			wantCode:        http.StatusOK,
+			wantBody:        `{"version"`,
+			wantContentType: domain.ExportFormatJSON.ContentType(),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", &UserClaims{Uid: 2001})
+			})
+			NewArticleTransferHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, "/articles/export?"+tc.query, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			if tc.wantBody != "" {
+				assert.Equal(t, tc.wantBody, resp.Body.String())
+				assert.Equal(t, tc.wantContentType, resp.Header().Get("Content-Type"))
+				assert.Contains(t, resp.Header().Get("Content-Disposition"), "attachment")
+				return
+			}
+			var res Result
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestArticleTransferHandler_Import(t *testing.T) {
+	testCases := []struct {
+		name string
+		size int
+
+		mock func(ctrl *gomock.Controller) service.ArticleTransferService
+
+		wantRes Result
+	}{
+		{
+			name: "导入成功",
+			size: 10,
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				svc := svcmocks.NewMockArticleTransferService(ctrl)
+				svc.EXPECT().Import(gomock.Any(), int64(2001), "a.md", bytes.Repeat([]byte("a"), 10)).
+					Return([]domain.ImportResult{{Name: "a.md", Title: "a", ID: 1}}, nil)
+				return svc
+			},
+			wantRes: Result{Msg: "ok"},
+		},
+		{
+			name: "请求体超过限制，不读取剩余的部分",
+			size: maxImportSize + 2<<20,
+			mock: func(ctrl *gomock.Controller) service.ArticleTransferService {
+				return svcmocks.NewMockArticleTransferService(ctrl)
+			},
+			wantRes: Result{Code: 4, Msg: "file too large"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", &UserClaims{Uid: 2001})
+			})
+			NewArticleTransferHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			fw, err := mw.CreateFormFile("file", "a.md")
+			require.NoError(t, err)
+			_, err = fw.Write(bytes.Repeat([]byte("a"), tc.size))
+			require.NoError(t, err)
+			require.NoError(t, mw.Close())
+			req, err := http.NewRequest(http.MethodPost, "/articles/import", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			var res Result
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, tc.wantRes.Code, res.Code)
+			assert.Equal(t, tc.wantRes.Msg, res.Msg)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// Delete 将文章移入回收站
+func (handler *ArticleHandler) Delete(c *gin.Context, req TrashReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Delete(c, domain.Article{
+		ID:     req.ID,
+		Author: domain.Author{ID: claims.Uid},
+	})
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+// ListTrash 按照删除时间倒序查询回收站中的文章
+func (handler *ArticleHandler) ListTrash(c *gin.Context, req TrashListReq, claims *UserClaims) (ginx.Result, error) {
+	if req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	arts, err := handler.svc.ListTrash(c, claims.Uid, req.Offset, pageLimit(req.Limit))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{
+		Msg: "ok",
+		Data: slice.Map(arts, func(idx int, src domain.Article) ArticleVO {
+			vo := handler.toListVO(src)
+			vo.DTime = src.DTime.Format(time.DateTime)
+			vo.ExpireAt = src.DTime.Add(domain.ArticleTrashTTL).Format(time.DateTime)
+			return vo
+		}),
+	}, nil
+}
+
+// Restore 将回收站中的文章恢复成草稿
+func (handler *ArticleHandler) Restore(c *gin.Context, req TrashReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Restore(c, domain.Article{
+		ID:     req.ID,
+		Author: domain.Author{ID: claims.Uid},
+	})
+	return handler.trashResult(err)
+}
+
+// Purge 彻底删除回收站中的文章
+func (handler *ArticleHandler) Purge(c *gin.Context, req TrashReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Purge(c, domain.Article{
+		ID:     req.ID,
+		Author: domain.Author{ID: claims.Uid},
+	})
+	return handler.trashResult(err)
+}
+
+func (handler *ArticleHandler) trashResult(err error) (ginx.Result, error) {
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrArticleNotInTrash:
+		return ginx.Result{Code: 4, Msg: "article not in trash"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
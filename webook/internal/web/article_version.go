@@ -0,0 +1,37 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+)
+
+// 修改已有的文章时必须带上读取草稿时拿到的版本号，草稿在这之后被其他请求（比如另一个浏览器标签页）修改过时，
+// 返回版本号冲突和服务端当前的草稿，客户端合并后带上新的版本号重新提交。
+// 保存、发布、定时发布、提交审核成功后返回新的版本号，客户端下次修改时带上，不需要重新读取草稿。
+
+// versionMissing 修改已有的文章时没有带上版本号
+func versionMissing(id int64, version int64) bool {
+	return id > 0 && version <= 0
+}
+
+// toSavedVO 保存成功后返回文章id和新的版本号
+func toSavedVO(art domain.Article) ArticleSavedVO {
+	return ArticleSavedVO{
+		ID:      art.ID,
+		Version: art.Version,
+	}
+}
+
+// conflictResult 返回服务端当前的草稿，查询失败时只返回冲突
+func (handler *ArticleHandler) conflictResult(c *gin.Context, uid int64, articleID int64) ginx.Result {
+	res := ginx.Result{Code: 4, Msg: "version conflict"}
+	current, err := handler.svc.GetDraft(c, uid, articleID)
+	if err != nil {
+		handler.log.Error("版本冲突时查询草稿失败", logger.Int64("article id", articleID), logger.Error(err))
+		return res
+	}
+	res.Data = handler.ToVO(current)
+	return res
+}
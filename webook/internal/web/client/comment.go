@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/syncx/atomicx"
+	"google.golang.org/grpc"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"math/rand"
+)
+
+// CommentServiceClient 和InteractionServiceClient一样，按照threshold在本地调用和rpc调用之间切换。
+type CommentServiceClient struct {
+	local  intrv1.CommentServiceClient
+	remote intrv1.CommentServiceClient
+
+	threshold *atomicx.Value[int32]
+}
+
+func NewCommentServiceClient(local intrv1.CommentServiceClient, remote intrv1.CommentServiceClient, threshold int32) *CommentServiceClient {
+	return &CommentServiceClient{
+		threshold: atomicx.NewValueOf[int32](threshold),
+		local:     local,
+		remote:    remote,
+	}
+}
+
+func (client *CommentServiceClient) CreateComment(ctx context.Context, in *intrv1.CreateCommentReq, opts ...grpc.CallOption) (*intrv1.CreateCommentResp, error) {
+	return client.selectClient().CreateComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) UpdateComment(ctx context.Context, in *intrv1.UpdateCommentReq, opts ...grpc.CallOption) (*intrv1.UpdateCommentResp, error) {
+	return client.selectClient().UpdateComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) DeleteComment(ctx context.Context, in *intrv1.DeleteCommentReq, opts ...grpc.CallOption) (*intrv1.DeleteCommentResp, error) {
+	return client.selectClient().DeleteComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) PinComment(ctx context.Context, in *intrv1.PinCommentReq, opts ...grpc.CallOption) (*intrv1.PinCommentResp, error) {
+	return client.selectClient().PinComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) ListComments(ctx context.Context, in *intrv1.ListCommentsReq, opts ...grpc.CallOption) (*intrv1.ListCommentsResp, error) {
+	return client.selectClient().ListComments(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) ListReplies(ctx context.Context, in *intrv1.ListRepliesReq, opts ...grpc.CallOption) (*intrv1.ListRepliesResp, error) {
+	return client.selectClient().ListReplies(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) LikeComment(ctx context.Context, in *intrv1.LikeCommentReq, opts ...grpc.CallOption) (*intrv1.LikeCommentResp, error) {
+	return client.selectClient().LikeComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) CancelLikeComment(ctx context.Context, in *intrv1.CancelLikeCommentReq, opts ...grpc.CallOption) (*intrv1.CancelLikeCommentResp, error) {
+	return client.selectClient().CancelLikeComment(ctx, in, opts...)
+}
+
+func (client *CommentServiceClient) selectClient() intrv1.CommentServiceClient {
+	num := rand.Int31n(100)
+	if num < client.threshold.Load() {
+		return client.remote
+	}
+	return client.local
+}
+
+func (client *CommentServiceClient) UpdateThreshold(val int32) {
+	client.threshold.Store(val)
+}
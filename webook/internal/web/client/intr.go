@@ -43,6 +43,30 @@ func (client *InteractionServiceClient) Favorite(ctx context.Context, in *intrv1
 	return client.selectClient().Favorite(ctx, in, opts...)
 }
 
+func (client *InteractionServiceClient) CancelFavorite(ctx context.Context, in *intrv1.CancelFavoriteReq, opts ...grpc.CallOption) (*intrv1.CancelFavoriteResp, error) {
+	return client.selectClient().CancelFavorite(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) CreateFavoriteFolder(ctx context.Context, in *intrv1.CreateFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.CreateFavoriteFolderResp, error) {
+	return client.selectClient().CreateFavoriteFolder(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) UpdateFavoriteFolder(ctx context.Context, in *intrv1.UpdateFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.UpdateFavoriteFolderResp, error) {
+	return client.selectClient().UpdateFavoriteFolder(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) DeleteFavoriteFolder(ctx context.Context, in *intrv1.DeleteFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.DeleteFavoriteFolderResp, error) {
+	return client.selectClient().DeleteFavoriteFolder(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) ListFavoriteFolders(ctx context.Context, in *intrv1.ListFavoriteFoldersReq, opts ...grpc.CallOption) (*intrv1.ListFavoriteFoldersResp, error) {
+	return client.selectClient().ListFavoriteFolders(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) ListFavoriteItems(ctx context.Context, in *intrv1.ListFavoriteItemsReq, opts ...grpc.CallOption) (*intrv1.ListFavoriteItemsResp, error) {
+	return client.selectClient().ListFavoriteItems(ctx, in, opts...)
+}
+
 func (client *InteractionServiceClient) Get(ctx context.Context, in *intrv1.GetReq, opts ...grpc.CallOption) (*intrv1.GetResp, error) {
 	return client.selectClient().Get(ctx, in, opts...)
 }
@@ -59,6 +83,26 @@ func (client *InteractionServiceClient) GetByIDs(ctx context.Context, in *intrv1
 	return client.selectClient().GetByIDs(ctx, in, opts...)
 }
 
+func (client *InteractionServiceClient) ListLikes(ctx context.Context, in *intrv1.ListLikesReq, opts ...grpc.CallOption) (*intrv1.ListLikesResp, error) {
+	return client.selectClient().ListLikes(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) BatchCheck(ctx context.Context, in *intrv1.BatchCheckReq, opts ...grpc.CallOption) (*intrv1.BatchCheckResp, error) {
+	return client.selectClient().BatchCheck(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) GetTrend(ctx context.Context, in *intrv1.GetTrendReq, opts ...grpc.CallOption) (*intrv1.GetTrendResp, error) {
+	return client.selectClient().GetTrend(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) GetAggregatedTrend(ctx context.Context, in *intrv1.GetAggregatedTrendReq, opts ...grpc.CallOption) (*intrv1.GetAggregatedTrendResp, error) {
+	return client.selectClient().GetAggregatedTrend(ctx, in, opts...)
+}
+
+func (client *InteractionServiceClient) Delete(ctx context.Context, in *intrv1.DeleteReq, opts ...grpc.CallOption) (*intrv1.DeleteResp, error) {
+	return client.selectClient().Delete(ctx, in, opts...)
+}
+
 func (client *InteractionServiceClient) selectClient() intrv1.InteractionServiceClient {
 	num := rand.Int31n(100)
 	if num < client.threshold.Load() {
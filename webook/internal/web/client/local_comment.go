@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	grpc2 "learn_go/webook/interaction/grpc"
+)
+
+// CommentServiceAdapter 将本地的comment grpc server伪装成rpc client，
+// 业务错误和远程调用一样转换成grpc的状态码。
+type CommentServiceAdapter struct {
+	server *grpc2.CommentServiceServer
+}
+
+func NewCommentServiceAdapter(server *grpc2.CommentServiceServer) *CommentServiceAdapter {
+	return &CommentServiceAdapter{server: server}
+}
+
+func (adapter *CommentServiceAdapter) CreateComment(ctx context.Context, in *intrv1.CreateCommentReq, opts ...grpc.CallOption) (*intrv1.CreateCommentResp, error) {
+	return adapter.server.CreateComment(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) UpdateComment(ctx context.Context, in *intrv1.UpdateCommentReq, opts ...grpc.CallOption) (*intrv1.UpdateCommentResp, error) {
+	return adapter.server.UpdateComment(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) DeleteComment(ctx context.Context, in *intrv1.DeleteCommentReq, opts ...grpc.CallOption) (*intrv1.DeleteCommentResp, error) {
+	return adapter.server.DeleteComment(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) PinComment(ctx context.Context, in *intrv1.PinCommentReq, opts ...grpc.CallOption) (*intrv1.PinCommentResp, error) {
+	return adapter.server.PinComment(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) ListComments(ctx context.Context, in *intrv1.ListCommentsReq, opts ...grpc.CallOption) (*intrv1.ListCommentsResp, error) {
+	return adapter.server.ListComments(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) ListReplies(ctx context.Context, in *intrv1.ListRepliesReq, opts ...grpc.CallOption) (*intrv1.ListRepliesResp, error) {
+	return adapter.server.ListReplies(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) LikeComment(ctx context.Context, in *intrv1.LikeCommentReq, opts ...grpc.CallOption) (*intrv1.LikeCommentResp, error) {
+	return adapter.server.LikeComment(ctx, in)
+}
+
+func (adapter *CommentServiceAdapter) CancelLikeComment(ctx context.Context, in *intrv1.CancelLikeCommentReq, opts ...grpc.CallOption) (*intrv1.CancelLikeCommentResp, error) {
+	return adapter.server.CancelLikeComment(ctx, in)
+}
@@ -4,92 +4,91 @@ import (
 	"context"
 	"google.golang.org/grpc"
 	intrv1 "learn_go/webook/api/proto/gen/intr"
-	"learn_go/webook/interaction/domain"
-	"learn_go/webook/interaction/service"
+	grpc2 "learn_go/webook/interaction/grpc"
 )
 
-// InteractionServiceAdapter 将本地的interaction服务伪装成rpc client。
+// InteractionServiceAdapter 将本地的interaction grpc server伪装成rpc client，
+// 业务错误和远程调用一样转换成grpc的状态码。
 type InteractionServiceAdapter struct {
-	svc service.InteractionService
+	server *grpc2.InteractionServiceServer
 }
 
-func NewInteractionServiceAdapter(svc service.InteractionService) *InteractionServiceAdapter {
-	return &InteractionServiceAdapter{svc: svc}
+func NewInteractionServiceAdapter(server *grpc2.InteractionServiceServer) *InteractionServiceAdapter {
+	return &InteractionServiceAdapter{server: server}
 }
 
 func (adapter *InteractionServiceAdapter) View(ctx context.Context, in *intrv1.ViewReq, opts ...grpc.CallOption) (*intrv1.ViewResp, error) {
-	err := adapter.svc.View(ctx, in.GetBiz(), in.GetBizId())
-	return &intrv1.ViewResp{}, err
+	return adapter.server.View(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) Like(ctx context.Context, in *intrv1.LikeReq, opts ...grpc.CallOption) (*intrv1.LikeResp, error) {
-	err := adapter.svc.Like(ctx, in.GetUid(), in.GetBiz(), in.GetBizId())
-	return &intrv1.LikeResp{}, err
+	return adapter.server.Like(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) CancelLike(ctx context.Context, in *intrv1.CancelLikeReq, opts ...grpc.CallOption) (*intrv1.CancelLikeResp, error) {
-	err := adapter.svc.CancelLike(ctx, in.GetUid(), in.GetBiz(), in.GetBizId())
-	return &intrv1.CancelLikeResp{}, err
+	return adapter.server.CancelLike(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) Favorite(ctx context.Context, in *intrv1.FavoriteReq, opts ...grpc.CallOption) (*intrv1.FavoriteResp, error) {
-	err := adapter.svc.Favorite(ctx, in.GetUid(), in.GetFavoriteId(), in.GetBiz(), in.GetBizId())
-	return &intrv1.FavoriteResp{}, err
+	return adapter.server.Favorite(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) CancelFavorite(ctx context.Context, in *intrv1.CancelFavoriteReq, opts ...grpc.CallOption) (*intrv1.CancelFavoriteResp, error) {
+	return adapter.server.CancelFavorite(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) CreateFavoriteFolder(ctx context.Context, in *intrv1.CreateFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.CreateFavoriteFolderResp, error) {
+	return adapter.server.CreateFavoriteFolder(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) UpdateFavoriteFolder(ctx context.Context, in *intrv1.UpdateFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.UpdateFavoriteFolderResp, error) {
+	return adapter.server.UpdateFavoriteFolder(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) DeleteFavoriteFolder(ctx context.Context, in *intrv1.DeleteFavoriteFolderReq, opts ...grpc.CallOption) (*intrv1.DeleteFavoriteFolderResp, error) {
+	return adapter.server.DeleteFavoriteFolder(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) ListFavoriteFolders(ctx context.Context, in *intrv1.ListFavoriteFoldersReq, opts ...grpc.CallOption) (*intrv1.ListFavoriteFoldersResp, error) {
+	return adapter.server.ListFavoriteFolders(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) ListFavoriteItems(ctx context.Context, in *intrv1.ListFavoriteItemsReq, opts ...grpc.CallOption) (*intrv1.ListFavoriteItemsResp, error) {
+	return adapter.server.ListFavoriteItems(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) Get(ctx context.Context, in *intrv1.GetReq, opts ...grpc.CallOption) (*intrv1.GetResp, error) {
-	inter, err := adapter.svc.Get(ctx, in.GetUid(), in.GetBiz(), in.GetBizId())
-	if err != nil {
-		return nil, err
-	}
-	return &intrv1.GetResp{
-		Inter: adapter.toDTO(inter),
-	}, nil
+	return adapter.server.Get(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) Liked(ctx context.Context, in *intrv1.LikedReq, opts ...grpc.CallOption) (*intrv1.LikedResp, error) {
-	liked, err := adapter.svc.Liked(ctx, in.GetUid(), in.GetBiz(), in.GetBizId())
-	if err != nil {
-		return nil, err
-	}
-	return &intrv1.LikedResp{Liked: liked}, nil
+	return adapter.server.Liked(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) Collected(ctx context.Context, in *intrv1.CollectedReq, opts ...grpc.CallOption) (*intrv1.CollectedResp, error) {
-	collected, err := adapter.svc.Collected(ctx, in.GetUid(), in.GetBiz(), in.GetBizId())
-	if err != nil {
-		return nil, err
-	}
-	return &intrv1.CollectedResp{Collected: collected}, nil
+	return adapter.server.Collected(ctx, in)
 }
 
 func (adapter *InteractionServiceAdapter) GetByIDs(ctx context.Context, in *intrv1.GetByIDsReq, opts ...grpc.CallOption) (*intrv1.GetByIDsResp, error) {
-	m, err := adapter.svc.GetByIDs(ctx, in.GetBiz(), in.GetBizIds())
-	if err != nil {
-		return nil, err
-	}
-	res := make(map[int64]*intrv1.Interaction, len(m))
-	for i, inter := range m {
-		res[i] = adapter.toDTO(inter)
-	}
-	return &intrv1.GetByIDsResp{
-		Inters: res,
-	}, nil
-}
-
-// data transfer object
-func (adapter *InteractionServiceAdapter) toDTO(inter domain.Interaction) *intrv1.Interaction {
-	return &intrv1.Interaction{
-		Id:        inter.ID,
-		Biz:       inter.Biz,
-		BizId:     inter.BizID,
-		CTime:     inter.CTime.UnixMilli(),
-		UTime:     inter.UTime.UnixMilli(),
-		Views:     inter.Views,
-		Likes:     inter.Likes,
-		Favorites: inter.Favorites,
-
-		Liked:     inter.Liked,
-		Collected: inter.Collected,
-	}
+	return adapter.server.GetByIDs(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) ListLikes(ctx context.Context, in *intrv1.ListLikesReq, opts ...grpc.CallOption) (*intrv1.ListLikesResp, error) {
+	return adapter.server.ListLikes(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) BatchCheck(ctx context.Context, in *intrv1.BatchCheckReq, opts ...grpc.CallOption) (*intrv1.BatchCheckResp, error) {
+	return adapter.server.BatchCheck(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) GetTrend(ctx context.Context, in *intrv1.GetTrendReq, opts ...grpc.CallOption) (*intrv1.GetTrendResp, error) {
+	return adapter.server.GetTrend(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) GetAggregatedTrend(ctx context.Context, in *intrv1.GetAggregatedTrendReq, opts ...grpc.CallOption) (*intrv1.GetAggregatedTrendResp, error) {
+	return adapter.server.GetAggregatedTrend(ctx, in)
+}
+
+func (adapter *InteractionServiceAdapter) Delete(ctx context.Context, in *intrv1.DeleteReq, opts ...grpc.CallOption) (*intrv1.DeleteResp, error) {
+	return adapter.server.Delete(ctx, in)
 }
@@ -0,0 +1,67 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/pkg/cursorx"
+	"time"
+)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// cursorPayload 游标中保存的排序键，字段名尽量短，让游标短一些
+type cursorPayload struct {
+	UTime int64 `json:"t"`
+	ID    int64 `json:"i"`
+}
+
+// decodeCursor 空字符串表示第一页
+func (handler *ArticleHandler) decodeCursor(cursor string) (domain.Cursor, error) {
+	return decodeCursor(handler.cursor, cursor)
+}
+
+func decodeCursor(signer *cursorx.Signer, cursor string) (domain.Cursor, error) {
+	if cursor == "" {
+		return domain.Cursor{}, nil
+	}
+	var p cursorPayload
+	err := signer.Decode(cursor, &p)
+	if err != nil {
+		return domain.Cursor{}, err
+	}
+	return domain.Cursor{UTime: time.UnixMilli(p.UTime), ID: p.ID}, nil
+}
+
+func encodeCursor(signer *cursorx.Signer, cursor domain.Cursor) (string, error) {
+	return signer.Encode(cursorPayload{UTime: cursor.UTime.UnixMilli(), ID: cursor.ID})
+}
+
+// toPageVO 查询到的数量不足limit时说明已经是最后一页，不再返回游标
+func (handler *ArticleHandler) toPageVO(arts []domain.Article, limit int) ArticlePageVO {
+	vo := ArticlePageVO{
+		Articles: slice.Map(arts, func(idx int, src domain.Article) ArticleVO {
+			return handler.toListVO(src)
+		}),
+	}
+	if len(arts) < limit {
+		return vo
+	}
+	next, err := encodeCursor(handler.cursor, domain.CursorOf(arts[len(arts)-1]))
+	if err != nil {
+		// 只有json序列化失败才会走到这里，不影响本页的数据
+		handler.log.Error("生成分页游标失败")
+		return vo
+	}
+	vo.NextCursor = next
+	return vo
+}
+
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return defaultPageLimit
+	}
+	return min(limit, maxPageLimit)
+}
@@ -0,0 +1,134 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "learn_go/webook/api/proto/gen/intr"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// FavoriteHandler 收藏夹，收藏夹保存在interaction服务中
+type FavoriteHandler struct {
+	interSvc intrv1.InteractionServiceClient
+}
+
+func NewFavoriteHandler(interSvc intrv1.InteractionServiceClient) *FavoriteHandler {
+	return &FavoriteHandler{
+		interSvc: interSvc,
+	}
+}
+
+func (handler *FavoriteHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/favorites")
+	g.GET("/folders", ginx.WrapBodyAndClaims(handler.ListFolders))
+	g.POST("/folders/create", ginx.WrapBodyAndClaims(handler.CreateFolder))
+	g.POST("/folders/update", ginx.WrapBodyAndClaims(handler.UpdateFolder))
+	g.POST("/folders/delete", ginx.WrapBodyAndClaims(handler.DeleteFolder))
+	// 收藏夹中的收藏
+	g.GET("/items", ginx.WrapBodyAndClaims(handler.ListItems))
+}
+
+func (handler *FavoriteHandler) CreateFolder(c *gin.Context, req FavoriteFolderReq, claims *UserClaims) (ginx.Result, error) {
+	resp, err := handler.interSvc.CreateFavoriteFolder(c, &intrv1.CreateFavoriteFolderReq{
+		Uid:     claims.Uid,
+		Name:    req.Name,
+		Private: req.Private,
+	})
+	if err != nil {
+		return favoriteResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: handler.toFolderVO(0, resp.GetFolder())}, nil
+}
+
+// UpdateFolder 修改收藏夹的名称和是否私密
+func (handler *FavoriteHandler) UpdateFolder(c *gin.Context, req FavoriteFolderReq, claims *UserClaims) (ginx.Result, error) {
+	_, err := handler.interSvc.UpdateFavoriteFolder(c, &intrv1.UpdateFavoriteFolderReq{
+		Uid:     claims.Uid,
+		Id:      req.ID,
+		Name:    req.Name,
+		Private: req.Private,
+	})
+	if err != nil {
+		return favoriteResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// DeleteFolder 删除收藏夹和其中的收藏
+func (handler *FavoriteHandler) DeleteFolder(c *gin.Context, req FavoriteFolderDeleteReq, claims *UserClaims) (ginx.Result, error) {
+	_, err := handler.interSvc.DeleteFavoriteFolder(c, &intrv1.DeleteFavoriteFolderReq{
+		Uid: claims.Uid,
+		Id:  req.ID,
+	})
+	if err != nil {
+		return favoriteResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// ListFolders 查询用户的收藏夹，查看其他用户时只返回公开的收藏夹
+func (handler *FavoriteHandler) ListFolders(c *gin.Context, req FavoriteFolderListReq, claims *UserClaims) (ginx.Result, error) {
+	owner := req.Uid
+	if owner <= 0 {
+		owner = claims.Uid
+	}
+	resp, err := handler.interSvc.ListFavoriteFolders(c, &intrv1.ListFavoriteFoldersReq{
+		Uid:   claims.Uid,
+		Owner: owner,
+	})
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(resp.GetFolders(), handler.toFolderVO)}, nil
+}
+
+// ListItems 按照收藏时间倒序查询收藏夹中的收藏
+func (handler *FavoriteHandler) ListItems(c *gin.Context, req FavoriteItemListReq, claims *UserClaims) (ginx.Result, error) {
+	resp, err := handler.interSvc.ListFavoriteItems(c, &intrv1.ListFavoriteItemsReq{
+		Uid:      claims.Uid,
+		FolderId: req.FolderID,
+		MaxId:    req.MaxID,
+		Limit:    int32(pageLimit(req.Limit)),
+	})
+	if err != nil {
+		return favoriteResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(resp.GetItems(), func(idx int, src *intrv1.FavoriteItem) FavoriteItemVO {
+		return FavoriteItemVO{
+			ID:       src.GetId(),
+			FolderID: src.GetFolderId(),
+			Biz:      src.GetBiz(),
+			BizID:    src.GetBizId(),
+			CTime:    time.UnixMilli(src.GetCTime()).Format(time.DateTime),
+		}
+	})}, nil
+}
+
+func (handler *FavoriteHandler) toFolderVO(idx int, f *intrv1.FavoriteFolder) FavoriteFolderVO {
+	return FavoriteFolderVO{
+		ID:      f.GetId(),
+		Uid:     f.GetUid(),
+		Name:    f.GetName(),
+		Private: f.GetPrivate(),
+		Items:   f.GetItems(),
+		CTime:   time.UnixMilli(f.GetCTime()).Format(time.DateTime),
+		UTime:   time.UnixMilli(f.GetUTime()).Format(time.DateTime),
+	}
+}
+
+// favoriteResult 根据interaction服务返回的状态码区分业务错误，文章收藏接口也使用
+func favoriteResult(err error) (ginx.Result, error) {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return ginx.Result{Code: 4, Msg: "favorite folder not found"}, nil
+	case codes.InvalidArgument:
+		return ginx.Result{Code: 4, Msg: "invalid favorite folder"}, nil
+	case codes.ResourceExhausted:
+		return ginx.Result{Code: 4, Msg: "too many favorite folders"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
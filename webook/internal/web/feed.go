@@ -0,0 +1,65 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/cursorx"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+// FeedHandler 关注流：关注的作者发布的文章
+type FeedHandler struct {
+	svc service.FeedService
+	// 对分页游标签名
+	cursor *cursorx.Signer
+	l      logger.LoggerV2
+}
+
+func NewFeedHandler(svc service.FeedService, cursor *cursorx.Signer, l logger.LoggerV2) *FeedHandler {
+	return &FeedHandler{
+		svc:    svc,
+		cursor: cursor,
+		l:      l,
+	}
+}
+
+func (handler *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/feed", ginx.WrapBodyAndClaims(handler.Feed))
+}
+
+// Feed 按照发布时间倒序游标分页
+func (handler *FeedHandler) Feed(c *gin.Context, req ListReq, claims *UserClaims) (ginx.Result, error) {
+	cursor, err := decodeCursor(handler.cursor, req.Cursor)
+	if err != nil {
+		return ginx.Result{Code: 4, Msg: "invalid cursor"}, nil
+	}
+	limit := pageLimit(req.Limit)
+	items, err := handler.svc.Feed(c, claims.Uid, cursor, limit)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+
+	vo := FeedPageVO{
+		Items: slice.Map(items, func(idx int, src domain.FeedItem) FeedVO {
+			return FeedVO{
+				ArticleID:   src.ArticleID,
+				AuthorID:    src.AuthorID,
+				Title:       src.Title,
+				Abstract:    src.Abstract,
+				PublishedAt: src.PublishedAt.Format(time.DateTime),
+			}
+		}),
+	}
+	if len(items) == limit {
+		vo.NextCursor, err = encodeCursor(handler.cursor, domain.CursorOfFeed(items[len(items)-1]))
+		if err != nil {
+			// 只有json序列化失败才会走到这里，不影响本页的数据
+			handler.l.Error("生成分页游标失败", logger.Error(err))
+		}
+	}
+	return ginx.Result{Msg: "ok", Data: vo}, nil
+}
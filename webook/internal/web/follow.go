@@ -0,0 +1,108 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"time"
+)
+
+// FollowHandler 用户之间的关注关系
+type FollowHandler struct {
+	svc service.FollowService
+}
+
+func NewFollowHandler(svc service.FollowService) *FollowHandler {
+	return &FollowHandler{
+		svc: svc,
+	}
+}
+
+func (handler *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/users")
+	g.POST("/follow", ginx.WrapBodyAndClaims(handler.Follow))
+	g.POST("/unfollow", ginx.WrapBodyAndClaims(handler.Unfollow))
+	g.GET("/followers", ginx.WrapBodyAndClaims(handler.ListFollowers))
+	g.GET("/followees", ginx.WrapBodyAndClaims(handler.ListFollowees))
+	g.GET("/follow/statics", ginx.WrapBodyAndClaims(handler.Statics))
+}
+
+func (handler *FollowHandler) Follow(c *gin.Context, req FollowReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Follow(c, claims.Uid, req.Followee)
+	switch err {
+	case nil:
+		return ginx.Result{Msg: "ok"}, nil
+	case service.ErrFollowSelf:
+		return ginx.Result{Code: 4, Msg: "can not follow yourself"}, nil
+	case service.ErrUserNotFound:
+		return ginx.Result{Code: 4, Msg: "user not found"}, nil
+	case service.ErrTooManyFollowees:
+		return ginx.Result{Code: 4, Msg: "too many followees"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *FollowHandler) Unfollow(c *gin.Context, req FollowReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Unfollow(c, claims.Uid, req.Followee)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+// ListFollowers 按照关注时间倒序查询粉丝
+func (handler *FollowHandler) ListFollowers(c *gin.Context, req FollowListReq, claims *UserClaims) (ginx.Result, error) {
+	res, err := handler.svc.ListFollowers(c, handler.uidOf(req.Uid, claims), req.MaxID, pageLimit(req.Limit))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(res, func(idx int, src domain.FollowRelation) FollowVO {
+		return handler.toFollowVO(src.ID, src.Follower, src.CTime)
+	})}, nil
+}
+
+// ListFollowees 按照关注时间倒序查询关注的用户
+func (handler *FollowHandler) ListFollowees(c *gin.Context, req FollowListReq, claims *UserClaims) (ginx.Result, error) {
+	res, err := handler.svc.ListFollowees(c, handler.uidOf(req.Uid, claims), req.MaxID, pageLimit(req.Limit))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(res, func(idx int, src domain.FollowRelation) FollowVO {
+		return handler.toFollowVO(src.ID, src.Followee, src.CTime)
+	})}, nil
+}
+
+// Statics 查询用户的粉丝数、关注数，以及当前用户是否关注了该用户
+func (handler *FollowHandler) Statics(c *gin.Context, req FollowStaticsReq, claims *UserClaims) (ginx.Result, error) {
+	uid := handler.uidOf(req.Uid, claims)
+	statics, err := handler.svc.Statics(c, uid)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	vo := FollowStaticsVO{Followers: statics.Followers, Followees: statics.Followees}
+	if uid != claims.Uid {
+		vo.Following, err = handler.svc.Following(c, claims.Uid, uid)
+		if err != nil {
+			return ginx.Result{Code: 5, Msg: "failed"}, err
+		}
+	}
+	return ginx.Result{Msg: "ok", Data: vo}, nil
+}
+
+func (handler *FollowHandler) uidOf(uid int64, claims *UserClaims) int64 {
+	if uid <= 0 {
+		return claims.Uid
+	}
+	return uid
+}
+
+func (handler *FollowHandler) toFollowVO(id int64, uid int64, ctime time.Time) FollowVO {
+	return FollowVO{
+		ID:    id,
+		Uid:   uid,
+		CTime: ctime.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,177 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"io"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// markdownEscaper 转义文件名中会破坏Markdown链接的字符
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// MediaHandler 文章的图片和附件
+type MediaHandler struct {
+	svc service.MediaService
+	l   logger.LoggerV2
+}
+
+func NewMediaHandler(svc service.MediaService, l logger.LoggerV2) *MediaHandler {
+	return &MediaHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (handler *MediaHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/articles/media")
+	g.POST("/upload", ginx.WrapClaims(handler.Upload))
+	g.GET("/list", ginx.WrapBodyAndClaims(handler.List))
+	g.POST("/delete", ginx.WrapBodyAndClaims(handler.Delete))
+
+	// 读取文件的内容，文章中引用的就是这两个地址
+	server.GET("/media/:id", handler.Open)
+	server.GET("/media/:id/thumbnail", handler.Open)
+}
+
+// Upload 表单中article_id是文章的id，file是上传的文件
+func (handler *MediaHandler) Upload(c *gin.Context, claims *UserClaims) (ginx.Result, error) {
+	// 在解析表单之前限制请求体的大小，避免读取超大的请求，表单的其他部分预留1MB
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, domain.MaxAttachmentSize+1<<20)
+	fh, err := c.FormFile("file")
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return ginx.Result{Code: 4, Msg: "file too large"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	articleID, err := strconv.ParseInt(c.PostForm("article_id"), 10, 64)
+	if err != nil {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	if fh.Size > domain.MaxAttachmentSize {
+		return ginx.Result{Code: 4, Msg: "file too large"}, nil
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, domain.MaxAttachmentSize+1))
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+
+	m, err := handler.svc.Upload(c, claims.Uid, articleID, fh.Filename, data)
+	if err != nil {
+		return handler.mediaResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: handler.toMediaVO(0, m)}, nil
+}
+
+// List 按照上传的先后查询文章的文件
+func (handler *MediaHandler) List(c *gin.Context, req MediaListReq, claims *UserClaims) (ginx.Result, error) {
+	res, err := handler.svc.List(c, claims.Uid, req.ArticleID)
+	if err != nil {
+		return handler.mediaResult(err)
+	}
+	return ginx.Result{Msg: "ok", Data: slice.Map(res, handler.toMediaVO)}, nil
+}
+
+// Delete 删除文件，已经引用了该文件的文章不会被修改
+func (handler *MediaHandler) Delete(c *gin.Context, req MediaDeleteReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.svc.Delete(c, claims.Uid, req.ID)
+	if err != nil {
+		return handler.mediaResult(err)
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *MediaHandler) Open(c *gin.Context) {
+	claims, ok := c.MustGet("user").(*UserClaims)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	thumbnail := c.FullPath() == "/media/:id/thumbnail"
+	m, data, err := handler.svc.Open(c, claims.Uid, id, thumbnail)
+	switch err {
+	case nil:
+	case service.ErrMediaNotFound:
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	default:
+		handler.l.Error("读取文件失败", logger.Int64("id", id), logger.Error(err))
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	// 同一个id的内容不会改变，但是能否读取取决于用户和文章的状态，只允许浏览器缓存，并且不能缓存太久
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.Header("X-Content-Type-Options", "nosniff")
+	if m.Kind == domain.MediaKindAttachment {
+		c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": m.Name}))
+	}
+	c.Data(http.StatusOK, m.ContentType, data)
+}
+
+func (handler *MediaHandler) mediaResult(err error) (ginx.Result, error) {
+	switch err {
+	case service.ErrArticleNotFound:
+		return ginx.Result{Code: 4, Msg: "article not found"}, nil
+	case service.ErrNoPermission:
+		return ginx.Result{Code: 4, Msg: "no permission"}, nil
+	case service.ErrMediaNotFound:
+		return ginx.Result{Code: 4, Msg: "media not found"}, nil
+	case service.ErrUnsupportedMedia:
+		return ginx.Result{Code: 4, Msg: "unsupported media type"}, nil
+	case service.ErrMediaTooLarge:
+		return ginx.Result{Code: 4, Msg: "file too large"}, nil
+	case service.ErrTooManyMedia:
+		return ginx.Result{Code: 4, Msg: "too many files"}, nil
+	case service.ErrInvalidImage:
+		return ginx.Result{Code: 4, Msg: "invalid image"}, nil
+	default:
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+}
+
+func (handler *MediaHandler) toMediaVO(idx int, m domain.Media) MediaVO {
+	url := "/media/" + strconv.FormatInt(m.ID, 10)
+	vo := MediaVO{
+		ID:          m.ID,
+		ArticleID:   m.ArticleID,
+		Kind:        m.Kind.String(),
+		Name:        m.Name,
+		ContentType: m.ContentType,
+		Size:        m.Size,
+		Width:       m.Width,
+		Height:      m.Height,
+		URL:         url,
+		CTime:       m.CTime.Format(time.DateTime),
+	}
+	name := markdownEscaper.Replace(m.Name)
+	if m.Kind == domain.MediaKindImage {
+		vo.ThumbnailURL = url + "/thumbnail"
+		vo.Markdown = fmt.Sprintf("![%s](%s)", name, url)
+	} else {
+		vo.Markdown = fmt.Sprintf("[%s](%s)", name, url)
+	}
+	return vo
+}
@@ -0,0 +1,154 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	svcmocks "learn_go/webook/internal/service/mocks"
+	"learn_go/webook/pkg/logger"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMediaHandler_Upload(t *testing.T) {
+	testCases := []struct {
+		name string
+		size int
+
+		mock func(ctrl *gomock.Controller) service.MediaService
+
+		wantRes Result
+	}{
+		{
+			name: "上传成功",
+			size: 10,
+			mock: func(ctrl *gomock.Controller) service.MediaService {
+				svc := svcmocks.NewMockMediaService(ctrl)
+				svc.EXPECT().Upload(gomock.Any(), int64(2001), int64(1), "a.txt", gomock.Any()).
+					Return(domain.Media{ID: 10, ArticleID: 1, Kind: domain.MediaKindAttachment, Name: "a.txt"}, nil)
+				return svc
+			},
+			wantRes: Result{Msg: "ok"},
+		},
+		{
+			name: "请求体超过限制，不读取剩余的部分",
+			size: domain.MaxAttachmentSize + 2<<20,
+			mock: func(ctrl *gomock.Controller) service.MediaService {
+				return svcmocks.NewMockMediaService(ctrl)
+			},
+			wantRes: Result{Code: 4, Msg: "file too large"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", &UserClaims{Uid: 2001})
+			})
+			NewMediaHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			require.NoError(t, mw.WriteField("article_id", "1"))
+			fw, err := mw.CreateFormFile("file", "a.txt")
+			require.NoError(t, err)
+			_, err = fw.Write(bytes.Repeat([]byte("a"), tc.size))
+			require.NoError(t, err)
+			require.NoError(t, mw.Close())
+			req, err := http.NewRequest(http.MethodPost, "/articles/media/upload", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", mw.FormDataContentType())
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			var res Result
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+			assert.Equal(t, tc.wantRes.Code, res.Code)
+			assert.Equal(t, tc.wantRes.Msg, res.Msg)
+		})
+	}
+}
+
+func TestMediaHandler_Open(t *testing.T) {
+	testCases := []struct {
+		name string
+		path string
+
+		mock func(ctrl *gomock.Controller) service.MediaService
+
+		wantCode int
+		wantBody string
+	}{
+		{
+			name: "读取原图",
+			path: "/media/10",
+			mock: func(ctrl *gomock.Controller) service.MediaService {
+				svc := svcmocks.NewMockMediaService(ctrl)
+				svc.EXPECT().Open(gomock.Any(), int64(2001), int64(10), false).
+					Return(domain.Media{ID: 10, Kind: domain.MediaKindImage, ContentType: "image/png"}, []byte("png"), nil)
+				return svc
+			},
+			wantCode: http.StatusOK,
+			wantBody: "png",
+		},
+		{
+			name: "读取缩略图",
+			path: "/media/10/thumbnail",
+			mock: func(ctrl *gomock.Controller) service.MediaService {
+				svc := svcmocks.NewMockMediaService(ctrl)
+				svc.EXPECT().Open(gomock.Any(), int64(2001), int64(10), true).
+					Return(domain.Media{ID: 10, Kind: domain.MediaKindImage, ContentType: "image/png"}, []byte("thumb"), nil)
+				return svc
+			},
+			wantCode: http.StatusOK,
+			wantBody: "thumb",
+		},
+		{
+			name: "没有权限",
+			path: "/media/10",
+			mock: func(ctrl *gomock.Controller) service.MediaService {
+				svc := svcmocks.NewMockMediaService(ctrl)
+				svc.EXPECT().Open(gomock.Any(), int64(2001), int64(10), false).
+					Return(domain.Media{}, nil, service.ErrMediaNotFound)
+				return svc
+			},
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", &UserClaims{Uid: 2001})
+			})
+			NewMediaHandler(tc.mock(ctrl), logger.NewNopLogger()).RegisterRoutes(server)
+
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+			server.ServeHTTP(resp, req)
+
+			assert.Equal(t, tc.wantCode, resp.Code)
+			assert.Equal(t, tc.wantBody, resp.Body.String())
+			if tc.wantCode == http.StatusOK {
+				// 能否读取取决于用户，不能被共享的缓存保存
+				assert.Equal(t, "private, max-age=3600", resp.Header().Get("Cache-Control"))
+			}
+		})
+	}
+}
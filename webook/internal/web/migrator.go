@@ -0,0 +1,120 @@
+package web
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/migrator"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"net/http"
+)
+
+// MigratorHandler 文章存储迁移的管理接口，只有管理员可以调用
+type MigratorHandler struct {
+	// 没有开启双写时为nil
+	migrator *migrator.Migrator
+	admins   map[int64]struct{}
+	l        logger.LoggerV2
+}
+
+func NewMigratorHandler(m *migrator.Migrator, admins []int64, l logger.LoggerV2) *MigratorHandler {
+	adminSet := make(map[int64]struct{}, len(admins))
+	for _, uid := range admins {
+		adminSet[uid] = struct{}{}
+	}
+	return &MigratorHandler{
+		migrator: m,
+		admins:   adminSet,
+		l:        l,
+	}
+}
+
+func (handler *MigratorHandler) RegisterRoutes(server *gin.Engine) {
+	g := server.Group("/admin/migrator/articles", handler.checkAdmin)
+	g.GET("/status", ginx.WrapBodyAndClaims(handler.Status))
+	g.POST("/pattern", ginx.WrapBodyAndClaims(handler.UpdatePattern))
+	g.POST("/full_copy/start", ginx.WrapBodyAndClaims(handler.StartFullCopy))
+	g.POST("/full_copy/stop", ginx.WrapBodyAndClaims(handler.StopFullCopy))
+	g.POST("/aux_copy/start", ginx.WrapBodyAndClaims(handler.StartAuxCopy))
+	g.POST("/aux_copy/stop", ginx.WrapBodyAndClaims(handler.StopAuxCopy))
+	g.POST("/verify/start", ginx.WrapBodyAndClaims(handler.StartVerify))
+	g.POST("/verify/stop", ginx.WrapBodyAndClaims(handler.StopVerify))
+}
+
+func (handler *MigratorHandler) checkAdmin(c *gin.Context) {
+	claims, ok := c.MustGet("user").(*UserClaims)
+	if !ok {
+		c.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	if _, ok = handler.admins[claims.Uid]; !ok {
+		handler.l.Warn("非管理员访问迁移接口", logger.Int64("uid", claims.Uid))
+		c.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	if handler.migrator == nil {
+		c.JSON(http.StatusOK, ginx.Result{Code: 4, Msg: "migration not enabled"})
+		c.Abort()
+		return
+	}
+}
+
+func (handler *MigratorHandler) Status(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	return ginx.Result{
+		Msg: "ok",
+		Data: MigrationStatusVO{
+			Pattern: handler.migrator.Pattern(),
+			Tasks:   handler.migrator.RunningTasks(),
+		},
+	}, nil
+}
+
+func (handler *MigratorHandler) UpdatePattern(c *gin.Context, req MigrationPatternReq, claims *UserClaims) (ginx.Result, error) {
+	err := handler.migrator.UpdatePattern(c, req.Pattern)
+	if errors.Is(err, dao.ErrUnknownPattern) {
+		return ginx.Result{Code: 4, Msg: "unknown pattern"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	handler.l.Info("管理员切换双写模式", logger.Int64("uid", claims.Uid), logger.String("pattern", req.Pattern))
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *MigratorHandler) StartFullCopy(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	return handler.taskResult(handler.migrator.StartFullCopy())
+}
+
+func (handler *MigratorHandler) StopFullCopy(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	handler.migrator.Stop(migrator.TaskFullCopy)
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *MigratorHandler) StartAuxCopy(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	return handler.taskResult(handler.migrator.StartAuxCopy())
+}
+
+func (handler *MigratorHandler) StopAuxCopy(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	handler.migrator.Stop(migrator.TaskAuxCopy)
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *MigratorHandler) StartVerify(c *gin.Context, req MigrationVerifyReq, claims *UserClaims) (ginx.Result, error) {
+	return handler.taskResult(handler.migrator.StartVerify(req.Incremental, req.Since))
+}
+
+func (handler *MigratorHandler) StopVerify(c *gin.Context, req struct{}, claims *UserClaims) (ginx.Result, error) {
+	handler.migrator.Stop(migrator.TaskVerify)
+	return ginx.Result{Msg: "ok"}, nil
+}
+
+func (handler *MigratorHandler) taskResult(err error) (ginx.Result, error) {
+	if errors.Is(err, migrator.ErrTaskRunning) {
+		return ginx.Result{Code: 4, Msg: "task is running"}, nil
+	}
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{Msg: "ok"}, nil
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"learn_go/webook/internal/domain"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/ginx"
+	"learn_go/webook/pkg/logger"
+	"strings"
+	"time"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+// SearchHandler 已发布文章的全文搜索
+type SearchHandler struct {
+	svc service.SearchService
+	l   logger.LoggerV2
+}
+
+func NewSearchHandler(svc service.SearchService, l logger.LoggerV2) *SearchHandler {
+	return &SearchHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (handler *SearchHandler) RegisterRoutes(server *gin.Engine) {
+	pub := server.Group("/articles/pub")
+	pub.GET("/search", ginx.WrapBodyAndClaims(handler.Search))
+}
+
+func (handler *SearchHandler) Search(c *gin.Context, req SearchReq, claims *UserClaims) (ginx.Result, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" || req.Offset < 0 {
+		return ginx.Result{Code: 4, Msg: "params error"}, nil
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	res, err := handler.svc.Search(c, query, req.Offset, limit)
+	if err != nil {
+		return ginx.Result{Code: 5, Msg: "failed"}, err
+	}
+	return ginx.Result{
+		Msg: "ok",
+		Data: SearchVO{
+			Total: res.Total,
+			Hits: slice.Map(res.Hits, func(idx int, src domain.SearchHit) SearchHitVO {
+				return SearchHitVO{
+					ID:       src.Article.ID,
+					AuthorID: src.Article.Author.ID,
+					Title:    src.TitleHighlight,
+					Abstract: src.ContentHighlight,
+					Score:    src.Score,
+					UTime:    src.Article.UTime.Format(time.DateTime),
+				}
+			}),
+		},
+	}, nil
+}
@@ -1,24 +1,59 @@
 package web
 
-import "learn_go/webook/internal/domain"
+import (
+	"learn_go/webook/internal/domain"
+	"time"
+)
 
 const ArticleLike = 1
 const ArticleUnlike = 0
 
 type ArticleVO struct {
-	ID      int64  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	CTime   string `json:"c_time"`
-	UTime   string `json:"u_time"`
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// Content Markdown原文，只有作者查看文章时返回
+	Content string `json:"content,omitempty"`
+	// HTML、TOC、Images 渲染后的正文，只有读者查看文章时返回
+	HTML   string      `json:"html,omitempty"`
+	TOC    []TOCItemVO `json:"toc,omitempty"`
+	Images []string    `json:"images,omitempty"`
+	// Abstract 摘要，只有列表中返回
+	Abstract string `json:"abstract,omitempty"`
+	CTime    string `json:"c_time"`
+	UTime    string `json:"u_time"`
+	// 定时发布的时间，没有设置时为空
+	PublishAt string `json:"publish_at,omitempty"`
+	// DTime、ExpireAt 移入回收站的时间和彻底删除的时间，只有回收站中返回
+	DTime    string  `json:"d_time,omitempty"`
+	ExpireAt string  `json:"expire_at,omitempty"`
+	Tags     []TagVO `json:"tags"`
+	// Contributors 文章的贡献者，所有者排在第一位
+	Contributors []ContributorVO `json:"contributors,omitempty"`
+	// Version 草稿的版本号，修改文章时带上，只有作者查看文章时返回
+	Version int64 `json:"version,omitempty"`
+	// Series 文章所属的系列，只有读者查看文章时返回
+	Series *SeriesNavVO `json:"series,omitempty"`
+
+	// Likes 所有表态的总数
+	Likes int64 `json:"likes"`
+	// Reactions 每种表态的数量
+	Reactions map[string]int64 `json:"reactions,omitempty"`
+	Favorites int64            `json:"favorites"`
+	Views     int64            `json:"views"`
+	// UniqueViews 去重后的阅读数，同一个用户每天最多计一次
+	UniqueViews int64 `json:"unique_views"`
+	Comments    int64 `json:"comments"`
 
-	Likes     int64 `json:"likes"`
-	Favorites int64 `json:"favorites"`
-	Views     int64 `json:"views"`
+	// 用户是否点赞（有任意表态），Reaction是用户当前的表态
+	Liked     bool   `json:"liked"`
+	Reaction  string `json:"reaction,omitempty"`
+	Collected bool   `json:"collected"`
+}
 
-	// 用户是否点赞
-	Liked     bool `json:"liked"`
-	Collected bool `json:"collected"`
+// ArticleSavedVO 保存、发布文章后返回，Version是新的版本号，为0时表示没有校验版本号，客户端需要重新读取草稿
+type ArticleSavedVO struct {
+	ID      int64 `json:"id"`
+	Version int64 `json:"version"`
 }
 
 type FavoriteReq struct {
@@ -27,20 +62,84 @@ type FavoriteReq struct {
 	FavoriteID int64 `json:"favorite_id"`
 }
 
+type CancelFavoriteReq struct {
+	ArticleID int64 `json:"article_id"`
+}
+
+type FavoriteFolderReq struct {
+	// ID 修改时必传
+	ID      int64  `json:"id"`
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+}
+
+type FavoriteFolderDeleteReq struct {
+	ID int64 `json:"id"`
+}
+
+type FavoriteFolderListReq struct {
+	// Uid 为0时查询自己
+	Uid int64 `form:"uid"`
+}
+
+type FavoriteItemListReq struct {
+	FolderID int64 `form:"folder_id"`
+	// MaxID 上一页最后一条收藏的id，第一页不传
+	MaxID int64 `form:"max_id"`
+	Limit int   `form:"limit"`
+}
+
+type FavoriteFolderVO struct {
+	ID      int64  `json:"id"`
+	Uid     int64  `json:"uid"`
+	Name    string `json:"name"`
+	Private bool   `json:"private"`
+	Items   int64  `json:"items"`
+	CTime   string `json:"c_time"`
+	UTime   string `json:"u_time"`
+}
+
+type FavoriteItemVO struct {
+	ID       int64  `json:"id"`
+	FolderID int64  `json:"folder_id"`
+	Biz      string `json:"biz"`
+	BizID    int64  `json:"biz_id"`
+	CTime    string `json:"c_time"`
+}
+
 type LikeReq struct {
 	ArticleID int64 `json:"article_id"`
 	Action    int
+	// Reaction 表态：like、love、insightful、funny，不传时是点赞。已经有其他表态时切换过去
+	Reaction string `json:"reaction"`
 }
 
 type ListReq struct {
-	Offset int `form:"offset"`
-	Limit  int `form:"limit"`
+	// Cursor 上一页返回的next_cursor，第一页不传
+	Cursor string `form:"cursor"`
+	Limit  int    `form:"limit"`
+}
+
+type ArticlePageVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// NextCursor 为空时说明没有下一页了
+	NextCursor string `json:"next_cursor"`
+}
+
+type TOCItemVO struct {
+	Level  int    `json:"level"`
+	Text   string `json:"text"`
+	Anchor string `json:"anchor"`
 }
 
 type ArticleReq struct {
 	ID      int64  `json:"id"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
+	// Tags 不传时不修改文章的标签，传空数组时清空标签
+	Tags []string `json:"tags"`
+	// Version 读取草稿时拿到的版本号，修改已有的文章时必填
+	Version int64 `json:"version"`
 }
 
 func (req ArticleReq) toDomain(uid int64) domain.Article {
@@ -49,5 +148,504 @@ func (req ArticleReq) toDomain(uid int64) domain.Article {
 		Title:   req.Title,
 		Content: req.Content,
 		Author:  domain.Author{ID: uid},
+		Tags:    toDomainTags(req.Tags),
+		Version: req.Version,
 	}
 }
+
+func toDomainTags(names []string) []domain.Tag {
+	if names == nil {
+		return nil
+	}
+	return domain.NewTags(names)
+}
+
+type TagVO struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+type TagListReq struct {
+	// Cursor 上一页返回的next_cursor，第一页不传
+	Cursor int64 `form:"cursor"`
+	Limit  int   `form:"limit"`
+}
+
+type TagListVO struct {
+	Articles []ArticleVO `json:"articles"`
+	// NextCursor 为0时说明没有下一页了
+	NextCursor int64 `json:"next_cursor"`
+}
+
+type RevisionListReq struct {
+	ArticleID int64 `form:"article_id"`
+	Offset    int   `form:"offset"`
+	Limit     int   `form:"limit"`
+}
+
+type RevisionDiffReq struct {
+	ArticleID int64 `form:"article_id"`
+	From      int64 `form:"from"`
+	To        int64 `form:"to"`
+}
+
+const (
+	RollbackTargetDraft     = "draft"
+	RollbackTargetPublished = "published"
+)
+
+type RollbackReq struct {
+	ArticleID  int64 `json:"article_id"`
+	RevisionID int64 `json:"revision_id"`
+	// draft: 回滚草稿，published: 回滚已发布的文章
+	Target string `json:"target"`
+}
+
+// RevisionVO 版本列表中不返回内容，内容通过diff接口查看
+type RevisionVO struct {
+	ID        int64  `json:"id"`
+	ArticleID int64  `json:"article_id"`
+	Title     string `json:"title"`
+	Status    int8   `json:"status"`
+	CTime     string `json:"c_time"`
+}
+
+type DiffLineVO struct {
+	// " "：未变化，"+"：新增，"-"：删除
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type RevisionDiffVO struct {
+	From  RevisionVO   `json:"from"`
+	To    RevisionVO   `json:"to"`
+	Lines []DiffLineVO `json:"lines"`
+}
+
+type ScheduleReq struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// 定时发布的时间，毫秒时间戳
+	PublishAt int64    `json:"publish_at"`
+	Tags      []string `json:"tags"`
+	// Version 和ArticleReq.Version相同
+	Version int64 `json:"version"`
+}
+
+func (req ScheduleReq) toDomain(uid int64) domain.Article {
+	return domain.Article{
+		ID:        req.ID,
+		Title:     req.Title,
+		Content:   req.Content,
+		Author:    domain.Author{ID: uid},
+		PublishAt: time.UnixMilli(req.PublishAt),
+		Tags:      toDomainTags(req.Tags),
+		Version:   req.Version,
+	}
+}
+
+type CancelScheduleReq struct {
+	ID int64 `json:"id"`
+}
+
+type TrashReq struct {
+	ID int64 `json:"id"`
+}
+
+type TrashListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+// LikedArticleVO 用户点赞的文章
+type LikedArticleVO struct {
+	ArticleID int64  `json:"article_id"`
+	Reaction  string `json:"reaction"`
+	LikedAt   string `json:"liked_at"`
+}
+
+type LikedPageVO struct {
+	Likes []LikedArticleVO `json:"likes"`
+	// NextCursor 为空时说明没有下一页了
+	NextCursor string `json:"next_cursor"`
+}
+
+type ArticleStatesReq struct {
+	// IDs 列表页的文章id，?ids=1&ids=2，一次最多100个
+	IDs []int64 `form:"ids"`
+}
+
+// ArticleStateVO 用户是否点赞、收藏了文章
+type ArticleStateVO struct {
+	ArticleID int64 `json:"article_id"`
+	Liked     bool  `json:"liked"`
+	Collected bool  `json:"collected"`
+}
+
+type TrendReq struct {
+	ArticleID int64 `form:"article_id"`
+	// Granularity hour 或者 day，默认为day
+	Granularity string `form:"granularity"`
+	// From, To 毫秒时间戳，默认查询最近30天或者最近24小时
+	From int64 `form:"from"`
+	To   int64 `form:"to"`
+}
+
+type TrendPointVO struct {
+	Time      string `json:"time"`
+	Views     int64  `json:"views"`
+	Likes     int64  `json:"likes"`
+	Favorites int64  `json:"favorites"`
+}
+
+// DashboardVO 创作者看板，总数是作者文章的累计值，趋势是每个时间桶新增的值
+type DashboardVO struct {
+	Articles int `json:"articles"`
+	// Truncated 作者的文章超过了domain.MaxDashboardArticles，只统计了最新的文章
+	Truncated   bool           `json:"truncated"`
+	Views       int64          `json:"views"`
+	UniqueViews int64          `json:"unique_views"`
+	Likes       int64          `json:"likes"`
+	Favorites   int64          `json:"favorites"`
+	Comments    int64          `json:"comments"`
+	Trend       []TrendPointVO `json:"trend"`
+}
+
+type ContributorVO struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+type CollaboratorVO struct {
+	ArticleID int64  `json:"article_id"`
+	Uid       int64  `json:"uid"`
+	Name      string `json:"name,omitempty"`
+	Role      string `json:"role"`
+	// Status pending：等待接受邀请，accepted：已接受
+	Status    string `json:"status"`
+	InviterID int64  `json:"inviter_id"`
+	CTime     string `json:"c_time"`
+}
+
+type InviteReq struct {
+	ArticleID int64 `json:"article_id"`
+	Uid       int64 `json:"uid"`
+	// Role owner、editor、viewer
+	Role string `json:"role"`
+}
+
+type CollaboratorReq struct {
+	ArticleID int64 `json:"article_id"`
+	Uid       int64 `json:"uid"`
+}
+
+type CollaboratorListReq struct {
+	ArticleID int64 `form:"article_id"`
+}
+
+type InvitationReq struct {
+	ArticleID int64 `json:"article_id"`
+}
+
+type InvitationListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type SearchReq struct {
+	Query  string `form:"q"`
+	Offset int    `form:"offset"`
+	Limit  int    `form:"limit"`
+}
+
+// SearchHitVO 标题、摘要中命中的词用<em></em>标记
+type SearchHitVO struct {
+	ID       int64   `json:"id"`
+	AuthorID int64   `json:"author_id"`
+	Title    string  `json:"title"`
+	Abstract string  `json:"abstract"`
+	Score    float64 `json:"score"`
+	UTime    string  `json:"u_time"`
+}
+
+type SearchVO struct {
+	Total int64         `json:"total"`
+	Hits  []SearchHitVO `json:"hits"`
+}
+
+type MigrationPatternReq struct {
+	Pattern string `json:"pattern"`
+}
+
+type MigrationVerifyReq struct {
+	// Incremental 为false时全量校验
+	Incremental bool `json:"incremental"`
+	// Since 增量校验的起始时间，毫秒时间戳
+	Since int64 `json:"since"`
+}
+
+type MigrationStatusVO struct {
+	Pattern string   `json:"pattern"`
+	Tasks   []string `json:"tasks"`
+}
+
+type ReviewVO struct {
+	ID          int64  `json:"id"`
+	ArticleID   int64  `json:"article_id"`
+	AuthorID    int64  `json:"author_id"`
+	SubmitterID int64  `json:"submitter_id"`
+	Title       string `json:"title"`
+	ReviewerID  int64  `json:"reviewer_id,omitempty"`
+	// Status pending、approved、rejected、canceled
+	Status  string `json:"status"`
+	Comment string `json:"comment,omitempty"`
+	CTime   string `json:"c_time"`
+	UTime   string `json:"u_time"`
+}
+
+type ReviewListReq struct {
+	ArticleID int64 `form:"article_id"`
+	Offset    int   `form:"offset"`
+	Limit     int   `form:"limit"`
+}
+
+type ReviewQueueReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type ReviewDecisionReq struct {
+	ReviewID int64 `json:"review_id"`
+	// Comment 驳回时必填
+	Comment string `json:"comment"`
+}
+
+type AutosaveReq struct {
+	ID      int64  `json:"id"`
+	Title   string `json:"title"`
+	Content string `json:"content"`
+	// Version 客户端编辑时基于的草稿版本号
+	Version int64 `json:"version"`
+}
+
+type AutosaveRecoverReq struct {
+	ID int64 `form:"id"`
+}
+
+type SeriesVO struct {
+	ID          int64  `json:"id"`
+	AuthorID    int64  `json:"author_id"`
+	AuthorName  string `json:"author_name,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Articles 按照在系列中的顺序排列，列表中只返回文章的id
+	Articles []SeriesArticleVO `json:"articles"`
+	CTime    string            `json:"c_time"`
+	UTime    string            `json:"u_time"`
+}
+
+type SeriesArticleVO struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title,omitempty"`
+}
+
+// SeriesNavVO 已发布的文章在系列中的导航
+type SeriesNavVO struct {
+	ID    int64  `json:"id"`
+	Title string `json:"title"`
+	// Index 从1开始
+	Index int `json:"index"`
+	Total int `json:"total"`
+	// Prev、Next 没有上一篇、下一篇时为空
+	Prev *SeriesArticleVO `json:"prev,omitempty"`
+	Next *SeriesArticleVO `json:"next,omitempty"`
+}
+
+type SeriesReq struct {
+	// ID 修改系列时必填
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type SeriesIDReq struct {
+	ID int64 `json:"id"`
+}
+
+type SeriesDetailReq struct {
+	ID int64 `form:"id"`
+}
+
+type SeriesListReq struct {
+	Offset int `form:"offset"`
+	Limit  int `form:"limit"`
+}
+
+type SeriesArticleReq struct {
+	SeriesID  int64 `json:"series_id"`
+	ArticleID int64 `json:"article_id"`
+}
+
+type SeriesReorderReq struct {
+	SeriesID int64 `json:"series_id"`
+	// ArticleIDs 系列中全部文章的新顺序
+	ArticleIDs []int64 `json:"article_ids"`
+}
+
+type ExportVO struct {
+	ID     int64  `json:"id"`
+	Format string `json:"format"`
+	// Status pending、running、done、failed
+	Status string `json:"status"`
+	Count  int    `json:"count,omitempty"`
+	Size   int64  `json:"size,omitempty"`
+	Error  string `json:"error,omitempty"`
+	// DownloadURL、ExpireAt 只有完成的任务才有，过期后导出文件被删除
+	DownloadURL string `json:"download_url,omitempty"`
+	ExpireAt    string `json:"expire_at,omitempty"`
+	CTime       string `json:"c_time"`
+}
+
+type ExportTaskReq struct {
+	ID int64 `form:"id"`
+}
+
+// ImportResultVO Error为空表示导入成功，ID是新建的草稿
+type ImportResultVO struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	ID    int64  `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type MediaVO struct {
+	ID        int64 `json:"id"`
+	ArticleID int64 `json:"article_id"`
+	// Kind image、attachment
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	ContentType  string `json:"content_type"`
+	Size         int64  `json:"size"`
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// Markdown 在文章中引用该文件的Markdown
+	Markdown string `json:"markdown"`
+	CTime    string `json:"c_time"`
+}
+
+type MediaListReq struct {
+	ArticleID int64 `form:"article_id"`
+}
+
+type MediaDeleteReq struct {
+	ID int64 `json:"id"`
+}
+
+type CommentVO struct {
+	ID        int64 `json:"id"`
+	Uid       int64 `json:"uid"`
+	ArticleID int64 `json:"article_id"`
+	// RootID 根评论为0
+	RootID     int64  `json:"root_id"`
+	ParentID   int64  `json:"parent_id"`
+	ReplyToUid int64  `json:"reply_to_uid,omitempty"`
+	Content    string `json:"content"`
+	Likes      int64  `json:"likes"`
+	Replies    int64  `json:"replies"`
+	Pinned     bool   `json:"pinned"`
+	Liked      bool   `json:"liked"`
+	CTime      string `json:"c_time"`
+	UTime      string `json:"u_time"`
+}
+
+type CommentListReq struct {
+	ArticleID int64 `form:"article_id"`
+	// Sort time、popular，默认按时间倒序
+	Sort string `form:"sort"`
+	// Cursor 按时间排序时是上一页最后一条评论的id，按热度排序时是已经查询的数量，第一页不传
+	Cursor int64 `form:"cursor"`
+	Limit  int   `form:"limit"`
+}
+
+type CommentReplyListReq struct {
+	ArticleID int64 `form:"article_id"`
+	RootID    int64 `form:"root_id"`
+	// MinID 上一页最后一条回复的id，第一页不传
+	MinID int64 `form:"min_id"`
+	Limit int   `form:"limit"`
+}
+
+type CommentReq struct {
+	ArticleID int64 `json:"article_id"`
+	// ParentID 回复的评论，为0时发表根评论
+	ParentID int64  `json:"parent_id"`
+	Content  string `json:"content"`
+}
+
+type CommentUpdateReq struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
+}
+
+type CommentDeleteReq struct {
+	ID int64 `json:"id"`
+}
+
+type CommentPinReq struct {
+	ArticleID int64 `json:"article_id"`
+	ID        int64 `json:"id"`
+	Pinned    bool  `json:"pinned"`
+}
+
+type CommentLikeReq struct {
+	ID int64 `json:"id"`
+	// Like 为false时取消点赞
+	Like bool `json:"like"`
+}
+
+type FollowReq struct {
+	Followee int64 `json:"followee"`
+}
+
+type FollowListReq struct {
+	// Uid 为0时查询自己
+	Uid int64 `form:"uid"`
+	// MaxID 上一页最后一条记录的id，第一页不传
+	MaxID int64 `form:"max_id"`
+	Limit int   `form:"limit"`
+}
+
+type FollowVO struct {
+	ID    int64  `json:"id"`
+	Uid   int64  `json:"uid"`
+	CTime string `json:"c_time"`
+}
+
+type FollowStaticsReq struct {
+	Uid int64 `form:"uid"`
+}
+
+type FollowStaticsVO struct {
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+	// Following 当前用户是否关注了该用户
+	Following bool `json:"following"`
+}
+
+type FeedVO struct {
+	ArticleID   int64  `json:"article_id"`
+	AuthorID    int64  `json:"author_id"`
+	Title       string `json:"title"`
+	Abstract    string `json:"abstract"`
+	PublishedAt string `json:"published_at"`
+}
+
+type FeedPageVO struct {
+	Items []FeedVO `json:"items"`
+	// NextCursor 为空时说明没有下一页了
+	NextCursor string `json:"next_cursor"`
+}
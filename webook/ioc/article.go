@@ -0,0 +1,153 @@
+package ioc
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/bwmarrin/snowflake"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/internal/migrator"
+	"learn_go/webook/internal/repository/cache"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/internal/web"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/objectstore"
+	"time"
+)
+
+// 文章、标签、版本记录、协作者、审核记录、系列、导出任务、媒体必须使用同一种存储，mongo中标签是内嵌在文章中的。
+// 双写迁移只迁移文章，附属数据在迁移期间继续使用mysql，切换到mongo之前通过aux_copy任务复制到mongo。
+// 没有复制附属数据就切换到mongo会启动失败。
+
+func InitArticleDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node,
+	doubleWrite *dao.DoubleWriteArticleDao, store objectstore.ObjectStore) dao.ArticleDao {
+	switch articleStorage() {
+	case articleStorageMongo:
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+		defer cancel()
+		err := dao.NewArticleAuxCopier(db, mdb).CheckAuxCopied(ctx)
+		if err != nil {
+			panic(err)
+		}
+		return dao.NewMongoArticleDao(mdb, node)
+	case articleStorageDoubleWrite:
+		return doubleWrite
+	case articleStorageOSS:
+		return dao.NewArticleS3DAO(db, store)
+	default:
+		return dao.NewArticleDao(db)
+	}
+}
+
+func InitTagDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.TagDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoTagDao(mdb, node)
+	}
+	return dao.NewTagDao(db)
+}
+
+func InitArticleRevisionDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.ArticleRevisionDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoArticleRevisionDao(mdb, node)
+	}
+	return dao.NewArticleRevisionDao(db)
+}
+
+func InitArticleCollaboratorDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.ArticleCollaboratorDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoArticleCollaboratorDao(mdb, node)
+	}
+	return dao.NewArticleCollaboratorDao(db)
+}
+
+func InitArticleReviewDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.ArticleReviewDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoArticleReviewDao(mdb, node)
+	}
+	return dao.NewArticleReviewDao(db)
+}
+
+func InitSeriesDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.SeriesDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoSeriesDao(mdb, node)
+	}
+	return dao.NewSeriesDao(db)
+}
+
+func InitArticleExportDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.ArticleExportDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoArticleExportDao(mdb, node)
+	}
+	return dao.NewArticleExportDao(db)
+}
+
+func InitMediaDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node) dao.MediaDao {
+	if articleStorage() == articleStorageMongo {
+		return dao.NewMongoMediaDao(mdb, node)
+	}
+	return dao.NewMediaDao(db)
+}
+
+// InitArticleCacheWatcher 只有mongo存储需要监听change stream，mysql存储返回nil
+func InitArticleCacheWatcher(mdb *mongo.Database, articleCache cache.ArticleCache, l logger.LoggerV2) *event.CacheWatcher {
+	if articleStorage() != articleStorageMongo {
+		return nil
+	}
+	return event.NewCacheWatcher(mdb, articleCache, l)
+}
+
+// InitDoubleWriteArticleDao 双写的src是mysql，dst是mongo，没有开启双写时返回nil
+func InitDoubleWriteArticleDao(db *gorm.DB, mdb *mongo.Database, node *snowflake.Node,
+	l logger.LoggerV2) *dao.DoubleWriteArticleDao {
+	if articleStorage() != articleStorageDoubleWrite {
+		return nil
+	}
+	pattern := viper.GetString("migration.pattern")
+	if pattern == "" {
+		pattern = dao.PatternSrcOnly
+	}
+	src := dao.NewArticleDao(db).(dao.ArticleMigrationDao)
+	dst := dao.NewMongoArticleDao(mdb, node).(dao.ArticleMigrationDao)
+	res, err := dao.NewDoubleWriteArticleDao(src, dst, pattern, l)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// InitArticleMigrator 启动后台同步双写模式，migration.syncInterval 同步的间隔，默认5秒
+func InitArticleMigrator(db *gorm.DB, mdb *mongo.Database, cmd redis.Cmdable, doubleWrite *dao.DoubleWriteArticleDao,
+	producer migration.Producer, l logger.LoggerV2) *migrator.Migrator {
+	if doubleWrite == nil {
+		return nil
+	}
+	interval := viper.GetDuration("migration.syncInterval")
+	if interval <= 0 {
+		interval = time.Second * 5
+	}
+	m := migrator.NewMigrator(doubleWrite, dao.NewArticleAuxCopier(db, mdb), producer, cmd, l)
+	go m.SyncPattern(context.Background(), interval)
+	return m
+}
+
+func InitMigrationFixConsumer(client sarama.Client, doubleWrite *dao.DoubleWriteArticleDao,
+	l logger.LoggerV2) *migration.FixConsumer {
+	if doubleWrite == nil {
+		return nil
+	}
+	return migration.NewFixConsumer(client, doubleWrite.Src(), doubleWrite.Dst(), l)
+}
+
+// InitMigratorHandler admin.uids 配置可以调用管理接口的用户
+func InitMigratorHandler(m *migrator.Migrator, l logger.LoggerV2) *web.MigratorHandler {
+	var admins []int64
+	err := viper.UnmarshalKey("admin.uids", &admins)
+	if err != nil {
+		panic(err)
+	}
+	return web.NewMigratorHandler(m, admins, l)
+}
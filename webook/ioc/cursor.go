@@ -0,0 +1,21 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"learn_go/webook/pkg/cursorx"
+	"os"
+)
+
+// InitCursorSigner 分页游标的签名密钥，多个实例之间需要使用相同的密钥。
+// 密钥是机密信息，从cursor.keyEnv指定的环境变量（默认WEBOOK_CURSOR_KEY）中读取，没有配置时启动失败
+func InitCursorSigner() *cursorx.Signer {
+	env := viper.GetString("cursor.keyEnv")
+	if env == "" {
+		env = "WEBOOK_CURSOR_KEY"
+	}
+	key := os.Getenv(env)
+	if key == "" {
+		panic("分页游标的签名密钥未配置，环境变量：" + env)
+	}
+	return cursorx.NewSigner([]byte(key))
+}
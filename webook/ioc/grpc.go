@@ -6,13 +6,39 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	intrv1 "learn_go/webook/api/proto/gen/intr"
-	"learn_go/webook/interaction/service"
+	grpc2 "learn_go/webook/interaction/grpc"
 	"learn_go/webook/internal/web/client"
+	"slices"
+	"sync"
 )
 
 // 构建grpc server、client
 
-func NewGRPCInteractionServiceClient(service service.InteractionService) intrv1.InteractionServiceClient {
+var (
+	configListenersMu sync.Mutex
+	configListeners   []func(e fsnotify.Event)
+	configDispatcher  sync.Once
+)
+
+// onConfigChange viper只保留最后一次OnConfigChange注册的回调，
+// 所以只向viper注册一个回调，由它依次通知这里注册的所有回调
+func onConfigChange(fn func(e fsnotify.Event)) {
+	configListenersMu.Lock()
+	configListeners = append(configListeners, fn)
+	configListenersMu.Unlock()
+	configDispatcher.Do(func() {
+		viper.OnConfigChange(func(e fsnotify.Event) {
+			configListenersMu.Lock()
+			listeners := slices.Clone(configListeners)
+			configListenersMu.Unlock()
+			for _, l := range listeners {
+				l(e)
+			}
+		})
+	})
+}
+
+func NewGRPCInteractionServiceClient(server *grpc2.InteractionServiceServer) intrv1.InteractionServiceClient {
 	type config struct {
 		Addr      string
 		Secure    bool
@@ -39,12 +65,53 @@ func NewGRPCInteractionServiceClient(service service.InteractionService) intrv1.
 	// rpc
 	remote := intrv1.NewInteractionServiceClient(cc)
 
-	local := client.NewInteractionServiceAdapter(service)
+	local := client.NewInteractionServiceAdapter(server)
 	interSvcClient := client.NewInteractionServiceClient(local, remote, cfg.Threshold)
 
 	// 当配置文件变动时重新加载配置
-	viper.OnConfigChange(func(e fsnotify.Event) {
-		interSvcClient.UpdateThreshold(cfg.Threshold)
+	onConfigChange(func(e fsnotify.Event) {
+		var newCfg config
+		if err := viper.UnmarshalKey("grpc.client.addr", &newCfg); err == nil {
+			interSvcClient.UpdateThreshold(newCfg.Threshold)
+		}
 	})
 	return interSvcClient
 }
+
+func NewGRPCCommentServiceClient(server *grpc2.CommentServiceServer) intrv1.CommentServiceClient {
+	type config struct {
+		Addr      string
+		Secure    bool
+		Threshold int32
+	}
+
+	var cfg config
+	err := viper.UnmarshalKey("grpc.client.addr", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	options := make([]grpc.DialOption, 0, 1)
+	if cfg.Secure {
+		// 添加https相关配置
+	} else {
+		options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	cc, err := grpc.Dial(cfg.Addr, options...)
+	if err != nil {
+		panic(err)
+	}
+
+	// 评论和interaction部署在同一个服务中
+	remote := intrv1.NewCommentServiceClient(cc)
+	local := client.NewCommentServiceAdapter(server)
+	commentSvcClient := client.NewCommentServiceClient(local, remote, cfg.Threshold)
+
+	onConfigChange(func(e fsnotify.Event) {
+		var newCfg config
+		if err := viper.UnmarshalKey("grpc.client.addr", &newCfg); err == nil {
+			commentSvcClient.UpdateThreshold(newCfg.Threshold)
+		}
+	})
+	return commentSvcClient
+}
@@ -1,7 +1,9 @@
 package ioc
 
 import (
+	"context"
 	"github.com/robfig/cron/v3"
+	"learn_go/webook/internal/domain"
 	"learn_go/webook/internal/job"
 	"learn_go/webook/internal/service"
 	"learn_go/webook/pkg/logger"
@@ -26,3 +28,33 @@ func InitCron(l logger.LoggerV2, rankingJob *job.RankingJob) *cron.Cron {
 	}
 	return c
 }
+
+// InitScheduler 初始化基于mysql抢占的任务调度器，并注册各个执行器和任务
+func InitScheduler(svc service.JobService, publishExecutor *job.ScheduledPublishExecutor,
+	trashExecutor *job.ArticleTrashPurgeExecutor, sweepExecutor *job.ArticleContentSweepExecutor,
+	autosaveExecutor *job.ArticleAutosaveFlushExecutor, exportExecutor *job.ArticleExportExecutor,
+	mediaExecutor *job.MediaSweepExecutor, l logger.LoggerV2) *job.Scheduler {
+	scheduler := job.NewScheduler(svc, l)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
+	defer cancel()
+
+	jobs := []domain.Job{publishExecutor.Job(), trashExecutor.Job(), autosaveExecutor.Job(), exportExecutor.Job(),
+		mediaExecutor.Job()}
+	scheduler.Register(publishExecutor.Name(), publishExecutor)
+	scheduler.Register(trashExecutor.Name(), trashExecutor)
+	scheduler.Register(autosaveExecutor.Name(), autosaveExecutor)
+	scheduler.Register(exportExecutor.Name(), exportExecutor)
+	scheduler.Register(mediaExecutor.Name(), mediaExecutor)
+	// 文章内容存储在对象存储中时才需要清理
+	if sweepExecutor != nil {
+		scheduler.Register(sweepExecutor.Name(), sweepExecutor)
+		jobs = append(jobs, sweepExecutor.Job())
+	}
+	for _, j := range jobs {
+		err := svc.AddJob(ctx, j)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return scheduler
+}
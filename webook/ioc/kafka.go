@@ -3,6 +3,10 @@ package ioc
 import (
 	"github.com/IBM/sarama"
 	"github.com/spf13/viper"
+	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/event/feed"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/pkg/saramax"
 )
 
 func NewSaramaConfig() *sarama.Config {
@@ -31,3 +35,34 @@ func NewSyncProducer(saramaCfg *sarama.Config) sarama.SyncProducer {
 	}
 	return producer
 }
+
+// NewConsumerClient 构建消息队列的消费者客户端
+func NewConsumerClient(saramaCfg *sarama.Config) sarama.Client {
+	type Config struct {
+		Addrs []string
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("kafka", &cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	client, err := sarama.NewClient(cfg.Addrs, saramaCfg)
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+func NewConsumers(searchConsumer *event.SearchSyncConsumer, feedConsumer *feed.PublishedConsumer,
+	cacheWatcher *event.CacheWatcher, fixConsumer *migration.FixConsumer) []saramax.Consumer {
+	consumers := []saramax.Consumer{searchConsumer, feedConsumer}
+	// 下面的消费者只在对应的存储模式下开启
+	if cacheWatcher != nil {
+		consumers = append(consumers, cacheWatcher)
+	}
+	if fixConsumer != nil {
+		consumers = append(consumers, fixConsumer)
+	}
+	return consumers
+}
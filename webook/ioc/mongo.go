@@ -0,0 +1,74 @@
+package ioc
+
+import (
+	"context"
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/pkg/logger"
+	"time"
+)
+
+const (
+	articleStorageMySQL = "mysql"
+	articleStorageMongo = "mongo"
+	// articleStorageDoubleWrite 从mysql迁移到mongo期间同时使用两种存储
+	articleStorageDoubleWrite = "double_write"
+	// articleStorageOSS 元数据存储在mysql中，线上库的内容存储在对象存储中
+	articleStorageOSS = "oss"
+)
+
+// articleStorage 文章的存储，默认使用mysql
+func articleStorage() string {
+	storage := viper.GetString("article.storage")
+	if storage == "" {
+		return articleStorageMySQL
+	}
+	return storage
+}
+
+// NewMongoDB 文章使用mongo存储或者双写时才会连接mongo，否则返回nil
+func NewMongoDB(log logger.LoggerV2) *mongo.Database {
+	if storage := articleStorage(); storage != articleStorageMongo && storage != articleStorageDoubleWrite {
+		return nil
+	}
+	type Config struct {
+		URI      string
+		Database string
+	}
+	var config Config
+	err := viper.UnmarshalKey("mongo", &config)
+	if err != nil {
+		panic(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(config.URI))
+	if err != nil {
+		panic(err)
+	}
+	err = client.Ping(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+	log.Info("mongo connected", logger.String("database", config.Database))
+
+	db := client.Database(config.Database)
+	err = dao.InitCollections(db)
+	if err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// NewSnowflakeNode mongo中文章的id由雪花算法生成，每个实例的node需要不同
+func NewSnowflakeNode() *snowflake.Node {
+	node, err := snowflake.NewNode(viper.GetInt64("snowflake.node"))
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
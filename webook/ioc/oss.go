@@ -0,0 +1,95 @@
+package ioc
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	"learn_go/webook/internal/job"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"learn_go/webook/pkg/objectstore"
+)
+
+// InitObjectStore 文章使用oss存储时才会初始化，否则返回nil
+func InitObjectStore() objectstore.ObjectStore {
+	if articleStorage() != articleStorageOSS {
+		return nil
+	}
+	return newObjectStore("oss")
+}
+
+// InitArticleTransferService 导出文件使用单独的对象存储，和文章内容的存储互不影响
+func InitArticleTransferService(artSvc service.ArticleService, repo article.ExportRepository,
+	l logger.LoggerV2) service.ArticleTransferService {
+	return service.NewArticleTransferService(artSvc, repo, newObjectStore("export"), l)
+}
+
+// InitMediaService 图片和附件使用单独的对象存储，配置项和oss相同
+func InitMediaService(artSvc service.ArticleService, repo article.MediaRepository,
+	l logger.LoggerV2) service.MediaService {
+	return service.NewMediaService(artSvc, repo, newObjectStore("media"), l)
+}
+
+// newObjectStore 根据key对应的配置初始化对象存储
+func newObjectStore(key string) objectstore.ObjectStore {
+	type Config struct {
+		// Backend s3、minio、local
+		Backend     string
+		Bucket      string
+		Prefix      string
+		Compression string
+		// s3、minio使用
+		Endpoint  string
+		Region    string
+		AccessKey string `mapstructure:"access_key"`
+		SecretKey string `mapstructure:"secret_key"`
+		// local使用
+		Root string
+	}
+	var config Config
+	err := viper.UnmarshalKey(key, &config)
+	if err != nil {
+		panic(err)
+	}
+
+	var store objectstore.ObjectStore
+	switch config.Backend {
+	case "s3":
+		sess, err := session.NewSession(&aws.Config{
+			Region:      aws.String(config.Region),
+			Endpoint:    aws.String(config.Endpoint),
+			Credentials: credentials.NewStaticCredentials(config.AccessKey, config.SecretKey, ""),
+		})
+		if err != nil {
+			panic(err)
+		}
+		store = objectstore.NewS3Store(s3.New(sess), config.Bucket)
+	case "minio":
+		store, err = objectstore.NewMinIOStore(config.Endpoint, config.AccessKey, config.SecretKey, config.Bucket)
+	case "local":
+		store, err = objectstore.NewLocalStore(config.Root)
+	default:
+		panic("unknown oss backend: " + config.Backend)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	store, err = objectstore.WithCompression(objectstore.WithPrefix(store, config.Prefix), config.Compression)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+func InitArticleContentSweepExecutor(db *gorm.DB, store objectstore.ObjectStore, l logger.LoggerV2) *job.ArticleContentSweepExecutor {
+	if store == nil {
+		return nil
+	}
+	return job.NewArticleContentSweepExecutor(dao.NewArticleContentSweeper(db, store), l)
+}
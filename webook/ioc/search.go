@@ -0,0 +1,67 @@
+package ioc
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/spf13/viper"
+	"gorm.io/gorm"
+	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/repository/article"
+	"learn_go/webook/internal/repository/dao"
+	"learn_go/webook/internal/repository/search"
+	"learn_go/webook/internal/service"
+	"learn_go/webook/pkg/logger"
+	"os"
+)
+
+const (
+	searchIndexMemory = "memory"
+	searchIndexMySQL  = "mysql"
+)
+
+func searchIndexType() string {
+	typ := viper.GetString("search.index")
+	if typ == "" {
+		return searchIndexMySQL
+	}
+	return typ
+}
+
+// InitArticleIndex 根据配置search.index选择索引的实现，默认使用mysql
+func InitArticleIndex(db *gorm.DB) search.ArticleIndex {
+	switch typ := searchIndexType(); typ {
+	case searchIndexMemory:
+		return search.NewMemoryArticleIndex()
+	case searchIndexMySQL:
+		return search.NewMySQLArticleIndex(dao.NewArticleSearchDao(db))
+	default:
+		panic("unknown search index: " + typ)
+	}
+}
+
+// InitSearchService 进程内的索引在启动时是空的，需要在后台从线上库重建
+func InitSearchService(index search.ArticleIndex, articleRepo article.ArticleRepository, l logger.LoggerV2) service.SearchService {
+	svc := service.NewSearchService(index, articleRepo, l)
+	if _, ok := index.(*search.MemoryArticleIndex); ok {
+		go func() {
+			err := svc.Reindex(context.Background())
+			if err != nil {
+				l.Error("重建进程内搜索索引失败", logger.Error(err))
+			}
+		}()
+	}
+	return svc
+}
+
+func NewSearchSyncConsumer(client sarama.Client, index search.ArticleIndex, l logger.LoggerV2) *event.SearchSyncConsumer {
+	group := "group:search"
+	if searchIndexType() == searchIndexMemory {
+		// 每个实例都要维护一份完整的索引，需要独立的消费组
+		hostname, err := os.Hostname()
+		if err != nil {
+			panic(err)
+		}
+		group = group + ":" + hostname
+	}
+	return event.NewSearchSyncConsumer(client, index, group, l)
+}
@@ -15,6 +15,13 @@ func InitGin(
 	smsHandler *web.SMSHandler,
 	userHandler *web.UserHandler,
 	oauthWechatHandler *web.OAuth2WechatHandler,
+	searchHandler *web.SearchHandler,
+	migratorHandler *web.MigratorHandler,
+	transferHandler *web.ArticleTransferHandler,
+	mediaHandler *web.MediaHandler,
+	followHandler *web.FollowHandler,
+	feedHandler *web.FeedHandler,
+	favoriteHandler *web.FavoriteHandler,
 ) *gin.Engine {
 
 	server := gin.Default()
@@ -24,6 +31,13 @@ func InitGin(
 	smsHandler.RegisterRoutes(server)
 	userHandler.RegisterRoutes(server)
 	oauthWechatHandler.RegisterRoutes(server)
+	searchHandler.RegisterRoutes(server)
+	migratorHandler.RegisterRoutes(server)
+	transferHandler.RegisterRoutes(server)
+	mediaHandler.RegisterRoutes(server)
+	followHandler.RegisterRoutes(server)
+	feedHandler.RegisterRoutes(server)
+	favoriteHandler.RegisterRoutes(server)
 
 	h := web.ObserveHandler{}
 	h.RegisterHandler(server)
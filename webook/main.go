@@ -18,8 +18,18 @@ func main() {
 
 	app := InitApp("test-template")
 
+	// 启动消费者服务
+	for _, consumer := range app.consumers {
+		err := consumer.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
+
 	// 启动定时任务
 	app.cron.Start()
+	schedulerCtx, schedulerCancel := context.WithCancel(context.Background())
+	go app.scheduler.Schedule(schedulerCtx)
 
 	// 启动监控服务
 	initPrometheus()
@@ -31,6 +41,7 @@ func main() {
 	app.server.Run(":9130")
 
 	// 等web服务器关闭后，再关系其他服务
+	schedulerCancel()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
@@ -0,0 +1,73 @@
+package cursorx
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+/*
+不透明的分页游标。
+
+游标的内容是上一页最后一条记录的排序键，例如(utime, id)。直接暴露给前端会有俩个问题：
+	1. 前端会依赖游标的格式，后端无法再调整排序键
+	2. 前端可以伪造任意的游标
+
+所以游标 = base64(json(payload)) + "." + base64(hmac-sha256(payload))，前端只能原样传回，
+服务端解码时校验签名，签名不一致返回ErrInvalidCursor。
+*/
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// 签名只需要防伪造，截断到16字节可以让游标短一些
+const sigLen = 16
+
+type Signer struct {
+	key []byte
+}
+
+func NewSigner(key []byte) *Signer {
+	return &Signer{
+		key: key,
+	}
+}
+
+// Encode 将payload编码为签名后的游标
+func (s *Signer) Encode(payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(data) + "." + enc.EncodeToString(s.sign(data)), nil
+}
+
+// Decode 校验游标的签名，并解码到payload中
+func (s *Signer) Decode(cursor string, payload any) error {
+	dataStr, sigStr, ok := strings.Cut(cursor, ".")
+	if !ok {
+		return ErrInvalidCursor
+	}
+	enc := base64.RawURLEncoding
+	data, err := enc.DecodeString(dataStr)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	sig, err := enc.DecodeString(sigStr)
+	if err != nil || !hmac.Equal(sig, s.sign(data)) {
+		return ErrInvalidCursor
+	}
+	if json.Unmarshal(data, payload) != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func (s *Signer) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(data)
+	return mac.Sum(nil)[:sigLen]
+}
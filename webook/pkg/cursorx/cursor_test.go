@@ -0,0 +1,70 @@
+package cursorx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+type payload struct {
+	UTime int64 `json:"t"`
+	ID    int64 `json:"i"`
+}
+
+func TestSigner(t *testing.T) {
+	signer := NewSigner([]byte("test key"))
+	cursor, err := signer.Encode(payload{UTime: 1700000000000, ID: 10})
+	require.NoError(t, err)
+	// 用另一个游标的内容拼上原来的签名
+	other, err := signer.Encode(payload{UTime: 1, ID: 1})
+	require.NoError(t, err)
+	_, sig, _ := strings.Cut(cursor, ".")
+	data, _, _ := strings.Cut(other, ".")
+
+	testCases := []struct {
+		name   string
+		signer *Signer
+		cursor string
+
+		want    payload
+		wantErr error
+	}{
+		{
+			name:   "解码成功",
+			signer: signer,
+			cursor: cursor,
+			want:   payload{UTime: 1700000000000, ID: 10},
+		},
+		{
+			name:    "篡改内容",
+			signer:  signer,
+			cursor:  data + "." + sig,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "密钥不一致",
+			signer:  NewSigner([]byte("other key")),
+			cursor:  cursor,
+			wantErr: ErrInvalidCursor,
+		},
+		{
+			name:    "格式错误",
+			signer:  signer,
+			cursor:  "abc",
+			wantErr: ErrInvalidCursor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var p payload
+			err := tc.signer.Decode(tc.cursor, &p)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tc.want, p)
+		})
+	}
+}
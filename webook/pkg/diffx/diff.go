@@ -0,0 +1,97 @@
+package diffx
+
+import "strings"
+
+/*
+行级别的文本diff。
+
+基于最长公共子序列（LCS）实现：
+	1. 将新旧文本按行切分
+	2. 通过动态规划求出俩个序列的LCS
+	3. 回溯LCS，不在LCS中的旧行为删除，不在LCS中的新行为新增
+
+时间、空间复杂度都是O(n*m)。文章的行数一般只有几百行，这个开销是可以接受的。
+*/
+
+type Op int8
+
+const (
+	OpEqual Op = iota
+	OpInsert
+	OpDelete
+)
+
+func (op Op) String() string {
+	switch op {
+	case OpInsert:
+		return "+"
+	case OpDelete:
+		return "-"
+	default:
+		return " "
+	}
+}
+
+// Line diff结果中的一行
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines 对比src、dst俩段文本，返回从src变化到dst的行级diff
+func Lines(src, dst string) []Line {
+	return Diff(Split(src), Split(dst))
+}
+
+// Diff 对比俩个字符串序列
+func Diff(src, dst []string) []Line {
+	n, m := len(src), len(dst)
+
+	// lcs[i][j] 表示 src[i:]、dst[j:] 的最长公共子序列长度
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if src[i] == dst[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	res := make([]Line, 0, max(n, m))
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case src[i] == dst[j]:
+			res = append(res, Line{Op: OpEqual, Text: src[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			res = append(res, Line{Op: OpDelete, Text: src[i]})
+			i++
+		default:
+			res = append(res, Line{Op: OpInsert, Text: dst[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		res = append(res, Line{Op: OpDelete, Text: src[i]})
+	}
+	for ; j < m; j++ {
+		res = append(res, Line{Op: OpInsert, Text: dst[j]})
+	}
+	return res
+}
+
+// Split 将文本按行切分，兼容\r\n换行
+func Split(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,66 @@
+package diffx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  string
+		dst  string
+
+		want []Line
+	}{
+		{
+			name: "内容相同",
+			src:  "a\nb",
+			dst:  "a\nb\n",
+			want: []Line{{Op: OpEqual, Text: "a"}, {Op: OpEqual, Text: "b"}},
+		},
+		{
+			name: "新增行",
+			src:  "a\nc",
+			dst:  "a\nb\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpInsert, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "删除行",
+			src:  "a\nb\nc",
+			dst:  "a\nc",
+			want: []Line{
+				{Op: OpEqual, Text: "a"},
+				{Op: OpDelete, Text: "b"},
+				{Op: OpEqual, Text: "c"},
+			},
+		},
+		{
+			name: "修改行",
+			src:  "title\nold line\nend",
+			dst:  "title\nnew line\nend",
+			want: []Line{
+				{Op: OpEqual, Text: "title"},
+				{Op: OpDelete, Text: "old line"},
+				{Op: OpInsert, Text: "new line"},
+				{Op: OpEqual, Text: "end"},
+			},
+		},
+		{
+			name: "从空文本开始",
+			src:  "",
+			dst:  "a\r\nb",
+			want: []Line{{Op: OpInsert, Text: "a"}, {Op: OpInsert, Text: "b"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Lines(tc.src, tc.dst))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package imagex
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+/*
+图片的解码和缩略图。
+
+只依赖标准库，支持jpeg、png、gif（只取第一帧）。
+缩放使用区域平均：目标图片的每个像素取原图中对应矩形区域内像素的平均值，
+缩小的倍数较大时也不会像最近邻采样那样出现明显的锯齿。
+*/
+
+var ErrTooLarge = errors.New("image too large")
+
+// Decode 解码图片，像素数超过maxPixels时返回ErrTooLarge，不会解码像素数据
+func Decode(data []byte, maxPixels int) (image.Image, string, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, "", ErrTooLarge
+	}
+	return image.Decode(bytes.NewReader(data))
+}
+
+// Thumbnail 等比缩放到宽高都不超过size，图片本身不超过size时返回false
+func Thumbnail(src image.Image, size int) (image.Image, bool) {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return src, false
+	}
+	tw, th := size, size
+	if w > h {
+		th = max(h*size/w, 1)
+	} else {
+		tw = max(w*size/h, 1)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*h/th, b.Min.Y+(y+1)*h/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*w/tw, b.Min.X+(x+1)*w/tw
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst, true
+}
+
+// Encode 编码缩略图。jpeg仍然编码成jpeg，其余格式编码成png以保留透明度，返回数据和Content-Type
+func Encode(img image.Image, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	if format == "jpeg" {
+		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85})
+		return buf.Bytes(), "image/jpeg", err
+	}
+	err := png.Encode(&buf, img)
+	return buf.Bytes(), "image/png", err
+}
@@ -0,0 +1,93 @@
+package imagex
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestThumbnail(t *testing.T) {
+	testCases := []struct {
+		name   string
+		width  int
+		height int
+		size   int
+
+		wantWidth  int
+		wantHeight int
+		wantOK     bool
+	}{
+		{
+			name:       "横图",
+			width:      800,
+			height:     400,
+			size:       100,
+			wantWidth:  100,
+			wantHeight: 50,
+			wantOK:     true,
+		},
+		{
+			name:       "竖图",
+			width:      300,
+			height:     900,
+			size:       100,
+			wantWidth:  33,
+			wantHeight: 100,
+			wantOK:     true,
+		},
+		{
+			name:       "细长的图片至少保留1个像素",
+			width:      1000,
+			height:     2,
+			size:       100,
+			wantWidth:  100,
+			wantHeight: 1,
+			wantOK:     true,
+		},
+		{
+			name:       "图片比缩略图小",
+			width:      80,
+			height:     60,
+			size:       100,
+			wantWidth:  80,
+			wantHeight: 60,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := image.NewRGBA(image.Rect(0, 0, tc.width, tc.height))
+			for y := 0; y < tc.height; y++ {
+				for x := 0; x < tc.width; x++ {
+					src.SetRGBA(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+				}
+			}
+			dst, ok := Thumbnail(src, tc.size)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantWidth, dst.Bounds().Dx())
+			assert.Equal(t, tc.wantHeight, dst.Bounds().Dy())
+			// 纯色图片缩放后颜色不变
+			assert.Equal(t, color.RGBAModel.Convert(src.At(0, 0)), color.RGBAModel.Convert(dst.At(0, 0)))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 100))))
+
+	img, format, err := Decode(buf.Bytes(), 10000)
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, 100, img.Bounds().Dx())
+
+	_, _, err = Decode(buf.Bytes(), 9999)
+	assert.Equal(t, ErrTooLarge, err)
+
+	_, _, err = Decode([]byte("not an image"), 10000)
+	assert.Error(t, err)
+}
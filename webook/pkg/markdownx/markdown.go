@@ -0,0 +1,174 @@
+package markdownx
+
+import (
+	"bytes"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"html"
+	"strconv"
+	"strings"
+)
+
+/*
+Markdown渲染成安全的HTML。
+
+使用goldmark解析CommonMark以及GFM的删除线、表格、任务列表，渲染结果再经过bluemonday过滤：
+	原文中的HTML被转义后原样展示，不会作为HTML输出；
+	链接只允许http、https、mailto以及相对地址，图片只允许http、https以及相对地址；
+	链接统一加上rel="nofollow noreferrer"。
+解析的同时从语法树中提取目录、图片和纯文本，标题的锚点由Slug生成，重复的标题加上"-1"、"-2"后缀。
+*/
+
+// Heading 目录中的一个标题
+type Heading struct {
+	Level int
+	Text  string
+	// Anchor 标题的id，可以通过#anchor跳转
+	Anchor string
+}
+
+// Document 渲染的结果
+type Document struct {
+	HTML string
+	// TOC 按照出现顺序排列的标题
+	TOC []Heading
+	// Images 文章中引用的图片地址，去重后按照出现顺序排列
+	Images []string
+	// Text 去掉格式后的纯文本，不包含代码块，用于生成摘要
+	Text string
+}
+
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.Strikethrough, extension.Table, extension.TaskList),
+	goldmark.WithRendererOptions(
+		renderer.WithNodeRenderers(util.Prioritized(rawHTMLEscaper{}, 100)),
+	),
+)
+
+// rawHTMLEscaper 原文中的HTML转义后输出，替换goldmark默认的"raw HTML omitted"
+type rawHTMLEscaper struct{}
+
+func (rawHTMLEscaper) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHTMLBlock, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		node := n.(*ast.HTMLBlock)
+		var b strings.Builder
+		lines := node.Lines()
+		for i := 0; i < lines.Len(); i++ {
+			line := lines.At(i)
+			b.Write(line.Value(source))
+		}
+		if node.HasClosure() {
+			b.Write(node.ClosureLine.Value(source))
+		}
+		_, _ = w.WriteString("<p>" + html.EscapeString(strings.TrimRight(b.String(), "\n")) + "</p>\n")
+		return ast.WalkContinue, nil
+	})
+	reg.Register(ast.KindRawHTML, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			segs := n.(*ast.RawHTML).Segments
+			for i := 0; i < segs.Len(); i++ {
+				seg := segs.At(i)
+				_, _ = w.WriteString(html.EscapeString(string(seg.Value(source))))
+			}
+		}
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// Render 渲染Markdown
+func Render(src string) Document {
+	source := []byte(src)
+	root := md.Parser().Parse(text.NewReader(source))
+
+	var (
+		doc     Document
+		textBuf strings.Builder
+		seen    = make(map[string]struct{})
+		anchors = make(map[string]int)
+	)
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch node := n.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			if entering {
+				title := nodeText(node, source)
+				anchor := uniqueAnchor(anchors, Slug(title))
+				node.SetAttributeString("id", []byte(anchor))
+				doc.TOC = append(doc.TOC, Heading{Level: node.Level, Text: title, Anchor: anchor})
+			}
+		case *ast.Image:
+			if entering && allowedURL(node.Destination, true) {
+				dst := string(node.Destination)
+				if _, ok := seen[dst]; !ok {
+					seen[dst] = struct{}{}
+					doc.Images = append(doc.Images, dst)
+				}
+			}
+		case *ast.Text:
+			if entering {
+				textBuf.Write(node.Segment.Value(source))
+				if node.SoftLineBreak() || node.HardLineBreak() {
+					textBuf.WriteByte('\n')
+				}
+			}
+		case *ast.String:
+			if entering {
+				textBuf.Write(node.Value)
+			}
+		case *ast.AutoLink:
+			if entering {
+				textBuf.Write(node.Label(source))
+			}
+		}
+		// 块级元素之间用换行分隔
+		if !entering && n.Type() == ast.TypeBlock && n.HasChildren() && n.FirstChild().Type() == ast.TypeInline {
+			textBuf.WriteByte('\n')
+		}
+		return ast.WalkContinue, nil
+	})
+
+	var buf bytes.Buffer
+	// 语法树是刚刚解析出来的，渲染不会失败
+	_ = md.Renderer().Render(&buf, source, root)
+	doc.HTML = policy.Sanitize(buf.String())
+	doc.Text = strings.TrimSpace(textBuf.String())
+	return doc
+}
+
+// nodeText 标题等节点去掉格式后的文本
+func nodeText(n ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(n, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch node := n.(type) {
+		case *ast.Text:
+			b.Write(node.Segment.Value(source))
+		case *ast.String:
+			b.Write(node.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
+
+func uniqueAnchor(anchors map[string]int, anchor string) string {
+	if anchor == "" {
+		anchor = "section"
+	}
+	cnt := anchors[anchor]
+	anchors[anchor] = cnt + 1
+	if cnt == 0 {
+		return anchor
+	}
+	return anchor + "-" + strconv.Itoa(cnt)
+}
@@ -0,0 +1,95 @@
+package markdownx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		wantHTML string
+	}{
+		{
+			name:     "标题和段落",
+			src:      "# Hello *World*\n\nfirst line\nsecond line",
+			wantHTML: "<h1 id=\"hello-world\">Hello <em>World</em></h1>\n<p>first line\nsecond line</p>\n",
+		},
+		{
+			name:     "行内格式",
+			src:      "**bold** _em_ ~~del~~ `a<b>` snake_case_name",
+			wantHTML: "<p><strong>bold</strong> <em>em</em> <del>del</del> <code>a&lt;b&gt;</code> snake_case_name</p>\n",
+		},
+		{
+			name:     "硬换行",
+			src:      "line1  \nline2\\\nline3",
+			wantHTML: "<p>line1<br>\nline2<br>\nline3</p>\n",
+		},
+		{
+			name:     "围栏代码块",
+			src:      "```go\nfmt.Println(\"<hi>\")\n```",
+			wantHTML: "<pre><code class=\"language-go\">fmt.Println(&#34;&lt;hi&gt;&#34;)\n</code></pre>\n",
+		},
+		{
+			name:     "列表",
+			src:      "- a\n- b\n  - c\n\n3. x\n4. y",
+			wantHTML: "<ul>\n<li>a</li>\n<li>b\n<ul>\n<li>c</li>\n</ul>\n</li>\n</ul>\n<ol start=\"3\">\n<li>x</li>\n<li>y</li>\n</ol>\n",
+		},
+		{
+			name:     "引用和分隔线",
+			src:      "> quote\nlazy\n\n---",
+			wantHTML: "<blockquote>\n<p>quote\nlazy</p>\n</blockquote>\n<hr>\n",
+		},
+		{
+			name:     "链接和图片",
+			src:      "[site](https://a.com \"t\") ![logo](/img/a.png) <https://b.com>",
+			wantHTML: "<p><a href=\"https://a.com\" title=\"t\" rel=\"nofollow noreferrer\">site</a> <img src=\"/img/a.png\" alt=\"logo\"> <a href=\"https://b.com\" rel=\"nofollow noreferrer\">https://b.com</a></p>\n",
+		},
+		{
+			name:     "转义内嵌的HTML",
+			src:      "<script>alert(1)</script>\n\n<img src=x onerror=alert(1)>",
+			wantHTML: "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n<p>&lt;img src=x onerror=alert(1)&gt;</p>\n",
+		},
+		{
+			name: "过滤危险的地址",
+			src:  "[x](javascript:alert(1)) [y](JavaScript:alert) ![z](data:image/png;base64,xx) [m](mailto:a@b.com)",
+			// 不安全的地址整个去掉，地址中的括号不会截断链接
+			wantHTML: "<p>x y <img alt=\"z\"> <a href=\"mailto:a@b.com\" rel=\"nofollow noreferrer\">m</a></p>\n",
+		},
+		{
+			name:     "地址中成对的括号",
+			src:      "[Go](https://en.wikipedia.org/wiki/Go_(language)) end",
+			wantHTML: "<p><a href=\"https://en.wikipedia.org/wiki/Go_(language)\" rel=\"nofollow noreferrer\">Go</a> end</p>\n",
+		},
+		{
+			name:     "属性中的引号",
+			src:      "![a\" onload=\"x](https://a.com/\"onerror=\"x)",
+			wantHTML: "<p><img src=\"https://a.com/%22onerror=%22x\"></p>\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := Render(tc.src)
+			assert.Equal(t, tc.wantHTML, doc.HTML)
+		})
+	}
+}
+
+func TestRender_Extract(t *testing.T) {
+	src := "# 介绍\n\n这是**第一段**，包含![图](https://a.com/1.png)。\n\n## 介绍\n\n```\ncode is not text\n```\n\n![again](https://a.com/1.png) ![two](./2.png)\n\n### Go 语言"
+	doc := Render(src)
+	assert.Equal(t, []Heading{
+		{Level: 1, Text: "介绍", Anchor: "介绍"},
+		{Level: 2, Text: "介绍", Anchor: "介绍-1"},
+		{Level: 3, Text: "Go 语言", Anchor: "go-语言"},
+	}, doc.TOC)
+	assert.Equal(t, []string{"https://a.com/1.png", "./2.png"}, doc.Images)
+	assert.Equal(t, "介绍\n这是第一段，包含图。\n介绍\nagain two\nGo 语言", doc.Text)
+}
+
+func TestAbstract(t *testing.T) {
+	assert.Equal(t, "a b c", Abstract("a\n  b\tc", 10))
+	assert.Equal(t, "一二三...", Abstract("一二三四五", 3))
+	assert.Equal(t, "ab...", Abstract("ab cd", 3))
+}
@@ -0,0 +1,64 @@
+package markdownx
+
+import (
+	"github.com/microcosm-cc/bluemonday"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// policy 在UGC的基础上允许标题的锚点、有序列表的起始序号和代码块的语言，链接加上nofollow
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("id").OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowAttrs("type", "checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.RequireNoReferrerOnLinks(true)
+	return p
+}()
+
+// allowedURL 图片只允许http、https以及相对地址，链接还允许mailto，和policy一致
+func allowedURL(raw []byte, image bool) bool {
+	u := strings.TrimSpace(string(raw))
+	colon := strings.IndexByte(u, ':')
+	if colon < 0 || strings.ContainsAny(u[:colon], "/?#") {
+		// 没有scheme的相对地址
+		return true
+	}
+	switch strings.ToLower(u[:colon]) {
+	case "http", "https":
+		return true
+	case "mailto":
+		return !image
+	}
+	return false
+}
+
+// Slug 标题转换成锚点：保留字母、数字（包括中文），空白转换成"-"，其余字符去掉
+func Slug(text string) string {
+	var b strings.Builder
+	lastDash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			lastDash = false
+		case (unicode.IsSpace(r) || r == '-' || r == '_') && !lastDash && b.Len() > 0:
+			b.WriteByte('-')
+			lastDash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// Abstract 从纯文本中截取摘要，空白合并成一个空格，超过maxLen个字符时截断并加上"..."
+func Abstract(text string, maxLen int) string {
+	words := strings.Fields(text)
+	runes := []rune(strings.Join(words, " "))
+	if len(runes) <= maxLen {
+		return string(runes)
+	}
+	return strings.TrimRight(string(runes[:maxLen]), " ") + "..."
+}
@@ -0,0 +1,99 @@
+package objectstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"github.com/klauspost/compress/zstd"
+	"io"
+)
+
+const (
+	CompressionNone = "none"
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+)
+
+// CompressedStore 写入时按照配置的算法压缩。
+// 读取时根据数据的magic number判断压缩算法，修改配置后之前写入的对象仍然可以正常读取。
+type CompressedStore struct {
+	ObjectStore
+	compression string
+}
+
+func WithCompression(store ObjectStore, compression string) (ObjectStore, error) {
+	switch compression {
+	case "", CompressionNone:
+		return &CompressedStore{ObjectStore: store, compression: CompressionNone}, nil
+	case CompressionGzip, CompressionZstd:
+		return &CompressedStore{ObjectStore: store, compression: compression}, nil
+	default:
+		return nil, errors.New("unknown compression: " + compression)
+	}
+}
+
+func (s *CompressedStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	compressed, err := compress(s.compression, data)
+	if err != nil {
+		return err
+	}
+	return s.ObjectStore.Put(ctx, key, compressed, contentType)
+}
+
+func (s *CompressedStore) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := s.ObjectStore.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return decompress(data)
+}
+
+func compress(compression string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch compression {
+	case CompressionGzip:
+		w = gzip.NewWriter(&buf)
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(&buf)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+	default:
+		return data, nil
+	}
+	_, err := w.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	return buf.Bytes(), err
+}
+
+func decompress(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case bytes.HasPrefix(data, zstdMagic):
+		r, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	default:
+		return data, nil
+	}
+}
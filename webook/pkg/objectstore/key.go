@@ -0,0 +1,14 @@
+package objectstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentKey 内容寻址的key：相同的内容总是得到相同的key，内容改变后key也会改变。
+// 用sha256的前两个字符分目录，避免本地文件系统单个目录下的文件过多。
+func ContentKey(namespace string, data []byte) string {
+	sum := sha256.Sum256(data)
+	h := hex.EncodeToString(sum[:])
+	return namespace + "/" + h[:2] + "/" + h
+}
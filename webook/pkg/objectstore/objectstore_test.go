@@ -0,0 +1,98 @@
+package objectstore
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestLocalStore(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prefix      string
+		compression string
+	}{
+		{name: "不压缩", compression: CompressionNone},
+		{name: "gzip", prefix: "webook", compression: CompressionGzip},
+		{name: "zstd", prefix: "webook/", compression: CompressionZstd},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			local, err := NewLocalStore(t.TempDir())
+			require.NoError(t, err)
+			store, err := WithCompression(WithPrefix(local, tc.prefix), tc.compression)
+			require.NoError(t, err)
+
+			content := []byte(strings.Repeat("hello webook ", 100))
+			key := ContentKey("articles", content)
+			err = store.Put(ctx, key, content, "text/plain")
+			require.NoError(t, err)
+			err = store.Put(ctx, "other/1", []byte("other"), "text/plain")
+			require.NoError(t, err)
+
+			data, err := store.Get(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, content, data)
+
+			// 压缩后的数据比原文小
+			rawKey := key
+			if tc.prefix != "" {
+				rawKey = strings.TrimSuffix(tc.prefix, "/") + "/" + key
+			}
+			raw, err := local.Get(ctx, rawKey)
+			require.NoError(t, err)
+			if tc.compression != CompressionNone {
+				assert.Less(t, len(raw), len(content))
+			}
+
+			var keys []string
+			err = store.List(ctx, "articles/", func(obj Object) error {
+				keys = append(keys, obj.Key)
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []string{key}, keys)
+
+			obj, err := store.Stat(ctx, key)
+			require.NoError(t, err)
+			assert.Equal(t, key, obj.Key)
+			assert.False(t, obj.ModTime.IsZero())
+
+			err = store.Delete(ctx, key)
+			require.NoError(t, err)
+			_, err = store.Get(ctx, key)
+			assert.Equal(t, ErrObjectNotFound, err)
+			_, err = store.Stat(ctx, key)
+			assert.Equal(t, ErrObjectNotFound, err)
+			// 删除不存在的对象不报错
+			assert.NoError(t, store.Delete(ctx, key))
+		})
+	}
+}
+
+func TestCompressedStore_ReadOldObjects(t *testing.T) {
+	ctx := context.Background()
+	local, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	gz, err := WithCompression(local, CompressionGzip)
+	require.NoError(t, err)
+	err = gz.Put(ctx, "a", []byte("content"), "text/plain")
+	require.NoError(t, err)
+
+	// 切换压缩算法后仍然可以读取之前写入的对象
+	zs, err := WithCompression(local, CompressionZstd)
+	require.NoError(t, err)
+	data, err := zs.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), data)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	local, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	err = local.Put(context.Background(), "../escape", []byte("x"), "text/plain")
+	assert.Error(t, err)
+}
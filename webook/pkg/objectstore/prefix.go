@@ -0,0 +1,47 @@
+package objectstore
+
+import (
+	"context"
+	"strings"
+)
+
+// PrefixStore 给所有的key加上统一的前缀，多个业务可以共用一个bucket
+type PrefixStore struct {
+	store  ObjectStore
+	prefix string
+}
+
+func WithPrefix(store ObjectStore, prefix string) ObjectStore {
+	if prefix == "" {
+		return store
+	}
+	return &PrefixStore{
+		store:  store,
+		prefix: strings.TrimSuffix(prefix, "/") + "/",
+	}
+}
+
+func (s *PrefixStore) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	return s.store.Put(ctx, s.prefix+key, data, contentType)
+}
+
+func (s *PrefixStore) Get(ctx context.Context, key string) ([]byte, error) {
+	return s.store.Get(ctx, s.prefix+key)
+}
+
+func (s *PrefixStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, s.prefix+key)
+}
+
+func (s *PrefixStore) Stat(ctx context.Context, key string) (Object, error) {
+	obj, err := s.store.Stat(ctx, s.prefix+key)
+	obj.Key = key
+	return obj, err
+}
+
+func (s *PrefixStore) List(ctx context.Context, prefix string, fn func(obj Object) error) error {
+	return s.store.List(ctx, s.prefix+prefix, func(obj Object) error {
+		obj.Key = strings.TrimPrefix(obj.Key, s.prefix)
+		return fn(obj)
+	})
+}
@@ -0,0 +1,118 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"io"
+)
+
+// S3Store 基于S3协议的实现，MinIO、腾讯云COS等兼容S3协议的存储都可以使用
+type S3Store struct {
+	client *s3.S3
+	bucket string
+}
+
+func NewS3Store(client *s3.S3, bucket string) *S3Store {
+	return &S3Store{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+// NewMinIOStore MinIO需要使用path-style的地址，即 endpoint/bucket/key
+func NewMinIOStore(endpoint string, accessKey string, secretKey string, bucket string) (*S3Store, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(endpoint),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials(accessKey, secretKey, ""),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewS3Store(s3.New(sess), bucket), nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String(contentType),
+	})
+	return err
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+	return io.ReadAll(out.Body)
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (s *S3Store) Stat(ctx context.Context, key string) (Object, error) {
+	out, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	// HeadObject没有响应体，不存在时返回的错误码是NotFound
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && (aerr.Code() == "NotFound" || aerr.Code() == s3.ErrCodeNoSuchKey) {
+		return Object{}, ErrObjectNotFound
+	}
+	if err != nil {
+		return Object{}, err
+	}
+	return Object{
+		Key:     key,
+		Size:    aws.Int64Value(out.ContentLength),
+		ModTime: aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+func (s *S3Store) List(ctx context.Context, prefix string, fn func(obj Object) error) error {
+	var fnErr error
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, item := range page.Contents {
+			fnErr = fn(Object{
+				Key:     aws.StringValue(item.Key),
+				Size:    aws.Int64Value(item.Size),
+				ModTime: aws.TimeValue(item.LastModified),
+			})
+			if fnErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return fnErr
+}
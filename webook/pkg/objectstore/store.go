@@ -0,0 +1,36 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+/*
+对象存储的抽象，屏蔽S3、MinIO、本地文件系统的差异。
+
+key使用"/"分隔，实现需要保证：
+	Put覆盖已经存在的对象
+	Get、Stat不存在的对象返回ErrObjectNotFound
+	Delete不存在的对象不返回错误
+	List按照key的字典序遍历
+*/
+
+var ErrObjectNotFound = errors.New("object not found")
+
+type Object struct {
+	Key  string
+	Size int64
+	// ModTime 最后一次写入的时间
+	ModTime time.Time
+}
+
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	// Stat 查询对象的元数据，不读取内容
+	Stat(ctx context.Context, key string) (Object, error)
+	// List 遍历key以prefix开头的对象，fn返回错误时停止遍历并返回该错误
+	List(ctx context.Context, prefix string, fn func(obj Object) error) error
+}
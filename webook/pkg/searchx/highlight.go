@@ -0,0 +1,75 @@
+package searchx
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	HighlightPre  = "<em>"
+	HighlightPost = "</em>"
+)
+
+// Highlight 截取text中第一个命中terms的片段，并用<em></em>标记命中的词。
+// maxLen是片段的最大长度（rune），<=0时不截取。原文会做html转义，可以直接在页面上展示。
+func Highlight(text string, terms []string, maxLen int) string {
+	runes := []rune(text)
+	hits := matchRanges(text, terms)
+
+	start, end := 0, len(runes)
+	if maxLen > 0 && len(runes) > maxLen {
+		if len(hits) > 0 {
+			// 命中的词前面保留一小段上下文
+			start = max(hits[0][0]-maxLen/4, 0)
+		}
+		end = min(start+maxLen, len(runes))
+		start = max(end-maxLen, 0)
+	}
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("...")
+	}
+	pos := start
+	for _, h := range hits {
+		hs, he := max(h[0], start), min(h[1], end)
+		if hs >= he {
+			continue
+		}
+		sb.WriteString(html.EscapeString(string(runes[pos:hs])))
+		sb.WriteString(HighlightPre)
+		sb.WriteString(html.EscapeString(string(runes[hs:he])))
+		sb.WriteString(HighlightPost)
+		pos = he
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		sb.WriteString("...")
+	}
+	return sb.String()
+}
+
+// matchRanges 返回text中命中terms的区间，相邻、重叠的区间会被合并。
+// bigram切分时"全文检索"会命中"全文"、"文检"、"检索"三个区间，合并后整体高亮。
+func matchRanges(text string, terms []string) [][2]int {
+	if len(terms) == 0 {
+		return nil
+	}
+	set := make(map[string]struct{}, len(terms))
+	for _, t := range terms {
+		set[t] = struct{}{}
+	}
+
+	var res [][2]int
+	for _, token := range Tokenize(text) {
+		if _, ok := set[token.Term]; !ok {
+			continue
+		}
+		if n := len(res); n > 0 && token.Start <= res[n-1][1] {
+			res[n-1][1] = max(res[n-1][1], token.End)
+			continue
+		}
+		res = append(res, [2]int{token.Start, token.End})
+	}
+	return res
+}
@@ -0,0 +1,86 @@
+package searchx
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTerms(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+
+		want []string
+	}{
+		{
+			name: "英文转小写",
+			text: "Hello, Go World! hello",
+			want: []string{"hello", "go", "world"},
+		},
+		{
+			name: "中文二元切分",
+			text: "全文检索",
+			want: []string{"全文", "文检", "检索"},
+		},
+		{
+			name: "单个汉字",
+			text: "读 书",
+			want: []string{"读", "书"},
+		},
+		{
+			name: "中英混合",
+			text: "Go语言实战2024",
+			want: []string{"go", "语言", "言实", "实战", "2024"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Terms(tc.text))
+		})
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	testCases := []struct {
+		name   string
+		text   string
+		terms  []string
+		maxLen int
+
+		want string
+	}{
+		{
+			name:  "合并相邻的命中",
+			text:  "基于全文检索的文章搜索",
+			terms: Terms("全文检索"),
+			want:  "基于<em>全文检索</em>的文章搜索",
+		},
+		{
+			name:  "英文忽略大小写",
+			text:  "Learn Go <fast>",
+			terms: Terms("go"),
+			want:  "Learn <em>Go</em> &lt;fast&gt;",
+		},
+		{
+			name:   "截取命中的片段",
+			text:   "0123456789 keyword 0123456789",
+			terms:  Terms("keyword"),
+			maxLen: 12,
+			want:   "...89 <em>keyword</em> 0...",
+		},
+		{
+			name:   "没有命中时截取开头",
+			text:   "abcdefghij",
+			terms:  Terms("xyz"),
+			maxLen: 4,
+			want:   "abcd...",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Highlight(tc.text, tc.terms, tc.maxLen))
+		})
+	}
+}
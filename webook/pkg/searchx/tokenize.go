@@ -0,0 +1,87 @@
+package searchx
+
+import (
+	"strings"
+	"unicode"
+)
+
+/*
+全文检索的分词。
+
+不依赖词典，按照字符类型切分：
+	1. 拉丁字母、数字：连续的字母、数字组成一个词，统一转为小写
+	2. 中日韩文字（CJK）：连续的CJK字符按二元组（bigram）切分，例如"全文检索" => "全文"、"文检"、"检索"；
+	   只有一个字符时单独成词
+	3. 其他字符（空白、标点等）作为分隔符
+
+bigram的召回率高，不需要维护词典，缺点是会产生一些无意义的词，对于文章搜索这个场景可以接受。
+MySQL的ngram全文解析器（ngram_token_size=2）也是同样的思路。
+*/
+
+// Token 分词结果，Start、End是词在原文中的rune下标，左闭右开
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize 对文本进行分词
+func Tokenize(text string) []Token {
+	runes := []rune(text)
+	var tokens []Token
+
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case isCJK(r):
+			j := i
+			for j < len(runes) && isCJK(runes[j]) {
+				j++
+			}
+			if j-i == 1 {
+				tokens = append(tokens, Token{Term: string(runes[i:j]), Start: i, End: j})
+			}
+			for k := i; k+1 < j; k++ {
+				tokens = append(tokens, Token{Term: string(runes[k : k+2]), Start: k, End: k + 2})
+			}
+			i = j
+		case isWord(r):
+			j := i
+			for j < len(runes) && isWord(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, Token{Term: strings.ToLower(string(runes[i:j])), Start: i, End: j})
+			i = j
+		default:
+			i++
+		}
+	}
+	return tokens
+}
+
+// Terms 返回去重后的词，保持首次出现的顺序
+func Terms(text string) []string {
+	tokens := Tokenize(text)
+	seen := make(map[string]struct{}, len(tokens))
+	terms := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if _, ok := seen[t.Term]; ok {
+			continue
+		}
+		seen[t.Term] = struct{}{}
+		terms = append(terms, t.Term)
+	}
+	return terms
+}
+
+func isCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+func isWord(r rune) bool {
+	return !isCJK(r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -5,11 +5,15 @@ package main
 
 import (
 	"github.com/google/wire"
+	grpc2 "learn_go/webook/interaction/grpc"
 	repository2 "learn_go/webook/interaction/repository"
 	cache2 "learn_go/webook/interaction/repository/cache"
 	dao2 "learn_go/webook/interaction/repository/dao"
 	service2 "learn_go/webook/interaction/service"
 	event "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/event/feed"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/internal/job"
 	"learn_go/webook/internal/repository"
 	"learn_go/webook/internal/repository/article"
 	"learn_go/webook/internal/repository/cache"
@@ -31,6 +35,9 @@ var thirdPartySet = wire.NewSet(
 	ioc.NewLogger,
 	ioc.NewDB,
 	ioc.NewRedis,
+	ioc.NewMongoDB,
+	ioc.NewSnowflakeNode,
+	ioc.InitObjectStore,
 	ioc.InitMiddlewares,
 	ioc.InitGin,
 )
@@ -38,6 +45,17 @@ var thirdPartySet = wire.NewSet(
 var jobSet = wire.NewSet(
 	ioc.InitRankingJob,
 	ioc.InitCron,
+
+	ioc.InitScheduler,
+	ioc.InitArticleContentSweepExecutor,
+	job.NewScheduledPublishExecutor,
+	job.NewArticleTrashPurgeExecutor,
+	job.NewArticleAutosaveFlushExecutor,
+	job.NewArticleExportExecutor,
+	job.NewMediaSweepExecutor,
+	service.NewJobService,
+	repository.NewCronJobRepository,
+	dao.NewJobDao,
 )
 
 // 生产者
@@ -45,24 +63,83 @@ var producerSet = wire.NewSet(
 	ioc.NewSaramaConfig,
 	ioc.NewSyncProducer,
 	event.NewSyncProducer,
+	migration.NewSyncProducer,
+)
+
+// 消费者
+var consumerSet = wire.NewSet(
+	ioc.NewConsumerClient,
+	ioc.NewConsumers,
+	ioc.NewSearchSyncConsumer,
+	feed.NewPublishedConsumer,
+	ioc.InitArticleCacheWatcher,
+	ioc.InitMigrationFixConsumer,
+)
+
+// 文章存储迁移
+var migrationSet = wire.NewSet(
+	ioc.InitDoubleWriteArticleDao,
+	ioc.InitArticleMigrator,
+	ioc.InitMigratorHandler,
+)
+
+var searchSet = wire.NewSet(
+	web.NewSearchHandler,
+	ioc.InitSearchService,
+	ioc.InitArticleIndex,
 )
 
 var articleSet = wire.NewSet(
 	web.NewArticleHandler,
+	web.NewArticleTransferHandler,
+	ioc.InitArticleTransferService,
+	web.NewMediaHandler,
+	ioc.InitMediaService,
+	ioc.InitCursorSigner,
 	service.NewArticleService,
 
 	article.NewArticleRepository,
 	article.NewArticleAuthorRepository,
 	article.NewArticleReaderRepository,
-	dao.NewArticleDao,
+	article.NewRevisionRepository,
+	article.NewTagRepository,
+	article.NewCollaboratorRepository,
+	article.NewReviewRepository,
+	article.NewSeriesRepository,
+	article.NewExportRepository,
+	article.NewMediaRepository,
+	ioc.InitArticleDao,
+	ioc.InitArticleRevisionDao,
+	ioc.InitArticleCollaboratorDao,
+	ioc.InitArticleReviewDao,
+	ioc.InitSeriesDao,
+	ioc.InitArticleExportDao,
+	ioc.InitMediaDao,
+	ioc.InitTagDao,
 	cache.NewArticleCache,
 
 	service2.NewInteractionService,
 	repository2.NewInteractionRepository,
 	dao2.NewInteractionDao,
 	cache2.NewInteractionCache,
+	cache2.NewCounterCache,
+	cache2.NewViewCache,
+	cache2.NewLikeCache,
+	service2.NewFavoriteService,
+	repository2.NewFavoriteRepository,
+	dao2.NewFavoriteDao,
+	service2.NewStatService,
+	repository2.NewStatRepository,
+	dao2.NewStatDao,
+	grpc2.NewInteractionServiceServer,
+	service2.NewCommentService,
+	repository2.NewCommentRepository,
+	dao2.NewCommentDao,
+	grpc2.NewCommentServiceServer,
 
 	ioc.NewGRPCInteractionServiceClient,
+	web.NewFavoriteHandler,
+	ioc.NewGRPCCommentServiceClient,
 )
 
 var smsSet = wire.NewSet(
@@ -83,6 +160,20 @@ var userSet = wire.NewSet(
 	dao.NewUserDao,
 )
 
+var followSet = wire.NewSet(
+	web.NewFollowHandler,
+	service.NewFollowService,
+	repository.NewFollowRepository,
+	dao.NewFollowDao,
+)
+
+var feedSet = wire.NewSet(
+	web.NewFeedHandler,
+	service.NewFeedService,
+	repository.NewFeedRepository,
+	dao.NewFeedDao,
+)
+
 var wechatSet = wire.NewSet(
 	web.NewOAuth2WechatHandler,
 	ioc.InitOAuth2Service,
@@ -93,12 +184,17 @@ var (
 	providers = wire.NewSet(
 		thirdPartySet,
 		producerSet,
+		consumerSet,
 		jobSet,
 
 		rankingSet,
 		articleSet,
+		searchSet,
+		migrationSet,
 		smsSet,
 		userSet,
+		followSet,
+		feedSet,
 		wechatSet,
 
 		web.NewTestHandler,
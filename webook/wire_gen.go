@@ -8,11 +8,15 @@ package main
 
 import (
 	"github.com/google/wire"
+	"learn_go/webook/interaction/grpc"
 	repository2 "learn_go/webook/interaction/repository"
 	cache2 "learn_go/webook/interaction/repository/cache"
 	dao2 "learn_go/webook/interaction/repository/dao"
 	service2 "learn_go/webook/interaction/service"
 	article2 "learn_go/webook/internal/event/article"
+	"learn_go/webook/internal/event/feed"
+	"learn_go/webook/internal/event/migration"
+	"learn_go/webook/internal/job"
 	"learn_go/webook/internal/repository"
 	"learn_go/webook/internal/repository/article"
 	"learn_go/webook/internal/repository/cache"
@@ -46,30 +50,96 @@ func InitApp(templateId string) *App {
 	userHandler := web.NewUserHandler(userService, codeService, jwtHandler)
 	oAuth2Service := ioc.InitOAuth2Service()
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(oAuth2Service, userService, jwtHandler)
-	engine := ioc.InitGin(v, smsHandler, userHandler, oAuth2WechatHandler)
-	articleDao := dao.NewArticleDao(db)
+	articleIndex := ioc.InitArticleIndex(db)
+	database := ioc.NewMongoDB(loggerV2)
+	node := ioc.NewSnowflakeNode()
+	doubleWriteArticleDao := ioc.InitDoubleWriteArticleDao(db, database, node, loggerV2)
+	objectStore := ioc.InitObjectStore()
+	articleDao := ioc.InitArticleDao(db, database, node, doubleWriteArticleDao, objectStore)
 	articleCache := cache.NewArticleCache(cmdable)
 	articleRepository := article.NewArticleRepository(articleDao, articleCache, userRepository, loggerV2)
-	authorRepository := article.NewArticleAuthorRepository()
-	readerRepository := article.NewArticleReaderRepository()
+	searchService := ioc.InitSearchService(articleIndex, articleRepository, loggerV2)
+	searchHandler := web.NewSearchHandler(searchService, loggerV2)
 	config := ioc.NewSaramaConfig()
 	syncProducer := ioc.NewSyncProducer(config)
-	producer := article2.NewSyncProducer(syncProducer)
-	articleService := service.NewArticleService(articleRepository, authorRepository, readerRepository, producer, loggerV2)
+	producer := migration.NewSyncProducer(syncProducer)
+	migrator := ioc.InitArticleMigrator(db, database, cmdable, doubleWriteArticleDao, producer, loggerV2)
+	migratorHandler := ioc.InitMigratorHandler(migrator, loggerV2)
+	authorRepository := article.NewArticleAuthorRepository()
+	readerRepository := article.NewArticleReaderRepository()
+	articleRevisionDao := ioc.InitArticleRevisionDao(db, database, node)
+	revisionRepository := article.NewRevisionRepository(articleRevisionDao)
+	tagDao := ioc.InitTagDao(db, database, node)
+	tagRepository := article.NewTagRepository(tagDao)
+	articleCollaboratorDao := ioc.InitArticleCollaboratorDao(db, database, node)
+	collaboratorRepository := article.NewCollaboratorRepository(articleCollaboratorDao, userRepository)
+	articleReviewDao := ioc.InitArticleReviewDao(db, database, node)
+	reviewRepository := article.NewReviewRepository(articleReviewDao)
+	seriesDao := ioc.InitSeriesDao(db, database, node)
+	seriesRepository := article.NewSeriesRepository(seriesDao)
+	mediaDao := ioc.InitMediaDao(db, database, node)
+	mediaRepository := article.NewMediaRepository(mediaDao)
+	articleProducer := article2.NewSyncProducer(syncProducer)
 	interactionDao := dao2.NewInteractionDao(db)
 	interactionCache := cache2.NewInteractionCache(cmdable)
-	interactionRepository := repository2.NewInteractionRepository(interactionDao, interactionCache)
+	counterCache := cache2.NewCounterCache(cmdable)
+	viewCache := cache2.NewViewCache(cmdable)
+	likeCache := cache2.NewLikeCache(cmdable)
+	interactionRepository := repository2.NewInteractionRepository(interactionDao, interactionCache, counterCache, viewCache, likeCache)
 	interactionService := service2.NewInteractionService(interactionRepository)
-	interactionServiceClient := ioc.NewGRPCInteractionServiceClient(interactionService)
+	favoriteDao := dao2.NewFavoriteDao(db)
+	favoriteRepository := repository2.NewFavoriteRepository(favoriteDao, interactionCache)
+	favoriteService := service2.NewFavoriteService(favoriteRepository)
+	statDao := dao2.NewStatDao(db)
+	statRepository := repository2.NewStatRepository(statDao)
+	statService := service2.NewStatService(statRepository)
+	interactionServiceServer := grpc.NewInteractionServiceServer(interactionService, favoriteService, statService)
+	interactionServiceClient := ioc.NewGRPCInteractionServiceClient(interactionServiceServer)
+	articleService := service.NewArticleService(articleRepository, authorRepository, readerRepository, revisionRepository, tagRepository, collaboratorRepository, reviewRepository, seriesRepository, mediaRepository, userRepository, articleProducer, interactionServiceClient, loggerV2)
+	articleExportDao := ioc.InitArticleExportDao(db, database, node)
+	exportRepository := article.NewExportRepository(articleExportDao)
+	articleTransferService := ioc.InitArticleTransferService(articleService, exportRepository, loggerV2)
+	articleTransferHandler := web.NewArticleTransferHandler(articleTransferService, loggerV2)
+	mediaService := ioc.InitMediaService(articleService, mediaRepository, loggerV2)
+	mediaHandler := web.NewMediaHandler(mediaService, loggerV2)
+	followDao := dao.NewFollowDao(db)
+	followRepository := repository.NewFollowRepository(followDao)
+	followService := service.NewFollowService(followRepository, userRepository)
+	followHandler := web.NewFollowHandler(followService)
+	feedDao := dao.NewFeedDao(db)
+	feedRepository := repository.NewFeedRepository(feedDao)
+	feedService := service.NewFeedService(feedRepository, followRepository)
+	signer := ioc.InitCursorSigner()
+	feedHandler := web.NewFeedHandler(feedService, signer, loggerV2)
+	favoriteHandler := web.NewFavoriteHandler(interactionServiceClient)
+	engine := ioc.InitGin(v, smsHandler, userHandler, oAuth2WechatHandler, searchHandler, migratorHandler, articleTransferHandler, mediaHandler, followHandler, feedHandler, favoriteHandler)
+	client := ioc.NewConsumerClient(config)
+	searchSyncConsumer := ioc.NewSearchSyncConsumer(client, articleIndex, loggerV2)
+	publishedConsumer := feed.NewPublishedConsumer(client, feedService, loggerV2)
+	cacheWatcher := ioc.InitArticleCacheWatcher(database, articleCache, loggerV2)
+	fixConsumer := ioc.InitMigrationFixConsumer(client, doubleWriteArticleDao, loggerV2)
+	v2 := ioc.NewConsumers(searchSyncConsumer, publishedConsumer, cacheWatcher, fixConsumer)
 	redisRanking := ioc.NewRedisRanking(cmdable)
 	localCacheRanking := ioc.NewLocalCacheRanking()
 	rankingRepository := repository.NewRankingRepository(redisRanking, localCacheRanking)
 	rankingService := service.NewRankingService(articleService, interactionServiceClient, rankingRepository)
 	rankingJob := ioc.InitRankingJob(rankingService)
 	cron := ioc.InitCron(loggerV2, rankingJob)
+	jobDao := dao.NewJobDao(db)
+	jobRepository := repository.NewCronJobRepository(jobDao)
+	jobService := service.NewJobService(jobRepository, loggerV2)
+	scheduledPublishExecutor := job.NewScheduledPublishExecutor(articleService, loggerV2)
+	articleTrashPurgeExecutor := job.NewArticleTrashPurgeExecutor(articleService, loggerV2)
+	articleContentSweepExecutor := ioc.InitArticleContentSweepExecutor(db, objectStore, loggerV2)
+	articleAutosaveFlushExecutor := job.NewArticleAutosaveFlushExecutor(articleService, loggerV2)
+	articleExportExecutor := job.NewArticleExportExecutor(articleTransferService, loggerV2)
+	mediaSweepExecutor := job.NewMediaSweepExecutor(mediaService, loggerV2)
+	scheduler := ioc.InitScheduler(jobService, scheduledPublishExecutor, articleTrashPurgeExecutor, articleContentSweepExecutor, articleAutosaveFlushExecutor, articleExportExecutor, mediaSweepExecutor, loggerV2)
 	app := &App{
-		server: engine,
-		cron:   cron,
+		server:    engine,
+		consumers: v2,
+		cron:      cron,
+		scheduler: scheduler,
 	}
 	return app
 }
@@ -79,31 +149,48 @@ func InitApp(templateId string) *App {
 var rankingSet = wire.NewSet(service.NewRankingService, repository.NewRankingRepository, ioc.NewRedisRanking, ioc.NewLocalCacheRanking)
 
 // 第三方依赖
-var thirdPartySet = wire.NewSet(ioc.NewLogger, ioc.NewDB, ioc.NewRedis, ioc.InitMiddlewares, ioc.InitGin)
+var thirdPartySet = wire.NewSet(ioc.NewLogger, ioc.NewDB, ioc.NewRedis, ioc.NewMongoDB, ioc.NewSnowflakeNode, ioc.InitObjectStore, ioc.InitMiddlewares, ioc.InitGin)
 
-var jobSet = wire.NewSet(ioc.InitRankingJob, ioc.InitCron)
+var jobSet = wire.NewSet(ioc.InitRankingJob, ioc.InitCron, ioc.InitScheduler, ioc.InitArticleContentSweepExecutor, job.NewScheduledPublishExecutor, job.NewArticleTrashPurgeExecutor, job.NewArticleAutosaveFlushExecutor, job.NewArticleExportExecutor, job.NewMediaSweepExecutor, service.NewJobService, repository.NewCronJobRepository, dao.NewJobDao)
 
 // 生产者
-var producerSet = wire.NewSet(ioc.NewSaramaConfig, ioc.NewSyncProducer, article2.NewSyncProducer)
+var producerSet = wire.NewSet(ioc.NewSaramaConfig, ioc.NewSyncProducer, article2.NewSyncProducer, migration.NewSyncProducer)
+
+// 消费者
+var consumerSet = wire.NewSet(ioc.NewConsumerClient, ioc.NewConsumers, ioc.NewSearchSyncConsumer, feed.NewPublishedConsumer, ioc.InitArticleCacheWatcher, ioc.InitMigrationFixConsumer)
 
-var articleSet = wire.NewSet(web.NewArticleHandler, service.NewArticleService, article.NewArticleRepository, article.NewArticleAuthorRepository, article.NewArticleReaderRepository, dao.NewArticleDao, cache.NewArticleCache, service2.NewInteractionService, repository2.NewInteractionRepository, dao2.NewInteractionDao, cache2.NewInteractionCache, ioc.NewGRPCInteractionServiceClient)
+// 文章存储迁移
+var migrationSet = wire.NewSet(ioc.InitDoubleWriteArticleDao, ioc.InitArticleMigrator, ioc.InitMigratorHandler)
+
+var searchSet = wire.NewSet(web.NewSearchHandler, ioc.InitSearchService, ioc.InitArticleIndex)
+
+var articleSet = wire.NewSet(web.NewArticleHandler, web.NewArticleTransferHandler, ioc.InitArticleTransferService, web.NewMediaHandler, ioc.InitMediaService, ioc.InitCursorSigner, service.NewArticleService, article.NewArticleRepository, article.NewArticleAuthorRepository, article.NewArticleReaderRepository, article.NewRevisionRepository, article.NewTagRepository, article.NewCollaboratorRepository, article.NewReviewRepository, article.NewSeriesRepository, article.NewExportRepository, article.NewMediaRepository, ioc.InitArticleDao, ioc.InitArticleRevisionDao, ioc.InitArticleCollaboratorDao, ioc.InitArticleReviewDao, ioc.InitSeriesDao, ioc.InitArticleExportDao, ioc.InitMediaDao, ioc.InitTagDao, cache.NewArticleCache, service2.NewInteractionService, repository2.NewInteractionRepository, dao2.NewInteractionDao, cache2.NewInteractionCache, cache2.NewCounterCache, cache2.NewViewCache, cache2.NewLikeCache, service2.NewFavoriteService, repository2.NewFavoriteRepository, dao2.NewFavoriteDao, service2.NewStatService, repository2.NewStatRepository, dao2.NewStatDao, grpc.NewInteractionServiceServer, service2.NewCommentService, repository2.NewCommentRepository, dao2.NewCommentDao, grpc.NewCommentServiceServer, ioc.NewGRPCInteractionServiceClient, web.NewFavoriteHandler, ioc.NewGRPCCommentServiceClient)
 
 var smsSet = wire.NewSet(web.NewSMSHandler, service.NewCodeService, ioc.NewSMSService, repository.NewCodeRepository, cache.NewCodeCache)
 
 var userSet = wire.NewSet(web.NewUserHandler, service.NewUserService, repository.NewUserRepository, cache.NewUserCache, dao.NewUserDao)
 
+var followSet = wire.NewSet(web.NewFollowHandler, service.NewFollowService, repository.NewFollowRepository, dao.NewFollowDao)
+
+var feedSet = wire.NewSet(web.NewFeedHandler, service.NewFeedService, repository.NewFeedRepository, dao.NewFeedDao)
+
 var wechatSet = wire.NewSet(web.NewOAuth2WechatHandler, ioc.InitOAuth2Service, web.NewJWTHandler)
 
 var (
 	providers = wire.NewSet(
 		thirdPartySet,
 		producerSet,
+		consumerSet,
 		jobSet,
 
 		rankingSet,
 		articleSet,
+		searchSet,
+		migrationSet,
 		smsSet,
 		userSet,
+		followSet,
+		feedSet,
 		wechatSet, web.NewTestHandler, wire.Struct(new(App), "*"),
 	)
 )